@@ -0,0 +1,147 @@
+package apidocs
+
+import (
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// OperationRef identifies one operation in a diff.
+type OperationRef struct {
+	Method      string `json:"method"`
+	Path        string `json:"path"`
+	OperationID string `json:"operationId,omitempty"`
+}
+
+// FieldChange is one differing field of a changed operation.
+type FieldChange struct {
+	Field string `json:"field"`
+	Old   any    `json:"old"`
+	New   any    `json:"new"`
+}
+
+// ChangedOperation is an operation present in both specs whose documented
+// surface differs.
+type ChangedOperation struct {
+	OperationRef
+	Changes []FieldChange `json:"changes"`
+}
+
+// SpecDiff lists operations added, removed, and changed between two specs,
+// each sorted by path then method.
+type SpecDiff struct {
+	Added   []OperationRef     `json:"added"`
+	Removed []OperationRef     `json:"removed"`
+	Changed []ChangedOperation `json:"changed"`
+}
+
+// Empty reports whether the specs have the same operation surface.
+func (d SpecDiff) Empty() bool {
+	return len(d.Added) == 0 && len(d.Removed) == 0 && len(d.Changed) == 0
+}
+
+// DiffOperations compares operations by method and path. An operation
+// counts as changed when its operationId, summary, parameters, deprecation
+// flag, or tags differ.
+func DiffOperations(oldOps, newOps []Operation) SpecDiff {
+	oldByKey := operationsByKey(oldOps)
+	newByKey := operationsByKey(newOps)
+
+	diff := SpecDiff{
+		Added:   []OperationRef{},
+		Removed: []OperationRef{},
+		Changed: []ChangedOperation{},
+	}
+	for _, key := range sortedOperationKeys(newByKey) {
+		newOp := newByKey[key]
+		oldOp, ok := oldByKey[key]
+		if !ok {
+			diff.Added = append(diff.Added, operationRef(newOp))
+			continue
+		}
+		if changes := operationChanges(oldOp, newOp); len(changes) > 0 {
+			diff.Changed = append(diff.Changed, ChangedOperation{OperationRef: operationRef(newOp), Changes: changes})
+		}
+	}
+	for _, key := range sortedOperationKeys(oldByKey) {
+		if _, ok := newByKey[key]; !ok {
+			diff.Removed = append(diff.Removed, operationRef(oldByKey[key]))
+		}
+	}
+	return diff
+}
+
+type operationKey struct {
+	path   string
+	method string
+}
+
+func operationsByKey(ops []Operation) map[operationKey]Operation {
+	out := make(map[operationKey]Operation, len(ops))
+	for _, op := range ops {
+		key := operationKey{path: op.Path, method: op.Method}
+		if _, exists := out[key]; !exists {
+			out[key] = op
+		}
+	}
+	return out
+}
+
+func sortedOperationKeys(ops map[operationKey]Operation) []operationKey {
+	keys := make([]operationKey, 0, len(ops))
+	for key := range ops {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].path == keys[j].path {
+			return keys[i].method < keys[j].method
+		}
+		return keys[i].path < keys[j].path
+	})
+	return keys
+}
+
+func operationRef(op Operation) OperationRef {
+	return OperationRef{Method: op.Method, Path: op.Path, OperationID: op.OperationID}
+}
+
+func operationChanges(oldOp, newOp Operation) []FieldChange {
+	var changes []FieldChange
+	if oldOp.OperationID != newOp.OperationID {
+		changes = append(changes, FieldChange{Field: "operationId", Old: oldOp.OperationID, New: newOp.OperationID})
+	}
+	if oldOp.Summary != newOp.Summary {
+		changes = append(changes, FieldChange{Field: "summary", Old: oldOp.Summary, New: newOp.Summary})
+	}
+	if oldParams, newParams := parameterSignatures(oldOp.Parameters), parameterSignatures(newOp.Parameters); !reflect.DeepEqual(oldParams, newParams) {
+		changes = append(changes, FieldChange{Field: "parameters", Old: oldParams, New: newParams})
+	}
+	if oldOp.Deprecated != newOp.Deprecated {
+		changes = append(changes, FieldChange{Field: "deprecated", Old: oldOp.Deprecated, New: newOp.Deprecated})
+	}
+	if oldTags, newTags := sortedCopy(oldOp.Tags), sortedCopy(newOp.Tags); !reflect.DeepEqual(oldTags, newTags) {
+		changes = append(changes, FieldChange{Field: "tags", Old: oldTags, New: newTags})
+	}
+	return changes
+}
+
+// parameterSignatures renders parameters as "in:name" with a trailing "*"
+// for required ones, sorted so declaration order does not matter.
+func parameterSignatures(params []Parameter) []string {
+	out := make([]string, 0, len(params))
+	for _, param := range params {
+		signature := strings.ToLower(param.In) + ":" + param.Name
+		if param.Required {
+			signature += "*"
+		}
+		out = append(out, signature)
+	}
+	sort.Strings(out)
+	return out
+}
+
+func sortedCopy(values []string) []string {
+	out := append([]string{}, values...)
+	sort.Strings(out)
+	return out
+}
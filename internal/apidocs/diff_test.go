@@ -0,0 +1,40 @@
+package apidocs
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDiffOperations(t *testing.T) {
+	t.Parallel()
+
+	oldOps := []Operation{
+		{Method: "GET", Path: "/a", OperationID: "getA", Summary: "A", Tags: []string{"x", "y"}},
+		{Method: "GET", Path: "/b", OperationID: "getB", Parameters: []Parameter{{Name: "limit", In: "query"}}},
+		{Method: "DELETE", Path: "/c", OperationID: "deleteC"},
+	}
+	newOps := []Operation{
+		{Method: "GET", Path: "/a", OperationID: "getA", Summary: "A", Tags: []string{"y", "x"}},
+		{Method: "GET", Path: "/b", OperationID: "getB", Deprecated: true, Parameters: []Parameter{{Name: "limit", In: "query", Required: true}}},
+		{Method: "POST", Path: "/d", OperationID: "createD"},
+	}
+
+	diff := DiffOperations(oldOps, newOps)
+	if !reflect.DeepEqual(diff.Added, []OperationRef{{Method: "POST", Path: "/d", OperationID: "createD"}}) {
+		t.Fatalf("unexpected added %+v", diff.Added)
+	}
+	if !reflect.DeepEqual(diff.Removed, []OperationRef{{Method: "DELETE", Path: "/c", OperationID: "deleteC"}}) {
+		t.Fatalf("unexpected removed %+v", diff.Removed)
+	}
+	want := []FieldChange{
+		{Field: "parameters", Old: []string{"query:limit"}, New: []string{"query:limit*"}},
+		{Field: "deprecated", Old: false, New: true},
+	}
+	if len(diff.Changed) != 1 || diff.Changed[0].Path != "/b" || !reflect.DeepEqual(diff.Changed[0].Changes, want) {
+		t.Fatalf("unexpected changed %+v", diff.Changed)
+	}
+
+	if !DiffOperations(oldOps, oldOps).Empty() {
+		t.Fatalf("expected identical specs to have an empty diff")
+	}
+}
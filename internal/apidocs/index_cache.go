@@ -6,7 +6,12 @@ import (
 	"os"
 )
 
+// operationIndexVersion is bumped whenever Operation gains fields so stale
+// caches are rebuilt from the spec.
+const operationIndexVersion = 7
+
 type operationIndexFile struct {
+	Version         int         `json:"version"`
 	SpecPath        string      `json:"specPath"`
 	SpecSize        int64       `json:"specSize"`
 	SpecModUnixNano int64       `json:"specModUnixNano"`
@@ -27,7 +32,7 @@ func LoadOperationIndex(indexPath string, specPath string, specSize int64, specM
 	if err := json.Unmarshal(b, &index); err != nil {
 		return nil, fmt.Errorf("parse operation index %q: %w", indexPath, err)
 	}
-	if index.SpecPath != specPath || index.SpecSize != specSize || index.SpecModUnixNano != specModUnixNano {
+	if index.Version != operationIndexVersion || index.SpecPath != specPath || index.SpecSize != specSize || index.SpecModUnixNano != specModUnixNano {
 		return nil, os.ErrNotExist
 	}
 
@@ -45,6 +50,7 @@ func WriteOperationIndex(indexPath string, specPath string, specSize int64, spec
 	}
 
 	payload := operationIndexFile{
+		Version:         operationIndexVersion,
 		SpecPath:        specPath,
 		SpecSize:        specSize,
 		SpecModUnixNano: specModUnixNano,
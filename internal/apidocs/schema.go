@@ -0,0 +1,143 @@
+package apidocs
+
+import (
+	"encoding/json"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// schemaRefs inlines "#/..." references against the whole spec document,
+// which is decoded on first use.
+type schemaRefs struct {
+	raw    []byte
+	root   any
+	parsed bool
+}
+
+// jsonMediaSchema picks the schema of the JSON media type, preferring
+// application/json over other JSON variants.
+func jsonMediaSchema(content map[string]specMediaType) json.RawMessage {
+	mediaTypes := make([]string, 0, len(content))
+	for mediaType := range content {
+		if isJSONMediaType(mediaType) && len(content[mediaType].Schema) > 0 {
+			mediaTypes = append(mediaTypes, mediaType)
+		}
+	}
+	if len(mediaTypes) == 0 {
+		return nil
+	}
+	sort.SliceStable(mediaTypes, func(i, j int) bool {
+		iJSON := strings.EqualFold(mediaTypes[i], "application/json")
+		jJSON := strings.EqualFold(mediaTypes[j], "application/json")
+		if iJSON != jJSON {
+			return iJSON
+		}
+		return mediaTypes[i] < mediaTypes[j]
+	})
+	return content[mediaTypes[0]].Schema
+}
+
+func isJSONMediaType(mediaType string) bool {
+	mediaType = strings.ToLower(strings.TrimSpace(mediaType))
+	if base, _, ok := strings.Cut(mediaType, ";"); ok {
+		mediaType = strings.TrimSpace(base)
+	}
+	return mediaType == "application/json" || strings.HasSuffix(mediaType, "+json") || mediaType == "*/*"
+}
+
+// resolve returns schema with every same-document $ref replaced by its
+// target. Unresolvable and recursive references become empty schemas,
+// which accept any value.
+func (r *schemaRefs) resolve(schema json.RawMessage) json.RawMessage {
+	if len(schema) == 0 {
+		return nil
+	}
+	var value any
+	if err := json.Unmarshal(schema, &value); err != nil {
+		return nil
+	}
+	resolved, err := json.Marshal(r.inline(value, map[string]bool{}))
+	if err != nil {
+		return nil
+	}
+	return resolved
+}
+
+func (r *schemaRefs) inline(value any, active map[string]bool) any {
+	switch typed := value.(type) {
+	case map[string]any:
+		if ref, ok := typed["$ref"].(string); ok {
+			if active[ref] {
+				return map[string]any{}
+			}
+			target, ok := r.lookup(ref)
+			if !ok {
+				return map[string]any{}
+			}
+			active[ref] = true
+			defer delete(active, ref)
+			return r.inline(target, active)
+		}
+		out := make(map[string]any, len(typed))
+		for key, item := range typed {
+			out[key] = r.inline(item, active)
+		}
+		return out
+	case []any:
+		out := make([]any, len(typed))
+		for i, item := range typed {
+			out[i] = r.inline(item, active)
+		}
+		return out
+	default:
+		return value
+	}
+}
+
+// lookup follows a "#/a/b" JSON pointer into the spec document.
+func (r *schemaRefs) lookup(ref string) (any, bool) {
+	pointer, ok := strings.CutPrefix(ref, "#/")
+	if !ok {
+		return nil, false
+	}
+	if !r.parsed {
+		r.parsed = true
+		if err := json.Unmarshal(r.raw, &r.root); err != nil {
+			r.root = nil
+		}
+	}
+
+	current := r.root
+	for _, token := range strings.Split(pointer, "/") {
+		if unescaped, err := url.PathUnescape(token); err == nil {
+			token = unescaped
+		}
+		token = strings.ReplaceAll(strings.ReplaceAll(token, "~1", "/"), "~0", "~")
+		object, ok := current.(map[string]any)
+		if !ok {
+			return nil, false
+		}
+		current, ok = object[token]
+		if !ok {
+			return nil, false
+		}
+	}
+	return current, true
+}
+
+// ResponseSchema returns the schema documented for status: an exact code
+// first, then its class ("2XX"), then "default".
+func (op Operation) ResponseSchema(status int) (json.RawMessage, bool) {
+	code := strconv.Itoa(status)
+	class := code[:1] + "XX"
+	for _, candidate := range []string{code, class, "default"} {
+		for _, resp := range op.Responses {
+			if strings.EqualFold(resp.Code, candidate) {
+				return resp.Schema, len(resp.Schema) > 0
+			}
+		}
+	}
+	return nil, false
+}
@@ -1,11 +1,13 @@
 package apidocs
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
 	"os"
 	"sort"
 	"strings"
+	"time"
 )
 
 const DefaultSpecFile = "openapi.json"
@@ -18,6 +20,67 @@ type Operation struct {
 	Description string   `json:"description,omitempty"`
 	Tags        []string `json:"tags,omitempty"`
 	Deprecated  bool     `json:"deprecated,omitempty"`
+	// Parameters merges path-level and operation-level parameter
+	// definitions; operation-level entries win on name/location clashes.
+	Parameters []Parameter `json:"parameters,omitempty"`
+	// RequestBodyExample is the request body example declared in the spec,
+	// preferring the application/json media type when several exist.
+	RequestBodyExample json.RawMessage `json:"requestBodyExample,omitempty"`
+	// RequestBodyRequired reports that the spec marks the request body as
+	// required; RequestBodySchema is its JSON schema with $refs inlined.
+	RequestBodyRequired bool            `json:"requestBodyRequired,omitempty"`
+	RequestBodySchema   json.RawMessage `json:"requestBodySchema,omitempty"`
+	// Responses lists the documented response codes in code order, with
+	// "default" last.
+	Responses []Response `json:"responses,omitempty"`
+	// Extensions holds the operation's vendor extensions ("x-" keys) as
+	// raw JSON.
+	Extensions map[string]json.RawMessage `json:"extensions,omitempty"`
+}
+
+// TimeoutExtension is the vendor extension that declares how long an
+// operation is expected to take.
+const TimeoutExtension = "x-timeout"
+
+// Timeout returns the operation's x-timeout, given either as a Go duration
+// string ("60s", "2m") or as a number of seconds. ok is false when the
+// extension is absent.
+func (op Operation) Timeout() (timeout time.Duration, ok bool, err error) {
+	raw, ok := op.Extensions[TimeoutExtension]
+	if !ok {
+		return 0, false, nil
+	}
+	var text string
+	if err := json.Unmarshal(raw, &text); err == nil {
+		timeout, err = time.ParseDuration(strings.TrimSpace(text))
+		if err != nil {
+			return 0, true, fmt.Errorf("invalid %s %q on %s %s: %w", TimeoutExtension, text, op.Method, op.Path, err)
+		}
+	} else {
+		var seconds float64
+		if err := json.Unmarshal(raw, &seconds); err != nil {
+			return 0, true, fmt.Errorf("invalid %s on %s %s: want a duration string or seconds", TimeoutExtension, op.Method, op.Path)
+		}
+		timeout = time.Duration(seconds * float64(time.Second))
+	}
+	if timeout <= 0 {
+		return 0, true, fmt.Errorf("invalid %s on %s %s: must be positive", TimeoutExtension, op.Method, op.Path)
+	}
+	return timeout, true, nil
+}
+
+type Response struct {
+	Code        string `json:"code"`
+	Description string `json:"description,omitempty"`
+	// Schema is the JSON response body schema with same-document $refs
+	// inlined; a recursive reference is replaced by an empty schema.
+	Schema json.RawMessage `json:"schema,omitempty"`
+}
+
+type Parameter struct {
+	Name     string `json:"name"`
+	In       string `json:"in"`
+	Required bool   `json:"required,omitempty"`
 }
 
 type Count struct {
@@ -33,15 +96,50 @@ type Stats struct {
 }
 
 type specDoc struct {
-	Paths map[string]map[string]json.RawMessage `json:"paths"`
+	Paths      map[string]map[string]json.RawMessage `json:"paths"`
+	Components struct {
+		Parameters    map[string]specParameter   `json:"parameters"`
+		RequestBodies map[string]specRequestBody `json:"requestBodies"`
+		Responses     map[string]specResponse    `json:"responses"`
+	} `json:"components"`
 }
 
 type specOperation struct {
-	OperationID string   `json:"operationId"`
-	Summary     string   `json:"summary"`
-	Description string   `json:"description"`
-	Tags        []string `json:"tags"`
-	Deprecated  bool     `json:"deprecated"`
+	OperationID string                  `json:"operationId"`
+	Summary     string                  `json:"summary"`
+	Description string                  `json:"description"`
+	Tags        []string                `json:"tags"`
+	Deprecated  bool                    `json:"deprecated"`
+	Parameters  []specParameter         `json:"parameters"`
+	RequestBody specRequestBody         `json:"requestBody"`
+	Responses   map[string]specResponse `json:"responses"`
+}
+
+type specResponse struct {
+	Ref         string                   `json:"$ref"`
+	Description string                   `json:"description"`
+	Content     map[string]specMediaType `json:"content"`
+}
+
+type specRequestBody struct {
+	Ref      string                   `json:"$ref"`
+	Required bool                     `json:"required"`
+	Content  map[string]specMediaType `json:"content"`
+}
+
+type specMediaType struct {
+	Schema   json.RawMessage `json:"schema"`
+	Example  json.RawMessage `json:"example"`
+	Examples map[string]struct {
+		Value json.RawMessage `json:"value"`
+	} `json:"examples"`
+}
+
+type specParameter struct {
+	Ref      string `json:"$ref"`
+	Name     string `json:"name"`
+	In       string `json:"in"`
+	Required bool   `json:"required"`
 }
 
 func LoadOperations(path string) ([]Operation, error) {
@@ -63,8 +161,16 @@ func loadOperationsFromJSON(raw []byte, source string) ([]Operation, error) {
 		return nil, fmt.Errorf("parse spec %q: %w", source, err)
 	}
 
+	refs := &schemaRefs{raw: raw}
 	ops := make([]Operation, 0, 256)
 	for apiPath, methods := range doc.Paths {
+		var pathParams []specParameter
+		if raw, ok := methods["parameters"]; ok {
+			if err := json.Unmarshal(raw, &pathParams); err != nil {
+				return nil, fmt.Errorf("parse parameters for %s from %q: %w", apiPath, source, err)
+			}
+		}
+
 		for method, raw := range methods {
 			normalized := strings.ToUpper(strings.TrimSpace(method))
 			if !isHTTPMethod(normalized) {
@@ -76,6 +182,7 @@ func loadOperationsFromJSON(raw []byte, source string) ([]Operation, error) {
 				return nil, fmt.Errorf("parse operation %s %s from %q: %w", normalized, apiPath, source, err)
 			}
 
+			requestBody := resolveRequestBody(doc, op.RequestBody)
 			ops = append(ops, Operation{
 				Method:      normalized,
 				Path:        apiPath,
@@ -84,6 +191,13 @@ func loadOperationsFromJSON(raw []byte, source string) ([]Operation, error) {
 				Description: strings.TrimSpace(op.Description),
 				Tags:        copyStrings(op.Tags),
 				Deprecated:  op.Deprecated,
+				Parameters:  mergeParameters(doc, pathParams, op.Parameters),
+
+				RequestBodyExample:  requestBodyExample(requestBody),
+				RequestBodyRequired: requestBody.Required,
+				RequestBodySchema:   refs.resolve(jsonMediaSchema(requestBody.Content)),
+				Responses:           documentedResponses(doc, refs, op.Responses),
+				Extensions:          vendorExtensions(raw),
 			})
 		}
 	}
@@ -98,6 +212,176 @@ func loadOperationsFromJSON(raw []byte, source string) ([]Operation, error) {
 	return ops, nil
 }
 
+// vendorExtensions collects the "x-" keys of an operation object.
+func vendorExtensions(raw json.RawMessage) map[string]json.RawMessage {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(raw, &fields); err != nil {
+		return nil
+	}
+	var out map[string]json.RawMessage
+	for key, value := range fields {
+		if !strings.HasPrefix(key, "x-") {
+			continue
+		}
+		if out == nil {
+			out = make(map[string]json.RawMessage)
+		}
+		out[key] = value
+	}
+	return out
+}
+
+func mergeParameters(doc specDoc, pathParams []specParameter, opParams []specParameter) []Parameter {
+	out := make([]Parameter, 0, len(pathParams)+len(opParams))
+	index := make(map[string]int, len(pathParams)+len(opParams))
+	for _, group := range [][]specParameter{pathParams, opParams} {
+		for _, raw := range group {
+			param, ok := resolveParameter(doc, raw)
+			if !ok {
+				continue
+			}
+			key := param.In + "\x00" + param.Name
+			if i, exists := index[key]; exists {
+				out[i] = param
+				continue
+			}
+			index[key] = len(out)
+			out = append(out, param)
+		}
+	}
+	if len(out) == 0 {
+		return nil
+	}
+	return out
+}
+
+func resolveParameter(doc specDoc, raw specParameter) (Parameter, bool) {
+	if ref := strings.TrimSpace(raw.Ref); ref != "" {
+		name, ok := strings.CutPrefix(ref, "#/components/parameters/")
+		if !ok {
+			return Parameter{}, false
+		}
+		target, ok := doc.Components.Parameters[name]
+		if !ok || strings.TrimSpace(target.Ref) != "" {
+			return Parameter{}, false
+		}
+		raw = target
+	}
+
+	name := strings.TrimSpace(raw.Name)
+	in := strings.ToLower(strings.TrimSpace(raw.In))
+	if name == "" || in == "" {
+		return Parameter{}, false
+	}
+	return Parameter{Name: name, In: in, Required: raw.Required || in == "path"}, true
+}
+
+// resolveRequestBody follows a components/requestBodies reference; an
+// unresolvable reference yields an empty body.
+func resolveRequestBody(doc specDoc, body specRequestBody) specRequestBody {
+	ref := strings.TrimSpace(body.Ref)
+	if ref == "" {
+		return body
+	}
+	name, ok := strings.CutPrefix(ref, "#/components/requestBodies/")
+	if !ok {
+		return specRequestBody{}
+	}
+	target, ok := doc.Components.RequestBodies[name]
+	if !ok || strings.TrimSpace(target.Ref) != "" {
+		return specRequestBody{}
+	}
+	return target
+}
+
+// requestBodyExample picks the example for the JSON media type when present,
+// otherwise the first media type (by name) that declares one. Named examples
+// are consulted in name order when no inline example is set.
+func requestBodyExample(body specRequestBody) json.RawMessage {
+
+	mediaTypes := make([]string, 0, len(body.Content))
+	for mediaType := range body.Content {
+		mediaTypes = append(mediaTypes, mediaType)
+	}
+	sort.SliceStable(mediaTypes, func(i, j int) bool {
+		iJSON := strings.EqualFold(mediaTypes[i], "application/json")
+		jJSON := strings.EqualFold(mediaTypes[j], "application/json")
+		if iJSON != jJSON {
+			return iJSON
+		}
+		return mediaTypes[i] < mediaTypes[j]
+	})
+
+	for _, mediaType := range mediaTypes {
+		content := body.Content[mediaType]
+		if example := compactExample(content.Example); example != nil {
+			return example
+		}
+		names := make([]string, 0, len(content.Examples))
+		for name := range content.Examples {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			if example := compactExample(content.Examples[name].Value); example != nil {
+				return example
+			}
+		}
+	}
+	return nil
+}
+
+// documentedResponses resolves response references for their descriptions
+// and schemas; a code is kept even when its reference cannot be resolved.
+func documentedResponses(doc specDoc, refs *schemaRefs, raw map[string]specResponse) []Response {
+	if len(raw) == 0 {
+		return nil
+	}
+
+	out := make([]Response, 0, len(raw))
+	for code, resp := range raw {
+		if ref := strings.TrimSpace(resp.Ref); ref != "" {
+			if name, ok := strings.CutPrefix(ref, "#/components/responses/"); ok {
+				resp = doc.Components.Responses[name]
+			}
+		}
+		out = append(out, Response{
+			Code:        strings.TrimSpace(code),
+			Description: strings.TrimSpace(resp.Description),
+			Schema:      refs.resolve(jsonMediaSchema(resp.Content)),
+		})
+	}
+	sort.Slice(out, func(i, j int) bool {
+		iDefault := strings.EqualFold(out[i].Code, "default")
+		jDefault := strings.EqualFold(out[j].Code, "default")
+		if iDefault != jDefault {
+			return jDefault
+		}
+		return out[i].Code < out[j].Code
+	})
+	return out
+}
+
+func compactExample(raw json.RawMessage) json.RawMessage {
+	var buf bytes.Buffer
+	if err := json.Compact(&buf, raw); err != nil || buf.Len() == 0 || buf.String() == "null" {
+		return nil
+	}
+	return json.RawMessage(buf.Bytes())
+}
+
+// ParametersIn returns the operation's parameters declared at location in
+// (for example "query" or "path").
+func (op Operation) ParametersIn(in string) []Parameter {
+	out := make([]Parameter, 0, len(op.Parameters))
+	for _, param := range op.Parameters {
+		if strings.EqualFold(param.In, in) {
+			out = append(out, param)
+		}
+	}
+	return out
+}
+
 func FilterByMethod(ops []Operation, method string) []Operation {
 	method = strings.ToUpper(strings.TrimSpace(method))
 	if method == "" {
@@ -339,6 +623,15 @@ func copyOperations(ops []Operation) []Operation {
 	for i := range ops {
 		out[i] = ops[i]
 		out[i].Tags = copyStrings(ops[i].Tags)
+		if len(ops[i].Parameters) > 0 {
+			out[i].Parameters = append([]Parameter(nil), ops[i].Parameters...)
+		}
+		if len(ops[i].RequestBodyExample) > 0 {
+			out[i].RequestBodyExample = append(json.RawMessage(nil), ops[i].RequestBodyExample...)
+		}
+		if len(ops[i].Responses) > 0 {
+			out[i].Responses = append([]Response(nil), ops[i].Responses...)
+		}
 	}
 	return out
 }
@@ -3,7 +3,9 @@ package apidocs
 import (
 	"os"
 	"path/filepath"
+	"reflect"
 	"testing"
+	"time"
 )
 
 const testSpec = `{
@@ -172,3 +174,212 @@ func writeSpec(t *testing.T, content string) string {
 	}
 	return path
 }
+
+func TestLoadOperationsParsesParameters(t *testing.T) {
+	t.Parallel()
+
+	ops, err := LoadOperationsFromJSON([]byte(`{
+  "openapi": "3.0.0",
+  "components": {
+    "parameters": {
+      "Limit": {"name": "limit", "in": "query"}
+    }
+  },
+  "paths": {
+    "/data/api/v1/projects/{name}": {
+      "parameters": [
+        {"name": "name", "in": "path"},
+        {"name": "verbose", "in": "query"}
+      ],
+      "get": {
+        "operationId": "getProject",
+        "parameters": [
+          {"$ref": "#/components/parameters/Limit"},
+          {"name": "verbose", "in": "query", "required": true}
+        ]
+      }
+    }
+  }
+}`))
+	if err != nil {
+		t.Fatalf("load operations: %v", err)
+	}
+	if len(ops) != 1 {
+		t.Fatalf("expected 1 operation, got %d", len(ops))
+	}
+
+	want := []Parameter{
+		{Name: "name", In: "path", Required: true},
+		{Name: "verbose", In: "query", Required: true},
+		{Name: "limit", In: "query"},
+	}
+	got := ops[0].Parameters
+	if len(got) != len(want) {
+		t.Fatalf("unexpected parameters %+v", got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("parameter %d = %+v, want %+v", i, got[i], want[i])
+		}
+	}
+	if query := ops[0].ParametersIn("query"); len(query) != 2 {
+		t.Fatalf("expected 2 query parameters, got %+v", query)
+	}
+}
+
+func TestLoadOperationsParsesRequestBodyExample(t *testing.T) {
+	t.Parallel()
+
+	ops, err := LoadOperationsFromJSON([]byte(`{
+  "openapi": "3.0.0",
+  "components": {
+    "requestBodies": {
+      "Project": {
+        "content": {
+          "application/json": {
+            "examples": {
+              "b": {"value": {"name": "second"}},
+              "a": {"value": {"name": "first"}}
+            }
+          }
+        }
+      }
+    }
+  },
+  "paths": {
+    "/data/api/v1/projects": {
+      "post": {
+        "operationId": "createProject",
+        "requestBody": {"$ref": "#/components/requestBodies/Project"}
+      },
+      "put": {
+        "operationId": "updateProject",
+        "requestBody": {
+          "content": {
+            "text/plain": {"example": "plain"},
+            "application/json": {"example": {"name": "demo", "enabled": true}}
+          }
+        }
+      },
+      "get": {"operationId": "listProjects"}
+    }
+  }
+}`))
+	if err != nil {
+		t.Fatalf("load operations: %v", err)
+	}
+
+	got := map[string]string{}
+	for _, op := range ops {
+		got[op.OperationID] = string(op.RequestBodyExample)
+	}
+	if got["createProject"] != `{"name":"first"}` {
+		t.Fatalf("unexpected createProject example %q", got["createProject"])
+	}
+	if got["updateProject"] != `{"name":"demo","enabled":true}` {
+		t.Fatalf("unexpected updateProject example %q", got["updateProject"])
+	}
+	if got["listProjects"] != "" {
+		t.Fatalf("expected no listProjects example, got %q", got["listProjects"])
+	}
+}
+
+func TestLoadOperationsParsesResponses(t *testing.T) {
+	t.Parallel()
+
+	ops, err := LoadOperationsFromJSON([]byte(`{
+  "openapi": "3.0.0",
+  "components": {
+    "responses": {
+      "Forbidden": {"description": "Token lacks permission"}
+    }
+  },
+  "paths": {
+    "/data/api/v1/projects": {
+      "post": {
+        "operationId": "createProject",
+        "responses": {
+          "default": {"description": "Unexpected error"},
+          "403": {"$ref": "#/components/responses/Forbidden"},
+          "200": {"description": "Project created"},
+          "400": {"description": "Invalid project"}
+        }
+      },
+      "get": {"operationId": "listProjects"}
+    }
+  }
+}`))
+	if err != nil {
+		t.Fatalf("load operations: %v", err)
+	}
+
+	byID := map[string]Operation{}
+	for _, op := range ops {
+		byID[op.OperationID] = op
+	}
+
+	want := []Response{
+		{Code: "200", Description: "Project created"},
+		{Code: "400", Description: "Invalid project"},
+		{Code: "403", Description: "Token lacks permission"},
+		{Code: "default", Description: "Unexpected error"},
+	}
+	got := byID["createProject"].Responses
+	if len(got) != len(want) {
+		t.Fatalf("unexpected responses %+v", got)
+	}
+	for i := range want {
+		if !reflect.DeepEqual(got[i], want[i]) {
+			t.Fatalf("response %d = %+v, want %+v", i, got[i], want[i])
+		}
+	}
+	if len(byID["listProjects"].Responses) != 0 {
+		t.Fatalf("expected no listProjects responses, got %+v", byID["listProjects"].Responses)
+	}
+}
+
+func TestLoadOperationsParsesTimeoutExtension(t *testing.T) {
+	t.Parallel()
+
+	ops, err := LoadOperationsFromJSON([]byte(`{
+  "openapi": "3.0.0",
+  "paths": {
+    "/data/api/v1/diagnostics/bundle/generate": {
+      "post": {"operationId": "generateBundle", "x-timeout": "60s", "x-owner": "ops"}
+    },
+    "/data/api/v1/backup": {
+      "get": {"operationId": "exportBackup", "x-timeout": 90}
+    },
+    "/data/api/v1/gateway-info": {
+      "get": {"operationId": "gatewayInfo"}
+    },
+    "/data/api/v1/broken": {
+      "get": {"operationId": "broken", "x-timeout": "soon"}
+    }
+  }
+}`))
+	if err != nil {
+		t.Fatalf("load operations: %v", err)
+	}
+
+	byID := map[string]Operation{}
+	for _, op := range ops {
+		byID[op.OperationID] = op
+	}
+
+	if got := string(byID["generateBundle"].Extensions["x-owner"]); got != `"ops"` {
+		t.Fatalf("expected x-owner extension, got %q", got)
+	}
+	for id, want := range map[string]time.Duration{"generateBundle": time.Minute, "exportBackup": 90 * time.Second} {
+		got, ok, err := byID[id].Timeout()
+		if err != nil || !ok || got != want {
+			t.Fatalf("%s timeout = %v, %v, %v; want %v", id, got, ok, err, want)
+		}
+	}
+	if _, ok, err := byID["gatewayInfo"].Timeout(); ok || err != nil {
+		t.Fatalf("expected no timeout for gatewayInfo, got ok=%v err=%v", ok, err)
+	}
+	if _, _, err := byID["broken"].Timeout(); err == nil {
+		t.Fatalf("expected invalid x-timeout error")
+	}
+}
@@ -0,0 +1,280 @@
+package apidocs
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+)
+
+// subsetTopLevelKeys are copied verbatim from the source spec so the exported
+// document stays a valid OpenAPI/Swagger file.
+var subsetTopLevelKeys = []string{"openapi", "swagger", "info", "servers", "host", "basePath", "schemes", "security"}
+
+// ExtractSubset builds a minimal OpenAPI document from raw that contains only
+// ops plus every local $ref (transitively) those operations depend on.
+func ExtractSubset(raw []byte, ops []Operation) ([]byte, error) {
+	doc, err := decodeSpecDocument(raw)
+	if err != nil {
+		return nil, err
+	}
+	out, err := subsetDocument(doc, ops)
+	if err != nil {
+		return nil, err
+	}
+	return encodeSubset(out)
+}
+
+// ExtractMergedSubset is ExtractSubset over several specs merged the way a
+// comma-separated --spec-file list is: each operation is taken from the
+// first spec that defines it, and a component defined by more than one
+// spec keeps the first definition.
+func ExtractMergedSubset(raws [][]byte, ops []Operation) ([]byte, error) {
+	docs := make([]map[string]any, 0, len(raws))
+	for _, raw := range raws {
+		doc, err := decodeSpecDocument(raw)
+		if err != nil {
+			return nil, err
+		}
+		docs = append(docs, doc)
+	}
+
+	owned := make([][]Operation, len(docs))
+	for _, op := range ops {
+		owner := -1
+		for i, doc := range docs {
+			if documentHasOperation(doc, op) {
+				owner = i
+				break
+			}
+		}
+		if owner < 0 {
+			return nil, fmt.Errorf("operation %s %s not found in any spec", op.Method, op.Path)
+		}
+		owned[owner] = append(owned[owner], op)
+	}
+
+	var out map[string]any
+	for i, doc := range docs {
+		if len(owned[i]) == 0 {
+			continue
+		}
+		subset, err := subsetDocument(doc, owned[i])
+		if err != nil {
+			return nil, err
+		}
+		if out == nil {
+			out = subset
+			continue
+		}
+		mergeMissing(out, subset)
+	}
+	if out == nil {
+		out = map[string]any{"paths": map[string]any{}}
+	}
+	return encodeSubset(out)
+}
+
+func decodeSpecDocument(raw []byte) (map[string]any, error) {
+	decoder := json.NewDecoder(bytes.NewReader(raw))
+	decoder.UseNumber()
+	var doc map[string]any
+	if err := decoder.Decode(&doc); err != nil {
+		return nil, fmt.Errorf("parse spec: %w", err)
+	}
+	return doc, nil
+}
+
+func documentHasOperation(doc map[string]any, op Operation) bool {
+	paths, _ := doc["paths"].(map[string]any)
+	item, _ := paths[op.Path].(map[string]any)
+	for key := range item {
+		if strings.EqualFold(key, op.Method) {
+			return true
+		}
+	}
+	return false
+}
+
+// mergeMissing copies keys from src that dst does not have yet, descending
+// into objects present in both.
+func mergeMissing(dst map[string]any, src map[string]any) {
+	for key, value := range src {
+		existing, ok := dst[key]
+		if !ok {
+			dst[key] = value
+			continue
+		}
+		dstMap, dstOK := existing.(map[string]any)
+		srcMap, srcOK := value.(map[string]any)
+		if dstOK && srcOK {
+			mergeMissing(dstMap, srcMap)
+		}
+	}
+}
+
+func subsetDocument(doc map[string]any, ops []Operation) (map[string]any, error) {
+	out := make(map[string]any, len(subsetTopLevelKeys)+2)
+	for _, key := range subsetTopLevelKeys {
+		if value, ok := doc[key]; ok {
+			out[key] = value
+		}
+	}
+
+	srcPaths, _ := doc["paths"].(map[string]any)
+	outPaths := make(map[string]any, len(ops))
+	for _, op := range ops {
+		srcItem, ok := srcPaths[op.Path].(map[string]any)
+		if !ok {
+			return nil, fmt.Errorf("path %q not found in spec", op.Path)
+		}
+		outItem, _ := outPaths[op.Path].(map[string]any)
+		if outItem == nil {
+			outItem = make(map[string]any, 2)
+			if params, ok := srcItem["parameters"]; ok {
+				outItem["parameters"] = params
+			}
+			outPaths[op.Path] = outItem
+		}
+		found := false
+		for key, value := range srcItem {
+			if strings.EqualFold(key, op.Method) {
+				outItem[key] = value
+				found = true
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("operation %s %s not found in spec", op.Method, op.Path)
+		}
+	}
+	out["paths"] = outPaths
+
+	seen := make(map[string]struct{})
+	queue := collectLocalRefs(outPaths, nil)
+	for len(queue) > 0 {
+		ref := queue[0]
+		queue = queue[1:]
+		if _, ok := seen[ref]; ok {
+			continue
+		}
+		seen[ref] = struct{}{}
+
+		tokens := refTokens(ref)
+		if len(tokens) == 0 || tokens[0] == "paths" {
+			continue
+		}
+		value, ok := lookupPointer(doc, tokens)
+		if !ok {
+			return nil, fmt.Errorf("unresolved $ref %q", ref)
+		}
+		setPointer(out, tokens, value)
+		queue = collectLocalRefs(value, queue)
+	}
+	return out, nil
+}
+
+func encodeSubset(out map[string]any) ([]byte, error) {
+	b, err := json.MarshalIndent(out, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("encode spec subset: %w", err)
+	}
+	return append(b, '\n'), nil
+}
+
+// WriteSubset reads the spec at specPath and writes the ExtractSubset result
+// for ops to outPath.
+func WriteSubset(specPath string, ops []Operation, outPath string) error {
+	raw, err := os.ReadFile(specPath) //nolint:gosec // user-provided spec path
+	if err != nil {
+		return fmt.Errorf("read spec file %q: %w", specPath, err)
+	}
+	subset, err := ExtractSubset(raw, ops)
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(outPath, subset, 0o600); err != nil {
+		return fmt.Errorf("write spec subset %q: %w", outPath, err)
+	}
+	return nil
+}
+
+// WriteMergedSubset reads every spec in specPaths and writes the
+// ExtractMergedSubset result for ops to outPath.
+func WriteMergedSubset(specPaths []string, ops []Operation, outPath string) error {
+	raws := make([][]byte, 0, len(specPaths))
+	for _, specPath := range specPaths {
+		raw, err := os.ReadFile(specPath) //nolint:gosec // user-provided spec path
+		if err != nil {
+			return fmt.Errorf("read spec file %q: %w", specPath, err)
+		}
+		raws = append(raws, raw)
+	}
+	subset, err := ExtractMergedSubset(raws, ops)
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(outPath, subset, 0o600); err != nil {
+		return fmt.Errorf("write spec subset %q: %w", outPath, err)
+	}
+	return nil
+}
+
+func collectLocalRefs(value any, refs []string) []string {
+	switch v := value.(type) {
+	case map[string]any:
+		if ref, ok := v["$ref"].(string); ok && strings.HasPrefix(ref, "#/") {
+			refs = append(refs, ref)
+		}
+		keys := make([]string, 0, len(v))
+		for key := range v {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			refs = collectLocalRefs(v[key], refs)
+		}
+	case []any:
+		for _, item := range v {
+			refs = collectLocalRefs(item, refs)
+		}
+	}
+	return refs
+}
+
+func refTokens(ref string) []string {
+	parts := strings.Split(strings.TrimPrefix(ref, "#/"), "/")
+	for i, part := range parts {
+		parts[i] = strings.ReplaceAll(strings.ReplaceAll(part, "~1", "/"), "~0", "~")
+	}
+	return parts
+}
+
+func lookupPointer(doc map[string]any, tokens []string) (any, bool) {
+	var current any = doc
+	for _, token := range tokens {
+		node, ok := current.(map[string]any)
+		if !ok {
+			return nil, false
+		}
+		current, ok = node[token]
+		if !ok {
+			return nil, false
+		}
+	}
+	return current, true
+}
+
+func setPointer(doc map[string]any, tokens []string, value any) {
+	current := doc
+	for _, token := range tokens[:len(tokens)-1] {
+		next, ok := current[token].(map[string]any)
+		if !ok {
+			next = make(map[string]any)
+			current[token] = next
+		}
+		current = next
+	}
+	current[tokens[len(tokens)-1]] = value
+}
@@ -0,0 +1,142 @@
+package apidocs
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+const subsetTestSpec = `{
+  "openapi": "3.0.0",
+  "info": {"title": "Ignition", "version": "8.3"},
+  "paths": {
+    "/data/api/v1/gateway-info": {
+      "get": {
+        "operationId": "gatewayInfo",
+        "responses": {
+          "200": {"content": {"application/json": {"schema": {"$ref": "#/components/schemas/GatewayInfo"}}}}
+        }
+      }
+    },
+    "/data/api/v1/scan/projects": {
+      "post": {"operationId": "scanProjects", "responses": {"200": {"$ref": "#/components/responses/Unused"}}}
+    }
+  },
+  "components": {
+    "schemas": {
+      "GatewayInfo": {"type": "object", "properties": {"version": {"$ref": "#/components/schemas/Version"}}},
+      "Version": {"type": "string"},
+      "Other": {"type": "integer"}
+    },
+    "responses": {
+      "Unused": {"description": "unused"}
+    }
+  }
+}`
+
+func TestWriteSubsetExportsSingleOperation(t *testing.T) {
+	t.Parallel()
+
+	specPath := writeSpec(t, subsetTestSpec)
+	ops, err := LoadOperations(specPath)
+	if err != nil {
+		t.Fatalf("load operations: %v", err)
+	}
+	selected := FilterByOperationID(ops, "gatewayInfo")
+	if len(selected) != 1 {
+		t.Fatalf("expected one gatewayInfo operation, got %d", len(selected))
+	}
+
+	outPath := filepath.Join(t.TempDir(), "subset.json")
+	if err := WriteSubset(specPath, selected, outPath); err != nil {
+		t.Fatalf("write subset: %v", err)
+	}
+
+	reloaded, err := LoadOperations(outPath)
+	if err != nil {
+		t.Fatalf("reload subset: %v", err)
+	}
+	if len(reloaded) != 1 || reloaded[0].OperationID != "gatewayInfo" || reloaded[0].Method != "GET" {
+		t.Fatalf("unexpected reloaded operations: %+v", reloaded)
+	}
+
+	raw, err := os.ReadFile(outPath)
+	if err != nil {
+		t.Fatalf("read subset: %v", err)
+	}
+	var doc struct {
+		Info       map[string]any `json:"info"`
+		Components struct {
+			Schemas   map[string]any `json:"schemas"`
+			Responses map[string]any `json:"responses"`
+		} `json:"components"`
+	}
+	if err := json.Unmarshal(raw, &doc); err != nil {
+		t.Fatalf("decode subset: %v", err)
+	}
+	if doc.Info["title"] != "Ignition" {
+		t.Fatalf("expected info to be preserved, got %#v", doc.Info)
+	}
+	if len(doc.Components.Schemas) != 2 || doc.Components.Schemas["GatewayInfo"] == nil || doc.Components.Schemas["Version"] == nil {
+		t.Fatalf("expected transitive schema refs only, got %#v", doc.Components.Schemas)
+	}
+	if len(doc.Components.Responses) != 0 {
+		t.Fatalf("expected unreferenced responses to be dropped, got %#v", doc.Components.Responses)
+	}
+}
+
+func TestExtractSubsetRejectsUnresolvedRef(t *testing.T) {
+	t.Parallel()
+
+	raw := []byte(`{"paths":{"/a":{"get":{"responses":{"200":{"$ref":"#/components/responses/Missing"}}}}}}`)
+	if _, err := ExtractSubset(raw, []Operation{{Method: "GET", Path: "/a"}}); err == nil {
+		t.Fatalf("expected unresolved ref error")
+	}
+}
+
+func TestExtractMergedSubsetTakesEachOperationFromItsFirstSpec(t *testing.T) {
+	t.Parallel()
+
+	moduleSpec := []byte(`{
+  "openapi": "3.0.0",
+  "paths": {
+    "/data/api/v1/gateway-info": {"get": {"operationId": "shadowed"}},
+    "/data/perspective/api/v1/sessions": {
+      "get": {"operationId": "listSessions", "responses": {"200": {"content": {"application/json": {"schema": {"$ref": "#/components/schemas/Session"}}}}}}
+    }
+  },
+  "components": {"schemas": {"Session": {"type": "object", "properties": {"version": {"$ref": "#/components/schemas/Version"}}}, "Version": {"type": "integer"}}}
+}`)
+	ops := []Operation{
+		{Method: "GET", Path: "/data/api/v1/gateway-info"},
+		{Method: "GET", Path: "/data/perspective/api/v1/sessions"},
+	}
+	raw, err := ExtractMergedSubset([][]byte{[]byte(subsetTestSpec), moduleSpec}, ops)
+	if err != nil {
+		t.Fatalf("extract merged subset: %v", err)
+	}
+
+	var doc struct {
+		Paths      map[string]map[string]map[string]any `json:"paths"`
+		Components struct {
+			Schemas map[string]map[string]any `json:"schemas"`
+		} `json:"components"`
+	}
+	if err := json.Unmarshal(raw, &doc); err != nil {
+		t.Fatalf("decode subset: %v", err)
+	}
+	if doc.Paths["/data/api/v1/gateway-info"]["get"]["operationId"] != "gatewayInfo" {
+		t.Fatalf("expected gateway-info from the first spec, got %v", doc.Paths)
+	}
+	if _, ok := doc.Components.Schemas["Session"]; !ok {
+		t.Fatalf("expected the second spec's schema, got %v", doc.Components.Schemas)
+	}
+	if doc.Components.Schemas["Version"]["type"] != "string" {
+		t.Fatalf("expected the first spec's Version schema to win, got %v", doc.Components.Schemas["Version"])
+	}
+
+	if _, err := ExtractMergedSubset([][]byte{moduleSpec}, []Operation{{Method: "GET", Path: "/missing"}}); err == nil {
+		t.Fatal("expected an operation missing from every spec to fail")
+	}
+}
@@ -0,0 +1,62 @@
+package apidocs
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+)
+
+// SyncMeta records where and when a spec file was synced so callers can
+// detect a spec that no longer matches the gateway it is used against.
+type SyncMeta struct {
+	GatewayVersion string    `json:"gatewayVersion,omitempty"`
+	SourceURL      string    `json:"sourceURL,omitempty"`
+	SyncedAt       time.Time `json:"syncedAt"`
+	// Hash is the SHA-256 of the synced spec; ETag and LastModified are
+	// the validators the gateway sent with it, for conditional re-syncs.
+	Hash         string `json:"hash,omitempty"`
+	ETag         string `json:"etag,omitempty"`
+	LastModified string `json:"lastModified,omitempty"`
+}
+
+// HashSpec returns the hex SHA-256 of a spec body.
+func HashSpec(body []byte) string {
+	sum := sha256.Sum256(body)
+	return hex.EncodeToString(sum[:])
+}
+
+func SyncMetaPathForSpec(specPath string) string {
+	return specPath + ".meta.json"
+}
+
+func LoadSyncMeta(metaPath string) (SyncMeta, error) {
+	b, err := os.ReadFile(metaPath) //nolint:gosec // meta path derived from spec file path
+	if err != nil {
+		return SyncMeta{}, err
+	}
+
+	var meta SyncMeta
+	if err := json.Unmarshal(b, &meta); err != nil {
+		return SyncMeta{}, fmt.Errorf("parse spec sync metadata %q: %w", metaPath, err)
+	}
+	return meta, nil
+}
+
+func WriteSyncMeta(metaPath string, meta SyncMeta) error {
+	b, err := json.MarshalIndent(meta, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encode spec sync metadata: %w", err)
+	}
+
+	tmp := metaPath + ".tmp"
+	if err := os.WriteFile(tmp, append(b, '\n'), 0o600); err != nil {
+		return fmt.Errorf("write spec sync metadata temp: %w", err)
+	}
+	if err := os.Rename(tmp, metaPath); err != nil {
+		return fmt.Errorf("commit spec sync metadata: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,232 @@
+package apidocs
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// SchemaViolation is one place where a JSON document does not match its
+// schema. Path is "$" for the root, then ".field" and "[index]" steps.
+type SchemaViolation struct {
+	Path    string `json:"path"`
+	Message string `json:"message"`
+}
+
+// ValidateJSON checks body against a resolved schema (see
+// Response.Schema). It supports the subset of JSON Schema the OpenAPI
+// specs use: type, nullable, enum, required, properties,
+// additionalProperties, items, allOf, anyOf, and oneOf. Other keywords are
+// ignored.
+func ValidateJSON(schema json.RawMessage, body []byte) ([]SchemaViolation, error) {
+	var parsedSchema any
+	if err := json.Unmarshal(schema, &parsedSchema); err != nil {
+		return nil, fmt.Errorf("parse schema: %w", err)
+	}
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+	var value any
+	if err := decoder.Decode(&value); err != nil {
+		return nil, fmt.Errorf("parse body: %w", err)
+	}
+
+	var out []SchemaViolation
+	validateValue(parsedSchema, value, "$", &out)
+	return out, nil
+}
+
+func validateValue(schema any, value any, path string, out *[]SchemaViolation) {
+	if allowed, ok := schema.(bool); ok {
+		if !allowed {
+			addViolation(out, path, "no value is allowed here")
+		}
+		return
+	}
+	rules, ok := schema.(map[string]any)
+	if !ok {
+		return
+	}
+
+	if value == nil && rules["nullable"] == true {
+		return
+	}
+
+	if all, ok := rules["allOf"].([]any); ok {
+		for _, sub := range all {
+			validateValue(sub, value, path, out)
+		}
+	}
+	if anyOf, ok := rules["anyOf"].([]any); ok && countMatches(anyOf, value, path) == 0 {
+		addViolation(out, path, "does not match any anyOf schema")
+	}
+	if oneOf, ok := rules["oneOf"].([]any); ok {
+		if matched := countMatches(oneOf, value, path); matched != 1 {
+			addViolation(out, path, fmt.Sprintf("matches %d oneOf schemas, want exactly 1", matched))
+		}
+	}
+
+	if want, ok := schemaTypes(rules["type"]); ok {
+		got := jsonTypeName(value)
+		if !typeAllowed(want, got) {
+			addViolation(out, path, fmt.Sprintf("expected %s, got %s", strings.Join(want, " or "), got))
+			return
+		}
+	}
+
+	if enum, ok := rules["enum"].([]any); ok && !enumContains(enum, value) {
+		addViolation(out, path, "value is not one of the enum values")
+	}
+
+	switch typed := value.(type) {
+	case map[string]any:
+		validateObject(rules, typed, path, out)
+	case []any:
+		if items, ok := rules["items"]; ok {
+			for i, item := range typed {
+				validateValue(items, item, fmt.Sprintf("%s[%d]", path, i), out)
+			}
+		}
+	}
+}
+
+func validateObject(rules map[string]any, object map[string]any, path string, out *[]SchemaViolation) {
+	if required, ok := rules["required"].([]any); ok {
+		for _, name := range required {
+			key, ok := name.(string)
+			if !ok {
+				continue
+			}
+			if _, present := object[key]; !present {
+				addViolation(out, path, fmt.Sprintf("missing required property %q", key))
+			}
+		}
+	}
+
+	properties, _ := rules["properties"].(map[string]any)
+	keys := make([]string, 0, len(object))
+	for key := range object {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		childPath := path + "." + key
+		if propSchema, ok := properties[key]; ok {
+			validateValue(propSchema, object[key], childPath, out)
+			continue
+		}
+		switch extra := rules["additionalProperties"].(type) {
+		case bool:
+			if !extra {
+				addViolation(out, path, fmt.Sprintf("unexpected property %q", key))
+			}
+		case map[string]any:
+			validateValue(extra, object[key], childPath, out)
+		}
+	}
+}
+
+func countMatches(schemas []any, value any, path string) int {
+	matched := 0
+	for _, sub := range schemas {
+		var violations []SchemaViolation
+		validateValue(sub, value, path, &violations)
+		if len(violations) == 0 {
+			matched++
+		}
+	}
+	return matched
+}
+
+func addViolation(out *[]SchemaViolation, path, message string) {
+	*out = append(*out, SchemaViolation{Path: path, Message: message})
+}
+
+func schemaTypes(raw any) ([]string, bool) {
+	switch typed := raw.(type) {
+	case string:
+		return []string{typed}, true
+	case []any:
+		types := make([]string, 0, len(typed))
+		for _, item := range typed {
+			if name, ok := item.(string); ok {
+				types = append(types, name)
+			}
+		}
+		return types, len(types) > 0
+	}
+	return nil, false
+}
+
+func typeAllowed(want []string, got string) bool {
+	for _, name := range want {
+		if name == got || (name == "number" && got == "integer") {
+			return true
+		}
+	}
+	return false
+}
+
+func jsonTypeName(value any) string {
+	switch typed := value.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case string:
+		return "string"
+	case []any:
+		return "array"
+	case map[string]any:
+		return "object"
+	case json.Number:
+		if _, err := strconv.ParseInt(typed.String(), 10, 64); err == nil {
+			return "integer"
+		}
+		if f, err := typed.Float64(); err == nil && f == math.Trunc(f) && !math.IsInf(f, 0) {
+			return "integer"
+		}
+		return "number"
+	case float64:
+		if typed == math.Trunc(typed) {
+			return "integer"
+		}
+		return "number"
+	}
+	return "unknown"
+}
+
+func enumContains(enum []any, value any) bool {
+	for _, candidate := range enum {
+		if jsonEqual(candidate, value) {
+			return true
+		}
+	}
+	return false
+}
+
+// jsonEqual compares a schema value with a decoded body value; numbers
+// compare by value because the body keeps json.Number.
+func jsonEqual(a, b any) bool {
+	if af, ok := jsonFloat(a); ok {
+		bf, ok := jsonFloat(b)
+		return ok && af == bf
+	}
+	aJSON, errA := json.Marshal(a)
+	bJSON, errB := json.Marshal(b)
+	return errA == nil && errB == nil && bytes.Equal(aJSON, bJSON)
+}
+
+func jsonFloat(value any) (float64, bool) {
+	switch typed := value.(type) {
+	case float64:
+		return typed, true
+	case json.Number:
+		f, err := typed.Float64()
+		return f, err == nil
+	}
+	return 0, false
+}
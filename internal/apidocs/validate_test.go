@@ -0,0 +1,108 @@
+package apidocs
+
+import (
+	"reflect"
+	"testing"
+)
+
+const schemaSpec = `{
+  "openapi": "3.0.0",
+  "paths": {
+    "/data/api/v1/projects": {
+      "get": {
+        "operationId": "listProjects",
+        "responses": {
+          "200": {
+            "description": "ok",
+            "content": {"application/json": {"schema": {"$ref": "#/components/schemas/ProjectList"}}}
+          },
+          "4XX": {
+            "description": "client error",
+            "content": {"application/json": {"schema": {"type": "object", "required": ["message"]}}}
+          }
+        }
+      }
+    }
+  },
+  "components": {
+    "schemas": {
+      "ProjectList": {
+        "type": "object",
+        "required": ["items"],
+        "properties": {
+          "items": {"type": "array", "items": {"$ref": "#/components/schemas/Project"}}
+        }
+      },
+      "Project": {
+        "type": "object",
+        "required": ["name", "enabled"],
+        "additionalProperties": false,
+        "properties": {
+          "name": {"type": "string"},
+          "enabled": {"type": "boolean"},
+          "state": {"type": "string", "enum": ["RUNNING", "STOPPED"], "nullable": true},
+          "version": {"type": "integer"},
+          "parent": {"$ref": "#/components/schemas/Project"}
+        }
+      }
+    }
+  }
+}`
+
+func TestResponseSchemaResolvesRefsAndValidates(t *testing.T) {
+	t.Parallel()
+
+	ops, err := LoadOperationsFromJSON([]byte(schemaSpec))
+	if err != nil {
+		t.Fatalf("load: %v", err)
+	}
+	schema, ok := ops[0].ResponseSchema(200)
+	if !ok {
+		t.Fatalf("expected a 200 schema in %+v", ops[0].Responses)
+	}
+
+	valid := `{"items":[{"name":"a","enabled":true,"state":null,"version":3,"parent":{"anything":1}}]}`
+	violations, err := ValidateJSON(schema, []byte(valid))
+	if err != nil || len(violations) != 0 {
+		t.Fatalf("expected a valid body, got %+v (%v)", violations, err)
+	}
+
+	invalid := `{"items":[{"name":5,"state":"PAUSED","version":1.5,"extra":true}]}`
+	violations, err = ValidateJSON(schema, []byte(invalid))
+	if err != nil {
+		t.Fatalf("validate: %v", err)
+	}
+	want := []SchemaViolation{
+		{Path: "$.items[0]", Message: `missing required property "enabled"`},
+		{Path: "$.items[0]", Message: `unexpected property "extra"`},
+		{Path: "$.items[0].name", Message: "expected string, got integer"},
+		{Path: "$.items[0].state", Message: "value is not one of the enum values"},
+		{Path: "$.items[0].version", Message: "expected integer, got number"},
+	}
+	if !reflect.DeepEqual(violations, want) {
+		t.Fatalf("unexpected violations\n got: %+v\nwant: %+v", violations, want)
+	}
+
+	if _, ok := ops[0].ResponseSchema(404); !ok {
+		t.Fatalf("expected 404 to fall back to the 4XX schema")
+	}
+	if _, ok := ops[0].ResponseSchema(500); ok {
+		t.Fatalf("expected no schema for 500")
+	}
+	if _, err := ValidateJSON(schema, []byte("not json")); err == nil {
+		t.Fatalf("expected a parse error for a non-JSON body")
+	}
+}
+
+func TestValidateJSONCompositionKeywords(t *testing.T) {
+	t.Parallel()
+
+	schema := []byte(`{"oneOf":[{"type":"string"},{"type":"integer"}],"anyOf":[{"type":"string"},{"type":"number"}]}`)
+	if violations, _ := ValidateJSON(schema, []byte(`"x"`)); len(violations) != 0 {
+		t.Fatalf("expected string to match, got %+v", violations)
+	}
+	violations, _ := ValidateJSON(schema, []byte(`true`))
+	if len(violations) != 2 {
+		t.Fatalf("expected anyOf and oneOf violations, got %+v", violations)
+	}
+}
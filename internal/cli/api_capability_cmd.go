@@ -33,7 +33,7 @@ func (c *CLI) runAPICapability(args []string) error {
 		return c.printAPICapabilityError(jsonRequested, jsonSelectOptions{}, &igwerr.UsageError{Msg: err.Error()})
 	}
 
-	selectOpts, selectErr := newJSONSelectOptions(common.jsonOutput, common.compactJSON, common.rawOutput, common.selectors)
+	selectOpts, selectErr := common.selectOptions()
 	if selectErr != nil {
 		return c.printAPICapabilityError(common.jsonOutput, selectionErrorOptions(selectOpts), selectErr)
 	}
@@ -53,15 +53,12 @@ func (c *CLI) runAPICapability(args []string) error {
 		return c.printAPICapabilityError(common.jsonOutput, selectOpts, &igwerr.UsageError{Msg: fmt.Sprintf("unsupported capability %q", capability)})
 	}
 
-	if common.apiKeyStdin {
-		if common.apiKey != "" {
-			return c.printAPICapabilityError(common.jsonOutput, selectOpts, &igwerr.UsageError{Msg: "use only one of --api-key or --api-key-stdin"})
-		}
-		tokenBytes, err := io.ReadAll(c.In)
-		if err != nil {
-			return c.printAPICapabilityError(common.jsonOutput, selectOpts, igwerr.NewTransportError(err))
-		}
-		common.apiKey = strings.TrimSpace(string(tokenBytes))
+	if err := c.readCredentials(&common); err != nil {
+		return c.printAPICapabilityError(common.jsonOutput, selectOpts, err)
+	}
+	signer, err := common.requestSigner()
+	if err != nil {
+		return c.printAPICapabilityError(common.jsonOutput, selectOpts, err)
 	}
 
 	start := time.Now()
@@ -70,6 +67,10 @@ func (c *CLI) runAPICapability(args []string) error {
 		GatewayURL: common.gatewayURL,
 		APIKey:     common.apiKey,
 		Timeout:    common.timeout,
+		Signer:     signer,
+		Proxy:      common.proxy,
+		NoProxy:    common.noProxy,
+		TLS:        common.tls,
 	})
 	if err != nil {
 		return c.printAPICapabilityError(common.jsonOutput, selectOpts, err)
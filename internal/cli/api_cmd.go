@@ -8,7 +8,7 @@ import (
 
 func (c *CLI) runAPI(args []string) error {
 	if len(args) == 0 {
-		fmt.Fprintln(c.Err, "Usage: igw api <list|show|search|tags|stats|capability|sync|refresh> [flags]")
+		fmt.Fprintln(c.Err, "Usage: igw api <list|show|resolve|search|tags|stats|capability|diff|sync|refresh> [flags]")
 		return &igwerr.UsageError{Msg: "required api subcommand"}
 	}
 
@@ -17,6 +17,8 @@ func (c *CLI) runAPI(args []string) error {
 		return c.runAPIList(args[1:])
 	case "show":
 		return c.runAPIShow(args[1:])
+	case "resolve":
+		return c.runAPIResolve(args[1:])
 	case "search":
 		return c.runAPISearch(args[1:])
 	case "tags":
@@ -25,6 +27,8 @@ func (c *CLI) runAPI(args []string) error {
 		return c.runAPIStats(args[1:])
 	case "capability":
 		return c.runAPICapability(args[1:])
+	case "diff":
+		return c.runAPIDiff(args[1:])
 	case "sync":
 		return c.runAPISync(args[1:])
 	case "refresh":
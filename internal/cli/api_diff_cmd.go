@@ -0,0 +1,94 @@
+package cli
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/alex-mccollum/igw-cli/internal/apidocs"
+	"github.com/alex-mccollum/igw-cli/internal/igwerr"
+)
+
+type apiDiffPayload struct {
+	Old string `json:"old"`
+	New string `json:"new"`
+	apidocs.SpecDiff
+}
+
+func (c *CLI) runAPIDiff(args []string) error {
+	jsonRequested := argsWantJSON(args)
+	fs := newAPIFlagSet("api diff", c.Err, jsonRequested)
+
+	var oldSpec string
+	var newSpec string
+	var failOnChange bool
+	var jsonOutput bool
+	var compact bool
+
+	fs.StringVar(&oldSpec, "old", "", "Previous OpenAPI JSON file (for example a saved snapshot)")
+	fs.StringVar(&newSpec, "new", apidocs.DefaultSpecFile, "Current OpenAPI JSON file (default: the synced spec)")
+	fs.BoolVar(&failOnChange, "fail-on-change", false, "Exit 2 when any operation was added, removed, or changed")
+	fs.BoolVar(&jsonOutput, "json", false, "Print JSON output")
+	fs.BoolVar(&compact, "compact", false, "Print compact one-line JSON (requires --json)")
+
+	if err := fs.Parse(args); err != nil {
+		return c.printJSONCommandErrorWithOptions(jsonRequested, argsWantCompact(args), &igwerr.UsageError{Msg: err.Error()})
+	}
+	if compact && !jsonOutput {
+		return c.printJSONCommandErrorWithOptions(false, false, &igwerr.UsageError{Msg: "--compact requires --json"})
+	}
+	if fs.NArg() > 0 {
+		return c.printJSONCommandErrorWithOptions(jsonOutput, compact, &igwerr.UsageError{Msg: "unexpected positional arguments"})
+	}
+	if strings.TrimSpace(oldSpec) == "" {
+		return c.printJSONCommandErrorWithOptions(jsonOutput, compact, &igwerr.UsageError{Msg: "required: --old"})
+	}
+
+	runtime := apiSyncRuntime{Timeout: 8 * time.Second}
+	oldOps, err := c.loadAPIOperations(oldSpec, runtime)
+	if err != nil {
+		return c.printJSONCommandErrorWithOptions(jsonOutput, compact, err)
+	}
+	newOps, err := c.loadAPIOperations(newSpec, runtime)
+	if err != nil {
+		return c.printJSONCommandErrorWithOptions(jsonOutput, compact, err)
+	}
+
+	diff := apidocs.DiffOperations(oldOps, newOps)
+	var changeErr error
+	if failOnChange && !diff.Empty() {
+		changeErr = &igwerr.UsageError{Msg: fmt.Sprintf(
+			"api changed: %d added, %d removed, %d changed (--fail-on-change)", len(diff.Added), len(diff.Removed), len(diff.Changed),
+		)}
+	}
+
+	if jsonOutput {
+		if err := writeJSONWithOptions(c.Out, apiDiffPayload{Old: oldSpec, New: newSpec, SpecDiff: diff}, compact); err != nil {
+			return err
+		}
+		return changeErr
+	}
+
+	if diff.Empty() {
+		fmt.Fprintln(c.Out, "no API changes")
+		return nil
+	}
+	fmt.Fprintln(c.Out, "CHANGE\tMETHOD\tPATH\tOPERATION\tFIELDS")
+	for _, op := range diff.Added {
+		fmt.Fprintf(c.Out, "added\t%s\t%s\t%s\t\n", op.Method, op.Path, op.OperationID)
+	}
+	for _, op := range diff.Removed {
+		fmt.Fprintf(c.Out, "removed\t%s\t%s\t%s\t\n", op.Method, op.Path, op.OperationID)
+	}
+	for _, op := range diff.Changed {
+		fields := make([]string, 0, len(op.Changes))
+		for _, change := range op.Changes {
+			fields = append(fields, change.Field)
+		}
+		fmt.Fprintf(c.Out, "changed\t%s\t%s\t%s\t%s\n", op.Method, op.Path, op.OperationID, strings.Join(fields, ","))
+	}
+	if changeErr != nil {
+		fmt.Fprintln(c.Err, changeErr.Error())
+	}
+	return changeErr
+}
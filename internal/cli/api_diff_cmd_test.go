@@ -0,0 +1,73 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+const apiDiffNewSpec = `{
+  "openapi": "3.0.0",
+  "paths": {
+    "/data/api/v1/gateway-info": {
+      "get": {
+        "operationId": "gatewayInfo",
+        "summary": "Gateway information",
+        "deprecated": true
+      }
+    },
+    "/data/api/v1/modules": {
+      "get": {"operationId": "listModules"}
+    }
+  }
+}`
+
+func TestAPIDiffReportsChanges(t *testing.T) {
+	t.Parallel()
+
+	oldSpec := writeCallOpSpec(t, callOpSpecFixture)
+	newSpec := writeCallOpSpec(t, apiDiffNewSpec)
+
+	var out, errOut bytes.Buffer
+	c := newAdminWrapperTestCLI(offlineTestClient())
+	c.Out = &out
+	c.Err = &errOut
+	if err := c.Execute([]string{"api", "diff", "--old", oldSpec, "--new", newSpec}); err != nil {
+		t.Fatalf("api diff: %v", err)
+	}
+	want := "CHANGE\tMETHOD\tPATH\tOPERATION\tFIELDS\n" +
+		"added\tGET\t/data/api/v1/modules\tlistModules\t\n" +
+		"removed\tPOST\t/data/api/v1/scan/projects\tscanProjects\t\n" +
+		"changed\tGET\t/data/api/v1/gateway-info\tgatewayInfo\tsummary,deprecated\n"
+	if out.String() != want {
+		t.Fatalf("unexpected table\n got: %q\nwant: %q", out.String(), want)
+	}
+
+	out.Reset()
+	err := c.Execute([]string{"api", "diff", "--old", oldSpec, "--new", newSpec, "--json", "--fail-on-change"})
+	requireUsageExitCode(t, err)
+	var payload struct {
+		Added   []map[string]any `json:"added"`
+		Removed []map[string]any `json:"removed"`
+		Changed []struct {
+			Changes []map[string]any `json:"changes"`
+		} `json:"changed"`
+	}
+	if jsonErr := json.Unmarshal(out.Bytes(), &payload); jsonErr != nil {
+		t.Fatalf("parse: %v\n%s", jsonErr, out.String())
+	}
+	if len(payload.Added) != 1 || len(payload.Removed) != 1 || len(payload.Changed) != 1 || payload.Changed[0].Changes[0]["new"] != "Gateway information" {
+		t.Fatalf("unexpected payload %s", out.String())
+	}
+
+	out.Reset()
+	if err := c.Execute([]string{"api", "diff", "--old", newSpec, "--new", newSpec, "--fail-on-change"}); err != nil {
+		t.Fatalf("identical specs: %v", err)
+	}
+	if !strings.Contains(out.String(), "no API changes") {
+		t.Fatalf("unexpected output %q", out.String())
+	}
+
+	requireUsageExitCode(t, c.Execute([]string{"api", "diff", "--new", newSpec}))
+}
@@ -19,27 +19,62 @@ func (c *CLI) runAPIList(args []string) error {
 	var method string
 	var pathContains string
 	var jsonOutput bool
+	var compact bool
 	var timing bool
 	var jsonStats bool
+	var outputFormat string
+	var outputTemplate string
+	var columns string
+	var pager pagerOptions
 
 	fs.StringVar(&specFile, "spec-file", apidocs.DefaultSpecFile, "Path to OpenAPI JSON file")
 	fs.StringVar(&method, "method", "", "Filter by HTTP method")
 	fs.StringVar(&pathContains, "path-contains", "", "Filter by path substring")
 	fs.BoolVar(&jsonOutput, "json", false, "Print JSON output")
+	fs.BoolVar(&compact, "compact", false, "Print compact one-line JSON (requires --json)")
 	fs.BoolVar(&timing, "timing", false, "Include command timing output")
 	fs.BoolVar(&jsonStats, "json-stats", false, "Include runtime stats in JSON output")
+	fs.StringVar(&outputFormat, "output", outputFormatText, "Text output format: text|markdown|table|tsv|csv")
+	fs.StringVar(&outputTemplate, "output-template", "", "Render the JSON result with a Go text/template (inline or @file)")
+	fs.StringVar(&columns, "columns", "", "Comma-separated operation fields for --output table|tsv|csv (default: method,path,operationId,summary)")
+	bindPagerFlags(fs, &pager)
 
 	if err := fs.Parse(args); err != nil {
-		return c.printJSONCommandError(jsonRequested, &igwerr.UsageError{Msg: err.Error()})
+		return c.printJSONCommandErrorWithOptions(jsonRequested, argsWantCompact(args), &igwerr.UsageError{Msg: err.Error()})
+	}
+	if compact && !jsonOutput {
+		return c.printJSONCommandErrorWithOptions(false, false, &igwerr.UsageError{Msg: "--compact requires --json"})
 	}
 	if fs.NArg() > 0 {
-		return c.printJSONCommandError(jsonOutput, &igwerr.UsageError{Msg: "unexpected positional arguments"})
+		return c.printJSONCommandErrorWithOptions(jsonOutput, compact, &igwerr.UsageError{Msg: "unexpected positional arguments"})
+	}
+	if err := pager.validate(); err != nil {
+		return c.printJSONCommandErrorWithOptions(jsonOutput, compact, err)
+	}
+	recordFormat := strings.ToLower(strings.TrimSpace(outputFormat))
+	if !isRecordOutputFormat(recordFormat) {
+		recordFormat = ""
+	}
+	var outputTmpl string
+	if recordFormat != "" {
+		if jsonOutput || strings.TrimSpace(outputTemplate) != "" {
+			return c.printJSONCommandErrorWithOptions(jsonOutput, compact, &igwerr.UsageError{Msg: fmt.Sprintf("--output %s is not supported with --json or --output-template", recordFormat)})
+		}
+	} else {
+		if strings.TrimSpace(columns) != "" {
+			return c.printJSONCommandErrorWithOptions(jsonOutput, compact, &igwerr.UsageError{Msg: "--columns requires --output table, tsv, or csv"})
+		}
+		tmpl, err := resolveOutputTemplate(outputFormat, outputTemplate, jsonOutput, apiListMarkdownTemplate)
+		if err != nil {
+			return c.printJSONCommandErrorWithOptions(jsonOutput, compact, err)
+		}
+		outputTmpl = tmpl
 	}
 
 	start := time.Now()
 	ops, err := c.loadAPIOperations(specFile, apiSyncRuntime{Timeout: 8 * time.Second})
 	if err != nil {
-		return c.printJSONCommandError(jsonOutput, err)
+		return c.printJSONCommandErrorWithOptions(jsonOutput, compact, err)
 	}
 
 	ops = apidocs.FilterByMethod(ops, method)
@@ -54,10 +89,32 @@ func (c *CLI) runAPIList(args []string) error {
 		if timing || jsonStats {
 			payload["stats"] = stats
 		}
-		return writeJSON(c.Out, payload)
+		return writeJSONWithOptions(c.Out, payload, compact)
+	}
+	out := c.pagedOutput(pager)
+	switch {
+	case recordFormat != "":
+		body, err := marshalRecords(ops)
+		if err != nil {
+			return err
+		}
+		recordColumns := parseColumns(columns)
+		if len(recordColumns) == 0 {
+			recordColumns = apiListRecordColumns
+		}
+		if err := writeRecordTable(out, c.Err, recordFormat, recordColumns, body); err != nil {
+			return err
+		}
+	case outputTmpl != "":
+		if err := renderOutputTemplate(out, outputTmpl, map[string]any{"count": len(ops), "operations": ops}); err != nil {
+			return err
+		}
+	default:
+		writeOperationTable(out, ops)
+	}
+	if err := out.flush(); err != nil {
+		return err
 	}
-
-	writeOperationTable(c.Out, ops)
 	if timing {
 		fmt.Fprintf(c.Err, "timing\telapsedMs=%d\n", stats["elapsedMs"])
 	}
@@ -71,33 +128,42 @@ func (c *CLI) runAPIShow(args []string) error {
 	var specFile string
 	var method string
 	var path string
+	var writeSpecTo string
 	var jsonOutput bool
+	var compact bool
 	var timing bool
 	var jsonStats bool
+	var includeResponses bool
 
 	fs.StringVar(&specFile, "spec-file", apidocs.DefaultSpecFile, "Path to OpenAPI JSON file")
 	fs.StringVar(&method, "method", "", "Filter by HTTP method")
 	fs.StringVar(&path, "path", "", "Exact API path to show")
+	fs.StringVar(&writeSpecTo, "write-spec-to", "", "Write a minimal OpenAPI document for the shown operations to file")
+	fs.BoolVar(&includeResponses, "include-responses", false, "Show documented response codes and descriptions")
 	fs.BoolVar(&jsonOutput, "json", false, "Print JSON output")
+	fs.BoolVar(&compact, "compact", false, "Print compact one-line JSON (requires --json)")
 	fs.BoolVar(&timing, "timing", false, "Include command timing output")
 	fs.BoolVar(&jsonStats, "json-stats", false, "Include runtime stats in JSON output")
 
 	if err := fs.Parse(args); err != nil {
-		return c.printJSONCommandError(jsonRequested, &igwerr.UsageError{Msg: err.Error()})
+		return c.printJSONCommandErrorWithOptions(jsonRequested, argsWantCompact(args), &igwerr.UsageError{Msg: err.Error()})
+	}
+	if compact && !jsonOutput {
+		return c.printJSONCommandErrorWithOptions(false, false, &igwerr.UsageError{Msg: "--compact requires --json"})
 	}
 	path, err := applySinglePositionalFallback(fs, path)
 	if err != nil {
-		return c.printJSONCommandError(jsonOutput, err)
+		return c.printJSONCommandErrorWithOptions(jsonOutput, compact, err)
 	}
 
 	if strings.TrimSpace(path) == "" {
-		return c.printJSONCommandError(jsonOutput, &igwerr.UsageError{Msg: "required: --path (or one positional path argument)"})
+		return c.printJSONCommandErrorWithOptions(jsonOutput, compact, &igwerr.UsageError{Msg: "required: --path (or one positional path argument)"})
 	}
 
 	start := time.Now()
 	ops, err := c.loadAPIOperations(specFile, apiSyncRuntime{Timeout: 8 * time.Second})
 	if err != nil {
-		return c.printJSONCommandError(jsonOutput, err)
+		return c.printJSONCommandErrorWithOptions(jsonOutput, compact, err)
 	}
 
 	ops = apidocs.FilterByPath(ops, strings.TrimSpace(path))
@@ -108,15 +174,23 @@ func (c *CLI) runAPIShow(args []string) error {
 	}
 
 	if len(ops) == 0 {
-		return c.printJSONCommandError(jsonOutput, &igwerr.UsageError{Msg: fmt.Sprintf("no API operation found for path %q", path)})
+		return c.printJSONCommandErrorWithOptions(jsonOutput, compact, &igwerr.UsageError{Msg: fmt.Sprintf("no API operation found for path %q", path)})
+	}
+	if strings.TrimSpace(writeSpecTo) != "" {
+		if err := writeOperationSpecSubset(specFile, ops, writeSpecTo); err != nil {
+			return c.printJSONCommandErrorWithOptions(jsonOutput, compact, err)
+		}
 	}
 
 	if jsonOutput {
 		payload := map[string]any{"count": len(ops), "operations": ops}
+		if includeResponses {
+			payload["operations"] = operationsWithResponses(ops)
+		}
 		if timing || jsonStats {
 			payload["stats"] = stats
 		}
-		return writeJSON(c.Out, payload)
+		return writeJSONWithOptions(c.Out, payload, compact)
 	}
 
 	for i, op := range ops {
@@ -134,6 +208,9 @@ func (c *CLI) runAPIShow(args []string) error {
 		if strings.TrimSpace(op.Description) != "" {
 			fmt.Fprintf(c.Out, "description\t%s\n", op.Description)
 		}
+		if includeResponses {
+			writeOperationResponses(c.Out, op)
+		}
 	}
 	if timing {
 		fmt.Fprintf(c.Err, "timing\telapsedMs=%d\n", stats["elapsedMs"])
@@ -142,6 +219,35 @@ func (c *CLI) runAPIShow(args []string) error {
 	return nil
 }
 
+// apiShowOperation always carries a responses array so JSON callers of
+// --include-responses can tell "none documented" from "not requested".
+type apiShowOperation struct {
+	apidocs.Operation
+	Responses []apidocs.Response `json:"responses"`
+}
+
+func operationsWithResponses(ops []apidocs.Operation) []apiShowOperation {
+	out := make([]apiShowOperation, 0, len(ops))
+	for _, op := range ops {
+		responses := op.Responses
+		if responses == nil {
+			responses = []apidocs.Response{}
+		}
+		out = append(out, apiShowOperation{Operation: op, Responses: responses})
+	}
+	return out
+}
+
+func writeOperationResponses(w io.Writer, op apidocs.Operation) {
+	if len(op.Responses) == 0 {
+		fmt.Fprintln(w, "responses\tnone documented")
+		return
+	}
+	for _, resp := range op.Responses {
+		fmt.Fprintf(w, "response\t%s\t%s\n", resp.Code, resp.Description)
+	}
+}
+
 func (c *CLI) runAPISearch(args []string) error {
 	jsonRequested := argsWantJSON(args)
 	fs := newAPIFlagSet("api search", c.Err, jsonRequested)
@@ -150,6 +256,7 @@ func (c *CLI) runAPISearch(args []string) error {
 	var method string
 	var query string
 	var jsonOutput bool
+	var compact bool
 	var timing bool
 	var jsonStats bool
 
@@ -157,25 +264,29 @@ func (c *CLI) runAPISearch(args []string) error {
 	fs.StringVar(&method, "method", "", "Filter by HTTP method")
 	fs.StringVar(&query, "query", "", "Search text")
 	fs.BoolVar(&jsonOutput, "json", false, "Print JSON output")
+	fs.BoolVar(&compact, "compact", false, "Print compact one-line JSON (requires --json)")
 	fs.BoolVar(&timing, "timing", false, "Include command timing output")
 	fs.BoolVar(&jsonStats, "json-stats", false, "Include runtime stats in JSON output")
 
 	if err := fs.Parse(args); err != nil {
-		return c.printJSONCommandError(jsonRequested, &igwerr.UsageError{Msg: err.Error()})
+		return c.printJSONCommandErrorWithOptions(jsonRequested, argsWantCompact(args), &igwerr.UsageError{Msg: err.Error()})
+	}
+	if compact && !jsonOutput {
+		return c.printJSONCommandErrorWithOptions(false, false, &igwerr.UsageError{Msg: "--compact requires --json"})
 	}
 	query, err := applySinglePositionalFallback(fs, query)
 	if err != nil {
-		return c.printJSONCommandError(jsonOutput, err)
+		return c.printJSONCommandErrorWithOptions(jsonOutput, compact, err)
 	}
 
 	if strings.TrimSpace(query) == "" {
-		return c.printJSONCommandError(jsonOutput, &igwerr.UsageError{Msg: "required: --query (or one positional query argument)"})
+		return c.printJSONCommandErrorWithOptions(jsonOutput, compact, &igwerr.UsageError{Msg: "required: --query (or one positional query argument)"})
 	}
 
 	start := time.Now()
 	ops, err := c.loadAPIOperations(specFile, apiSyncRuntime{Timeout: 8 * time.Second})
 	if err != nil {
-		return c.printJSONCommandError(jsonOutput, err)
+		return c.printJSONCommandErrorWithOptions(jsonOutput, compact, err)
 	}
 
 	ops = apidocs.FilterByMethod(ops, method)
@@ -190,7 +301,7 @@ func (c *CLI) runAPISearch(args []string) error {
 		if timing || jsonStats {
 			payload["stats"] = stats
 		}
-		return writeJSON(c.Out, payload)
+		return writeJSONWithOptions(c.Out, payload, compact)
 	}
 
 	writeOperationTable(c.Out, ops)
@@ -208,6 +319,7 @@ func (c *CLI) runAPITags(args []string) error {
 	var method string
 	var pathContains string
 	var jsonOutput bool
+	var compact bool
 	var timing bool
 	var jsonStats bool
 
@@ -215,20 +327,24 @@ func (c *CLI) runAPITags(args []string) error {
 	fs.StringVar(&method, "method", "", "Filter by HTTP method")
 	fs.StringVar(&pathContains, "path-contains", "", "Filter by path substring")
 	fs.BoolVar(&jsonOutput, "json", false, "Print JSON output")
+	fs.BoolVar(&compact, "compact", false, "Print compact one-line JSON (requires --json)")
 	fs.BoolVar(&timing, "timing", false, "Include command timing output")
 	fs.BoolVar(&jsonStats, "json-stats", false, "Include runtime stats in JSON output")
 
 	if err := fs.Parse(args); err != nil {
-		return c.printJSONCommandError(jsonRequested, &igwerr.UsageError{Msg: err.Error()})
+		return c.printJSONCommandErrorWithOptions(jsonRequested, argsWantCompact(args), &igwerr.UsageError{Msg: err.Error()})
+	}
+	if compact && !jsonOutput {
+		return c.printJSONCommandErrorWithOptions(false, false, &igwerr.UsageError{Msg: "--compact requires --json"})
 	}
 	if fs.NArg() > 0 {
-		return c.printJSONCommandError(jsonOutput, &igwerr.UsageError{Msg: "unexpected positional arguments"})
+		return c.printJSONCommandErrorWithOptions(jsonOutput, compact, &igwerr.UsageError{Msg: "unexpected positional arguments"})
 	}
 
 	start := time.Now()
 	ops, err := c.loadAPIOperations(specFile, apiSyncRuntime{Timeout: 8 * time.Second})
 	if err != nil {
-		return c.printJSONCommandError(jsonOutput, err)
+		return c.printJSONCommandErrorWithOptions(jsonOutput, compact, err)
 	}
 
 	ops = apidocs.FilterByMethod(ops, method)
@@ -244,7 +360,7 @@ func (c *CLI) runAPITags(args []string) error {
 		if timing || jsonStats {
 			payload["stats"] = stats
 		}
-		return writeJSON(c.Out, payload)
+		return writeJSONWithOptions(c.Out, payload, compact)
 	}
 
 	for _, tag := range tags {
@@ -266,6 +382,7 @@ func (c *CLI) runAPIStats(args []string) error {
 	var query string
 	var prefixDepth int
 	var jsonOutput bool
+	var compact bool
 	var timing bool
 	var jsonStats bool
 
@@ -275,23 +392,27 @@ func (c *CLI) runAPIStats(args []string) error {
 	fs.StringVar(&query, "query", "", "Search text")
 	fs.IntVar(&prefixDepth, "prefix-depth", 0, "Path prefix segment depth for aggregation (0 = auto)")
 	fs.BoolVar(&jsonOutput, "json", false, "Print JSON output")
+	fs.BoolVar(&compact, "compact", false, "Print compact one-line JSON (requires --json)")
 	fs.BoolVar(&timing, "timing", false, "Include command timing output")
 	fs.BoolVar(&jsonStats, "json-stats", false, "Include runtime stats in JSON output")
 
 	if err := fs.Parse(args); err != nil {
-		return c.printJSONCommandError(jsonRequested, &igwerr.UsageError{Msg: err.Error()})
+		return c.printJSONCommandErrorWithOptions(jsonRequested, argsWantCompact(args), &igwerr.UsageError{Msg: err.Error()})
+	}
+	if compact && !jsonOutput {
+		return c.printJSONCommandErrorWithOptions(false, false, &igwerr.UsageError{Msg: "--compact requires --json"})
 	}
 	if fs.NArg() > 0 {
-		return c.printJSONCommandError(jsonOutput, &igwerr.UsageError{Msg: "unexpected positional arguments"})
+		return c.printJSONCommandErrorWithOptions(jsonOutput, compact, &igwerr.UsageError{Msg: "unexpected positional arguments"})
 	}
 	if prefixDepth < 0 {
-		return c.printJSONCommandError(jsonOutput, &igwerr.UsageError{Msg: "--prefix-depth must be >= 0"})
+		return c.printJSONCommandErrorWithOptions(jsonOutput, compact, &igwerr.UsageError{Msg: "--prefix-depth must be >= 0"})
 	}
 
 	start := time.Now()
 	ops, err := c.loadAPIOperations(specFile, apiSyncRuntime{Timeout: 8 * time.Second})
 	if err != nil {
-		return c.printJSONCommandError(jsonOutput, err)
+		return c.printJSONCommandErrorWithOptions(jsonOutput, compact, err)
 	}
 
 	ops = apidocs.FilterByMethod(ops, method)
@@ -313,7 +434,7 @@ func (c *CLI) runAPIStats(args []string) error {
 		if timing || jsonStats {
 			payload["stats"] = meta
 		}
-		return writeJSON(c.Out, payload)
+		return writeJSONWithOptions(c.Out, payload, compact)
 	}
 
 	writeStatsTable(c.Out, stats)
@@ -323,6 +444,9 @@ func (c *CLI) runAPIStats(args []string) error {
 	return nil
 }
 
+// apiListMarkdownTemplate renders api list --output markdown.
+const apiListMarkdownTemplate = `{{table .operations "method" "path" "operationId" "summary"}}`
+
 func newAPIFlagSet(name string, errOut io.Writer, jsonRequested bool) *flag.FlagSet {
 	fs := flag.NewFlagSet(name, flag.ContinueOnError)
 	fs.SetOutput(errOut)
@@ -342,6 +466,9 @@ func applySinglePositionalFallback(fs *flag.FlagSet, current string) (string, er
 	return "", &igwerr.UsageError{Msg: "unexpected positional arguments"}
 }
 
+// apiListRecordColumns are the api list --output table|tsv|csv defaults.
+var apiListRecordColumns = []string{"method", "path", "operationId", "summary"}
+
 func writeOperationTable(out io.Writer, ops []apidocs.Operation) {
 	fmt.Fprintln(out, "METHOD\tPATH\tOPERATION_ID\tSUMMARY")
 	for _, op := range ops {
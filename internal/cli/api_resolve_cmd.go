@@ -0,0 +1,97 @@
+package cli
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/alex-mccollum/igw-cli/internal/apidocs"
+	"github.com/alex-mccollum/igw-cli/internal/igwerr"
+)
+
+// resolvedOperation is the JSON shape of `api resolve` and `call --explain-op`.
+type resolvedOperation struct {
+	OperationID string `json:"operationId"`
+	Method      string `json:"method"`
+	Path        string `json:"path"`
+}
+
+func (c *CLI) runAPIResolve(args []string) error {
+	jsonRequested := argsWantJSON(args)
+	fs := newAPIFlagSet("api resolve", c.Err, jsonRequested)
+
+	var specFile string
+	var op string
+	var opMethod string
+	var jsonOutput bool
+	var compact bool
+
+	fs.StringVar(&specFile, "spec-file", apidocs.DefaultSpecFile, "Path to OpenAPI JSON file")
+	fs.StringVar(&op, "op", "", "OperationId to resolve (or one positional argument)")
+	fs.StringVar(&opMethod, "op-method", "", "Disambiguate --op by HTTP method")
+	fs.BoolVar(&jsonOutput, "json", false, "Print JSON output")
+	fs.BoolVar(&compact, "compact", false, "Print compact one-line JSON (requires --json)")
+
+	if err := fs.Parse(args); err != nil {
+		return c.printJSONCommandErrorWithOptions(jsonRequested, argsWantCompact(args), &igwerr.UsageError{Msg: err.Error()})
+	}
+	if compact && !jsonOutput {
+		return c.printJSONCommandErrorWithOptions(false, false, &igwerr.UsageError{Msg: "--compact requires --json"})
+	}
+	op, err := applySinglePositionalFallback(fs, op)
+	if err != nil {
+		return c.printJSONCommandErrorWithOptions(jsonOutput, compact, err)
+	}
+	if strings.TrimSpace(op) == "" {
+		return c.printJSONCommandErrorWithOptions(jsonOutput, compact, &igwerr.UsageError{Msg: "required: --op (or one positional operationId)"})
+	}
+
+	ops, err := c.loadAPIOperations(specFile, apiSyncRuntime{Timeout: 8 * time.Second})
+	if err != nil {
+		return c.printJSONCommandErrorWithOptions(jsonOutput, compact, err)
+	}
+	return c.explainOperation(ops, op, opMethod, specFile, jsonOutput, compact)
+}
+
+// explainOperation prints the METHOD PATH an operationId resolves to without
+// calling it. An ambiguous id prints every candidate and exits 2.
+func (c *CLI) explainOperation(ops []apidocs.Operation, op string, methodHint string, specFile string, jsonOutput bool, compact bool) error {
+	matches, err := matchOperationWithHints(ops, op, methodHint, specFile)
+	if err != nil {
+		if jsonOutput {
+			return c.printJSONCommandErrorWithOptions(true, compact, err)
+		}
+		fmt.Fprintln(c.Err, err.Error())
+		return err
+	}
+
+	resolved := make([]resolvedOperation, 0, len(matches))
+	for _, match := range matches {
+		resolved = append(resolved, resolvedOperation{
+			OperationID: match.OperationID,
+			Method:      match.Method,
+			Path:        match.Path,
+		})
+	}
+
+	if len(matches) > 1 {
+		ambiguousErr := ambiguousOperationError(op, matches)
+		if jsonOutput {
+			payload := jsonErrorPayload(ambiguousErr)
+			payload["candidates"] = resolved
+			_ = writeJSONWithOptions(c.Out, payload, compact)
+			return ambiguousErr
+		}
+		for _, candidate := range resolved {
+			fmt.Fprintf(c.Out, "%s %s\n", candidate.Method, candidate.Path)
+		}
+		fmt.Fprintln(c.Err, ambiguousErr.Error())
+		return ambiguousErr
+	}
+
+	if jsonOutput {
+		return writeJSONWithOptions(c.Out, resolved[0], compact)
+	}
+	fmt.Fprintf(c.Out, "%s %s\n", resolved[0].Method, resolved[0].Path)
+	return nil
+}
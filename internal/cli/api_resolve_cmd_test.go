@@ -0,0 +1,126 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+const ambiguousOpSpecFixture = `{
+  "openapi": "3.0.0",
+  "paths": {
+    "/data/api/v1/projects": {
+      "get": {"operationId": "listProjects"}
+    },
+    "/data/api/v2/projects": {
+      "get": {"operationId": "listProjects"}
+    }
+  }
+}`
+
+// offlineTestClient panics if a command that should work from the spec
+// alone reaches the gateway.
+func offlineTestClient() *http.Client {
+	return newMockHTTPClient(func(r *http.Request) (*http.Response, error) {
+		panic("resolving an operation must not call the gateway")
+	})
+}
+
+func TestAPIResolveUniqueOperation(t *testing.T) {
+	t.Parallel()
+
+	specPath := writeCallOpSpec(t, callOpSpecFixture)
+
+	var out, errOut bytes.Buffer
+	c := newAdminWrapperTestCLI(offlineTestClient())
+	c.Out = &out
+	c.Err = &errOut
+	if err := c.Execute([]string{"api", "resolve", "--spec-file", specPath, "scanProjects"}); err != nil {
+		t.Fatalf("api resolve failed: %v", err)
+	}
+	if got := out.String(); got != "POST /data/api/v1/scan/projects\n" {
+		t.Fatalf("unexpected output %q", got)
+	}
+
+	out.Reset()
+	if err := c.Execute([]string{"api", "resolve", "--spec-file", specPath, "--op", "gatewayInfo", "--json"}); err != nil {
+		t.Fatalf("api resolve --json failed: %v", err)
+	}
+	var payload resolvedOperation
+	if err := json.Unmarshal(out.Bytes(), &payload); err != nil {
+		t.Fatalf("decode json: %v", err)
+	}
+	if payload != (resolvedOperation{OperationID: "gatewayInfo", Method: http.MethodGet, Path: "/data/api/v1/gateway-info"}) {
+		t.Fatalf("unexpected payload %+v", payload)
+	}
+}
+
+func TestAPIResolveAmbiguousListsCandidates(t *testing.T) {
+	t.Parallel()
+
+	specPath := writeCallOpSpec(t, ambiguousOpSpecFixture)
+
+	var out, errOut bytes.Buffer
+	c := newAdminWrapperTestCLI(offlineTestClient())
+	c.Out = &out
+	c.Err = &errOut
+	err := c.Execute([]string{"api", "resolve", "--spec-file", specPath, "listProjects"})
+	requireUsageExitCode(t, err)
+	if got := out.String(); got != "GET /data/api/v1/projects\nGET /data/api/v2/projects\n" {
+		t.Fatalf("unexpected candidates %q", got)
+	}
+	if !strings.Contains(errOut.String(), "ambiguous (2 matches)") {
+		t.Fatalf("expected ambiguity error on stderr, got %q", errOut.String())
+	}
+
+	out.Reset()
+	err = c.Execute([]string{"api", "resolve", "--spec-file", specPath, "--json", "listProjects"})
+	requireUsageExitCode(t, err)
+	var payload struct {
+		OK         bool                `json:"ok"`
+		Code       int                 `json:"code"`
+		Candidates []resolvedOperation `json:"candidates"`
+	}
+	if err := json.Unmarshal(out.Bytes(), &payload); err != nil {
+		t.Fatalf("decode json: %v", err)
+	}
+	if payload.OK || payload.Code != 2 || len(payload.Candidates) != 2 {
+		t.Fatalf("unexpected payload %+v", payload)
+	}
+}
+
+func TestCallExplainOpSkipsRequest(t *testing.T) {
+	t.Parallel()
+
+	specPath := writeCallOpSpec(t, callOpSpecFixture)
+
+	var out, errOut bytes.Buffer
+	c := newAdminWrapperTestCLI(offlineTestClient())
+	c.Out = &out
+	c.Err = &errOut
+	err := c.Execute([]string{
+		"call",
+		"--gateway-url", mockGatewayURL,
+		"--api-key", "secret",
+		"--spec-file", specPath,
+		"--op", "scanProjects",
+		"--explain-op",
+	})
+	if err != nil {
+		t.Fatalf("call --explain-op failed: %v", err)
+	}
+	if got := out.String(); got != "POST /data/api/v1/scan/projects\n" {
+		t.Fatalf("unexpected output %q", got)
+	}
+
+	err = c.Execute([]string{
+		"call",
+		"--gateway-url", mockGatewayURL,
+		"--api-key", "secret",
+		"--path", "/data/api/v1/gateway-info",
+		"--explain-op",
+	})
+	requireUsageExitCode(t, err)
+}
@@ -1,10 +1,10 @@
 package cli
 
 import (
-	"bytes"
 	"context"
 	"errors"
 	"fmt"
+	"net/http"
 	"os"
 	"path/filepath"
 	"strings"
@@ -12,6 +12,7 @@ import (
 
 	"github.com/alex-mccollum/igw-cli/internal/apidocs"
 	"github.com/alex-mccollum/igw-cli/internal/config"
+	"github.com/alex-mccollum/igw-cli/internal/exitcode"
 	"github.com/alex-mccollum/igw-cli/internal/gateway"
 	"github.com/alex-mccollum/igw-cli/internal/igwerr"
 )
@@ -29,6 +30,14 @@ type apiSyncRequest struct {
 	Resolved    config.Effective
 	Timeout     time.Duration
 	OpenAPIPath string
+	Signer      *gateway.RequestSigner
+	Proxy       gateway.Proxy
+	// HTTP carries the TLS settings; nil uses the shared client.
+	HTTP *http.Client
+	// Check reports whether the spec changed without writing anything.
+	Check bool
+	// Pin, when set, refuses a fetched spec with a different SHA-256.
+	Pin string
 }
 
 type apiSyncResult struct {
@@ -38,6 +47,38 @@ type apiSyncResult struct {
 	Bytes          int
 	Changed        bool
 	AttemptedPaths []string
+	GatewayVersion string
+	Hash           string
+	PreviousHash   string
+	// NotModified is set when the gateway answered a conditional fetch
+	// with 304.
+	NotModified bool
+}
+
+// openAPIFetch is the outcome of fetchOpenAPISpec.
+type openAPIFetch struct {
+	Body           []byte
+	Path           string
+	OperationCount int
+	Attempted      []string
+	NotModified    bool
+	ETag           string
+	LastModified   string
+}
+
+// specPinError reports a fetched spec whose hash differs from --pin. It
+// exits 2 like other usage errors; the local spec is left unchanged.
+type specPinError struct {
+	Pin  string
+	Hash string
+}
+
+func (e *specPinError) Error() string {
+	return fmt.Sprintf("fetched spec sha256 %s does not match --pin %s; local spec left unchanged", e.Hash, e.Pin)
+}
+
+func (e *specPinError) ExitCode() int {
+	return exitcode.Usage
 }
 
 type apiSyncRuntime struct {
@@ -45,9 +86,18 @@ type apiSyncRuntime struct {
 	GatewayURL string
 	APIKey     string
 	Timeout    time.Duration
+	Signer     *gateway.RequestSigner
+	// Proxy and NoProxy are the --proxy and --no-proxy flag values.
+	Proxy   string
+	NoProxy bool
+	TLS     tlsFlags
 }
 
 func (c *CLI) loadAPIOperations(specFile string, runtime apiSyncRuntime) ([]apidocs.Operation, error) {
+	if specFiles := splitSpecFiles(specFile); len(specFiles) > 1 {
+		return c.loadMergedAPIOperations(specFiles)
+	}
+
 	ops, resolvedSpecFile, candidates, err := c.loadCachedAPIOperations(specFile)
 	if err == nil {
 		return ops, nil
@@ -68,9 +118,20 @@ func (c *CLI) loadAPIOperations(specFile string, runtime apiSyncRuntime) ([]apid
 		}
 	}
 
+	proxy, proxyErr := resolveClientProxy(runtime.Proxy, runtime.NoProxy, resolved)
+	if proxyErr != nil {
+		return nil, proxyErr
+	}
+	httpClient, httpErr := c.gatewayHTTPClient(runtime.TLS, resolved)
+	if httpErr != nil {
+		return nil, httpErr
+	}
 	_, syncErr := c.syncOpenAPISpec(apiSyncRequest{
 		Resolved: resolved,
 		Timeout:  runtime.Timeout,
+		Signer:   runtime.Signer,
+		Proxy:    proxy,
+		HTTP:     httpClient,
 	})
 	if syncErr != nil {
 		return nil, &igwerr.UsageError{
@@ -102,31 +163,84 @@ func (c *CLI) syncOpenAPISpec(req apiSyncRequest) (apiSyncResult, error) {
 	}
 
 	paths := candidateOpenAPIPaths(req.OpenAPIPath)
+	httpClient := req.HTTP
+	if httpClient == nil {
+		httpClient = c.runtimeHTTPClient()
+	}
+	defaultHeaders, err := configDefaultHeaders(req.Resolved)
+	if err != nil {
+		return apiSyncResult{}, err
+	}
 	client := &gateway.Client{
-		BaseURL: req.Resolved.GatewayURL,
-		Token:   req.Resolved.Token,
-		HTTP:    c.runtimeHTTPClient(),
+		BaseURL:        req.Resolved.GatewayURL,
+		Token:          req.Resolved.Token,
+		HTTP:           httpClient,
+		Signer:         req.Signer,
+		Proxy:          req.Proxy,
+		DefaultHeaders: defaultHeaders,
+	}
+
+	cfgDir, err := config.Dir()
+	if err != nil {
+		return apiSyncResult{}, &igwerr.UsageError{Msg: err.Error()}
+	}
+	specPath := filepath.Join(cfgDir, apidocs.DefaultSpecFile)
+
+	// A conditional fetch is only safe while the local file is the one the
+	// stored validators describe.
+	previousHash := ""
+	existing, readErr := os.ReadFile(specPath) //nolint:gosec // fixed spec path under the config dir
+	var conditional []string
+	if readErr == nil {
+		previousHash = apidocs.HashSpec(existing)
+		if meta, metaErr := apidocs.LoadSyncMeta(apidocs.SyncMetaPathForSpec(specPath)); metaErr == nil && meta.Hash == previousHash {
+			if meta.ETag != "" {
+				conditional = append(conditional, "If-None-Match: "+meta.ETag)
+			}
+			if meta.LastModified != "" {
+				conditional = append(conditional, "If-Modified-Since: "+meta.LastModified)
+			}
+		}
 	}
 
-	specBody, sourcePath, operationCount, attemptedPaths, fetchErr := fetchOpenAPISpec(context.Background(), client, req.Timeout, paths)
+	fetched, fetchErr := fetchOpenAPISpec(context.Background(), client, req.Timeout, paths, conditional)
 	if fetchErr != nil {
 		return apiSyncResult{}, fetchErr
 	}
+	specBody := fetched.Body
+	if fetched.NotModified {
+		specBody = existing
+		fetched.OperationCount, _ = validateOpenAPISpecBody(existing)
+	}
 
-	cfgDir, err := config.Dir()
-	if err != nil {
-		return apiSyncResult{}, &igwerr.UsageError{Msg: err.Error()}
+	hash := apidocs.HashSpec(specBody)
+	if req.Pin != "" && hash != req.Pin {
+		return apiSyncResult{}, &specPinError{Pin: req.Pin, Hash: hash}
 	}
-	if err := os.MkdirAll(cfgDir, 0o700); err != nil {
-		return apiSyncResult{}, &igwerr.UsageError{Msg: fmt.Sprintf("create config dir: %v", err)}
+
+	sourceURL, joinErr := gateway.JoinURL(req.Resolved.GatewayURL, fetched.Path)
+	if joinErr != nil {
+		sourceURL = strings.TrimRight(req.Resolved.GatewayURL, "/") + fetched.Path
+	}
+	result := apiSyncResult{
+		SpecPath:       specPath,
+		SourceURL:      sourceURL,
+		OperationCount: fetched.OperationCount,
+		Bytes:          len(specBody),
+		Changed:        hash != previousHash,
+		AttemptedPaths: fetched.Attempted,
+		Hash:           hash,
+		PreviousHash:   previousHash,
+		NotModified:    fetched.NotModified,
+	}
+	if req.Check {
+		return result, nil
 	}
 
-	specPath := filepath.Join(cfgDir, apidocs.DefaultSpecFile)
-	changed := true
-	if existing, readErr := os.ReadFile(specPath); readErr == nil && bytes.Equal(existing, specBody) {
-		changed = false
+	if err := os.MkdirAll(cfgDir, 0o700); err != nil {
+		return apiSyncResult{}, &igwerr.UsageError{Msg: fmt.Sprintf("create config dir: %v", err)}
 	}
-	if changed {
+	if result.Changed {
 		tmpPath := specPath + ".tmp"
 		if err := os.WriteFile(tmpPath, specBody, 0o600); err != nil {
 			return apiSyncResult{}, &igwerr.UsageError{Msg: fmt.Sprintf("write spec temp: %v", err)}
@@ -137,19 +251,17 @@ func (c *CLI) syncOpenAPISpec(req apiSyncRequest) (apiSyncResult, error) {
 	}
 	c.invalidateRuntimeCaches()
 
-	sourceURL, joinErr := gateway.JoinURL(req.Resolved.GatewayURL, sourcePath)
-	if joinErr != nil {
-		sourceURL = strings.TrimRight(req.Resolved.GatewayURL, "/") + sourcePath
+	version, err := recordSpecSyncMeta(context.Background(), client, req.Timeout, specPath, apidocs.SyncMeta{
+		SourceURL:    sourceURL,
+		Hash:         hash,
+		ETag:         fetched.ETag,
+		LastModified: fetched.LastModified,
+	})
+	if err != nil {
+		return apiSyncResult{}, err
 	}
-
-	return apiSyncResult{
-		SpecPath:       specPath,
-		SourceURL:      sourceURL,
-		OperationCount: operationCount,
-		Bytes:          len(specBody),
-		Changed:        changed,
-		AttemptedPaths: attemptedPaths,
-	}, nil
+	result.GatewayVersion = version
+	return result, nil
 }
 
 func candidateOpenAPIPaths(explicit string) []string {
@@ -166,7 +278,10 @@ func candidateOpenAPIPaths(explicit string) []string {
 	return out
 }
 
-func fetchOpenAPISpec(ctx context.Context, client *gateway.Client, timeout time.Duration, paths []string) ([]byte, string, int, []string, error) {
+// fetchOpenAPISpec tries each candidate path in order. conditional headers
+// (If-None-Match, If-Modified-Since) let the gateway answer 304, reported
+// as NotModified without a body.
+func fetchOpenAPISpec(ctx context.Context, client *gateway.Client, timeout time.Duration, paths []string, conditional []string) (openAPIFetch, error) {
 	attempted := make([]string, 0, len(paths))
 	var firstErr error
 
@@ -180,13 +295,17 @@ func fetchOpenAPISpec(ctx context.Context, client *gateway.Client, timeout time.
 		resp, err := client.Call(ctx, gateway.CallRequest{
 			Method:  "GET",
 			Path:    candidate,
+			Headers: conditional,
 			Timeout: timeout,
+			AcceptStatus: func(status int) bool {
+				return (status >= 200 && status < 300) || (len(conditional) > 0 && status == http.StatusNotModified)
+			},
 		})
 		if err != nil {
 			var statusErr *igwerr.StatusError
 			if errors.As(err, &statusErr) {
 				if statusErr.AuthFailure() {
-					return nil, "", 0, attempted, err
+					return openAPIFetch{Attempted: attempted}, err
 				}
 				if statusErr.StatusCode == 404 || statusErr.StatusCode == 405 {
 					if firstErr == nil {
@@ -198,7 +317,7 @@ func fetchOpenAPISpec(ctx context.Context, client *gateway.Client, timeout time.
 
 			var transportErr *igwerr.TransportError
 			if errors.As(err, &transportErr) {
-				return nil, "", 0, attempted, err
+				return openAPIFetch{Attempted: attempted}, err
 			}
 
 			if firstErr == nil {
@@ -207,6 +326,10 @@ func fetchOpenAPISpec(ctx context.Context, client *gateway.Client, timeout time.
 			continue
 		}
 
+		if resp.StatusCode == http.StatusNotModified {
+			return openAPIFetch{Path: candidate, Attempted: attempted, NotModified: true}, nil
+		}
+
 		operationCount, validateErr := validateOpenAPISpecBody(resp.Body)
 		if validateErr != nil {
 			if firstErr == nil {
@@ -217,16 +340,23 @@ func fetchOpenAPISpec(ctx context.Context, client *gateway.Client, timeout time.
 			continue
 		}
 
-		return resp.Body, candidate, operationCount, attempted, nil
+		return openAPIFetch{
+			Body:           resp.Body,
+			Path:           candidate,
+			OperationCount: operationCount,
+			Attempted:      attempted,
+			ETag:           resp.Headers.Get("ETag"),
+			LastModified:   resp.Headers.Get("Last-Modified"),
+		}, nil
 	}
 
 	if firstErr != nil {
-		return nil, "", 0, attempted, &igwerr.UsageError{
+		return openAPIFetch{Attempted: attempted}, &igwerr.UsageError{
 			Msg: fmt.Sprintf("failed to fetch OpenAPI spec from known endpoints (%s): %v", strings.Join(attempted, ", "), firstErr),
 		}
 	}
 
-	return nil, "", 0, attempted, &igwerr.UsageError{Msg: "failed to fetch OpenAPI spec: no endpoints attempted"}
+	return openAPIFetch{Attempted: attempted}, &igwerr.UsageError{Msg: "failed to fetch OpenAPI spec: no endpoints attempted"}
 }
 
 func validateOpenAPISpecBody(body []byte) (int, error) {
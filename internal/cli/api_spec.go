@@ -43,6 +43,28 @@ func openAPILoadError(resolvedSpecFile string, candidates []string, err error) e
 	return nil
 }
 
+// writeOperationSpecSubset exports the resolved operations and their $ref
+// dependencies from the active spec file, or the merged spec list, to
+// outPath.
+func writeOperationSpecSubset(specFile string, ops []apidocs.Operation, outPath string) error {
+	specFiles := splitSpecFiles(specFile)
+	resolved := make([]string, 0, len(specFiles))
+	for _, file := range specFiles {
+		resolvedSpecFile, _ := resolveSpecFile(file)
+		resolved = append(resolved, resolvedSpecFile)
+	}
+	var err error
+	if len(resolved) == 1 {
+		err = apidocs.WriteSubset(resolved[0], ops, strings.TrimSpace(outPath))
+	} else {
+		err = apidocs.WriteMergedSubset(resolved, ops, strings.TrimSpace(outPath))
+	}
+	if err != nil {
+		return &igwerr.UsageError{Msg: fmt.Sprintf("--write-spec-to: %v", err)}
+	}
+	return nil
+}
+
 func formatOperationMatches(ops []apidocs.Operation) string {
 	if len(ops) == 0 {
 		return ""
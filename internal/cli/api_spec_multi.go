@@ -0,0 +1,87 @@
+package cli
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/alex-mccollum/igw-cli/internal/apidocs"
+)
+
+// splitSpecFiles splits a comma-separated --spec-file value into its paths.
+// A single path (or the default) comes back as one entry.
+func splitSpecFiles(specFile string) []string {
+	if !strings.Contains(specFile, ",") {
+		return []string{specFile}
+	}
+	parts := strings.Split(specFile, ",")
+	files := make([]string, 0, len(parts))
+	for _, part := range parts {
+		if part = strings.TrimSpace(part); part != "" {
+			files = append(files, part)
+		}
+	}
+	return files
+}
+
+// loadMergedAPIOperations loads every spec file and merges them into one
+// operation set. Operations are de-duplicated by method and path (the first
+// file wins), and an operationId reused for a different endpoint in a later
+// file prints a warning because --op resolution becomes ambiguous.
+func (c *CLI) loadMergedAPIOperations(specFiles []string) ([]apidocs.Operation, error) {
+	sources := make([][]apidocs.Operation, 0, len(specFiles))
+	for _, specFile := range specFiles {
+		ops, resolvedSpecFile, candidates, err := c.loadCachedAPIOperations(specFile)
+		if err != nil {
+			return nil, openAPILoadError(resolvedSpecFile, candidates, err)
+		}
+		sources = append(sources, ops)
+	}
+
+	ops, warnings := mergeOperationSets(specFiles, sources)
+	for _, warning := range warnings {
+		fmt.Fprintf(c.Err, "warning: %s\n", warning)
+	}
+	return ops, nil
+}
+
+func mergeOperationSets(specFiles []string, sources [][]apidocs.Operation) ([]apidocs.Operation, []string) {
+	type origin struct {
+		file     string
+		endpoint string
+	}
+
+	var merged []apidocs.Operation
+	var warnings []string
+	seenEndpoints := make(map[string]bool)
+	operationIDs := make(map[string]origin)
+	for i, ops := range sources {
+		for _, op := range ops {
+			endpoint := strings.ToUpper(op.Method) + " " + op.Path
+			if seenEndpoints[endpoint] {
+				continue
+			}
+			seenEndpoints[endpoint] = true
+
+			if id := strings.TrimSpace(op.OperationID); id != "" {
+				if first, ok := operationIDs[id]; ok && first.file != specFiles[i] {
+					warnings = append(warnings, fmt.Sprintf(
+						"operationId %q is defined in %s (%s) and %s (%s)",
+						id, first.file, first.endpoint, specFiles[i], endpoint,
+					))
+				} else if !ok {
+					operationIDs[id] = origin{file: specFiles[i], endpoint: endpoint}
+				}
+			}
+			merged = append(merged, op)
+		}
+	}
+
+	sort.SliceStable(merged, func(i, j int) bool {
+		if merged[i].Path == merged[j].Path {
+			return merged[i].Method < merged[j].Method
+		}
+		return merged[i].Path < merged[j].Path
+	})
+	return merged, warnings
+}
@@ -0,0 +1,168 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/alex-mccollum/igw-cli/internal/apidocs"
+	"github.com/alex-mccollum/igw-cli/internal/config"
+)
+
+// moduleOpSpecFixture shares GET /data/api/v1/gateway-info with
+// callOpSpecFixture and reuses the scanProjects operationId for a different
+// endpoint.
+const moduleOpSpecFixture = `{
+  "openapi": "3.0.0",
+  "paths": {
+    "/data/api/v1/gateway-info": {
+      "get": {"operationId": "gatewayInfo"}
+    },
+    "/data/perspective/api/v1/scan/projects": {
+      "post": {"operationId": "scanProjects"}
+    },
+    "/data/perspective/api/v1/sessions": {
+      "get": {"operationId": "listSessions"}
+    }
+  }
+}`
+
+func TestAPIListMergesMultipleSpecFiles(t *testing.T) {
+	t.Parallel()
+
+	coreSpec := writeCallOpSpec(t, callOpSpecFixture)
+	moduleSpec := writeCallOpSpec(t, moduleOpSpecFixture)
+
+	var out, errOut bytes.Buffer
+	c := &CLI{
+		In:     strings.NewReader(""),
+		Out:    &out,
+		Err:    &errOut,
+		Getenv: func(string) string { return "" },
+	}
+	if err := c.Execute([]string{"api", "list", "--spec-file", coreSpec + "," + moduleSpec, "--json"}); err != nil {
+		t.Fatalf("api list failed: %v", err)
+	}
+
+	var payload struct {
+		Count int `json:"count"`
+	}
+	if err := json.Unmarshal(out.Bytes(), &payload); err != nil {
+		t.Fatalf("decode json: %v", err)
+	}
+	if payload.Count != 4 {
+		t.Fatalf("expected 4 merged operations, got %d:\n%s", payload.Count, out.String())
+	}
+
+	warnings := errOut.String()
+	if strings.Count(warnings, "warning:") != 1 || !strings.Contains(warnings, `operationId "scanProjects" is defined in`) {
+		t.Fatalf("expected one scanProjects collision warning, got %q", warnings)
+	}
+}
+
+func TestCallOperationIDResolvesAcrossSpecFiles(t *testing.T) {
+	t.Parallel()
+
+	coreSpec := writeCallOpSpec(t, callOpSpecFixture)
+	moduleSpec := writeCallOpSpec(t, moduleOpSpecFixture)
+
+	var gotPath string
+	c := &CLI{
+		In:     strings.NewReader(""),
+		Out:    new(bytes.Buffer),
+		Err:    new(bytes.Buffer),
+		Getenv: func(string) string { return "" },
+		ReadConfig: func() (config.File, error) {
+			return config.File{}, nil
+		},
+		HTTPClient: newMockHTTPClient(func(r *http.Request) (*http.Response, error) {
+			gotPath = r.URL.Path
+			return mockHTTPResponse(http.StatusOK, `[]`, nil), nil
+		}),
+	}
+	err := c.Execute([]string{
+		"call",
+		"--gateway-url", mockGatewayURL,
+		"--api-key", "secret",
+		"--spec-file", coreSpec + "," + moduleSpec,
+		"--op", "listSessions",
+	})
+	if err != nil {
+		t.Fatalf("call failed: %v", err)
+	}
+	if gotPath != "/data/perspective/api/v1/sessions" {
+		t.Fatalf("unexpected path %q", gotPath)
+	}
+}
+
+func TestWriteSpecToMergesSpecFileList(t *testing.T) {
+	t.Parallel()
+
+	specList := writeCallOpSpec(t, callOpSpecFixture) + "," + writeCallOpSpec(t, moduleOpSpecFixture)
+	c := newAdminWrapperTestCLI(newMockHTTPClient(func(*http.Request) (*http.Response, error) {
+		return mockHTTPResponse(http.StatusOK, `[]`, nil), nil
+	}))
+
+	callOut := filepath.Join(t.TempDir(), "call-subset.json")
+	if err := c.Execute([]string{
+		"call",
+		"--gateway-url", mockGatewayURL,
+		"--api-key", "secret",
+		"--spec-file", specList,
+		"--op", "listSessions",
+		"--write-spec-to", callOut,
+	}); err != nil {
+		t.Fatalf("call --write-spec-to failed: %v", err)
+	}
+	showOut := filepath.Join(t.TempDir(), "show-subset.json")
+	if err := c.Execute([]string{"api", "show", "--spec-file", specList, "--path", "/data/api/v1/gateway-info", "--write-spec-to", showOut}); err != nil {
+		t.Fatalf("api show --write-spec-to failed: %v", err)
+	}
+
+	for path, want := range map[string]string{callOut: "listSessions", showOut: "gatewayInfo"} {
+		ops, err := apidocs.LoadOperations(path)
+		if err != nil {
+			t.Fatalf("load written spec: %v", err)
+		}
+		if len(ops) != 1 || ops[0].OperationID != want {
+			t.Fatalf("%s: expected only %s, got %+v", filepath.Base(path), want, ops)
+		}
+	}
+}
+
+func TestStrictSpecVersionChecksEverySpecFile(t *testing.T) {
+	t.Parallel()
+
+	coreSpec := writeCallOpSpec(t, callOpSpecFixture)
+	moduleSpec := writeCallOpSpec(t, moduleOpSpecFixture)
+	run := func(moduleVersion string) error {
+		for spec, version := range map[string]string{coreSpec: "8.3.1", moduleSpec: moduleVersion} {
+			if err := apidocs.WriteSyncMeta(apidocs.SyncMetaPathForSpec(spec), apidocs.SyncMeta{GatewayVersion: version}); err != nil {
+				t.Fatalf("write sync meta: %v", err)
+			}
+		}
+		c := newAdminWrapperTestCLI(newMockHTTPClient(func(*http.Request) (*http.Response, error) {
+			return mockHTTPResponse(http.StatusOK, `{"version":"8.3.1"}`, nil), nil
+		}))
+		return c.Execute([]string{
+			"call",
+			"--gateway-url", mockGatewayURL,
+			"--api-key", "secret",
+			"--spec-file", coreSpec + "," + moduleSpec,
+			"--op", "listSessions",
+			"--strict-spec-version",
+		})
+	}
+
+	if err := run("8.3.1"); err != nil {
+		t.Fatalf("expected matching spec versions to pass, got %v", err)
+	}
+	err := run("8.1.40")
+	requireUsageExitCode(t, err)
+	if !strings.Contains(err.Error(), moduleSpec) || !strings.Contains(err.Error(), "8.1.40") {
+		t.Fatalf("expected the stale module spec to be named, got %v", err)
+	}
+}
@@ -0,0 +1,93 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/alex-mccollum/igw-cli/internal/apidocs"
+	"github.com/alex-mccollum/igw-cli/internal/config"
+	"github.com/alex-mccollum/igw-cli/internal/igwerr"
+)
+
+type apiSyncCheckPayload struct {
+	SpecPath     string `json:"specPath"`
+	Changed      bool   `json:"changed"`
+	Hash         string `json:"hash"`
+	PreviousHash string `json:"previousHash"`
+	NotModified  bool   `json:"notModified"`
+}
+
+func TestAPISyncCheckAndPin(t *testing.T) {
+	setIsolatedConfigDir(t)
+
+	spec := apiSpecFixture
+	var conditional []string
+	client := newMockHTTPClient(func(r *http.Request) (*http.Response, error) {
+		if r.URL.Path != "/openapi" {
+			return mockHTTPResponse(http.StatusOK, `{"version":"8.1.40"}`, nil), nil
+		}
+		if match := r.Header.Get("If-None-Match"); match != "" {
+			conditional = append(conditional, match)
+			if match == `"v1"` && spec == apiSpecFixture {
+				return mockHTTPResponse(http.StatusNotModified, "", nil), nil
+			}
+		}
+		return mockHTTPResponse(http.StatusOK, spec, http.Header{"Etag": []string{`"v1"`}}), nil
+	})
+
+	out := new(bytes.Buffer)
+	c := &CLI{
+		In:         strings.NewReader(""),
+		Out:        out,
+		Err:        new(bytes.Buffer),
+		Getenv:     func(string) string { return "" },
+		ReadConfig: func() (config.File, error) { return config.File{}, nil },
+		HTTPClient: client,
+	}
+	sync := func(extra ...string) (apiSyncCheckPayload, error) {
+		out.Reset()
+		err := c.Execute(append([]string{"api", "sync", "--gateway-url", mockGatewayURL, "--api-key", "secret", "--json"}, extra...))
+		var payload apiSyncCheckPayload
+		_ = json.Unmarshal(out.Bytes(), &payload)
+		return payload, err
+	}
+
+	first, err := sync()
+	if err != nil || !first.Changed || first.Hash != apidocs.HashSpec([]byte(apiSpecFixture)) || first.PreviousHash != "" {
+		t.Fatalf("first sync: %+v (%v)", first, err)
+	}
+
+	checked, err := sync("--check")
+	if err != nil || checked.Changed || !checked.NotModified || checked.PreviousHash != first.Hash {
+		t.Fatalf("expected an unchanged 304 check, got %+v (%v)", checked, err)
+	}
+	if len(conditional) != 1 {
+		t.Fatalf("expected a conditional request, got %v", conditional)
+	}
+
+	spec = strings.Replace(apiSpecFixture, "Gateway info", "Gateway information", 1)
+	checked, err = sync("--check")
+	if err != nil || !checked.Changed || checked.Hash == first.Hash {
+		t.Fatalf("expected a changed check, got %+v (%v)", checked, err)
+	}
+	if raw, _ := os.ReadFile(first.SpecPath); string(raw) != apiSpecFixture {
+		t.Fatalf("--check must not rewrite the spec")
+	}
+
+	_, err = sync("--pin", first.Hash)
+	if err == nil || igwerr.ExitCode(err) != 2 || !strings.Contains(out.String(), `"pin": "`+first.Hash+`"`) {
+		t.Fatalf("expected a pin mismatch error, got %v\n%s", err, out.String())
+	}
+	if raw, _ := os.ReadFile(first.SpecPath); string(raw) != apiSpecFixture {
+		t.Fatalf("a pin mismatch must leave the spec unchanged")
+	}
+
+	if _, err := sync("--pin", "sha256:"+apidocs.HashSpec([]byte(spec))); err != nil {
+		t.Fatalf("matching pin: %v", err)
+	}
+	requireUsageExitCode(t, c.Execute([]string{"api", "sync", "--gateway-url", mockGatewayURL, "--api-key", "secret", "--pin", "abc"}))
+}
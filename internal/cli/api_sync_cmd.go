@@ -1,6 +1,8 @@
 package cli
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"flag"
 	"fmt"
 	"io"
@@ -28,8 +30,12 @@ func (c *CLI) runAPISyncLike(args []string, mode string) error {
 
 	var common wrapperCommon
 	var openAPIPath string
+	var check bool
+	var pin string
 	bindWrapperCommonWithDefaults(fs, &common, 8*time.Second, false)
 	fs.StringVar(&openAPIPath, "openapi-path", "", "Override OpenAPI endpoint path (default: auto-detect)")
+	fs.BoolVar(&check, "check", false, "Report whether the gateway spec changed without writing the local spec")
+	fs.StringVar(&pin, "pin", "", "Refuse to save a fetched spec whose SHA-256 differs from this hex digest")
 
 	if err := fs.Parse(args); err != nil {
 		return c.printAPISyncError(jsonRequested, jsonSelectOptions{}, &igwerr.UsageError{Msg: err.Error()})
@@ -38,32 +44,47 @@ func (c *CLI) runAPISyncLike(args []string, mode string) error {
 		return c.printAPISyncError(common.jsonOutput, jsonSelectOptions{}, &igwerr.UsageError{Msg: "unexpected positional arguments"})
 	}
 
-	selectOpts, selectErr := newJSONSelectOptions(common.jsonOutput, common.compactJSON, common.rawOutput, common.selectors)
+	pin, pinErr := normalizeSpecPin(pin)
+	if pinErr != nil {
+		return c.printAPISyncError(common.jsonOutput, jsonSelectOptions{}, pinErr)
+	}
+
+	selectOpts, selectErr := common.selectOptions()
 	if selectErr != nil {
 		return c.printAPISyncError(common.jsonOutput, selectionErrorOptions(selectOpts), selectErr)
 	}
 
-	if common.apiKeyStdin {
-		if common.apiKey != "" {
-			return c.printAPISyncError(common.jsonOutput, selectOpts, &igwerr.UsageError{Msg: "use only one of --api-key or --api-key-stdin"})
-		}
-		tokenBytes, err := io.ReadAll(c.In)
-		if err != nil {
-			return c.printAPISyncError(common.jsonOutput, selectOpts, igwerr.NewTransportError(err))
-		}
-		common.apiKey = strings.TrimSpace(string(tokenBytes))
+	if err := c.readCredentials(&common); err != nil {
+		return c.printAPISyncError(common.jsonOutput, selectOpts, err)
+	}
+	signer, err := common.requestSigner()
+	if err != nil {
+		return c.printAPISyncError(common.jsonOutput, selectOpts, err)
 	}
 
 	resolved, err := c.resolveRuntimeConfig(common.profile, common.gatewayURL, common.apiKey)
 	if err != nil {
 		return c.printAPISyncError(common.jsonOutput, selectOpts, err)
 	}
+	proxy, err := common.clientProxy(resolved)
+	if err != nil {
+		return c.printAPISyncError(common.jsonOutput, selectOpts, err)
+	}
+	httpClient, err := c.gatewayHTTPClient(common.tls, resolved)
+	if err != nil {
+		return c.printAPISyncError(common.jsonOutput, selectOpts, err)
+	}
 
 	start := time.Now()
 	result, err := c.syncOpenAPISpec(apiSyncRequest{
 		Resolved:    resolved,
 		Timeout:     common.timeout,
 		OpenAPIPath: strings.TrimSpace(openAPIPath),
+		Signer:      signer,
+		Proxy:       proxy,
+		HTTP:        httpClient,
+		Check:       check,
+		Pin:         pin,
 	})
 	if err != nil {
 		return c.printAPISyncError(common.jsonOutput, selectOpts, err)
@@ -79,6 +100,19 @@ func (c *CLI) runAPISyncLike(args []string, mode string) error {
 			"bytes":          result.Bytes,
 			"changed":        result.Changed,
 			"attemptedPaths": result.AttemptedPaths,
+			"hash":           result.Hash,
+		}
+		if result.PreviousHash != "" {
+			payload["previousHash"] = result.PreviousHash
+		}
+		if result.NotModified {
+			payload["notModified"] = true
+		}
+		if check {
+			payload["check"] = true
+		}
+		if result.GatewayVersion != "" {
+			payload["gatewayVersion"] = result.GatewayVersion
 		}
 		if common.timing || common.jsonStats {
 			payload["stats"] = map[string]any{
@@ -96,6 +130,13 @@ func (c *CLI) runAPISyncLike(args []string, mode string) error {
 	fmt.Fprintf(c.Out, "operations\t%d\n", result.OperationCount)
 	fmt.Fprintf(c.Out, "bytes\t%d\n", result.Bytes)
 	fmt.Fprintf(c.Out, "changed\t%t\n", result.Changed)
+	fmt.Fprintf(c.Out, "hash\t%s\n", result.Hash)
+	if result.PreviousHash != "" {
+		fmt.Fprintf(c.Out, "previous_hash\t%s\n", result.PreviousHash)
+	}
+	if result.GatewayVersion != "" {
+		fmt.Fprintf(c.Out, "gateway_version\t%s\n", result.GatewayVersion)
+	}
 	if common.timing {
 		fmt.Fprintf(c.Err, "timing\telapsedMs=%d\n", elapsedMs)
 	}
@@ -115,3 +156,17 @@ func (c *CLI) printAPISyncError(jsonOutput bool, selectOpts jsonSelectOptions, e
 
 	return err
 }
+
+// normalizeSpecPin accepts a hex SHA-256 digest, optionally prefixed with
+// "sha256:".
+func normalizeSpecPin(pin string) (string, error) {
+	pin = strings.ToLower(strings.TrimSpace(pin))
+	if pin == "" {
+		return "", nil
+	}
+	pin = strings.TrimPrefix(pin, "sha256:")
+	if decoded, err := hex.DecodeString(pin); err != nil || len(decoded) != sha256.Size {
+		return "", &igwerr.UsageError{Msg: "--pin must be a 64-character hex SHA-256 digest"}
+	}
+	return pin, nil
+}
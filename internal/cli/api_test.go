@@ -338,3 +338,72 @@ func writeAPISpec(t *testing.T, content string) string {
 
 	return path
 }
+
+func TestAPIShowIncludeResponses(t *testing.T) {
+	t.Parallel()
+
+	specPath := writeAPISpec(t, `{
+  "openapi": "3.0.0",
+  "paths": {
+    "/data/api/v1/projects": {
+      "post": {
+        "operationId": "createProject",
+        "responses": {
+          "200": {"description": "Project created"},
+          "400": {"description": "Invalid project definition"},
+          "403": {"description": "Token lacks permission"}
+        }
+      },
+      "get": {"operationId": "listProjects"}
+    }
+  }
+}`)
+
+	var out bytes.Buffer
+	c := &CLI{Out: &out, Err: new(bytes.Buffer)}
+	if err := c.Execute([]string{"api", "show", "--spec-file", specPath, "--path", "/data/api/v1/projects", "--include-responses"}); err != nil {
+		t.Fatalf("api show failed: %v", err)
+	}
+	for _, want := range []string{
+		"response\t200\tProject created\n",
+		"response\t400\tInvalid project definition\n",
+		"response\t403\tToken lacks permission\n",
+		"responses\tnone documented\n",
+	} {
+		if !strings.Contains(out.String(), want) {
+			t.Fatalf("expected %q in output, got %q", want, out.String())
+		}
+	}
+
+	out.Reset()
+	if err := c.Execute([]string{"api", "show", "--spec-file", specPath, "--path", "/data/api/v1/projects", "--include-responses", "--json"}); err != nil {
+		t.Fatalf("api show --json failed: %v", err)
+	}
+	var payload struct {
+		Operations []struct {
+			OperationID string `json:"operationId"`
+			Responses   []struct {
+				Code        string `json:"code"`
+				Description string `json:"description"`
+			} `json:"responses"`
+		} `json:"operations"`
+	}
+	if err := json.Unmarshal(out.Bytes(), &payload); err != nil {
+		t.Fatalf("parse json output: %v", err)
+	}
+	codes := map[string][]string{}
+	for _, op := range payload.Operations {
+		if op.Responses == nil {
+			t.Fatalf("expected responses array for %s, got %s", op.OperationID, out.String())
+		}
+		for _, resp := range op.Responses {
+			codes[op.OperationID] = append(codes[op.OperationID], resp.Code+"="+resp.Description)
+		}
+	}
+	if strings.Join(codes["createProject"], ",") != "200=Project created,400=Invalid project definition,403=Token lacks permission" {
+		t.Fatalf("unexpected createProject responses %v", codes["createProject"])
+	}
+	if len(codes["listProjects"]) != 0 {
+		t.Fatalf("expected no listProjects responses, got %v", codes["listProjects"])
+	}
+}
@@ -0,0 +1,95 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+
+	"github.com/alex-mccollum/igw-cli/internal/exitcode"
+	"github.com/alex-mccollum/igw-cli/internal/igwerr"
+)
+
+// batchCircuitBreaker is --fail-fast-after: once threshold requests in a row
+// fail with a network error, the circuit opens and every later request is
+// skipped without touching the gateway. A nil breaker never opens, so
+// callers can thread it unconditionally.
+type batchCircuitBreaker struct {
+	threshold int
+
+	mu          sync.Mutex
+	consecutive int
+	open        bool
+	executed    int
+	skipped     int
+}
+
+func newBatchCircuitBreaker(threshold int) *batchCircuitBreaker {
+	if threshold <= 0 {
+		return nil
+	}
+	return &batchCircuitBreaker{threshold: threshold}
+}
+
+// allow reports whether the next request may run, counting it as executed
+// or skipped.
+func (b *batchCircuitBreaker) allow() bool {
+	if b == nil {
+		return true
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.open {
+		b.skipped++
+		return false
+	}
+	b.executed++
+	return true
+}
+
+// record feeds the outcome of an executed request to the breaker. Only
+// transport failures count; any other outcome resets the streak.
+func (b *batchCircuitBreaker) record(err error) {
+	if b == nil {
+		return
+	}
+	var transportErr *igwerr.TransportError
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if err == nil || !errors.As(err, &transportErr) {
+		b.consecutive = 0
+		return
+	}
+	b.consecutive++
+	if b.consecutive >= b.threshold {
+		b.open = true
+	}
+}
+
+// skipError is the synthetic error reported for a skipped request.
+func (b *batchCircuitBreaker) skipError() error {
+	return &circuitOpenError{threshold: b.threshold}
+}
+
+// writeSummary prints the executed/skipped counts to w.
+func (b *batchCircuitBreaker) writeSummary(w io.Writer) {
+	if b == nil {
+		return
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	fmt.Fprintf(w, "fail-fast\texecuted=%d\tskipped=%d\n", b.executed, b.skipped)
+}
+
+type circuitOpenError struct {
+	threshold int
+}
+
+func (e *circuitOpenError) Error() string {
+	return fmt.Sprintf("skipped: circuit open after %d consecutive network failures (--fail-fast-after)", e.threshold)
+}
+
+// ExitCode makes skipped requests count as network failures.
+func (e *circuitOpenError) ExitCode() int {
+	return exitcode.Network
+}
@@ -0,0 +1,84 @@
+package cli
+
+import (
+	"bytes"
+	"errors"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/alex-mccollum/igw-cli/internal/config"
+	"github.com/alex-mccollum/igw-cli/internal/exitcode"
+)
+
+func newOfflineGatewayCLI(in string, out, errOut *bytes.Buffer, requests *atomic.Int32) *CLI {
+	return &CLI{
+		In:     strings.NewReader(in),
+		Out:    out,
+		Err:    errOut,
+		Getenv: func(string) string { return "" },
+		ReadConfig: func() (config.File, error) {
+			return config.File{}, nil
+		},
+		HTTPClient: newMockHTTPClient(func(*http.Request) (*http.Response, error) {
+			requests.Add(1)
+			return nil, errors.New("connection refused")
+		}),
+	}
+}
+
+func TestCallBatchFailFastSkipsAfterNetworkFailures(t *testing.T) {
+	t.Parallel()
+
+	items := strings.Repeat(`{"method":"GET","path":"/data/api/v1/gateway-info"}`+"\n", 5)
+	var out, errOut bytes.Buffer
+	var requests atomic.Int32
+	c := newOfflineGatewayCLI(items, &out, &errOut, &requests)
+
+	err := c.Execute([]string{"call", "--gateway-url", mockGatewayURL, "--api-key", "secret", "--batch", "-", "--fail-fast-after", "2"})
+	if code := exitCodeForError(err); code != exitcode.Network {
+		t.Fatalf("expected network exit code, got %d (%v)", code, err)
+	}
+	if got := requests.Load(); got != 2 {
+		t.Fatalf("expected 2 requests before the circuit opened, got %d", got)
+	}
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	if len(lines) != 5 || strings.Contains(lines[1], `"skipped"`) || !strings.Contains(lines[2], `"skipped":true`) || !strings.Contains(lines[4], "circuit open after 2 consecutive network failures") {
+		t.Fatalf("unexpected batch output %q", out.String())
+	}
+	if !strings.Contains(errOut.String(), "fail-fast\texecuted=2\tskipped=3") {
+		t.Fatalf("expected executed/skipped summary, got %q", errOut.String())
+	}
+
+	requireUsageExitCode(t, c.Execute([]string{"call", "--gateway-url", mockGatewayURL, "--api-key", "secret", "--path", "/x", "--fail-fast-after", "2"}))
+}
+
+func TestRPCFailFastSkipsCalls(t *testing.T) {
+	t.Parallel()
+
+	call := `{"id":"c","op":"call","args":{"method":"GET","path":"/data/api/v1/gateway-info"}}` + "\n"
+	var out, errOut bytes.Buffer
+	var requests atomic.Int32
+	c := newOfflineGatewayCLI(strings.Repeat(call, 3), &out, &errOut, &requests)
+
+	if err := c.Execute([]string{"rpc", "--gateway-url", mockGatewayURL, "--api-key", "secret", "--fail-fast-after", "1"}); err != nil {
+		t.Fatalf("rpc: %v", err)
+	}
+	responses := decodeRPCResponses(t, out.String())
+	skipped := 0
+	for _, resp := range responses {
+		if resp["skipped"] == true {
+			skipped++
+			if resp["code"] != float64(exitcode.Network) {
+				t.Fatalf("skipped call should report a network code: %#v", resp)
+			}
+		}
+	}
+	if requests.Load() != 1 || skipped != 2 {
+		t.Fatalf("expected 1 request and 2 skipped calls, got %d and %d: %q", requests.Load(), skipped, out.String())
+	}
+	if !strings.Contains(errOut.String(), "fail-fast\texecuted=1\tskipped=2") {
+		t.Fatalf("expected executed/skipped summary, got %q", errOut.String())
+	}
+}
@@ -0,0 +1,82 @@
+package cli
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/alex-mccollum/igw-cli/internal/igwerr"
+)
+
+// statusRange is an inclusive range of HTTP status codes.
+type statusRange struct {
+	low  int
+	high int
+}
+
+// parseAcceptStatus parses --accept-status: comma-separated classes (2xx),
+// ranges (200-204), and single codes. An empty value returns nil, which
+// keeps the default 2xx success check.
+func parseAcceptStatus(value string) (func(status int) bool, error) {
+	return parseStatusSet("--accept-status", value)
+}
+
+func parseStatusSet(flagName string, value string) (func(status int) bool, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return nil, nil
+	}
+
+	var ranges []statusRange
+	for _, part := range strings.Split(value, ",") {
+		part = strings.ToLower(strings.TrimSpace(part))
+		r, ok := parseStatusRange(part)
+		if !ok {
+			return nil, &igwerr.UsageError{
+				Msg: fmt.Sprintf("invalid %s entry %q (use a class like 2xx, a range like 200-204, or a code)", flagName, part),
+			}
+		}
+		ranges = append(ranges, r)
+	}
+
+	return func(status int) bool {
+		for _, r := range ranges {
+			if status >= r.low && status <= r.high {
+				return true
+			}
+		}
+		return false
+	}, nil
+}
+
+func parseStatusRange(part string) (statusRange, bool) {
+	if len(part) == 3 && strings.HasSuffix(part, "xx") {
+		class := int(part[0] - '0')
+		if class < 1 || class > 5 {
+			return statusRange{}, false
+		}
+		return statusRange{low: class * 100, high: class*100 + 99}, true
+	}
+	if low, high, ok := strings.Cut(part, "-"); ok {
+		lowCode, okLow := parseStatusCode(low)
+		highCode, okHigh := parseStatusCode(high)
+		if !okLow || !okHigh || lowCode > highCode {
+			return statusRange{}, false
+		}
+		return statusRange{low: lowCode, high: highCode}, true
+	}
+	code, ok := parseStatusCode(part)
+	return statusRange{low: code, high: code}, ok
+}
+
+func parseStatusCode(value string) (int, bool) {
+	value = strings.TrimSpace(value)
+	if len(value) != 3 {
+		return 0, false
+	}
+	code, err := strconv.Atoi(value)
+	if err != nil || code < 100 || code > 599 {
+		return 0, false
+	}
+	return code, true
+}
@@ -0,0 +1,63 @@
+package cli
+
+import (
+	"bytes"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/alex-mccollum/igw-cli/internal/config"
+	"github.com/alex-mccollum/igw-cli/internal/igwerr"
+)
+
+func runAcceptStatusCall(t *testing.T, status int, acceptStatus string) error {
+	t.Helper()
+
+	c := &CLI{
+		In:     strings.NewReader(""),
+		Out:    new(bytes.Buffer),
+		Err:    new(bytes.Buffer),
+		Getenv: func(string) string { return "" },
+		ReadConfig: func() (config.File, error) {
+			return config.File{}, nil
+		},
+		HTTPClient: newMockHTTPClient(func(r *http.Request) (*http.Response, error) {
+			return mockHTTPResponse(status, `{}`, nil), nil
+		}),
+	}
+	return c.Execute([]string{
+		"call",
+		"--gateway-url", mockGatewayURL,
+		"--api-key", "secret",
+		"--path", "/data/api/v1/gateway-info",
+		"--accept-status", acceptStatus,
+	})
+}
+
+func TestCallAcceptStatusClass(t *testing.T) {
+	t.Parallel()
+
+	if err := runAcceptStatusCall(t, http.StatusMovedPermanently, "3xx"); err != nil {
+		t.Fatalf("expected 301 to succeed under 3xx, got %v", err)
+	}
+	err := runAcceptStatusCall(t, http.StatusInternalServerError, "2xx,3xx")
+	var statusErr *igwerr.StatusError
+	if !errors.As(err, &statusErr) || statusErr.StatusCode != http.StatusInternalServerError {
+		t.Fatalf("expected 500 status error, got %v", err)
+	}
+	if err := runAcceptStatusCall(t, http.StatusNotFound, "200-204,404"); err != nil {
+		t.Fatalf("expected 404 to succeed when listed, got %v", err)
+	}
+	if err := runAcceptStatusCall(t, http.StatusOK, "3xx"); err == nil {
+		t.Fatalf("expected 200 to fail when only 3xx is accepted")
+	}
+}
+
+func TestCallAcceptStatusInvalidSyntax(t *testing.T) {
+	t.Parallel()
+
+	for _, value := range []string{"6xx", "2xy", "204-200", "20", "abc", "2xx,"} {
+		requireUsageExitCode(t, runAcceptStatusCall(t, http.StatusOK, value))
+	}
+}
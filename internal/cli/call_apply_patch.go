@@ -0,0 +1,51 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/alex-mccollum/igw-cli/internal/gateway"
+	"github.com/alex-mccollum/igw-cli/internal/igwerr"
+	"github.com/alex-mccollum/igw-cli/internal/jsonpatch"
+)
+
+// loadApplyPatch reads and validates --apply-patch up front so a malformed
+// patch fails before any network work.
+func loadApplyPatch(stdin io.Reader, input string) ([]jsonpatch.Operation, error) {
+	if strings.TrimSpace(input) == "" {
+		return nil, nil
+	}
+	raw, err := readBody(stdin, input)
+	if err != nil {
+		return nil, err
+	}
+	ops, err := jsonpatch.Decode(raw)
+	if err != nil {
+		return nil, &igwerr.UsageError{Msg: fmt.Sprintf("invalid --apply-patch: %v", err)}
+	}
+	return ops, nil
+}
+
+// fetchPatchedBody GETs the current resource with fetch and returns it with
+// ops applied. Fetch failures keep their own exit codes; a patch that does not
+// apply, including a failed "test" op, is a usage error.
+func fetchPatchedBody(client *gateway.Client, fetch callExecutionInput, ops []jsonpatch.Operation) ([]byte, error) {
+	fetch.Method = http.MethodGet
+	resp, _, _, err := executeCallCore(client, fetch)
+	if err != nil {
+		return nil, err
+	}
+
+	patched, err := jsonpatch.Apply(resp.Body, ops)
+	if err != nil {
+		var testErr *jsonpatch.TestFailedError
+		if errors.As(err, &testErr) {
+			return nil, &igwerr.UsageError{Msg: fmt.Sprintf("--apply-patch aborted: %v", testErr)}
+		}
+		return nil, &igwerr.UsageError{Msg: fmt.Sprintf("--apply-patch: %v", err)}
+	}
+	return patched, nil
+}
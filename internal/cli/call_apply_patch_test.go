@@ -0,0 +1,108 @@
+package cli
+
+import (
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestCallApplyPatchReplacesFetchedResource(t *testing.T) {
+	t.Parallel()
+
+	var requests []string
+	var gotBody []byte
+	client := newMockHTTPClient(func(r *http.Request) (*http.Response, error) {
+		requests = append(requests, r.Method+" "+r.URL.Path)
+		if r.Method == http.MethodGet {
+			return mockHTTPResponse(http.StatusOK, `{"name":"demo","enabled":false,"version":3}`, nil), nil
+		}
+		gotBody, _ = io.ReadAll(r.Body)
+		return mockHTTPResponse(http.StatusOK, `{"ok":true}`, nil), nil
+	})
+
+	patchFile := filepath.Join(t.TempDir(), "patch.json")
+	patch := `[{"op":"test","path":"/version","value":3},{"op":"replace","path":"/enabled","value":true}]`
+	if err := os.WriteFile(patchFile, []byte(patch), 0o600); err != nil {
+		t.Fatalf("write patch: %v", err)
+	}
+
+	c := newAdminWrapperTestCLI(client)
+	if err := c.Execute([]string{
+		"call",
+		"--gateway-url", mockGatewayURL,
+		"--api-key", "secret",
+		"--method", "PUT",
+		"--path", "/data/api/v1/projects/demo",
+		"--fetch-path", "/data/api/v1/projects/demo/config",
+		"--apply-patch", "@" + patchFile,
+		"--yes",
+	}); err != nil {
+		t.Fatalf("call failed: %v", err)
+	}
+
+	if strings.Join(requests, ",") != "GET /data/api/v1/projects/demo/config,PUT /data/api/v1/projects/demo" {
+		t.Fatalf("unexpected request sequence %v", requests)
+	}
+	if string(gotBody) != `{"enabled":true,"name":"demo","version":3}` {
+		t.Fatalf("unexpected patched body %s", gotBody)
+	}
+}
+
+func TestCallApplyPatchFailingTestAborts(t *testing.T) {
+	t.Parallel()
+
+	var methods []string
+	client := newMockHTTPClient(func(r *http.Request) (*http.Response, error) {
+		methods = append(methods, r.Method)
+		return mockHTTPResponse(http.StatusOK, `{"version":4}`, nil), nil
+	})
+
+	c := newAdminWrapperTestCLI(client)
+	err := c.Execute([]string{
+		"call",
+		"--gateway-url", mockGatewayURL,
+		"--api-key", "secret",
+		"--method", "PATCH",
+		"--path", "/data/api/v1/projects/demo",
+		"--apply-patch", `[{"op":"test","path":"/version","value":3},{"op":"replace","path":"/version","value":5}]`,
+		"--yes",
+	})
+	requireUsageExitCode(t, err)
+	if strings.Join(methods, ",") != "GET" {
+		t.Fatalf("expected only the fetch to be sent, got %v", methods)
+	}
+}
+
+func TestCallApplyPatchRejectsInvalidCombinations(t *testing.T) {
+	t.Parallel()
+
+	patch := `[{"op":"remove","path":"/a"}]`
+	cases := [][]string{
+		{"--method", "PUT", "--apply-patch", patch, "--body", "{}", "--yes"},
+		{"--method", "POST", "--apply-patch", patch, "--yes"},
+		{"--method", "PUT", "--apply-patch", patch},
+		{"--method", "PUT", "--apply-patch", `[{"op":"bogus","path":"/a"}]`, "--yes"},
+		{"--method", "PUT", "--fetch-path", "/data/api/v1/projects/demo", "--yes"},
+	}
+	for _, extra := range cases {
+		called := false
+		client := newMockHTTPClient(func(r *http.Request) (*http.Response, error) {
+			called = true
+			return mockHTTPResponse(http.StatusOK, `{}`, nil), nil
+		})
+		c := newAdminWrapperTestCLI(client)
+		args := append([]string{
+			"call",
+			"--gateway-url", mockGatewayURL,
+			"--api-key", "secret",
+			"--path", "/data/api/v1/projects/demo",
+		}, extra...)
+		requireUsageExitCode(t, c.Execute(args))
+		if called {
+			t.Fatalf("args %v should fail before any request", extra)
+		}
+	}
+}
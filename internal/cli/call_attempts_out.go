@@ -0,0 +1,47 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"sync"
+)
+
+// attemptCounter totals the HTTP attempts made by the retry loop, including
+// Retry-After waits and failed final attempts, for --attempts-out. A nil
+// *attemptCounter ignores attempts so callers can thread it unconditionally.
+type attemptCounter struct {
+	mu    sync.Mutex
+	total int
+}
+
+// reporter adapts the counter to gateway.CallRequest.OnAttempt.
+func (a *attemptCounter) reporter() func(attempt int) {
+	if a == nil {
+		return nil
+	}
+	return a.record
+}
+
+func (a *attemptCounter) record(int) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.total++
+}
+
+func (a *attemptCounter) count() int {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.total
+}
+
+// writeFile replaces path with the attempt count followed by a newline.
+func (a *attemptCounter) writeFile(path string) error {
+	if a == nil {
+		return nil
+	}
+	if err := os.WriteFile(path, []byte(strconv.Itoa(a.count())+"\n"), 0o600); err != nil {
+		return fmt.Errorf("write --attempts-out: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,86 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// attemptsTestClient answers the first request with a retryable 503 and
+// every later one with 200.
+func attemptsTestClient() *http.Client {
+	calls := 0
+	return newMockHTTPClient(func(r *http.Request) (*http.Response, error) {
+		calls++
+		if calls == 1 {
+			return mockHTTPResponse(http.StatusServiceUnavailable, `{"error":"busy"}`, http.Header{"Retry-After": []string{"0"}}), nil
+		}
+		return mockHTTPResponse(http.StatusOK, `{"ok":true}`, nil), nil
+	})
+}
+
+func TestCallAttemptsOutCountsRetries(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "attempts")
+	var out, errOut bytes.Buffer
+	c := newAdminWrapperTestCLI(attemptsTestClient())
+	c.Out = &out
+	c.Err = &errOut
+	err := c.Execute([]string{
+		"call",
+		"--gateway-url", mockGatewayURL,
+		"--api-key", "secret",
+		"--path", "/data/api/v1/gateway-info",
+		"--retry", "1",
+		"--retry-backoff", "1ms",
+		"--attempts-out", path,
+		"--timing",
+	})
+	if err != nil {
+		t.Fatalf("call failed: %v", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("read attempts file: %v", err)
+	}
+	if got := string(data); got != "2\n" {
+		t.Fatalf("attempts file = %q, want %q", got, "2\n")
+	}
+	if !strings.Contains(errOut.String(), "attempts\t2\n") {
+		t.Fatalf("expected attempts in timing summary, got %q", errOut.String())
+	}
+}
+
+func TestCallJSONStatsIncludeAttempts(t *testing.T) {
+	t.Parallel()
+
+	var out, errOut bytes.Buffer
+	c := newAdminWrapperTestCLI(attemptsTestClient())
+	c.Out = &out
+	c.Err = &errOut
+	err := c.Execute([]string{
+		"call",
+		"--gateway-url", mockGatewayURL,
+		"--api-key", "secret",
+		"--path", "/data/api/v1/gateway-info",
+		"--retry", "1",
+		"--retry-backoff", "1ms",
+		"--json", "--json-stats",
+	})
+	if err != nil {
+		t.Fatalf("call failed: %v", err)
+	}
+	var payload callJSONEnvelope
+	if err := json.Unmarshal(out.Bytes(), &payload); err != nil {
+		t.Fatalf("decode json: %v", err)
+	}
+	if payload.Stats == nil || payload.Stats.Attempts != 2 {
+		t.Fatalf("expected stats.attempts 2, got %+v", payload.Stats)
+	}
+}
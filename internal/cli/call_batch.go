@@ -2,16 +2,20 @@ package cli
 
 import (
 	"bufio"
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
+	"math/rand"
+	"net/http"
 	"os"
 	"strings"
 	"sync"
 	"time"
 
 	"github.com/alex-mccollum/igw-cli/internal/apidocs"
+	"github.com/alex-mccollum/igw-cli/internal/config"
 	"github.com/alex-mccollum/igw-cli/internal/exitcode"
 	"github.com/alex-mccollum/igw-cli/internal/gateway"
 	"github.com/alex-mccollum/igw-cli/internal/igwerr"
@@ -30,6 +34,58 @@ type callBatchDefaults struct {
 	OutputFormat string
 	Parallel     int
 	Compact      bool
+	Proxy        gateway.Proxy
+	// HTTP carries the TLS settings; nil uses the shared client. TLS keeps
+	// the raw flags for spec auto-sync.
+	HTTP *http.Client
+	TLS  tlsFlags
+	// DefaultHeaders are the config defaultHeaders; item headers win.
+	DefaultHeaders http.Header
+
+	NoDefaultContentType bool
+	RetryAfterMax        time.Duration
+	IgnoreRetryAfter     bool
+	RetryJitter          float64
+	RetryMaxWait         time.Duration
+	RetryOn              func(status int) bool
+	JitterRand           *rand.Rand
+	HedgeAfter           time.Duration
+	AcceptStatus         func(status int) bool
+
+	AdaptiveRate       bool
+	AdaptiveRateHeader string
+	// Rate caps requests per second across every worker (--rate; 0 = off).
+	Rate float64
+
+	// FailFastAfter opens Breaker after that many consecutive network
+	// failures (--fail-fast-after; 0 = off).
+	FailFastAfter int
+	Breaker       *batchCircuitBreaker
+
+	BatchOut        string
+	BatchOutMaxSize int64
+	FlushRecords    bool
+	Flatten         *recordFlattener
+	// Delimiter splits the batch input on a custom separator instead of
+	// newlines (--batch-delimiter); empty keeps NDJSON/array sniffing.
+	Delimiter string
+	HAR       *harRecorder
+	Verbose   *verboseTracer
+	// Session shares one cookie jar across every item (--session).
+	Session bool
+	// HeaderFromResponse is --header-from-response: the first item's
+	// capture, sent as a session header by every later item.
+	HeaderFromResponse *callBatchHeaderCapture
+
+	GatewayStrategy string
+	Summary         *runSummary
+	Policy          *config.Policy
+	Progress        *batchProgress
+	Outcomes        *outcomeFiles
+	Records         *batchRecordStream
+	Signer          *gateway.RequestSigner
+	// Context cancels every item when set (the rpc batch op).
+	Context context.Context
 }
 
 type callBatchItem struct {
@@ -46,6 +102,9 @@ type callBatchItem struct {
 	Retry        *int     `json:"retry,omitempty"`
 	RetryBackoff string   `json:"retryBackoff,omitempty"`
 	Timeout      string   `json:"timeout,omitempty"`
+
+	SetHeaderFromResponse *callBatchHeaderCapture `json:"setHeaderFromResponse,omitempty"`
+	UseSessionHeaders     bool                    `json:"useSessionHeaders,omitempty"`
 }
 
 type callBatchItemResult struct {
@@ -55,10 +114,13 @@ type callBatchItemResult struct {
 	Code     int              `json:"code"`
 	Status   int              `json:"status,omitempty"`
 	Error    string           `json:"error,omitempty"`
+	Skipped  bool             `json:"skipped,omitempty"`
 	TimingMs int64            `json:"timingMs"`
 	Request  callJSONRequest  `json:"request,omitempty"`
 	Response callJSONResponse `json:"response,omitempty"`
 	Stats    *callStats       `json:"stats,omitempty"`
+
+	headers http.Header
 }
 
 type batchExitError struct {
@@ -127,42 +189,85 @@ func (l *batchOperationMapLoader) get() (map[string]apidocs.Operation, error) {
 }
 
 func (c *CLI) runCallBatch(baseURL string, token string, inputSource string, defaults callBatchDefaults) error {
-	if defaults.Parallel <= 0 {
-		return &igwerr.UsageError{Msg: "--parallel must be >= 1"}
+	format, err := defaults.validate()
+	if err != nil {
+		return err
 	}
-	if defaults.Timeout <= 0 {
-		return &igwerr.UsageError{Msg: "--timeout must be positive"}
+
+	reader, closer, err := readBatchSource(c.In, inputSource)
+	if err != nil {
+		return err
 	}
-	if defaults.Retry < 0 {
-		return &igwerr.UsageError{Msg: "--retry must be >= 0"}
+	defer closer()
+
+	return c.runCallBatchReader(baseURL, token, reader, format, defaults)
+}
+
+// validate checks batch-wide options and returns the normalized output format.
+func (d callBatchDefaults) validate() (string, error) {
+	if d.Parallel <= 0 {
+		return "", &igwerr.UsageError{Msg: "--parallel must be >= 1"}
 	}
-	if defaults.Retry > 0 && defaults.RetryBackoff <= 0 {
-		return &igwerr.UsageError{Msg: "--retry-backoff must be positive when --retry is set"}
+	if d.Timeout <= 0 {
+		return "", &igwerr.UsageError{Msg: "--timeout must be positive"}
+	}
+	if d.Retry < 0 {
+		return "", &igwerr.UsageError{Msg: "--retry must be >= 0"}
+	}
+	if d.Retry > 0 && d.RetryBackoff <= 0 {
+		return "", &igwerr.UsageError{Msg: "--retry-backoff must be positive when --retry is set"}
 	}
 
-	format := strings.ToLower(strings.TrimSpace(defaults.OutputFormat))
+	format := strings.ToLower(strings.TrimSpace(d.OutputFormat))
 	if format == "" {
 		format = "ndjson"
 	}
 	if format != "ndjson" && format != "json" {
-		return &igwerr.UsageError{Msg: "--batch-output must be one of: ndjson, json"}
+		return "", &igwerr.UsageError{Msg: "--batch-output must be one of: ndjson, json"}
 	}
-
-	reader, closer, err := readBatchSource(c.In, inputSource)
-	if err != nil {
-		return err
+	if d.Rate < 0 {
+		return "", &igwerr.UsageError{Msg: "--rate must be >= 0"}
 	}
-	defer closer()
+	if d.FailFastAfter < 0 {
+		return "", &igwerr.UsageError{Msg: "--fail-fast-after must be >= 0"}
+	}
+	if d.BatchOutMaxSize < 0 {
+		return "", &igwerr.UsageError{Msg: "--batch-out-max-size must be >= 0"}
+	}
+	if strings.TrimSpace(d.BatchOut) == "" && d.BatchOutMaxSize > 0 {
+		return "", &igwerr.UsageError{Msg: "--batch-out-max-size requires --batch-out"}
+	}
+	if strings.TrimSpace(d.BatchOut) != "" && format != "ndjson" {
+		return "", &igwerr.UsageError{Msg: "--batch-out requires --batch-output ndjson"}
+	}
+	return format, nil
+}
 
+func (c *CLI) runCallBatchReader(baseURL string, token string, reader io.Reader, format string, defaults callBatchDefaults) error {
+	c.reserveConnections(defaults.Parallel)
+	httpClient := defaults.HTTP
+	if httpClient == nil {
+		httpClient = c.runtimeHTTPClient()
+	}
 	client := &gateway.Client{
-		BaseURL: baseURL,
-		Token:   token,
-		HTTP:    c.runtimeHTTPClient(),
+		BaseURL:        baseURL,
+		Token:          token,
+		HTTP:           httpClient,
+		Strategy:       defaults.GatewayStrategy,
+		Signer:         defaults.Signer,
+		Rand:           defaults.JitterRand,
+		Recorder:       chainRecorders(defaults.HAR.recorder(), defaults.Verbose.recorder()),
+		OnRetry:        defaults.Verbose.onRetry(),
+		Session:        defaults.Session,
+		Limiter:        gateway.NewRateLimiter(defaults.Rate),
+		Proxy:          defaults.Proxy,
+		DefaultHeaders: defaults.DefaultHeaders,
 	}
 	opMapLoader := &batchOperationMapLoader{
 		cli:      c,
 		defaults: defaults,
 	}
+	defaults.Breaker = newBatchCircuitBreaker(defaults.FailFastAfter)
 
 	var (
 		resultsByIndex map[int]callBatchItemResult
@@ -171,6 +276,9 @@ func (c *CLI) runCallBatch(baseURL string, token string, inputSource string, def
 		exitState      batchExitState
 	)
 
+	if format == "ndjson" && strings.TrimSpace(defaults.BatchOut) == "" {
+		defaults.Records = newBatchRecordStream(c.Out, defaults.FlushRecords, defaults.Flatten)
+	}
 	if defaults.Parallel == 1 {
 		resultsByIndex, exitState, itemCount, parseErr = c.runCallBatchSequential(reader, client, defaults, opMapLoader)
 	} else {
@@ -178,12 +286,14 @@ func (c *CLI) runCallBatch(baseURL string, token string, inputSource string, def
 	}
 
 	if parseErr != nil {
+		_ = defaults.Records.finish()
 		return parseErr
 	}
+	defaults.Progress.finish()
 	if resultsByIndex == nil {
 		resultsByIndex = make(map[int]callBatchItemResult)
 	}
-	return c.finalizeBatchRun(resultsByIndex, itemCount, exitState, format, c.Out, defaults.Compact)
+	return c.finalizeBatchRun(resultsByIndex, itemCount, exitState, format, c.Out, defaults)
 }
 
 func (c *CLI) runCallBatchSequential(
@@ -193,14 +303,18 @@ func (c *CLI) runCallBatchSequential(
 	opMapLoader *batchOperationMapLoader,
 ) (map[int]callBatchItemResult, batchExitState, int, error) {
 	resultsByIndex := make(map[int]callBatchItemResult)
+	session := newBatchSession()
 	var (
 		itemCount int
 		exitState batchExitState
 	)
-	itemCount, parseErr := c.parseBatchItems(reader, opMapLoader, func(item callBatchWorkItem) error {
-		result := c.executeBatchCallItem(client, item.index, item.call, defaults, item.opMap)
+	itemCount, parseErr := c.parseBatchItems(reader, defaults, opMapLoader, func(item callBatchWorkItem) error {
+		defaults.Progress.queue()
+		result := c.executeBatchCallItem(client, item.index, item.call, defaults, item.opMap, session)
 		exitState.record(result.Code)
 		resultsByIndex[result.Index] = result
+		defaults.Records.add(result)
+		defaults.Progress.complete()
 		return nil
 	})
 	if parseErr != nil {
@@ -221,15 +335,25 @@ func (c *CLI) runCallBatchParallel(
 	results := make(chan callBatchItemResult, defaults.Parallel*2)
 	var wg sync.WaitGroup
 	var drainWG sync.WaitGroup
+	var inFlight sync.WaitGroup
 	var exitState batchExitState
 	var itemCount int
+	session := newBatchSession()
+	var adaptive *adaptiveRateController
+	if defaults.AdaptiveRate {
+		adaptive = newAdaptiveRateController(defaults.AdaptiveRateHeader, defaults.Parallel)
+	}
 
 	for worker := 0; worker < defaults.Parallel; worker++ {
 		wg.Add(1)
 		go func() {
 			defer wg.Done()
 			for item := range work {
-				results <- c.executeBatchCallItem(client, item.index, item.call, defaults, item.opMap)
+				adaptive.acquire()
+				result := c.executeBatchCallItem(client, item.index, item.call, defaults, item.opMap, session)
+				adaptive.release(result.headers)
+				results <- result
+				inFlight.Done()
 			}
 		}()
 	}
@@ -240,10 +364,21 @@ func (c *CLI) runCallBatchParallel(
 		for result := range results {
 			exitState.record(result.Code)
 			resultsByIndex[result.Index] = result
+			defaults.Records.add(result)
+			defaults.Progress.complete()
 		}
 	}()
 
-	itemCount, parseErr := c.parseBatchItems(reader, opMapLoader, func(item callBatchWorkItem) error {
+	itemCount, parseErr := c.parseBatchItems(reader, defaults, opMapLoader, func(item callBatchWorkItem) error {
+		defaults.Progress.queue()
+		if usesBatchSession(item.call) {
+			// Session items are ordering barriers: wait for earlier items,
+			// then run inline so later items observe captured headers.
+			inFlight.Wait()
+			results <- c.executeBatchCallItem(client, item.index, item.call, defaults, item.opMap, session)
+			return nil
+		}
+		inFlight.Add(1)
 		work <- item
 		return nil
 	})
@@ -260,11 +395,13 @@ func (c *CLI) runCallBatchParallel(
 
 func (c *CLI) parseBatchItems(
 	reader io.Reader,
+	defaults callBatchDefaults,
 	opMapLoader *batchOperationMapLoader,
 	emit func(callBatchWorkItem) error,
 ) (int, error) {
 	itemCount := 0
-	parseErr := streamCallBatchItems(reader, func(index int, item callBatchItem) error {
+	parseErr := streamCallBatchItems(reader, defaults.Delimiter, func(index int, item callBatchItem) error {
+		item = withHeaderFromResponse(index, item, defaults.HeaderFromResponse)
 		workItem, err := c.makeBatchWorkItem(index, item, opMapLoader)
 		if err != nil {
 			return err
@@ -299,15 +436,35 @@ func (c *CLI) finalizeBatchRun(
 	exitState batchExitState,
 	format string,
 	out io.Writer,
-	compact bool,
+	defaults callBatchDefaults,
 ) error {
 	if itemCount == 0 {
 		return &igwerr.UsageError{Msg: "batch request list is empty"}
 	}
 	results := orderedBatchResults(resultsByIndex, itemCount)
-	if err := writeBatchResults(out, results, format, compact); err != nil {
+	if prefix := strings.TrimSpace(defaults.BatchOut); prefix != "" {
+		rotated := newRotatingNDJSONWriter(prefix, defaults.BatchOutMaxSize)
+		writeErr := writeBatchResultsRotated(rotated, results, defaults.Flatten)
+		if closeErr := rotated.Close(); writeErr == nil {
+			writeErr = closeErr
+		}
+		if writeErr != nil {
+			return igwerr.NewTransportError(writeErr)
+		}
+		for _, name := range rotated.files {
+			c.info("wrote batch results: %s", name)
+		}
+	} else if defaults.Records != nil {
+		if err := defaults.Records.finish(); err != nil {
+			return igwerr.NewTransportError(err)
+		}
+	} else if err := writeBatchResults(out, results, format, defaults.Compact, defaults.Flatten); err != nil {
+		return igwerr.NewTransportError(err)
+	}
+	if err := defaults.Outcomes.writeBatchResults(results); err != nil {
 		return igwerr.NewTransportError(err)
 	}
+	defaults.Breaker.writeSummary(c.Err)
 	exit := exitState.result()
 	if exit == exitcode.Success {
 		return nil
@@ -332,6 +489,7 @@ func (c *CLI) executeBatchCallItem(
 	item callBatchItem,
 	defaults callBatchDefaults,
 	opMap map[string]apidocs.Operation,
+	session *batchSession,
 ) callBatchItemResult {
 	out := callBatchItemResult{
 		Index: index,
@@ -349,16 +507,50 @@ func (c *CLI) executeBatchCallItem(
 		OperationMap: opMap,
 		EnableTiming: true,
 	})
+	if parseErr == nil {
+		parseErr = validateBatchHeaderCapture(item.SetHeaderFromResponse)
+	}
 	if parseErr != nil {
 		out.OK = false
 		out.Code = exitCodeForError(parseErr)
 		out.Error = "batch item: " + parseErr.Error()
 		return out
 	}
+	input.NoDefaultContentType = defaults.NoDefaultContentType
+	input.RetryAfterMax = defaults.RetryAfterMax
+	input.RetryJitter = defaults.RetryJitter
+	input.RetryMaxWait = defaults.RetryMaxWait
+	input.RetryOn = defaults.RetryOn
+	input.HedgeAfter = defaults.HedgeAfter
+	input.AcceptStatus = defaults.AcceptStatus
+	input.IgnoreRetryAfter = defaults.IgnoreRetryAfter
+	input.Summary = defaults.Summary
+	input.Policy = defaults.Policy
+	if defaults.Context != nil {
+		input.Context = defaults.Context
+	}
+	if item.UseSessionHeaders {
+		input.Headers = session.apply(input.Headers)
+	}
+
+	var rateWait time.Duration
+	input.OnRateWait = func(wait time.Duration) {
+		rateWait += wait
+	}
+
+	if !defaults.Breaker.allow() {
+		skipErr := defaults.Breaker.skipError()
+		out.OK = false
+		out.Skipped = true
+		out.Code = exitCodeForError(skipErr)
+		out.Error = skipErr.Error()
+		return out
+	}
 
 	start := time.Now()
 	resp, reqMethod, reqPath, err := executeCallCore(client, input)
 	out.TimingMs = time.Since(start).Milliseconds()
+	defaults.Breaker.record(err)
 	if reqMethod != "" || reqPath != "" {
 		out.Request = callJSONRequest{Method: reqMethod, URL: reqPath}
 	}
@@ -369,7 +561,16 @@ func (c *CLI) executeBatchCallItem(
 		out.OK = false
 		out.Code = exitCodeForError(err)
 		out.Error = err.Error()
+		// A 429 or 503 usually carries the rate budget the adaptive
+		// limiter needs, so keep its headers too.
+		var statusErr *igwerr.StatusError
+		if resp != nil {
+			out.headers = resp.Headers
+		} else if errors.As(err, &statusErr) {
+			out.headers = statusErr.Headers
+		}
 		stats := buildCallStats(resp, out.TimingMs)
+		stats.RateWaitMs = rateWait.Milliseconds()
 		out.Stats = &stats
 		return out
 	}
@@ -377,6 +578,7 @@ func (c *CLI) executeBatchCallItem(
 	out.OK = true
 	out.Code = exitcode.Success
 	out.Status = resp.StatusCode
+	out.headers = resp.Headers
 	out.Request = callJSONRequest{
 		Method: resp.Method,
 		URL:    resp.URL,
@@ -389,17 +591,32 @@ func (c *CLI) executeBatchCallItem(
 		Truncated: resp.Truncated,
 	}
 	stats := buildCallStats(resp, out.TimingMs)
+	stats.RateWaitMs = rateWait.Milliseconds()
 	out.Stats = &stats
+	if item.SetHeaderFromResponse != nil {
+		if captureErr := session.capture(item.SetHeaderFromResponse, resp.Body); captureErr != nil {
+			out.OK = false
+			out.Code = exitCodeForError(captureErr)
+			out.Error = captureErr.Error()
+		}
+	}
 	return out
 }
 
 func (c *CLI) loadBatchOperationMap(defaults callBatchDefaults) (map[string]apidocs.Operation, error) {
-	ops, err := c.loadAPIOperations(defaults.SpecFile, apiSyncRuntime{
+	runtime := apiSyncRuntime{
 		Profile:    defaults.Profile,
 		GatewayURL: defaults.GatewayURL,
 		APIKey:     defaults.APIKey,
 		Timeout:    defaults.Timeout,
-	})
+		Signer:     defaults.Signer,
+		NoProxy:    defaults.Proxy.Disabled,
+		TLS:        defaults.TLS,
+	}
+	if defaults.Proxy.URL != nil {
+		runtime.Proxy = defaults.Proxy.URL.String()
+	}
+	ops, err := c.loadAPIOperations(defaults.SpecFile, runtime)
 	if err != nil {
 		return nil, err
 	}
@@ -429,7 +646,7 @@ func readCallBatchItems(stdin io.Reader, source string) ([]callBatchItem, error)
 	defer closer()
 
 	items := make([]callBatchItem, 0, 16)
-	err = streamCallBatchItems(reader, func(index int, item callBatchItem) error {
+	err = streamCallBatchItems(reader, "", func(index int, item callBatchItem) error {
 		items = append(items, item)
 		_ = index
 		return nil
@@ -440,8 +657,11 @@ func readCallBatchItems(stdin io.Reader, source string) ([]callBatchItem, error)
 	return items, nil
 }
 
-func streamCallBatchItems(reader io.Reader, handle func(index int, item callBatchItem) error) error {
+func streamCallBatchItems(reader io.Reader, delimiter string, handle func(index int, item callBatchItem) error) error {
 	buffered := bufio.NewReader(reader)
+	if delimiter != "" {
+		return decodeBatchDelimitedStream(buffered, delimiter, handle)
+	}
 	leadByte, isEOF := peekBatchLeadByte(buffered)
 	if isEOF {
 		return &igwerr.UsageError{Msg: "batch input is empty"}
@@ -581,16 +801,23 @@ func isJSONWhitespace(b byte) bool {
 	}
 }
 
-func writeBatchResults(w io.Writer, items []callBatchItemResult, format string, compact bool) error {
+func writeBatchResults(w io.Writer, items []callBatchItemResult, format string, compact bool, flatten *recordFlattener) error {
+	records := make([]any, 0, len(items))
+	for i := range items {
+		record, err := flatten.apply(items[i])
+		if err != nil {
+			return err
+		}
+		records = append(records, record)
+	}
 	if format == "json" {
-		return writeJSONWithOptions(w, items, compact)
+		return writeJSONWithOptions(w, records, compact)
 	}
 
 	enc := json.NewEncoder(w)
 	enc.SetEscapeHTML(false)
-	for i := range items {
-		item := items[i]
-		if err := enc.Encode(item); err != nil {
+	for _, record := range records {
+		if err := enc.Encode(record); err != nil {
 			return err
 		}
 	}
@@ -0,0 +1,91 @@
+package cli
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+const defaultAdaptiveRateHeader = "X-RateLimit-Remaining"
+
+// adaptiveRateController bounds batch in-flight requests by the remaining
+// rate budget a gateway advertises in a response header. The limit shrinks as
+// the budget drops and recovers (up to the configured worker count) as it rises.
+type adaptiveRateController struct {
+	mu       sync.Mutex
+	cond     *sync.Cond
+	header   string
+	max      int
+	limit    int
+	inFlight int
+}
+
+func newAdaptiveRateController(header string, max int) *adaptiveRateController {
+	header = strings.TrimSpace(header)
+	if header == "" {
+		header = defaultAdaptiveRateHeader
+	}
+	if max < 1 {
+		max = 1
+	}
+	ctrl := &adaptiveRateController{header: header, max: max, limit: max}
+	ctrl.cond = sync.NewCond(&ctrl.mu)
+	return ctrl
+}
+
+func (a *adaptiveRateController) acquire() {
+	if a == nil {
+		return
+	}
+	a.mu.Lock()
+	for a.inFlight >= a.limit {
+		a.cond.Wait()
+	}
+	a.inFlight++
+	a.mu.Unlock()
+}
+
+// release frees a slot and folds the advertised remaining budget (if any)
+// from headers into the current limit.
+func (a *adaptiveRateController) release(headers http.Header) {
+	if a == nil {
+		return
+	}
+	a.mu.Lock()
+	a.inFlight--
+	if remaining, ok := a.remaining(headers); ok {
+		a.limit = adaptiveLimitForRemaining(remaining, a.max)
+	}
+	a.mu.Unlock()
+	a.cond.Broadcast()
+}
+
+func (a *adaptiveRateController) currentLimit() int {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.limit
+}
+
+func (a *adaptiveRateController) remaining(headers http.Header) (int, bool) {
+	raw := strings.TrimSpace(headers.Get(a.header))
+	if raw == "" {
+		return 0, false
+	}
+	value, err := strconv.Atoi(raw)
+	if err != nil {
+		return 0, false
+	}
+	return value, true
+}
+
+func adaptiveLimitForRemaining(remaining int, max int) int {
+	switch {
+	case remaining < 1:
+		return 1
+	case remaining > max:
+		return max
+	default:
+		return remaining
+	}
+}
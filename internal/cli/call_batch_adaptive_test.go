@@ -0,0 +1,22 @@
+package cli
+
+import "testing"
+
+func TestAdaptiveLimitForRemaining(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		remaining int
+		want      int
+	}{
+		{remaining: -3, want: 1},
+		{remaining: 0, want: 1},
+		{remaining: 2, want: 2},
+		{remaining: 50, want: 4},
+	}
+	for _, tc := range cases {
+		if got := adaptiveLimitForRemaining(tc.remaining, 4); got != tc.want {
+			t.Fatalf("remaining=%d: expected %d, got %d", tc.remaining, tc.want, got)
+		}
+	}
+}
@@ -0,0 +1,208 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/alex-mccollum/igw-cli/internal/apidocs"
+	"github.com/alex-mccollum/igw-cli/internal/igwerr"
+)
+
+const (
+	csvMapQuery = "query"
+	csvMapBody  = "body"
+)
+
+// callBatchCSVOptions describes how CSV rows become batch items: every row
+// shares the request target and static flags, and each non-empty cell maps to
+// a query parameter or a top-level JSON body field named by its header.
+type callBatchCSVOptions struct {
+	Source      string
+	Map         string
+	IDColumn    string
+	Method      string
+	Path        string
+	Query       []string
+	Headers     []string
+	ContentType string
+	DryRun      bool
+	Operation   *apidocs.Operation
+}
+
+func (c *CLI) runCallBatchCSV(baseURL string, token string, opts callBatchCSVOptions, defaults callBatchDefaults) error {
+	format, err := defaults.validate()
+	if err != nil {
+		return err
+	}
+
+	items, err := readCallBatchCSV(c.In, opts)
+	if err != nil {
+		return err
+	}
+
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	for _, item := range items {
+		if err := enc.Encode(item); err != nil {
+			return &igwerr.UsageError{Msg: fmt.Sprintf("encode --batch-csv item: %v", err)}
+		}
+	}
+	return c.runCallBatchReader(baseURL, token, &buf, format, defaults)
+}
+
+func readCallBatchCSV(stdin io.Reader, opts callBatchCSVOptions) ([]callBatchItem, error) {
+	mapping := strings.ToLower(strings.TrimSpace(opts.Map))
+	if mapping != csvMapQuery && mapping != csvMapBody {
+		return nil, &igwerr.UsageError{Msg: "--csv-map must be one of: query, body"}
+	}
+
+	source, closer, err := readBatchSource(stdin, opts.Source)
+	if err != nil {
+		return nil, err
+	}
+	defer closer()
+
+	reader := csv.NewReader(source)
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err == io.EOF {
+		return nil, &igwerr.UsageError{Msg: "--batch-csv input has no header row"}
+	}
+	if err != nil {
+		return nil, &igwerr.UsageError{Msg: fmt.Sprintf("read --batch-csv header: %v", err)}
+	}
+	if len(header) > 0 {
+		header[0] = strings.TrimPrefix(header[0], "\ufeff")
+	}
+
+	idIndex := -1
+	seen := make(map[string]bool, len(header))
+	for i := range header {
+		header[i] = strings.TrimSpace(header[i])
+		if header[i] == "" {
+			return nil, &igwerr.UsageError{Msg: fmt.Sprintf("--batch-csv header column %d is empty", i+1)}
+		}
+		if seen[header[i]] {
+			return nil, &igwerr.UsageError{Msg: fmt.Sprintf("--batch-csv header column %q is duplicated", header[i])}
+		}
+		seen[header[i]] = true
+		if opts.IDColumn != "" && header[i] == strings.TrimSpace(opts.IDColumn) {
+			idIndex = i
+		}
+	}
+	if opts.IDColumn != "" && idIndex < 0 {
+		return nil, &igwerr.UsageError{Msg: fmt.Sprintf("--id-column %q not found in --batch-csv header", strings.TrimSpace(opts.IDColumn))}
+	}
+	if err := validateCSVColumns(header, idIndex, mapping, opts.Operation); err != nil {
+		return nil, err
+	}
+
+	items := make([]callBatchItem, 0, 16)
+	for row := 2; ; row++ {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, &igwerr.UsageError{Msg: fmt.Sprintf("read --batch-csv row %d: %v", row, err)}
+		}
+
+		item := callBatchItem{
+			Method:      opts.Method,
+			Path:        opts.Path,
+			Query:       append([]string(nil), opts.Query...),
+			Headers:     opts.Headers,
+			ContentType: opts.ContentType,
+			DryRun:      opts.DryRun,
+		}
+		body := make(map[string]any)
+		for i, name := range header {
+			value := record[i]
+			if i == idIndex {
+				if strings.TrimSpace(value) != "" {
+					item.ID = value
+				}
+				continue
+			}
+			if strings.TrimSpace(value) == "" {
+				continue
+			}
+			if mapping == csvMapQuery {
+				item.Query = append(item.Query, name+"="+value)
+			} else {
+				body[name] = value
+			}
+		}
+		if len(body) > 0 {
+			encoded, err := json.Marshal(body)
+			if err != nil {
+				return nil, &igwerr.UsageError{Msg: fmt.Sprintf("encode --batch-csv row %d body: %v", row, err)}
+			}
+			item.Body = string(encoded)
+		}
+		items = append(items, item)
+	}
+	return items, nil
+}
+
+// validateCSVColumns checks query-mapped headers against the operation's
+// declared query parameters. Body mappings are not validated because request
+// body schemas are not indexed.
+func validateCSVColumns(header []string, idIndex int, mapping string, op *apidocs.Operation) error {
+	if op == nil || mapping != csvMapQuery {
+		return nil
+	}
+
+	declared := make(map[string]apidocs.Parameter)
+	for _, param := range op.ParametersIn("query") {
+		declared[param.Name] = param
+	}
+
+	unknown := make([]string, 0)
+	present := make(map[string]bool, len(header))
+	for i, name := range header {
+		if i == idIndex {
+			continue
+		}
+		present[name] = true
+		if _, ok := declared[name]; !ok {
+			unknown = append(unknown, name)
+		}
+	}
+	if len(unknown) > 0 {
+		allowed := make([]string, 0, len(declared))
+		for name := range declared {
+			allowed = append(allowed, name)
+		}
+		sort.Strings(allowed)
+		allowedText := "none"
+		if len(allowed) > 0 {
+			allowedText = strings.Join(allowed, ", ")
+		}
+		return &igwerr.UsageError{Msg: fmt.Sprintf(
+			"--batch-csv columns %s are not query parameters of operationId %q (declared: %s)",
+			strings.Join(unknown, ", "), op.OperationID, allowedText,
+		)}
+	}
+
+	missing := make([]string, 0)
+	for name, param := range declared {
+		if param.Required && !present[name] {
+			missing = append(missing, name)
+		}
+	}
+	if len(missing) > 0 {
+		sort.Strings(missing)
+		return &igwerr.UsageError{Msg: fmt.Sprintf(
+			"--batch-csv is missing required query parameter columns for operationId %q: %s",
+			op.OperationID, strings.Join(missing, ", "),
+		)}
+	}
+	return nil
+}
@@ -0,0 +1,176 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/alex-mccollum/igw-cli/internal/config"
+)
+
+const callBatchCSVSpec = `{
+  "openapi": "3.0.0",
+  "paths": {
+    "/data/api/v1/logs": {
+      "get": {
+        "operationId": "listLogs",
+        "parameters": [
+          {"name": "level", "in": "query"},
+          {"name": "limit", "in": "query"}
+        ]
+      }
+    }
+  }
+}`
+
+func TestCallBatchCSVIssuesOneCallPerRow(t *testing.T) {
+	t.Parallel()
+
+	var (
+		mu      sync.Mutex
+		queries []string
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		queries = append(queries, r.URL.RawQuery)
+		mu.Unlock()
+		_, _ = w.Write([]byte(`{"ok":true}`))
+	}))
+	defer srv.Close()
+
+	dir := t.TempDir()
+	specPath := filepath.Join(dir, "openapi.json")
+	if err := os.WriteFile(specPath, []byte(callBatchCSVSpec), 0o600); err != nil {
+		t.Fatalf("write spec: %v", err)
+	}
+	csvPath := filepath.Join(dir, "rows.csv")
+	if err := os.WriteFile(csvPath, []byte("name,level,limit\nwarnings,WARN,5\nall,,10\n"), 0o600); err != nil {
+		t.Fatalf("write csv: %v", err)
+	}
+
+	var out bytes.Buffer
+	c := &CLI{
+		In:     strings.NewReader(""),
+		Out:    &out,
+		Err:    new(bytes.Buffer),
+		Getenv: func(string) string { return "" },
+		ReadConfig: func() (config.File, error) {
+			return config.File{}, nil
+		},
+		HTTPClient: srv.Client(),
+	}
+
+	if err := c.Execute([]string{
+		"call",
+		"--gateway-url", srv.URL,
+		"--api-key", "secret",
+		"--spec-file", specPath,
+		"--op", "listLogs",
+		"--batch-csv", "@" + csvPath,
+		"--id-column", "name",
+	}); err != nil {
+		t.Fatalf("batch csv failed: %v\n%s", err, out.String())
+	}
+
+	if len(queries) != 2 || queries[0] != "level=WARN&limit=5" || queries[1] != "limit=10" {
+		t.Fatalf("unexpected per-row queries %q", queries)
+	}
+
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("expected 2 result lines, got %q", out.String())
+	}
+	for i, wantID := range []string{"warnings", "all"} {
+		var result callBatchItemResult
+		if err := json.Unmarshal([]byte(lines[i]), &result); err != nil {
+			t.Fatalf("decode result: %v", err)
+		}
+		if result.ID != wantID || !result.OK {
+			t.Fatalf("unexpected result %d: %+v", i, result)
+		}
+	}
+}
+
+func TestCallBatchCSVRejectsUnknownColumns(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	specPath := filepath.Join(dir, "openapi.json")
+	if err := os.WriteFile(specPath, []byte(callBatchCSVSpec), 0o600); err != nil {
+		t.Fatalf("write spec: %v", err)
+	}
+
+	c := &CLI{
+		In:     strings.NewReader("level,severity\nWARN,high\n"),
+		Out:    new(bytes.Buffer),
+		Err:    new(bytes.Buffer),
+		Getenv: func(string) string { return "" },
+		ReadConfig: func() (config.File, error) {
+			return config.File{}, nil
+		},
+	}
+
+	err := c.Execute([]string{
+		"call",
+		"--gateway-url", "http://127.0.0.1:8088",
+		"--api-key", "secret",
+		"--spec-file", specPath,
+		"--op", "listLogs",
+		"--batch-csv", "-",
+	})
+	requireUsageExitCode(t, err)
+	if !strings.Contains(err.Error(), "severity") {
+		t.Fatalf("expected unknown column in error, got %v", err)
+	}
+}
+
+func TestCallBatchCSVWithoutTargetPrintsJSONError(t *testing.T) {
+	t.Parallel()
+
+	c := newAdminWrapperTestCLI(nil)
+	c.In = strings.NewReader("level\nWARN\n")
+	err := c.Execute([]string{
+		"call",
+		"--gateway-url", "http://127.0.0.1:8088",
+		"--api-key", "secret",
+		"--batch-csv", "-",
+		"--json",
+	})
+	requireUsageExitCode(t, err)
+	var payload map[string]any
+	if decodeErr := json.Unmarshal(c.Out.(*bytes.Buffer).Bytes(), &payload); decodeErr != nil {
+		t.Fatalf("expected a JSON error envelope: %v", decodeErr)
+	}
+	if payload["ok"] != false || payload["error"] != "--batch-csv requires --op or --path" {
+		t.Fatalf("unexpected envelope: %v", payload)
+	}
+}
+
+func TestReadCallBatchCSVBodyMapping(t *testing.T) {
+	t.Parallel()
+
+	items, err := readCallBatchCSV(strings.NewReader("name,enabled\nalpha,true\n,\n"), callBatchCSVOptions{
+		Source: "-",
+		Map:    csvMapBody,
+		Method: http.MethodPost,
+		Path:   "/data/api/v1/projects",
+	})
+	if err != nil {
+		t.Fatalf("read csv: %v", err)
+	}
+	if len(items) != 2 {
+		t.Fatalf("expected 2 items, got %d", len(items))
+	}
+	if items[0].Body != `{"enabled":"true","name":"alpha"}` {
+		t.Fatalf("unexpected body %q", items[0].Body)
+	}
+	if items[1].Body != "" {
+		t.Fatalf("expected empty cells to be skipped, got %q", items[1].Body)
+	}
+}
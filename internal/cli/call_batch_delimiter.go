@@ -0,0 +1,73 @@
+package cli
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/alex-mccollum/igw-cli/internal/igwerr"
+)
+
+// batchDelimiterEscapes lets a delimiter such as a NUL byte be typed on the
+// command line as \0.
+var batchDelimiterEscapes = strings.NewReplacer(`\\`, `\`, `\0`, "\x00", `\n`, "\n", `\r`, "\r", `\t`, "\t")
+
+// parseBatchDelimiter expands escapes in --batch-delimiter. An empty value
+// keeps the default NDJSON/array sniffing.
+func parseBatchDelimiter(raw string) string {
+	if raw == "" {
+		return ""
+	}
+	return batchDelimiterEscapes.Replace(raw)
+}
+
+// decodeBatchDelimitedStream parses request items separated by delimiter
+// instead of newlines, so producers can emit pretty-printed items or bodies
+// with embedded newlines. Blank chunks are skipped.
+func decodeBatchDelimitedStream(reader *bufio.Reader, delimiter string, handle func(index int, item callBatchItem) error) error {
+	scanner := bufio.NewScanner(reader)
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+	scanner.Split(splitOnDelimiter([]byte(delimiter)))
+
+	chunk := 0
+	itemCount := 0
+	for scanner.Scan() {
+		chunk++
+		text := bytes.TrimSpace(scanner.Bytes())
+		if len(text) == 0 {
+			continue
+		}
+		var item callBatchItem
+		if err := json.Unmarshal(text, &item); err != nil {
+			return &igwerr.UsageError{Msg: fmt.Sprintf("parse batch item %d (--batch-delimiter): %v", chunk, err)}
+		}
+		if err := handle(itemCount, item); err != nil {
+			return err
+		}
+		itemCount++
+	}
+	if err := scanner.Err(); err != nil {
+		return igwerr.NewTransportError(err)
+	}
+	if itemCount == 0 {
+		return &igwerr.UsageError{Msg: "batch input is empty"}
+	}
+	return nil
+}
+
+func splitOnDelimiter(delimiter []byte) bufio.SplitFunc {
+	return func(data []byte, atEOF bool) (int, []byte, error) {
+		if atEOF && len(data) == 0 {
+			return 0, nil, nil
+		}
+		if i := bytes.Index(data, delimiter); i >= 0 {
+			return i + len(delimiter), data[:i], nil
+		}
+		if atEOF {
+			return len(data), data, nil
+		}
+		return 0, nil, nil
+	}
+}
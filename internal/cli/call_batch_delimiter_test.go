@@ -0,0 +1,119 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/alex-mccollum/igw-cli/internal/config"
+)
+
+func runDelimitedBatch(t *testing.T, input string, delimiter string) ([]string, []map[string]any) {
+	t.Helper()
+
+	var mu sync.Mutex
+	var bodies []string
+	var out bytes.Buffer
+	c := &CLI{
+		In:     strings.NewReader(input),
+		Out:    &out,
+		Err:    new(bytes.Buffer),
+		Getenv: func(string) string { return "" },
+		ReadConfig: func() (config.File, error) {
+			return config.File{}, nil
+		},
+		HTTPClient: newMockHTTPClient(func(r *http.Request) (*http.Response, error) {
+			var body []byte
+			if r.Body != nil {
+				body, _ = io.ReadAll(r.Body)
+			}
+			mu.Lock()
+			bodies = append(bodies, string(body))
+			mu.Unlock()
+			return mockHTTPResponse(http.StatusOK, `{}`, nil), nil
+		}),
+	}
+	err := c.Execute([]string{
+		"call",
+		"--gateway-url", mockGatewayURL,
+		"--api-key", "secret",
+		"--yes",
+		"--batch", "-",
+		"--batch-delimiter", delimiter,
+	})
+	if err != nil {
+		t.Fatalf("call batch failed: %v", err)
+	}
+
+	var records []map[string]any
+	decoder := json.NewDecoder(&out)
+	for decoder.More() {
+		var record map[string]any
+		if err := decoder.Decode(&record); err != nil {
+			t.Fatalf("decode result: %v", err)
+		}
+		records = append(records, record)
+	}
+	return bodies, records
+}
+
+func TestCallBatchDelimiterNUL(t *testing.T) {
+	t.Parallel()
+
+	input := `{"id":"a","method":"POST","path":"/data/api/v1/scripts","body":"line one\nline two"}` + "\x00" +
+		"{\n  \"id\": \"b\",\n  \"method\": \"GET\",\n  \"path\": \"/data/api/v1/gateway-info\"\n}\x00"
+	bodies, records := runDelimitedBatch(t, input, `\0`)
+
+	if len(records) != 2 || records[0]["id"] != "a" || records[1]["id"] != "b" {
+		t.Fatalf("unexpected batch results: %v", records)
+	}
+	if len(bodies) != 2 || bodies[0] != "line one\nline two" {
+		t.Fatalf("unexpected request bodies: %q", bodies)
+	}
+}
+
+func TestCallBatchDelimiterDashes(t *testing.T) {
+	t.Parallel()
+
+	input := strings.Join([]string{
+		`{"id":1,"method":"PUT","path":"/data/api/v1/a","body":"x\ny"}`,
+		"{\"id\":2,\n\"method\":\"PUT\",\n\"path\":\"/data/api/v1/b\"}",
+		"",
+	}, "\n---\n")
+	_, records := runDelimitedBatch(t, input, "---")
+
+	if len(records) != 2 || records[0]["id"] != float64(1) || records[1]["id"] != float64(2) {
+		t.Fatalf("unexpected batch results: %v", records)
+	}
+	for _, record := range records {
+		if record["ok"] != true {
+			t.Fatalf("expected successful result: %v", record)
+		}
+	}
+}
+
+func TestCallBatchDelimiterValidation(t *testing.T) {
+	t.Parallel()
+
+	c := &CLI{
+		In:     strings.NewReader("not json---"),
+		Out:    new(bytes.Buffer),
+		Err:    new(bytes.Buffer),
+		Getenv: func(string) string { return "" },
+		ReadConfig: func() (config.File, error) {
+			return config.File{}, nil
+		},
+	}
+	requireUsageExitCode(t, c.Execute([]string{
+		"call", "--gateway-url", mockGatewayURL, "--api-key", "secret",
+		"--path", "/data/api/v1/gateway-info", "--batch-delimiter", "---",
+	}))
+	requireUsageExitCode(t, c.Execute([]string{
+		"call", "--gateway-url", mockGatewayURL, "--api-key", "secret",
+		"--batch", "-", "--batch-delimiter", "---",
+	}))
+}
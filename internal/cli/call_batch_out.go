@@ -0,0 +1,87 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+)
+
+// rotatingNDJSONWriter writes NDJSON records to prefix-0001.ndjson,
+// prefix-0002.ndjson, ... starting a new file whenever the next record would
+// push the current file past maxSize. A single record larger than maxSize is
+// written alone to its own file.
+type rotatingNDJSONWriter struct {
+	prefix  string
+	maxSize int64
+
+	index   int
+	file    *os.File
+	written int64
+	files   []string
+}
+
+func newRotatingNDJSONWriter(prefix string, maxSize int64) *rotatingNDJSONWriter {
+	return &rotatingNDJSONWriter{prefix: prefix, maxSize: maxSize}
+}
+
+func (w *rotatingNDJSONWriter) writeRecord(record []byte) error {
+	size := int64(len(record))
+	if w.file == nil || (w.maxSize > 0 && w.written > 0 && w.written+size > w.maxSize) {
+		if err := w.rotate(); err != nil {
+			return err
+		}
+	}
+	n, err := w.file.Write(record)
+	w.written += int64(n)
+	return err
+}
+
+func (w *rotatingNDJSONWriter) rotate() error {
+	if err := w.closeCurrent(); err != nil {
+		return err
+	}
+	w.index++
+	name := fmt.Sprintf("%s-%04d.ndjson", w.prefix, w.index)
+	file, err := os.OpenFile(name, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0o600)
+	if err != nil {
+		return err
+	}
+	w.file = file
+	w.written = 0
+	w.files = append(w.files, name)
+	return nil
+}
+
+func (w *rotatingNDJSONWriter) closeCurrent() error {
+	if w.file == nil {
+		return nil
+	}
+	err := w.file.Close()
+	w.file = nil
+	return err
+}
+
+func (w *rotatingNDJSONWriter) Close() error {
+	return w.closeCurrent()
+}
+
+func writeBatchResultsRotated(w *rotatingNDJSONWriter, items []callBatchItemResult, flatten *recordFlattener) error {
+	var record bytes.Buffer
+	enc := json.NewEncoder(&record)
+	enc.SetEscapeHTML(false)
+	for i := range items {
+		record.Reset()
+		flattened, err := flatten.apply(items[i])
+		if err != nil {
+			return err
+		}
+		if err := enc.Encode(flattened); err != nil {
+			return err
+		}
+		if err := w.writeRecord(record.Bytes()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
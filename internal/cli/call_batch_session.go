@@ -0,0 +1,130 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/alex-mccollum/igw-cli/internal/igwerr"
+)
+
+// callBatchHeaderCapture declares a response field to store as a session
+// header for later batch items, e.g. a login token.
+type callBatchHeaderCapture struct {
+	Header string `json:"header"`
+	Select string `json:"select"`
+}
+
+// batchSession holds headers captured from earlier batch responses.
+type batchSession struct {
+	mu      sync.Mutex
+	headers map[string]string
+}
+
+func newBatchSession() *batchSession {
+	return &batchSession{headers: make(map[string]string)}
+}
+
+// usesBatchSession reports whether item writes session headers and therefore
+// must not run concurrently with other items. Items that only read them run
+// after every earlier capture has finished, so they need no barrier.
+func usesBatchSession(item callBatchItem) bool {
+	return item.SetHeaderFromResponse != nil
+}
+
+// parseHeaderFromResponse reads --header-from-response <header>=<select>.
+func parseHeaderFromResponse(raw string) (*callBatchHeaderCapture, error) {
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		return nil, nil
+	}
+	header, selectPath, ok := strings.Cut(raw, "=")
+	capture := &callBatchHeaderCapture{Header: strings.TrimSpace(header), Select: strings.TrimSpace(selectPath)}
+	if !ok || capture.Header == "" || capture.Select == "" {
+		return nil, &igwerr.UsageError{Msg: fmt.Sprintf("invalid --header-from-response %q (use <header>=<select>)", raw)}
+	}
+	return capture, nil
+}
+
+// withHeaderFromResponse applies --header-from-response: the first item
+// captures the header and every later item sends it. Items that declare
+// their own setHeaderFromResponse keep it.
+func withHeaderFromResponse(index int, item callBatchItem, capture *callBatchHeaderCapture) callBatchItem {
+	if capture == nil {
+		return item
+	}
+	if index == 0 {
+		if item.SetHeaderFromResponse == nil {
+			item.SetHeaderFromResponse = capture
+		}
+		return item
+	}
+	item.UseSessionHeaders = true
+	return item
+}
+
+func validateBatchHeaderCapture(capture *callBatchHeaderCapture) error {
+	if capture == nil {
+		return nil
+	}
+	if strings.TrimSpace(capture.Header) == "" {
+		return &igwerr.UsageError{Msg: "setHeaderFromResponse.header is required"}
+	}
+	if strings.TrimSpace(capture.Select) == "" {
+		return &igwerr.UsageError{Msg: "setHeaderFromResponse.select is required"}
+	}
+	return nil
+}
+
+// apply prepends stored session headers to itemHeaders, skipping any header
+// the item already sets explicitly.
+func (s *batchSession) apply(itemHeaders []string) []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if len(s.headers) == 0 {
+		return itemHeaders
+	}
+
+	explicit := make(map[string]struct{}, len(itemHeaders))
+	for _, pair := range itemHeaders {
+		key, _, _ := strings.Cut(pair, ":")
+		explicit[http.CanonicalHeaderKey(strings.TrimSpace(key))] = struct{}{}
+	}
+
+	keys := make([]string, 0, len(s.headers))
+	for key := range s.headers {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	out := make([]string, 0, len(keys)+len(itemHeaders))
+	for _, key := range keys {
+		if _, ok := explicit[key]; ok {
+			continue
+		}
+		out = append(out, key+":"+s.headers[key])
+	}
+	return append(out, itemHeaders...)
+}
+
+// capture extracts the selected response field and stores it as a header.
+// A body that does not hold the field is a usage error: the select path
+// does not fit the endpoint.
+func (s *batchSession) capture(capture *callBatchHeaderCapture, body []byte) error {
+	var payload any
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return &igwerr.UsageError{Msg: fmt.Sprintf("setHeaderFromResponse: response body is not JSON: %v", err)}
+	}
+	value, err := extractJSONPathRaw(payload, strings.TrimSpace(capture.Select))
+	if err != nil {
+		return &igwerr.UsageError{Msg: fmt.Sprintf("setHeaderFromResponse: select %q: %v", strings.TrimSpace(capture.Select), err)}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.headers[http.CanonicalHeaderKey(strings.TrimSpace(capture.Header))] = value
+	return nil
+}
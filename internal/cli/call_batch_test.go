@@ -7,6 +7,7 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+	"sync"
 	"sync/atomic"
 	"testing"
 	"time"
@@ -282,3 +283,381 @@ func TestRunCallBatchParallelHandlesLargeInputWithoutDeadlock(t *testing.T) {
 		t.Fatalf("expected %d ndjson lines, got %d", len(items), lines)
 	}
 }
+
+func TestCallBatchSessionHeaderChainsLoginToken(t *testing.T) {
+	t.Parallel()
+
+	var gotSession string
+	client := newMockHTTPClient(func(r *http.Request) (*http.Response, error) {
+		if r.URL.Path == "/login" {
+			return mockHTTPResponse(http.StatusOK, `{"data":{"token":"abc123"}}`, nil), nil
+		}
+		gotSession = r.Header.Get("X-Session")
+		return mockHTTPResponse(http.StatusOK, `{"ok":true}`, nil), nil
+	})
+
+	dir := t.TempDir()
+	batchFile := filepath.Join(dir, "batch.ndjson")
+	content := strings.Join([]string{
+		`{"id":"login","method":"GET","path":"/login","setHeaderFromResponse":{"header":"X-Session","select":"data.token"}}`,
+		`{"id":"info","method":"GET","path":"/data/api/v1/gateway-info","useSessionHeaders":true}`,
+	}, "\n")
+	if err := os.WriteFile(batchFile, []byte(content), 0o600); err != nil {
+		t.Fatalf("write batch file: %v", err)
+	}
+
+	var out bytes.Buffer
+	c := &CLI{
+		In:     strings.NewReader(""),
+		Out:    &out,
+		Err:    new(bytes.Buffer),
+		Getenv: func(string) string { return "" },
+		ReadConfig: func() (config.File, error) {
+			return config.File{}, nil
+		},
+		HTTPClient: client,
+	}
+
+	if err := c.Execute([]string{
+		"call",
+		"--gateway-url", mockGatewayURL,
+		"--api-key", "secret",
+		"--batch", "@" + batchFile,
+		"--parallel", "4",
+	}); err != nil {
+		t.Fatalf("call batch failed: %v\n%s", err, out.String())
+	}
+
+	if gotSession != "abc123" {
+		t.Fatalf("expected session header abc123, got %q", gotSession)
+	}
+}
+
+func TestCallBatchSessionHeaderMissingSelectFailsItem(t *testing.T) {
+	t.Parallel()
+
+	client := newMockHTTPClient(func(r *http.Request) (*http.Response, error) {
+		return mockHTTPResponse(http.StatusOK, `{"data":{}}`, nil), nil
+	})
+
+	dir := t.TempDir()
+	batchFile := filepath.Join(dir, "batch.ndjson")
+	content := `{"id":"login","method":"GET","path":"/login","setHeaderFromResponse":{"header":"X-Session","select":"data.token"}}`
+	if err := os.WriteFile(batchFile, []byte(content), 0o600); err != nil {
+		t.Fatalf("write batch file: %v", err)
+	}
+
+	c := &CLI{
+		In:     strings.NewReader(""),
+		Out:    new(bytes.Buffer),
+		Err:    new(bytes.Buffer),
+		Getenv: func(string) string { return "" },
+		ReadConfig: func() (config.File, error) {
+			return config.File{}, nil
+		},
+		HTTPClient: client,
+	}
+
+	err := c.Execute([]string{
+		"call",
+		"--gateway-url", mockGatewayURL,
+		"--api-key", "secret",
+		"--batch", "@" + batchFile,
+	})
+	requireUsageExitCode(t, err)
+}
+
+func TestCallBatchHeaderFromResponseFlag(t *testing.T) {
+	t.Parallel()
+
+	var mu sync.Mutex
+	var sessions []string
+	client := newMockHTTPClient(func(r *http.Request) (*http.Response, error) {
+		if r.URL.Path == "/login" {
+			return mockHTTPResponse(http.StatusOK, `{"data":{"token":"abc123"}}`, nil), nil
+		}
+		mu.Lock()
+		sessions = append(sessions, r.Header.Get("X-Session"))
+		mu.Unlock()
+		return mockHTTPResponse(http.StatusOK, `{"ok":true}`, nil), nil
+	})
+
+	batchFile := filepath.Join(t.TempDir(), "batch.ndjson")
+	content := strings.Join([]string{
+		`{"id":"login","method":"GET","path":"/login"}`,
+		`{"id":"a","method":"GET","path":"/data/api/v1/gateway-info"}`,
+		`{"id":"b","method":"GET","path":"/data/api/v1/gateway-info"}`,
+	}, "\n")
+	if err := os.WriteFile(batchFile, []byte(content), 0o600); err != nil {
+		t.Fatalf("write batch file: %v", err)
+	}
+
+	var out bytes.Buffer
+	c := newAdminWrapperTestCLI(client)
+	c.Out = &out
+	base := []string{"call", "--gateway-url", mockGatewayURL, "--api-key", "secret"}
+
+	requireUsageExitCode(t, c.Execute(append(base, "--path", "/x", "--header-from-response", "X-Session=data.token")))
+	requireUsageExitCode(t, c.Execute(append(base, "--batch", "@"+batchFile, "--header-from-response", "X-Session")))
+
+	if err := c.Execute(append(base, "--batch", "@"+batchFile, "--parallel", "4", "--header-from-response", "X-Session=data.token")); err != nil {
+		t.Fatalf("call batch failed: %v\n%s", err, out.String())
+	}
+	if len(sessions) != 2 || sessions[0] != "abc123" || sessions[1] != "abc123" {
+		t.Fatalf("expected every later item to send the captured header, got %q", sessions)
+	}
+}
+
+func TestRunCallBatchAdaptiveRateReducesConcurrency(t *testing.T) {
+	t.Parallel()
+
+	const total = 30
+	var (
+		calls     int32
+		active    int32
+		lateMax   int32
+		earlyMax  int32
+		completed int32
+	)
+	recordMax := func(target *int32, value int32) {
+		for {
+			recorded := atomic.LoadInt32(target)
+			if value <= recorded || atomic.CompareAndSwapInt32(target, recorded, value) {
+				return
+			}
+		}
+	}
+	client := newMockHTTPClient(func(r *http.Request) (*http.Response, error) {
+		n := atomic.AddInt32(&calls, 1)
+		current := atomic.AddInt32(&active, 1)
+		defer atomic.AddInt32(&active, -1)
+		if n <= 4 {
+			recordMax(&earlyMax, current)
+		}
+		if n > total-10 {
+			recordMax(&lateMax, current)
+		}
+		time.Sleep(20 * time.Millisecond)
+
+		remaining := "100"
+		if atomic.AddInt32(&completed, 1) > 4 {
+			remaining = "0"
+		}
+		headers := http.Header{}
+		headers.Set("X-RateLimit-Remaining", remaining)
+		return mockHTTPResponse(http.StatusOK, `{"ok":true}`, headers), nil
+	})
+
+	dir := t.TempDir()
+	batchFile := filepath.Join(dir, "batch.ndjson")
+	items := make([]string, total)
+	for i := range items {
+		items[i] = `{"method":"GET","path":"/data/api/v1/gateway-info"}`
+	}
+	if err := os.WriteFile(batchFile, []byte(strings.Join(items, "\n")), 0o600); err != nil {
+		t.Fatalf("write batch file: %v", err)
+	}
+
+	c := &CLI{
+		In:     strings.NewReader(""),
+		Out:    new(bytes.Buffer),
+		Err:    new(bytes.Buffer),
+		Getenv: func(string) string { return "" },
+		ReadConfig: func() (config.File, error) {
+			return config.File{}, nil
+		},
+		HTTPClient: client,
+	}
+
+	err := c.runCallBatch(mockGatewayURL, "secret", "@"+batchFile, callBatchDefaults{
+		RetryBackoff:       250 * time.Millisecond,
+		Timeout:            2 * time.Second,
+		OutputFormat:       "ndjson",
+		Parallel:           4,
+		AdaptiveRate:       true,
+		AdaptiveRateHeader: "X-RateLimit-Remaining",
+	})
+	if err != nil {
+		t.Fatalf("runCallBatch failed: %v", err)
+	}
+
+	if got := atomic.LoadInt32(&earlyMax); got < 2 {
+		t.Fatalf("expected early concurrency >= 2, got %d", got)
+	}
+	if got := atomic.LoadInt32(&lateMax); got != 1 {
+		t.Fatalf("expected throttled concurrency of 1, got %d", got)
+	}
+}
+
+func TestRunCallBatchAdaptiveRateReadsRateLimitedErrors(t *testing.T) {
+	t.Parallel()
+
+	const total = 12
+	var (
+		calls   int32
+		active  int32
+		lateMax int32
+	)
+	client := newMockHTTPClient(func(r *http.Request) (*http.Response, error) {
+		n := atomic.AddInt32(&calls, 1)
+		current := atomic.AddInt32(&active, 1)
+		defer atomic.AddInt32(&active, -1)
+		if n > 4 {
+			for {
+				recorded := atomic.LoadInt32(&lateMax)
+				if current <= recorded || atomic.CompareAndSwapInt32(&lateMax, recorded, current) {
+					break
+				}
+			}
+		}
+		time.Sleep(20 * time.Millisecond)
+
+		headers := http.Header{}
+		headers.Set("X-RateLimit-Remaining", "0")
+		return mockHTTPResponse(http.StatusTooManyRequests, `{"error":"slow down"}`, headers), nil
+	})
+
+	dir := t.TempDir()
+	batchFile := filepath.Join(dir, "batch.ndjson")
+	items := make([]string, total)
+	for i := range items {
+		items[i] = `{"method":"GET","path":"/data/api/v1/gateway-info"}`
+	}
+	if err := os.WriteFile(batchFile, []byte(strings.Join(items, "\n")), 0o600); err != nil {
+		t.Fatalf("write batch file: %v", err)
+	}
+
+	c := newAdminWrapperTestCLI(client)
+
+	// Every item fails, so the batch error itself is expected.
+	_ = c.runCallBatch(mockGatewayURL, "secret", "@"+batchFile, callBatchDefaults{
+		Timeout:            2 * time.Second,
+		OutputFormat:       "ndjson",
+		Parallel:           4,
+		AdaptiveRate:       true,
+		AdaptiveRateHeader: "X-RateLimit-Remaining",
+	})
+
+	if got := atomic.LoadInt32(&calls); got != total {
+		t.Fatalf("expected %d requests, got %d", total, got)
+	}
+	if got := atomic.LoadInt32(&lateMax); got != 1 {
+		t.Fatalf("expected a 429 with no remaining budget to throttle to 1, got %d", got)
+	}
+}
+
+func TestRunCallBatchRotatesOutputFiles(t *testing.T) {
+	t.Parallel()
+
+	client := newMockHTTPClient(func(r *http.Request) (*http.Response, error) {
+		return mockHTTPResponse(http.StatusOK, `{"ok":true}`, nil), nil
+	})
+
+	dir := t.TempDir()
+	batchFile := filepath.Join(dir, "batch.ndjson")
+	items := make([]string, 20)
+	for i := range items {
+		items[i] = `{"method":"GET","path":"/data/api/v1/gateway-info"}`
+	}
+	if err := os.WriteFile(batchFile, []byte(strings.Join(items, "\n")), 0o600); err != nil {
+		t.Fatalf("write batch file: %v", err)
+	}
+
+	var out, errOut bytes.Buffer
+	c := &CLI{
+		In:     strings.NewReader(""),
+		Out:    &out,
+		Err:    &errOut,
+		Getenv: func(string) string { return "" },
+		ReadConfig: func() (config.File, error) {
+			return config.File{}, nil
+		},
+		HTTPClient: client,
+	}
+
+	const maxSize = 1024
+	prefix := filepath.Join(dir, "results")
+	if err := c.runCallBatch(mockGatewayURL, "secret", "@"+batchFile, callBatchDefaults{
+		RetryBackoff:    250 * time.Millisecond,
+		Timeout:         2 * time.Second,
+		OutputFormat:    "ndjson",
+		Parallel:        1,
+		BatchOut:        prefix,
+		BatchOutMaxSize: maxSize,
+	}); err != nil {
+		t.Fatalf("runCallBatch failed: %v", err)
+	}
+
+	files, err := filepath.Glob(prefix + "-*.ndjson")
+	if err != nil {
+		t.Fatalf("glob output files: %v", err)
+	}
+	if len(files) < 2 {
+		t.Fatalf("expected multiple rotated files, got %v", files)
+	}
+	if files[0] != prefix+"-0001.ndjson" {
+		t.Fatalf("expected zero-padded first file, got %q", files[0])
+	}
+
+	lines := 0
+	for _, name := range files {
+		data, err := os.ReadFile(name)
+		if err != nil {
+			t.Fatalf("read %s: %v", name, err)
+		}
+		if len(data) > maxSize {
+			t.Fatalf("%s exceeds cap: %d bytes", name, len(data))
+		}
+		lines += strings.Count(string(data), "\n")
+		if !strings.Contains(errOut.String(), "wrote batch results: "+name) {
+			t.Fatalf("expected %s to be reported on stderr, got %q", name, errOut.String())
+		}
+	}
+	if out.Len() != 0 {
+		t.Fatalf("expected nothing on stdout with --batch-out, got %q", out.String())
+	}
+	if lines != len(items) {
+		t.Fatalf("expected %d results across files, got %d", len(items), lines)
+	}
+}
+
+func TestCallBatchRateRecordsLimiterWait(t *testing.T) {
+	t.Parallel()
+
+	var out bytes.Buffer
+	c := &CLI{
+		In:     strings.NewReader(strings.Repeat(`{"method":"GET","path":"/data/api/v1/gateway-info"}`+"\n", 3)),
+		Out:    &out,
+		Err:    new(bytes.Buffer),
+		Getenv: func(string) string { return "" },
+		ReadConfig: func() (config.File, error) {
+			return config.File{}, nil
+		},
+		HTTPClient: newMockHTTPClient(func(*http.Request) (*http.Response, error) {
+			return mockHTTPResponse(http.StatusOK, `{}`, nil), nil
+		}),
+	}
+	base := []string{"call", "--gateway-url", mockGatewayURL, "--api-key", "secret"}
+
+	start := time.Now()
+	if err := c.Execute(append(base, "--batch", "-", "--parallel", "3", "--rate", "50")); err != nil {
+		t.Fatalf("batch --rate: %v", err)
+	}
+	if elapsed := time.Since(start); elapsed < 35*time.Millisecond {
+		t.Fatalf("expected 3 requests at 50/s to take at least 40ms, took %v", elapsed)
+	}
+	var waited int64
+	for _, line := range strings.Split(strings.TrimSpace(out.String()), "\n") {
+		var result callBatchItemResult
+		if err := json.Unmarshal([]byte(line), &result); err != nil {
+			t.Fatalf("parse %q: %v", line, err)
+		}
+		waited += result.Stats.RateWaitMs
+	}
+	if waited < 30 {
+		t.Fatalf("expected stats.rateWaitMs to record limiter waits, got %dms total", waited)
+	}
+
+	requireUsageExitCode(t, c.Execute(append(base, "--path", "/x", "--rate", "2")))
+	requireUsageExitCode(t, c.Execute(append(base, "--batch", "-", "--rate", "-1")))
+}
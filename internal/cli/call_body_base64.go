@@ -0,0 +1,33 @@
+package cli
+
+import (
+	"encoding/base64"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/alex-mccollum/igw-cli/internal/igwerr"
+)
+
+// readBase64Body reads --body-base64 input like readBody and decodes it.
+// Standard and URL-safe alphabets are accepted, padded or not, and
+// whitespace (such as line wrapping from base64 tools) is ignored.
+func readBase64Body(stdin io.Reader, input string) ([]byte, error) {
+	encoded, err := readBody(stdin, input)
+	if err != nil {
+		return nil, err
+	}
+	text := strings.Join(strings.Fields(string(encoded)), "")
+	if text == "" {
+		return nil, &igwerr.UsageError{Msg: "--body-base64 is empty"}
+	}
+
+	encodings := []*base64.Encoding{base64.StdEncoding, base64.RawStdEncoding, base64.URLEncoding, base64.RawURLEncoding}
+	for _, enc := range encodings {
+		if decoded, decodeErr := enc.DecodeString(text); decodeErr == nil {
+			return decoded, nil
+		}
+	}
+	_, decodeErr := base64.StdEncoding.DecodeString(text)
+	return nil, &igwerr.UsageError{Msg: fmt.Sprintf("invalid --body-base64: %v", decodeErr)}
+}
@@ -0,0 +1,90 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/base64"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestCallBodyBase64SendsDecodedBytes(t *testing.T) {
+	t.Parallel()
+
+	raw := []byte{0x50, 0x4b, 0x03, 0x04, 0x00, 0xff, 0xfe, '\n'}
+	encoded := base64.StdEncoding.EncodeToString(raw)
+
+	bodyFile := filepath.Join(t.TempDir(), "backup.b64")
+	// Wrapped output as produced by `base64` tools must still decode.
+	if err := os.WriteFile(bodyFile, []byte(encoded[:4]+"\n"+encoded[4:]+"\n"), 0o600); err != nil {
+		t.Fatalf("write body file: %v", err)
+	}
+
+	for _, input := range []string{encoded, "@" + bodyFile} {
+		var gotBody []byte
+		client := newMockHTTPClient(func(r *http.Request) (*http.Response, error) {
+			gotBody, _ = io.ReadAll(r.Body)
+			return mockHTTPResponse(http.StatusOK, `{"ok":true}`, nil), nil
+		})
+
+		c := newAdminWrapperTestCLI(client)
+		if err := c.Execute([]string{
+			"call",
+			"--gateway-url", mockGatewayURL,
+			"--api-key", "secret",
+			"--method", "POST",
+			"--path", "/data/api/v1/backup",
+			"--body-base64", input,
+			"--content-type", "application/octet-stream",
+			"--yes",
+		}); err != nil {
+			t.Fatalf("call with %q failed: %v", input, err)
+		}
+		if !bytes.Equal(gotBody, raw) {
+			t.Fatalf("input %q: server received %v, want %v", input, gotBody, raw)
+		}
+	}
+}
+
+func TestCallBodyBase64InvalidIsUsageError(t *testing.T) {
+	t.Parallel()
+
+	called := false
+	client := newMockHTTPClient(func(r *http.Request) (*http.Response, error) {
+		called = true
+		return mockHTTPResponse(http.StatusOK, `{}`, nil), nil
+	})
+
+	c := newAdminWrapperTestCLI(client)
+	err := c.Execute([]string{
+		"call",
+		"--gateway-url", mockGatewayURL,
+		"--api-key", "secret",
+		"--method", "POST",
+		"--path", "/data/api/v1/backup",
+		"--body-base64", "not*base64!",
+		"--yes",
+	})
+	requireUsageExitCode(t, err)
+	if called {
+		t.Fatalf("request should not be sent when --body-base64 is invalid")
+	}
+}
+
+func TestCallBodyBase64ConflictsWithBody(t *testing.T) {
+	t.Parallel()
+
+	c := newAdminWrapperTestCLI(nil)
+	requireUsageExitCode(t, c.Execute([]string{
+		"call",
+		"--gateway-url", mockGatewayURL,
+		"--api-key", "secret",
+		"--method", "POST",
+		"--path", "/data/api/v1/backup",
+		"--body", "{}",
+		"--body-base64", "e30=",
+		"--yes",
+	}))
+}
@@ -0,0 +1,51 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/alex-mccollum/igw-cli/internal/igwerr"
+	"github.com/alex-mccollum/igw-cli/internal/jqlite"
+)
+
+// compileBodyJQ validates --body-jq up front so malformed expressions fail
+// before any config resolution or network work.
+func compileBodyJQ(expr string, body string) (*jqlite.Query, error) {
+	expr = strings.TrimSpace(expr)
+	if expr == "" {
+		return nil, nil
+	}
+	if body == "" {
+		return nil, &igwerr.UsageError{Msg: "--body-jq requires --body"}
+	}
+	query, err := jqlite.Compile(expr)
+	if err != nil {
+		return nil, &igwerr.UsageError{Msg: fmt.Sprintf("invalid --body-jq expression: %v", err)}
+	}
+	return query, nil
+}
+
+// applyBodyJQ runs query against the JSON request body and returns the single
+// emitted value re-encoded as the new body. Numbers decode as json.Number so
+// ids beyond 2^53 are sent with every digit.
+func applyBodyJQ(query *jqlite.Query, body []byte) ([]byte, error) {
+	input, err := jsonPathReader{preserveNumbers: true}.decode(body)
+	if err != nil {
+		return nil, &igwerr.UsageError{Msg: fmt.Sprintf("--body-jq requires a JSON body: %v", err)}
+	}
+
+	results, err := query.Run(input)
+	if err != nil {
+		return nil, &igwerr.UsageError{Msg: fmt.Sprintf("--body-jq: %v", err)}
+	}
+	if len(results) != 1 {
+		return nil, &igwerr.UsageError{Msg: fmt.Sprintf("--body-jq must produce exactly one value, got %d", len(results))}
+	}
+
+	out, err := json.Marshal(results[0])
+	if err != nil {
+		return nil, &igwerr.UsageError{Msg: fmt.Sprintf("--body-jq: encode result: %v", err)}
+	}
+	return out, nil
+}
@@ -0,0 +1,132 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/alex-mccollum/igw-cli/internal/config"
+	"github.com/alex-mccollum/igw-cli/internal/igwerr"
+)
+
+func TestCallBodyJQTransformsBodySubset(t *testing.T) {
+	t.Parallel()
+
+	var gotBody []byte
+	client := newMockHTTPClient(func(r *http.Request) (*http.Response, error) {
+		gotBody, _ = io.ReadAll(r.Body)
+		return mockHTTPResponse(http.StatusOK, `{"ok":true}`, nil), nil
+	})
+
+	bodyFile := filepath.Join(t.TempDir(), "body.json")
+	if err := os.WriteFile(bodyFile, []byte(`{"name":"demo","enabled":true,"secret":"x","tags":[1,2],"id":9007199254740993}`), 0o600); err != nil {
+		t.Fatalf("write body file: %v", err)
+	}
+
+	c := &CLI{
+		In:     strings.NewReader(""),
+		Out:    new(bytes.Buffer),
+		Err:    new(bytes.Buffer),
+		Getenv: func(string) string { return "" },
+		ReadConfig: func() (config.File, error) {
+			return config.File{}, nil
+		},
+		HTTPClient: client,
+	}
+
+	if err := c.Execute([]string{
+		"call",
+		"--gateway-url", mockGatewayURL,
+		"--api-key", "secret",
+		"--method", "POST",
+		"--path", "/data/api/v1/scan/projects",
+		"--body", "@" + bodyFile,
+		"--body-jq", "{name, enabled, id, count: (.tags | length)}",
+		"--yes",
+	}); err != nil {
+		t.Fatalf("call failed: %v", err)
+	}
+
+	if !bytes.Contains(gotBody, []byte(`"id":9007199254740993`)) {
+		t.Fatalf("expected the id sent with every digit, got %s", gotBody)
+	}
+	var payload map[string]any
+	if err := json.Unmarshal(gotBody, &payload); err != nil {
+		t.Fatalf("decode sent body %q: %v", gotBody, err)
+	}
+	if len(payload) != 4 || payload["name"] != "demo" || payload["enabled"] != true || payload["count"] != float64(2) {
+		t.Fatalf("unexpected transformed body: %#v", payload)
+	}
+}
+
+func TestCallBodyJQInvalidExpressionIsUsageError(t *testing.T) {
+	t.Parallel()
+
+	var errOut bytes.Buffer
+	c := &CLI{
+		In:     strings.NewReader(`{"a":1}`),
+		Out:    new(bytes.Buffer),
+		Err:    &errOut,
+		Getenv: func(string) string { return "" },
+		ReadConfig: func() (config.File, error) {
+			return config.File{}, nil
+		},
+		HTTPClient: newMockHTTPClient(func(*http.Request) (*http.Response, error) {
+			t.Fatalf("request should not be sent")
+			return nil, nil
+		}),
+	}
+
+	err := c.Execute([]string{
+		"call",
+		"--gateway-url", mockGatewayURL,
+		"--api-key", "secret",
+		"--method", "POST",
+		"--path", "/data/api/v1/scan/projects",
+		"--body", "-",
+		"--body-jq", "{a: }",
+		"--yes",
+	})
+	if err == nil {
+		t.Fatalf("expected invalid expression error")
+	}
+	if code := igwerr.ExitCode(err); code != 2 {
+		t.Fatalf("expected exit code 2, got %d", code)
+	}
+	if !strings.Contains(errOut.String(), "invalid --body-jq expression") {
+		t.Fatalf("unexpected stderr: %q", errOut.String())
+	}
+}
+
+func TestCallBodyJQRuntimeErrorIsUsageError(t *testing.T) {
+	t.Parallel()
+
+	c := &CLI{
+		In:     strings.NewReader(`{"a":"text"}`),
+		Out:    new(bytes.Buffer),
+		Err:    new(bytes.Buffer),
+		Getenv: func(string) string { return "" },
+		ReadConfig: func() (config.File, error) {
+			return config.File{}, nil
+		},
+	}
+
+	err := c.Execute([]string{
+		"call",
+		"--gateway-url", mockGatewayURL,
+		"--api-key", "secret",
+		"--method", "POST",
+		"--path", "/data/api/v1/scan/projects",
+		"--body", "-",
+		"--body-jq", ".a[]",
+		"--yes",
+	})
+	if code := igwerr.ExitCode(err); code != 2 {
+		t.Fatalf("expected exit code 2, got %d (%v)", code, err)
+	}
+}
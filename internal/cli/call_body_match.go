@@ -0,0 +1,66 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/alex-mccollum/igw-cli/internal/gateway"
+	"github.com/alex-mccollum/igw-cli/internal/igwerr"
+)
+
+const bodyMatchHint = "response body matched --retry-on-body-match"
+
+// bodyMatcher compares one dot-path field of a JSON response body against an
+// expected raw value, e.g. "error==temporarily unavailable".
+type bodyMatcher struct {
+	selector string
+	value    string
+}
+
+func parseBodyMatcher(spec string) (*bodyMatcher, error) {
+	spec = strings.TrimSpace(spec)
+	if spec == "" {
+		return nil, nil
+	}
+	selector, value, ok := strings.Cut(spec, "==")
+	selector = strings.TrimSpace(selector)
+	if !ok || selector == "" {
+		return nil, &igwerr.UsageError{Msg: fmt.Sprintf("invalid --retry-on-body-match %q (expected <select>==<value>)", spec)}
+	}
+	return &bodyMatcher{selector: selector, value: strings.TrimSpace(value)}, nil
+}
+
+// Matches reports whether body decodes as JSON and the selected field renders
+// to the expected value. Non-JSON bodies and missing fields never match.
+func (m *bodyMatcher) Matches(body []byte) bool {
+	if m == nil || len(body) == 0 {
+		return false
+	}
+	var payload any
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return false
+	}
+	raw, err := extractJSONPathRaw(payload, m.selector)
+	if err != nil {
+		return false
+	}
+	return raw == m.value
+}
+
+func (m *bodyMatcher) retryFunc() func([]byte) bool {
+	if m == nil {
+		return nil
+	}
+	return m.Matches
+}
+
+// bodyMatchError is reported when --fail-on-body-match is set and the final
+// attempt still matches; it maps to the network/other HTTP exit code.
+func bodyMatchError(resp *gateway.CallResponse) error {
+	return &igwerr.StatusError{
+		StatusCode: resp.StatusCode,
+		Body:       string(resp.Body),
+		Hint:       bodyMatchHint,
+	}
+}
@@ -0,0 +1,94 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/alex-mccollum/igw-cli/internal/igwerr"
+)
+
+func TestCallRetryOnBodyMatchRetriesUntilSuccess(t *testing.T) {
+	t.Parallel()
+
+	var calls int32
+	client := newMockHTTPClient(func(*http.Request) (*http.Response, error) {
+		if atomic.AddInt32(&calls, 1) == 1 {
+			return mockHTTPResponse(http.StatusOK, `{"error":"temporarily unavailable"}`, nil), nil
+		}
+		return mockHTTPResponse(http.StatusOK, `{"ok":true}`, nil), nil
+	})
+
+	var out bytes.Buffer
+	c := newAdminWrapperTestCLI(client)
+	c.Out = &out
+	if err := c.Execute([]string{
+		"call",
+		"--gateway-url", mockGatewayURL,
+		"--api-key", "secret",
+		"--path", "/data/api/v1/gateway-info",
+		"--retry", "2",
+		"--retry-backoff", "1ms",
+		"--retry-on-body-match", "error==temporarily unavailable",
+		"--fail-on-body-match",
+		"--json",
+	}); err != nil {
+		t.Fatalf("call failed: %v", err)
+	}
+
+	if got := atomic.LoadInt32(&calls); got != 2 {
+		t.Fatalf("expected 2 attempts, got %d", got)
+	}
+	var payload callJSONEnvelope
+	if err := json.Unmarshal(out.Bytes(), &payload); err != nil {
+		t.Fatalf("decode output: %v", err)
+	}
+	if !payload.OK || payload.Response.Body != `{"ok":true}` {
+		t.Fatalf("unexpected payload: %#v", payload)
+	}
+}
+
+func TestCallFailOnBodyMatchReturnsBodyWithNetworkExit(t *testing.T) {
+	t.Parallel()
+
+	client := newMockHTTPClient(func(*http.Request) (*http.Response, error) {
+		return mockHTTPResponse(http.StatusOK, `{"error":"temporarily unavailable"}`, nil), nil
+	})
+
+	var out bytes.Buffer
+	c := newAdminWrapperTestCLI(client)
+	c.Out = &out
+	err := c.Execute([]string{
+		"call",
+		"--gateway-url", mockGatewayURL,
+		"--api-key", "secret",
+		"--path", "/data/api/v1/gateway-info",
+		"--retry", "1",
+		"--retry-backoff", "1ms",
+		"--retry-on-body-match", "error==temporarily unavailable",
+		"--fail-on-body-match",
+	})
+	if code := igwerr.ExitCode(err); code != 7 {
+		t.Fatalf("expected exit code 7, got %d (%v)", code, err)
+	}
+	if !strings.Contains(out.String(), "temporarily unavailable") {
+		t.Fatalf("expected response body on stdout, got %q", out.String())
+	}
+}
+
+func TestCallRetryOnBodyMatchRejectsInvalidSpec(t *testing.T) {
+	t.Parallel()
+
+	c := newAdminWrapperTestCLI(nil)
+	err := c.Execute([]string{
+		"call",
+		"--gateway-url", mockGatewayURL,
+		"--api-key", "secret",
+		"--path", "/data/api/v1/gateway-info",
+		"--retry-on-body-match", "error",
+	})
+	requireUsageExitCode(t, err)
+}
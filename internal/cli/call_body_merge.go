@@ -0,0 +1,130 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime"
+	"strings"
+
+	"github.com/alex-mccollum/igw-cli/internal/igwerr"
+)
+
+func isJSONContentType(contentType string) bool {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return false
+	}
+	return mediaType == "application/json" || strings.HasSuffix(mediaType, "+json")
+}
+
+// validateBodyMerge checks --body-merge and --merge-arrays up front so bad
+// combinations fail before any config resolution or network work.
+func validateBodyMerge(merges []string, mergeArrays bool, body string, contentType string) error {
+	if len(merges) == 0 {
+		if mergeArrays {
+			return &igwerr.UsageError{Msg: "--merge-arrays requires --body-merge"}
+		}
+		return nil
+	}
+	if strings.TrimSpace(body) == "" {
+		return &igwerr.UsageError{Msg: "--body-merge requires --body"}
+	}
+	if ct := strings.TrimSpace(contentType); ct != "" && !isJSONContentType(ct) {
+		return &igwerr.UsageError{Msg: fmt.Sprintf("--body-merge requires a JSON content type, got %q", ct)}
+	}
+	stdinSources := 0
+	for _, source := range append([]string{body}, merges...) {
+		if source == "-" {
+			stdinSources++
+		}
+	}
+	if stdinSources > 1 {
+		return &igwerr.UsageError{Msg: "only one of --body and --body-merge can read from stdin"}
+	}
+	return nil
+}
+
+// mergeBodyFragments deep-merges each --body-merge fragment into base. Later
+// fragments override earlier keys; nested objects merge recursively, and
+// arrays are replaced unless concat is set.
+func mergeBodyFragments(stdin io.Reader, base []byte, merges []string, concat bool) ([]byte, error) {
+	merged, err := decodeBodyFragment("--body", base)
+	if err != nil {
+		return nil, err
+	}
+	for _, source := range merges {
+		raw, err := readBody(stdin, source)
+		if err != nil {
+			return nil, err
+		}
+		fragment, err := decodeBodyFragment(fmt.Sprintf("--body-merge %s", source), raw)
+		if err != nil {
+			return nil, err
+		}
+		deepMergeObjects(merged, fragment, concat)
+	}
+
+	out, err := json.Marshal(merged)
+	if err != nil {
+		return nil, &igwerr.UsageError{Msg: fmt.Sprintf("--body-merge: encode result: %v", err)}
+	}
+	return out, nil
+}
+
+func decodeBodyFragment(name string, raw []byte) (map[string]any, error) {
+	decoder := json.NewDecoder(bytes.NewReader(raw))
+	decoder.UseNumber()
+	var value any
+	if err := decoder.Decode(&value); err != nil {
+		return nil, &igwerr.UsageError{Msg: fmt.Sprintf("%s is not valid JSON: %v", name, err)}
+	}
+	object, ok := value.(map[string]any)
+	if !ok {
+		return nil, &igwerr.UsageError{Msg: fmt.Sprintf("%s must be a JSON object to merge, got %s", name, jsonKind(value))}
+	}
+	return object, nil
+}
+
+func deepMergeObjects(dst map[string]any, src map[string]any, concat bool) {
+	for key, value := range src {
+		existing, ok := dst[key]
+		if !ok {
+			dst[key] = value
+			continue
+		}
+		if dstObject, ok := existing.(map[string]any); ok {
+			if srcObject, ok := value.(map[string]any); ok {
+				deepMergeObjects(dstObject, srcObject, concat)
+				continue
+			}
+		}
+		if concat {
+			if dstArray, ok := existing.([]any); ok {
+				if srcArray, ok := value.([]any); ok {
+					dst[key] = append(dstArray, srcArray...)
+					continue
+				}
+			}
+		}
+		dst[key] = value
+	}
+}
+
+func jsonKind(value any) string {
+	switch value.(type) {
+	case nil:
+		return "null"
+	case []any:
+		return "an array"
+	case string:
+		return "a string"
+	case bool:
+		return "a boolean"
+	case json.Number:
+		return "a number"
+	default:
+		return fmt.Sprintf("%T", value)
+	}
+}
@@ -0,0 +1,133 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/alex-mccollum/igw-cli/internal/config"
+)
+
+func TestCallBodyMergeDeepMergesFiles(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	basePath := filepath.Join(dir, "base.json")
+	overridePath := filepath.Join(dir, "override.json")
+	if err := os.WriteFile(basePath, []byte(`{"name":"gw","settings":{"port":8088,"tls":false},"tags":["a"]}`), 0o600); err != nil {
+		t.Fatalf("write base: %v", err)
+	}
+	if err := os.WriteFile(overridePath, []byte(`{"settings":{"tls":true},"tags":["b"]}`), 0o600); err != nil {
+		t.Fatalf("write override: %v", err)
+	}
+
+	var sent []byte
+	c := &CLI{
+		In:     strings.NewReader(""),
+		Out:    new(bytes.Buffer),
+		Err:    new(bytes.Buffer),
+		Getenv: func(string) string { return "" },
+		ReadConfig: func() (config.File, error) {
+			return config.File{}, nil
+		},
+		HTTPClient: newMockHTTPClient(func(r *http.Request) (*http.Response, error) {
+			sent, _ = io.ReadAll(r.Body)
+			return mockHTTPResponse(http.StatusOK, `{}`, nil), nil
+		}),
+	}
+	err := c.Execute([]string{
+		"call",
+		"--gateway-url", mockGatewayURL,
+		"--api-key", "secret",
+		"--method", "PUT",
+		"--path", "/data/api/v1/config",
+		"--yes",
+		"--body", "@" + basePath,
+		"--body-merge", "@" + overridePath,
+		"--body-merge", `{"name":"gw2"}`,
+	})
+	if err != nil {
+		t.Fatalf("call failed: %v", err)
+	}
+
+	var got map[string]any
+	if err := json.Unmarshal(sent, &got); err != nil {
+		t.Fatalf("decode sent body %q: %v", sent, err)
+	}
+	want := map[string]any{
+		"name":     "gw2",
+		"settings": map[string]any{"port": float64(8088), "tls": true},
+		"tags":     []any{"b"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("merged body = %v, want %v", got, want)
+	}
+}
+
+func TestMergeBodyFragmentsArrays(t *testing.T) {
+	t.Parallel()
+
+	base := []byte(`{"items":[1,2],"nested":{"items":["x"]}}`)
+	merges := []string{`{"items":[3],"nested":{"items":["y"]}}`}
+
+	replaced, err := mergeBodyFragments(strings.NewReader(""), base, merges, false)
+	if err != nil {
+		t.Fatalf("replace merge: %v", err)
+	}
+	if got := string(replaced); got != `{"items":[3],"nested":{"items":["y"]}}` {
+		t.Fatalf("replace merge = %s", got)
+	}
+
+	concatenated, err := mergeBodyFragments(strings.NewReader(""), base, merges, true)
+	if err != nil {
+		t.Fatalf("concat merge: %v", err)
+	}
+	if got := string(concatenated); got != `{"items":[1,2,3],"nested":{"items":["x","y"]}}` {
+		t.Fatalf("concat merge = %s", got)
+	}
+}
+
+func TestBodyMergeRejectsNonObjectFragment(t *testing.T) {
+	t.Parallel()
+
+	_, err := mergeBodyFragments(strings.NewReader(""), []byte(`{"a":1}`), []string{`[1,2]`}, false)
+	requireUsageExitCode(t, err)
+	if !strings.Contains(err.Error(), "must be a JSON object to merge, got an array") {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	_, err = mergeBodyFragments(strings.NewReader(""), []byte(`"text"`), []string{`{"a":1}`}, false)
+	requireUsageExitCode(t, err)
+
+	for _, args := range [][]string{
+		{"--body", `{"a":1}`, "--body-merge", `{"b":2}`, "--content-type", "text/plain"},
+		{"--body-merge", `{"b":2}`},
+		{"--body", `{"a":1}`, "--merge-arrays"},
+		{"--body", "-", "--body-merge", "-"},
+	} {
+		c := &CLI{
+			In:     strings.NewReader(""),
+			Out:    new(bytes.Buffer),
+			Err:    new(bytes.Buffer),
+			Getenv: func(string) string { return "" },
+			ReadConfig: func() (config.File, error) {
+				return config.File{}, nil
+			},
+		}
+		err := c.Execute(append([]string{
+			"call",
+			"--gateway-url", mockGatewayURL,
+			"--api-key", "secret",
+			"--method", "POST",
+			"--path", "/data/api/v1/config",
+			"--yes",
+		}, args...))
+		requireUsageExitCode(t, err)
+	}
+}
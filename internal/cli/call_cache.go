@@ -0,0 +1,121 @@
+package cli
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/alex-mccollum/igw-cli/internal/config"
+	"github.com/alex-mccollum/igw-cli/internal/gateway"
+	"github.com/alex-mccollum/igw-cli/internal/igwerr"
+)
+
+// callCacheOptions holds --cache-ttl and --no-cache.
+type callCacheOptions struct {
+	ttl     time.Duration
+	noCache bool
+}
+
+func bindCallCacheFlags(fs *flag.FlagSet, opts *callCacheOptions) {
+	fs.DurationVar(&opts.ttl, "cache-ttl", 0, "Serve GET responses from the local cache for this long (0 = no caching)")
+	fs.BoolVar(&opts.noCache, "no-cache", false, "Skip the cached entry but store the fresh response (requires --cache-ttl)")
+}
+
+func (o callCacheOptions) enabled() bool {
+	return o.ttl > 0
+}
+
+// validate checks the cache flags against the other call modes. An empty
+// method defaults to GET; an --op method is checked once it is resolved.
+func (o callCacheOptions) validate(modes callModes, method string) error {
+	if o.ttl < 0 {
+		return &igwerr.UsageError{Msg: "--cache-ttl must be >= 0"}
+	}
+	if o.noCache && !o.enabled() {
+		return &igwerr.UsageError{Msg: "--no-cache requires --cache-ttl"}
+	}
+	if !o.enabled() {
+		return nil
+	}
+	if modes.batch || modes.repeat || modes.paginate || modes.stream || modes.sse || modes.fanout || modes.curl || modes.expand || modes.applyPatch || modes.dumpRaw {
+		return &igwerr.UsageError{Msg: "--cache-ttl is not supported with --batch, --repeat, --paginate, --stream, --sse, --all-profiles, --curl, --expand, --apply-patch, or --dump-raw"}
+	}
+	method = strings.ToUpper(strings.TrimSpace(method))
+	if method != "" && method != http.MethodGet {
+		return &igwerr.UsageError{Msg: fmt.Sprintf("--cache-ttl is only supported for GET requests; got %s", method)}
+	}
+	return nil
+}
+
+func (c *CLI) responseCache() (config.ResponseCache, error) {
+	if c.CacheDir == nil {
+		return config.ResponseCache{}, errors.New("the response cache is not available in this runtime")
+	}
+	dir, err := c.CacheDir()
+	if err != nil {
+		return config.ResponseCache{}, err
+	}
+	return config.ResponseCache{Dir: dir, Now: c.clock}, nil
+}
+
+// executeCachedCall runs a GET through the response cache. A hit returns
+// the stored response without touching the network and reports cached;
+// a miss (or --no-cache) calls the gateway and stores a 2xx response.
+// Cache read and write failures only warn.
+func (c *CLI) executeCachedCall(client *gateway.Client, input callExecutionInput, opts callCacheOptions) (*gateway.CallResponse, bool, error) {
+	req, err := buildCallRequest(input)
+	if err != nil {
+		return nil, false, err
+	}
+	if req.Method != http.MethodGet {
+		return nil, false, &igwerr.UsageError{Msg: fmt.Sprintf("--cache-ttl is only supported for GET requests; got %s", req.Method)}
+	}
+
+	cache, err := c.responseCache()
+	if err != nil {
+		return nil, false, &igwerr.UsageError{Msg: err.Error()}
+	}
+	headers, err := client.RequestHeaders(req)
+	if err != nil {
+		return nil, false, err
+	}
+	key := config.CacheKey(client.BaseURL, req.Method, req.Path, req.Query, headers, client.Token)
+	if !opts.noCache {
+		entry, hit, getErr := cache.Get(key)
+		if getErr != nil {
+			fmt.Fprintf(c.Err, "warning: response cache: %v\n", getErr)
+		}
+		if hit {
+			return &gateway.CallResponse{
+				Method:     entry.Method,
+				URL:        entry.URL,
+				StatusCode: entry.StatusCode,
+				Headers:    entry.Headers,
+				Body:       entry.Body,
+				BodyBytes:  int64(len(entry.Body)),
+			}, true, nil
+		}
+	}
+
+	resp, _, _, err := executeCallCore(client, input)
+	if err != nil || resp.Truncated || resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return resp, false, err
+	}
+	now := c.clock()
+	putErr := cache.Put(key, config.CacheEntry{
+		StoredAt:   now.UTC(),
+		ExpiresAt:  now.Add(opts.ttl).UTC(),
+		Method:     resp.Method,
+		URL:        resp.URL,
+		StatusCode: resp.StatusCode,
+		Headers:    resp.Headers,
+		Body:       resp.Body,
+	})
+	if putErr != nil {
+		fmt.Fprintf(c.Err, "warning: response cache: %v\n", putErr)
+	}
+	return resp, false, nil
+}
@@ -0,0 +1,94 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/alex-mccollum/igw-cli/internal/config"
+)
+
+func TestCallCacheTTLServesRepeatGets(t *testing.T) {
+	t.Parallel()
+
+	var requests atomic.Int64
+	now := time.Unix(1_700_000_000, 0)
+	cacheDir := t.TempDir()
+	var out bytes.Buffer
+	c := &CLI{
+		In:         strings.NewReader(""),
+		Out:        &out,
+		Err:        new(bytes.Buffer),
+		Getenv:     func(string) string { return "" },
+		ReadConfig: func() (config.File, error) { return config.File{}, nil },
+		CacheDir:   func() (string, error) { return cacheDir, nil },
+		now:        func() time.Time { return now },
+		HTTPClient: newMockHTTPClient(func(*http.Request) (*http.Response, error) {
+			n := requests.Add(1)
+			return mockHTTPResponse(http.StatusOK, fmt.Sprintf(`{"version":"8.1.%d"}`, n), nil), nil
+		}),
+	}
+	call := func(extra ...string) map[string]any {
+		t.Helper()
+		out.Reset()
+		args := append([]string{"call", "--gateway-url", mockGatewayURL, "--api-key", "secret", "--path", "/data/api/v1/gateway-info", "--json", "--json-stats", "--cache-ttl", "30s"}, extra...)
+		if err := c.Execute(args); err != nil {
+			t.Fatalf("call %v: %v", extra, err)
+		}
+		var envelope map[string]any
+		if err := json.Unmarshal(out.Bytes(), &envelope); err != nil {
+			t.Fatalf("decode envelope: %v", err)
+		}
+		return envelope
+	}
+	body := func(envelope map[string]any) string {
+		response, _ := envelope["response"].(map[string]any)
+		text, _ := response["body"].(string)
+		return text
+	}
+
+	if first := call(); first["cached"] != nil || body(first) != `{"version":"8.1.1"}` {
+		t.Fatalf("expected a fresh first response, got %v", first)
+	}
+	second := call()
+	stats, _ := second["stats"].(map[string]any)
+	if second["cached"] != true || stats["cached"] != true || body(second) != `{"version":"8.1.1"}` || requests.Load() != 1 {
+		t.Fatalf("expected a cache hit without a request, got %v after %d requests", second, requests.Load())
+	}
+
+	if refreshed := call("--no-cache"); refreshed["cached"] != nil || body(refreshed) != `{"version":"8.1.2"}` {
+		t.Fatalf("expected --no-cache to refresh, got %v", refreshed)
+	}
+	if updated := call(); updated["cached"] != true || body(updated) != `{"version":"8.1.2"}` {
+		t.Fatalf("expected --no-cache to update the entry, got %v", updated)
+	}
+
+	now = now.Add(31 * time.Second)
+	if expired := call(); expired["cached"] != nil || requests.Load() != 3 {
+		t.Fatalf("expected an expired entry to be refetched, got %v after %d requests", expired, requests.Load())
+	}
+
+	if accept := call("--header", "Accept: text/plain"); accept["cached"] != nil || requests.Load() != 4 {
+		t.Fatalf("expected a different Accept header to miss the cache, got %v after %d requests", accept, requests.Load())
+	}
+	if accept := call("--header", "Accept: text/plain"); accept["cached"] != true || requests.Load() != 4 {
+		t.Fatalf("expected the Accept variant to be cached separately, got %v after %d requests", accept, requests.Load())
+	}
+
+	for _, args := range [][]string{
+		{"--method", "POST", "--yes"},
+		{"--cache-ttl", "-1s"},
+		{"--repeat", "2"},
+	} {
+		requireUsageExitCode(t, c.Execute(append([]string{"call", "--gateway-url", mockGatewayURL, "--api-key", "secret", "--path", "/data/api/v1/gateway-info", "--cache-ttl", "30s"}, args...)))
+	}
+	requireUsageExitCode(t, c.Execute([]string{"call", "--gateway-url", mockGatewayURL, "--api-key", "secret", "--path", "/data/api/v1/gateway-info", "--no-cache"}))
+	if requests.Load() != 4 {
+		t.Fatalf("rejected calls must not reach the gateway, got %d requests", requests.Load())
+	}
+}
@@ -1,6 +1,7 @@
 package cli
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"flag"
@@ -8,10 +9,12 @@ import (
 	"io"
 	"net/http"
 	"os"
+	"os/signal"
 	"strings"
 	"time"
 
 	"github.com/alex-mccollum/igw-cli/internal/apidocs"
+	"github.com/alex-mccollum/igw-cli/internal/config"
 	"github.com/alex-mccollum/igw-cli/internal/gateway"
 	"github.com/alex-mccollum/igw-cli/internal/igwerr"
 )
@@ -23,95 +26,487 @@ func (c *CLI) runCall(args []string) error {
 	var (
 		common        wrapperCommon
 		op            string
+		opMethod      string
+		opFuzzy       bool
+		explainOp     bool
+		noDeprWarn    bool
+		failOnDepr    bool
 		specFile      string
+		writeSpecTo   string
+		strictSpecVer bool
 		batchInput    string
+		batchDelim    string
 		batchOutput   string
+		outputFlush   bool
+		batchCSV      string
+		csvMap        string
+		csvIDColumn   string
 		batchParallel int
+		adaptiveRate  bool
+		adaptiveHdr   string
+		failFastAfter int
+		rate          float64
+		batchOut      string
+		batchOutMax   int64
+		headerFromRsp string
 		method        string
 		path          string
 		body          string
+		bodyJQ        string
+		bodyMerges    stringList
+		formFields    stringList
+		formFiles     stringList
+		mergeArrays   bool
+		bodyBase64    string
+		useExample    bool
 		contentType   string
+		noDefaultCT   bool
 		dryRun        bool
 		yes           bool
 		stream        bool
+		sse           callSSEOptions
+		maxTime       time.Duration
+		deadline      commandDeadline
+		repeat        int
+		paginate      callPaginateOptions
+		prettyXML     bool
+		preserveNums  bool
+		flatten       flattenOptions
+		outputFormat  string
+		columns       string
+		gwStrategy    string
+		summaryOut    bool
+		session       bool
+		curl          bool
+		fanout        callFanoutOptions
+		cacheOpts     callCacheOptions
+		validateResp  bool
+		strictValid   bool
+		progressOut   bool
+		applyPatch    string
+		fetchPath     string
+		expand        string
+		expandKey     string
 		maxBodyBytes  int64
 		retry         int
 		retryBackoff  time.Duration
+		retryAfterMax time.Duration
+		retryJitter   float64
+		retryMaxWait  time.Duration
+		retryOn       string
+		jitterSeed    string
+		hedgeAfter    time.Duration
+		rawPath       bool
+		acceptStatus  string
+		followRedirs  int
+		successOut    string
+		failureOut    string
+		attemptsOut   string
+		ignoreRetryAf bool
+		retryOnBody   string
+		failOnBody    bool
+		failOnWarning bool
 		outPath       string
+		dumpRawPath   string
+		grep          callGrepOptions
 		queries       stringList
+		opParams      stringList
 		headers       stringList
 	)
 
 	bindWrapperCommonWithDefaults(fs, &common, 8*time.Second, true)
-	fs.StringVar(&op, "op", "", "OpenAPI operationId to call")
+	bindHARFlags(fs, &common.har)
+	bindVerboseFlags(fs, &common.verbose)
+	bindExpectStatusFlag(fs, &common)
+	fs.StringVar(&op, "op", "", "OpenAPI operationId to call (optionally namespace:operationId)")
+	fs.BoolVar(&opFuzzy, "op-fuzzy", false, "Run the closest operationId when --op has no exact match and one candidate is clearly nearest")
+	fs.BoolVar(&explainOp, "explain-op", false, "Print the METHOD PATH that --op resolves to and exit without calling")
+	fs.Var(&opParams, "param", "Operation parameter name=value for --op, placed in the path, query, or headers as the spec declares (repeatable)")
+	fs.StringVar(&opMethod, "op-method", "", "HTTP method used to disambiguate --op matches")
+	fs.BoolVar(&noDeprWarn, "no-deprecation-warnings", false, "Do not warn when --op resolves to a deprecated operation")
+	fs.BoolVar(&failOnDepr, "fail-on-deprecated", false, "Fail when --op resolves to a deprecated operation")
 	fs.StringVar(&specFile, "spec-file", apidocs.DefaultSpecFile, "Path to OpenAPI JSON file (used with --op)")
+	fs.StringVar(&writeSpecTo, "write-spec-to", "", "Write a minimal OpenAPI document for the resolved --op to file")
+	fs.BoolVar(&validateResp, "validate-response", false, "Check the JSON response body against the --op response schema and warn on mismatches")
+	fs.BoolVar(&strictValid, "strict-validate", false, "Like --validate-response, but exit non-zero on schema mismatches")
+	fs.BoolVar(&strictSpecVer, "strict-spec-version", false, "Fail --op calls when the spec was synced from a different gateway version")
 	fs.StringVar(&batchInput, "batch", "", "Batch request source (@file, file, or - for stdin)")
+	fs.StringVar(&batchDelim, "batch-delimiter", "", "Split --batch input on this string (escapes: \\0 \\n \\t) instead of newlines")
 	fs.StringVar(&batchOutput, "batch-output", "ndjson", "Batch output format: ndjson|json")
-	fs.IntVar(&batchParallel, "parallel", 1, "Batch parallel worker count (requires --batch)")
+	fs.BoolVar(&outputFlush, "output-buffer-flush", true, "Flush each NDJSON record (batch results, --sse events) as soon as it is written; false buffers output in blocks")
+	fs.StringVar(&batchCSV, "batch-csv", "", "Batch from CSV rows (@file, file, or - for stdin); requires --op or --path")
+	fs.StringVar(&csvMap, "csv-map", csvMapQuery, "Map --batch-csv columns to: query|body")
+	fs.StringVar(&csvIDColumn, "id-column", "", "--batch-csv column used as the batch result id")
+	fs.StringVar(&batchOut, "batch-out", "", "Write batch NDJSON results to rotated <prefix>-NNNN.ndjson files")
+	fs.Int64Var(&batchOutMax, "batch-out-max-size", 0, "Maximum bytes per --batch-out file before rotating (0 = no rotation)")
+	fs.StringVar(&headerFromRsp, "header-from-response", "", "Store <header>=<select> from the first batch item's JSON response and send it on every later item (requires --batch)")
+	fs.IntVar(&batchParallel, "parallel", 1, "Parallel worker count (requires --batch, --expand, --all-profiles, or --profiles; profile fan-out defaults to 4)")
+	fs.BoolVar(&adaptiveRate, "adaptive-rate", false, "Throttle batch concurrency from a remaining-budget response header (requires --batch)")
+	fs.StringVar(&adaptiveHdr, "adaptive-rate-header", defaultAdaptiveRateHeader, "Response header read by --adaptive-rate")
+	fs.Float64Var(&rate, "rate", 0, "Maximum requests per second shared by all workers, fractions allowed (0 = off; requires --batch, --all-profiles, or --profiles)")
+	fs.IntVar(&failFastAfter, "fail-fast-after", 0, "Skip the remaining batch items after N consecutive network failures (0 = off; requires --batch)")
 	fs.StringVar(&method, "method", "", "HTTP method")
 	fs.StringVar(&path, "path", "", "API path")
+	fs.BoolVar(&rawPath, "raw-path", false, "Send --path verbatim (already percent-encoded) without normalization")
 	fs.Var(&queries, "query", "Query parameter key=value (repeatable)")
 	fs.Var(&headers, "header", "Request header key:value (repeatable)")
 	fs.StringVar(&body, "body", "", "Request body, @file, or - for stdin")
+	fs.StringVar(&bodyBase64, "body-base64", "", "Base64-encoded request body (inline, @file, or - for stdin), sent as decoded bytes")
+	fs.StringVar(&applyPatch, "apply-patch", "", "RFC 6902 JSON Patch (inline, @file, or - for stdin) applied to the fetched resource and sent as the body")
+	fs.StringVar(&fetchPath, "fetch-path", "", "Path to GET the current resource from for --apply-patch (default: --path)")
+	fs.StringVar(&expand, "expand", "", "Expand each array element's <field>=<pathTemplate> with a GET per element ({} is replaced by the value)")
+	fs.StringVar(&expandKey, "expand-key", "", "Key that holds each --expand sub-response (default <field>Expanded)")
+	fs.BoolVar(&useExample, "use-example-body", false, "Send the spec's request body example when --op is used without --body")
+	fs.StringVar(&bodyJQ, "body-jq", "", "jq-style expression applied to the JSON --body before sending")
+	fs.Var(&bodyMerges, "body-merge", "Deep-merge a JSON object (inline, @file, or - for stdin) over --body; repeatable, later wins")
+	fs.Var(&formFields, "form", "Multipart form field field=value (repeatable); sends multipart/form-data")
+	fs.Var(&formFiles, "form-file", "Multipart file part field=@path (repeatable), streamed from disk")
+	fs.BoolVar(&mergeArrays, "merge-arrays", false, "With --body-merge, concatenate arrays instead of replacing them")
 	fs.StringVar(&contentType, "content-type", "", "Content-Type header value")
+	fs.BoolVar(&noDefaultCT, "no-default-content-type", false, "Do not default Content-Type to application/json when a body is sent")
 	fs.BoolVar(&dryRun, "dry-run", false, "Append dryRun=true query parameter")
 	fs.BoolVar(&yes, "yes", false, "Confirm mutating requests (POST/PUT/PATCH/DELETE)")
 	fs.BoolVar(&stream, "stream", false, "Stream response body directly (non-JSON mode)")
+	fs.BoolVar(&sse.enabled, "sse", false, "Parse a text/event-stream response and print one JSON event per line (GET only)")
+	fs.DurationVar(&maxTime, "max-time", 0, "Stop an --sse stream after this duration and exit successfully, or bound an --expand run")
+	bindCommandDeadline(fs, &deadline)
+	fs.StringVar(&outputFormat, "output", outputFormatText, "Response output format: text|kv|table|tsv|csv (kv: field<TAB>value rows for a JSON object; table/tsv/csv: one row per object in a JSON array)")
+	fs.StringVar(&columns, "columns", "", "Comma-separated columns for --output table|tsv|csv, as dotted paths (default: every key)")
 	fs.Int64Var(&maxBodyBytes, "max-body-bytes", 0, "Maximum response bytes to read/stream (0 = unlimited)")
 	fs.IntVar(&retry, "retry", 0, "Retry attempts for idempotent requests")
 	fs.DurationVar(&retryBackoff, "retry-backoff", 250*time.Millisecond, "Retry backoff duration")
+	fs.DurationVar(&retryAfterMax, "retry-after-max", gateway.DefaultRetryAfterMax, "Maximum wait honored from a Retry-After response header")
+	fs.Float64Var(&retryJitter, "retry-jitter", 0, "Spread each retry backoff by up to ±fraction (0..1)")
+	fs.StringVar(&jitterSeed, "retry-jitter-seed", "", "Seed --retry-jitter for reproducible retry timing")
+	fs.DurationVar(&retryMaxWait, "retry-max-wait", 0, "Use exponential retry backoff with full jitter, capping each wait (including Retry-After, together with --retry-after-max) at this duration")
+	fs.StringVar(&retryOn, "retry-on", "", "Statuses to retry: classes, ranges, or codes (e.g. 429,503,5xx; default 429 and 5xx)")
+	fs.DurationVar(&hedgeAfter, "hedge-after", 0, "Send a duplicate idempotent request when no response arrives within this delay; the first response wins")
+	fs.BoolVar(&ignoreRetryAf, "ignore-retry-after", false, "Ignore Retry-After response headers and use --retry-backoff")
+	fs.StringVar(&retryOnBody, "retry-on-body-match", "", "Retry when a JSON response field matches <select>==<value>")
+	fs.BoolVar(&failOnBody, "fail-on-body-match", false, "Exit non-zero if the final response still matches --retry-on-body-match")
+	fs.BoolVar(&failOnWarning, "fail-on-warning", false, "Exit non-zero when the response carries a Warning, Deprecation, or Sunset header")
+	fs.IntVar(&repeat, "repeat", 1, "Send the request N times in sequence and report latency percentiles")
+	bindPaginateFlags(fs, &paginate)
+	fs.StringVar(&gwStrategy, "gateway-strategy", gateway.StrategyFailover, "How to use a comma-separated --gateway-url list: failover|round-robin")
+	bindCallFanoutFlags(fs, &fanout)
+	bindCallCacheFlags(fs, &cacheOpts)
+	fs.StringVar(&acceptStatus, "accept-status", "", "Statuses that count as success: classes, ranges, or codes (e.g. 2xx,3xx or 200-204,404)")
+	fs.IntVar(&followRedirs, "follow-redirects", 0, "Follow at most N redirects and return an unfollowed 3xx as-is (default: follow up to 10)")
+	fs.StringVar(&successOut, "success-out", "", "Append an NDJSON record for each successful response to this file")
+	fs.StringVar(&attemptsOut, "attempts-out", "", "Write the number of HTTP attempts (including retries) to this file after the call")
+	fs.StringVar(&failureOut, "failure-out", "", "Append an NDJSON record for each failed response to this file")
+	fs.BoolVar(&summaryOut, "summary", false, "Print a requests/failures/bytes/elapsed summary to stderr when the command ends")
+	fs.BoolVar(&session, "session", false, "Keep cookies set by the gateway (for example sticky-session cookies) across retries, redirects, pages, and --batch items; never saved")
+	fs.BoolVar(&curl, "curl", false, "Print the equivalent curl command (token as $IGNITION_API_TOKEN) instead of sending the request")
+	fs.BoolVar(&progressOut, "progress", false, "Print throttled download or batch progress to stderr")
+	fs.BoolVar(&prettyXML, "pretty-xml", false, "Re-indent XML response bodies (non-JSON mode)")
+	fs.BoolVar(&preserveNums, "preserve-numbers", false, "Keep large integers exact in --select output instead of rounding through float64")
+	bindFlattenFlags(fs, &flatten)
 	fs.StringVar(&outPath, "out", "", "Write response body to file")
+	fs.StringVar(&dumpRawPath, "dump-raw", "", "Write the exact response bytes received (before gzip decoding) to file")
+	fs.StringVar(&grep.pattern, "grep", "", "Print only text response lines containing pattern")
+	fs.BoolVar(&grep.regex, "grep-regex", false, "Treat --grep pattern as a regular expression")
+	fs.BoolVar(&grep.invert, "grep-invert", false, "Print lines that do not match --grep")
+	fs.BoolVar(&grep.count, "grep-count", false, "Print the number of matching lines instead of the lines")
+	fs.BoolVar(&grep.exit, "grep-exit", false, "Exit non-zero when no lines match --grep")
 
 	if err := fs.Parse(args); err != nil {
 		return &igwerr.UsageError{Msg: err.Error()}
 	}
 
-	selectOpts, selectErr := newJSONSelectOptions(common.jsonOutput, common.compactJSON, common.rawOutput, common.selectors)
+	selectOpts, selectErr := common.selectOptions()
+	if err := grep.validate(common, stream, outPath); err != nil {
+		return c.printCallError(common.jsonOutput, selectionErrorOptions(selectOpts), err)
+	}
 	if selectErr != nil {
 		return c.printCallError(common.jsonOutput, selectionErrorOptions(selectOpts), selectErr)
 	}
+	selectOpts.preserveNumbers = preserveNums
 
 	if fs.NArg() > 0 {
 		return c.printCallError(common.jsonOutput, selectOpts, &igwerr.UsageError{Msg: "unexpected positional arguments"})
 	}
 
-	batchRequested := strings.TrimSpace(batchInput) != ""
-	if !batchRequested && batchParallel != 1 {
-		return c.printCallError(common.jsonOutput, selectOpts, &igwerr.UsageError{Msg: "--parallel requires --batch"})
+	batchCSVRequested := strings.TrimSpace(batchCSV) != ""
+	if batchCSVRequested && strings.TrimSpace(batchInput) != "" {
+		return c.printCallError(common.jsonOutput, selectOpts, &igwerr.UsageError{Msg: "use either --batch or --batch-csv, not both"})
+	}
+	if batchDelim != "" && strings.TrimSpace(batchInput) == "" {
+		return c.printCallError(common.jsonOutput, selectOpts, &igwerr.UsageError{Msg: "--batch-delimiter requires --batch"})
+	}
+	if !batchCSVRequested && (csvMap != csvMapQuery || strings.TrimSpace(csvIDColumn) != "") {
+		return c.printCallError(common.jsonOutput, selectOpts, &igwerr.UsageError{Msg: "--csv-map and --id-column require --batch-csv"})
+	}
+	if batchCSVRequested && strings.TrimSpace(body) != "" {
+		return c.printCallError(common.jsonOutput, selectOpts, &igwerr.UsageError{Msg: "--body is not supported with --batch-csv"})
+	}
+	if strings.TrimSpace(body) != "" && strings.TrimSpace(bodyBase64) != "" {
+		return c.printCallError(common.jsonOutput, selectOpts, &igwerr.UsageError{Msg: "use either --body or --body-base64, not both"})
+	}
+	if strings.TrimSpace(applyPatch) != "" && (strings.TrimSpace(body) != "" || strings.TrimSpace(bodyBase64) != "" || useExample) {
+		return c.printCallError(common.jsonOutput, selectOpts, &igwerr.UsageError{Msg: "--apply-patch builds the body; do not combine it with --body, --body-base64, or --use-example-body"})
+	}
+	if strings.TrimSpace(fetchPath) != "" && strings.TrimSpace(applyPatch) == "" {
+		return c.printCallError(common.jsonOutput, selectOpts, &igwerr.UsageError{Msg: "--fetch-path requires --apply-patch"})
+	}
+	gwStrategy, err := gateway.ParseStrategy(gwStrategy)
+	if err != nil {
+		return c.printCallError(common.jsonOutput, selectOpts, &igwerr.UsageError{Msg: err.Error()})
+	}
+	form, err := parseCallForm(formFields, formFiles)
+	if err != nil {
+		return c.printCallError(common.jsonOutput, selectOpts, err)
+	}
+	if form != nil && (strings.TrimSpace(body) != "" || strings.TrimSpace(bodyBase64) != "" || strings.TrimSpace(applyPatch) != "" || useExample || len(bodyMerges) > 0 || strings.TrimSpace(bodyJQ) != "") {
+		return c.printCallError(common.jsonOutput, selectOpts, &igwerr.UsageError{Msg: "--form and --form-file build the body; do not combine them with --body, --body-base64, --body-merge, --body-jq, --apply-patch, or --use-example-body"})
+	}
+	if form != nil && strings.TrimSpace(contentType) != "" {
+		return c.printCallError(common.jsonOutput, selectOpts, &igwerr.UsageError{Msg: "--content-type is set by --form and --form-file"})
+	}
+	if form != nil && strings.TrimSpace(common.signKey) != "" {
+		return c.printCallError(common.jsonOutput, selectOpts, &igwerr.UsageError{Msg: "--sign-key is not supported with --form or --form-file"})
+	}
+	jitterRand, err := parseRetryJitter(retryJitter, jitterSeed)
+	if err != nil {
+		return c.printCallError(common.jsonOutput, selectOpts, err)
+	}
+	if retryMaxWait < 0 {
+		return c.printCallError(common.jsonOutput, selectOpts, &igwerr.UsageError{Msg: "--retry-max-wait must be >= 0"})
+	}
+	if retryMaxWait > 0 && retryJitter > 0 {
+		return c.printCallError(common.jsonOutput, selectOpts, &igwerr.UsageError{Msg: "--retry-max-wait applies full jitter; do not combine it with --retry-jitter"})
+	}
+	if retryMaxWait > 0 && flagWasSet(fs, "retry-after-max") && retryAfterMax > retryMaxWait {
+		return c.printCallError(common.jsonOutput, selectOpts, &igwerr.UsageError{Msg: "--retry-after-max cannot exceed --retry-max-wait, which also caps Retry-After waits"})
+	}
+	retryOnFn, err := parseStatusSet("--retry-on", retryOn)
+	if err != nil {
+		return c.printCallError(common.jsonOutput, selectOpts, err)
+	}
+	if hedgeAfter < 0 {
+		return c.printCallError(common.jsonOutput, selectOpts, &igwerr.UsageError{Msg: "--hedge-after must be >= 0"})
+	}
+	if hedgeAfter > 0 && sse.enabled {
+		return c.printCallError(common.jsonOutput, selectOpts, &igwerr.UsageError{Msg: "--hedge-after is not supported with --sse"})
+	}
+	if rawPath && strings.TrimSpace(op) != "" {
+		return c.printCallError(common.jsonOutput, selectOpts, &igwerr.UsageError{Msg: "--raw-path requires --path and is not supported with --op"})
+	}
+	acceptStatusFn, err := parseAcceptStatus(acceptStatus)
+	if err != nil {
+		return c.printCallError(common.jsonOutput, selectOpts, err)
+	}
+	expect := &callExpectStatus{entries: common.expectStatus}
+	if len(expect.entries) > 0 {
+		if acceptStatusFn != nil {
+			return c.printCallError(common.jsonOutput, selectOpts, &igwerr.UsageError{Msg: "use only one of --accept-status or --expect-status"})
+		}
+		if strings.TrimSpace(batchInput) != "" || batchCSVRequested {
+			return c.printCallError(common.jsonOutput, selectOpts, &igwerr.UsageError{Msg: "--expect-status is not supported with --batch"})
+		}
+		if err := expect.parse(); err != nil {
+			return c.printCallError(common.jsonOutput, selectOpts, err)
+		}
+		acceptStatusFn = expect.match
+	}
+	var followLimit *int
+	if flagWasSet(fs, "follow-redirects") {
+		if followRedirs < 0 {
+			return c.printCallError(common.jsonOutput, selectOpts, &igwerr.UsageError{Msg: "--follow-redirects must be >= 0"})
+		}
+		followLimit = &followRedirs
+	}
+	expandSpec, err := parseCallExpand(expand, expandKey)
+	if err != nil {
+		return c.printCallError(common.jsonOutput, selectOpts, err)
+	}
+	batchRequested := strings.TrimSpace(batchInput) != "" || batchCSVRequested
+	flattener, err := flatten.flattener(fs, common.jsonOutput || batchRequested, selectOpts)
+	if err != nil {
+		return c.printCallError(common.jsonOutput, selectOpts, err)
+	}
+	selectOpts.flatten = flattener
+	if !batchRequested && expandSpec == nil && !fanout.enabled() && batchParallel != 1 {
+		return c.printCallError(common.jsonOutput, selectOpts, &igwerr.UsageError{Msg: "--parallel requires --batch, --expand, or --all-profiles"})
+	}
+	if batchRequested && expandSpec != nil {
+		return c.printCallError(common.jsonOutput, selectOpts, &igwerr.UsageError{Msg: "--expand is not supported with --batch"})
+	}
+	if expandSpec != nil && (stream || sse.enabled || repeat != 1 || grep.enabled() || prettyXML) {
+		return c.printCallError(common.jsonOutput, selectOpts, &igwerr.UsageError{Msg: "--expand is not supported with --stream, --sse, --repeat, --grep, or --pretty-xml"})
+	}
+	if !batchRequested && (strings.TrimSpace(batchOut) != "" || batchOutMax != 0) {
+		return c.printCallError(common.jsonOutput, selectOpts, &igwerr.UsageError{Msg: "--batch-out requires --batch"})
+	}
+	if !batchRequested && adaptiveRate {
+		return c.printCallError(common.jsonOutput, selectOpts, &igwerr.UsageError{Msg: "--adaptive-rate requires --batch"})
+	}
+	if !batchRequested && !fanout.enabled() && rate != 0 {
+		return c.printCallError(common.jsonOutput, selectOpts, &igwerr.UsageError{Msg: "--rate requires --batch, --all-profiles, or --profiles"})
+	}
+	if rate < 0 {
+		return c.printCallError(common.jsonOutput, selectOpts, &igwerr.UsageError{Msg: "--rate must be >= 0"})
+	}
+	if !batchRequested && failFastAfter != 0 {
+		return c.printCallError(common.jsonOutput, selectOpts, &igwerr.UsageError{Msg: "--fail-fast-after requires --batch"})
+	}
+	headerCapture, err := parseHeaderFromResponse(headerFromRsp)
+	if err != nil {
+		return c.printCallError(common.jsonOutput, selectOpts, err)
+	}
+	if !batchRequested && headerCapture != nil {
+		return c.printCallError(common.jsonOutput, selectOpts, &igwerr.UsageError{Msg: "--header-from-response requires --batch"})
 	}
 	if batchRequested && len(common.selectors) > 0 {
 		return c.printCallError(common.jsonOutput, selectOpts, &igwerr.UsageError{Msg: "--select is not supported with --batch"})
 	}
+	if batchRequested && common.expr != "" {
+		return c.printCallError(common.jsonOutput, selectOpts, &igwerr.UsageError{Msg: "--expr is not supported with --batch"})
+	}
 	if batchRequested && common.rawOutput {
 		return c.printCallError(common.jsonOutput, selectOpts, &igwerr.UsageError{Msg: "--raw is not supported with --batch"})
 	}
 	if batchRequested && strings.TrimSpace(outPath) != "" {
 		return c.printCallError(common.jsonOutput, selectOpts, &igwerr.UsageError{Msg: "--out is not supported with --batch"})
 	}
+	if batchRequested && strings.TrimSpace(dumpRawPath) != "" {
+		return c.printCallError(common.jsonOutput, selectOpts, &igwerr.UsageError{Msg: "--dump-raw is not supported with --batch"})
+	}
+	if batchRequested && rawPath {
+		return c.printCallError(common.jsonOutput, selectOpts, &igwerr.UsageError{Msg: "--raw-path is not supported with --batch"})
+	}
+	if batchRequested && deadline.enabled() {
+		return c.printCallError(common.jsonOutput, selectOpts, &igwerr.UsageError{Msg: "--deadline is not supported with --batch"})
+	}
+	if batchRequested && repeat != 1 {
+		return c.printCallError(common.jsonOutput, selectOpts, &igwerr.UsageError{Msg: "--repeat is not supported with --batch"})
+	}
+	if batchRequested && prettyXML {
+		return c.printCallError(common.jsonOutput, selectOpts, &igwerr.UsageError{Msg: "--pretty-xml is not supported with --batch"})
+	}
+	if batchRequested && sse.enabled {
+		return c.printCallError(common.jsonOutput, selectOpts, &igwerr.UsageError{Msg: "--sse is not supported with --batch"})
+	}
 	if batchRequested && stream {
 		return c.printCallError(common.jsonOutput, selectOpts, &igwerr.UsageError{Msg: "--stream is not supported with --batch"})
 	}
+	if batchRequested && grep.enabled() {
+		return c.printCallError(common.jsonOutput, selectOpts, &igwerr.UsageError{Msg: "--grep is not supported with --batch"})
+	}
+	if batchRequested && form != nil {
+		return c.printCallError(common.jsonOutput, selectOpts, &igwerr.UsageError{Msg: "--form and --form-file are not supported with --batch"})
+	}
+	if batchRequested && followLimit != nil {
+		return c.printCallError(common.jsonOutput, selectOpts, &igwerr.UsageError{Msg: "--follow-redirects is not supported with --batch"})
+	}
+	if batchRequested && strings.TrimSpace(attemptsOut) != "" {
+		return c.printCallError(common.jsonOutput, selectOpts, &igwerr.UsageError{Msg: "--attempts-out is not supported with --batch"})
+	}
+	if batchRequested && strings.TrimSpace(bodyBase64) != "" {
+		return c.printCallError(common.jsonOutput, selectOpts, &igwerr.UsageError{Msg: "--body-base64 is not supported with --batch"})
+	}
+	if batchRequested && strings.TrimSpace(applyPatch) != "" {
+		return c.printCallError(common.jsonOutput, selectOpts, &igwerr.UsageError{Msg: "--apply-patch is not supported with --batch"})
+	}
+	if batchRequested && useExample {
+		return c.printCallError(common.jsonOutput, selectOpts, &igwerr.UsageError{Msg: "--use-example-body is not supported with --batch"})
+	}
+	if batchRequested && strings.TrimSpace(bodyJQ) != "" {
+		return c.printCallError(common.jsonOutput, selectOpts, &igwerr.UsageError{Msg: "--body-jq is not supported with --batch"})
+	}
+	bodyQuery, err := compileBodyJQ(bodyJQ, body)
+	if err != nil {
+		return c.printCallError(common.jsonOutput, selectOpts, err)
+	}
+	if batchRequested && len(bodyMerges) > 0 {
+		return c.printCallError(common.jsonOutput, selectOpts, &igwerr.UsageError{Msg: "--body-merge is not supported with --batch"})
+	}
+	if err := validateBodyMerge(bodyMerges, mergeArrays, body, contentType); err != nil {
+		return c.printCallError(common.jsonOutput, selectOpts, err)
+	}
+	if batchRequested && strings.TrimSpace(retryOnBody) != "" {
+		return c.printCallError(common.jsonOutput, selectOpts, &igwerr.UsageError{Msg: "--retry-on-body-match is not supported with --batch"})
+	}
+	patchOps, err := loadApplyPatch(c.In, applyPatch)
+	if err != nil {
+		return c.printCallError(common.jsonOutput, selectOpts, err)
+	}
+	bodyMatch, err := parseBodyMatcher(retryOnBody)
+	if err != nil {
+		return c.printCallError(common.jsonOutput, selectOpts, err)
+	}
+	if failOnBody && bodyMatch == nil {
+		return c.printCallError(common.jsonOutput, selectOpts, &igwerr.UsageError{Msg: "--fail-on-body-match requires --retry-on-body-match"})
+	}
+	validateResp = validateResp || strictValid
+	if validateResp && (batchRequested || stream || sse.enabled || paginate.enabled) {
+		return c.printCallError(common.jsonOutput, selectOpts, &igwerr.UsageError{Msg: "--validate-response is not supported with --batch, --stream, --sse, or --paginate"})
+	}
+	if curl && (batchRequested || repeat != 1 || paginate.enabled || expandSpec != nil || patchOps != nil || form != nil || stream || sse.enabled || strings.TrimSpace(outPath) != "" || strings.TrimSpace(dumpRawPath) != "") {
+		return c.printCallError(common.jsonOutput, selectOpts, &igwerr.UsageError{Msg: "--curl is not supported with --batch, --repeat, --paginate, --expand, --apply-patch, --form, --stream, --sse, --out, or --dump-raw"})
+	}
+	modes := callModes{
+		batch:            batchRequested,
+		curl:             curl,
+		repeat:           repeat != 1,
+		paginate:         paginate.enabled,
+		expand:           expandSpec != nil,
+		applyPatch:       patchOps != nil,
+		stream:           stream,
+		sse:              sse.enabled,
+		grep:             grep.enabled(),
+		prettyXML:        prettyXML,
+		validateResponse: validateResp,
+		selection:        len(common.selectors) > 0 || common.expr != "" || common.rawOutput,
+		out:              strings.TrimSpace(outPath) != "",
+		dumpRaw:          strings.TrimSpace(dumpRawPath) != "",
+		attemptsOut:      strings.TrimSpace(attemptsOut) != "",
+		progress:         progressOut,
+		output:           flagWasSet(fs, "output"),
+		fanout:           fanout.enabled(),
+	}
+	if err := fanout.validate(common, modes); err != nil {
+		return c.printCallError(common.jsonOutput, selectOpts, err)
+	}
 
-	if common.apiKeyStdin {
-		if common.apiKey != "" {
-			return c.printCallError(common.jsonOutput, selectOpts, &igwerr.UsageError{Msg: "use only one of --api-key or --api-key-stdin"})
-		}
-		tokenBytes, err := io.ReadAll(c.In)
-		if err != nil {
-			return c.printCallError(common.jsonOutput, selectOpts, igwerr.NewTransportError(err))
-		}
-		common.apiKey = strings.TrimSpace(string(tokenBytes))
+	if err := c.readCredentials(&common); err != nil {
+		return c.printCallError(common.jsonOutput, selectOpts, err)
+	}
+	signer, err := common.requestSigner()
+	if err != nil {
+		return c.printCallError(common.jsonOutput, selectOpts, err)
 	}
 
 	resolved, err := c.resolveRuntimeConfig(common.profile, common.gatewayURL, common.apiKey)
 	if err != nil {
 		return c.printCallError(common.jsonOutput, selectOpts, err)
 	}
+	proxy, err := common.clientProxy(resolved)
+	if err != nil {
+		return c.printCallError(common.jsonOutput, selectOpts, err)
+	}
+	// --header values travel on each request and win over these.
+	defaultHeaders, err := configDefaultHeaders(resolved)
+	if err != nil {
+		return c.printCallError(common.jsonOutput, selectOpts, err)
+	}
+	// Fan-out builds a client per profile instead.
+	httpClient := c.runtimeHTTPClient()
+	if !fanout.enabled() {
+		httpClient, err = c.gatewayHTTPClient(common.tls, resolved)
+		if err != nil {
+			return c.printCallError(common.jsonOutput, selectOpts, err)
+		}
+	}
 
+	var resolvedOp *apidocs.Operation
 	if strings.TrimSpace(op) != "" {
-		if batchRequested {
+		if batchRequested && !batchCSVRequested {
 			return c.printCallError(common.jsonOutput, selectOpts, &igwerr.UsageError{Msg: "--op is not supported with --batch (set op per batch item)"})
 		}
+		if batchRequested && len(opParams) > 0 {
+			return c.printCallError(common.jsonOutput, selectOpts, &igwerr.UsageError{Msg: "--param is not supported with --batch-csv"})
+		}
 		if strings.TrimSpace(method) != "" || strings.TrimSpace(path) != "" {
 			return c.printCallError(common.jsonOutput, selectOpts, &igwerr.UsageError{Msg: "use either --op or --method/--path, not both"})
 		}
@@ -121,47 +516,211 @@ func (c *CLI) runCall(args []string) error {
 			GatewayURL: common.gatewayURL,
 			APIKey:     common.apiKey,
 			Timeout:    common.timeout,
+			Signer:     signer,
+			Proxy:      common.proxy,
+			NoProxy:    common.noProxy,
+			TLS:        common.tls,
 		})
 		if loadErr != nil {
 			return c.printCallError(common.jsonOutput, selectOpts, loadErr)
 		}
+		if explainOp {
+			return c.explainOperation(ops, op, opMethod, specFile, common.jsonOutput, common.compactJSON)
+		}
+
+		matches, resolveErr := resolveOperationWithHints(ops, op, opMethod, specFile)
+		if resolveErr != nil && opFuzzy && len(resolveOperationsByID(ops, op)) == 0 {
+			if nearest, ok := nearestOperationID(ops, op); ok {
+				c.info("note: --op-fuzzy resolved %q to %q", op, nearest)
+				matches, resolveErr = resolveOperationWithHints(ops, nearest, opMethod, specFile)
+			}
+		}
+		if resolveErr != nil {
+			return c.printCallError(common.jsonOutput, selectOpts, resolveErr)
+		}
+		if matches[0].Deprecated {
+			msg := fmt.Sprintf("operationId %q is marked deprecated in the spec", matches[0].OperationID)
+			if failOnDepr {
+				return c.printCallError(common.jsonOutput, selectOpts, &igwerr.UsageError{Msg: msg})
+			}
+			if !noDeprWarn {
+				fmt.Fprintf(c.Err, "warning: %s\n", msg)
+			}
+		}
 
-		matches := resolveOperationsByID(ops, op)
-		if len(matches) == 0 {
-			return c.printCallError(common.jsonOutput, selectOpts, &igwerr.UsageError{
-				Msg: fmt.Sprintf("operationId %q not found in spec %q", strings.TrimSpace(op), strings.TrimSpace(specFile)),
-			})
+		if strings.TrimSpace(writeSpecTo) != "" {
+			if writeErr := writeOperationSpecSubset(specFile, matches, writeSpecTo); writeErr != nil {
+				return c.printCallError(common.jsonOutput, selectOpts, writeErr)
+			}
 		}
-		if len(matches) > 1 {
-			return c.printCallError(common.jsonOutput, selectOpts, &igwerr.UsageError{
-				Msg: fmt.Sprintf("operationId %q is ambiguous (%d matches): %s", strings.TrimSpace(op), len(matches), formatOperationMatches(matches)),
-			})
+
+		if !flagWasSet(fs, "timeout") {
+			// Slow operations can declare x-timeout in the spec; an
+			// explicit --timeout always wins.
+			opTimeout, ok, timeoutErr := matches[0].Timeout()
+			if timeoutErr != nil {
+				fmt.Fprintf(c.Err, "warning: %v; using --timeout %s\n", timeoutErr, common.timeout)
+			} else if ok {
+				common.timeout = opTimeout
+			}
 		}
 
+		resolvedOp = &matches[0]
 		method = matches[0].Method
 		path = matches[0].Path
+		if !batchRequested {
+			var paramErr error
+			path, queries, headers, paramErr = applyOperationParams(matches[0], opParams, queries, headers)
+			if paramErr != nil {
+				return c.printCallError(common.jsonOutput, selectOpts, paramErr)
+			}
+		}
+	} else if strings.TrimSpace(writeSpecTo) != "" {
+		return c.printCallError(common.jsonOutput, selectOpts, &igwerr.UsageError{Msg: "--write-spec-to requires --op"})
+	} else if strings.TrimSpace(opMethod) != "" {
+		return c.printCallError(common.jsonOutput, selectOpts, &igwerr.UsageError{Msg: "--op-method requires --op"})
+	} else if opFuzzy {
+		return c.printCallError(common.jsonOutput, selectOpts, &igwerr.UsageError{Msg: "--op-fuzzy requires --op"})
+	} else if explainOp {
+		return c.printCallError(common.jsonOutput, selectOpts, &igwerr.UsageError{Msg: "--explain-op requires --op"})
+	} else if failOnDepr {
+		return c.printCallError(common.jsonOutput, selectOpts, &igwerr.UsageError{Msg: "--fail-on-deprecated requires --op"})
+	} else if strictSpecVer {
+		return c.printCallError(common.jsonOutput, selectOpts, &igwerr.UsageError{Msg: "--strict-spec-version requires --op"})
+	} else if len(opParams) > 0 {
+		return c.printCallError(common.jsonOutput, selectOpts, &igwerr.UsageError{Msg: "--param requires --op"})
+	} else if validateResp {
+		return c.printCallError(common.jsonOutput, selectOpts, &igwerr.UsageError{Msg: "--validate-response and --strict-validate require --op"})
+	} else if useExample {
+		return c.printCallError(common.jsonOutput, selectOpts, &igwerr.UsageError{Msg: "--use-example-body requires --op"})
 	}
 
-	if strings.TrimSpace(resolved.GatewayURL) == "" {
-		return c.printCallError(common.jsonOutput, selectOpts, &igwerr.UsageError{Msg: "required: --gateway-url (or IGNITION_GATEWAY_URL/config)"})
+	if expandSpec != nil {
+		if expandMethod := strings.ToUpper(strings.TrimSpace(method)); expandMethod != "" && expandMethod != http.MethodGet {
+			return c.printCallError(common.jsonOutput, selectOpts, &igwerr.UsageError{Msg: "--expand requires a GET request"})
+		}
 	}
-	if strings.TrimSpace(resolved.Token) == "" {
-		return c.printCallError(common.jsonOutput, selectOpts, &igwerr.UsageError{Msg: "required: --api-key (or IGNITION_API_TOKEN/config)"})
+
+	if patchOps != nil {
+		patchMethod := strings.ToUpper(strings.TrimSpace(method))
+		if patchMethod != http.MethodPut && patchMethod != http.MethodPatch {
+			return c.printCallError(common.jsonOutput, selectOpts, &igwerr.UsageError{Msg: "--apply-patch requires a PUT or PATCH request"})
+		}
+		// Confirm before the fetch so an unconfirmed run makes no requests.
+		if !yes {
+			return c.printCallError(common.jsonOutput, selectOpts, &igwerr.UsageError{Msg: fmt.Sprintf("method %s requires --yes confirmation", patchMethod)})
+		}
 	}
+
+	var fanoutProfiles []string
+	if fanout.enabled() {
+		if err := fanout.checkMethod(method, yes); err != nil {
+			return c.printCallError(common.jsonOutput, selectOpts, err)
+		}
+		cfg, err := c.ReadConfig()
+		if err != nil {
+			return c.printCallError(common.jsonOutput, selectOpts, &igwerr.UsageError{Msg: fmt.Sprintf("load config: %v", err)})
+		}
+		fanoutProfiles, err = fanout.selectProfiles(cfg)
+		if err != nil {
+			return c.printCallError(common.jsonOutput, selectOpts, err)
+		}
+	} else {
+		if strings.TrimSpace(resolved.GatewayURL) == "" {
+			return c.printCallError(common.jsonOutput, selectOpts, &igwerr.UsageError{Msg: "required: --gateway-url (or IGNITION_GATEWAY_URL/config)"})
+		}
+		if strings.TrimSpace(resolved.Token) == "" {
+			return c.printCallError(common.jsonOutput, selectOpts, &igwerr.UsageError{Msg: "required: --api-key (or IGNITION_API_TOKEN/config)"})
+		}
+	}
+	var summary *runSummary
+	if summaryOut {
+		summary = newRunSummary(c.clock())
+		defer func() { summary.write(c.Err, c.clock()) }()
+	}
+	outcomes, err := openOutcomeFiles(successOut, failureOut)
+	if err != nil {
+		return c.printCallError(common.jsonOutput, selectOpts, err)
+	}
+	defer func() {
+		if closeErr := outcomes.close(); closeErr != nil {
+			fmt.Fprintf(c.Err, "warning: %v\n", closeErr)
+		}
+	}()
+	har := newHARRecorder(common.har)
+	defer c.saveHAR(har)
+	verbose := c.newVerboseTracer(common.verbose, resolved.Token)
+
 	if batchRequested {
 		defaults := callBatchDefaults{
-			Retry:        retry,
-			RetryBackoff: retryBackoff,
-			Timeout:      common.timeout,
-			Yes:          yes,
-			SpecFile:     specFile,
-			Profile:      common.profile,
-			GatewayURL:   common.gatewayURL,
-			APIKey:       common.apiKey,
-			IncludeHeads: common.includeHeaders,
-			OutputFormat: batchOutput,
-			Parallel:     batchParallel,
-			Compact:      common.compactJSON,
+			Retry:          retry,
+			RetryBackoff:   retryBackoff,
+			Timeout:        common.timeout,
+			Yes:            yes,
+			SpecFile:       specFile,
+			Profile:        common.profile,
+			GatewayURL:     common.gatewayURL,
+			APIKey:         common.apiKey,
+			IncludeHeads:   common.includeHeaders,
+			OutputFormat:   batchOutput,
+			Parallel:       batchParallel,
+			Compact:        common.compactJSON,
+			Proxy:          proxy,
+			HTTP:           httpClient,
+			DefaultHeaders: defaultHeaders,
+			TLS:            common.tls,
+
+			NoDefaultContentType: noDefaultCT,
+			RetryAfterMax:        retryAfterMax,
+			RetryJitter:          retryJitter,
+			RetryMaxWait:         retryMaxWait,
+			RetryOn:              retryOnFn,
+			JitterRand:           jitterRand,
+			HedgeAfter:           hedgeAfter,
+			AcceptStatus:         acceptStatusFn,
+			IgnoreRetryAfter:     ignoreRetryAf,
+
+			AdaptiveRate:       adaptiveRate,
+			AdaptiveRateHeader: adaptiveHdr,
+			Rate:               rate,
+			FailFastAfter:      failFastAfter,
+
+			BatchOut:        batchOut,
+			BatchOutMaxSize: batchOutMax,
+			FlushRecords:    outputFlush,
+			Flatten:         flattener,
+			Delimiter:       parseBatchDelimiter(batchDelim),
+			HAR:             har,
+			Verbose:         verbose,
+			Session:         session,
+
+			HeaderFromResponse: headerCapture,
+
+			GatewayStrategy: gwStrategy,
+			Summary:         summary,
+			Outcomes:        outcomes,
+			Policy:          resolved.Policy,
+			Signer:          signer,
+		}
+		if progressOut {
+			defaults.Progress = newBatchProgress(c.Err, c.clock)
+		}
+		if batchCSVRequested {
+			if strings.TrimSpace(path) == "" {
+				return c.printCallError(common.jsonOutput, selectOpts, &igwerr.UsageError{Msg: "--batch-csv requires --op or --path"})
+			}
+			return c.runCallBatchCSV(resolved.GatewayURL, resolved.Token, callBatchCSVOptions{
+				Source:      batchCSV,
+				Map:         csvMap,
+				IDColumn:    csvIDColumn,
+				Method:      method,
+				Path:        path,
+				Query:       queries,
+				Headers:     headers,
+				ContentType: contentType,
+				DryRun:      dryRun,
+				Operation:   resolvedOp,
+			}, defaults)
 		}
 		return c.runCallBatch(resolved.GatewayURL, resolved.Token, batchInput, defaults)
 	}
@@ -174,19 +733,105 @@ func (c *CLI) runCall(args []string) error {
 	if maxBodyBytes < 0 {
 		return c.printCallError(common.jsonOutput, selectOpts, &igwerr.UsageError{Msg: "--max-body-bytes must be >= 0"})
 	}
+	if retryAfterMax <= 0 {
+		return c.printCallError(common.jsonOutput, selectOpts, &igwerr.UsageError{Msg: "--retry-after-max must be positive"})
+	}
 	method = strings.TrimSpace(method)
 	path = strings.TrimSpace(path)
+	if err := sse.validate(method, common.jsonOutput, stream, grep.enabled(), common.includeHeaders, bodyMatch != nil); err != nil {
+		return c.printCallError(common.jsonOutput, selectOpts, err)
+	}
+	if prettyXML && common.jsonOutput {
+		return c.printCallError(common.jsonOutput, selectOpts, &igwerr.UsageError{Msg: "--pretty-xml is not supported with --json"})
+	}
+	if prettyXML && (stream || sse.enabled) {
+		return c.printCallError(common.jsonOutput, selectOpts, &igwerr.UsageError{Msg: "--pretty-xml is not supported with --stream or --sse"})
+	}
+	outputFormat, err = parseResponseOutputFormat(outputFormat)
+	if err != nil {
+		return c.printCallError(common.jsonOutput, selectOpts, err)
+	}
+	if outputFormat != outputFormatText && (common.jsonOutput || stream || sse.enabled || grep.enabled() || batchRequested || strings.TrimSpace(outPath) != "") {
+		return c.printCallError(common.jsonOutput, selectOpts, &igwerr.UsageError{Msg: fmt.Sprintf("--output %s is not supported with --json, --stream, --sse, --grep, --batch, or --out", outputFormat)})
+	}
+	if strings.TrimSpace(columns) != "" && !isRecordOutputFormat(outputFormat) {
+		return c.printCallError(common.jsonOutput, selectOpts, &igwerr.UsageError{Msg: "--columns requires --output table, tsv, or csv"})
+	}
+	if repeat < 1 {
+		return c.printCallError(common.jsonOutput, selectOpts, &igwerr.UsageError{Msg: "--repeat must be >= 1"})
+	}
+	if repeat > 1 && (stream || sse.enabled) {
+		return c.printCallError(common.jsonOutput, selectOpts, &igwerr.UsageError{Msg: "--repeat is not supported with --stream or --sse"})
+	}
+	if repeat > 1 && strings.TrimSpace(dumpRawPath) != "" {
+		return c.printCallError(common.jsonOutput, selectOpts, &igwerr.UsageError{Msg: "--repeat is not supported with --dump-raw"})
+	}
+	if err := paginate.validate(fs); err != nil {
+		return c.printCallError(common.jsonOutput, selectOpts, err)
+	}
+	if paginate.enabled && (stream || sse.enabled || batchRequested || repeat != 1 || strings.TrimSpace(dumpRawPath) != "" || form != nil) {
+		return c.printCallError(common.jsonOutput, selectOpts, &igwerr.UsageError{Msg: "--paginate is not supported with --stream, --sse, --batch, --repeat, --dump-raw, or --form"})
+	}
+	if paginate.enabled && strings.TrimSpace(method) != "" && !strings.EqualFold(strings.TrimSpace(method), http.MethodGet) {
+		return c.printCallError(common.jsonOutput, selectOpts, &igwerr.UsageError{Msg: fmt.Sprintf("--paginate requires GET; got %s", strings.ToUpper(strings.TrimSpace(method)))})
+	}
+	if err := cacheOpts.validate(modes, method); err != nil {
+		return c.printCallError(common.jsonOutput, selectOpts, err)
+	}
+	if maxTime < 0 {
+		return c.printCallError(common.jsonOutput, selectOpts, &igwerr.UsageError{Msg: "--max-time must be >= 0"})
+	}
+	if maxTime > 0 && !sse.enabled && expandSpec == nil {
+		return c.printCallError(common.jsonOutput, selectOpts, &igwerr.UsageError{Msg: "--max-time requires --sse or --expand"})
+	}
+	if deadline.enabled() && sse.enabled {
+		return c.printCallError(common.jsonOutput, selectOpts, &igwerr.UsageError{Msg: "--deadline is not supported with --sse (use --max-time)"})
+	}
 	if stream && common.jsonOutput {
 		return c.printCallError(common.jsonOutput, selectOpts, &igwerr.UsageError{Msg: "--stream is not supported with --json"})
 	}
+	if stream && bodyMatch != nil {
+		return c.printCallError(common.jsonOutput, selectOpts, &igwerr.UsageError{Msg: "--retry-on-body-match is not supported with --stream"})
+	}
 	if stream && common.includeHeaders && strings.TrimSpace(outPath) == "" {
 		return c.printCallError(common.jsonOutput, selectOpts, &igwerr.UsageError{Msg: "--include-headers with --stream requires --out"})
 	}
+	if sse.enabled {
+		stream = true
+		headers = sse.withAcceptHeader(headers)
+	}
 
 	client := &gateway.Client{
-		BaseURL: resolved.GatewayURL,
-		Token:   resolved.Token,
-		HTTP:    c.runtimeHTTPClient(),
+		BaseURL:        resolved.GatewayURL,
+		Token:          resolved.Token,
+		HTTP:           httpClient,
+		Strategy:       gwStrategy,
+		Signer:         signer,
+		Rand:           jitterRand,
+		Recorder:       chainRecorders(har.recorder(), verbose.recorder()),
+		OnRetry:        verbose.onRetry(),
+		Session:        session,
+		Proxy:          proxy,
+		DefaultHeaders: defaultHeaders,
+	}
+
+	callCtx, cancelDeadline, err := deadline.context(context.Background(), c.clock)
+	if err != nil {
+		return c.printCallError(common.jsonOutput, selectOpts, err)
+	}
+	defer cancelDeadline()
+	if expandSpec != nil && maxTime > 0 {
+		var cancel context.CancelFunc
+		callCtx, cancel = context.WithTimeout(callCtx, maxTime)
+		defer cancel()
+	}
+	if strictSpecVer && !curl && !fanout.enabled() {
+		if err := c.checkSpecGatewayVersion(callCtx, client, common.timeout, specFile); err != nil {
+			if deadline.enabled() && callCtx.Err() != nil {
+				err = deadlineExceededError("the spec version check", err)
+			}
+			return c.printCallError(common.jsonOutput, selectOpts, err)
+		}
 	}
 
 	streamWriter, closeStreamWriter, err := c.callOutputWriter(outPath, stream, common.jsonOutput)
@@ -196,31 +841,242 @@ func (c *CLI) runCall(args []string) error {
 	if closeStreamWriter != nil {
 		defer closeStreamWriter()
 	}
+	// --pretty-xml and --expand need the whole body before it can be
+	// written to --out.
+	var prettyOut io.Writer
+	if prettyXML || expandSpec != nil {
+		prettyOut, streamWriter = streamWriter, nil
+	}
+
+	callTimeout := common.timeout
+	if sse.enabled {
+		var stop context.CancelFunc
+		callCtx, stop = signal.NotifyContext(callCtx, os.Interrupt)
+		defer stop()
+		if maxTime > 0 {
+			callTimeout = maxTime
+		}
+		sseOut := newSSEWriter(streamWriter, outputFlush)
+		defer func() { _ = sseOut.flush() }()
+		streamWriter = sseOut
+	}
+
+	rawDump, closeRawDump, err := openRawDumpFile(dumpRawPath)
+	if err != nil {
+		return c.printCallError(common.jsonOutput, selectOpts, err)
+	}
+	if closeRawDump != nil {
+		defer closeRawDump()
+	}
 
 	bodyBytes, err := readBody(c.In, body)
 	if err != nil {
 		return c.printCallError(common.jsonOutput, selectOpts, err)
 	}
+	if bodyBase64 != "" {
+		bodyBytes, err = readBase64Body(c.In, bodyBase64)
+		if err != nil {
+			return c.printCallError(common.jsonOutput, selectOpts, err)
+		}
+	}
+	if len(bodyMerges) > 0 {
+		bodyBytes, err = mergeBodyFragments(c.In, bodyBytes, bodyMerges, mergeArrays)
+		if err != nil {
+			return c.printCallError(common.jsonOutput, selectOpts, err)
+		}
+	}
+	if useExample && body == "" && bodyBase64 == "" {
+		if len(resolvedOp.RequestBodyExample) > 0 {
+			bodyBytes = append([]byte(nil), resolvedOp.RequestBodyExample...)
+			c.info("note: using request body example from operationId %q", resolvedOp.OperationID)
+		} else {
+			fmt.Fprintf(c.Err, "warning: operationId %q has no request body example; sending without a body\n", resolvedOp.OperationID)
+		}
+	}
+	if patchOps != nil {
+		if strings.TrimSpace(fetchPath) == "" {
+			fetchPath = path
+		}
+		bodyBytes, err = fetchPatchedBody(client, callExecutionInput{
+			Context:          callCtx,
+			Path:             fetchPath,
+			Headers:          headers,
+			Timeout:          callTimeout,
+			Retry:            retry,
+			RetryBackoff:     retryBackoff,
+			RetryAfterMax:    retryAfterMax,
+			RetryJitter:      retryJitter,
+			RetryMaxWait:     retryMaxWait,
+			RetryOn:          retryOnFn,
+			IgnoreRetryAfter: ignoreRetryAf,
+			Summary:          summary,
+			Policy:           resolved.Policy,
+		}, patchOps)
+		if err != nil {
+			if deadline.enabled() && callCtx.Err() != nil {
+				err = deadlineExceededError("the --apply-patch fetch", err)
+			}
+			return c.printCallError(common.jsonOutput, selectOpts, err)
+		}
+	}
+	if bodyQuery != nil {
+		bodyBytes, err = applyBodyJQ(bodyQuery, bodyBytes)
+		if err != nil {
+			return c.printCallError(common.jsonOutput, selectOpts, err)
+		}
+	}
+	if resolvedOp != nil {
+		if err := checkOperationBody(c.Err, *resolvedOp, bodyBytes, form != nil); err != nil {
+			return c.printCallError(common.jsonOutput, selectOpts, err)
+		}
+	}
 
 	start := time.Now()
-	resp, _, _, err := executeCallCore(client, callExecutionInput{
-		Method:       method,
-		Path:         path,
-		Query:        queries,
-		Headers:      headers,
-		Body:         bodyBytes,
-		ContentType:  contentType,
-		DryRun:       dryRun,
-		Yes:          yes,
-		Timeout:      common.timeout,
-		Retry:        retry,
-		RetryBackoff: retryBackoff,
-		Stream:       streamWriter,
-		MaxBodyBytes: maxBodyBytes,
-		EnableTiming: common.timing || common.jsonStats,
-	})
+	input := callExecutionInput{
+		Context:              callCtx,
+		Method:               method,
+		Path:                 path,
+		Query:                queries,
+		Headers:              headers,
+		Body:                 bodyBytes,
+		ContentType:          contentType,
+		Form:                 form,
+		NoDefaultContentType: noDefaultCT,
+		DryRun:               dryRun,
+		Yes:                  yes,
+		Timeout:              callTimeout,
+		Retry:                retry,
+		RetryBackoff:         retryBackoff,
+		RetryOnBody:          bodyMatch.retryFunc(),
+		RetryAfterMax:        retryAfterMax,
+		IgnoreRetryAfter:     ignoreRetryAf,
+		RetryJitter:          retryJitter,
+		RetryMaxWait:         retryMaxWait,
+		RetryOn:              retryOnFn,
+		HedgeAfter:           hedgeAfter,
+		RawPath:              rawPath,
+		AcceptStatus:         acceptStatusFn,
+		FollowRedirects:      followLimit,
+		Stream:               streamWriter,
+		RawDump:              rawDump,
+		MaxBodyBytes:         maxBodyBytes,
+		EnableTiming:         common.timing || common.jsonStats,
+		Summary:              summary,
+		Outcomes:             outcomes,
+		Policy:               resolved.Policy,
+	}
+	if progressOut {
+		input.Progress = newDownloadProgress(c.Err, c.clock)
+	}
+	if strings.TrimSpace(attemptsOut) != "" {
+		input.Attempts = &attemptCounter{}
+	}
+	if curl {
+		return c.printCallCurl(client, input, selectOpts, common.jsonOutput)
+	}
+	if fanout.enabled() {
+		parallel := defaultFanoutParallel
+		if flagWasSet(fs, "parallel") {
+			parallel = batchParallel
+		}
+		limiter := gateway.NewRateLimiter(rate)
+		return c.runCallFanout(input, callFanoutRun{
+			Profiles:       fanoutProfiles,
+			Parallel:       parallel,
+			IncludeHeaders: common.includeHeaders,
+			JSONOutput:     common.jsonOutput,
+			Compact:        common.compactJSON,
+			NewClient: func(profile config.Effective) (*gateway.Client, error) {
+				profileProxy, err := common.clientProxy(profile)
+				if err != nil {
+					return nil, err
+				}
+				profileHTTP, err := c.gatewayHTTPClient(common.tls, profile)
+				if err != nil {
+					return nil, err
+				}
+				profileHeaders, err := configDefaultHeaders(profile)
+				if err != nil {
+					return nil, err
+				}
+				profileVerbose := c.newVerboseTracer(common.verbose, profile.Token)
+				return &gateway.Client{
+					BaseURL:        profile.GatewayURL,
+					Token:          profile.Token,
+					HTTP:           profileHTTP,
+					Strategy:       gwStrategy,
+					Signer:         signer,
+					Rand:           jitterRand,
+					Recorder:       chainRecorders(har.recorder(), profileVerbose.recorder()),
+					OnRetry:        profileVerbose.onRetry(),
+					Session:        session,
+					Limiter:        limiter,
+					Proxy:          profileProxy,
+					DefaultHeaders: profileHeaders,
+				}, nil
+			},
+		})
+	}
+	var resp *gateway.CallResponse
+	var latency *callLatencyStats
+	var pages []callPageTiming
+	cached := false
+	if paginate.enabled {
+		resp, pages, err = paginateCall(client, input, paginate)
+	} else if repeat > 1 {
+		var stats callLatencyStats
+		resp, stats, err = c.repeatCall(client, input, repeat)
+		if stats.Count > 0 {
+			latency = &stats
+			if !common.jsonOutput {
+				printLatencySummary(c.Err, stats)
+			}
+		}
+	} else if cacheOpts.enabled() {
+		resp, cached, err = c.executeCachedCall(client, input, cacheOpts)
+	} else {
+		resp, _, _, err = executeCallCore(client, input)
+	}
+	if writeErr := input.Attempts.writeFile(strings.TrimSpace(attemptsOut)); writeErr != nil {
+		fmt.Fprintf(c.Err, "warning: %v\n", writeErr)
+	}
+	if entry, ok := newHistoryEntry(input, resolved, start, resp, err); ok && !cached {
+		c.recordHistory(entry)
+	}
+	if sse.enabled && err != nil && sseStopped(callCtx, maxTime, err) {
+		return nil
+	}
 	if err != nil {
-		return c.printCallError(common.jsonOutput, selectOpts, err)
+		if deadline.enabled() && callCtx.Err() != nil {
+			err = deadlineExceededError("the request", err)
+		}
+		return c.printCallError(common.jsonOutput, selectOpts, expect.check(err))
+	}
+	input.Progress.finish()
+	var validation []apidocs.SchemaViolation
+	validated := false
+	if validateResp {
+		// Validate before --expand rewrites the body.
+		validation, validated = validateCallResponse(resolvedOp, resp)
+	}
+	if expandSpec != nil {
+		expandInput := input
+		expandInput.Query = nil
+		expandInput.Body = nil
+		expandInput.Form = nil
+		expandInput.DryRun = false
+		expandInput.RetryOnBody = nil
+		expandInput.Stream = nil
+		expandInput.RawDump = nil
+		expandInput.Progress = nil
+		expandInput.Attempts = nil
+		resp.Body, err = expandResponse(client, expandInput, expandSpec, resp.Body, batchParallel)
+		if err != nil {
+			if deadline.enabled() && callCtx.Err() != nil {
+				err = deadlineExceededError("the --expand requests", err)
+			}
+			return c.printCallError(common.jsonOutput, selectOpts, err)
+		}
 	}
 
 	bodyFile := ""
@@ -229,13 +1085,39 @@ func (c *CLI) runCall(args []string) error {
 	}
 
 	timingPayload := buildCallStats(resp, time.Since(start).Milliseconds())
+	timingPayload.Latency = latency
+	timingPayload.Cached = cached
+	if paginate.enabled {
+		timingPayload.Pages = len(pages)
+		timingPayload.PageTimings = pages
+	}
+
+	var matchErr error
+	if failOnBody && bodyMatch.Matches(resp.Body) {
+		matchErr = bodyMatchError(resp)
+	}
+	warnings := responseWarnings(resp.Headers)
+	printResponseWarnings(c.Err, warnings)
+	if matchErr == nil && failOnWarning && len(warnings) > 0 {
+		matchErr = warningHeaderError(resp)
+	}
+	if validateResp && !common.jsonOutput {
+		printResponseValidation(c.Err, resolvedOp, resp, validation, validated)
+	}
+	if matchErr == nil && strictValid && len(validation) > 0 {
+		matchErr = responseValidationError(resp)
+	}
 
 	if common.jsonOutput {
 		payload := callJSONEnvelope{
-			OK: true,
+			OK:     true,
+			Cached: cached,
 			Request: callJSONRequest{
-				Method: resp.Method,
-				URL:    resp.URL,
+				Method:      resp.Method,
+				URL:         resp.URL,
+				ContentType: form.contentType(),
+				FormFields:  form.fieldNames(),
+				HeaderNames: requestHeaderNames(defaultHeaders, headers),
 			},
 			Response: callJSONResponse{
 				Status:    resp.StatusCode,
@@ -246,16 +1128,40 @@ func (c *CLI) runCall(args []string) error {
 				Bytes:     resp.BodyBytes,
 			},
 		}
-		if common.jsonStats || common.timing {
+		if common.jsonStats || common.timing || latency != nil || paginate.enabled {
 			payload.Stats = &timingPayload
 		}
+		payload.Redirects = resp.Redirects
+		payload.Warnings = warnings
+		payload.Validation = validation
+		if matchErr != nil {
+			errPayload := jsonErrorPayload(matchErr)
+			payload.OK = false
+			payload.Code = igwerr.ExitCode(matchErr)
+			payload.Error = matchErr.Error()
+			payload.Details, _ = errPayload["details"].(map[string]any)
+		}
 		if selectWriteErr := printJSONSelection(c.Out, payload, selectOpts); selectWriteErr != nil {
 			return c.printCallError(common.jsonOutput, selectionErrorOptions(selectOpts), selectWriteErr)
 		}
-		return nil
+		return matchErr
+	}
+
+	if prettyXML && isXMLContentType(resp.Headers.Get("Content-Type")) {
+		if formatted, fmtErr := indentXML(resp.Body); fmtErr != nil {
+			fmt.Fprintf(c.Err, "warning: --pretty-xml left the response unchanged: %v\n", fmtErr)
+		} else {
+			resp.Body = formatted
+		}
+	}
+	if prettyOut != nil {
+		if _, err := prettyOut.Write(resp.Body); err != nil {
+			return igwerr.NewTransportError(err)
+		}
 	}
 
 	if common.includeHeaders {
+		printRedirectChain(c.Out, resp.Redirects)
 		fmt.Fprintf(c.Out, "HTTP %d\n", resp.StatusCode)
 		for k, vals := range resp.Headers {
 			for _, v := range vals {
@@ -266,21 +1172,39 @@ func (c *CLI) runCall(args []string) error {
 	}
 
 	if bodyFile != "" {
-		fmt.Fprintf(c.Out, "saved response body: %s\n", bodyFile)
+		c.info("saved response body: %s", bodyFile)
 		if common.timing {
 			printTimingSummary(c.Err, timingPayload)
 		}
-		return nil
+		if matchErr != nil {
+			fmt.Fprintln(c.Err, matchErr.Error())
+		}
+		return matchErr
 	}
 
 	if stream && strings.TrimSpace(outPath) == "" {
 		if common.timing {
 			printTimingSummary(c.Err, timingPayload)
 		}
-		return nil
+		if matchErr != nil {
+			fmt.Fprintln(c.Err, matchErr.Error())
+		}
+		return matchErr
 	}
 
-	if len(resp.Body) > 0 {
+	if grep.enabled() {
+		if err := grep.write(c.Out, resp.Body); err != nil {
+			return c.printCallError(false, selectOpts, err)
+		}
+	} else if outputFormat == outputFormatKV {
+		if err := writeKVBody(c.Out, resp.Body); err != nil {
+			return igwerr.NewTransportError(err)
+		}
+	} else if isRecordOutputFormat(outputFormat) {
+		if err := writeRecordTable(c.Out, c.Err, outputFormat, parseColumns(columns), resp.Body); err != nil {
+			return igwerr.NewTransportError(err)
+		}
+	} else if len(resp.Body) > 0 {
 		if _, err := c.Out.Write(resp.Body); err != nil {
 			return igwerr.NewTransportError(err)
 		}
@@ -288,8 +1212,11 @@ func (c *CLI) runCall(args []string) error {
 	if common.timing {
 		printTimingSummary(c.Err, timingPayload)
 	}
+	if matchErr != nil {
+		fmt.Fprintln(c.Err, matchErr.Error())
+	}
 
-	return nil
+	return matchErr
 }
 
 func (c *CLI) callOutputWriter(outPath string, stream bool, jsonOutput bool) (io.Writer, func() error, error) {
@@ -312,6 +1239,105 @@ func (c *CLI) callOutputWriter(outPath string, stream bool, jsonOutput bool) (io
 	return outFile, outFile.Close, nil
 }
 
+func openRawDumpFile(path string) (io.Writer, func() error, error) {
+	path = strings.TrimSpace(path)
+	if path == "" {
+		return nil, nil, nil
+	}
+	dumpFile, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0o600)
+	if err != nil {
+		return nil, nil, igwerr.NewTransportError(err)
+	}
+	return dumpFile, dumpFile.Close, nil
+}
+
+// resolveOperationWithHints resolves --op to exactly one operation. An
+// operationId may be qualified as "namespace:operationId", where namespace
+// matches a tag or path segment, and methodHint narrows candidates by HTTP
+// method before ambiguity is reported.
+func resolveOperationWithHints(ops []apidocs.Operation, op string, methodHint string, specFile string) ([]apidocs.Operation, error) {
+	matches, err := matchOperationWithHints(ops, op, methodHint, specFile)
+	if err != nil {
+		return nil, err
+	}
+	if len(matches) > 1 {
+		return nil, ambiguousOperationError(op, matches)
+	}
+	return matches, nil
+}
+
+// matchOperationWithHints applies the same namespace and method filtering as
+// resolveOperationWithHints but returns every remaining candidate, so
+// callers can list them when the operationId is ambiguous.
+func matchOperationWithHints(ops []apidocs.Operation, op string, methodHint string, specFile string) ([]apidocs.Operation, error) {
+	op = strings.TrimSpace(op)
+	methodHint = strings.ToUpper(strings.TrimSpace(methodHint))
+
+	namespace := ""
+	matches := resolveOperationsByID(ops, op)
+	if len(matches) == 0 {
+		if ns, id, ok := strings.Cut(op, ":"); ok && strings.TrimSpace(ns) != "" && strings.TrimSpace(id) != "" {
+			namespace = strings.TrimSpace(ns)
+			matches = resolveOperationsByID(ops, id)
+		}
+	}
+	if len(matches) == 0 {
+		msg := fmt.Sprintf("operationId %q not found in spec %q", op, strings.TrimSpace(specFile))
+		if suggestions := suggestOperationIDs(ops, op); len(suggestions) > 0 {
+			msg += fmt.Sprintf("; did you mean: %s?", strings.Join(suggestions, ", "))
+		}
+		return nil, &igwerr.UsageError{Msg: msg}
+	}
+
+	candidates := matches
+	if namespace != "" {
+		matches = filterOperations(matches, func(candidate apidocs.Operation) bool {
+			return operationInNamespace(candidate, namespace)
+		})
+	}
+	if methodHint != "" {
+		matches = filterOperations(matches, func(candidate apidocs.Operation) bool {
+			return strings.EqualFold(candidate.Method, methodHint)
+		})
+	}
+	if len(matches) == 0 {
+		return nil, &igwerr.UsageError{
+			Msg: fmt.Sprintf("operationId %q has no match for the given namespace/method (candidates: %s)", op, formatOperationMatches(candidates)),
+		}
+	}
+	return matches, nil
+}
+
+func ambiguousOperationError(op string, matches []apidocs.Operation) error {
+	return &igwerr.UsageError{
+		Msg: fmt.Sprintf("operationId %q is ambiguous (%d matches): %s; qualify with namespace:operationId or --op-method", strings.TrimSpace(op), len(matches), formatOperationMatches(matches)),
+	}
+}
+
+func filterOperations(ops []apidocs.Operation, keep func(apidocs.Operation) bool) []apidocs.Operation {
+	out := make([]apidocs.Operation, 0, len(ops))
+	for _, op := range ops {
+		if keep(op) {
+			out = append(out, op)
+		}
+	}
+	return out
+}
+
+func operationInNamespace(op apidocs.Operation, namespace string) bool {
+	for _, tag := range op.Tags {
+		if strings.EqualFold(strings.TrimSpace(tag), namespace) {
+			return true
+		}
+	}
+	for _, segment := range strings.Split(op.Path, "/") {
+		if segment != "" && strings.EqualFold(segment, namespace) {
+			return true
+		}
+	}
+	return false
+}
+
 func resolveOperationsByID(ops []apidocs.Operation, operationID string) []apidocs.Operation {
 	operationID = strings.TrimSpace(operationID)
 	if operationID == "" {
@@ -383,11 +1409,24 @@ type callJSONEnvelope struct {
 	Request  callJSONRequest  `json:"request,omitempty"`
 	Response callJSONResponse `json:"response,omitempty"`
 	Stats    *callStats       `json:"stats,omitempty"`
+	// Cached is set when --cache-ttl served the response from the cache.
+	Cached    bool                  `json:"cached,omitempty"`
+	Redirects []gateway.Redirect    `json:"redirects,omitempty"`
+	Warnings  []callResponseWarning `json:"warnings,omitempty"`
+	// Validation lists --validate-response schema mismatches.
+	Validation []apidocs.SchemaViolation `json:"validation,omitempty"`
 }
 
 type callJSONRequest struct {
 	Method string `json:"method"`
 	URL    string `json:"url"`
+	// ContentType and FormFields describe a --form body; values are
+	// never echoed.
+	ContentType string   `json:"contentType,omitempty"`
+	FormFields  []string `json:"formFields,omitempty"`
+	// HeaderNames lists the config defaultHeaders and --header names
+	// that were sent; values are never echoed.
+	HeaderNames []string `json:"headerNames,omitempty"`
 }
 
 type callJSONResponse struct {
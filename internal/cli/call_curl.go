@@ -0,0 +1,83 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/alex-mccollum/igw-cli/internal/gateway"
+)
+
+// curlTokenPlaceholder stands in for the API token so printed commands
+// never contain it.
+const curlTokenPlaceholder = "$IGNITION_API_TOKEN"
+
+// printCallCurl prints the curl command equivalent to input instead of
+// sending it. Nothing is sent, so mutating methods need no --yes.
+func (c *CLI) printCallCurl(client *gateway.Client, input callExecutionInput, selectOpts jsonSelectOptions, jsonOutput bool) error {
+	input.Yes = true
+	req, err := buildCallRequest(input)
+	if err != nil {
+		return c.printCallError(jsonOutput, selectOpts, err)
+	}
+	httpReq, err := client.NewRequest(context.Background(), req)
+	if err != nil {
+		return c.printCallError(jsonOutput, selectOpts, err)
+	}
+
+	command, stdinBody := curlCommand(httpReq, req.Body)
+	if stdinBody {
+		c.info("note: the request body is binary; pipe the same %d bytes to curl on stdin", len(req.Body))
+	}
+	if jsonOutput {
+		return printJSONSelection(c.Out, map[string]any{"curl": command}, selectOpts)
+	}
+	fmt.Fprintln(c.Out, command)
+	return nil
+}
+
+// curlCommand renders req as a single-line curl command. Text bodies are
+// inlined; binary bodies are read from stdin, reported by the second
+// result.
+func curlCommand(req *http.Request, body []byte) (string, bool) {
+	parts := []string{"curl"}
+	if req.Method != http.MethodGet || len(body) > 0 {
+		parts = append(parts, "-X", req.Method)
+	}
+	parts = append(parts, shellQuote(req.URL.String()))
+
+	names := make([]string, 0, len(req.Header))
+	for name := range req.Header {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		for _, value := range req.Header[name] {
+			if name == http.CanonicalHeaderKey(gateway.TokenHeader) {
+				parts = append(parts, "-H", `"`+gateway.TokenHeader+": "+curlTokenPlaceholder+`"`)
+				continue
+			}
+			parts = append(parts, "-H", shellQuote(name+": "+value))
+		}
+	}
+
+	stdinBody := false
+	if len(body) > 0 {
+		if utf8.Valid(body) && !bytes.ContainsRune(body, 0) {
+			parts = append(parts, "--data-binary", shellQuote(string(body)))
+		} else {
+			parts = append(parts, "--data-binary", "@-")
+			stdinBody = true
+		}
+	}
+	return strings.Join(parts, " "), stdinBody
+}
+
+// shellQuote single-quotes s for POSIX shells.
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
@@ -0,0 +1,103 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+// noSendTestClient fails the test if --curl sends anything.
+func noSendTestClient(t *testing.T) *http.Client {
+	t.Helper()
+
+	return newMockHTTPClient(func(r *http.Request) (*http.Response, error) {
+		t.Fatalf("--curl must not send %s %s", r.Method, r.URL)
+		return nil, nil
+	})
+}
+
+func TestCallCurlPrintsResolvedCommandWithoutSending(t *testing.T) {
+	t.Parallel()
+
+	specPath := writeCallOpSpec(t, callOpSpecFixture)
+	out := new(bytes.Buffer)
+	c := newAdminWrapperTestCLI(noSendTestClient(t))
+	c.Out = out
+	err := c.Execute([]string{
+		"call",
+		"--gateway-url", mockGatewayURL,
+		"--api-key", "curl-secret",
+		"--op", "scanProjects",
+		"--spec-file", specPath,
+		"--query", "force=true",
+		"--dry-run",
+		"--header", "X-Note: it's here",
+		"--body", `{"a":1}`,
+		"--curl",
+	})
+	if err != nil {
+		t.Fatalf("curl without --yes: %v", err)
+	}
+
+	got := strings.TrimSpace(out.String())
+	want := `curl -X POST 'http://gateway.test/data/api/v1/scan/projects?dryRun=true&force=true'` +
+		` -H 'Content-Type: application/json'` +
+		` -H "X-Ignition-API-Token: $IGNITION_API_TOKEN"` +
+		` -H 'X-Note: it'\''s here'` +
+		` --data-binary '{"a":1}'`
+	if got != want {
+		t.Fatalf("unexpected command\n got: %s\nwant: %s", got, want)
+	}
+	if strings.Contains(got, "curl-secret") {
+		t.Fatalf("command leaked the token: %s", got)
+	}
+}
+
+func TestCallCurlJSONAndBinaryBody(t *testing.T) {
+	t.Parallel()
+
+	out := new(bytes.Buffer)
+	errOut := new(bytes.Buffer)
+	c := newAdminWrapperTestCLI(noSendTestClient(t))
+	c.Out = out
+	c.Err = errOut
+	err := c.Execute([]string{
+		"call",
+		"--gateway-url", mockGatewayURL,
+		"--api-key", "curl-secret",
+		"--method", "PUT",
+		"--path", "/data/api/v1/resources/x",
+		"--body-base64", "AP8=",
+		"--content-type", "application/octet-stream",
+		"--curl",
+		"--json",
+	})
+	if err != nil {
+		t.Fatalf("curl json: %v", err)
+	}
+
+	var payload struct {
+		Curl string `json:"curl"`
+	}
+	if err := json.Unmarshal(out.Bytes(), &payload); err != nil {
+		t.Fatalf("parse: %v\n%s", err, out.String())
+	}
+	if !strings.HasSuffix(payload.Curl, "--data-binary @-") || !strings.HasPrefix(payload.Curl, "curl -X PUT ") {
+		t.Fatalf("unexpected command %q", payload.Curl)
+	}
+	if !strings.Contains(errOut.String(), "pipe the same 2 bytes") {
+		t.Fatalf("expected stdin note, got %q", errOut.String())
+	}
+}
+
+func TestCallCurlRejectsMultiRequestModes(t *testing.T) {
+	t.Parallel()
+
+	c := newAdminWrapperTestCLI(noSendTestClient(t))
+	base := []string{"call", "--gateway-url", mockGatewayURL, "--api-key", "curl-secret", "--path", "/data/api/v1/projects", "--curl"}
+	requireUsageExitCode(t, c.Execute(append(base, "--repeat", "3")))
+	requireUsageExitCode(t, c.Execute(append(base, "--paginate")))
+	requireUsageExitCode(t, c.Execute(append(base, "--stream")))
+}
@@ -0,0 +1,154 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+
+	"github.com/alex-mccollum/igw-cli/internal/gateway"
+	"github.com/alex-mccollum/igw-cli/internal/igwerr"
+)
+
+// callExpand is a parsed --expand <field>=<pathTemplate> client-side join.
+type callExpand struct {
+	field    string
+	template string
+	key      string
+}
+
+func parseCallExpand(value string, key string) (*callExpand, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		if strings.TrimSpace(key) != "" {
+			return nil, &igwerr.UsageError{Msg: "--expand-key requires --expand"}
+		}
+		return nil, nil
+	}
+	field, template, ok := strings.Cut(value, "=")
+	field, template = strings.TrimSpace(field), strings.TrimSpace(template)
+	if !ok || field == "" || template == "" {
+		return nil, &igwerr.UsageError{Msg: "--expand must be <field>=<pathTemplate>"}
+	}
+	if !strings.HasPrefix(template, "/") || !strings.Contains(template, "{}") {
+		return nil, &igwerr.UsageError{Msg: "--expand path template must start with / and contain {}"}
+	}
+	key = strings.TrimSpace(key)
+	if key == "" {
+		key = field + "Expanded"
+	}
+	return &callExpand{field: field, template: template, key: key}, nil
+}
+
+// path substitutes an element's field value into the template.
+func (e *callExpand) path(value any) (string, bool) {
+	var id string
+	switch v := value.(type) {
+	case string:
+		id = v
+	case json.Number:
+		id = v.String()
+	case bool:
+		id = fmt.Sprint(v)
+	default:
+		return "", false
+	}
+	if id == "" {
+		return "", false
+	}
+	return strings.ReplaceAll(e.template, "{}", url.PathEscape(id)), true
+}
+
+// expandResponse issues one GET per array element (at most parallel at a
+// time) and embeds each decoded sub-response under e.key. Elements without
+// the field, or with a non-scalar value, are left unchanged.
+func expandResponse(client *gateway.Client, base callExecutionInput, e *callExpand, body []byte, parallel int) ([]byte, error) {
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+	var items []any
+	if err := decoder.Decode(&items); err != nil {
+		return nil, fmt.Errorf("--expand requires a JSON array response: %w", err)
+	}
+
+	type job struct {
+		item map[string]any
+		path string
+	}
+	jobs := make([]job, 0, len(items))
+	for _, raw := range items {
+		item, ok := raw.(map[string]any)
+		if !ok {
+			continue
+		}
+		if path, ok := e.path(item[e.field]); ok {
+			jobs = append(jobs, job{item: item, path: path})
+		}
+	}
+
+	if parallel < 1 {
+		parallel = 1
+	}
+	var (
+		mu       sync.Mutex
+		firstErr error
+		wg       sync.WaitGroup
+	)
+	work := make(chan job)
+	for worker := 0; worker < parallel; worker++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := range work {
+				input := base
+				input.Method = http.MethodGet
+				input.Path = j.path
+				input.OperationID = ""
+				input.Outcomes = nil
+				resp, _, _, err := executeCallCore(client, input)
+				var embedded any
+				if err == nil {
+					embedded = decodeExpandedBody(resp.Body)
+				}
+				mu.Lock()
+				if err != nil && firstErr == nil {
+					firstErr = fmt.Errorf("expand %s: %w", j.path, err)
+				}
+				if err == nil {
+					j.item[e.key] = embedded
+				}
+				mu.Unlock()
+			}
+		}()
+	}
+	for _, j := range jobs {
+		mu.Lock()
+		failed := firstErr != nil
+		mu.Unlock()
+		if failed {
+			break
+		}
+		work <- j
+	}
+	close(work)
+	wg.Wait()
+	if firstErr != nil {
+		return nil, firstErr
+	}
+
+	return json.Marshal(items)
+}
+
+// decodeExpandedBody embeds JSON sub-responses as values and anything else
+// as a string.
+func decodeExpandedBody(body []byte) any {
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+	var value any
+	if err := decoder.Decode(&value); err != nil || decoder.More() {
+		return string(body)
+	}
+	return value
+}
@@ -0,0 +1,107 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"testing"
+)
+
+func TestCallExpandEmbedsSubResources(t *testing.T) {
+	t.Parallel()
+
+	var subCalls atomic.Int32
+	client := newMockHTTPClient(func(r *http.Request) (*http.Response, error) {
+		switch r.URL.Path {
+		case "/data/api/v1/projects":
+			return mockHTTPResponse(http.StatusOK, `[{"id":"alpha"},{"id":"beta gamma"},{"name":"no-id"}]`, nil), nil
+		case "/data/api/v1/projects/alpha":
+			subCalls.Add(1)
+			return mockHTTPResponse(http.StatusOK, `{"title":"Alpha","enabled":true}`, nil), nil
+		case "/data/api/v1/projects/beta gamma":
+			subCalls.Add(1)
+			return mockHTTPResponse(http.StatusOK, `{"title":"Beta","enabled":false}`, nil), nil
+		}
+		t.Errorf("unexpected request %s", r.URL.String())
+		return mockHTTPResponse(http.StatusNotFound, `{}`, nil), nil
+	})
+
+	c := newAdminWrapperTestCLI(client)
+	if err := c.Execute([]string{
+		"call",
+		"--gateway-url", mockGatewayURL,
+		"--api-key", "secret",
+		"--path", "/data/api/v1/projects",
+		"--expand", "id=/data/api/v1/projects/{}",
+		"--expand-key", "project",
+		"--parallel", "2",
+	}); err != nil {
+		t.Fatalf("call --expand failed: %v", err)
+	}
+	if subCalls.Load() != 2 {
+		t.Fatalf("expected 2 sub-requests, got %d", subCalls.Load())
+	}
+
+	var got []map[string]any
+	if err := json.Unmarshal(c.Out.(*bytes.Buffer).Bytes(), &got); err != nil {
+		t.Fatalf("output is not a JSON array: %v (%s)", err, c.Out.(*bytes.Buffer).String())
+	}
+	if len(got) != 3 {
+		t.Fatalf("expected 3 elements, got %d", len(got))
+	}
+	alpha, _ := got[0]["project"].(map[string]any)
+	if alpha["title"] != "Alpha" || alpha["enabled"] != true {
+		t.Fatalf("unexpected alpha expansion %#v", got[0])
+	}
+	beta, _ := got[1]["project"].(map[string]any)
+	if beta["title"] != "Beta" {
+		t.Fatalf("unexpected beta expansion %#v", got[1])
+	}
+	if _, ok := got[2]["project"]; ok {
+		t.Fatalf("element without id should not be expanded: %#v", got[2])
+	}
+}
+
+func TestCallExpandFailsWhenSubRequestFails(t *testing.T) {
+	t.Parallel()
+
+	client := newMockHTTPClient(func(r *http.Request) (*http.Response, error) {
+		if r.URL.Path == "/data/api/v1/projects" {
+			return mockHTTPResponse(http.StatusOK, `[{"id":1}]`, nil), nil
+		}
+		return mockHTTPResponse(http.StatusNotFound, `{"error":"missing"}`, nil), nil
+	})
+
+	c := newAdminWrapperTestCLI(client)
+	err := c.Execute([]string{
+		"call",
+		"--gateway-url", mockGatewayURL,
+		"--api-key", "secret",
+		"--path", "/data/api/v1/projects",
+		"--expand", "id=/data/api/v1/projects/{}",
+	})
+	if err == nil || !strings.Contains(err.Error(), "expand /data/api/v1/projects/1") {
+		t.Fatalf("expected expand failure, got %v", err)
+	}
+}
+
+func TestCallExpandValidation(t *testing.T) {
+	t.Parallel()
+
+	for _, args := range [][]string{
+		{"--path", "/data/api/v1/projects", "--expand", "id"},
+		{"--path", "/data/api/v1/projects", "--expand", "id=/data/api/v1/projects"},
+		{"--path", "/data/api/v1/projects", "--expand-key", "project"},
+		{"--method", "POST", "--path", "/data/api/v1/projects", "--expand", "id=/p/{}", "--yes"},
+		{"--path", "/data/api/v1/projects", "--parallel", "4"},
+	} {
+		c := newAdminWrapperTestCLI(newMockHTTPClient(func(r *http.Request) (*http.Response, error) {
+			t.Errorf("unexpected request for %v", args)
+			return mockHTTPResponse(http.StatusOK, `[]`, nil), nil
+		}))
+		err := c.Execute(append([]string{"call", "--gateway-url", mockGatewayURL, "--api-key", "secret"}, args...))
+		requireUsageExitCode(t, err)
+	}
+}
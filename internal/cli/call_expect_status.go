@@ -0,0 +1,76 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/alex-mccollum/igw-cli/internal/exitcode"
+	"github.com/alex-mccollum/igw-cli/internal/igwerr"
+)
+
+// callExpectStatus holds the repeatable --expect-status flag. The parsed
+// set replaces the 2xx success check, like --accept-status, but a miss
+// exits with exitcode.UnexpectedStatus instead of the auth/network codes.
+type callExpectStatus struct {
+	entries stringList
+	match   func(status int) bool
+}
+
+func (e *callExpectStatus) parse() error {
+	var parts []string
+	for _, entry := range e.entries {
+		for _, part := range strings.Split(entry, ",") {
+			parts = append(parts, strings.ToLower(strings.TrimSpace(part)))
+		}
+	}
+	e.entries = parts
+	match, err := parseStatusSet("--expect-status", strings.Join(parts, ","))
+	if err != nil {
+		return err
+	}
+	if match == nil {
+		return &igwerr.UsageError{Msg: "--expect-status must not be empty"}
+	}
+	e.match = match
+	return nil
+}
+
+func (e *callExpectStatus) enabled() bool {
+	return e != nil && e.match != nil
+}
+
+// check turns a status failure into an unexpectedStatusError; other
+// errors pass through.
+func (e *callExpectStatus) check(err error) error {
+	if !e.enabled() || err == nil {
+		return err
+	}
+	var statusErr *igwerr.StatusError
+	if !errors.As(err, &statusErr) {
+		return err
+	}
+	return &unexpectedStatusError{
+		Expected: append([]string(nil), e.entries...),
+		Status:   statusErr,
+	}
+}
+
+// unexpectedStatusError is a response whose status is outside
+// --expect-status.
+type unexpectedStatusError struct {
+	Expected []string
+	Status   *igwerr.StatusError
+}
+
+func (e *unexpectedStatusError) Error() string {
+	return fmt.Sprintf("http %d: expected status %s", e.Status.StatusCode, strings.Join(e.Expected, ","))
+}
+
+func (e *unexpectedStatusError) ExitCode() int {
+	return exitcode.UnexpectedStatus
+}
+
+func (e *unexpectedStatusError) Unwrap() error {
+	return e.Status
+}
@@ -0,0 +1,121 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/alex-mccollum/igw-cli/internal/exitcode"
+	"github.com/alex-mccollum/igw-cli/internal/igwerr"
+)
+
+func TestCallExpectStatusMatches(t *testing.T) {
+	t.Parallel()
+
+	c := newAdminWrapperTestCLI(newStaticHTTPClient(http.StatusConflict, `{"ok":true}`))
+	err := c.Execute([]string{
+		"call",
+		"--gateway-url", mockGatewayURL,
+		"--api-key", "secret",
+		"--path", "/data/api/v1/projects",
+		"--expect-status", "409",
+	})
+	if err != nil {
+		t.Fatalf("expected 409 to satisfy --expect-status 409, got %v", err)
+	}
+}
+
+func TestCallExpectStatusMismatchJSON(t *testing.T) {
+	t.Parallel()
+
+	var out bytes.Buffer
+	c := newAdminWrapperTestCLI(newStaticHTTPClient(http.StatusOK, `{"ok":true}`))
+	c.Out = &out
+	err := c.Execute([]string{
+		"call",
+		"--gateway-url", mockGatewayURL,
+		"--api-key", "secret",
+		"--method", "DELETE",
+		"--path", "/data/api/v1/projects/demo",
+		"--yes",
+		"--expect-status", "204",
+		"--expect-status", "4xx",
+		"--json",
+	})
+	if code := igwerr.ExitCode(err); code != exitcode.UnexpectedStatus {
+		t.Fatalf("expected exit code %d, got %d (%v)", exitcode.UnexpectedStatus, code, err)
+	}
+
+	var payload struct {
+		OK      bool   `json:"ok"`
+		Code    int    `json:"code"`
+		Error   string `json:"error"`
+		Details struct {
+			Status         int      `json:"status"`
+			ExpectedStatus []string `json:"expectedStatus"`
+		} `json:"details"`
+	}
+	if err := json.Unmarshal(out.Bytes(), &payload); err != nil {
+		t.Fatalf("decode json: %v (%q)", err, out.String())
+	}
+	if payload.OK || payload.Code != exitcode.UnexpectedStatus || payload.Details.Status != http.StatusOK {
+		t.Fatalf("unexpected payload %+v", payload)
+	}
+	if strings.Join(payload.Details.ExpectedStatus, ",") != "204,4xx" {
+		t.Fatalf("unexpected expectedStatus %v", payload.Details.ExpectedStatus)
+	}
+	if payload.Error != "http 200: expected status 204,4xx" {
+		t.Fatalf("unexpected error %q", payload.Error)
+	}
+}
+
+func TestCallWithoutExpectStatusKeepsStatusErrors(t *testing.T) {
+	t.Parallel()
+
+	c := newAdminWrapperTestCLI(newStaticHTTPClient(http.StatusForbidden, `{"ok":true}`))
+	err := c.Execute([]string{
+		"call",
+		"--gateway-url", mockGatewayURL,
+		"--api-key", "secret",
+		"--path", "/data/api/v1/gateway-info",
+	})
+	if code := igwerr.ExitCode(err); code != exitcode.Auth {
+		t.Fatalf("expected auth exit code, got %d (%v)", code, err)
+	}
+}
+
+func TestCallExpectStatusUsageErrors(t *testing.T) {
+	t.Parallel()
+
+	for _, extra := range [][]string{
+		{"--expect-status", "2xy"},
+		{"--expect-status", ""},
+		{"--expect-status", "200", "--accept-status", "2xx"},
+	} {
+		c := newAdminWrapperTestCLI(newStaticHTTPClient(http.StatusOK, `{"ok":true}`))
+		args := append([]string{
+			"call",
+			"--gateway-url", mockGatewayURL,
+			"--api-key", "secret",
+			"--path", "/data/api/v1/gateway-info",
+		}, extra...)
+		requireUsageExitCode(t, c.Execute(args))
+	}
+}
+
+func TestWrapperForwardsExpectStatus(t *testing.T) {
+	t.Parallel()
+
+	c := newAdminWrapperTestCLI(newStaticHTTPClient(http.StatusOK, `{"ok":true}`))
+	err := c.Execute([]string{
+		"projects", "list",
+		"--gateway-url", mockGatewayURL,
+		"--api-key", "secret",
+		"--expect-status", "204",
+	})
+	if code := igwerr.ExitCode(err); code != exitcode.UnexpectedStatus {
+		t.Fatalf("expected exit code %d from wrapper, got %d (%v)", exitcode.UnexpectedStatus, code, err)
+	}
+}
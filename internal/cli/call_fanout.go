@@ -0,0 +1,255 @@
+package cli
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/alex-mccollum/igw-cli/internal/config"
+	"github.com/alex-mccollum/igw-cli/internal/exitcode"
+	"github.com/alex-mccollum/igw-cli/internal/gateway"
+	"github.com/alex-mccollum/igw-cli/internal/igwerr"
+)
+
+const defaultFanoutParallel = 4
+
+// callFanoutOptions is --all-profiles / --profiles: run one call against
+// several config profiles instead of the single resolved gateway.
+type callFanoutOptions struct {
+	allProfiles    bool
+	profiles       string
+	allowMutations bool
+}
+
+func bindCallFanoutFlags(fs *flag.FlagSet, opts *callFanoutOptions) {
+	fs.BoolVar(&opts.allProfiles, "all-profiles", false, "Run the request against every config profile concurrently and print one result per profile")
+	fs.StringVar(&opts.profiles, "profiles", "", "Comma-separated config profiles to run the request against (like --all-profiles)")
+	fs.BoolVar(&opts.allowMutations, "allow-fanout-mutations", false, "Allow POST/PUT/PATCH/DELETE with --all-profiles or --profiles (also requires --yes)")
+}
+
+func (o callFanoutOptions) enabled() bool {
+	return o.allProfiles || strings.TrimSpace(o.profiles) != ""
+}
+
+func (o callFanoutOptions) args() []string {
+	var args []string
+	if o.allProfiles {
+		args = append(args, "--all-profiles")
+	}
+	if strings.TrimSpace(o.profiles) != "" {
+		args = append(args, "--profiles", strings.TrimSpace(o.profiles))
+	}
+	if o.allowMutations {
+		args = append(args, "--allow-fanout-mutations")
+	}
+	return args
+}
+
+// validate rejects fan-out combined with an explicit gateway or with call
+// modes that cannot run once per profile.
+func (o callFanoutOptions) validate(common wrapperCommon, modes callModes) error {
+	if !o.enabled() {
+		if o.allowMutations {
+			return &igwerr.UsageError{Msg: "--allow-fanout-mutations requires --all-profiles or --profiles"}
+		}
+		return nil
+	}
+	if strings.TrimSpace(common.profile) != "" || strings.TrimSpace(common.gatewayURL) != "" || strings.TrimSpace(common.apiKey) != "" || common.apiKeyStdin || strings.TrimSpace(common.tokenEnv) != "" {
+		return &igwerr.UsageError{Msg: "--all-profiles and --profiles resolve each profile's gateway; do not combine them with --profile, --gateway-url, --api-key, --api-key-stdin, or --token-env"}
+	}
+	if modes.batch || modes.curl || modes.repeat || modes.paginate || modes.expand || modes.applyPatch || modes.stream || modes.sse || modes.grep || modes.prettyXML || modes.validateResponse || modes.selection || modes.out || modes.dumpRaw || modes.attemptsOut || modes.progress || modes.output {
+		return &igwerr.UsageError{Msg: "--all-profiles and --profiles are not supported with --batch, --curl, --repeat, --paginate, --expand, --apply-patch, --stream, --sse, --grep, --pretty-xml, --validate-response, --select, --expr, --raw, --out, --dump-raw, --attempts-out, --progress, or --output"}
+	}
+	return nil
+}
+
+// checkMethod guards mutating fan-out behind both --yes and
+// --allow-fanout-mutations, since one command writes to every gateway.
+func (o callFanoutOptions) checkMethod(method string, yes bool) error {
+	method = strings.ToUpper(strings.TrimSpace(method))
+	if !isMutatingMethod(method) || (yes && o.allowMutations) {
+		return nil
+	}
+	return &igwerr.UsageError{Msg: fmt.Sprintf("method %s across profiles requires --yes and --allow-fanout-mutations", method)}
+}
+
+// selectProfiles returns the profile names to run against, sorted for
+// --all-profiles and in the given order for --profiles.
+func (o callFanoutOptions) selectProfiles(cfg config.File) ([]string, error) {
+	if o.allProfiles && strings.TrimSpace(o.profiles) != "" {
+		return nil, &igwerr.UsageError{Msg: "use either --all-profiles or --profiles, not both"}
+	}
+	if o.allProfiles {
+		names := make([]string, 0, len(cfg.Profiles))
+		for name := range cfg.Profiles {
+			names = append(names, name)
+		}
+		if len(names) == 0 {
+			return nil, &igwerr.UsageError{Msg: "--all-profiles: no profiles are configured"}
+		}
+		sort.Strings(names)
+		return names, nil
+	}
+
+	var names []string
+	seen := map[string]bool{}
+	for _, name := range strings.Split(o.profiles, ",") {
+		name = strings.TrimSpace(name)
+		if name == "" || seen[name] {
+			continue
+		}
+		if _, ok := cfg.Profiles[name]; !ok {
+			return nil, &igwerr.UsageError{Msg: fmt.Sprintf("--profiles: profile %q not found", name)}
+		}
+		seen[name] = true
+		names = append(names, name)
+	}
+	if len(names) == 0 {
+		return nil, &igwerr.UsageError{Msg: "--profiles requires at least one profile name"}
+	}
+	return names, nil
+}
+
+type callFanoutResult struct {
+	Profile  string           `json:"profile"`
+	OK       bool             `json:"ok"`
+	Code     int              `json:"code"`
+	Status   int              `json:"status,omitempty"`
+	Error    string           `json:"error,omitempty"`
+	TimingMs int64            `json:"timingMs"`
+	Request  callJSONRequest  `json:"request,omitempty"`
+	Response callJSONResponse `json:"response,omitempty"`
+	// RateWaitMs is the time spent waiting on the --rate limiter.
+	RateWaitMs int64 `json:"rateWaitMs,omitempty"`
+}
+
+type callFanoutRun struct {
+	Profiles       []string
+	Parallel       int
+	IncludeHeaders bool
+	JSONOutput     bool
+	Compact        bool
+	// NewClient builds the gateway client for one resolved profile.
+	NewClient func(config.Effective) (*gateway.Client, error)
+}
+
+// runCallFanout sends input once per profile with a bounded worker pool.
+// A failing profile never stops the others; the exit code aggregates
+// failures the same way batch mode does.
+func (c *CLI) runCallFanout(input callExecutionInput, run callFanoutRun) error {
+	parallel := run.Parallel
+	if parallel < 1 {
+		parallel = 1
+	}
+	if parallel > len(run.Profiles) {
+		parallel = len(run.Profiles)
+	}
+
+	results := make([]callFanoutResult, len(run.Profiles))
+	work := make(chan int)
+	var wg sync.WaitGroup
+	for i := 0; i < parallel; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for index := range work {
+				results[index] = c.executeFanoutProfile(run.Profiles[index], input, run)
+			}
+		}()
+	}
+	for index := range run.Profiles {
+		work <- index
+	}
+	close(work)
+	wg.Wait()
+
+	var exitState batchExitState
+	records := make([]any, 0, len(results))
+	for _, result := range results {
+		exitState.record(result.Code)
+		records = append(records, result)
+	}
+	var writeErr error
+	if run.JSONOutput {
+		writeErr = writeJSONWithOptions(c.Out, records, run.Compact)
+	} else {
+		enc := json.NewEncoder(c.Out)
+		enc.SetEscapeHTML(false)
+		for _, record := range records {
+			if writeErr = enc.Encode(record); writeErr != nil {
+				break
+			}
+		}
+	}
+	if writeErr != nil {
+		return igwerr.NewTransportError(writeErr)
+	}
+
+	exit := exitState.result()
+	if exit == exitcode.Success {
+		return nil
+	}
+	return &batchExitError{
+		msg:  "one or more profile requests failed",
+		code: exit,
+	}
+}
+
+func (c *CLI) executeFanoutProfile(profile string, input callExecutionInput, run callFanoutRun) callFanoutResult {
+	out := callFanoutResult{Profile: profile}
+	fail := func(err error) callFanoutResult {
+		out.OK = false
+		out.Code = exitCodeForError(err)
+		out.Error = err.Error()
+		return out
+	}
+
+	resolved, err := c.resolveRuntimeConfig(profile, "", "")
+	if err != nil {
+		return fail(err)
+	}
+	if strings.TrimSpace(resolved.GatewayURL) == "" {
+		return fail(&igwerr.UsageError{Msg: fmt.Sprintf("profile %q has no gateway URL", profile)})
+	}
+	if strings.TrimSpace(resolved.Token) == "" {
+		return fail(&igwerr.UsageError{Msg: fmt.Sprintf("profile %q has no API token", profile)})
+	}
+
+	client, err := run.NewClient(resolved)
+	if err != nil {
+		return fail(err)
+	}
+	input.OnRateWait = func(wait time.Duration) {
+		out.RateWaitMs += wait.Milliseconds()
+	}
+	start := time.Now()
+	resp, reqMethod, reqPath, err := executeCallCore(client, input)
+	out.TimingMs = time.Since(start).Milliseconds()
+	if reqMethod != "" || reqPath != "" {
+		out.Request = callJSONRequest{Method: reqMethod, URL: reqPath}
+	}
+	if err != nil {
+		failed := fail(err)
+		if resp != nil {
+			failed.Status = resp.StatusCode
+		}
+		return failed
+	}
+
+	out.OK = true
+	out.Code = exitcode.Success
+	out.Status = resp.StatusCode
+	out.Request = callJSONRequest{Method: resp.Method, URL: resp.URL}
+	out.Response = callJSONResponse{
+		Status:    resp.StatusCode,
+		Headers:   maybeHeaders(resp.Headers, run.IncludeHeaders),
+		Body:      string(resp.Body),
+		Bytes:     resp.BodyBytes,
+		Truncated: resp.Truncated,
+	}
+	return out
+}
@@ -0,0 +1,135 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/alex-mccollum/igw-cli/internal/config"
+	"github.com/alex-mccollum/igw-cli/internal/exitcode"
+)
+
+// fanoutTestClient answers per host: dev and prod succeed, staging is
+// unauthorized.
+func fanoutTestClient() *http.Client {
+	return newMockHTTPClient(func(r *http.Request) (*http.Response, error) {
+		switch r.URL.Host {
+		case "dev.test":
+			return mockHTTPResponse(http.StatusOK, `{"name":"dev"}`, nil), nil
+		case "staging.test":
+			return mockHTTPResponse(http.StatusUnauthorized, `{"error":"denied"}`, nil), nil
+		default:
+			return mockHTTPResponse(http.StatusOK, `{"name":"prod"}`, nil), nil
+		}
+	})
+}
+
+func fanoutTestConfig() (config.File, error) {
+	return config.File{Profiles: map[string]config.Profile{
+		"dev":     {GatewayURL: "http://dev.test", Token: "dev-token"},
+		"prod":    {GatewayURL: "http://prod.test", Token: "prod-token"},
+		"staging": {GatewayURL: "http://staging.test", Token: "staging-token"},
+	}}, nil
+}
+
+func TestCallAllProfilesAggregatesResults(t *testing.T) {
+	t.Parallel()
+
+	var out bytes.Buffer
+	c := newAdminWrapperTestCLI(fanoutTestClient())
+	c.Out = &out
+	c.ReadConfig = fanoutTestConfig
+	err := c.Execute([]string{"call", "--all-profiles", "--path", "/data/api/v1/gateway-info"})
+	if code := exitCodeForError(err); code != exitcode.Auth {
+		t.Fatalf("expected auth exit code, got %d (%v)", code, err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	if len(lines) != 3 {
+		t.Fatalf("expected one NDJSON line per profile, got %q", out.String())
+	}
+	var results []callFanoutResult
+	for _, line := range lines {
+		var result callFanoutResult
+		if jsonErr := json.Unmarshal([]byte(line), &result); jsonErr != nil {
+			t.Fatalf("parse %q: %v", line, jsonErr)
+		}
+		results = append(results, result)
+	}
+	if results[0].Profile != "dev" || !results[0].OK || results[0].Response.Body != `{"name":"dev"}` {
+		t.Fatalf("unexpected dev result %+v", results[0])
+	}
+	if results[1].Profile != "prod" || !results[1].OK {
+		t.Fatalf("unexpected prod result %+v", results[1])
+	}
+	if results[2].Profile != "staging" || results[2].OK || results[2].Code != exitcode.Auth {
+		t.Fatalf("unexpected staging result %+v", results[2])
+	}
+	if strings.Contains(out.String(), "-token") {
+		t.Fatalf("tokens leaked into output %q", out.String())
+	}
+
+	out.Reset()
+	if err := c.Execute([]string{"gateway", "info", "--profiles", "prod,dev", "--json"}); err != nil {
+		t.Fatalf("gateway info fan-out: %v", err)
+	}
+	var payload []callFanoutResult
+	if jsonErr := json.Unmarshal(out.Bytes(), &payload); jsonErr != nil {
+		t.Fatalf("parse: %v\n%s", jsonErr, out.String())
+	}
+	if len(payload) != 2 || payload[0].Profile != "prod" || payload[1].Profile != "dev" {
+		t.Fatalf("unexpected payload %s", out.String())
+	}
+}
+
+func TestCallFanoutValidation(t *testing.T) {
+	t.Parallel()
+
+	var out bytes.Buffer
+	c := newAdminWrapperTestCLI(fanoutTestClient())
+	c.Out = &out
+	c.ReadConfig = fanoutTestConfig
+	base := []string{"call", "--profiles", "dev", "--path", "/data/api/v1/scan/projects"}
+
+	requireUsageExitCode(t, c.Execute([]string{"call", "--profiles", "dev,missing", "--path", "/x"}))
+	requireUsageExitCode(t, c.Execute([]string{"call", "--all-profiles", "--profile", "dev", "--path", "/x"}))
+	requireUsageExitCode(t, c.Execute([]string{"call", "--allow-fanout-mutations", "--gateway-url", mockGatewayURL, "--api-key", "k", "--path", "/x"}))
+	requireUsageExitCode(t, c.Execute(append(base, "--method", "POST", "--yes")))
+	requireUsageExitCode(t, c.Execute(append(base, "--method", "POST", "--allow-fanout-mutations")))
+	if out.Len() != 0 {
+		t.Fatalf("expected no requests for rejected fan-out, got %q", out.String())
+	}
+
+	if err := c.Execute(append(base, "--method", "POST", "--yes", "--allow-fanout-mutations")); err != nil {
+		t.Fatalf("confirmed fan-out mutation: %v", err)
+	}
+	if !strings.Contains(out.String(), `"profile":"dev"`) {
+		t.Fatalf("unexpected output %q", out.String())
+	}
+}
+
+func TestCallFanoutOptionsValidate(t *testing.T) {
+	t.Parallel()
+
+	fanout := callFanoutOptions{profiles: "dev"}
+	if err := fanout.validate(wrapperCommon{}, callModes{fanout: true}); err != nil {
+		t.Fatalf("expected plain fan-out to be valid: %v", err)
+	}
+	for name, modes := range map[string]callModes{
+		"batch":     {batch: true},
+		"selection": {selection: true},
+		"output":    {output: true},
+	} {
+		if err := fanout.validate(wrapperCommon{}, modes); err == nil || !strings.Contains(err.Error(), "not supported with") {
+			t.Fatalf("%s: expected a fan-out conflict, got %v", name, err)
+		}
+	}
+	if err := fanout.validate(wrapperCommon{gatewayURL: mockGatewayURL}, callModes{}); err == nil {
+		t.Fatal("expected --gateway-url to conflict with fan-out")
+	}
+	if err := (callFanoutOptions{allowMutations: true}).validate(wrapperCommon{}, callModes{}); err == nil {
+		t.Fatal("expected --allow-fanout-mutations to require fan-out")
+	}
+}
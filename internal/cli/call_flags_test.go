@@ -2,6 +2,7 @@ package cli
 
 import (
 	"bytes"
+	"encoding/json"
 	"net/http"
 	"net/http/httptest"
 	"os"
@@ -327,3 +328,452 @@ func TestCallRawAndCompactAreMutuallyExclusive(t *testing.T) {
 		t.Fatalf("unexpected error: %v", err)
 	}
 }
+
+func TestCallContentTypeDefaulting(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name string
+		args []string
+		want string
+	}{
+		{name: "default json", want: "application/json"},
+		{name: "explicit", args: []string{"--content-type", "text/plain"}, want: "text/plain"},
+		{name: "no default", args: []string{"--no-default-content-type"}, want: ""},
+		{name: "no default with explicit", args: []string{"--no-default-content-type", "--content-type", "text/csv"}, want: "text/csv"},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			gotContentType := "unset"
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				gotContentType = r.Header.Get("Content-Type")
+				w.WriteHeader(http.StatusOK)
+			}))
+			defer srv.Close()
+
+			c := &CLI{
+				In:     strings.NewReader(""),
+				Out:    new(bytes.Buffer),
+				Err:    new(bytes.Buffer),
+				Getenv: func(string) string { return "" },
+				ReadConfig: func() (config.File, error) {
+					return config.File{}, nil
+				},
+				HTTPClient: srv.Client(),
+			}
+
+			args := append([]string{
+				"call",
+				"--gateway-url", srv.URL,
+				"--api-key", "secret",
+				"--method", "PUT",
+				"--path", "/data/api/v1/notes",
+				"--body", "plain text",
+				"--yes",
+			}, tc.args...)
+			if err := c.Execute(args); err != nil {
+				t.Fatalf("call failed: %v", err)
+			}
+			if gotContentType != tc.want {
+				t.Fatalf("expected Content-Type %q, got %q", tc.want, gotContentType)
+			}
+		})
+	}
+}
+
+func TestCallRetryAfterMaxMustBePositive(t *testing.T) {
+	t.Parallel()
+
+	c := &CLI{
+		In:     strings.NewReader(""),
+		Out:    new(bytes.Buffer),
+		Err:    new(bytes.Buffer),
+		Getenv: func(string) string { return "" },
+		ReadConfig: func() (config.File, error) {
+			return config.File{}, nil
+		},
+	}
+
+	err := c.Execute([]string{
+		"call",
+		"--gateway-url", "http://127.0.0.1:8088",
+		"--api-key", "secret",
+		"--path", "/data/api/v1/gateway-info",
+		"--retry-after-max", "0s",
+	})
+	requireUsageExitCode(t, err)
+}
+
+func TestCallTokenEnvPrecedence(t *testing.T) {
+	t.Parallel()
+
+	env := map[string]string{
+		"CI_IGNITION_TOKEN":  "ci-token",
+		"IGNITION_API_TOKEN": "default-env-token",
+	}
+
+	cases := []struct {
+		name      string
+		extra     []string
+		wantToken string
+	}{
+		{name: "custom env beats default env", extra: []string{"--token-env", "CI_IGNITION_TOKEN"}, wantToken: "ci-token"},
+		{name: "api key wins", extra: []string{"--token-env", "CI_IGNITION_TOKEN", "--api-key", "flag-token"}, wantToken: "flag-token"},
+		{name: "unset falls back", extra: []string{"--token-env", "MISSING_TOKEN"}, wantToken: "default-env-token"},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			var gotToken string
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				gotToken = r.Header.Get("X-Ignition-API-Token")
+				w.WriteHeader(http.StatusOK)
+				_, _ = w.Write([]byte(`{"ok":true}`))
+			}))
+			defer srv.Close()
+
+			c := &CLI{
+				In:     strings.NewReader(""),
+				Out:    new(bytes.Buffer),
+				Err:    new(bytes.Buffer),
+				Getenv: func(name string) string { return env[name] },
+				ReadConfig: func() (config.File, error) {
+					return config.File{}, nil
+				},
+				HTTPClient: srv.Client(),
+			}
+
+			args := append([]string{
+				"call",
+				"--gateway-url", srv.URL,
+				"--path", "/data/api/v1/gateway-info",
+			}, tc.extra...)
+			if err := c.Execute(args); err != nil {
+				t.Fatalf("call failed: %v", err)
+			}
+			if gotToken != tc.wantToken {
+				t.Fatalf("token = %q, want %q", gotToken, tc.wantToken)
+			}
+		})
+	}
+}
+
+func TestCallTokenEnvUnsetRequiresAPIKey(t *testing.T) {
+	t.Parallel()
+
+	c := &CLI{
+		In:     strings.NewReader(""),
+		Out:    new(bytes.Buffer),
+		Err:    new(bytes.Buffer),
+		Getenv: func(string) string { return "" },
+		ReadConfig: func() (config.File, error) {
+			return config.File{}, nil
+		},
+	}
+
+	err := c.Execute([]string{
+		"call",
+		"--gateway-url", "http://127.0.0.1:8088",
+		"--path", "/data/api/v1/gateway-info",
+		"--token-env", "CI_IGNITION_TOKEN",
+	})
+	requireUsageExitCode(t, err)
+	if !strings.Contains(err.Error(), "required: --api-key") {
+		t.Fatalf("unexpected error %v", err)
+	}
+}
+
+func TestCallGatewayURLListRoundRobinReportsServingGateway(t *testing.T) {
+	t.Parallel()
+
+	var hitsA, hitsB int
+	a := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hitsA++
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer a.Close()
+	b := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hitsB++
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer b.Close()
+
+	var out bytes.Buffer
+	c := &CLI{
+		In:     strings.NewReader(""),
+		Out:    &out,
+		Err:    new(bytes.Buffer),
+		Getenv: func(string) string { return "" },
+		ReadConfig: func() (config.File, error) {
+			return config.File{}, nil
+		},
+	}
+	if err := c.Execute([]string{
+		"call",
+		"--gateway-url", a.URL + "," + b.URL,
+		"--gateway-strategy", "round-robin",
+		"--api-key", "secret",
+		"--path", "/data/api/v1/gateway-info",
+		"--repeat", "4",
+		"--json",
+		"--select", "stats.gatewayUrl",
+		"--raw",
+	}); err != nil {
+		t.Fatalf("call failed: %v", err)
+	}
+
+	if hitsA != 2 || hitsB != 2 {
+		t.Fatalf("expected 2/2 distribution, got %d/%d", hitsA, hitsB)
+	}
+	// The fourth (last) attempt lands on the second gateway.
+	if strings.TrimSpace(out.String()) != b.URL {
+		t.Fatalf("expected stats.gatewayUrl %q, got %q", b.URL, out.String())
+	}
+}
+
+func TestCallGatewayStrategyRejectsUnknownValue(t *testing.T) {
+	t.Parallel()
+
+	c := &CLI{
+		In:     strings.NewReader(""),
+		Out:    new(bytes.Buffer),
+		Err:    new(bytes.Buffer),
+		Getenv: func(string) string { return "" },
+		ReadConfig: func() (config.File, error) {
+			return config.File{}, nil
+		},
+	}
+	err := c.Execute([]string{
+		"call",
+		"--gateway-url", "http://127.0.0.1:8088",
+		"--gateway-strategy", "random",
+		"--api-key", "secret",
+		"--path", "/data/api/v1/gateway-info",
+	})
+	requireUsageExitCode(t, err)
+}
+
+func TestCallSignKeyAddsSignatureHeaders(t *testing.T) {
+	t.Parallel()
+
+	var gotHeaders http.Header
+	client := newMockHTTPClient(func(r *http.Request) (*http.Response, error) {
+		gotHeaders = r.Header.Clone()
+		return mockHTTPResponse(http.StatusOK, `{}`, nil), nil
+	})
+	c := &CLI{
+		In:     strings.NewReader(""),
+		Out:    new(bytes.Buffer),
+		Err:    new(bytes.Buffer),
+		Getenv: func(string) string { return "" },
+		ReadConfig: func() (config.File, error) {
+			return config.File{}, nil
+		},
+		HTTPClient: client,
+	}
+	if err := c.Execute([]string{
+		"gateway", "info",
+		"--gateway-url", mockGatewayURL,
+		"--api-key", "secret",
+		"--sign-key", "shared-key",
+		"--sign-header", "X-Proxy-Signature",
+	}); err != nil {
+		t.Fatalf("gateway info failed: %v", err)
+	}
+	if gotHeaders.Get("X-Proxy-Signature") == "" || gotHeaders.Get("X-Igw-Timestamp") == "" {
+		t.Fatalf("expected signature headers, got %v", gotHeaders)
+	}
+
+	err := c.Execute([]string{
+		"call",
+		"--gateway-url", mockGatewayURL,
+		"--api-key", "secret",
+		"--path", "/data/api/v1/gateway-info",
+		"--sign-header", "X-Proxy-Signature",
+	})
+	requireUsageExitCode(t, err)
+}
+
+func TestCallRetryJitterValidation(t *testing.T) {
+	t.Parallel()
+
+	for _, args := range [][]string{
+		{"--retry-jitter", "1.5"},
+		{"--retry-jitter-seed", "7"},
+		{"--retry-jitter", "0.2", "--retry-jitter-seed", "seven"},
+	} {
+		c := &CLI{
+			In:     strings.NewReader(""),
+			Out:    new(bytes.Buffer),
+			Err:    new(bytes.Buffer),
+			Getenv: func(string) string { return "" },
+			ReadConfig: func() (config.File, error) {
+				return config.File{}, nil
+			},
+		}
+		err := c.Execute(append([]string{
+			"call",
+			"--gateway-url", "http://127.0.0.1:8088",
+			"--api-key", "secret",
+			"--path", "/data/api/v1/gateway-info",
+			"--retry", "2",
+		}, args...))
+		requireUsageExitCode(t, err)
+	}
+}
+
+func TestCallHedgeAfterValidation(t *testing.T) {
+	t.Parallel()
+
+	for _, args := range [][]string{
+		{"--path", "/data/api/v1/gateway-info", "--hedge-after", "-1s"},
+		{"--path", "/data/api/v1/gateway-info", "--hedge-after", "50ms", "--sse"},
+		{"--method", "POST", "--path", "/data/api/v1/scan/projects", "--yes", "--hedge-after", "50ms"},
+	} {
+		c := &CLI{
+			In:     strings.NewReader(""),
+			Out:    new(bytes.Buffer),
+			Err:    new(bytes.Buffer),
+			Getenv: func(string) string { return "" },
+			ReadConfig: func() (config.File, error) {
+				return config.File{}, nil
+			},
+		}
+		err := c.Execute(append([]string{
+			"call",
+			"--gateway-url", "http://127.0.0.1:8088",
+			"--api-key", "secret",
+		}, args...))
+		requireUsageExitCode(t, err)
+	}
+}
+
+func TestCallHedgeAfterReportsStats(t *testing.T) {
+	t.Parallel()
+
+	var out bytes.Buffer
+	c := &CLI{
+		In:     strings.NewReader(""),
+		Out:    &out,
+		Err:    new(bytes.Buffer),
+		Getenv: func(string) string { return "" },
+		ReadConfig: func() (config.File, error) {
+			return config.File{}, nil
+		},
+		HTTPClient: newMockHTTPClient(func(r *http.Request) (*http.Response, error) {
+			return mockHTTPResponse(http.StatusOK, `{"ok":true}`, nil), nil
+		}),
+	}
+	err := c.Execute([]string{
+		"call",
+		"--gateway-url", mockGatewayURL,
+		"--api-key", "secret",
+		"--path", "/data/api/v1/gateway-info",
+		"--hedge-after", "1m",
+		"--json", "--json-stats",
+	})
+	if err != nil {
+		t.Fatalf("call failed: %v", err)
+	}
+
+	var payload struct {
+		Stats struct {
+			Hedge *callHedgeStats `json:"hedge"`
+		} `json:"stats"`
+	}
+	if err := json.Unmarshal(out.Bytes(), &payload); err != nil {
+		t.Fatalf("decode output: %v", err)
+	}
+	if payload.Stats.Hedge == nil || payload.Stats.Hedge.Triggered || payload.Stats.Hedge.Winner != "primary" {
+		t.Fatalf("unexpected hedge stats: %+v", payload.Stats.Hedge)
+	}
+}
+
+func TestCallRawPathSendsEncodedSegmentUnaltered(t *testing.T) {
+	t.Parallel()
+
+	var gotURI string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotURI = r.RequestURI
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"ok":true}`))
+	}))
+	defer srv.Close()
+
+	c := &CLI{
+		In:     strings.NewReader(""),
+		Out:    new(bytes.Buffer),
+		Err:    new(bytes.Buffer),
+		Getenv: func(string) string { return "" },
+		ReadConfig: func() (config.File, error) {
+			return config.File{}, nil
+		},
+		HTTPClient: srv.Client(),
+	}
+	err := c.Execute([]string{
+		"call",
+		"--gateway-url", srv.URL,
+		"--api-key", "secret",
+		"--path", "/data/api/v1/tags/default/Folder%2FTag",
+		"--raw-path",
+	})
+	if err != nil {
+		t.Fatalf("call failed: %v", err)
+	}
+	if gotURI != "/data/api/v1/tags/default/Folder%2FTag" {
+		t.Fatalf("encoded segment was altered: %q", gotURI)
+	}
+}
+
+func TestCallRetryOnReportsRetryStats(t *testing.T) {
+	t.Parallel()
+
+	calls := 0
+	var out bytes.Buffer
+	c := &CLI{
+		In:     strings.NewReader(""),
+		Out:    &out,
+		Err:    new(bytes.Buffer),
+		Getenv: func(string) string { return "" },
+		ReadConfig: func() (config.File, error) {
+			return config.File{}, nil
+		},
+		HTTPClient: newMockHTTPClient(func(*http.Request) (*http.Response, error) {
+			calls++
+			if calls == 1 {
+				return mockHTTPResponse(http.StatusConflict, `{"busy":true}`, nil), nil
+			}
+			return mockHTTPResponse(http.StatusOK, `{"ok":true}`, nil), nil
+		}),
+	}
+	base := []string{"call", "--gateway-url", mockGatewayURL, "--api-key", "secret", "--path", "/data/api/v1/gateway-info", "--retry", "2", "--retry-backoff", "1ms"}
+
+	requireUsageExitCode(t, c.Execute(append(base, "--retry-on", "4x")))
+	requireUsageExitCode(t, c.Execute(append(base, "--retry-max-wait", "-1s")))
+	requireUsageExitCode(t, c.Execute(append(base, "--retry-max-wait", "1s", "--retry-jitter", "0.5")))
+	requireUsageExitCode(t, c.Execute(append(base, "--retry-max-wait", "1s", "--retry-after-max", "30s")))
+
+	if err := c.Execute(append(base, "--retry-on", "409,5xx", "--retry-max-wait", "5ms", "--json", "--json-stats")); err != nil {
+		t.Fatalf("call: %v", err)
+	}
+	var payload struct {
+		Stats struct {
+			Attempts int   `json:"attempts"`
+			Retries  int   `json:"retries"`
+			Statuses []int `json:"statuses"`
+		} `json:"stats"`
+	}
+	if err := json.Unmarshal(out.Bytes(), &payload); err != nil {
+		t.Fatalf("parse: %v\n%s", err, out.String())
+	}
+	if payload.Stats.Attempts != 2 || payload.Stats.Retries != 1 || len(payload.Stats.Statuses) != 2 || payload.Stats.Statuses[0] != http.StatusConflict {
+		t.Fatalf("unexpected stats %s", out.String())
+	}
+}
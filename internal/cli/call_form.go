@@ -0,0 +1,161 @@
+package cli
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/alex-mccollum/igw-cli/internal/igwerr"
+)
+
+// multipartForm is the multipart/form-data body built from --form and
+// --form-file. File parts are streamed from disk on every attempt, so
+// large uploads are never held in memory.
+type multipartForm struct {
+	boundary string
+	parts    []formPart
+}
+
+type formPart struct {
+	name  string
+	value string
+	// path is set for --form-file parts.
+	path string
+}
+
+// parseCallForm builds the form from --form field=value and
+// --form-file field=@path flags, in flag order with fields first. It
+// returns nil when neither flag was given.
+func parseCallForm(fields, files []string) (*multipartForm, error) {
+	if len(fields) == 0 && len(files) == 0 {
+		return nil, nil
+	}
+
+	form := &multipartForm{boundary: multipart.NewWriter(io.Discard).Boundary()}
+	for _, raw := range fields {
+		name, value, ok := strings.Cut(raw, "=")
+		if !ok || strings.TrimSpace(name) == "" {
+			return nil, &igwerr.UsageError{Msg: fmt.Sprintf("invalid --form %q (expected field=value)", raw)}
+		}
+		form.parts = append(form.parts, formPart{name: strings.TrimSpace(name), value: value})
+	}
+	for _, raw := range files {
+		name, path, ok := strings.Cut(raw, "=")
+		path = strings.TrimPrefix(path, "@")
+		if !ok || strings.TrimSpace(name) == "" || path == "" {
+			return nil, &igwerr.UsageError{Msg: fmt.Sprintf("invalid --form-file %q (expected field=@path)", raw)}
+		}
+		info, err := os.Stat(path)
+		if err != nil {
+			return nil, &igwerr.UsageError{Msg: fmt.Sprintf("--form-file %s: %v", name, err)}
+		}
+		if info.IsDir() {
+			return nil, &igwerr.UsageError{Msg: fmt.Sprintf("--form-file %s: %s is a directory", name, path)}
+		}
+		form.parts = append(form.parts, formPart{name: strings.TrimSpace(name), path: path})
+	}
+	return form, nil
+}
+
+func (f *multipartForm) contentType() string {
+	if f == nil {
+		return ""
+	}
+	return "multipart/form-data; boundary=" + f.boundary
+}
+
+// fieldNames lists the part names (never values) for the JSON envelope.
+func (f *multipartForm) fieldNames() []string {
+	if f == nil {
+		return nil
+	}
+	names := make([]string, 0, len(f.parts))
+	for _, part := range f.parts {
+		names = append(names, part.name)
+	}
+	return names
+}
+
+// opener adapts f to gateway.CallRequest.OpenBody; nil when there is no form.
+func (f *multipartForm) opener() func() (io.ReadCloser, int64, error) {
+	if f == nil {
+		return nil
+	}
+	return f.open
+}
+
+// open returns the encoded body and its exact length. Part headers and the
+// closing boundary are rendered up front; file contents are read through
+// when the request is sent.
+func (f *multipartForm) open() (io.ReadCloser, int64, error) {
+	var (
+		encoded bytes.Buffer
+		readers []io.Reader
+		files   multiCloser
+		size    int64
+	)
+	writer := multipart.NewWriter(&encoded)
+	if err := writer.SetBoundary(f.boundary); err != nil {
+		return nil, 0, err
+	}
+	flush := func() {
+		size += int64(encoded.Len())
+		readers = append(readers, bytes.NewReader(bytes.Clone(encoded.Bytes())))
+		encoded.Reset()
+	}
+
+	for _, part := range f.parts {
+		if part.path == "" {
+			if err := writer.WriteField(part.name, part.value); err != nil {
+				files.Close()
+				return nil, 0, err
+			}
+			continue
+		}
+		file, err := os.Open(part.path)
+		if err != nil {
+			files.Close()
+			return nil, 0, err
+		}
+		files = append(files, file)
+		info, err := file.Stat()
+		if err != nil {
+			files.Close()
+			return nil, 0, err
+		}
+		if _, err := writer.CreateFormFile(part.name, filepath.Base(part.path)); err != nil {
+			files.Close()
+			return nil, 0, err
+		}
+		flush()
+		readers = append(readers, io.LimitReader(file, info.Size()))
+		size += info.Size()
+	}
+	if err := writer.Close(); err != nil {
+		files.Close()
+		return nil, 0, err
+	}
+	flush()
+
+	return struct {
+		io.Reader
+		io.Closer
+	}{io.MultiReader(readers...), files}, size, nil
+}
+
+// multiCloser closes every file opened for one form body.
+type multiCloser []*os.File
+
+func (m multiCloser) Close() error {
+	var first error
+	for _, file := range m {
+		if err := file.Close(); err != nil && first == nil {
+			first = err
+		}
+	}
+	return first
+}
@@ -0,0 +1,197 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/alex-mccollum/igw-cli/internal/config"
+)
+
+type receivedForm struct {
+	contentLength int64
+	bodyLength    int
+	fields        map[string]string
+	files         map[string]string
+	fileNames     map[string]string
+}
+
+func readMultipartRequest(t *testing.T, r *http.Request) receivedForm {
+	t.Helper()
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		t.Errorf("read body: %v", err)
+		return receivedForm{}
+	}
+	mediaType, params, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if err != nil || mediaType != "multipart/form-data" {
+		t.Errorf("unexpected content type %q", r.Header.Get("Content-Type"))
+		return receivedForm{}
+	}
+	got := receivedForm{
+		contentLength: r.ContentLength,
+		bodyLength:    len(body),
+		fields:        map[string]string{},
+		files:         map[string]string{},
+		fileNames:     map[string]string{},
+	}
+	reader := multipart.NewReader(bytes.NewReader(body), params["boundary"])
+	for {
+		part, err := reader.NextPart()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Errorf("next part: %v", err)
+			break
+		}
+		data, _ := io.ReadAll(part)
+		if part.FileName() != "" {
+			got.files[part.FormName()] = string(data)
+			got.fileNames[part.FormName()] = part.FileName()
+		} else {
+			got.fields[part.FormName()] = string(data)
+		}
+	}
+	return got
+}
+
+func TestCallFormUploadsMultipart(t *testing.T) {
+	t.Parallel()
+
+	modulePath := filepath.Join(t.TempDir(), "module.modl")
+	if err := os.WriteFile(modulePath, []byte("module-bytes\x00\x01"), 0o600); err != nil {
+		t.Fatalf("write module: %v", err)
+	}
+
+	var got receivedForm
+	var out bytes.Buffer
+	c := &CLI{
+		In:     strings.NewReader(""),
+		Out:    &out,
+		Err:    new(bytes.Buffer),
+		Getenv: func(string) string { return "" },
+		ReadConfig: func() (config.File, error) {
+			return config.File{}, nil
+		},
+		HTTPClient: newMockHTTPClient(func(r *http.Request) (*http.Response, error) {
+			got = readMultipartRequest(t, r)
+			return mockHTTPResponse(http.StatusOK, `{"ok":true}`, nil), nil
+		}),
+	}
+	err := c.Execute([]string{
+		"call",
+		"--gateway-url", mockGatewayURL,
+		"--api-key", "secret",
+		"--method", "POST",
+		"--path", "/data/api/v1/modules/install",
+		"--form", "overwrite=true",
+		"--form-file", "file=@" + modulePath,
+		"--yes",
+		"--json",
+	})
+	if err != nil {
+		t.Fatalf("call failed: %v", err)
+	}
+
+	if got.fields["overwrite"] != "true" || got.files["file"] != "module-bytes\x00\x01" || got.fileNames["file"] != "module.modl" {
+		t.Fatalf("unexpected form: %+v", got)
+	}
+	if got.contentLength != int64(got.bodyLength) {
+		t.Fatalf("content length %d does not match body length %d", got.contentLength, got.bodyLength)
+	}
+
+	var payload struct {
+		Request callJSONRequest `json:"request"`
+	}
+	if err := json.Unmarshal(out.Bytes(), &payload); err != nil {
+		t.Fatalf("decode envelope: %v", err)
+	}
+	if !strings.HasPrefix(payload.Request.ContentType, "multipart/form-data; boundary=") {
+		t.Fatalf("unexpected contentType %q", payload.Request.ContentType)
+	}
+	if strings.Join(payload.Request.FormFields, ",") != "overwrite,file" {
+		t.Fatalf("unexpected formFields %v", payload.Request.FormFields)
+	}
+	if strings.Contains(out.String(), "module-bytes") {
+		t.Fatalf("envelope echoed form values: %s", out.String())
+	}
+}
+
+func TestCallFormReopensFilesOnRetry(t *testing.T) {
+	t.Parallel()
+
+	projectPath := filepath.Join(t.TempDir(), "project.zip")
+	if err := os.WriteFile(projectPath, []byte("zip"), 0o600); err != nil {
+		t.Fatalf("write project: %v", err)
+	}
+
+	var calls atomic.Int32
+	var last receivedForm
+	c := &CLI{
+		In:     strings.NewReader(""),
+		Out:    new(bytes.Buffer),
+		Err:    new(bytes.Buffer),
+		Getenv: func(string) string { return "" },
+		ReadConfig: func() (config.File, error) {
+			return config.File{}, nil
+		},
+		HTTPClient: newMockHTTPClient(func(r *http.Request) (*http.Response, error) {
+			last = readMultipartRequest(t, r)
+			if calls.Add(1) == 1 {
+				return mockHTTPResponse(http.StatusServiceUnavailable, "busy", nil), nil
+			}
+			return mockHTTPResponse(http.StatusOK, `{}`, nil), nil
+		}),
+	}
+	err := c.Execute([]string{
+		"call",
+		"--gateway-url", mockGatewayURL,
+		"--api-key", "secret",
+		"--method", "PUT",
+		"--path", "/data/api/v1/projects/import",
+		"--form-file", "file=@" + projectPath,
+		"--retry", "1",
+		"--retry-backoff", "1ms",
+		"--yes",
+	})
+	if err != nil {
+		t.Fatalf("call failed: %v", err)
+	}
+	if calls.Load() != 2 || last.files["file"] != "zip" {
+		t.Fatalf("expected a full body on the retry, got %d calls and %+v", calls.Load(), last)
+	}
+}
+
+func TestCallFormValidation(t *testing.T) {
+	t.Parallel()
+
+	c := &CLI{
+		In:     strings.NewReader(""),
+		Out:    new(bytes.Buffer),
+		Err:    new(bytes.Buffer),
+		Getenv: func(string) string { return "" },
+		ReadConfig: func() (config.File, error) {
+			return config.File{}, nil
+		},
+	}
+	base := []string{"call", "--gateway-url", mockGatewayURL, "--api-key", "secret", "--method", "POST", "--yes"}
+	for _, args := range [][]string{
+		{"--path", "/data/api/v1/x", "--form", "a=1", "--body", "{}"},
+		{"--path", "/data/api/v1/x", "--form", "novalue"},
+		{"--path", "/data/api/v1/x", "--form-file", "file=@" + filepath.Join(t.TempDir(), "missing")},
+		{"--path", "/data/api/v1/x", "--form", "a=1", "--content-type", "text/plain"},
+		{"--batch", "-", "--form", "a=1"},
+	} {
+		requireUsageExitCode(t, c.Execute(append(append([]string(nil), base...), args...)))
+	}
+}
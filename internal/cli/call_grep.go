@@ -0,0 +1,111 @@
+package cli
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+
+	"github.com/alex-mccollum/igw-cli/internal/exitcode"
+	"github.com/alex-mccollum/igw-cli/internal/igwerr"
+)
+
+// callGrepOptions filters buffered text response bodies line by line.
+type callGrepOptions struct {
+	pattern string
+	regex   bool
+	invert  bool
+	count   bool
+	exit    bool
+
+	compiled *regexp.Regexp
+}
+
+func (o *callGrepOptions) enabled() bool {
+	return o.pattern != ""
+}
+
+// validate runs before the JSON selection flags are checked, so --grep with
+// --select or --expr reports the real conflict instead of asking for --json.
+func (o *callGrepOptions) validate(common wrapperCommon, stream bool, outPath string) error {
+	if !o.enabled() {
+		if o.regex || o.invert || o.count || o.exit {
+			return &igwerr.UsageError{Msg: "--grep-regex, --grep-invert, --grep-count, and --grep-exit require --grep"}
+		}
+		return nil
+	}
+	if common.jsonOutput || len(common.selectors) > 0 || strings.TrimSpace(common.expr) != "" || common.rawOutput {
+		return &igwerr.UsageError{Msg: "--grep filters text lines and is not supported with --json, --select, --expr, or --raw"}
+	}
+	if stream {
+		return &igwerr.UsageError{Msg: "--grep is not supported with --stream"}
+	}
+	if strings.TrimSpace(outPath) != "" {
+		return &igwerr.UsageError{Msg: "--grep is not supported with --out"}
+	}
+	if o.regex {
+		compiled, err := regexp.Compile(o.pattern)
+		if err != nil {
+			return &igwerr.UsageError{Msg: fmt.Sprintf("invalid --grep pattern: %v", err)}
+		}
+		o.compiled = compiled
+	}
+	return nil
+}
+
+func (o *callGrepOptions) matches(line string) bool {
+	var hit bool
+	if o.compiled != nil {
+		hit = o.compiled.MatchString(line)
+	} else {
+		hit = strings.Contains(line, o.pattern)
+	}
+	return hit != o.invert
+}
+
+// write prints matching lines (or their count) and returns an error when
+// --grep-exit is set and nothing matched.
+func (o *callGrepOptions) write(w io.Writer, body []byte) error {
+	scanner := bufio.NewScanner(bytes.NewReader(body))
+	scanner.Buffer(make([]byte, 0, 64*1024), len(body)+1)
+
+	matched := 0
+	for scanner.Scan() {
+		line := strings.TrimSuffix(scanner.Text(), "\r")
+		if !o.matches(line) {
+			continue
+		}
+		matched++
+		if !o.count {
+			if _, err := fmt.Fprintln(w, line); err != nil {
+				return igwerr.NewTransportError(err)
+			}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return igwerr.NewTransportError(err)
+	}
+	if o.count {
+		if _, err := fmt.Fprintln(w, matched); err != nil {
+			return igwerr.NewTransportError(err)
+		}
+	}
+	if matched == 0 && o.exit {
+		return &grepNoMatchError{}
+	}
+	return nil
+}
+
+// grepNoMatchError mirrors grep's "no lines selected" status using the
+// CLI's stable non-usage failure code.
+type grepNoMatchError struct{}
+
+func (e *grepNoMatchError) Error() string {
+	return "no lines matched --grep"
+}
+
+func (e *grepNoMatchError) ExitCode() int {
+	return exitcode.Network
+}
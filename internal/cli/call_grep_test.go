@@ -0,0 +1,107 @@
+package cli
+
+import (
+	"bytes"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/alex-mccollum/igw-cli/internal/config"
+	"github.com/alex-mccollum/igw-cli/internal/igwerr"
+)
+
+const grepTestBody = "INFO gateway started\nWARN disk low\nINFO module loaded\n"
+
+func runGrepCall(t *testing.T, extra ...string) (string, error) {
+	t.Helper()
+
+	var out bytes.Buffer
+	c := &CLI{
+		In:     strings.NewReader(""),
+		Out:    &out,
+		Err:    new(bytes.Buffer),
+		Getenv: func(string) string { return "" },
+		ReadConfig: func() (config.File, error) {
+			return config.File{}, nil
+		},
+		HTTPClient: newMockHTTPClient(func(*http.Request) (*http.Response, error) {
+			return mockHTTPResponse(http.StatusOK, grepTestBody, nil), nil
+		}),
+	}
+
+	args := append([]string{
+		"call",
+		"--gateway-url", mockGatewayURL,
+		"--api-key", "secret",
+		"--path", "/data/api/v1/logs",
+	}, extra...)
+	err := c.Execute(args)
+	return out.String(), err
+}
+
+func TestCallGrepSubstringMatch(t *testing.T) {
+	t.Parallel()
+
+	out, err := runGrepCall(t, "--grep", "INFO")
+	if err != nil {
+		t.Fatalf("call failed: %v", err)
+	}
+	if out != "INFO gateway started\nINFO module loaded\n" {
+		t.Fatalf("unexpected output %q", out)
+	}
+}
+
+func TestCallGrepInvertAndCount(t *testing.T) {
+	t.Parallel()
+
+	out, err := runGrepCall(t, "--grep", "INFO", "--grep-invert")
+	if err != nil {
+		t.Fatalf("call failed: %v", err)
+	}
+	if out != "WARN disk low\n" {
+		t.Fatalf("unexpected inverted output %q", out)
+	}
+
+	out, err = runGrepCall(t, "--grep", `^INFO .*ed$`, "--grep-regex", "--grep-count")
+	if err != nil {
+		t.Fatalf("call failed: %v", err)
+	}
+	if out != "2\n" {
+		t.Fatalf("unexpected count output %q", out)
+	}
+}
+
+func TestCallGrepExitWhenNoLinesMatch(t *testing.T) {
+	t.Parallel()
+
+	out, err := runGrepCall(t, "--grep", "ERROR")
+	if err != nil {
+		t.Fatalf("expected success without --grep-exit, got %v", err)
+	}
+	if out != "" {
+		t.Fatalf("expected empty output, got %q", out)
+	}
+
+	_, err = runGrepCall(t, "--grep", "ERROR", "--grep-exit")
+	if code := igwerr.ExitCode(err); code != 7 {
+		t.Fatalf("expected exit code 7, got %d (%v)", code, err)
+	}
+}
+
+func TestCallGrepRejectsJSONSelection(t *testing.T) {
+	t.Parallel()
+
+	for _, extra := range [][]string{
+		{"--json"},
+		{"--json", "--select", "response.body"},
+		{"--select", "response.body"},
+		{"--expr", "response.status"},
+		{"--json", "--raw", "--select", "response.body"},
+	} {
+		_, err := runGrepCall(t, append([]string{"--grep", "INFO"}, extra...)...)
+		requireUsageExitCode(t, err)
+		if !strings.Contains(err.Error(), "--grep filters text lines") {
+			t.Fatalf("expected the --grep conflict for %v, got %v", extra, err)
+		}
+	}
+}
@@ -0,0 +1,25 @@
+package cli
+
+// callModes records which call modes a run uses, so option groups such as
+// fan-out and the response cache can check their own combinations outside
+// runCall.
+type callModes struct {
+	batch            bool
+	curl             bool
+	repeat           bool
+	paginate         bool
+	expand           bool
+	applyPatch       bool
+	stream           bool
+	sse              bool
+	grep             bool
+	prettyXML        bool
+	validateResponse bool
+	selection        bool
+	out              bool
+	dumpRaw          bool
+	attemptsOut      bool
+	progress         bool
+	output           bool
+	fanout           bool
+}
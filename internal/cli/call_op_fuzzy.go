@@ -0,0 +1,111 @@
+package cli
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/alex-mccollum/igw-cli/internal/apidocs"
+)
+
+const (
+	// opSuggestionLimit caps the did-you-mean list on an unknown --op.
+	opSuggestionLimit = 3
+	// opSuggestionMinSimilarity filters out suggestions that share little
+	// with what was typed.
+	opSuggestionMinSimilarity = 0.5
+	// opFuzzyMinSimilarity is how close the nearest operationId must be
+	// for --op-fuzzy to run it.
+	opFuzzyMinSimilarity = 0.75
+)
+
+type operationIDCandidate struct {
+	id         string
+	similarity float64
+}
+
+// rankOperationIDs scores every distinct operationId against op by
+// case-insensitive edit distance, best first. A namespace qualifier
+// ("namespace:operationId") is ignored for scoring.
+func rankOperationIDs(ops []apidocs.Operation, op string) []operationIDCandidate {
+	op = strings.TrimSpace(op)
+	if _, id, ok := strings.Cut(op, ":"); ok && strings.TrimSpace(id) != "" {
+		op = strings.TrimSpace(id)
+	}
+	target := strings.ToLower(op)
+
+	seen := make(map[string]bool, len(ops))
+	ranked := make([]operationIDCandidate, 0, len(ops))
+	for _, candidate := range ops {
+		id := strings.TrimSpace(candidate.OperationID)
+		if id == "" || seen[id] {
+			continue
+		}
+		seen[id] = true
+		ranked = append(ranked, operationIDCandidate{id: id, similarity: similarity(target, strings.ToLower(id))})
+	}
+	sort.SliceStable(ranked, func(i, j int) bool {
+		if ranked[i].similarity != ranked[j].similarity {
+			return ranked[i].similarity > ranked[j].similarity
+		}
+		return ranked[i].id < ranked[j].id
+	})
+	return ranked
+}
+
+// suggestOperationIDs returns up to opSuggestionLimit operationIds close to op.
+func suggestOperationIDs(ops []apidocs.Operation, op string) []string {
+	out := make([]string, 0, opSuggestionLimit)
+	for _, candidate := range rankOperationIDs(ops, op) {
+		if len(out) == opSuggestionLimit || candidate.similarity < opSuggestionMinSimilarity {
+			break
+		}
+		out = append(out, candidate.id)
+	}
+	return out
+}
+
+// nearestOperationID returns the closest operationId for --op-fuzzy when it
+// is similar enough and strictly closer than the runner-up.
+func nearestOperationID(ops []apidocs.Operation, op string) (string, bool) {
+	ranked := rankOperationIDs(ops, op)
+	if len(ranked) == 0 || ranked[0].similarity < opFuzzyMinSimilarity || ranked[0].similarity == 1 {
+		return "", false
+	}
+	if len(ranked) > 1 && ranked[1].similarity == ranked[0].similarity {
+		return "", false
+	}
+	return ranked[0].id, true
+}
+
+// similarity is 1 minus the edit distance normalized by the longer length.
+func similarity(a string, b string) float64 {
+	longest := len([]rune(a))
+	if n := len([]rune(b)); n > longest {
+		longest = n
+	}
+	if longest == 0 {
+		return 1
+	}
+	return 1 - float64(levenshtein(a, b))/float64(longest)
+}
+
+func levenshtein(a string, b string) int {
+	ar, br := []rune(a), []rune(b)
+	prev := make([]int, len(br)+1)
+	curr := make([]int, len(br)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(ar); i++ {
+		curr[0] = i
+		for j := 1; j <= len(br); j++ {
+			cost := 1
+			if ar[i-1] == br[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(br)]
+}
@@ -0,0 +1,99 @@
+package cli
+
+import (
+	"bytes"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/alex-mccollum/igw-cli/internal/apidocs"
+	"github.com/alex-mccollum/igw-cli/internal/config"
+)
+
+func TestResolveOperationSuggestsClosestIDs(t *testing.T) {
+	t.Parallel()
+
+	ops := []apidocs.Operation{
+		{Method: http.MethodGet, Path: "/data/api/v1/projects", OperationID: "listProjects"},
+		{Method: http.MethodPost, Path: "/data/api/v1/projects", OperationID: "createProject"},
+		{Method: http.MethodGet, Path: "/data/api/v1/gateway-info", OperationID: "gatewayInfo"},
+		{Method: http.MethodGet, Path: "/data/api/v1/modules", OperationID: "listModules"},
+	}
+
+	_, err := resolveOperationWithHints(ops, "listProjcts", "", "openapi.json")
+	requireUsageExitCode(t, err)
+	if !strings.Contains(err.Error(), "did you mean: listProjects?") {
+		t.Fatalf("expected did-you-mean suggestions, got %v", err)
+	}
+
+	if got := suggestOperationIDs(ops, "zzzz"); len(got) != 0 {
+		t.Fatalf("expected no suggestions for an unrelated id, got %v", got)
+	}
+	if got := suggestOperationIDs(ops, "v1:gatewayInf"); !reflect.DeepEqual(got, []string{"gatewayInfo"}) {
+		t.Fatalf("expected namespace-qualified suggestion, got %v", got)
+	}
+}
+
+func TestNearestOperationIDRequiresClearWinner(t *testing.T) {
+	t.Parallel()
+
+	ops := []apidocs.Operation{
+		{OperationID: "listProjects"},
+		{OperationID: "listProject"},
+		{OperationID: "gatewayInfo"},
+	}
+	if _, ok := nearestOperationID(ops, "listProjectz"); ok {
+		t.Fatalf("expected tie between listProjects and listProject to stay unresolved")
+	}
+	if got, ok := nearestOperationID(ops, "gatewayInf"); !ok || got != "gatewayInfo" {
+		t.Fatalf("expected gatewayInfo, got %q %v", got, ok)
+	}
+	if _, ok := nearestOperationID(ops, "gateway"); ok {
+		t.Fatalf("expected low-similarity input to stay unresolved")
+	}
+}
+
+func TestCallOpFuzzyRunsNearestMatch(t *testing.T) {
+	t.Parallel()
+
+	specPath := writeCallOpSpec(t, callOpSpecFixture)
+	var gotPath string
+	var stderr bytes.Buffer
+	c := &CLI{
+		In:     strings.NewReader(""),
+		Out:    new(bytes.Buffer),
+		Err:    &stderr,
+		Getenv: func(string) string { return "" },
+		ReadConfig: func() (config.File, error) {
+			return config.File{}, nil
+		},
+		HTTPClient: newMockHTTPClient(func(r *http.Request) (*http.Response, error) {
+			gotPath = r.URL.Path
+			return mockHTTPResponse(http.StatusOK, `{}`, nil), nil
+		}),
+	}
+
+	args := []string{
+		"call",
+		"--gateway-url", mockGatewayURL,
+		"--api-key", "secret",
+		"--op", "gatewayInfoo",
+		"--spec-file", specPath,
+	}
+	err := c.Execute(args)
+	requireUsageExitCode(t, err)
+	if !strings.Contains(err.Error(), "did you mean: gatewayInfo?") {
+		t.Fatalf("expected suggestion without --op-fuzzy, got %v", err)
+	}
+
+	if err := c.Execute(append(args, "--op-fuzzy")); err != nil {
+		t.Fatalf("fuzzy call failed: %v", err)
+	}
+	if gotPath != "/data/api/v1/gateway-info" {
+		t.Fatalf("expected gateway-info call, got %q", gotPath)
+	}
+	if !strings.Contains(stderr.String(), `--op-fuzzy resolved "gatewayInfoo" to "gatewayInfo"`) {
+		t.Fatalf("expected fuzzy note on stderr, got %q", stderr.String())
+	}
+}
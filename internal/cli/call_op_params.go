@@ -0,0 +1,143 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/alex-mccollum/igw-cli/internal/apidocs"
+	"github.com/alex-mccollum/igw-cli/internal/igwerr"
+)
+
+var pathTemplateParam = regexp.MustCompile(`\{([^{}/]+)\}`)
+
+// applyOperationParams places each --param name=value by the location the
+// spec declares for it: path values are escaped into the path template,
+// query values become query parameters, and header values become request
+// headers. It fails when a required parameter is still missing.
+func applyOperationParams(op apidocs.Operation, params []string, queries []string, headers []string) (string, []string, []string, error) {
+	declared := make(map[string]apidocs.Parameter, len(op.Parameters))
+	for _, param := range op.Parameters {
+		// A name declared in several locations resolves to the path one.
+		if existing, ok := declared[param.Name]; ok && existing.In == "path" {
+			continue
+		}
+		declared[param.Name] = param
+	}
+
+	pathValues := make(map[string]string)
+	queries = append([]string(nil), queries...)
+	headers = append([]string(nil), headers...)
+	for _, raw := range params {
+		name, value, ok := strings.Cut(raw, "=")
+		name = strings.TrimSpace(name)
+		if !ok || name == "" {
+			return "", nil, nil, &igwerr.UsageError{Msg: fmt.Sprintf("invalid --param %q (want name=value)", raw)}
+		}
+		param, ok := declared[name]
+		if !ok {
+			return "", nil, nil, &igwerr.UsageError{Msg: fmt.Sprintf(
+				"operationId %q has no parameter %q (declared: %s)", op.OperationID, name, declaredParamList(op.Parameters),
+			)}
+		}
+		switch param.In {
+		case "path":
+			pathValues[name] = value
+		case "query":
+			queries = append(queries, name+"="+value)
+		case "header":
+			headers = append(headers, name+": "+value)
+		default:
+			return "", nil, nil, &igwerr.UsageError{Msg: fmt.Sprintf("--param %q: %s parameters are not supported", name, param.In)}
+		}
+	}
+
+	var missing []string
+	path := pathTemplateParam.ReplaceAllStringFunc(op.Path, func(token string) string {
+		name := token[1 : len(token)-1]
+		if value, ok := pathValues[name]; ok {
+			return url.PathEscape(value)
+		}
+		missing = append(missing, name+" (path)")
+		return token
+	})
+	for _, param := range op.Parameters {
+		if !param.Required {
+			continue
+		}
+		switch {
+		case param.In == "query" && !hasQueryParam(queries, param.Name):
+			missing = append(missing, param.Name+" (query)")
+		case param.In == "header" && !hasHeader(headers, param.Name):
+			missing = append(missing, param.Name+" (header)")
+		}
+	}
+	if len(missing) > 0 {
+		return "", nil, nil, &igwerr.UsageError{Msg: fmt.Sprintf(
+			"operationId %q is missing required parameters: %s; pass --param name=value", op.OperationID, strings.Join(missing, ", "),
+		)}
+	}
+	return path, queries, headers, nil
+}
+
+// checkOperationBody fails when the spec requires a request body and none
+// is sent, and warns about a JSON body that does not match the request
+// body schema.
+func checkOperationBody(w io.Writer, op apidocs.Operation, body []byte, form bool) error {
+	if form {
+		return nil
+	}
+	if len(body) == 0 {
+		if op.RequestBodyRequired {
+			return &igwerr.UsageError{Msg: fmt.Sprintf("operationId %q requires a request body (--body or --use-example-body)", op.OperationID)}
+		}
+		return nil
+	}
+	if len(op.RequestBodySchema) == 0 {
+		return nil
+	}
+	violations, err := apidocs.ValidateJSON(op.RequestBodySchema, body)
+	if err != nil {
+		// Not JSON; the schema says nothing about other media types.
+		return nil
+	}
+	for _, violation := range violations {
+		fmt.Fprintf(w, "warning: request body schema: %s: %s\n", violation.Path, violation.Message)
+	}
+	return nil
+}
+
+func declaredParamList(params []apidocs.Parameter) string {
+	if len(params) == 0 {
+		return "none"
+	}
+	names := make([]string, 0, len(params))
+	for _, param := range params {
+		names = append(names, param.Name+" ("+param.In+")")
+	}
+	sort.Strings(names)
+	return strings.Join(names, ", ")
+}
+
+func hasQueryParam(queries []string, name string) bool {
+	for _, query := range queries {
+		key, _, _ := strings.Cut(query, "=")
+		if strings.TrimSpace(key) == name {
+			return true
+		}
+	}
+	return false
+}
+
+func hasHeader(headers []string, name string) bool {
+	for _, header := range headers {
+		key, _, _ := strings.Cut(header, ":")
+		if strings.EqualFold(strings.TrimSpace(key), name) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,90 @@
+package cli
+
+import (
+	"bytes"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/alex-mccollum/igw-cli/internal/config"
+)
+
+const opParamsSpec = `{
+  "openapi": "3.0.0",
+  "paths": {
+    "/data/api/v1/projects/{projectName}": {
+      "parameters": [{"name": "projectName", "in": "path", "required": true}],
+      "get": {
+        "operationId": "getProject",
+        "parameters": [
+          {"name": "include", "in": "query", "required": true},
+          {"name": "X-Trace", "in": "header"}
+        ]
+      },
+      "put": {
+        "operationId": "updateProject",
+        "requestBody": {
+          "required": true,
+          "content": {"application/json": {"schema": {"type": "object", "required": ["enabled"]}}}
+        }
+      }
+    }
+  }
+}`
+
+func TestCallOpParamsSubstituteAndValidate(t *testing.T) {
+	t.Parallel()
+
+	specPath := writeCallOpSpec(t, opParamsSpec)
+	var got *http.Request
+	errOut := new(bytes.Buffer)
+	c := &CLI{
+		In:     strings.NewReader(""),
+		Out:    new(bytes.Buffer),
+		Err:    errOut,
+		Getenv: func(string) string { return "" },
+		ReadConfig: func() (config.File, error) {
+			return config.File{}, nil
+		},
+		HTTPClient: newMockHTTPClient(func(r *http.Request) (*http.Response, error) {
+			got = r
+			return mockHTTPResponse(http.StatusOK, `{}`, nil), nil
+		}),
+	}
+	base := []string{"call", "--gateway-url", mockGatewayURL, "--api-key", "secret", "--spec-file", specPath}
+
+	err := c.Execute(append(base, "--op", "getProject"))
+	requireUsageExitCode(t, err)
+	if !strings.Contains(err.Error(), "missing required parameters: projectName (path), include (query)") {
+		t.Fatalf("unexpected error %v", err)
+	}
+	requireUsageExitCode(t, c.Execute(append(base, "--op", "getProject", "--param", "nope=1")))
+	requireUsageExitCode(t, c.Execute(append(base, "--path", "/x", "--param", "a=1")))
+
+	err = c.Execute(append(base, "--op", "getProject", "--param", "projectName=My Project", "--param", "include=tags", "--param", "X-Trace=abc"))
+	if err != nil {
+		t.Fatalf("call: %v", err)
+	}
+	if got.URL.EscapedPath() != "/data/api/v1/projects/My%20Project" || got.URL.Query().Get("include") != "tags" || got.Header.Get("X-Trace") != "abc" {
+		t.Fatalf("unexpected request %s %v", got.URL, got.Header)
+	}
+
+	got = nil
+	err = c.Execute(append(base, "--op", "getProject", "--param", "projectName=p", "--query", "include=all"))
+	if err != nil || got.URL.Query().Get("include") != "all" {
+		t.Fatalf("expected --query to satisfy a required query param: %v", err)
+	}
+
+	update := append(base, "--op", "updateProject", "--param", "projectName=p", "--yes")
+	err = c.Execute(update)
+	requireUsageExitCode(t, err)
+	if !strings.Contains(err.Error(), "requires a request body") {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if err := c.Execute(append(update, "--body", `{"title":"x"}`)); err != nil {
+		t.Fatalf("update: %v", err)
+	}
+	if !strings.Contains(errOut.String(), `warning: request body schema: $: missing required property "enabled"`) {
+		t.Fatalf("expected body schema warning, got %q", errOut.String())
+	}
+}
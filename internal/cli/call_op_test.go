@@ -2,13 +2,16 @@ package cli
 
 import (
 	"bytes"
+	"io"
 	"net/http"
 	"net/http/httptest"
 	"os"
 	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 
+	"github.com/alex-mccollum/igw-cli/internal/apidocs"
 	"github.com/alex-mccollum/igw-cli/internal/config"
 	"github.com/alex-mccollum/igw-cli/internal/igwerr"
 )
@@ -227,3 +230,436 @@ func writeCallOpSpec(t *testing.T, content string) string {
 	}
 	return path
 }
+
+func TestCallOperationIDWritesSpecSubset(t *testing.T) {
+	t.Parallel()
+
+	specPath := writeCallOpSpec(t, callOpSpecFixture)
+	outPath := filepath.Join(t.TempDir(), "subset.json")
+
+	c := &CLI{
+		In:     strings.NewReader(""),
+		Out:    new(bytes.Buffer),
+		Err:    new(bytes.Buffer),
+		Getenv: func(string) string { return "" },
+		ReadConfig: func() (config.File, error) {
+			return config.File{}, nil
+		},
+		HTTPClient: newMockHTTPClient(func(*http.Request) (*http.Response, error) {
+			return mockHTTPResponse(http.StatusOK, `{}`, nil), nil
+		}),
+	}
+
+	if err := c.Execute([]string{
+		"call",
+		"--gateway-url", mockGatewayURL,
+		"--api-key", "secret",
+		"--op", "gatewayInfo",
+		"--spec-file", specPath,
+		"--write-spec-to", outPath,
+	}); err != nil {
+		t.Fatalf("call by op failed: %v", err)
+	}
+
+	ops, err := apidocs.LoadOperations(outPath)
+	if err != nil {
+		t.Fatalf("load written spec: %v", err)
+	}
+	if len(ops) != 1 || ops[0].OperationID != "gatewayInfo" {
+		t.Fatalf("unexpected written operations: %+v", ops)
+	}
+}
+
+func TestCallWriteSpecToRequiresOperationID(t *testing.T) {
+	t.Parallel()
+
+	c := &CLI{
+		In:     strings.NewReader(""),
+		Out:    new(bytes.Buffer),
+		Err:    new(bytes.Buffer),
+		Getenv: func(string) string { return "" },
+		ReadConfig: func() (config.File, error) {
+			return config.File{}, nil
+		},
+	}
+
+	err := c.Execute([]string{
+		"call",
+		"--gateway-url", mockGatewayURL,
+		"--api-key", "secret",
+		"--path", "/data/api/v1/gateway-info",
+		"--write-spec-to", filepath.Join(t.TempDir(), "subset.json"),
+	})
+	requireUsageExitCode(t, err)
+}
+
+func runCallOpWithSpecVersion(t *testing.T, recorded string, live string, extra ...string) (string, int, error) {
+	t.Helper()
+
+	specPath := writeCallOpSpec(t, callOpSpecFixture)
+	if err := apidocs.WriteSyncMeta(apidocs.SyncMetaPathForSpec(specPath), apidocs.SyncMeta{GatewayVersion: recorded}); err != nil {
+		t.Fatalf("write sync meta: %v", err)
+	}
+
+	var errOut bytes.Buffer
+	var requests int
+	c := &CLI{
+		In:     strings.NewReader(""),
+		Out:    new(bytes.Buffer),
+		Err:    &errOut,
+		Getenv: func(string) string { return "" },
+		ReadConfig: func() (config.File, error) {
+			return config.File{}, nil
+		},
+		HTTPClient: newMockHTTPClient(func(*http.Request) (*http.Response, error) {
+			requests++
+			return mockHTTPResponse(http.StatusOK, `{"name":"gw","version":"`+live+`"}`, nil), nil
+		}),
+	}
+
+	args := append([]string{
+		"call",
+		"--gateway-url", mockGatewayURL,
+		"--api-key", "secret",
+		"--op", "gatewayInfo",
+		"--spec-file", specPath,
+	}, extra...)
+	err := c.Execute(args)
+	return errOut.String(), requests, err
+}
+
+func TestCallOperationIDSpecVersionMatchIsSilent(t *testing.T) {
+	t.Parallel()
+
+	stderr, _, err := runCallOpWithSpecVersion(t, "8.3.1", "8.3.1", "--strict-spec-version")
+	if err != nil {
+		t.Fatalf("call failed: %v", err)
+	}
+	if strings.Contains(stderr, "warning") {
+		t.Fatalf("expected no warning, got %q", stderr)
+	}
+}
+
+func TestCallOperationIDSpecVersionMismatchFailsUnderStrict(t *testing.T) {
+	t.Parallel()
+
+	_, requests, err := runCallOpWithSpecVersion(t, "8.1.40", "8.3.1")
+	if err != nil {
+		t.Fatalf("call failed: %v", err)
+	}
+	if requests != 1 {
+		t.Fatalf("expected no gateway-info probe without --strict-spec-version, got %d requests", requests)
+	}
+
+	_, _, err = runCallOpWithSpecVersion(t, "8.1.40", "8.3.1", "--strict-spec-version")
+	requireUsageExitCode(t, err)
+}
+
+func TestCallOperationIDStrictSpecVersionRequiresLiveVersion(t *testing.T) {
+	t.Parallel()
+
+	_, requests, err := runCallOpWithSpecVersion(t, "8.3.1", "", "--strict-spec-version")
+	requireUsageExitCode(t, err)
+	if requests != 1 {
+		t.Fatalf("expected the call to stop after the version check, got %d requests", requests)
+	}
+}
+
+func TestResolveOperationWithHintsDisambiguates(t *testing.T) {
+	t.Parallel()
+
+	ops := []apidocs.Operation{
+		{Method: http.MethodGet, Path: "/data/api/v1/projects", OperationID: "listProjects", Tags: []string{"v1"}},
+		{Method: http.MethodGet, Path: "/data/api/v2/projects", OperationID: "listProjects", Tags: []string{"projects"}},
+		{Method: http.MethodPost, Path: "/data/api/v2/projects", OperationID: "listProjects"},
+	}
+
+	byNamespace, err := resolveOperationWithHints(ops, "v1:listProjects", "", "openapi.json")
+	if err != nil {
+		t.Fatalf("resolve by tag namespace: %v", err)
+	}
+	if byNamespace[0].Path != "/data/api/v1/projects" {
+		t.Fatalf("unexpected namespace match %+v", byNamespace[0])
+	}
+
+	byNamespaceAndMethod, err := resolveOperationWithHints(ops, "v2:listProjects", "post", "openapi.json")
+	if err != nil {
+		t.Fatalf("resolve by path namespace and method: %v", err)
+	}
+	if byNamespaceAndMethod[0].Method != http.MethodPost || byNamespaceAndMethod[0].Path != "/data/api/v2/projects" {
+		t.Fatalf("unexpected namespace+method match %+v", byNamespaceAndMethod[0])
+	}
+
+	byMethod, err := resolveOperationWithHints(ops, "listProjects", "POST", "openapi.json")
+	if err != nil {
+		t.Fatalf("resolve by method hint: %v", err)
+	}
+	if byMethod[0].Method != http.MethodPost {
+		t.Fatalf("unexpected method match %+v", byMethod[0])
+	}
+}
+
+func TestResolveOperationWithHintsStillAmbiguous(t *testing.T) {
+	t.Parallel()
+
+	ops := []apidocs.Operation{
+		{Method: http.MethodGet, Path: "/data/api/v1/projects", OperationID: "listProjects"},
+		{Method: http.MethodGet, Path: "/data/api/v2/projects", OperationID: "listProjects"},
+	}
+
+	_, err := resolveOperationWithHints(ops, "listProjects", "GET", "openapi.json")
+	requireUsageExitCode(t, err)
+	if !strings.Contains(err.Error(), "ambiguous (2 matches)") {
+		t.Fatalf("expected ambiguity error, got %v", err)
+	}
+
+	_, err = resolveOperationWithHints(ops, "v3:listProjects", "", "openapi.json")
+	requireUsageExitCode(t, err)
+	if !strings.Contains(err.Error(), "no match for the given namespace/method") {
+		t.Fatalf("expected namespace miss error, got %v", err)
+	}
+}
+
+func TestCallOperationIDDeprecatedHandling(t *testing.T) {
+	t.Parallel()
+
+	specPath := writeCallOpSpec(t, `{
+  "openapi": "3.0.0",
+  "paths": {
+    "/data/api/v1/legacy-info": {
+      "get": {
+        "operationId": "legacyInfo",
+        "deprecated": true
+      }
+    }
+  }
+}`)
+
+	cases := []struct {
+		name        string
+		extra       []string
+		wantWarning bool
+		wantErr     bool
+	}{
+		{name: "warns", wantWarning: true},
+		{name: "suppressed", extra: []string{"--no-deprecation-warnings"}},
+		{name: "fails", extra: []string{"--fail-on-deprecated"}, wantErr: true},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			calls := 0
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path == "/data/api/v1/legacy-info" {
+					calls++
+				}
+				w.WriteHeader(http.StatusOK)
+				_, _ = w.Write([]byte(`ok`))
+			}))
+			defer srv.Close()
+
+			var errOut bytes.Buffer
+			c := &CLI{
+				In:     strings.NewReader(""),
+				Out:    new(bytes.Buffer),
+				Err:    &errOut,
+				Getenv: func(string) string { return "" },
+				ReadConfig: func() (config.File, error) {
+					return config.File{}, nil
+				},
+				HTTPClient: srv.Client(),
+			}
+
+			args := append([]string{
+				"call",
+				"--gateway-url", srv.URL,
+				"--api-key", "secret",
+				"--op", "legacyInfo",
+				"--spec-file", specPath,
+			}, tc.extra...)
+			err := c.Execute(args)
+
+			if tc.wantErr {
+				requireUsageExitCode(t, err)
+				if calls != 0 {
+					t.Fatalf("expected no request when failing on deprecated op, got %d", calls)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("call failed: %v", err)
+			}
+			hasWarning := strings.Contains(errOut.String(), `warning: operationId "legacyInfo" is marked deprecated in the spec`)
+			if hasWarning != tc.wantWarning {
+				t.Fatalf("warning presence %t, want %t (stderr %q)", hasWarning, tc.wantWarning, errOut.String())
+			}
+			if calls != 1 {
+				t.Fatalf("expected one request, got %d", calls)
+			}
+		})
+	}
+}
+
+func TestCallOperationIDUseExampleBody(t *testing.T) {
+	t.Parallel()
+
+	specPath := writeCallOpSpec(t, `{
+  "openapi": "3.0.0",
+  "paths": {
+    "/data/api/v1/projects": {
+      "post": {
+        "operationId": "createProject",
+        "requestBody": {
+          "content": {
+            "application/json": {"example": {"name": "demo", "enabled": true}}
+          }
+        }
+      }
+    }
+  }
+}`)
+
+	cases := []struct {
+		name     string
+		extra    []string
+		wantBody string
+		wantNote bool
+	}{
+		{name: "example", extra: []string{"--use-example-body"}, wantBody: `{"name":"demo","enabled":true}`, wantNote: true},
+		{name: "explicit body wins", extra: []string{"--use-example-body", "--body", `{"name":"mine"}`}, wantBody: `{"name":"mine"}`},
+		{name: "not requested", wantBody: ""},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			var gotBody string
+			var gotContentType string
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				b, _ := io.ReadAll(r.Body)
+				gotBody = string(b)
+				gotContentType = r.Header.Get("Content-Type")
+				w.WriteHeader(http.StatusOK)
+				_, _ = w.Write([]byte(`ok`))
+			}))
+			defer srv.Close()
+
+			var errOut bytes.Buffer
+			c := &CLI{
+				In:     strings.NewReader(""),
+				Out:    new(bytes.Buffer),
+				Err:    &errOut,
+				Getenv: func(string) string { return "" },
+				ReadConfig: func() (config.File, error) {
+					return config.File{}, nil
+				},
+				HTTPClient: srv.Client(),
+			}
+
+			args := append([]string{
+				"call",
+				"--gateway-url", srv.URL,
+				"--api-key", "secret",
+				"--op", "createProject",
+				"--spec-file", specPath,
+				"--yes",
+			}, tc.extra...)
+			if err := c.Execute(args); err != nil {
+				t.Fatalf("call failed: %v", err)
+			}
+
+			if gotBody != tc.wantBody {
+				t.Fatalf("unexpected body %q, want %q", gotBody, tc.wantBody)
+			}
+			if tc.wantBody != "" && gotContentType != "application/json" {
+				t.Fatalf("unexpected content type %q", gotContentType)
+			}
+			hasNote := strings.Contains(errOut.String(), `note: using request body example from operationId "createProject"`)
+			if hasNote != tc.wantNote {
+				t.Fatalf("note presence = %v, want %v (stderr %q)", hasNote, tc.wantNote, errOut.String())
+			}
+		})
+	}
+}
+
+func TestCallUseExampleBodyRequiresOperationID(t *testing.T) {
+	t.Parallel()
+
+	c := &CLI{
+		In:     strings.NewReader(""),
+		Out:    new(bytes.Buffer),
+		Err:    new(bytes.Buffer),
+		Getenv: func(string) string { return "" },
+		ReadConfig: func() (config.File, error) {
+			return config.File{}, nil
+		},
+	}
+
+	err := c.Execute([]string{
+		"call",
+		"--gateway-url", "http://127.0.0.1:8088",
+		"--api-key", "secret",
+		"--method", "POST",
+		"--path", "/data/api/v1/projects",
+		"--use-example-body",
+		"--yes",
+	})
+	requireUsageExitCode(t, err)
+}
+
+func TestCallOperationIDUsesSpecTimeoutUnlessOverridden(t *testing.T) {
+	t.Parallel()
+
+	specPath := writeCallOpSpec(t, `{
+  "openapi": "3.0.0",
+  "paths": {
+    "/data/api/v1/diagnostics/bundle/generate": {
+      "get": {"operationId": "generateBundle", "x-timeout": "60s"}
+    }
+  }
+}`)
+
+	requestTimeout := func(extra ...string) time.Duration {
+		t.Helper()
+
+		var remaining time.Duration
+		c := &CLI{
+			In:     strings.NewReader(""),
+			Out:    new(bytes.Buffer),
+			Err:    new(bytes.Buffer),
+			Getenv: func(string) string { return "" },
+			ReadConfig: func() (config.File, error) {
+				return config.File{}, nil
+			},
+			HTTPClient: newMockHTTPClient(func(r *http.Request) (*http.Response, error) {
+				if deadline, ok := r.Context().Deadline(); ok {
+					remaining = time.Until(deadline)
+				}
+				return mockHTTPResponse(http.StatusOK, `{}`, nil), nil
+			}),
+		}
+		err := c.Execute(append([]string{
+			"call",
+			"--gateway-url", mockGatewayURL,
+			"--api-key", "secret",
+			"--op", "generateBundle",
+			"--spec-file", specPath,
+		}, extra...))
+		if err != nil {
+			t.Fatalf("call failed: %v", err)
+		}
+		return remaining
+	}
+
+	if got := requestTimeout(); got <= 30*time.Second || got > 60*time.Second {
+		t.Fatalf("expected the 60s x-timeout, got %v remaining", got)
+	}
+	if got := requestTimeout("--timeout", "5s"); got > 5*time.Second {
+		t.Fatalf("expected explicit --timeout 5s to win, got %v remaining", got)
+	}
+}
@@ -0,0 +1,141 @@
+package cli
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/alex-mccollum/igw-cli/internal/exitcode"
+	"github.com/alex-mccollum/igw-cli/internal/gateway"
+	"github.com/alex-mccollum/igw-cli/internal/igwerr"
+)
+
+// outcomeFiles appends one NDJSON record per response to --success-out or
+// --failure-out, independent of stdout. Either file may be unset, and a nil
+// *outcomeFiles ignores records so callers can thread it unconditionally.
+type outcomeFiles struct {
+	mu      sync.Mutex
+	success *os.File
+	failure *os.File
+	err     error
+}
+
+func openOutcomeFiles(successPath string, failurePath string) (*outcomeFiles, error) {
+	successPath, failurePath = strings.TrimSpace(successPath), strings.TrimSpace(failurePath)
+	if successPath == "" && failurePath == "" {
+		return nil, nil
+	}
+	if successPath != "" && successPath == failurePath {
+		return nil, &igwerr.UsageError{Msg: "--success-out and --failure-out must be different files"}
+	}
+
+	o := &outcomeFiles{}
+	open := func(path string, flag string) (*os.File, error) {
+		if path == "" {
+			return nil, nil
+		}
+		file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o600)
+		if err != nil {
+			return nil, &igwerr.UsageError{Msg: fmt.Sprintf("open %s: %v", flag, err)}
+		}
+		return file, nil
+	}
+	var err error
+	if o.success, err = open(successPath, "--success-out"); err != nil {
+		return nil, err
+	}
+	if o.failure, err = open(failurePath, "--failure-out"); err != nil {
+		_ = o.close()
+		return nil, err
+	}
+	return o, nil
+}
+
+// recordCall routes one executed request by its exit code.
+func (o *outcomeFiles) recordCall(method string, path string, resp *gateway.CallResponse, err error, elapsed time.Duration) {
+	if o == nil {
+		return
+	}
+	record := callBatchItemResult{
+		OK:       err == nil,
+		Code:     exitCodeForError(err),
+		TimingMs: elapsed.Milliseconds(),
+		Request:  callJSONRequest{Method: method, URL: path},
+	}
+	if resp != nil {
+		record.Status = resp.StatusCode
+		record.Request.URL = resp.URL
+		record.Response = callJSONResponse{
+			Status:    resp.StatusCode,
+			Body:      string(resp.Body),
+			Bytes:     resp.BodyBytes,
+			Truncated: resp.Truncated,
+		}
+	}
+	if err != nil {
+		record.Error = err.Error()
+		var statusErr *igwerr.StatusError
+		if errors.As(err, &statusErr) {
+			record.Status = statusErr.StatusCode
+			record.Response = callJSONResponse{Status: statusErr.StatusCode, Body: statusErr.Body}
+		}
+	}
+	o.write(record)
+}
+
+// writeBatchResults routes batch envelopes in input order.
+func (o *outcomeFiles) writeBatchResults(results []callBatchItemResult) error {
+	if o == nil {
+		return nil
+	}
+	for _, result := range results {
+		o.write(result)
+	}
+	return o.firstErr()
+}
+
+func (o *outcomeFiles) write(record callBatchItemResult) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	file := o.success
+	if record.Code != exitcode.Success {
+		file = o.failure
+	}
+	if file == nil || o.err != nil {
+		return
+	}
+	line, err := json.Marshal(record)
+	if err == nil {
+		_, err = file.Write(append(line, '\n'))
+	}
+	if err != nil {
+		o.err = fmt.Errorf("write %s: %w", file.Name(), err)
+	}
+}
+
+func (o *outcomeFiles) firstErr() error {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	return o.err
+}
+
+// close closes both files and returns the first write or close error.
+func (o *outcomeFiles) close() error {
+	if o == nil {
+		return nil
+	}
+	err := o.firstErr()
+	for _, file := range []*os.File{o.success, o.failure} {
+		if file == nil {
+			continue
+		}
+		if closeErr := file.Close(); err == nil {
+			err = closeErr
+		}
+	}
+	return err
+}
@@ -0,0 +1,153 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/alex-mccollum/igw-cli/internal/config"
+)
+
+func readOutcomeRecords(t *testing.T, path string) []callBatchItemResult {
+	t.Helper()
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("read %s: %v", path, err)
+	}
+	var records []callBatchItemResult
+	for _, line := range strings.Split(strings.TrimSpace(string(data)), "\n") {
+		if line == "" {
+			continue
+		}
+		var record callBatchItemResult
+		if err := json.Unmarshal([]byte(line), &record); err != nil {
+			t.Fatalf("decode record %q: %v", line, err)
+		}
+		records = append(records, record)
+	}
+	return records
+}
+
+func TestCallRepeatRoutesOutcomesToFiles(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	successPath := filepath.Join(dir, "ok.ndjson")
+	failurePath := filepath.Join(dir, "failed.ndjson")
+
+	calls := 0
+	c := &CLI{
+		In:     strings.NewReader(""),
+		Out:    new(bytes.Buffer),
+		Err:    new(bytes.Buffer),
+		Getenv: func(string) string { return "" },
+		ReadConfig: func() (config.File, error) {
+			return config.File{}, nil
+		},
+		HTTPClient: newMockHTTPClient(func(r *http.Request) (*http.Response, error) {
+			calls++
+			if calls%2 == 0 {
+				return mockHTTPResponse(http.StatusServiceUnavailable, `{"error":"busy"}`, nil), nil
+			}
+			return mockHTTPResponse(http.StatusOK, `{"ok":true}`, nil), nil
+		}),
+	}
+	err := c.Execute([]string{
+		"call",
+		"--gateway-url", mockGatewayURL,
+		"--api-key", "secret",
+		"--path", "/data/api/v1/gateway-info",
+		"--repeat", "2",
+		"--success-out", successPath,
+		"--failure-out", failurePath,
+	})
+	if err == nil {
+		t.Fatalf("expected failure exit from the failed repeat")
+	}
+
+	successes := readOutcomeRecords(t, successPath)
+	if len(successes) != 1 || !successes[0].OK || successes[0].Response.Body != `{"ok":true}` {
+		t.Fatalf("unexpected success records: %+v", successes)
+	}
+	failures := readOutcomeRecords(t, failurePath)
+	if len(failures) != 1 || failures[0].OK || failures[0].Status != http.StatusServiceUnavailable || failures[0].Code != 7 {
+		t.Fatalf("unexpected failure records: %+v", failures)
+	}
+}
+
+func TestCallBatchRoutesOutcomesToFiles(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	successPath := filepath.Join(dir, "ok.ndjson")
+	failurePath := filepath.Join(dir, "failed.ndjson")
+	// Appends to existing content.
+	if err := os.WriteFile(successPath, []byte(`{"id":"earlier","ok":true,"code":0,"timingMs":0}`+"\n"), 0o600); err != nil {
+		t.Fatalf("seed success file: %v", err)
+	}
+
+	c := &CLI{
+		In: strings.NewReader(
+			`{"id":"a","method":"GET","path":"/data/api/v1/gateway-info"}` + "\n" +
+				`{"id":"b","method":"GET","path":"/data/api/v1/missing"}` + "\n",
+		),
+		Out:    new(bytes.Buffer),
+		Err:    new(bytes.Buffer),
+		Getenv: func(string) string { return "" },
+		ReadConfig: func() (config.File, error) {
+			return config.File{}, nil
+		},
+		HTTPClient: newMockHTTPClient(func(r *http.Request) (*http.Response, error) {
+			if r.URL.Path == "/data/api/v1/missing" {
+				return mockHTTPResponse(http.StatusNotFound, `{}`, nil), nil
+			}
+			return mockHTTPResponse(http.StatusOK, `{"ok":true}`, nil), nil
+		}),
+	}
+	_ = c.Execute([]string{
+		"call",
+		"--gateway-url", mockGatewayURL,
+		"--api-key", "secret",
+		"--batch", "-",
+		"--success-out", successPath,
+		"--failure-out", failurePath,
+	})
+
+	successes := readOutcomeRecords(t, successPath)
+	if len(successes) != 2 || successes[1].ID != "a" {
+		t.Fatalf("unexpected success records: %+v", successes)
+	}
+	failures := readOutcomeRecords(t, failurePath)
+	if len(failures) != 1 || failures[0].ID != "b" {
+		t.Fatalf("unexpected failure records: %+v", failures)
+	}
+}
+
+func TestCallOutcomeFilesMustDiffer(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "out.ndjson")
+	c := &CLI{
+		In:     strings.NewReader(""),
+		Out:    new(bytes.Buffer),
+		Err:    new(bytes.Buffer),
+		Getenv: func(string) string { return "" },
+		ReadConfig: func() (config.File, error) {
+			return config.File{}, nil
+		},
+	}
+	err := c.Execute([]string{
+		"call",
+		"--gateway-url", mockGatewayURL,
+		"--api-key", "secret",
+		"--path", "/data/api/v1/gateway-info",
+		"--success-out", path,
+		"--failure-out", path,
+	})
+	requireUsageExitCode(t, err)
+}
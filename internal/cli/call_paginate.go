@@ -0,0 +1,202 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/alex-mccollum/igw-cli/internal/gateway"
+	"github.com/alex-mccollum/igw-cli/internal/igwerr"
+)
+
+// callPaginateOptions holds --paginate and its tuning flags.
+type callPaginateOptions struct {
+	enabled   bool
+	pageParam string
+	sizeParam string
+	pageSize  int
+	maxPages  int
+}
+
+func bindPaginateFlags(fs *flag.FlagSet, opts *callPaginateOptions) {
+	fs.BoolVar(&opts.enabled, "paginate", false, "Follow page/pageSize paged GET responses and print one merged JSON body")
+	fs.StringVar(&opts.pageParam, "page-param", "page", "Query parameter carrying the page number for --paginate")
+	fs.StringVar(&opts.sizeParam, "page-size-param", "pageSize", "Query parameter carrying --page-size for --paginate")
+	fs.IntVar(&opts.pageSize, "page-size", 0, "Items per page requested by --paginate (0 = gateway default)")
+	fs.IntVar(&opts.maxPages, "max-pages", 0, "Stop --paginate after N pages (0 = until the last page)")
+}
+
+func (o callPaginateOptions) validate(fs *flag.FlagSet) error {
+	if !o.enabled {
+		for _, name := range []string{"page-param", "page-size-param", "page-size", "max-pages"} {
+			if flagWasSet(fs, name) {
+				return &igwerr.UsageError{Msg: fmt.Sprintf("--%s requires --paginate", name)}
+			}
+		}
+		return nil
+	}
+	if strings.TrimSpace(o.pageParam) == "" || strings.TrimSpace(o.sizeParam) == "" {
+		return &igwerr.UsageError{Msg: "--page-param and --page-size-param must not be empty"}
+	}
+	if o.pageSize < 0 {
+		return &igwerr.UsageError{Msg: "--page-size must be >= 0"}
+	}
+	if o.maxPages < 0 {
+		return &igwerr.UsageError{Msg: "--max-pages must be >= 0"}
+	}
+	return nil
+}
+
+// callPageTiming is one fetched page in the --paginate stats payload.
+type callPageTiming struct {
+	Page      int   `json:"page"`
+	TimingMs  int64 `json:"timingMs"`
+	BodyBytes int64 `json:"bodyBytes"`
+	Items     int   `json:"items"`
+}
+
+// paginateCall fetches pages starting at the --query page value (default 1)
+// until a page is empty, the body's totalPages is reached, or --max-pages
+// pages were read. Each page goes through executeCallCore, so --retry
+// applies per page. The returned response is the last page with its body
+// replaced by the merged items.
+func paginateCall(client *gateway.Client, input callExecutionInput, opts callPaginateOptions) (*gateway.CallResponse, []callPageTiming, error) {
+	pageParam := strings.TrimSpace(opts.pageParam)
+	sizeParam := strings.TrimSpace(opts.sizeParam)
+	page := 1
+	query := make([]string, 0, len(input.Query)+2)
+	for _, pair := range input.Query {
+		key, value, _ := strings.Cut(pair, "=")
+		switch strings.TrimSpace(key) {
+		case pageParam:
+			start, err := strconv.Atoi(strings.TrimSpace(value))
+			if err != nil {
+				return nil, nil, &igwerr.UsageError{Msg: fmt.Sprintf("--query %s must be an integer with --paginate", pageParam)}
+			}
+			page = start
+			continue
+		case sizeParam:
+			if opts.pageSize > 0 {
+				continue
+			}
+		}
+		query = append(query, pair)
+	}
+	if opts.pageSize > 0 {
+		query = append(query, fmt.Sprintf("%s=%d", sizeParam, opts.pageSize))
+	}
+
+	var (
+		merged    []any
+		container map[string]any
+		itemsKey  string
+		timings   []callPageTiming
+		last      *gateway.CallResponse
+	)
+	for opts.maxPages == 0 || len(timings) < opts.maxPages {
+		pageInput := input
+		pageInput.Query = append(append([]string(nil), query...), fmt.Sprintf("%s=%d", pageParam, page))
+
+		started := time.Now()
+		resp, _, _, err := executeCallCore(client, pageInput)
+		if err != nil {
+			return nil, timings, err
+		}
+		last = resp
+
+		items, object, key, err := pageItems(resp.Body)
+		if err != nil {
+			return nil, timings, fmt.Errorf("--paginate page %d: %w", page, err)
+		}
+		timings = append(timings, callPageTiming{
+			Page:      page,
+			TimingMs:  time.Since(started).Milliseconds(),
+			BodyBytes: resp.BodyBytes,
+			Items:     len(items),
+		})
+		if container == nil && object != nil {
+			container, itemsKey = object, key
+		}
+		if len(items) == 0 {
+			break
+		}
+		merged = append(merged, items...)
+		if total, ok := pageTotal(object); ok && page >= total {
+			break
+		}
+		page++
+	}
+
+	if merged == nil {
+		merged = []any{}
+	}
+	var body []byte
+	var err error
+	if container != nil {
+		container[itemsKey] = merged
+		body, err = json.Marshal(container)
+	} else {
+		body, err = json.Marshal(merged)
+	}
+	if err != nil {
+		return nil, timings, fmt.Errorf("--paginate: encode merged body: %w", err)
+	}
+
+	out := *last
+	out.Body = body
+	out.BodyBytes = int64(len(body))
+	out.Truncated = false
+	return &out, timings, nil
+}
+
+// pageItems finds the item array of one page: the body itself, its "items"
+// field, or its only array field. object is nil for a top-level array.
+func pageItems(body []byte) (items []any, object map[string]any, key string, err error) {
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+	var value any
+	if err := decoder.Decode(&value); err != nil {
+		return nil, nil, "", fmt.Errorf("response is not JSON: %w", err)
+	}
+
+	switch v := value.(type) {
+	case []any:
+		return v, nil, "", nil
+	case map[string]any:
+		if items, ok := v["items"].([]any); ok {
+			return items, v, "items", nil
+		}
+		for name, field := range v {
+			arr, ok := field.([]any)
+			if !ok {
+				continue
+			}
+			if key != "" {
+				return nil, nil, "", fmt.Errorf("response has several array fields (%s, %s); cannot tell which holds the items", key, name)
+			}
+			items, key = arr, name
+		}
+		if key == "" {
+			return nil, nil, "", fmt.Errorf("response has no item array")
+		}
+		return items, v, key, nil
+	default:
+		return nil, nil, "", fmt.Errorf("response is not a JSON object or array")
+	}
+}
+
+func pageTotal(object map[string]any) (int, bool) {
+	number, ok := object["totalPages"].(json.Number)
+	if !ok {
+		return 0, false
+	}
+	total, err := number.Int64()
+	if err != nil {
+		return 0, false
+	}
+	return int(total), true
+}
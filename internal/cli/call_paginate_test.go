@@ -0,0 +1,136 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"testing"
+)
+
+func TestCallPaginateMergesPagesUntilTotalPages(t *testing.T) {
+	t.Parallel()
+
+	var queries []string
+	var out bytes.Buffer
+	c := newAdminWrapperTestCLI(newMockHTTPClient(func(r *http.Request) (*http.Response, error) {
+		queries = append(queries, r.URL.RawQuery)
+		page := r.URL.Query().Get("page")
+		return mockHTTPResponse(http.StatusOK, `{"items":[{"name":"p`+page+`"}],"page":`+page+`,"totalPages":3}`, nil), nil
+	}))
+	c.Out = &out
+	err := c.Execute([]string{
+		"call",
+		"--gateway-url", mockGatewayURL,
+		"--api-key", "secret",
+		"--path", "/data/api/v1/projects/list",
+		"--paginate",
+		"--page-size", "1",
+		"--json",
+	})
+	if err != nil {
+		t.Fatalf("call failed: %v", err)
+	}
+
+	if strings.Join(queries, "|") != "page=1&pageSize=1|page=2&pageSize=1|page=3&pageSize=1" {
+		t.Fatalf("unexpected page queries %q", queries)
+	}
+	var payload struct {
+		Response struct {
+			Body string `json:"body"`
+		} `json:"response"`
+		Stats callStats `json:"stats"`
+	}
+	if err := json.Unmarshal(out.Bytes(), &payload); err != nil {
+		t.Fatalf("decode envelope: %v", err)
+	}
+	if payload.Response.Body != `{"items":[{"name":"p1"},{"name":"p2"},{"name":"p3"}],"page":1,"totalPages":3}` {
+		t.Fatalf("unexpected merged body %s", payload.Response.Body)
+	}
+	if payload.Stats.Pages != 3 || len(payload.Stats.PageTimings) != 3 || payload.Stats.PageTimings[2].Page != 3 || payload.Stats.PageTimings[2].Items != 1 {
+		t.Fatalf("unexpected stats %+v", payload.Stats)
+	}
+}
+
+func TestCallPaginateStopsOnEmptyPageAndRetriesPerPage(t *testing.T) {
+	t.Parallel()
+
+	var calls atomic.Int32
+	var out bytes.Buffer
+	c := newAdminWrapperTestCLI(newMockHTTPClient(func(r *http.Request) (*http.Response, error) {
+		n := calls.Add(1)
+		if n == 2 {
+			return mockHTTPResponse(http.StatusServiceUnavailable, "busy", nil), nil
+		}
+		switch r.URL.Query().Get("page") {
+		case "5":
+			return mockHTTPResponse(http.StatusOK, `[1,2]`, nil), nil
+		case "6":
+			return mockHTTPResponse(http.StatusOK, `[3]`, nil), nil
+		default:
+			return mockHTTPResponse(http.StatusOK, `[]`, nil), nil
+		}
+	}))
+	c.Out = &out
+	err := c.Execute([]string{
+		"call",
+		"--gateway-url", mockGatewayURL,
+		"--api-key", "secret",
+		"--path", "/data/api/v1/logs",
+		"--query", "page=5",
+		"--paginate",
+		"--retry", "1",
+		"--retry-backoff", "1ms",
+	})
+	if err != nil {
+		t.Fatalf("call failed: %v", err)
+	}
+	if strings.TrimSpace(out.String()) != "[1,2,3]" {
+		t.Fatalf("unexpected merged body %q", out.String())
+	}
+	if calls.Load() != 4 {
+		t.Fatalf("expected 4 requests (one retry), got %d", calls.Load())
+	}
+}
+
+func TestCallPaginateMaxPages(t *testing.T) {
+	t.Parallel()
+
+	var calls atomic.Int32
+	var out bytes.Buffer
+	c := newAdminWrapperTestCLI(newMockHTTPClient(func(r *http.Request) (*http.Response, error) {
+		calls.Add(1)
+		return mockHTTPResponse(http.StatusOK, `{"tags":["a"],"count":1}`, nil), nil
+	}))
+	c.Out = &out
+	err := c.Execute([]string{
+		"call",
+		"--gateway-url", mockGatewayURL,
+		"--api-key", "secret",
+		"--path", "/data/api/v1/tags",
+		"--paginate",
+		"--max-pages", "2",
+	})
+	if err != nil {
+		t.Fatalf("call failed: %v", err)
+	}
+	if calls.Load() != 2 || !strings.HasPrefix(out.String(), `{"count":1,"tags":["a","a"]}`) {
+		t.Fatalf("unexpected result after %d calls: %q", calls.Load(), out.String())
+	}
+}
+
+func TestCallPaginateValidation(t *testing.T) {
+	t.Parallel()
+
+	c := newAdminWrapperTestCLI(nil)
+	base := []string{"call", "--gateway-url", mockGatewayURL, "--api-key", "secret", "--path", "/data/api/v1/x"}
+	for _, args := range [][]string{
+		{"--paginate", "--method", "POST", "--yes"},
+		{"--paginate", "--stream"},
+		{"--max-pages", "2"},
+		{"--paginate", "--max-pages", "-1"},
+	} {
+		requireUsageExitCode(t, c.Execute(append(append([]string(nil), base...), args...)))
+	}
+}
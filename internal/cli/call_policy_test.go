@@ -0,0 +1,133 @@
+package cli
+
+import (
+	"bytes"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/alex-mccollum/igw-cli/internal/config"
+)
+
+func policyTestClient(calls *int) *http.Client {
+	return newMockHTTPClient(func(r *http.Request) (*http.Response, error) {
+		*calls++
+		return mockHTTPResponse(http.StatusOK, `{"ok":true}`, nil), nil
+	})
+}
+
+func policyTestConfig(policy *config.Policy) func() (config.File, error) {
+	return func() (config.File, error) {
+		return config.File{
+			GatewayURL: mockGatewayURL,
+			Token:      "secret",
+			Policy:     policy,
+		}, nil
+	}
+}
+
+func TestCallPolicyBlocksDeniedDelete(t *testing.T) {
+	t.Parallel()
+
+	policy := &config.Policy{Deny: []config.PolicyRule{{Method: "DELETE", Path: "/data/api/v1/backup"}}}
+
+	calls := 0
+	var errOut bytes.Buffer
+	c := newAdminWrapperTestCLI(policyTestClient(&calls))
+	c.Err = &errOut
+	c.ReadConfig = policyTestConfig(policy)
+	err := c.Execute([]string{"call", "--method", "DELETE", "--path", "/data/api/v1/backup", "--yes"})
+	requireUsageExitCode(t, err)
+	if calls != 0 {
+		t.Fatalf("blocked call reached the gateway %d times", calls)
+	}
+	if !strings.Contains(errOut.String(), "blocked by policy: DELETE /data/api/v1/backup") {
+		t.Fatalf("unexpected stderr %q", errOut.String())
+	}
+
+	c = newAdminWrapperTestCLI(policyTestClient(&calls))
+	c.ReadConfig = policyTestConfig(policy)
+	if err := c.Execute([]string{"call", "--method", "GET", "--path", "/data/api/v1/backup"}); err != nil {
+		t.Fatalf("GET should not be blocked: %v", err)
+	}
+	if calls != 1 {
+		t.Fatalf("expected one call, got %d", calls)
+	}
+}
+
+func TestCallPolicyAllowlistMiss(t *testing.T) {
+	t.Parallel()
+
+	policy := &config.Policy{
+		Mode:  config.PolicyModeAllow,
+		Allow: []config.PolicyRule{{Method: "GET", Path: "/data/api/v1/gateway-info"}},
+	}
+
+	calls := 0
+	var errOut bytes.Buffer
+	c := newAdminWrapperTestCLI(policyTestClient(&calls))
+	c.Err = &errOut
+	c.ReadConfig = policyTestConfig(policy)
+	err := c.Execute([]string{"call", "--path", "/data/api/v1/logs", "--json"})
+	requireUsageExitCode(t, err)
+	if calls != 0 {
+		t.Fatalf("blocked call reached the gateway %d times", calls)
+	}
+	if !strings.Contains(c.Out.(*bytes.Buffer).String(), "not on the allowlist") {
+		t.Fatalf("expected allowlist miss in JSON error, got %q (stderr %q)", c.Out.(*bytes.Buffer).String(), errOut.String())
+	}
+
+	c = newAdminWrapperTestCLI(policyTestClient(&calls))
+	c.ReadConfig = policyTestConfig(policy)
+	if err := c.Execute([]string{"call", "--path", "/data/api/v1/gateway-info"}); err != nil {
+		t.Fatalf("allowlisted call failed: %v", err)
+	}
+	if calls != 1 {
+		t.Fatalf("expected one call, got %d", calls)
+	}
+}
+
+func TestConfigSetPolicyRules(t *testing.T) {
+	t.Parallel()
+
+	var saved config.File
+	c := &CLI{
+		In:     strings.NewReader(""),
+		Out:    new(bytes.Buffer),
+		Err:    new(bytes.Buffer),
+		Getenv: func(string) string { return "" },
+		ReadConfig: func() (config.File, error) {
+			return saved, nil
+		},
+		WriteConfig: func(cfg config.File) error {
+			saved = cfg
+			return nil
+		},
+	}
+
+	if err := c.Execute([]string{"config", "set", "--deny-path", "/data/api/v1/backup", "--deny-method", "delete"}); err != nil {
+		t.Fatalf("config set deny failed: %v", err)
+	}
+	if err := c.Execute([]string{"config", "set", "--policy-mode", "allow", "--allow-method", "GET"}); err != nil {
+		t.Fatalf("config set allow failed: %v", err)
+	}
+	if saved.Policy == nil || saved.Policy.Mode != config.PolicyModeAllow {
+		t.Fatalf("expected allow mode policy, got %#v", saved.Policy)
+	}
+	if len(saved.Policy.Deny) != 1 || saved.Policy.Deny[0] != (config.PolicyRule{Method: "DELETE", Path: "/data/api/v1/backup"}) {
+		t.Fatalf("unexpected deny rules %#v", saved.Policy.Deny)
+	}
+	if len(saved.Policy.Allow) != 1 || saved.Policy.Allow[0].Method != "GET" {
+		t.Fatalf("unexpected allow rules %#v", saved.Policy.Allow)
+	}
+
+	requireUsageExitCode(t, c.Execute([]string{"config", "set", "--policy-mode", "audit"}))
+	requireUsageExitCode(t, c.Execute([]string{"config", "set", "--profile", "dev", "--deny-method", "DELETE"}))
+
+	if err := c.Execute([]string{"config", "set", "--clear-policy"}); err != nil {
+		t.Fatalf("config set --clear-policy failed: %v", err)
+	}
+	if saved.Policy != nil {
+		t.Fatalf("expected policy cleared, got %#v", saved.Policy)
+	}
+}
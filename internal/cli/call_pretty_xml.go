@@ -0,0 +1,99 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"strings"
+)
+
+func isXMLContentType(contentType string) bool {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return false
+	}
+	return mediaType == "application/xml" || mediaType == "text/xml" || strings.HasSuffix(mediaType, "+xml")
+}
+
+// indentXML re-indents an XML document token by token. Prefixed names are
+// kept as written (the decoder runs in raw mode) and whitespace-only text
+// between elements is dropped so the encoder controls layout; all other
+// content is preserved.
+func indentXML(raw []byte) ([]byte, error) {
+	dec := xml.NewDecoder(bytes.NewReader(raw))
+	dec.Strict = true
+
+	var out bytes.Buffer
+	enc := xml.NewEncoder(&out)
+	enc.Indent("", "  ")
+
+	// RawToken does not verify nesting, so track open elements here.
+	var open []string
+	for {
+		tok, err := dec.RawToken()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		switch t := tok.(type) {
+		case xml.StartElement:
+			t.Name = rawXMLName(t.Name)
+			attrs := make([]xml.Attr, len(t.Attr))
+			for i, attr := range t.Attr {
+				attrs[i] = xml.Attr{Name: rawXMLName(attr.Name), Value: attr.Value}
+			}
+			t.Attr = attrs
+			tok = t
+			open = append(open, t.Name.Local)
+		case xml.EndElement:
+			t.Name = rawXMLName(t.Name)
+			if len(open) == 0 || open[len(open)-1] != t.Name.Local {
+				return nil, fmt.Errorf("unexpected end element </%s>", t.Name.Local)
+			}
+			open = open[:len(open)-1]
+			tok = t
+		case xml.CharData:
+			if len(bytes.TrimSpace(t)) == 0 {
+				continue
+			}
+		case xml.ProcInst:
+			if err := enc.EncodeToken(t); err != nil {
+				return nil, err
+			}
+			// The encoder does not break the line after a declaration.
+			if err := enc.Flush(); err != nil {
+				return nil, err
+			}
+			out.WriteByte('\n')
+			continue
+		}
+		if err := enc.EncodeToken(xml.CopyToken(tok)); err != nil {
+			return nil, err
+		}
+	}
+
+	if len(open) > 0 {
+		return nil, fmt.Errorf("unclosed element <%s>", open[len(open)-1])
+	}
+	if err := enc.Flush(); err != nil {
+		return nil, err
+	}
+	if out.Len() == 0 {
+		return nil, errors.New("empty XML document")
+	}
+	out.WriteByte('\n')
+	return out.Bytes(), nil
+}
+
+func rawXMLName(name xml.Name) xml.Name {
+	if name.Space == "" {
+		return name
+	}
+	return xml.Name{Local: name.Space + ":" + name.Local}
+}
@@ -0,0 +1,145 @@
+package cli
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/alex-mccollum/igw-cli/internal/config"
+)
+
+func TestCallPrettyXMLIndentsResponse(t *testing.T) {
+	t.Parallel()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/xml; charset=utf-8")
+		_, _ = w.Write([]byte(`<?xml version="1.0" encoding="UTF-8"?><Tags><Tag name="Motor &amp; Pump" type="AtomicTag"><Property name="value">42</Property></Tag></Tags>`))
+	}))
+	defer srv.Close()
+
+	var out, errOut bytes.Buffer
+	c := newAdminWrapperTestCLI(srv.Client())
+	c.Out = &out
+	c.Err = &errOut
+	if err := c.Execute([]string{
+		"call",
+		"--gateway-url", srv.URL,
+		"--api-key", "secret",
+		"--path", "/data/api/v1/tags/export",
+		"--pretty-xml",
+	}); err != nil {
+		t.Fatalf("call failed: %v", err)
+	}
+
+	want := `<?xml version="1.0" encoding="UTF-8"?>
+<Tags>
+  <Tag name="Motor &amp; Pump" type="AtomicTag">
+    <Property name="value">42</Property>
+  </Tag>
+</Tags>
+`
+	if out.String() != want {
+		t.Fatalf("unexpected output:\n%s", out.String())
+	}
+	if errOut.Len() != 0 {
+		t.Fatalf("unexpected stderr %q", errOut.String())
+	}
+}
+
+func TestCallPrettyXMLWritesIndentedOutFile(t *testing.T) {
+	t.Parallel()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/xml")
+		_, _ = w.Write([]byte(`<a><b>x</b></a>`))
+	}))
+	defer srv.Close()
+
+	outPath := filepath.Join(t.TempDir(), "tags.xml")
+	var out, errOut bytes.Buffer
+	c := newAdminWrapperTestCLI(srv.Client())
+	c.Out = &out
+	c.Err = &errOut
+	if err := c.Execute([]string{
+		"call",
+		"--gateway-url", srv.URL,
+		"--api-key", "secret",
+		"--path", "/data/api/v1/tags/export",
+		"--pretty-xml",
+		"--out", outPath,
+	}); err != nil {
+		t.Fatalf("call failed: %v", err)
+	}
+
+	data, err := os.ReadFile(outPath)
+	if err != nil {
+		t.Fatalf("read out file: %v", err)
+	}
+	if string(data) != "<a>\n  <b>x</b>\n</a>\n" {
+		t.Fatalf("unexpected file content %q", string(data))
+	}
+	if !strings.Contains(errOut.String(), "saved response body: "+outPath) {
+		t.Fatalf("expected saved message, got %q", errOut.String())
+	}
+	if out.Len() != 0 {
+		t.Fatalf("unexpected stdout %q", out.String())
+	}
+}
+
+func TestCallPrettyXMLPassesMalformedThrough(t *testing.T) {
+	t.Parallel()
+
+	body := `<a><b>unterminated</a>`
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/xml")
+		_, _ = w.Write([]byte(body))
+	}))
+	defer srv.Close()
+
+	var out, errOut bytes.Buffer
+	c := newAdminWrapperTestCLI(srv.Client())
+	c.Out = &out
+	c.Err = &errOut
+	if err := c.Execute([]string{
+		"call",
+		"--gateway-url", srv.URL,
+		"--api-key", "secret",
+		"--path", "/data/api/v1/tags/export",
+		"--pretty-xml",
+	}); err != nil {
+		t.Fatalf("call failed: %v", err)
+	}
+
+	if out.String() != body {
+		t.Fatalf("expected body unchanged, got %q", out.String())
+	}
+	if !strings.Contains(errOut.String(), "warning: --pretty-xml") {
+		t.Fatalf("expected warning, got %q", errOut.String())
+	}
+}
+
+func TestCallPrettyXMLRejectsJSON(t *testing.T) {
+	t.Parallel()
+
+	c := &CLI{
+		In:     strings.NewReader(""),
+		Out:    new(bytes.Buffer),
+		Err:    new(bytes.Buffer),
+		Getenv: func(string) string { return "" },
+		ReadConfig: func() (config.File, error) {
+			return config.File{}, nil
+		},
+	}
+	requireUsageExitCode(t, c.Execute([]string{
+		"call",
+		"--gateway-url", "http://127.0.0.1:8088",
+		"--api-key", "secret",
+		"--path", "/data/api/v1/tags/export",
+		"--pretty-xml",
+		"--json",
+	}))
+}
@@ -0,0 +1,16 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/alex-mccollum/igw-cli/internal/gateway"
+)
+
+// printRedirectChain writes one line per followed redirect ahead of the
+// --include-headers status line.
+func printRedirectChain(w io.Writer, redirects []gateway.Redirect) {
+	for _, hop := range redirects {
+		fmt.Fprintf(w, "redirect\t%d\t%s -> %s\ttokenResent=%t\n", hop.Status, hop.From, hop.Location, hop.TokenResent)
+	}
+}
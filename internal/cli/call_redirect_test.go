@@ -0,0 +1,114 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+// redirectTestClient answers /data/api/v1/old with a 301 to
+// /data/api/v1/new and everything else with 200.
+func redirectTestClient() *http.Client {
+	return newMockHTTPClient(func(r *http.Request) (*http.Response, error) {
+		switch r.URL.Path {
+		case "/data/api/v1/old":
+			return mockHTTPResponse(http.StatusMovedPermanently, "", http.Header{"Location": []string{"/data/api/v1/new"}}), nil
+		default:
+			return mockHTTPResponse(http.StatusOK, `{"ok":true}`, nil), nil
+		}
+	})
+}
+
+func TestCallFollowRedirectsJSONEnvelope(t *testing.T) {
+	t.Parallel()
+
+	var out bytes.Buffer
+	c := newAdminWrapperTestCLI(redirectTestClient())
+	c.Out = &out
+	err := c.Execute([]string{
+		"call",
+		"--gateway-url", mockGatewayURL,
+		"--api-key", "secret",
+		"--path", "/data/api/v1/old",
+		"--follow-redirects", "3",
+		"--json",
+	})
+	if err != nil {
+		t.Fatalf("call failed: %v", err)
+	}
+
+	var payload struct {
+		Response  struct{ Status int } `json:"response"`
+		Redirects []map[string]any     `json:"redirects"`
+	}
+	if err := json.Unmarshal(out.Bytes(), &payload); err != nil {
+		t.Fatalf("decode envelope: %v", err)
+	}
+	if payload.Response.Status != http.StatusOK || len(payload.Redirects) != 1 {
+		t.Fatalf("unexpected envelope: %s", out.String())
+	}
+	hop := payload.Redirects[0]
+	if hop["status"] != float64(http.StatusMovedPermanently) || hop["location"] != mockGatewayURL+"/data/api/v1/new" || hop["tokenResent"] != true {
+		t.Fatalf("unexpected hop: %v", hop)
+	}
+}
+
+func TestCallFollowRedirectsZeroReturnsRedirect(t *testing.T) {
+	t.Parallel()
+
+	var out bytes.Buffer
+	c := newAdminWrapperTestCLI(redirectTestClient())
+	c.Out = &out
+	err := c.Execute([]string{
+		"call",
+		"--gateway-url", mockGatewayURL,
+		"--api-key", "secret",
+		"--path", "/data/api/v1/old",
+		"--follow-redirects", "0",
+		"--include-headers",
+	})
+	if err != nil {
+		t.Fatalf("call failed: %v", err)
+	}
+	if !strings.HasPrefix(out.String(), "HTTP 301\n") || !strings.Contains(out.String(), "Location: /data/api/v1/new") {
+		t.Fatalf("unexpected output: %q", out.String())
+	}
+}
+
+func TestCallFollowRedirectsIncludeHeadersChain(t *testing.T) {
+	t.Parallel()
+
+	var out bytes.Buffer
+	c := newAdminWrapperTestCLI(redirectTestClient())
+	c.Out = &out
+	err := c.Execute([]string{
+		"call",
+		"--gateway-url", mockGatewayURL,
+		"--api-key", "secret",
+		"--path", "/data/api/v1/old",
+		"--include-headers",
+	})
+	if err != nil {
+		t.Fatalf("call failed: %v", err)
+	}
+	want := "redirect\t301\t" + mockGatewayURL + "/data/api/v1/old -> " + mockGatewayURL + "/data/api/v1/new\ttokenResent=true\nHTTP 200\n"
+	if !strings.HasPrefix(out.String(), want) {
+		t.Fatalf("unexpected output: %q", out.String())
+	}
+}
+
+func TestCallFollowRedirectsValidation(t *testing.T) {
+	t.Parallel()
+
+	c := newAdminWrapperTestCLI(redirectTestClient())
+	requireUsageExitCode(t, c.Execute([]string{
+		"call", "--gateway-url", mockGatewayURL, "--api-key", "secret",
+		"--path", "/data/api/v1/old", "--follow-redirects", "-1",
+	}))
+	requireUsageExitCode(t, c.Execute([]string{
+		"call", "--gateway-url", mockGatewayURL, "--api-key", "secret",
+		"--batch", "-", "--follow-redirects", "2",
+	}))
+}
@@ -0,0 +1,118 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/alex-mccollum/igw-cli/internal/gateway"
+	"github.com/alex-mccollum/igw-cli/internal/igwerr"
+)
+
+// callLatencyStats aggregates per-request latencies for `call --repeat`.
+// Percentiles use the nearest-rank method over all samples.
+type callLatencyStats struct {
+	Count  int     `json:"count"`
+	Failed int     `json:"failed"`
+	MinMs  float64 `json:"minMs"`
+	AvgMs  float64 `json:"avgMs"`
+	MaxMs  float64 `json:"maxMs"`
+	P50Ms  float64 `json:"p50Ms"`
+	P90Ms  float64 `json:"p90Ms"`
+	P95Ms  float64 `json:"p95Ms"`
+	P99Ms  float64 `json:"p99Ms"`
+}
+
+func (c *CLI) clock() time.Time {
+	if c.now != nil {
+		return c.now()
+	}
+	return time.Now()
+}
+
+// repeatCall issues the same request count times in sequence and returns the
+// last response. Every attempt runs even when some fail so the latency sample
+// stays complete; the first failure is returned after the loop. Usage errors
+// stop immediately because they would repeat identically.
+func (c *CLI) repeatCall(client *gateway.Client, input callExecutionInput, count int) (*gateway.CallResponse, callLatencyStats, error) {
+	samples := make([]time.Duration, 0, count)
+	failed := 0
+	var lastResp *gateway.CallResponse
+	var firstErr error
+
+	for i := 0; i < count; i++ {
+		started := c.clock()
+		resp, _, _, err := executeCallCore(client, input)
+		samples = append(samples, c.clock().Sub(started))
+
+		if err != nil {
+			var usageErr *igwerr.UsageError
+			if errors.As(err, &usageErr) {
+				return nil, callLatencyStats{}, err
+			}
+			failed++
+			if firstErr == nil {
+				firstErr = err
+			}
+			continue
+		}
+		lastResp = resp
+	}
+
+	return lastResp, buildLatencyStats(samples, failed), firstErr
+}
+
+func buildLatencyStats(samples []time.Duration, failed int) callLatencyStats {
+	stats := callLatencyStats{Count: len(samples), Failed: failed}
+	if len(samples) == 0 {
+		return stats
+	}
+
+	sorted := append([]time.Duration(nil), samples...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+
+	var total time.Duration
+	for _, sample := range sorted {
+		total += sample
+	}
+
+	stats.MinMs = durationMs(sorted[0])
+	stats.MaxMs = durationMs(sorted[len(sorted)-1])
+	stats.AvgMs = durationMs(total / time.Duration(len(sorted)))
+	stats.P50Ms = durationMs(nearestRank(sorted, 50))
+	stats.P90Ms = durationMs(nearestRank(sorted, 90))
+	stats.P95Ms = durationMs(nearestRank(sorted, 95))
+	stats.P99Ms = durationMs(nearestRank(sorted, 99))
+	return stats
+}
+
+// nearestRank returns the smallest sample such that at least p percent of
+// samples are less than or equal to it. sorted must be ascending and non-empty.
+func nearestRank(sorted []time.Duration, p float64) time.Duration {
+	rank := int(math.Ceil(p / 100 * float64(len(sorted))))
+	if rank < 1 {
+		rank = 1
+	}
+	if rank > len(sorted) {
+		rank = len(sorted)
+	}
+	return sorted[rank-1]
+}
+
+func durationMs(d time.Duration) float64 {
+	return float64(d.Microseconds()) / 1000
+}
+
+func printLatencySummary(w io.Writer, stats callLatencyStats) {
+	if w == nil {
+		return
+	}
+	fmt.Fprintf(
+		w,
+		"latency\tcount=%d\tfailed=%d\tmin=%gms\tavg=%gms\tp50=%gms\tp90=%gms\tp95=%gms\tp99=%gms\tmax=%gms\n",
+		stats.Count, stats.Failed, stats.MinMs, stats.AvgMs, stats.P50Ms, stats.P90Ms, stats.P95Ms, stats.P99Ms, stats.MaxMs,
+	)
+}
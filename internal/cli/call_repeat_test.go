@@ -0,0 +1,112 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/alex-mccollum/igw-cli/internal/config"
+)
+
+// fakeRepeatClock returns a clock whose consecutive start/end reads are
+// separated by the given latencies, in order.
+func fakeRepeatClock(latencies []time.Duration) func() time.Time {
+	base := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	reads := 0
+	return func() time.Time {
+		sample := reads / 2
+		isEnd := reads%2 == 1
+		reads++
+		at := base.Add(time.Duration(sample) * time.Second)
+		if isEnd {
+			at = at.Add(latencies[sample])
+		}
+		return at
+	}
+}
+
+func TestCallRepeatReportsLatencyPercentiles(t *testing.T) {
+	t.Parallel()
+
+	// 20 samples of 1ms..20ms in shuffled order.
+	order := []int{7, 19, 3, 12, 1, 20, 15, 9, 4, 18, 11, 2, 16, 6, 13, 8, 17, 5, 14, 10}
+	latencies := make([]time.Duration, len(order))
+	for i, ms := range order {
+		latencies[i] = time.Duration(ms) * time.Millisecond
+	}
+
+	calls := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"ok":true}`))
+	}))
+	defer srv.Close()
+
+	newCLI := func(out *bytes.Buffer, errOut *bytes.Buffer) *CLI {
+		return &CLI{
+			In:     strings.NewReader(""),
+			Out:    out,
+			Err:    errOut,
+			Getenv: func(string) string { return "" },
+			ReadConfig: func() (config.File, error) {
+				return config.File{}, nil
+			},
+			HTTPClient: srv.Client(),
+			now:        fakeRepeatClock(latencies),
+		}
+	}
+	args := []string{
+		"call",
+		"--gateway-url", srv.URL,
+		"--api-key", "secret",
+		"--path", "/data/api/v1/gateway-info",
+		"--repeat", "20",
+	}
+
+	var out bytes.Buffer
+	if err := newCLI(&out, new(bytes.Buffer)).Execute(append(args, "--json")); err != nil {
+		t.Fatalf("repeat call failed: %v", err)
+	}
+	if calls != 20 {
+		t.Fatalf("expected 20 requests, got %d", calls)
+	}
+
+	var payload struct {
+		Stats struct {
+			Latency callLatencyStats `json:"latency"`
+		} `json:"stats"`
+	}
+	if err := json.Unmarshal(out.Bytes(), &payload); err != nil {
+		t.Fatalf("decode output: %v", err)
+	}
+	want := callLatencyStats{Count: 20, MinMs: 1, AvgMs: 10.5, MaxMs: 20, P50Ms: 10, P90Ms: 18, P95Ms: 19, P99Ms: 20}
+	if payload.Stats.Latency != want {
+		t.Fatalf("latency = %+v, want %+v", payload.Stats.Latency, want)
+	}
+
+	var errOut bytes.Buffer
+	if err := newCLI(new(bytes.Buffer), &errOut).Execute(args); err != nil {
+		t.Fatalf("repeat call failed: %v", err)
+	}
+	wantLine := "latency\tcount=20\tfailed=0\tmin=1ms\tavg=10.5ms\tp50=10ms\tp90=18ms\tp95=19ms\tp99=20ms\tmax=20ms\n"
+	if errOut.String() != wantLine {
+		t.Fatalf("unexpected text summary %q", errOut.String())
+	}
+}
+
+func TestNearestRankPercentile(t *testing.T) {
+	t.Parallel()
+
+	sorted := []time.Duration{15, 20, 35, 40, 50}
+	cases := map[float64]time.Duration{5: 15, 30: 20, 40: 20, 50: 35, 100: 50}
+	for p, want := range cases {
+		if got := nearestRank(sorted, p); got != want {
+			t.Fatalf("p%v = %v, want %v", p, got, want)
+		}
+	}
+}
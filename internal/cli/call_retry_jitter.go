@@ -0,0 +1,29 @@
+package cli
+
+import (
+	"math/rand"
+	"strconv"
+	"strings"
+
+	"github.com/alex-mccollum/igw-cli/internal/igwerr"
+)
+
+// parseRetryJitter validates --retry-jitter and returns a seeded source when
+// --retry-jitter-seed is set; nil keeps the default random source.
+func parseRetryJitter(fraction float64, seed string) (*rand.Rand, error) {
+	if fraction < 0 || fraction > 1 {
+		return nil, &igwerr.UsageError{Msg: "--retry-jitter must be between 0 and 1"}
+	}
+	seed = strings.TrimSpace(seed)
+	if seed == "" {
+		return nil, nil
+	}
+	if fraction == 0 {
+		return nil, &igwerr.UsageError{Msg: "--retry-jitter-seed requires --retry-jitter"}
+	}
+	value, err := strconv.ParseInt(seed, 10, 64)
+	if err != nil {
+		return nil, &igwerr.UsageError{Msg: "--retry-jitter-seed must be an integer"}
+	}
+	return rand.New(rand.NewSource(value)), nil
+}
@@ -0,0 +1,82 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/alex-mccollum/igw-cli/internal/config"
+)
+
+func runSessionBatch(t *testing.T, session bool) []callBatchItemResult {
+	t.Helper()
+
+	var requests atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if requests.Add(1) == 1 {
+			http.SetCookie(w, &http.Cookie{Name: "JSESSIONID", Value: "node-a", Path: "/"})
+			_, _ = w.Write([]byte(`{}`))
+			return
+		}
+		if cookie, err := r.Cookie("JSESSIONID"); err != nil || cookie.Value != "node-a" {
+			http.Error(w, "wrong node", http.StatusForbidden)
+			return
+		}
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer srv.Close()
+
+	var out bytes.Buffer
+	c := &CLI{
+		In:         strings.NewReader(`{"path":"/data/api/v1/gateway-info"}` + "\n" + `{"path":"/data/api/v1/gateway-info"}` + "\n"),
+		Out:        &out,
+		Err:        new(bytes.Buffer),
+		Getenv:     func(string) string { return "" },
+		ReadConfig: func() (config.File, error) { return config.File{}, nil },
+		HTTPClient: srv.Client(),
+	}
+	args := []string{"call", "--gateway-url", srv.URL, "--api-key", "secret", "--batch", "-", "--batch-output", "json"}
+	if session {
+		args = append(args, "--session")
+	}
+	_ = c.Execute(args)
+
+	var results []callBatchItemResult
+	if err := json.Unmarshal(out.Bytes(), &results); err != nil {
+		t.Fatalf("decode batch output: %v\n%s", err, out.String())
+	}
+	if len(results) != 2 {
+		t.Fatalf("expected 2 results, got %d", len(results))
+	}
+	return results
+}
+
+func TestCallBatchSessionReplaysCookies(t *testing.T) {
+	t.Parallel()
+
+	results := runSessionBatch(t, true)
+	for i, result := range results {
+		if !result.OK {
+			t.Fatalf("item %d failed: %s", i, result.Error)
+		}
+		if result.Stats == nil || result.Stats.SessionCookies == nil || *result.Stats.SessionCookies != 1 {
+			t.Fatalf("item %d: expected stats.sessionCookies=1, got %+v", i, result.Stats)
+		}
+	}
+}
+
+func TestCallBatchWithoutSessionLosesCookies(t *testing.T) {
+	t.Parallel()
+
+	results := runSessionBatch(t, false)
+	if !results[0].OK || results[1].OK {
+		t.Fatalf("expected only the first item to succeed: %+v", results)
+	}
+	if results[0].Stats != nil && results[0].Stats.SessionCookies != nil {
+		t.Fatalf("sessionCookies must be absent without --session")
+	}
+}
@@ -0,0 +1,171 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/alex-mccollum/igw-cli/internal/igwerr"
+)
+
+// callSSEOptions turns a text/event-stream response into NDJSON events.
+type callSSEOptions struct {
+	enabled bool
+}
+
+func (o callSSEOptions) validate(method string, jsonOutput bool, stream bool, grep bool, includeHeaders bool, bodyMatch bool) error {
+	if !o.enabled {
+		return nil
+	}
+	if jsonOutput {
+		return &igwerr.UsageError{Msg: "--sse is not supported with --json"}
+	}
+	if stream {
+		return &igwerr.UsageError{Msg: "use either --sse or --stream, not both"}
+	}
+	if grep {
+		return &igwerr.UsageError{Msg: "--grep is not supported with --sse"}
+	}
+	if includeHeaders {
+		return &igwerr.UsageError{Msg: "--include-headers is not supported with --sse"}
+	}
+	if bodyMatch {
+		return &igwerr.UsageError{Msg: "--retry-on-body-match is not supported with --sse"}
+	}
+	if method != "" && !strings.EqualFold(method, http.MethodGet) {
+		return &igwerr.UsageError{Msg: "--sse requires GET"}
+	}
+	return nil
+}
+
+// withAcceptHeader asks for an event stream unless the caller already set an
+// Accept header.
+func (o callSSEOptions) withAcceptHeader(headers stringList) stringList {
+	for _, header := range headers {
+		name, _, _ := strings.Cut(header, ":")
+		if strings.EqualFold(strings.TrimSpace(name), "Accept") {
+			return headers
+		}
+	}
+	return append(headers, "Accept: text/event-stream")
+}
+
+// sseStopped reports whether an --sse call ended because the user interrupted
+// it or --max-time elapsed, both of which are normal ways to close a stream.
+func sseStopped(ctx context.Context, maxTime time.Duration, err error) bool {
+	if ctx.Err() != nil {
+		return true
+	}
+	return maxTime > 0 && errors.Is(err, context.DeadlineExceeded)
+}
+
+type sseEvent struct {
+	Event string `json:"event"`
+	Data  string `json:"data"`
+	ID    string `json:"id,omitempty"`
+	Retry *int   `json:"retry,omitempty"`
+}
+
+// sseWriter parses a Server-Sent Events byte stream as it is written and
+// emits one compact JSON object per dispatched event. Field handling follows
+// the HTML event-stream rules: comments are ignored, data lines are joined
+// with newlines, and events without data are dropped.
+type sseWriter struct {
+	out     *recordWriter
+	pending []byte
+
+	eventType string
+	data      []string
+	hasData   bool
+	lastID    string
+	retry     *int
+}
+
+func newSSEWriter(out io.Writer, flush bool) *sseWriter {
+	return &sseWriter{out: newRecordWriter(out, flush)}
+}
+
+// flush writes any events still buffered when per-event flushing is off.
+func (w *sseWriter) flush() error {
+	return w.out.Flush()
+}
+
+func (w *sseWriter) Write(p []byte) (int, error) {
+	w.pending = append(w.pending, p...)
+	for {
+		idx := bytes.IndexAny(w.pending, "\r\n")
+		if idx < 0 {
+			break
+		}
+		// A trailing \r may be the first half of \r\n; wait for more input.
+		if w.pending[idx] == '\r' && idx == len(w.pending)-1 {
+			break
+		}
+		line := string(w.pending[:idx])
+		next := idx + 1
+		if w.pending[idx] == '\r' && w.pending[next] == '\n' {
+			next++
+		}
+		w.pending = w.pending[next:]
+		if err := w.processLine(line); err != nil {
+			return 0, err
+		}
+	}
+	return len(p), nil
+}
+
+func (w *sseWriter) processLine(line string) error {
+	if line == "" {
+		return w.dispatch()
+	}
+	if strings.HasPrefix(line, ":") {
+		return nil
+	}
+
+	field, value, _ := strings.Cut(line, ":")
+	value = strings.TrimPrefix(value, " ")
+	switch field {
+	case "event":
+		w.eventType = value
+	case "data":
+		w.data = append(w.data, value)
+		w.hasData = true
+	case "id":
+		if !strings.ContainsRune(value, 0) {
+			w.lastID = value
+		}
+	case "retry":
+		if ms, err := strconv.Atoi(value); err == nil && ms >= 0 && strings.Trim(value, "0123456789") == "" {
+			w.retry = &ms
+		}
+	}
+	return nil
+}
+
+func (w *sseWriter) dispatch() error {
+	defer func() {
+		w.eventType = ""
+		w.data = w.data[:0]
+		w.hasData = false
+		w.retry = nil
+	}()
+	if !w.hasData {
+		return nil
+	}
+
+	event := sseEvent{
+		Event: w.eventType,
+		Data:  strings.Join(w.data, "\n"),
+		ID:    w.lastID,
+		Retry: w.retry,
+	}
+	if event.Event == "" {
+		event.Event = "message"
+	}
+	return w.out.writeRecord(event)
+}
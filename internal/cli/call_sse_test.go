@@ -0,0 +1,148 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/alex-mccollum/igw-cli/internal/config"
+)
+
+func TestCallSSEEmitsNDJSONEvents(t *testing.T) {
+	t.Parallel()
+
+	var gotAccept string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotAccept = r.Header.Get("Accept")
+		w.Header().Set("Content-Type", "text/event-stream")
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(": keepalive\n\nevent: status\nid: 1\nretry: 500\ndata: {\"state\":\n"))
+		w.(http.Flusher).Flush()
+		_, _ = w.Write([]byte("data: \"RUNNING\"}\n\r\ndata: second\r\n\r\n"))
+	}))
+	defer srv.Close()
+
+	var out bytes.Buffer
+	c := &CLI{
+		In:     strings.NewReader(""),
+		Out:    &out,
+		Err:    new(bytes.Buffer),
+		Getenv: func(string) string { return "" },
+		ReadConfig: func() (config.File, error) {
+			return config.File{}, nil
+		},
+		HTTPClient: srv.Client(),
+	}
+
+	if err := c.Execute([]string{
+		"call",
+		"--gateway-url", srv.URL,
+		"--api-key", "secret",
+		"--path", "/data/api/v1/events",
+		"--sse",
+	}); err != nil {
+		t.Fatalf("sse call failed: %v", err)
+	}
+
+	if gotAccept != "text/event-stream" {
+		t.Fatalf("unexpected Accept header %q", gotAccept)
+	}
+
+	lines := strings.Split(strings.TrimSuffix(out.String(), "\n"), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("expected 2 NDJSON events, got %q", out.String())
+	}
+
+	var first, second sseEvent
+	if err := json.Unmarshal([]byte(lines[0]), &first); err != nil {
+		t.Fatalf("decode first event: %v", err)
+	}
+	if err := json.Unmarshal([]byte(lines[1]), &second); err != nil {
+		t.Fatalf("decode second event: %v", err)
+	}
+
+	if first.Event != "status" || first.Data != "{\"state\":\n\"RUNNING\"}" || first.ID != "1" {
+		t.Fatalf("unexpected first event %+v", first)
+	}
+	if first.Retry == nil || *first.Retry != 500 {
+		t.Fatalf("unexpected first event retry %v", first.Retry)
+	}
+	if second.Event != "message" || second.Data != "second" || second.ID != "1" || second.Retry != nil {
+		t.Fatalf("unexpected second event %+v", second)
+	}
+}
+
+func TestCallSSEMaxTimeEndsStreamCleanly(t *testing.T) {
+	t.Parallel()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte("data: tick\n\n"))
+		w.(http.Flusher).Flush()
+		select {
+		case <-r.Context().Done():
+		case <-time.After(5 * time.Second):
+		}
+	}))
+	defer srv.Close()
+
+	var out bytes.Buffer
+	c := &CLI{
+		In:     strings.NewReader(""),
+		Out:    &out,
+		Err:    new(bytes.Buffer),
+		Getenv: func(string) string { return "" },
+		ReadConfig: func() (config.File, error) {
+			return config.File{}, nil
+		},
+		HTTPClient: srv.Client(),
+	}
+
+	if err := c.Execute([]string{
+		"call",
+		"--gateway-url", srv.URL,
+		"--api-key", "secret",
+		"--path", "/data/api/v1/events",
+		"--sse",
+		"--max-time", "200ms",
+	}); err != nil {
+		t.Fatalf("expected clean exit at --max-time, got %v", err)
+	}
+	if out.String() != "{\"event\":\"message\",\"data\":\"tick\"}\n" {
+		t.Fatalf("unexpected output %q", out.String())
+	}
+}
+
+func TestCallSSERejectsIncompatibleFlags(t *testing.T) {
+	t.Parallel()
+
+	cases := [][]string{
+		{"--sse", "--json"},
+		{"--sse", "--stream"},
+		{"--sse", "--method", "POST", "--yes"},
+		{"--max-time", "5s"},
+	}
+	for _, extra := range cases {
+		c := &CLI{
+			In:     strings.NewReader(""),
+			Out:    new(bytes.Buffer),
+			Err:    new(bytes.Buffer),
+			Getenv: func(string) string { return "" },
+			ReadConfig: func() (config.File, error) {
+				return config.File{}, nil
+			},
+		}
+		args := append([]string{
+			"call",
+			"--gateway-url", "http://127.0.0.1:8088",
+			"--api-key", "secret",
+			"--path", "/data/api/v1/events",
+		}, extra...)
+		requireUsageExitCode(t, c.Execute(args))
+	}
+}
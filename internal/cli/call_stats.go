@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"strings"
 
 	"github.com/alex-mccollum/igw-cli/internal/gateway"
 )
@@ -23,6 +24,33 @@ type callStats struct {
 	HTTP      *gateway.CallTiming `json:"http,omitempty"`
 	Truncated bool                `json:"truncated,omitempty"`
 	RPC       *rpcQueueStats      `json:"rpc,omitempty"`
+	Latency   *callLatencyStats   `json:"latency,omitempty"`
+	// GatewayURL names the gateway that answered when several are configured.
+	GatewayURL string `json:"gatewayUrl,omitempty"`
+	// Hedge is set when --hedge-after was used.
+	Hedge *callHedgeStats `json:"hedge,omitempty"`
+	// Attempts counts retry-loop attempts, including the successful one.
+	Attempts int `json:"attempts,omitempty"`
+	// Retries, RetryWaitMs, and Statuses are set when the call retried:
+	// the retry count, total time spent waiting, and every attempt status.
+	Retries     int   `json:"retries,omitempty"`
+	RetryWaitMs int64 `json:"retryWaitMs,omitempty"`
+	Statuses    []int `json:"statuses,omitempty"`
+	// Pages and PageTimings are set by --paginate.
+	Pages       int              `json:"pages,omitempty"`
+	PageTimings []callPageTiming `json:"pageTimings,omitempty"`
+	// SessionCookies is how many cookies the --session jar holds.
+	SessionCookies *int `json:"sessionCookies,omitempty"`
+	// RateWaitMs is the time spent waiting on the --rate limiter.
+	RateWaitMs int64 `json:"rateWaitMs,omitempty"`
+	// Cached is set when the response came from the --cache-ttl cache.
+	Cached bool `json:"cached,omitempty"`
+}
+
+type callHedgeStats struct {
+	Triggered bool `json:"triggered"`
+	// Winner is "primary" or "hedge".
+	Winner string `json:"winner"`
 }
 
 func buildCallStats(resp *gateway.CallResponse, timingMs int64) callStats {
@@ -38,6 +66,21 @@ func buildCallStats(resp *gateway.CallResponse, timingMs int64) callStats {
 	stats.BodyBytes = resp.BodyBytes
 	stats.HTTP = resp.Timing
 	stats.Truncated = resp.Truncated
+	stats.GatewayURL = resp.GatewayURL
+	stats.Attempts = resp.Attempts
+	if resp.Attempts > 1 {
+		stats.Retries = resp.Attempts - 1
+		stats.RetryWaitMs = resp.RetryWait.Milliseconds()
+		stats.Statuses = resp.Statuses
+	}
+	stats.SessionCookies = resp.SessionCookies
+	if resp.Hedge != nil {
+		winner := "primary"
+		if resp.Hedge.Winner == gateway.HedgeSecondary {
+			winner = "hedge"
+		}
+		stats.Hedge = &callHedgeStats{Triggered: resp.Hedge.Triggered, Winner: winner}
+	}
 	return stats
 }
 
@@ -81,11 +124,43 @@ func printTimingSummary(w io.Writer, payload callStats) {
 	if w == nil {
 		return
 	}
+	if payload.Attempts > 0 {
+		fmt.Fprintf(w, "attempts\t%d\n", payload.Attempts)
+	}
+	if payload.Hedge != nil {
+		fmt.Fprintf(w, "hedge\ttriggered=%t\twinner=%s\n", payload.Hedge.Triggered, payload.Hedge.Winner)
+	}
+	if payload.Cached {
+		fmt.Fprintf(w, "cached\tbodyBytes=%d\n", payload.BodyBytes)
+		return
+	}
 	if payload.HTTP != nil {
-		fmt.Fprintf(w, "timing\thttp=%v\tbodyBytes=%v\ttruncated=%t\n", payload.HTTP, payload.BodyBytes, payload.Truncated)
+		fmt.Fprintf(w, "timing\t%s\tbodyBytes=%d\ttruncated=%t\n", formatHTTPTiming(payload.HTTP), payload.BodyBytes, payload.Truncated)
 		return
 	}
 	if payload.Truncated || payload.BodyBytes > 0 {
 		fmt.Fprintf(w, "timing\tbodyBytes=%v\ttruncated=%t\n", payload.BodyBytes, payload.Truncated)
 	}
 }
+
+// formatHTTPTiming renders the phases of timing that occurred as
+// tab-separated key=value pairs named like the JSON fields.
+func formatHTTPTiming(timing *gateway.CallTiming) string {
+	parts := []string{fmt.Sprintf("totalMs=%d", timing.TotalMs)}
+	for _, phase := range []struct {
+		name string
+		ms   *int64
+	}{
+		{"dnsMs", timing.DNSMs},
+		{"connectMs", timing.ConnectMs},
+		{"tlsHandshakeMs", timing.TLSHandshakeMs},
+		{"ttfbMs", timing.TTFBMs},
+		{"bodyReadMs", timing.BodyReadMs},
+	} {
+		if phase.ms != nil {
+			parts = append(parts, fmt.Sprintf("%s=%d", phase.name, *phase.ms))
+		}
+	}
+	parts = append(parts, fmt.Sprintf("connReused=%t", timing.ConnReused))
+	return strings.Join(parts, "\t")
+}
@@ -0,0 +1,23 @@
+package cli
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/alex-mccollum/igw-cli/internal/gateway"
+)
+
+func TestPrintTimingSummaryBreakdown(t *testing.T) {
+	t.Parallel()
+
+	ms := func(v int64) *int64 { return &v }
+	var out bytes.Buffer
+	printTimingSummary(&out, callStats{
+		BodyBytes: 42,
+		HTTP:      &gateway.CallTiming{TotalMs: 120, DNSMs: ms(3), ConnectMs: ms(0), TTFBMs: ms(90), BodyReadMs: ms(20)},
+	})
+	want := "timing\ttotalMs=120\tdnsMs=3\tconnectMs=0\tttfbMs=90\tbodyReadMs=20\tconnReused=false\tbodyBytes=42\ttruncated=false\n"
+	if out.String() != want {
+		t.Fatalf("unexpected summary:\n got %q\nwant %q", out.String(), want)
+	}
+}
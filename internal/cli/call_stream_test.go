@@ -2,6 +2,8 @@ package cli
 
 import (
 	"bytes"
+	"compress/gzip"
+	"encoding/json"
 	"net/http"
 	"net/http/httptest"
 	"os"
@@ -55,3 +57,65 @@ func TestCallStreamRespectsMaxBodyBytes(t *testing.T) {
 		t.Fatalf("unexpected streamed body %q", string(b))
 	}
 }
+
+func TestCallDumpRawKeepsCompressedBytes(t *testing.T) {
+	t.Parallel()
+
+	var compressed bytes.Buffer
+	zw := gzip.NewWriter(&compressed)
+	_, _ = zw.Write([]byte(`{"name":"gw"}`))
+	if err := zw.Close(); err != nil {
+		t.Fatalf("gzip body: %v", err)
+	}
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !strings.Contains(r.Header.Get("Accept-Encoding"), "gzip") {
+			t.Errorf("expected gzip Accept-Encoding, got %q", r.Header.Get("Accept-Encoding"))
+		}
+		w.Header().Set("Content-Encoding", "gzip")
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write(compressed.Bytes())
+	}))
+	defer srv.Close()
+
+	dumpPath := filepath.Join(t.TempDir(), "raw.bin")
+	var out bytes.Buffer
+	c := &CLI{
+		In:     strings.NewReader(""),
+		Out:    &out,
+		Err:    new(bytes.Buffer),
+		Getenv: func(string) string { return "" },
+		ReadConfig: func() (config.File, error) {
+			return config.File{}, nil
+		},
+		HTTPClient: srv.Client(),
+	}
+
+	if err := c.Execute([]string{
+		"call",
+		"--gateway-url", srv.URL,
+		"--api-key", "secret",
+		"--path", "/data/api/v1/gateway-info",
+		"--dump-raw", dumpPath,
+		"--json",
+	}); err != nil {
+		t.Fatalf("call failed: %v", err)
+	}
+
+	dumped, err := os.ReadFile(dumpPath)
+	if err != nil {
+		t.Fatalf("read dump file: %v", err)
+	}
+	if !bytes.Equal(dumped, compressed.Bytes()) {
+		t.Fatalf("dump file does not hold the compressed wire bytes: %q", dumped)
+	}
+
+	var payload callJSONEnvelope
+	if err := json.Unmarshal(out.Bytes(), &payload); err != nil {
+		t.Fatalf("decode envelope: %v\n%s", err, out.String())
+	}
+	if payload.Response.Body != `{"name":"gw"}` {
+		t.Fatalf("expected decoded body, got %q", payload.Response.Body)
+	}
+}
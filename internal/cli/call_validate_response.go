@@ -0,0 +1,48 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/alex-mccollum/igw-cli/internal/apidocs"
+	"github.com/alex-mccollum/igw-cli/internal/gateway"
+	"github.com/alex-mccollum/igw-cli/internal/igwerr"
+)
+
+const responseValidationHint = "response body does not match the operation's response schema (--strict-validate)"
+
+// validateCallResponse checks resp against the schema op documents for its
+// status. ok is false when there is nothing to validate against.
+func validateCallResponse(op *apidocs.Operation, resp *gateway.CallResponse) (violations []apidocs.SchemaViolation, ok bool) {
+	schema, ok := op.ResponseSchema(resp.StatusCode)
+	if !ok || resp.Truncated {
+		return nil, false
+	}
+	violations, err := apidocs.ValidateJSON(schema, resp.Body)
+	if err != nil {
+		return []apidocs.SchemaViolation{{Path: "$", Message: "response body is not JSON"}}, true
+	}
+	return violations, true
+}
+
+func printResponseValidation(w io.Writer, op *apidocs.Operation, resp *gateway.CallResponse, violations []apidocs.SchemaViolation, validated bool) {
+	if !validated {
+		reason := fmt.Sprintf("operationId %q documents no JSON schema for status %d", op.OperationID, resp.StatusCode)
+		if resp.Truncated {
+			reason = "the response body was truncated"
+		}
+		fmt.Fprintf(w, "note: response not validated: %s\n", reason)
+		return
+	}
+	for _, violation := range violations {
+		fmt.Fprintf(w, "warning: response schema: %s: %s\n", violation.Path, violation.Message)
+	}
+}
+
+func responseValidationError(resp *gateway.CallResponse) error {
+	return &igwerr.StatusError{
+		StatusCode: resp.StatusCode,
+		Body:       string(resp.Body),
+		Hint:       responseValidationHint,
+	}
+}
@@ -0,0 +1,94 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/alex-mccollum/igw-cli/internal/apidocs"
+)
+
+const validateResponseSpec = `{
+  "openapi": "3.0.0",
+  "paths": {
+    "/data/api/v1/gateway-info": {
+      "get": {
+        "operationId": "gatewayInfo",
+        "responses": {
+          "200": {
+            "description": "ok",
+            "content": {"application/json": {"schema": {"$ref": "#/components/schemas/GatewayInfo"}}}
+          }
+        }
+      }
+    }
+  },
+  "components": {
+    "schemas": {
+      "GatewayInfo": {
+        "type": "object",
+        "required": ["name", "version"],
+        "properties": {"name": {"type": "string"}, "version": {"type": "string"}}
+      }
+    }
+  }
+}`
+
+func TestCallValidateResponseWarnsAndStrictFails(t *testing.T) {
+	t.Parallel()
+
+	specPath := writeCallOpSpec(t, validateResponseSpec)
+	args := []string{"call", "--gateway-url", mockGatewayURL, "--api-key", "secret", "--op", "gatewayInfo", "--spec-file", specPath}
+
+	errOut := new(bytes.Buffer)
+	c := newAdminWrapperTestCLI(newStaticHTTPClient(http.StatusOK, `{"name":7}`))
+	c.Err = errOut
+	if err := c.Execute(append(args, "--validate-response")); err != nil {
+		t.Fatalf("validate-response should only warn: %v", err)
+	}
+	for _, want := range []string{
+		`warning: response schema: $: missing required property "version"`,
+		"warning: response schema: $.name: expected string, got integer",
+	} {
+		if !strings.Contains(errOut.String(), want) {
+			t.Fatalf("expected %q on stderr, got %q", want, errOut.String())
+		}
+	}
+
+	out := new(bytes.Buffer)
+	c = newAdminWrapperTestCLI(newStaticHTTPClient(http.StatusOK, `{"name":7}`))
+	c.Out = out
+	err := c.Execute(append(args, "--strict-validate", "--json"))
+	if err == nil {
+		t.Fatalf("expected --strict-validate to fail")
+	}
+	var payload struct {
+		OK         bool                      `json:"ok"`
+		Validation []apidocs.SchemaViolation `json:"validation"`
+	}
+	if jsonErr := json.Unmarshal(out.Bytes(), &payload); jsonErr != nil {
+		t.Fatalf("parse: %v\n%s", jsonErr, out.String())
+	}
+	if payload.OK || len(payload.Validation) != 2 {
+		t.Fatalf("unexpected envelope %+v", payload)
+	}
+
+	errOut = new(bytes.Buffer)
+	c = newAdminWrapperTestCLI(newStaticHTTPClient(http.StatusOK, `{"name":"gw","version":"8.1.40"}`))
+	c.Err = errOut
+	if err := c.Execute(append(args, "--strict-validate")); err != nil {
+		t.Fatalf("valid body: %v", err)
+	}
+	if strings.Contains(errOut.String(), "warning") {
+		t.Fatalf("unexpected warnings %q", errOut.String())
+	}
+}
+
+func TestCallValidateResponseRequiresOp(t *testing.T) {
+	t.Parallel()
+
+	c := newAdminWrapperTestCLI(newStaticHTTPClient(http.StatusOK, `{}`))
+	requireUsageExitCode(t, c.Execute([]string{"call", "--gateway-url", mockGatewayURL, "--api-key", "secret", "--path", "/data/api/v1/gateway-info", "--validate-response"}))
+}
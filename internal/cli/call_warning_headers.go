@@ -0,0 +1,49 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/alex-mccollum/igw-cli/internal/gateway"
+	"github.com/alex-mccollum/igw-cli/internal/igwerr"
+)
+
+const warningHeaderHint = "response carried a Warning, Deprecation, or Sunset header (--fail-on-warning)"
+
+// warningHeaderNames are the response headers that signal an endpoint is
+// degraded, deprecated, or scheduled for removal.
+var warningHeaderNames = []string{"Warning", "Deprecation", "Sunset"}
+
+// callResponseWarning is one warning-style header value from a response.
+type callResponseWarning struct {
+	Header string `json:"header"`
+	Value  string `json:"value"`
+}
+
+// responseWarnings collects warning-style headers in a fixed order so output
+// is stable across runs.
+func responseWarnings(headers http.Header) []callResponseWarning {
+	var warnings []callResponseWarning
+	for _, name := range warningHeaderNames {
+		for _, value := range headers.Values(name) {
+			warnings = append(warnings, callResponseWarning{Header: name, Value: strings.TrimSpace(value)})
+		}
+	}
+	return warnings
+}
+
+func printResponseWarnings(w io.Writer, warnings []callResponseWarning) {
+	for _, warning := range warnings {
+		fmt.Fprintf(w, "notice: response header %s: %s\n", warning.Header, warning.Value)
+	}
+}
+
+func warningHeaderError(resp *gateway.CallResponse) error {
+	return &igwerr.StatusError{
+		StatusCode: resp.StatusCode,
+		Body:       string(resp.Body),
+		Hint:       warningHeaderHint,
+	}
+}
@@ -0,0 +1,125 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/alex-mccollum/igw-cli/internal/config"
+)
+
+func newDeprecatedEndpointCLI() (*CLI, *bytes.Buffer, *bytes.Buffer) {
+	var out bytes.Buffer
+	var errOut bytes.Buffer
+	c := &CLI{
+		In:     strings.NewReader(""),
+		Out:    &out,
+		Err:    &errOut,
+		Getenv: func(string) string { return "" },
+		ReadConfig: func() (config.File, error) {
+			return config.File{}, nil
+		},
+		HTTPClient: newMockHTTPClient(func(r *http.Request) (*http.Response, error) {
+			return mockHTTPResponse(http.StatusOK, `{"ok":true}`, http.Header{
+				"Deprecation": []string{"true"},
+				"Sunset":      []string{"Wed, 31 Dec 2026 23:59:59 GMT"},
+			}), nil
+		}),
+	}
+	return c, &out, &errOut
+}
+
+func TestCallWarningHeadersPrintNotice(t *testing.T) {
+	t.Parallel()
+
+	c, out, errOut := newDeprecatedEndpointCLI()
+	err := c.Execute([]string{
+		"call",
+		"--gateway-url", mockGatewayURL,
+		"--api-key", "secret",
+		"--path", "/data/api/v1/gateway-info",
+	})
+	if err != nil {
+		t.Fatalf("call failed: %v", err)
+	}
+	if got := out.String(); got != `{"ok":true}` {
+		t.Fatalf("unexpected body %q", got)
+	}
+	if !strings.Contains(errOut.String(), "notice: response header Deprecation: true") {
+		t.Fatalf("expected deprecation notice, got %q", errOut.String())
+	}
+	if !strings.Contains(errOut.String(), "notice: response header Sunset: Wed, 31 Dec 2026 23:59:59 GMT") {
+		t.Fatalf("expected sunset notice, got %q", errOut.String())
+	}
+}
+
+func TestCallWarningHeadersInJSON(t *testing.T) {
+	t.Parallel()
+
+	c, out, errOut := newDeprecatedEndpointCLI()
+	err := c.Execute([]string{
+		"call",
+		"--gateway-url", mockGatewayURL,
+		"--api-key", "secret",
+		"--path", "/data/api/v1/gateway-info",
+		"--json",
+	})
+	if err != nil {
+		t.Fatalf("call failed: %v", err)
+	}
+	var payload callJSONEnvelope
+	if err := json.Unmarshal(out.Bytes(), &payload); err != nil {
+		t.Fatalf("decode json: %v", err)
+	}
+	if !payload.OK || len(payload.Warnings) != 2 {
+		t.Fatalf("unexpected payload: %+v", payload)
+	}
+	if payload.Warnings[0] != (callResponseWarning{Header: "Deprecation", Value: "true"}) {
+		t.Fatalf("unexpected first warning: %+v", payload.Warnings[0])
+	}
+	if !strings.Contains(errOut.String(), "notice: response header Deprecation: true") {
+		t.Fatalf("expected notice on stderr in json mode, got %q", errOut.String())
+	}
+}
+
+func TestCallFailOnWarning(t *testing.T) {
+	t.Parallel()
+
+	c, out, _ := newDeprecatedEndpointCLI()
+	err := c.Execute([]string{
+		"call",
+		"--gateway-url", mockGatewayURL,
+		"--api-key", "secret",
+		"--path", "/data/api/v1/gateway-info",
+		"--fail-on-warning",
+		"--json",
+	})
+	if err == nil {
+		t.Fatalf("expected --fail-on-warning to fail the call")
+	}
+	var payload callJSONEnvelope
+	if err := json.Unmarshal(out.Bytes(), &payload); err != nil {
+		t.Fatalf("decode json: %v", err)
+	}
+	if payload.OK || payload.Code != 7 || !strings.Contains(payload.Error, "--fail-on-warning") {
+		t.Fatalf("unexpected payload: %+v", payload)
+	}
+}
+
+func TestResponseWarningsIgnoresOtherHeaders(t *testing.T) {
+	t.Parallel()
+
+	headers := http.Header{}
+	headers.Set("Content-Type", "application/json")
+	if warnings := responseWarnings(headers); warnings != nil {
+		t.Fatalf("expected no warnings, got %+v", warnings)
+	}
+
+	headers.Add("Warning", `299 - "legacy endpoint"`)
+	warnings := responseWarnings(headers)
+	if len(warnings) != 1 || warnings[0].Header != "Warning" {
+		t.Fatalf("unexpected warnings: %+v", warnings)
+	}
+}
@@ -7,7 +7,9 @@ import (
 	"net/http"
 	"os"
 	"sort"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/alex-mccollum/igw-cli/internal/buildinfo"
 	"github.com/alex-mccollum/igw-cli/internal/config"
@@ -23,8 +25,13 @@ type CLI struct {
 	ReadConfig      func() (config.File, error)
 	WriteConfig     func(config.File) error
 	DetectWSLHostIP func() (string, string, error)
+	DetectWSL       func() bool
 	HTTPClient      *http.Client
+	HistoryPath     func() (string, error)
+	CacheDir        func() (string, error)
 	runtime         *runtimeState
+	now             func() time.Time
+	quiet           bool
 }
 
 func New() *CLI {
@@ -36,6 +43,9 @@ func New() *CLI {
 		ReadConfig:      config.Read,
 		WriteConfig:     config.Write,
 		DetectWSLHostIP: wsl.DetectWindowsHostIP,
+		DetectWSL:       wsl.IsWSL,
+		HistoryPath:     config.HistoryPath,
+		CacheDir:        config.CacheDir,
 		runtime:         newRuntimeState(),
 	}
 }
@@ -49,7 +59,7 @@ type rootCommand struct {
 
 var rootCommandSummaries = map[string]string{
 	"api":         "Query local OpenAPI documentation",
-	"backup":      "Gateway backup export/restore",
+	"backup":      "Gateway backup export/restore/inspect",
 	"call":        "Execute generic Ignition Gateway API request",
 	"completion":  "Output shell completion script",
 	"config":      "Manage local configuration",
@@ -57,72 +67,88 @@ var rootCommandSummaries = map[string]string{
 	"doctor":      "Check connectivity and auth",
 	"exit-codes":  "Print stable machine exit code contract",
 	"gateway":     "Convenience gateway commands",
+	"history":     "List, show, and replay recorded calls",
 	"logs":        "Gateway log helpers",
+	"modules":     "Module list/install/uninstall/restart helpers",
+	"projects":    "Project list/export/import/delete helpers",
 	"restart":     "Restart task/gateway helpers",
 	"rpc":         "Persistent NDJSON RPC mode for machine callers",
 	"scan":        "Convenience scan commands",
 	"schema":      "Print machine-readable CLI command schema",
-	"tags":        "Tag import/export helpers",
+	"tags":        "Tag browse, read/write, and import/export helpers",
 	"wait":        "Wait for operational readiness conditions",
 	"version":     "Print build version information",
 }
 
 var rootCommands = []rootCommand{
-	{Name: "api", Summary: rootCommandSummaries["api"], Subcommands: []string{"list", "show", "search", "tags", "stats", "capability", "sync", "refresh"}, Run: (*CLI).runAPI},
-	{Name: "backup", Summary: rootCommandSummaries["backup"], Subcommands: []string{"export", "restore"}, Run: (*CLI).runBackup},
+	{Name: "api", Summary: rootCommandSummaries["api"], Subcommands: []string{"list", "show", "resolve", "search", "tags", "stats", "capability", "diff", "sync", "refresh"}, Run: (*CLI).runAPI},
+	{Name: "backup", Summary: rootCommandSummaries["backup"], Subcommands: []string{"export", "restore", "inspect"}, Run: (*CLI).runBackup},
 	{Name: "call", Summary: rootCommandSummaries["call"], Run: (*CLI).runCall},
 	{Name: "completion", Summary: rootCommandSummaries["completion"], Run: (*CLI).runCompletion},
-	{Name: "config", Summary: rootCommandSummaries["config"], Subcommands: []string{"set", "show", "profile"}, Run: (*CLI).runConfig},
+	{Name: "config", Summary: rootCommandSummaries["config"], Subcommands: []string{"set", "show", "profile", "encrypt", "decrypt"}, Run: (*CLI).runConfig},
 	{Name: "diagnostics", Summary: rootCommandSummaries["diagnostics"], Subcommands: []string{"bundle"}, Run: (*CLI).runDiagnostics},
 	{Name: "doctor", Summary: rootCommandSummaries["doctor"], Run: (*CLI).runDoctor},
 	{Name: "exit-codes", Summary: rootCommandSummaries["exit-codes"], Run: (*CLI).runExitCodes},
-	{Name: "gateway", Summary: rootCommandSummaries["gateway"], Subcommands: []string{"info"}, Run: (*CLI).runGateway},
-	{Name: "logs", Summary: rootCommandSummaries["logs"], Subcommands: []string{"list", "download", "loggers", "logger", "level-reset"}, Run: (*CLI).runLogs},
+	{Name: "gateway", Summary: rootCommandSummaries["gateway"], Subcommands: []string{"info", "status"}, Run: (*CLI).runGateway},
+	{Name: "history", Summary: rootCommandSummaries["history"], Subcommands: []string{"list", "show", "replay", "clear"}, Run: (*CLI).runHistory},
+	{Name: "logs", Summary: rootCommandSummaries["logs"], Subcommands: []string{"list", "tail", "download", "loggers", "logger", "level-reset"}, Run: (*CLI).runLogs},
+	{Name: "modules", Summary: rootCommandSummaries["modules"], Subcommands: []string{"list", "install", "uninstall", "restart"}, Run: (*CLI).runModules},
+	{Name: "projects", Summary: rootCommandSummaries["projects"], Subcommands: []string{"list", "export", "import", "delete"}, Run: (*CLI).runProjects},
 	{Name: "restart", Summary: rootCommandSummaries["restart"], Subcommands: []string{"tasks", "gateway"}, Run: (*CLI).runRestart},
 	{Name: "rpc", Summary: rootCommandSummaries["rpc"], Run: (*CLI).runRPC},
 	{Name: "scan", Summary: rootCommandSummaries["scan"], Subcommands: scanSubcommands, Run: (*CLI).runScan},
 	{Name: "schema", Summary: rootCommandSummaries["schema"], Run: (*CLI).runSchema},
-	{Name: "tags", Summary: rootCommandSummaries["tags"], Subcommands: []string{"export", "import"}, Run: (*CLI).runTags},
-	{Name: "wait", Summary: rootCommandSummaries["wait"], Subcommands: []string{"gateway", "diagnostics-bundle", "restart-tasks"}, Run: (*CLI).runWait},
+	{Name: "tags", Summary: rootCommandSummaries["tags"], Subcommands: []string{"browse", "read", "write", "export", "import"}, Run: (*CLI).runTags},
+	{Name: "wait", Summary: rootCommandSummaries["wait"], Subcommands: []string{"gateway", "version", "diagnostics-bundle", "restart-tasks"}, Run: (*CLI).runWait},
 	{Name: "version", Summary: rootCommandSummaries["version"], Run: (*CLI).runVersion},
 }
 
 var completionRootCommands = []string{
-	"api", "backup", "call", "completion", "config", "diagnostics", "doctor", "exit-codes", "gateway", "help", "logs", "restart", "rpc", "scan", "schema", "tags", "wait", "version",
+	"api", "backup", "call", "completion", "config", "diagnostics", "doctor", "exit-codes", "gateway", "help", "history", "logs", "modules", "projects", "restart", "rpc", "scan", "schema", "tags", "wait", "version",
 }
 
 var completionSubcommands = map[string][]string{
-	"api":         {"list", "show", "search", "tags", "stats", "capability", "sync", "refresh"},
-	"backup":      {"export", "restore"},
-	"config":      {"set", "show", "profile"},
+	"api":         {"list", "show", "resolve", "search", "tags", "stats", "capability", "diff", "sync", "refresh"},
+	"backup":      {"export", "restore", "inspect"},
+	"config":      {"set", "show", "profile", "encrypt", "decrypt"},
 	"diagnostics": {"bundle"},
-	"gateway":     {"info"},
-	"logs":        {"list", "download", "loggers", "logger", "level-reset"},
+	"gateway":     {"info", "status"},
+	"history":     {"list", "show", "replay", "clear"},
+	"logs":        {"list", "tail", "download", "loggers", "logger", "level-reset"},
+	"modules":     {"list", "install", "uninstall", "restart"},
+	"projects":    {"list", "export", "import", "delete"},
 	"restart":     {"tasks", "gateway"},
 	"scan":        scanSubcommands,
-	"tags":        {"export", "import"},
-	"wait":        {"gateway", "diagnostics-bundle", "restart-tasks"},
+	"tags":        {"browse", "read", "write", "export", "import"},
+	"wait":        {"gateway", "version", "diagnostics-bundle", "restart-tasks"},
 }
 
 var nestedCompletionCommands = map[string][]string{
-	"config profile":     {"add", "use", "list"},
+	"config profile":     {"add", "use", "list", "names", "remove", "rename"},
 	"diagnostics bundle": {"generate", "status", "download"},
 	"logs logger":        {"set"},
 }
 
 var completionFlags = []string{
-	"--profile", "--gateway-url", "--api-key", "--api-key-stdin", "--timeout", "--json", "--timing", "--json-stats", "--include-headers",
-	"--spec-file", "--op", "--method", "--path", "--query", "--header", "--body", "--content-type", "--yes",
-	"--dry-run", "--retry", "--retry-backoff", "--out", "--batch", "--batch-output", "--parallel", "--select", "--raw", "--compact", "--in", "--provider", "--type", "--collision-policy", "--prefix-depth",
-	"--interval", "--wait-timeout", "--openapi-path",
-	"--check-write",
-	"--workers", "--queue-size",
+	"--profile", "--gateway-url", "--gateway-strategy", "--api-key", "--api-key-stdin", "--api-key-env", "--api-key-command", "--token-env", "--token-ref", "--sign-key", "--sign-header", "--timeout", "--json", "--timing", "--json-stats", "--include-headers",
+	"--spec-file", "--write-spec-to", "--strict-spec-version", "--validate-response", "--strict-validate", "--op", "--param", "--old", "--new", "--fail-on-change", "--op-method", "--op-fuzzy", "--explain-op", "--no-deprecation-warnings", "--fail-on-deprecated", "--method", "--path", "--query", "--header", "--body", "--body-jq", "--body-merge", "--form", "--form-file", "--merge-arrays", "--body-base64", "--apply-patch", "--fetch-path", "--expand", "--expand-key", "--use-example-body", "--content-type", "--no-default-content-type", "--yes",
+	"--dry-run", "--output", "--columns", "--output-template", "--retry", "--retry-backoff", "--retry-after-max", "--retry-jitter", "--retry-jitter-seed", "--retry-max-wait", "--retry-on", "--hedge-after", "--raw-path", "--accept-status", "--expect-status", "--follow-redirects", "--success-out", "--failure-out", "--attempts-out", "--har", "--limit", "--history", "--session", "--curl", "--all-profiles", "--profiles", "--allow-fanout-mutations", "--verbose", "--har-bodies", "--pager", "--no-pager", "--ignore-retry-after", "--retry-on-body-match", "--fail-on-body-match", "--fail-on-warning", "--out", "--dump-raw", "--sse", "--max-time", "--repeat", "--paginate", "--page-param", "--page-size-param", "--page-size", "--max-pages", "--summary", "--progress", "--pretty-xml", "--preserve-numbers", "--flatten", "--flatten-sep", "--grep", "--grep-regex", "--grep-invert", "--grep-count", "--grep-exit", "--batch", "--batch-output", "--batch-delimiter", "--output-buffer-flush", "--batch-csv", "--csv-map", "--id-column", "--batch-out", "--batch-out-max-size", "--header-from-response", "--parallel", "--adaptive-rate", "--adaptive-rate-header", "--fail-fast-after", "--rate", "--passphrase-stdin", "--key-file", "--errors-since", "--error-level", "--proxy", "--no-proxy", "--proxy-url", "--ca-cert", "--client-cert", "--client-key", "--insecure-skip-verify", "--paths", "--value", "--select", "--expr", "--expr-body", "--raw", "--compact", "--in", "--provider", "--type", "--collision-policy", "--prefix-depth",
+	"--interval", "--after-restart", "--require-downtime", "--expect", "--version-field", "--min-level", "--logger", "--since", "--follow", "--watch", "--watch-diff", "--count", "--restart", "--uptime-field", "--wait", "--wait-timeout", "--deadline", "--deadline-at", "--openapi-path", "--check", "--pin",
+	"--check-write", "--suggest-fix", "--remediate", "--remediation-out", "--probe-paths", "--reveal-token", "--include-responses", "--deny-path", "--deny-method", "--allow-path", "--allow-method", "--policy-mode", "--clear-policy",
+	"--workers", "--queue-size", "--framing",
 	"--command",
-	"--name", "--level", "--restore-disabled", "--disable-temp-project-backup", "--rename-enabled", "--include-peer-local",
-	"--recursive", "--include-udts",
+	"--name", "--level", "--restore-disabled", "--overwrite", "--id", "--force", "--disable-temp-project-backup", "--rename-enabled", "--include-peer-local", "--verify",
+	"--recursive", "--include-udts", "--cache-ttl", "--no-cache",
+	"--quiet",
 }
 
 func (c *CLI) Execute(args []string) error {
+	args, quiet, err := extractQuietFlag(args)
+	if err != nil {
+		return err
+	}
+	c.quiet = quiet
+
 	if len(args) == 0 {
 		c.printRootUsage()
 		return &igwerr.UsageError{Msg: "required command"}
@@ -146,6 +172,42 @@ func (c *CLI) Execute(args []string) error {
 	return cmd.Run(c, args[1:])
 }
 
+// extractQuietFlag removes --quiet from anywhere before a "--" terminator,
+// so every command accepts it without binding it in each flag set.
+func extractQuietFlag(args []string) ([]string, bool, error) {
+	quiet := false
+	rest := make([]string, 0, len(args))
+	for i, arg := range args {
+		if arg == "--" {
+			rest = append(rest, args[i:]...)
+			break
+		}
+		name, value, hasValue := strings.Cut(strings.TrimLeft(arg, "-"), "=")
+		if !strings.HasPrefix(arg, "-") || name != "quiet" {
+			rest = append(rest, arg)
+			continue
+		}
+		quiet = true
+		if hasValue {
+			parsed, err := strconv.ParseBool(value)
+			if err != nil {
+				return nil, false, &igwerr.UsageError{Msg: fmt.Sprintf("invalid --quiet value %q", value)}
+			}
+			quiet = parsed
+		}
+	}
+	return rest, quiet, nil
+}
+
+// info prints an informational line to stderr unless --quiet is set.
+// Payloads (response bodies, JSON, tables) always go to c.Out instead.
+func (c *CLI) info(format string, args ...any) {
+	if c.quiet {
+		return
+	}
+	fmt.Fprintf(c.Err, format+"\n", args...)
+}
+
 func findRootCommand(name string) (rootCommand, bool) {
 	for _, cmd := range rootCommands {
 		if cmd.Name == name {
@@ -165,20 +227,39 @@ func (c *CLI) printRootUsage() {
 }
 
 func (c *CLI) runCompletion(args []string) error {
-	if len(args) != 1 {
-		return &igwerr.UsageError{Msg: "usage: igw completion <bash>"}
+	fs := flag.NewFlagSet("completion", flag.ContinueOnError)
+	fs.SetOutput(c.Err)
+	var listCommands bool
+	fs.BoolVar(&listCommands, "list-commands", false, "Print the command/subcommand tree and completion flags as JSON")
+	if err := fs.Parse(args); err != nil {
+		return &igwerr.UsageError{Msg: err.Error()}
 	}
 
-	switch strings.TrimSpace(args[0]) {
-	case "bash":
-		_, err := io.WriteString(c.Out, bashCompletionScript())
-		if err != nil {
-			return igwerr.NewTransportError(err)
+	if listCommands {
+		if fs.NArg() > 0 {
+			return &igwerr.UsageError{Msg: "usage: igw completion --list-commands"}
 		}
-		return nil
+		return writeJSON(c.Out, buildCompletionSpec())
+	}
+	if fs.NArg() != 1 {
+		return &igwerr.UsageError{Msg: "usage: igw completion <bash|zsh|fish>"}
+	}
+
+	var script string
+	switch strings.TrimSpace(fs.Arg(0)) {
+	case "bash":
+		script = bashCompletionScript()
+	case "zsh":
+		script = zshCompletionScript()
+	case "fish":
+		script = fishCompletionScript()
 	default:
-		return &igwerr.UsageError{Msg: "unsupported shell (supported: bash)"}
+		return &igwerr.UsageError{Msg: "unsupported shell (supported: bash, zsh, fish)"}
 	}
+	if _, err := io.WriteString(c.Out, script); err != nil {
+		return igwerr.NewTransportError(err)
+	}
+	return nil
 }
 
 func (c *CLI) runVersion(args []string) error {
@@ -226,7 +307,7 @@ func bashCompletionScript() string {
 
 	return fmt.Sprintf(`# bash completion for igw
 _igw_profiles() {
-  igw config profile list 2>/dev/null | awk 'NR>1 {print $2}'
+  igw config profile names 2>/dev/null
 }
 
 _igw_completion() {
@@ -243,11 +324,11 @@ _igw_completion() {
       return 0
       ;;
     --method)
-      COMPREPLY=( $(compgen -W "GET POST PUT PATCH DELETE HEAD OPTIONS" -- "${cur}") )
+      COMPREPLY=( $(compgen -W "%s" -- "${cur}") )
       return 0
       ;;
     completion)
-      COMPREPLY=( $(compgen -W "bash" -- "${cur}") )
+      COMPREPLY=( $(compgen -W "%s" -- "${cur}") )
       return 0
       ;;
 %s  esac
@@ -264,5 +345,5 @@ _igw_completion() {
 }
 
 complete -F _igw_completion igw
-`, secondLevel.String(), nested.String(), strings.Join(completionRootCommands, " "), flags)
+`, strings.Join(completionMethods, " "), strings.Join(completionShells, " "), secondLevel.String(), nested.String(), strings.Join(completionRootCommands, " "), flags)
 }
@@ -0,0 +1,64 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/alex-mccollum/igw-cli/internal/igwerr"
+)
+
+// commandDeadline is one overall budget shared by every network step of a
+// command, set relative (--deadline) or absolute (--deadline-at). Per-request
+// --timeout values still apply inside it.
+type commandDeadline struct {
+	after time.Duration
+	at    string
+}
+
+func bindCommandDeadline(fs *flag.FlagSet, d *commandDeadline) {
+	fs.DurationVar(&d.after, "deadline", 0, "Overall time budget shared by every request the command makes")
+	fs.StringVar(&d.at, "deadline-at", "", "Absolute RFC3339 deadline shared by every request the command makes")
+}
+
+func (d commandDeadline) enabled() bool {
+	return d.after != 0 || strings.TrimSpace(d.at) != ""
+}
+
+// context returns a context carrying the deadline, or parent unchanged when
+// neither flag is set.
+func (d commandDeadline) context(parent context.Context, now func() time.Time) (context.Context, context.CancelFunc, error) {
+	at := strings.TrimSpace(d.at)
+	if d.after < 0 {
+		return nil, nil, &igwerr.UsageError{Msg: "--deadline must be >= 0"}
+	}
+	if d.after > 0 && at != "" {
+		return nil, nil, &igwerr.UsageError{Msg: "use only one of --deadline or --deadline-at"}
+	}
+	if d.after > 0 {
+		ctx, cancel := context.WithDeadline(parent, now().Add(d.after))
+		return ctx, cancel, nil
+	}
+	if at != "" {
+		deadline, err := time.Parse(time.RFC3339, at)
+		if err != nil {
+			return nil, nil, &igwerr.UsageError{Msg: fmt.Sprintf("invalid --deadline-at %q (expected RFC3339)", at)}
+		}
+		ctx, cancel := context.WithDeadline(parent, deadline)
+		return ctx, cancel, nil
+	}
+	return parent, func() {}, nil
+}
+
+// deadlineExceededError reports a tripped command deadline as a network
+// failure, naming the step that was running and the last error seen.
+func deadlineExceededError(step string, lastErr error) error {
+	msg := fmt.Sprintf("deadline exceeded during %s", step)
+	if lastErr != nil && !errors.Is(lastErr, context.DeadlineExceeded) {
+		msg += fmt.Sprintf(" (last error: %v)", lastErr)
+	}
+	return igwerr.NewTransportError(errors.New(msg))
+}
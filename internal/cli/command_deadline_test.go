@@ -0,0 +1,183 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/alex-mccollum/igw-cli/internal/apidocs"
+	"github.com/alex-mccollum/igw-cli/internal/config"
+	"github.com/alex-mccollum/igw-cli/internal/igwerr"
+)
+
+func TestWaitDeadlineTripsDuringWaitPhase(t *testing.T) {
+	t.Parallel()
+
+	var restarts int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/data/api/v1/restart-tasks/restart":
+			restarts++
+			w.WriteHeader(http.StatusOK)
+		case r.Method == http.MethodGet && r.URL.Path == "/data/api/v1/gateway-info":
+			// The uptime never resets, so only the deadline can end the wait.
+			_, _ = w.Write([]byte(`{"uptime":100}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+
+	var out bytes.Buffer
+	c := testWaitCLI(t, srv, &out)
+	started := time.Now()
+	err := c.Execute([]string{
+		"wait", "gateway",
+		"--gateway-url", srv.URL,
+		"--api-key", "secret",
+		"--after-restart",
+		"--restart", "--yes",
+		"--interval", "10ms",
+		"--wait-timeout", "1m",
+		"--deadline", "200ms",
+		"--json",
+	})
+	if err == nil {
+		t.Fatalf("expected the deadline to end the wait")
+	}
+	if elapsed := time.Since(started); elapsed > 5*time.Second {
+		t.Fatalf("deadline did not bound the wait: %s", elapsed)
+	}
+	if restarts != 1 {
+		t.Fatalf("expected the restart step to run before the wait, got %d restarts", restarts)
+	}
+	if code := igwerr.ExitCode(err); code != 7 {
+		t.Fatalf("expected network exit code 7, got %d", code)
+	}
+
+	var payload map[string]any
+	if decodeErr := json.Unmarshal(out.Bytes(), &payload); decodeErr != nil {
+		t.Fatalf("decode output: %v", decodeErr)
+	}
+	msg, _ := payload["error"].(string)
+	if !strings.Contains(msg, "deadline exceeded during the wait for gateway to become healthy") {
+		t.Fatalf("unexpected error message %q", msg)
+	}
+}
+
+func TestCallDeadlineBoundsRequest(t *testing.T) {
+	t.Parallel()
+
+	c := &CLI{
+		In:     strings.NewReader(""),
+		Out:    new(bytes.Buffer),
+		Err:    new(bytes.Buffer),
+		Getenv: func(string) string { return "" },
+		ReadConfig: func() (config.File, error) {
+			return config.File{}, nil
+		},
+		HTTPClient: newMockHTTPClient(func(r *http.Request) (*http.Response, error) {
+			<-r.Context().Done()
+			return nil, r.Context().Err()
+		}),
+	}
+	err := c.Execute([]string{
+		"call",
+		"--gateway-url", mockGatewayURL,
+		"--api-key", "secret",
+		"--path", "/data/api/v1/gateway-info",
+		"--timeout", "1m",
+		"--deadline", "50ms",
+	})
+	if code := igwerr.ExitCode(err); code != 7 {
+		t.Fatalf("expected network exit code 7, got %d (%v)", code, err)
+	}
+	if !strings.Contains(err.Error(), "deadline exceeded during the request") {
+		t.Fatalf("unexpected error %v", err)
+	}
+}
+
+func TestCallDeadlineBoundsSpecVersionCheck(t *testing.T) {
+	t.Parallel()
+
+	spec := writeCallOpSpec(t, callOpSpecFixture)
+	if err := apidocs.WriteSyncMeta(apidocs.SyncMetaPathForSpec(spec), apidocs.SyncMeta{GatewayVersion: "8.3.1"}); err != nil {
+		t.Fatalf("write sync meta: %v", err)
+	}
+	var paths []string
+	c := newAdminWrapperTestCLI(newMockHTTPClient(func(r *http.Request) (*http.Response, error) {
+		paths = append(paths, r.URL.Path)
+		<-r.Context().Done()
+		return nil, r.Context().Err()
+	}))
+	err := c.Execute([]string{
+		"call",
+		"--gateway-url", mockGatewayURL,
+		"--api-key", "secret",
+		"--spec-file", spec,
+		"--op", "gatewayInfo",
+		"--strict-spec-version",
+		"--timeout", "1m",
+		"--deadline", "50ms",
+	})
+	if code := igwerr.ExitCode(err); code != 7 {
+		t.Fatalf("expected network exit code 7, got %d (%v)", code, err)
+	}
+	if !strings.Contains(err.Error(), "deadline exceeded during the spec version check") {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if len(paths) != 1 {
+		t.Fatalf("expected the call to stop after the version check, got %v", paths)
+	}
+}
+
+func TestCommandDeadlineContext(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	clock := func() time.Time { return now }
+
+	ctx, cancel, err := commandDeadline{after: time.Minute}.context(context.Background(), clock)
+	if err != nil {
+		t.Fatalf("relative deadline: %v", err)
+	}
+	if deadline, ok := ctx.Deadline(); !ok || !deadline.Equal(now.Add(time.Minute)) {
+		t.Fatalf("unexpected deadline %v (%v)", deadline, ok)
+	}
+	cancel()
+
+	ctx, cancel, err = commandDeadline{at: "2026-01-02T03:05:05Z"}.context(context.Background(), clock)
+	if err != nil {
+		t.Fatalf("absolute deadline: %v", err)
+	}
+	if deadline, _ := ctx.Deadline(); !deadline.Equal(now.Add(time.Minute)) {
+		t.Fatalf("unexpected deadline %v", deadline)
+	}
+	cancel()
+
+	ctx, cancel, err = commandDeadline{}.context(context.Background(), clock)
+	if err != nil {
+		t.Fatalf("no deadline: %v", err)
+	}
+	if _, ok := ctx.Deadline(); ok {
+		t.Fatalf("expected no deadline")
+	}
+	cancel()
+
+	for _, d := range []commandDeadline{
+		{after: -time.Second},
+		{after: time.Second, at: "2026-01-02T03:05:05Z"},
+		{at: "tomorrow"},
+	} {
+		if _, _, err := d.context(context.Background(), clock); err == nil {
+			t.Fatalf("%+v: expected usage error", d)
+		} else {
+			requireUsageExitCode(t, err)
+		}
+	}
+}
@@ -0,0 +1,175 @@
+package cli
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+var completionShells = []string{"bash", "zsh", "fish"}
+
+var completionMethods = []string{"GET", "POST", "PUT", "PATCH", "DELETE", "HEAD", "OPTIONS"}
+
+// completionCommand is one node of the `completion --list-commands` tree.
+type completionCommand struct {
+	Name        string              `json:"name"`
+	Subcommands []completionCommand `json:"subcommands,omitempty"`
+}
+
+// completionSpec is the single description every shell script is rendered
+// from, and what `igw completion --list-commands` prints.
+type completionSpec struct {
+	Commands []completionCommand `json:"commands"`
+	Flags    []string            `json:"flags"`
+	Methods  []string            `json:"methods"`
+	Shells   []string            `json:"shells"`
+}
+
+func buildCompletionSpec() completionSpec {
+	spec := completionSpec{
+		Commands: make([]completionCommand, 0, len(completionRootCommands)),
+		Flags:    completionFlags,
+		Methods:  completionMethods,
+		Shells:   completionShells,
+	}
+	for _, root := range completionRootCommands {
+		node := completionCommand{Name: root}
+		subcommands := completionSubcommands[root]
+		if root == "completion" {
+			subcommands = completionShells
+		}
+		for _, sub := range subcommands {
+			child := completionCommand{Name: sub}
+			for _, nested := range nestedCompletionCommands[root+" "+sub] {
+				child.Subcommands = append(child.Subcommands, completionCommand{Name: nested})
+			}
+			node.Subcommands = append(node.Subcommands, child)
+		}
+		spec.Commands = append(spec.Commands, node)
+	}
+	return spec
+}
+
+// completionPath is a command prefix and the words that may follow it.
+type completionPath struct {
+	words []string
+	next  []string
+}
+
+// paths flattens the tree into prefixes, shortest first.
+func (s completionSpec) paths() []completionPath {
+	var paths []completionPath
+	var walk func(prefix []string, nodes []completionCommand)
+	walk = func(prefix []string, nodes []completionCommand) {
+		for _, node := range nodes {
+			if len(node.Subcommands) == 0 {
+				continue
+			}
+			words := append(append([]string(nil), prefix...), node.Name)
+			next := make([]string, 0, len(node.Subcommands))
+			for _, child := range node.Subcommands {
+				next = append(next, child.Name)
+			}
+			paths = append(paths, completionPath{words: words, next: next})
+			walk(words, node.Subcommands)
+		}
+	}
+	walk(nil, s.Commands)
+	sort.SliceStable(paths, func(i, j int) bool { return len(paths[i].words) < len(paths[j].words) })
+	return paths
+}
+
+func (s completionSpec) rootNames() []string {
+	names := make([]string, 0, len(s.Commands))
+	for _, cmd := range s.Commands {
+		names = append(names, cmd.Name)
+	}
+	return names
+}
+
+func zshCompletionScript() string {
+	spec := buildCompletionSpec()
+
+	cases := strings.Builder{}
+	for _, path := range spec.paths() {
+		fmt.Fprintf(&cases, "  if (( CURRENT == %d )) && [[ \"${words[2,%d]}\" == \"%s\" ]]; then\n", len(path.words)+2, len(path.words)+1, strings.Join(path.words, " "))
+		fmt.Fprintf(&cases, "    compadd -- %s\n", strings.Join(path.next, " "))
+		fmt.Fprintf(&cases, "    return\n")
+		fmt.Fprintf(&cases, "  fi\n")
+	}
+
+	return fmt.Sprintf(`#compdef igw
+# zsh completion for igw
+
+_igw() {
+  case "${words[CURRENT-1]}" in
+    --profile)
+      compadd -- ${(f)"$(igw config profile names 2>/dev/null)"}
+      return
+      ;;
+    --method)
+      compadd -- %s
+      return
+      ;;
+  esac
+
+  if [[ "${PREFIX}" == -* ]]; then
+    compadd -- %s
+    return
+  fi
+
+  if (( CURRENT == 2 )); then
+    compadd -- %s
+    return
+  fi
+
+%s
+  compadd -- %s
+}
+
+if [[ "${funcstack[1]}" == "_igw" ]]; then
+  _igw "$@"
+else
+  compdef _igw igw
+fi
+`, strings.Join(spec.Methods, " "), strings.Join(spec.Flags, " "), strings.Join(spec.rootNames(), " "), cases.String(), strings.Join(spec.Flags, " "))
+}
+
+func fishCompletionScript() string {
+	spec := buildCompletionSpec()
+
+	out := strings.Builder{}
+	out.WriteString(`# fish completion for igw
+function __igw_profiles
+    igw config profile names 2>/dev/null
+end
+
+# __igw_args_are succeeds when the words before the cursor are exactly
+# igw followed by the given arguments.
+function __igw_args_are
+    set -l tokens (commandline -opc)
+    test (count $tokens) -eq (math (count $argv) + 1); or return 1
+    for i in (seq (count $argv))
+        test "$tokens[(math $i + 1)]" = "$argv[$i]"; or return 1
+    end
+end
+
+`)
+	fmt.Fprintf(&out, "complete -c igw -f -n '__igw_args_are' -a '%s'\n", strings.Join(spec.rootNames(), " "))
+	for _, path := range spec.paths() {
+		fmt.Fprintf(&out, "complete -c igw -f -n '__igw_args_are %s' -a '%s'\n", strings.Join(path.words, " "), strings.Join(path.next, " "))
+	}
+	out.WriteString("\n")
+	for _, flag := range spec.Flags {
+		name := strings.TrimPrefix(flag, "--")
+		switch name {
+		case "profile":
+			fmt.Fprintf(&out, "complete -c igw -l %s -x -a '(__igw_profiles)'\n", name)
+		case "method":
+			fmt.Fprintf(&out, "complete -c igw -l %s -x -a '%s'\n", name, strings.Join(spec.Methods, " "))
+		default:
+			fmt.Fprintf(&out, "complete -c igw -l %s\n", name)
+		}
+	}
+	return out.String()
+}
@@ -2,6 +2,7 @@ package cli
 
 import (
 	"bytes"
+	"encoding/json"
 	"strings"
 	"testing"
 
@@ -25,7 +26,7 @@ func TestCompletionBash(t *testing.T) {
 	if !strings.Contains(script, "_igw_completion") {
 		t.Fatalf("missing completion function in script")
 	}
-	if !strings.Contains(script, "igw config profile list") {
+	if !strings.Contains(script, "igw config profile names") {
 		t.Fatalf("missing profile-aware completion in script")
 	}
 	if !strings.Contains(script, "version") {
@@ -34,7 +35,7 @@ func TestCompletionBash(t *testing.T) {
 	if !strings.Contains(script, "exit-codes") || !strings.Contains(script, "schema") {
 		t.Fatalf("missing new machine contract command completion entries")
 	}
-	if !strings.Contains(script, "list download loggers logger level-reset") || !strings.Contains(script, "generate status download") {
+	if !strings.Contains(script, "list tail download loggers logger level-reset") || !strings.Contains(script, "generate status download") || !strings.Contains(script, "list install uninstall restart") {
 		t.Fatalf("missing new command completion entries")
 	}
 	if !strings.Contains(script, "sync") || !strings.Contains(script, "refresh") || !strings.Contains(script, "diagnostics-bundle") || !strings.Contains(script, "restart-tasks") {
@@ -68,7 +69,7 @@ func TestCompletionUnsupportedShell(t *testing.T) {
 		Err: new(bytes.Buffer),
 	}
 
-	err := c.Execute([]string{"completion", "zsh"})
+	err := c.Execute([]string{"completion", "powershell"})
 	if err == nil {
 		t.Fatalf("expected usage error")
 	}
@@ -76,3 +77,71 @@ func TestCompletionUnsupportedShell(t *testing.T) {
 		t.Fatalf("unexpected exit code %d", code)
 	}
 }
+
+func TestCompletionZshAndFish(t *testing.T) {
+	t.Parallel()
+
+	for _, shell := range []string{"zsh", "fish"} {
+		var out bytes.Buffer
+		c := &CLI{Out: &out, Err: new(bytes.Buffer)}
+		if err := c.Execute([]string{"completion", shell}); err != nil {
+			t.Fatalf("completion %s failed: %v", shell, err)
+		}
+
+		script := out.String()
+		if !strings.Contains(script, "igw config profile names") {
+			t.Fatalf("%s: missing profile-aware completion", shell)
+		}
+		if !strings.Contains(script, "GET POST PUT PATCH DELETE HEAD OPTIONS") {
+			t.Fatalf("%s: missing --method values", shell)
+		}
+		if !strings.Contains(script, "list install uninstall restart") || !strings.Contains(script, "add use list names") {
+			t.Fatalf("%s: missing subcommand entries", shell)
+		}
+	}
+
+	var zsh bytes.Buffer
+	if err := (&CLI{Out: &zsh, Err: new(bytes.Buffer)}).Execute([]string{"completion", "zsh"}); err != nil {
+		t.Fatalf("completion zsh failed: %v", err)
+	}
+	if !strings.HasPrefix(zsh.String(), "#compdef igw") || !strings.Contains(zsh.String(), `"${words[2,3]}" == "config profile"`) || !strings.Contains(zsh.String(), "--select") {
+		t.Fatalf("unexpected zsh script")
+	}
+
+	var fish bytes.Buffer
+	if err := (&CLI{Out: &fish, Err: new(bytes.Buffer)}).Execute([]string{"completion", "fish"}); err != nil {
+		t.Fatalf("completion fish failed: %v", err)
+	}
+	if !strings.Contains(fish.String(), "complete -c igw -f -n '__igw_args_are diagnostics bundle' -a 'generate status download'") || !strings.Contains(fish.String(), "complete -c igw -l select\n") {
+		t.Fatalf("unexpected fish script")
+	}
+}
+
+func TestCompletionListCommands(t *testing.T) {
+	t.Parallel()
+
+	var out bytes.Buffer
+	c := &CLI{Out: &out, Err: new(bytes.Buffer)}
+	if err := c.Execute([]string{"completion", "--list-commands"}); err != nil {
+		t.Fatalf("completion --list-commands failed: %v", err)
+	}
+
+	var spec completionSpec
+	if err := json.Unmarshal(out.Bytes(), &spec); err != nil {
+		t.Fatalf("decode command tree: %v", err)
+	}
+	if len(spec.Commands) != len(completionRootCommands) || len(spec.Flags) != len(completionFlags) {
+		t.Fatalf("unexpected tree sizes: %d commands, %d flags", len(spec.Commands), len(spec.Flags))
+	}
+	for _, cmd := range spec.Commands {
+		if cmd.Name != "config" {
+			continue
+		}
+		for _, sub := range cmd.Subcommands {
+			if sub.Name == "profile" && len(sub.Subcommands) == len(nestedCompletionCommands["config profile"]) {
+				return
+			}
+		}
+	}
+	t.Fatalf("config profile subcommands missing from tree: %s", out.String())
+}
@@ -1,20 +1,21 @@
 package cli
 
 import (
-	"encoding/json"
 	"flag"
 	"fmt"
 	"io"
+	"net/http"
 	"sort"
 	"strings"
 
 	"github.com/alex-mccollum/igw-cli/internal/config"
+	"github.com/alex-mccollum/igw-cli/internal/gateway"
 	"github.com/alex-mccollum/igw-cli/internal/igwerr"
 )
 
 func (c *CLI) runConfig(args []string) error {
 	if len(args) == 0 {
-		fmt.Fprintln(c.Err, "Usage: igw config <set|show|profile> [flags]")
+		fmt.Fprintln(c.Err, "Usage: igw config <set|show|profile|encrypt|decrypt> [flags]")
 		return &igwerr.UsageError{Msg: "required config subcommand"}
 	}
 
@@ -25,6 +26,10 @@ func (c *CLI) runConfig(args []string) error {
 		return c.runConfigShow(args[1:])
 	case "profile":
 		return c.runConfigProfile(args[1:])
+	case "encrypt":
+		return c.runConfigEncrypt(args[1:])
+	case "decrypt":
+		return c.runConfigDecrypt(args[1:])
 	default:
 		return &igwerr.UsageError{Msg: fmt.Sprintf("unknown config subcommand %q", args[0])}
 	}
@@ -32,7 +37,7 @@ func (c *CLI) runConfig(args []string) error {
 
 func (c *CLI) runConfigProfile(args []string) error {
 	if len(args) == 0 {
-		fmt.Fprintln(c.Err, "Usage: igw config profile <add|use|list> [flags]")
+		fmt.Fprintln(c.Err, "Usage: igw config profile <add|use|list|names|remove|rename> [flags]")
 		return &igwerr.UsageError{Msg: "required config profile subcommand"}
 	}
 
@@ -43,6 +48,12 @@ func (c *CLI) runConfigProfile(args []string) error {
 		return c.runConfigProfileUse(args[1:])
 	case "list":
 		return c.runConfigProfileList(args[1:])
+	case "names":
+		return c.runConfigProfileNames(args[1:])
+	case "remove":
+		return c.runConfigProfileRemove(args[1:])
+	case "rename":
+		return c.runConfigProfileRename(args[1:])
 	default:
 		return &igwerr.UsageError{Msg: fmt.Sprintf("unknown config profile subcommand %q", args[0])}
 	}
@@ -61,62 +72,129 @@ func (c *CLI) runConfigSet(args []string) error {
 	var profileName string
 	var apiKey string
 	var apiKeyStdin bool
+	var apiKeyEnv string
+	var apiKeyCommand string
 	var jsonOutput bool
+	var compact bool
+	var denyPath string
+	var denyMethod string
+	var allowPath string
+	var allowMethod string
+	var policyMode string
+	var clearPolicy bool
+	var history string
+	var proxyURL string
 
 	fs.StringVar(&gatewayURL, "gateway-url", "", "Gateway base URL")
 	fs.BoolVar(&autoGateway, "auto-gateway", false, "Detect Windows host IP from WSL and set gateway URL")
 	fs.StringVar(&profileName, "profile", "", "Profile to update instead of default config")
 	fs.StringVar(&apiKey, "api-key", "", "Ignition API token")
 	fs.BoolVar(&apiKeyStdin, "api-key-stdin", false, "Read API token from stdin")
+	fs.StringVar(&apiKeyEnv, "api-key-env", "", "Read the profile token from this environment variable at call time (requires --profile)")
+	fs.StringVar(&apiKeyCommand, "api-key-command", "", "Run this command for the profile token at call time (requires --profile)")
 	fs.BoolVar(&jsonOutput, "json", false, "Print JSON output")
+	fs.BoolVar(&compact, "compact", false, "Print compact one-line JSON (requires --json)")
+	fs.StringVar(&denyPath, "deny-path", "", "Add a policy rule blocking this path prefix")
+	fs.StringVar(&denyMethod, "deny-method", "", "Add a policy rule blocking this method (combine with --deny-path)")
+	fs.StringVar(&allowPath, "allow-path", "", "Add a policy allowlist rule for this path prefix")
+	fs.StringVar(&allowMethod, "allow-method", "", "Add a policy allowlist rule for this method (combine with --allow-path)")
+	fs.StringVar(&policyMode, "policy-mode", "", "Policy mode: deny|allow")
+	fs.BoolVar(&clearPolicy, "clear-policy", false, "Remove all policy rules before applying other policy flags")
+	fs.StringVar(&history, "history", "", "Record executed calls for igw history: on|off")
+	fs.StringVar(&proxyURL, "proxy-url", "", "Proxy URL for gateway requests: http://, https://, or socks5:// (empty clears it)")
 
 	if err := fs.Parse(args); err != nil {
-		return c.printJSONCommandError(jsonRequested, &igwerr.UsageError{Msg: err.Error()})
+		return c.printJSONCommandErrorWithOptions(jsonRequested, argsWantCompact(args), &igwerr.UsageError{Msg: err.Error()})
+	}
+	if compact && !jsonOutput {
+		return c.printJSONCommandErrorWithOptions(false, false, &igwerr.UsageError{Msg: "--compact requires --json"})
 	}
 	if fs.NArg() > 0 {
-		return c.printJSONCommandError(jsonOutput, &igwerr.UsageError{Msg: "unexpected positional arguments"})
+		return c.printJSONCommandErrorWithOptions(jsonOutput, compact, &igwerr.UsageError{Msg: "unexpected positional arguments"})
 	}
 
 	if apiKeyStdin {
 		if apiKey != "" {
-			return c.printJSONCommandError(jsonOutput, &igwerr.UsageError{Msg: "use only one of --api-key or --api-key-stdin"})
+			return c.printJSONCommandErrorWithOptions(jsonOutput, compact, &igwerr.UsageError{Msg: "use only one of --api-key or --api-key-stdin"})
 		}
 		tokenBytes, err := io.ReadAll(c.In)
 		if err != nil {
-			return c.printJSONCommandError(jsonOutput, igwerr.NewTransportError(err))
+			return c.printJSONCommandErrorWithOptions(jsonOutput, compact, igwerr.NewTransportError(err))
 		}
 		apiKey = strings.TrimSpace(string(tokenBytes))
 	}
 
+	apiKeyEnv = strings.TrimSpace(apiKeyEnv)
+	apiKeyCommand = strings.TrimSpace(apiKeyCommand)
+	tokenSources := 0
+	for _, set := range []bool{strings.TrimSpace(apiKey) != "", apiKeyEnv != "", apiKeyCommand != ""} {
+		if set {
+			tokenSources++
+		}
+	}
+	if tokenSources > 1 {
+		return c.printJSONCommandErrorWithOptions(jsonOutput, compact, &igwerr.UsageError{Msg: "use only one of --api-key, --api-key-stdin, --api-key-env, or --api-key-command"})
+	}
+	if (apiKeyEnv != "" || apiKeyCommand != "") && strings.TrimSpace(profileName) == "" {
+		return c.printJSONCommandErrorWithOptions(jsonOutput, compact, &igwerr.UsageError{Msg: "--api-key-env and --api-key-command require --profile"})
+	}
+
 	if autoGateway && strings.TrimSpace(gatewayURL) != "" {
-		return c.printJSONCommandError(jsonOutput, &igwerr.UsageError{Msg: "use only one of --gateway-url or --auto-gateway"})
+		return c.printJSONCommandErrorWithOptions(jsonOutput, compact, &igwerr.UsageError{Msg: "use only one of --gateway-url or --auto-gateway"})
 	}
 
 	autoGatewaySource := ""
 	if autoGateway {
 		if c.DetectWSLHostIP == nil {
-			return c.printJSONCommandError(jsonOutput, &igwerr.UsageError{Msg: "auto-gateway is not available in this runtime"})
+			return c.printJSONCommandErrorWithOptions(jsonOutput, compact, &igwerr.UsageError{Msg: "auto-gateway is not available in this runtime"})
 		}
 
 		hostIP, source, detectErr := c.DetectWSLHostIP()
 		if detectErr != nil {
-			return c.printJSONCommandError(jsonOutput, &igwerr.UsageError{Msg: fmt.Sprintf("auto-gateway failed: %v", detectErr)})
+			return c.printJSONCommandErrorWithOptions(jsonOutput, compact, &igwerr.UsageError{Msg: fmt.Sprintf("auto-gateway failed: %v", detectErr)})
 		}
 
 		gatewayURL = fmt.Sprintf("http://%s:8088", hostIP)
 		autoGatewaySource = source
 		if !jsonOutput {
-			fmt.Fprintf(c.Out, "auto-detected gateway URL from %s: %s\n", source, gatewayURL)
+			c.info("auto-detected gateway URL from %s: %s", source, gatewayURL)
 		}
 	}
 
-	if strings.TrimSpace(gatewayURL) == "" && strings.TrimSpace(apiKey) == "" {
-		return c.printJSONCommandError(jsonOutput, &igwerr.UsageError{Msg: "set at least one of --gateway-url or --api-key"})
+	policyRequested := clearPolicy || strings.TrimSpace(policyMode) != "" ||
+		strings.TrimSpace(denyPath) != "" || strings.TrimSpace(denyMethod) != "" ||
+		strings.TrimSpace(allowPath) != "" || strings.TrimSpace(allowMethod) != ""
+	historyRequested := strings.TrimSpace(history) != ""
+	historyEnabled := false
+	if historyRequested {
+		switch strings.ToLower(strings.TrimSpace(history)) {
+		case "on":
+			historyEnabled = true
+		case "off":
+		default:
+			return c.printJSONCommandErrorWithOptions(jsonOutput, compact, &igwerr.UsageError{Msg: fmt.Sprintf("invalid --history %q (expected on|off)", history)})
+		}
+	}
+	proxyRequested := flagWasSet(fs, "proxy-url")
+	proxyURL = strings.TrimSpace(proxyURL)
+	if proxyURL != "" {
+		if _, err := gateway.ParseProxyURL(proxyURL); err != nil {
+			return c.printJSONCommandErrorWithOptions(jsonOutput, compact, &igwerr.UsageError{Msg: fmt.Sprintf("--proxy-url: %v", err)})
+		}
+	}
+	if strings.TrimSpace(gatewayURL) == "" && tokenSources == 0 && !policyRequested && !historyRequested && !proxyRequested {
+		return c.printJSONCommandErrorWithOptions(jsonOutput, compact, &igwerr.UsageError{Msg: "set at least one of --gateway-url, --api-key, --api-key-env, --api-key-command, --proxy-url, --history, or a policy flag"})
+	}
+	if policyRequested && strings.TrimSpace(profileName) != "" {
+		return c.printJSONCommandErrorWithOptions(jsonOutput, compact, &igwerr.UsageError{Msg: "policy flags apply to all profiles; do not combine with --profile"})
+	}
+	if historyRequested && strings.TrimSpace(profileName) != "" {
+		return c.printJSONCommandErrorWithOptions(jsonOutput, compact, &igwerr.UsageError{Msg: "--history applies to all profiles; do not combine with --profile"})
 	}
 
 	cfg, err := c.ReadConfig()
 	if err != nil {
-		return c.printJSONCommandError(jsonOutput, &igwerr.UsageError{Msg: fmt.Sprintf("load config: %v", err)})
+		return c.printJSONCommandErrorWithOptions(jsonOutput, compact, &igwerr.UsageError{Msg: fmt.Sprintf("load config: %v", err)})
 	}
 
 	profileName = strings.TrimSpace(profileName)
@@ -129,8 +207,14 @@ func (c *CLI) runConfigSet(args []string) error {
 		if strings.TrimSpace(gatewayURL) != "" {
 			profileCfg.GatewayURL = strings.TrimSpace(gatewayURL)
 		}
-		if strings.TrimSpace(apiKey) != "" {
+		if tokenSources > 0 {
+			// Each token source replaces the others.
 			profileCfg.Token = strings.TrimSpace(apiKey)
+			profileCfg.TokenEnv = apiKeyEnv
+			profileCfg.TokenCommand = apiKeyCommand
+		}
+		if proxyRequested {
+			profileCfg.ProxyURL = proxyURL
 		}
 		cfg.Profiles[profileName] = profileCfg
 	} else {
@@ -140,15 +224,35 @@ func (c *CLI) runConfigSet(args []string) error {
 		if strings.TrimSpace(apiKey) != "" {
 			cfg.Token = strings.TrimSpace(apiKey)
 		}
+		if proxyRequested {
+			cfg.ProxyURL = proxyURL
+		}
+	}
+
+	if policyRequested {
+		policy, policyErr := updatePolicy(cfg.Policy, clearPolicy, policyMode, denyMethod, denyPath, allowMethod, allowPath)
+		if policyErr != nil {
+			return c.printJSONCommandErrorWithOptions(jsonOutput, compact, &igwerr.UsageError{Msg: policyErr.Error()})
+		}
+		cfg.Policy = policy
+	}
+	if historyRequested {
+		cfg.History = nil
+		if historyEnabled {
+			cfg.History = &config.History{Enabled: true}
+		}
 	}
 
 	if c.WriteConfig == nil {
-		return c.printJSONCommandError(jsonOutput, &igwerr.UsageError{Msg: "config writer is not configured"})
+		return c.printJSONCommandErrorWithOptions(jsonOutput, compact, &igwerr.UsageError{Msg: "config writer is not configured"})
 	}
 	if err := c.WriteConfig(cfg); err != nil {
-		return c.printJSONCommandError(jsonOutput, &igwerr.UsageError{Msg: fmt.Sprintf("save config: %v", err)})
+		return c.printJSONCommandErrorWithOptions(jsonOutput, compact, &igwerr.UsageError{Msg: fmt.Sprintf("save config: %v", err)})
 	}
 	c.invalidateRuntimeCaches()
+	if cfg.Encryption != nil && strings.TrimSpace(apiKey) != "" {
+		fmt.Fprintln(c.Err, "warning: the new token is stored in plaintext; run igw config encrypt to encrypt it")
+	}
 
 	path, pathErr := config.Path()
 	pathValue := ""
@@ -162,36 +266,131 @@ func (c *CLI) runConfigSet(args []string) error {
 			"configPath":   pathValue,
 			"profile":      profileName,
 			"gatewayURL":   strings.TrimSpace(gatewayURL),
-			"tokenUpdated": strings.TrimSpace(apiKey) != "",
+			"tokenUpdated": tokenSources > 0,
+		}
+		if profileName != "" {
+			if source := cfg.Profiles[profileName].TokenSource(); source != "" {
+				payload["tokenSource"] = source
+			}
 		}
 		if autoGatewaySource != "" {
 			payload["autoGatewaySource"] = autoGatewaySource
 		}
-		return writeJSON(c.Out, payload)
+		if policyRequested {
+			payload["policy"] = cfg.Policy
+		}
+		if historyRequested {
+			payload["historyEnabled"] = cfg.HistoryEnabled()
+		}
+		if proxyRequested {
+			payload["proxyURL"] = redactProxyURL(proxyURL)
+		}
+		return writeJSONWithOptions(c.Out, payload, compact)
 	}
 
 	if pathErr == nil {
-		fmt.Fprintf(c.Out, "saved config: %s\n", path)
+		c.info("saved config: %s", path)
 	} else {
-		fmt.Fprintln(c.Out, "saved config")
+		c.info("saved config")
 	}
 	if profileName != "" {
-		fmt.Fprintf(c.Out, "updated profile: %s\n", profileName)
+		c.info("updated profile: %s", profileName)
+	}
+	if policyRequested {
+		writePolicyLines(c.Out, cfg.Policy)
+	}
+	if historyRequested {
+		writeHistoryLine(c.Out, cfg)
 	}
 
 	return nil
 }
 
+// updatePolicy applies config set policy flags to the current policy and
+// returns nil when nothing remains.
+func updatePolicy(current *config.Policy, clear bool, mode string, denyMethod string, denyPath string, allowMethod string, allowPath string) (*config.Policy, error) {
+	next := config.Policy{}
+	if current != nil && !clear {
+		next.Mode = current.Mode
+		next.Deny = append([]config.PolicyRule(nil), current.Deny...)
+		next.Allow = append([]config.PolicyRule(nil), current.Allow...)
+	}
+
+	if strings.TrimSpace(mode) != "" {
+		parsed, err := config.ParsePolicyMode(mode)
+		if err != nil {
+			return nil, err
+		}
+		next.Mode = parsed
+	}
+	if strings.TrimSpace(denyMethod) != "" || strings.TrimSpace(denyPath) != "" {
+		rule, err := config.NewPolicyRule(denyMethod, denyPath)
+		if err != nil {
+			return nil, err
+		}
+		next.Deny = appendPolicyRule(next.Deny, rule)
+	}
+	if strings.TrimSpace(allowMethod) != "" || strings.TrimSpace(allowPath) != "" {
+		rule, err := config.NewPolicyRule(allowMethod, allowPath)
+		if err != nil {
+			return nil, err
+		}
+		next.Allow = appendPolicyRule(next.Allow, rule)
+	}
+
+	if next.Mode == config.PolicyModeDeny {
+		next.Mode = ""
+	}
+	if next.IsEmpty() {
+		return nil, nil
+	}
+	return &next, nil
+}
+
+func appendPolicyRule(rules []config.PolicyRule, rule config.PolicyRule) []config.PolicyRule {
+	for _, existing := range rules {
+		if existing == rule {
+			return rules
+		}
+	}
+	return append(rules, rule)
+}
+
+func writePolicyLines(w io.Writer, policy *config.Policy) {
+	if policy.IsEmpty() {
+		fmt.Fprintln(w, "policy\tnone")
+		return
+	}
+	mode := policy.Mode
+	if mode == "" {
+		mode = config.PolicyModeDeny
+	}
+	fmt.Fprintf(w, "policy_mode\t%s\n", mode)
+	for _, rule := range policy.Deny {
+		fmt.Fprintf(w, "policy_deny\t%s\n", rule)
+	}
+	for _, rule := range policy.Allow {
+		fmt.Fprintf(w, "policy_allow\t%s\n", rule)
+	}
+}
+
 func (c *CLI) runConfigShow(args []string) error {
 	fs := flag.NewFlagSet("config show", flag.ContinueOnError)
 	fs.SetOutput(c.Err)
 
 	var jsonOutput bool
+	var compact bool
+	var revealToken bool
 	fs.BoolVar(&jsonOutput, "json", false, "Print JSON output")
+	fs.BoolVar(&compact, "compact", false, "Print compact one-line JSON (requires --json)")
+	fs.BoolVar(&revealToken, "reveal-token", false, "Print tokens unmasked")
 
 	if err := fs.Parse(args); err != nil {
 		return &igwerr.UsageError{Msg: err.Error()}
 	}
+	if compact && !jsonOutput {
+		return c.printJSONCommandErrorWithOptions(false, false, &igwerr.UsageError{Msg: "--compact requires --json"})
+	}
 	if fs.NArg() > 0 {
 		return &igwerr.UsageError{Msg: "unexpected positional arguments"}
 	}
@@ -200,17 +399,43 @@ func (c *CLI) runConfigShow(args []string) error {
 	if err != nil {
 		return &igwerr.UsageError{Msg: fmt.Sprintf("load config: %v", err)}
 	}
+	if revealToken {
+		c.warnRevealToken()
+	}
 
 	if jsonOutput {
 		type profileView struct {
-			GatewayURL  string `json:"gatewayURL,omitempty"`
-			TokenMasked string `json:"tokenMasked,omitempty"`
+			GatewayURL         string            `json:"gatewayURL,omitempty"`
+			TokenMasked        string            `json:"tokenMasked,omitempty"`
+			Token              string            `json:"token,omitempty"`
+			TokenSource        string            `json:"tokenSource,omitempty"`
+			TokenRef           string            `json:"tokenRef,omitempty"`
+			TokenInheritedFrom string            `json:"tokenInheritedFrom,omitempty"`
+			TokenError         string            `json:"tokenError,omitempty"`
+			ProxyURL           string            `json:"proxyURL,omitempty"`
+			CACert             string            `json:"caCert,omitempty"`
+			ClientCert         string            `json:"clientCert,omitempty"`
+			ClientKey          string            `json:"clientKey,omitempty"`
+			InsecureSkipVerify bool              `json:"insecureSkipVerify,omitempty"`
+			DefaultHeaders     map[string]string `json:"defaultHeaders,omitempty"`
 		}
 		profiles := map[string]profileView{}
 		for name, profile := range cfg.Profiles {
+			token := resolveProfileTokenView(cfg, name, revealToken)
 			profiles[name] = profileView{
-				GatewayURL:  profile.GatewayURL,
-				TokenMasked: config.MaskToken(profile.Token),
+				GatewayURL:         profile.GatewayURL,
+				TokenMasked:        token.masked,
+				Token:              token.token,
+				TokenSource:        token.source,
+				TokenRef:           profile.TokenRef,
+				TokenInheritedFrom: token.inheritedFrom,
+				TokenError:         token.err,
+				ProxyURL:           redactProxyURL(profile.ProxyURL),
+				CACert:             profile.CACert,
+				ClientCert:         profile.ClientCert,
+				ClientKey:          profile.ClientKey,
+				InsecureSkipVerify: profile.InsecureSkipVerify,
+				DefaultHeaders:     maskDefaultHeaders(profile.DefaultHeaders),
 			}
 		}
 		payload := map[string]any{
@@ -220,16 +445,38 @@ func (c *CLI) runConfigShow(args []string) error {
 			"profiles":      profiles,
 			"profileCount":  len(profiles),
 		}
-		enc := json.NewEncoder(c.Out)
-		enc.SetIndent("", "  ")
-		if err := enc.Encode(payload); err != nil {
-			return igwerr.NewTransportError(err)
+		if revealToken {
+			delete(payload, "tokenMasked")
+			payload["token"] = cfg.Token
 		}
-		return nil
+		if !cfg.Policy.IsEmpty() {
+			payload["policy"] = cfg.Policy
+		}
+		if cfg.HistoryEnabled() {
+			payload["historyEnabled"] = true
+		}
+		if cfg.Encryption != nil {
+			payload["encryption"] = cfg.Encryption.KDF
+		}
+		if strings.TrimSpace(cfg.ProxyURL) != "" {
+			payload["proxyURL"] = redactProxyURL(cfg.ProxyURL)
+		}
+		if len(cfg.DefaultHeaders) > 0 {
+			payload["defaultHeaders"] = maskDefaultHeaders(cfg.DefaultHeaders)
+		}
+		return writeJSONWithOptions(c.Out, payload, compact)
 	}
 
 	fmt.Fprintf(c.Out, "gateway_url\t%s\n", cfg.GatewayURL)
-	fmt.Fprintf(c.Out, "token\t%s\n", config.MaskToken(cfg.Token))
+	if revealToken {
+		fmt.Fprintf(c.Out, "token\t%s\n", cfg.Token)
+	} else {
+		fmt.Fprintf(c.Out, "token\t%s\n", config.MaskToken(cfg.Token))
+	}
+	if strings.TrimSpace(cfg.ProxyURL) != "" {
+		fmt.Fprintf(c.Out, "proxy_url\t%s\n", redactProxyURL(cfg.ProxyURL))
+	}
+	writeDefaultHeaderLines(c.Out, "default_header", cfg.DefaultHeaders)
 	if strings.TrimSpace(cfg.ActiveProfile) != "" {
 		fmt.Fprintf(c.Out, "active_profile\t%s\n", cfg.ActiveProfile)
 	}
@@ -241,20 +488,103 @@ func (c *CLI) runConfigShow(args []string) error {
 		sort.Strings(names)
 		for _, name := range names {
 			profile := cfg.Profiles[name]
-			fmt.Fprintf(c.Out, "profile\t%s\t%s\t%s\n", name, profile.GatewayURL, config.MaskToken(profile.Token))
+			fmt.Fprintf(c.Out, "profile\t%s\t%s\t%s\n", name, profile.GatewayURL, resolveProfileTokenView(cfg, name, revealToken).text())
+			if strings.TrimSpace(profile.ProxyURL) != "" {
+				fmt.Fprintf(c.Out, "profile_proxy\t%s\t%s\n", name, redactProxyURL(profile.ProxyURL))
+			}
+			if line := profileTLSLine(profile); line != "" {
+				fmt.Fprintf(c.Out, "profile_tls\t%s\t%s\n", name, line)
+			}
+			writeDefaultHeaderLines(c.Out, "profile_default_header\t"+name, profile.DefaultHeaders)
 		}
 	}
+	if !cfg.Policy.IsEmpty() {
+		writePolicyLines(c.Out, cfg.Policy)
+	}
+	if cfg.HistoryEnabled() {
+		writeHistoryLine(c.Out, cfg)
+	}
+	if cfg.Encryption != nil {
+		fmt.Fprintf(c.Out, "encryption\t%s\n", cfg.Encryption.KDF)
+	}
 	return nil
 }
 
+// maskDefaultHeaders masks the values of credential headers such as
+// Authorization for display, using the same set as --har and --verbose.
+func maskDefaultHeaders(headers map[string]string) map[string]string {
+	if len(headers) == 0 {
+		return nil
+	}
+	out := make(map[string]string, len(headers))
+	for name, value := range headers {
+		if harSensitiveHeaders[http.CanonicalHeaderKey(strings.TrimSpace(name))] {
+			value = config.MaskToken(value)
+		}
+		out[name] = value
+	}
+	return out
+}
+
+// writeDefaultHeaderLines prints one "<prefix>\tname\tvalue" line per
+// default header, sorted by name, with credential values masked.
+func writeDefaultHeaderLines(w io.Writer, prefix string, headers map[string]string) {
+	masked := maskDefaultHeaders(headers)
+	names := make([]string, 0, len(masked))
+	for name := range masked {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		fmt.Fprintf(w, "%s\t%s\t%s\n", prefix, name, masked[name])
+	}
+}
+
+// profileTLSLine summarizes a profile's TLS settings for config show.
+func profileTLSLine(profile config.Profile) string {
+	var parts []string
+	if profile.CACert != "" {
+		parts = append(parts, "ca_cert="+profile.CACert)
+	}
+	if profile.ClientCert != "" {
+		parts = append(parts, "client_cert="+profile.ClientCert, "client_key="+profile.ClientKey)
+	}
+	if profile.InsecureSkipVerify {
+		parts = append(parts, "insecure_skip_verify=true")
+	}
+	return strings.Join(parts, "\t")
+}
+
+// redactProxyURL hides proxy credentials for display.
+func redactProxyURL(raw string) string {
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		return ""
+	}
+	proxyURL, err := gateway.ParseProxyURL(raw)
+	if err != nil {
+		// Unparseable URLs may still hold credentials.
+		return "<invalid>"
+	}
+	return gateway.Proxy{URL: proxyURL}.String()
+}
+
+func writeHistoryLine(w io.Writer, cfg config.File) {
+	state := "disabled"
+	if cfg.HistoryEnabled() {
+		state = "enabled"
+	}
+	fmt.Fprintf(w, "history\t%s\n", state)
+}
+
 func (c *CLI) runConfigProfileAdd(args []string) error {
 	jsonRequested := argsWantJSON(args)
 	if len(args) == 0 {
-		return c.printJSONCommandError(jsonRequested, &igwerr.UsageError{Msg: "usage: igw config profile add <name> [flags]"})
+		return c.printJSONCommandErrorWithOptions(jsonRequested, argsWantCompact(args), &igwerr.UsageError{Msg: "usage: igw config profile add <name> [flags]"})
 	}
 	name := strings.TrimSpace(args[0])
 	if strings.HasPrefix(name, "-") || name == "" {
-		return c.printJSONCommandError(jsonRequested, &igwerr.UsageError{Msg: "usage: igw config profile add <name> [flags]"})
+		return c.printJSONCommandErrorWithOptions(jsonRequested, argsWantCompact(args), &igwerr.UsageError{Msg: "usage: igw config profile add <name> [flags]"})
 	}
 
 	fs := flag.NewFlagSet("config profile add", flag.ContinueOnError)
@@ -267,57 +597,78 @@ func (c *CLI) runConfigProfileAdd(args []string) error {
 	var autoGateway bool
 	var apiKey string
 	var apiKeyStdin bool
+	var tokenRef string
 	var makeActive bool
 	var jsonOutput bool
+	var compact bool
+	var proxyURL string
+	var tlsSettings tlsFlags
 
 	fs.StringVar(&gatewayURL, "gateway-url", "", "Gateway base URL")
 	fs.BoolVar(&autoGateway, "auto-gateway", false, "Detect Windows host IP from WSL and set gateway URL")
 	fs.StringVar(&apiKey, "api-key", "", "Ignition API token")
+	bindTLSFlags(fs, &tlsSettings)
 	fs.BoolVar(&apiKeyStdin, "api-key-stdin", false, "Read API token from stdin")
+	fs.StringVar(&tokenRef, "token-ref", "", "Reuse the token of another profile (or @default for the top-level token)")
 	fs.BoolVar(&makeActive, "use", false, "Set added profile as active profile")
+	fs.StringVar(&proxyURL, "proxy-url", "", "Proxy URL for the profile's requests: http://, https://, or socks5://")
 	fs.BoolVar(&jsonOutput, "json", false, "Print JSON output")
+	fs.BoolVar(&compact, "compact", false, "Print compact one-line JSON (requires --json)")
 
 	if err := fs.Parse(args[1:]); err != nil {
-		return c.printJSONCommandError(jsonRequested, &igwerr.UsageError{Msg: err.Error()})
+		return c.printJSONCommandErrorWithOptions(jsonRequested, argsWantCompact(args), &igwerr.UsageError{Msg: err.Error()})
+	}
+	if compact && !jsonOutput {
+		return c.printJSONCommandErrorWithOptions(false, false, &igwerr.UsageError{Msg: "--compact requires --json"})
 	}
 	if fs.NArg() > 0 {
-		return c.printJSONCommandError(jsonOutput, &igwerr.UsageError{Msg: "unexpected positional arguments"})
+		return c.printJSONCommandErrorWithOptions(jsonOutput, compact, &igwerr.UsageError{Msg: "unexpected positional arguments"})
 	}
 
+	tokenRef = strings.TrimSpace(tokenRef)
+	if tokenRef != "" && (apiKey != "" || apiKeyStdin) {
+		return c.printJSONCommandErrorWithOptions(jsonOutput, compact, &igwerr.UsageError{Msg: "use only one of --api-key, --api-key-stdin, or --token-ref"})
+	}
 	if apiKeyStdin {
 		if apiKey != "" {
-			return c.printJSONCommandError(jsonOutput, &igwerr.UsageError{Msg: "use only one of --api-key or --api-key-stdin"})
+			return c.printJSONCommandErrorWithOptions(jsonOutput, compact, &igwerr.UsageError{Msg: "use only one of --api-key or --api-key-stdin"})
 		}
 		tokenBytes, err := io.ReadAll(c.In)
 		if err != nil {
-			return c.printJSONCommandError(jsonOutput, igwerr.NewTransportError(err))
+			return c.printJSONCommandErrorWithOptions(jsonOutput, compact, igwerr.NewTransportError(err))
 		}
 		apiKey = strings.TrimSpace(string(tokenBytes))
 	}
 
 	if autoGateway && strings.TrimSpace(gatewayURL) != "" {
-		return c.printJSONCommandError(jsonOutput, &igwerr.UsageError{Msg: "use only one of --gateway-url or --auto-gateway"})
+		return c.printJSONCommandErrorWithOptions(jsonOutput, compact, &igwerr.UsageError{Msg: "use only one of --gateway-url or --auto-gateway"})
 	}
 	autoGatewaySource := ""
 	if autoGateway {
 		if c.DetectWSLHostIP == nil {
-			return c.printJSONCommandError(jsonOutput, &igwerr.UsageError{Msg: "auto-gateway is not available in this runtime"})
+			return c.printJSONCommandErrorWithOptions(jsonOutput, compact, &igwerr.UsageError{Msg: "auto-gateway is not available in this runtime"})
 		}
 		hostIP, source, detectErr := c.DetectWSLHostIP()
 		if detectErr != nil {
-			return c.printJSONCommandError(jsonOutput, &igwerr.UsageError{Msg: fmt.Sprintf("auto-gateway failed: %v", detectErr)})
+			return c.printJSONCommandErrorWithOptions(jsonOutput, compact, &igwerr.UsageError{Msg: fmt.Sprintf("auto-gateway failed: %v", detectErr)})
 		}
 		gatewayURL = fmt.Sprintf("http://%s:8088", hostIP)
 		autoGatewaySource = source
 	}
 
-	if strings.TrimSpace(gatewayURL) == "" && strings.TrimSpace(apiKey) == "" {
-		return c.printJSONCommandError(jsonOutput, &igwerr.UsageError{Msg: "set at least one of --gateway-url or --api-key"})
+	proxyURL = strings.TrimSpace(proxyURL)
+	if proxyURL != "" {
+		if _, err := gateway.ParseProxyURL(proxyURL); err != nil {
+			return c.printJSONCommandErrorWithOptions(jsonOutput, compact, &igwerr.UsageError{Msg: fmt.Sprintf("--proxy-url: %v", err)})
+		}
+	}
+	if strings.TrimSpace(gatewayURL) == "" && strings.TrimSpace(apiKey) == "" && tokenRef == "" && proxyURL == "" && !tlsSettings.set() {
+		return c.printJSONCommandErrorWithOptions(jsonOutput, compact, &igwerr.UsageError{Msg: "set at least one of --gateway-url, --api-key, --token-ref, --proxy-url, or a TLS flag"})
 	}
 
 	cfg, err := c.ReadConfig()
 	if err != nil {
-		return c.printJSONCommandError(jsonOutput, &igwerr.UsageError{Msg: fmt.Sprintf("load config: %v", err)})
+		return c.printJSONCommandErrorWithOptions(jsonOutput, compact, &igwerr.UsageError{Msg: fmt.Sprintf("load config: %v", err)})
 	}
 	if cfg.Profiles == nil {
 		cfg.Profiles = map[string]config.Profile{}
@@ -330,8 +681,38 @@ func (c *CLI) runConfigProfileAdd(args []string) error {
 	}
 	if strings.TrimSpace(apiKey) != "" {
 		profile.Token = strings.TrimSpace(apiKey)
+		profile.TokenRef = ""
+	}
+	if tokenRef != "" {
+		profile.Token = ""
+		profile.TokenRef = tokenRef
+	}
+	if proxyURL != "" {
+		profile.ProxyURL = proxyURL
+	}
+	if tlsSettings.set() {
+		tlsOpts, err := tlsSettings.tlsOptions(config.Effective{
+			GatewayURL:         profile.GatewayURL,
+			CACert:             profile.CACert,
+			ClientCert:         profile.ClientCert,
+			ClientKey:          profile.ClientKey,
+			InsecureSkipVerify: profile.InsecureSkipVerify,
+		})
+		if err == nil {
+			_, err = tlsOpts.Config()
+		}
+		if err != nil {
+			return c.printJSONCommandErrorWithOptions(jsonOutput, compact, &igwerr.UsageError{Msg: err.Error()})
+		}
+		profile.CACert = tlsOpts.CACert
+		profile.ClientCert = tlsOpts.ClientCert
+		profile.ClientKey = tlsOpts.ClientKey
+		profile.InsecureSkipVerify = tlsOpts.InsecureSkipVerify
 	}
 	cfg.Profiles[name] = profile
+	if _, _, err := cfg.ProfileToken(name); err != nil {
+		return c.printJSONCommandErrorWithOptions(jsonOutput, compact, &igwerr.UsageError{Msg: err.Error()})
+	}
 
 	if makeActive {
 		cfg.ActiveProfile = name
@@ -341,12 +722,15 @@ func (c *CLI) runConfigProfileAdd(args []string) error {
 	}
 
 	if c.WriteConfig == nil {
-		return c.printJSONCommandError(jsonOutput, &igwerr.UsageError{Msg: "config writer is not configured"})
+		return c.printJSONCommandErrorWithOptions(jsonOutput, compact, &igwerr.UsageError{Msg: "config writer is not configured"})
 	}
 	if err := c.WriteConfig(cfg); err != nil {
-		return c.printJSONCommandError(jsonOutput, &igwerr.UsageError{Msg: fmt.Sprintf("save config: %v", err)})
+		return c.printJSONCommandErrorWithOptions(jsonOutput, compact, &igwerr.UsageError{Msg: fmt.Sprintf("save config: %v", err)})
 	}
 	c.invalidateRuntimeCaches()
+	if cfg.Encryption != nil && strings.TrimSpace(apiKey) != "" {
+		fmt.Fprintln(c.Err, "warning: the new token is stored in plaintext; run igw config encrypt to encrypt it")
+	}
 
 	if jsonOutput {
 		payload := map[string]any{
@@ -359,12 +743,12 @@ func (c *CLI) runConfigProfileAdd(args []string) error {
 		if autoGatewaySource != "" {
 			payload["autoGatewaySource"] = autoGatewaySource
 		}
-		return writeJSON(c.Out, payload)
+		return writeJSONWithOptions(c.Out, payload, compact)
 	}
 
-	fmt.Fprintf(c.Out, "saved profile: %s\n", name)
+	c.info("saved profile: %s", name)
 	if cfg.ActiveProfile == name {
-		fmt.Fprintf(c.Out, "active profile: %s\n", name)
+		c.info("active profile: %s", name)
 	}
 	return nil
 }
@@ -372,11 +756,11 @@ func (c *CLI) runConfigProfileAdd(args []string) error {
 func (c *CLI) runConfigProfileUse(args []string) error {
 	jsonRequested := argsWantJSON(args)
 	if len(args) == 0 {
-		return c.printJSONCommandError(jsonRequested, &igwerr.UsageError{Msg: "usage: igw config profile use <name> [flags]"})
+		return c.printJSONCommandErrorWithOptions(jsonRequested, argsWantCompact(args), &igwerr.UsageError{Msg: "usage: igw config profile use <name> [flags]"})
 	}
 	name := strings.TrimSpace(args[0])
 	if strings.HasPrefix(name, "-") || name == "" {
-		return c.printJSONCommandError(jsonRequested, &igwerr.UsageError{Msg: "usage: igw config profile use <name> [flags]"})
+		return c.printJSONCommandErrorWithOptions(jsonRequested, argsWantCompact(args), &igwerr.UsageError{Msg: "usage: igw config profile use <name> [flags]"})
 	}
 
 	fs := flag.NewFlagSet("config profile use", flag.ContinueOnError)
@@ -385,52 +769,252 @@ func (c *CLI) runConfigProfileUse(args []string) error {
 		fs.SetOutput(io.Discard)
 	}
 	var jsonOutput bool
+	var compact bool
 	fs.BoolVar(&jsonOutput, "json", false, "Print JSON output")
+	fs.BoolVar(&compact, "compact", false, "Print compact one-line JSON (requires --json)")
 
 	if err := fs.Parse(args[1:]); err != nil {
-		return c.printJSONCommandError(jsonRequested, &igwerr.UsageError{Msg: err.Error()})
+		return c.printJSONCommandErrorWithOptions(jsonRequested, argsWantCompact(args), &igwerr.UsageError{Msg: err.Error()})
+	}
+	if compact && !jsonOutput {
+		return c.printJSONCommandErrorWithOptions(false, false, &igwerr.UsageError{Msg: "--compact requires --json"})
 	}
 	if fs.NArg() > 0 {
-		return c.printJSONCommandError(jsonOutput, &igwerr.UsageError{Msg: "unexpected positional arguments"})
+		return c.printJSONCommandErrorWithOptions(jsonOutput, compact, &igwerr.UsageError{Msg: "unexpected positional arguments"})
 	}
 	cfg, err := c.ReadConfig()
 	if err != nil {
-		return c.printJSONCommandError(jsonOutput, &igwerr.UsageError{Msg: fmt.Sprintf("load config: %v", err)})
+		return c.printJSONCommandErrorWithOptions(jsonOutput, compact, &igwerr.UsageError{Msg: fmt.Sprintf("load config: %v", err)})
 	}
 	if _, ok := cfg.Profiles[name]; !ok {
-		return c.printJSONCommandError(jsonOutput, &igwerr.UsageError{Msg: fmt.Sprintf("profile %q not found", name)})
+		return c.printJSONCommandErrorWithOptions(jsonOutput, compact, &igwerr.UsageError{Msg: fmt.Sprintf("profile %q not found", name)})
 	}
 	cfg.ActiveProfile = name
 
 	if c.WriteConfig == nil {
-		return c.printJSONCommandError(jsonOutput, &igwerr.UsageError{Msg: "config writer is not configured"})
+		return c.printJSONCommandErrorWithOptions(jsonOutput, compact, &igwerr.UsageError{Msg: "config writer is not configured"})
 	}
 	if err := c.WriteConfig(cfg); err != nil {
-		return c.printJSONCommandError(jsonOutput, &igwerr.UsageError{Msg: fmt.Sprintf("save config: %v", err)})
+		return c.printJSONCommandErrorWithOptions(jsonOutput, compact, &igwerr.UsageError{Msg: fmt.Sprintf("save config: %v", err)})
 	}
 	c.invalidateRuntimeCaches()
 
 	if jsonOutput {
-		return writeJSON(c.Out, map[string]any{
+		return writeJSONWithOptions(c.Out, map[string]any{
 			"ok":     true,
 			"active": name,
-		})
+		}, compact)
 	}
 
-	fmt.Fprintf(c.Out, "active profile: %s\n", name)
+	c.info("active profile: %s", name)
 	return nil
 }
 
+func (c *CLI) runConfigProfileRemove(args []string) error {
+	jsonRequested := argsWantJSON(args)
+	usage := &igwerr.UsageError{Msg: "usage: igw config profile remove <name> [flags]"}
+	if len(args) == 0 {
+		return c.printJSONCommandErrorWithOptions(jsonRequested, argsWantCompact(args), usage)
+	}
+	name := strings.TrimSpace(args[0])
+	if strings.HasPrefix(name, "-") || name == "" {
+		return c.printJSONCommandErrorWithOptions(jsonRequested, argsWantCompact(args), usage)
+	}
+
+	fs := flag.NewFlagSet("config profile remove", flag.ContinueOnError)
+	fs.SetOutput(c.Err)
+	if jsonRequested {
+		fs.SetOutput(io.Discard)
+	}
+	var force bool
+	var jsonOutput bool
+	var compact bool
+	fs.BoolVar(&force, "force", false, "Remove the active profile and clear the active selection")
+	fs.BoolVar(&jsonOutput, "json", false, "Print JSON output")
+	fs.BoolVar(&compact, "compact", false, "Print compact one-line JSON (requires --json)")
+
+	if err := fs.Parse(args[1:]); err != nil {
+		return c.printJSONCommandErrorWithOptions(jsonRequested, argsWantCompact(args), &igwerr.UsageError{Msg: err.Error()})
+	}
+	if compact && !jsonOutput {
+		return c.printJSONCommandErrorWithOptions(false, false, &igwerr.UsageError{Msg: "--compact requires --json"})
+	}
+	if fs.NArg() > 0 {
+		return c.printJSONCommandErrorWithOptions(jsonOutput, compact, &igwerr.UsageError{Msg: "unexpected positional arguments"})
+	}
+	cfg, err := c.ReadConfig()
+	if err != nil {
+		return c.printJSONCommandErrorWithOptions(jsonOutput, compact, &igwerr.UsageError{Msg: fmt.Sprintf("load config: %v", err)})
+	}
+	if _, ok := cfg.Profiles[name]; !ok {
+		return c.printJSONCommandErrorWithOptions(jsonOutput, compact, &igwerr.UsageError{Msg: fmt.Sprintf("profile %q not found", name)})
+	}
+	clearedActive := cfg.ActiveProfile == name
+	if clearedActive && !force {
+		return c.printJSONCommandErrorWithOptions(jsonOutput, compact, &igwerr.UsageError{Msg: fmt.Sprintf("profile %q is the active profile (use --force to remove it anyway)", name)})
+	}
+	delete(cfg.Profiles, name)
+	if clearedActive {
+		cfg.ActiveProfile = ""
+	}
+
+	if c.WriteConfig == nil {
+		return c.printJSONCommandErrorWithOptions(jsonOutput, compact, &igwerr.UsageError{Msg: "config writer is not configured"})
+	}
+	if err := c.WriteConfig(cfg); err != nil {
+		return c.printJSONCommandErrorWithOptions(jsonOutput, compact, &igwerr.UsageError{Msg: fmt.Sprintf("save config: %v", err)})
+	}
+	c.invalidateRuntimeCaches()
+	for _, dependent := range profilesReferencing(cfg, name) {
+		fmt.Fprintf(c.Err, "warning: profile %q still has tokenRef %q\n", dependent, name)
+	}
+
+	if jsonOutput {
+		views := configProfileViews(cfg, false)
+		return writeJSONWithOptions(c.Out, map[string]any{
+			"ok":            true,
+			"removed":       name,
+			"activeProfile": cfg.ActiveProfile,
+			"count":         len(views),
+			"profiles":      views,
+		}, compact)
+	}
+
+	c.info("removed profile: %s", name)
+	if clearedActive {
+		c.info("active profile: (none)")
+	}
+	return nil
+}
+
+func (c *CLI) runConfigProfileRename(args []string) error {
+	jsonRequested := argsWantJSON(args)
+	usage := &igwerr.UsageError{Msg: "usage: igw config profile rename <old> <new> [flags]"}
+	if len(args) < 2 {
+		return c.printJSONCommandErrorWithOptions(jsonRequested, argsWantCompact(args), usage)
+	}
+	oldName := strings.TrimSpace(args[0])
+	newName := strings.TrimSpace(args[1])
+	if oldName == "" || newName == "" || strings.HasPrefix(oldName, "-") || strings.HasPrefix(newName, "-") {
+		return c.printJSONCommandErrorWithOptions(jsonRequested, argsWantCompact(args), usage)
+	}
+
+	fs := flag.NewFlagSet("config profile rename", flag.ContinueOnError)
+	fs.SetOutput(c.Err)
+	if jsonRequested {
+		fs.SetOutput(io.Discard)
+	}
+	var overwrite bool
+	var jsonOutput bool
+	var compact bool
+	fs.BoolVar(&overwrite, "overwrite", false, "Replace an existing profile with the new name")
+	fs.BoolVar(&jsonOutput, "json", false, "Print JSON output")
+	fs.BoolVar(&compact, "compact", false, "Print compact one-line JSON (requires --json)")
+
+	if err := fs.Parse(args[2:]); err != nil {
+		return c.printJSONCommandErrorWithOptions(jsonRequested, argsWantCompact(args), &igwerr.UsageError{Msg: err.Error()})
+	}
+	if compact && !jsonOutput {
+		return c.printJSONCommandErrorWithOptions(false, false, &igwerr.UsageError{Msg: "--compact requires --json"})
+	}
+	if fs.NArg() > 0 {
+		return c.printJSONCommandErrorWithOptions(jsonOutput, compact, &igwerr.UsageError{Msg: "unexpected positional arguments"})
+	}
+	if oldName == newName {
+		return c.printJSONCommandErrorWithOptions(jsonOutput, compact, &igwerr.UsageError{Msg: "old and new profile names are the same"})
+	}
+	if newName == config.TokenRefDefault {
+		return c.printJSONCommandErrorWithOptions(jsonOutput, compact, &igwerr.UsageError{Msg: fmt.Sprintf("profile name %q is reserved", newName)})
+	}
+	cfg, err := c.ReadConfig()
+	if err != nil {
+		return c.printJSONCommandErrorWithOptions(jsonOutput, compact, &igwerr.UsageError{Msg: fmt.Sprintf("load config: %v", err)})
+	}
+	profile, ok := cfg.Profiles[oldName]
+	if !ok {
+		return c.printJSONCommandErrorWithOptions(jsonOutput, compact, &igwerr.UsageError{Msg: fmt.Sprintf("profile %q not found", oldName)})
+	}
+	_, replaced := cfg.Profiles[newName]
+	if replaced && !overwrite {
+		return c.printJSONCommandErrorWithOptions(jsonOutput, compact, &igwerr.UsageError{Msg: fmt.Sprintf("profile %q already exists (use --overwrite to replace it)", newName)})
+	}
+
+	delete(cfg.Profiles, oldName)
+	if profile.TokenRef == newName {
+		// The replaced profile can no longer supply the token.
+		profile.TokenRef = ""
+	}
+	cfg.Profiles[newName] = profile
+	// Keep tokenRef links pointing at the renamed profile.
+	for _, dependent := range profilesReferencing(cfg, oldName) {
+		linked := cfg.Profiles[dependent]
+		linked.TokenRef = newName
+		cfg.Profiles[dependent] = linked
+	}
+	if cfg.ActiveProfile == oldName {
+		cfg.ActiveProfile = newName
+	}
+	if _, _, err := cfg.ProfileToken(newName); err != nil {
+		return c.printJSONCommandErrorWithOptions(jsonOutput, compact, &igwerr.UsageError{Msg: fmt.Sprintf("rename profile: %v", err)})
+	}
+
+	if c.WriteConfig == nil {
+		return c.printJSONCommandErrorWithOptions(jsonOutput, compact, &igwerr.UsageError{Msg: "config writer is not configured"})
+	}
+	if err := c.WriteConfig(cfg); err != nil {
+		return c.printJSONCommandErrorWithOptions(jsonOutput, compact, &igwerr.UsageError{Msg: fmt.Sprintf("save config: %v", err)})
+	}
+	c.invalidateRuntimeCaches()
+
+	if jsonOutput {
+		views := configProfileViews(cfg, false)
+		return writeJSONWithOptions(c.Out, map[string]any{
+			"ok":            true,
+			"renamed":       map[string]string{"from": oldName, "to": newName},
+			"replaced":      replaced,
+			"activeProfile": cfg.ActiveProfile,
+			"count":         len(views),
+			"profiles":      views,
+		}, compact)
+	}
+
+	c.info("renamed profile: %s -> %s", oldName, newName)
+	if replaced {
+		c.info("replaced existing profile: %s", newName)
+	}
+	return nil
+}
+
+// profilesReferencing returns the sorted names of profiles whose tokenRef
+// is name.
+func profilesReferencing(cfg config.File, name string) []string {
+	var names []string
+	for candidate, profile := range cfg.Profiles {
+		if strings.TrimSpace(profile.TokenRef) == name {
+			names = append(names, candidate)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
 func (c *CLI) runConfigProfileList(args []string) error {
 	fs := flag.NewFlagSet("config profile list", flag.ContinueOnError)
 	fs.SetOutput(c.Err)
 
 	var jsonOutput bool
+	var compact bool
+	var revealToken bool
 	fs.BoolVar(&jsonOutput, "json", false, "Print JSON output")
+	fs.BoolVar(&compact, "compact", false, "Print compact one-line JSON (requires --json)")
+	fs.BoolVar(&revealToken, "reveal-token", false, "Print tokens unmasked")
 
 	if err := fs.Parse(args); err != nil {
 		return &igwerr.UsageError{Msg: err.Error()}
 	}
+	if compact && !jsonOutput {
+		return c.printJSONCommandErrorWithOptions(false, false, &igwerr.UsageError{Msg: "--compact requires --json"})
+	}
 	if fs.NArg() > 0 {
 		return &igwerr.UsageError{Msg: "unexpected positional arguments"}
 	}
@@ -439,33 +1023,17 @@ func (c *CLI) runConfigProfileList(args []string) error {
 	if err != nil {
 		return &igwerr.UsageError{Msg: fmt.Sprintf("load config: %v", err)}
 	}
-
-	type profileView struct {
-		Name        string `json:"name"`
-		Active      bool   `json:"active"`
-		GatewayURL  string `json:"gatewayURL,omitempty"`
-		TokenMasked string `json:"tokenMasked,omitempty"`
+	if revealToken {
+		c.warnRevealToken()
 	}
 
-	views := make([]profileView, 0, len(cfg.Profiles))
-	for name, profile := range cfg.Profiles {
-		views = append(views, profileView{
-			Name:        name,
-			Active:      name == cfg.ActiveProfile,
-			GatewayURL:  profile.GatewayURL,
-			TokenMasked: config.MaskToken(profile.Token),
-		})
-	}
-	sort.Slice(views, func(i, j int) bool {
-		return views[i].Name < views[j].Name
-	})
-
+	views := configProfileViews(cfg, revealToken)
 	if jsonOutput {
-		return writeJSON(c.Out, map[string]any{
+		return writeJSONWithOptions(c.Out, map[string]any{
 			"activeProfile": cfg.ActiveProfile,
 			"count":         len(views),
 			"profiles":      views,
-		})
+		}, compact)
 	}
 
 	fmt.Fprintln(c.Out, "ACTIVE\tNAME\tGATEWAY_URL\tTOKEN")
@@ -474,9 +1042,120 @@ func (c *CLI) runConfigProfileList(args []string) error {
 		if view.Active {
 			active = "*"
 		}
-		fmt.Fprintf(c.Out, "%s\t%s\t%s\t%s\n", active, view.Name, view.GatewayURL, view.TokenMasked)
+		fmt.Fprintf(c.Out, "%s\t%s\t%s\t%s\n", active, view.Name, view.GatewayURL, view.tokenText)
+	}
+
+	return nil
+}
+
+type configProfileView struct {
+	Name               string `json:"name"`
+	Active             bool   `json:"active"`
+	GatewayURL         string `json:"gatewayURL,omitempty"`
+	TokenMasked        string `json:"tokenMasked,omitempty"`
+	Token              string `json:"token,omitempty"`
+	TokenSource        string `json:"tokenSource,omitempty"`
+	TokenInheritedFrom string `json:"tokenInheritedFrom,omitempty"`
+	TokenError         string `json:"tokenError,omitempty"`
+
+	tokenText string
+}
+
+// configProfileViews lists the profiles sorted by name, as printed by
+// `config profile list`.
+func configProfileViews(cfg config.File, revealToken bool) []configProfileView {
+	views := make([]configProfileView, 0, len(cfg.Profiles))
+	for name, profile := range cfg.Profiles {
+		token := resolveProfileTokenView(cfg, name, revealToken)
+		views = append(views, configProfileView{
+			Name:               name,
+			Active:             name == cfg.ActiveProfile,
+			GatewayURL:         profile.GatewayURL,
+			TokenMasked:        token.masked,
+			Token:              token.token,
+			TokenSource:        token.source,
+			TokenInheritedFrom: token.inheritedFrom,
+			TokenError:         token.err,
+			tokenText:          token.text(),
+		})
+	}
+	sort.Slice(views, func(i, j int) bool {
+		return views[i].Name < views[j].Name
+	})
+	return views
+}
+
+// profileTokenView carries either the masked token or, with --reveal-token,
+// the real one; never both.
+type profileTokenView struct {
+	masked        string
+	token         string
+	source        string
+	inheritedFrom string
+	err           string
+}
+
+// resolveProfileTokenView follows a profile's tokenRef so listings show the
+// token that will actually be sent, masked unless reveal is set, along with
+// where it came from.
+func resolveProfileTokenView(cfg config.File, name string, reveal bool) profileTokenView {
+	token, from, err := cfg.ProfileToken(name)
+	if err != nil {
+		return profileTokenView{err: err.Error()}
+	}
+	if source, _, _ := cfg.ProfileTokenSource(name); source != "" {
+		// Env and command tokens are only read at call time.
+		return profileTokenView{source: source, inheritedFrom: from}
+	}
+	if reveal {
+		return profileTokenView{token: token, inheritedFrom: from}
 	}
+	return profileTokenView{masked: config.MaskToken(token), inheritedFrom: from}
+}
 
+func (v profileTokenView) text() string {
+	display := v.masked
+	if v.token != "" {
+		display = v.token
+	}
+	if v.source != "" {
+		display = v.source
+	}
+	switch {
+	case v.err != "":
+		return "error: " + v.err
+	case v.inheritedFrom != "":
+		return fmt.Sprintf("%s (inherited from %s)", display, v.inheritedFrom)
+	default:
+		return display
+	}
+}
+
+func (c *CLI) warnRevealToken() {
+	fmt.Fprintln(c.Err, "warning: --reveal-token prints API tokens in plain text; check who can see this screen or output")
+}
+
+// runConfigProfileNames prints sorted profile names one per line with no
+// header so shell completion can consume the output directly.
+func (c *CLI) runConfigProfileNames(args []string) error {
+	if len(args) > 0 {
+		return &igwerr.UsageError{Msg: "unexpected arguments"}
+	}
+
+	cfg, err := c.ReadConfig()
+	if err != nil {
+		return &igwerr.UsageError{Msg: fmt.Sprintf("load config: %v", err)}
+	}
+
+	names := make([]string, 0, len(cfg.Profiles))
+	for name := range cfg.Profiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		fmt.Fprintln(c.Out, name)
+	}
 	return nil
 }
 
@@ -0,0 +1,206 @@
+package cli
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+
+	"github.com/alex-mccollum/igw-cli/internal/config"
+	"github.com/alex-mccollum/igw-cli/internal/exitcode"
+	"github.com/alex-mccollum/igw-cli/internal/igwerr"
+)
+
+// configDecryptError reports encrypted config tokens that could not be
+// opened; it exits with the auth code like a rejected token.
+type configDecryptError struct {
+	err error
+}
+
+func (e *configDecryptError) Error() string {
+	return e.err.Error()
+}
+
+func (e *configDecryptError) Unwrap() error {
+	return e.err
+}
+
+func (e *configDecryptError) ExitCode() int {
+	return exitcode.Auth
+}
+
+// configPassphrase is the --passphrase-stdin value, falling back to
+// IGW_CONFIG_PASSPHRASE.
+func (c *CLI) configPassphrase() string {
+	if c.runtime != nil {
+		c.runtime.mu.RLock()
+		passphrase := c.runtime.configPassphrase
+		c.runtime.mu.RUnlock()
+		if passphrase != "" {
+			return passphrase
+		}
+	}
+	if c.Getenv == nil {
+		return ""
+	}
+	return c.Getenv(config.EnvPassphrase)
+}
+
+// readPassphraseStdin stores the --passphrase-stdin value for resolving
+// encrypted config tokens.
+func (c *CLI) readPassphraseStdin(common wrapperCommon) error {
+	if !common.passphraseStdin {
+		return nil
+	}
+	if common.apiKeyStdin {
+		return &igwerr.UsageError{Msg: "use only one of --api-key-stdin or --passphrase-stdin"}
+	}
+	passphrase, err := c.readStdinPassphrase()
+	if err != nil {
+		return err
+	}
+	if c.runtime == nil {
+		c.runtime = newRuntimeState()
+	}
+	c.runtime.mu.Lock()
+	c.runtime.configPassphrase = passphrase
+	c.runtime.mu.Unlock()
+	return nil
+}
+
+// readStdinPassphrase reads a passphrase from stdin, dropping only the
+// trailing line break so surrounding spaces stay significant.
+func (c *CLI) readStdinPassphrase() (string, error) {
+	b, err := io.ReadAll(c.In)
+	if err != nil {
+		return "", igwerr.NewTransportError(err)
+	}
+	passphrase := strings.TrimRight(string(b), "\r\n")
+	if passphrase == "" {
+		return "", &igwerr.UsageError{Msg: "--passphrase-stdin read an empty passphrase"}
+	}
+	return passphrase, nil
+}
+
+func (c *CLI) runConfigEncrypt(args []string) error {
+	return c.runConfigCrypt("config encrypt", args, true)
+}
+
+func (c *CLI) runConfigDecrypt(args []string) error {
+	return c.runConfigCrypt("config decrypt", args, false)
+}
+
+// runConfigCrypt implements `config encrypt` and `config decrypt`, which
+// rewrite every stored token in place.
+func (c *CLI) runConfigCrypt(name string, args []string, encrypt bool) error {
+	jsonRequested := argsWantJSON(args)
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	fs.SetOutput(c.Err)
+	if jsonRequested {
+		fs.SetOutput(io.Discard)
+	}
+
+	var passphraseStdin bool
+	var keyFile string
+	var jsonOutput bool
+	var compact bool
+	fs.BoolVar(&passphraseStdin, "passphrase-stdin", false, "Read the passphrase from stdin instead of "+config.EnvPassphrase)
+	if encrypt {
+		fs.StringVar(&keyFile, "key-file", "", "Derive the key from this file instead of a passphrase")
+	}
+	fs.BoolVar(&jsonOutput, "json", false, "Print JSON output")
+	fs.BoolVar(&compact, "compact", false, "Print compact one-line JSON (requires --json)")
+
+	if err := fs.Parse(args); err != nil {
+		return c.printJSONCommandErrorWithOptions(jsonRequested, argsWantCompact(args), &igwerr.UsageError{Msg: err.Error()})
+	}
+	if compact && !jsonOutput {
+		return c.printJSONCommandErrorWithOptions(false, false, &igwerr.UsageError{Msg: "--compact requires --json"})
+	}
+	if fs.NArg() > 0 {
+		return c.printJSONCommandErrorWithOptions(jsonOutput, compact, &igwerr.UsageError{Msg: "unexpected positional arguments"})
+	}
+	keyFile = strings.TrimSpace(keyFile)
+	if passphraseStdin && keyFile != "" {
+		return c.printJSONCommandErrorWithOptions(jsonOutput, compact, &igwerr.UsageError{Msg: "use only one of --passphrase-stdin or --key-file"})
+	}
+
+	cfg, err := c.ReadConfig()
+	if err != nil {
+		return c.printJSONCommandErrorWithOptions(jsonOutput, compact, &igwerr.UsageError{Msg: fmt.Sprintf("load config: %v", err)})
+	}
+
+	enc := cfg.Encryption
+	switch {
+	case encrypt && enc == nil:
+		enc, err = config.NewEncryption(keyFile)
+		if err != nil {
+			return c.printJSONCommandErrorWithOptions(jsonOutput, compact, err)
+		}
+	case encrypt && keyFile != "" && !sameKeyFile(enc, keyFile):
+		return c.printJSONCommandErrorWithOptions(jsonOutput, compact, &igwerr.UsageError{Msg: "config is already encrypted with another key; run igw config decrypt first"})
+	case !encrypt && enc == nil:
+		return c.printJSONCommandErrorWithOptions(jsonOutput, compact, &igwerr.UsageError{Msg: "config tokens are not encrypted"})
+	}
+
+	passphrase := ""
+	if enc.KDF == config.KDFScrypt {
+		if passphraseStdin {
+			passphrase, err = c.readStdinPassphrase()
+			if err != nil {
+				return c.printJSONCommandErrorWithOptions(jsonOutput, compact, err)
+			}
+		} else if c.Getenv != nil {
+			passphrase = c.Getenv(config.EnvPassphrase)
+		}
+		if passphrase == "" {
+			return c.printJSONCommandErrorWithOptions(jsonOutput, compact, &igwerr.UsageError{Msg: "required: --passphrase-stdin, --key-file, or " + config.EnvPassphrase})
+		}
+	}
+
+	key, err := enc.Key(passphrase)
+	if err != nil {
+		return c.printJSONCommandErrorWithOptions(jsonOutput, compact, &configDecryptError{err: err})
+	}
+	var changed int
+	if encrypt {
+		changed, err = cfg.EncryptTokens(key)
+		cfg.Encryption = enc
+	} else {
+		changed, err = cfg.DecryptTokens(key)
+		cfg.Encryption = nil
+	}
+	if err != nil {
+		return c.printJSONCommandErrorWithOptions(jsonOutput, compact, &configDecryptError{err: err})
+	}
+
+	if c.WriteConfig == nil {
+		return c.printJSONCommandErrorWithOptions(jsonOutput, compact, &igwerr.UsageError{Msg: "config writer is not configured"})
+	}
+	if err := c.WriteConfig(cfg); err != nil {
+		return c.printJSONCommandErrorWithOptions(jsonOutput, compact, &igwerr.UsageError{Msg: fmt.Sprintf("save config: %v", err)})
+	}
+	c.invalidateRuntimeCaches()
+
+	action := "decrypted"
+	if encrypt {
+		action = "encrypted"
+	}
+	if jsonOutput {
+		return writeJSONWithOptions(c.Out, map[string]any{
+			"ok":     true,
+			"action": action,
+			"kdf":    enc.KDF,
+			"tokens": changed,
+		}, compact)
+	}
+	fmt.Fprintf(c.Out, "%s\ttokens=%d\tkdf=%s\n", action, changed, enc.KDF)
+	return nil
+}
+
+// sameKeyFile reports whether enc already uses the key file at path.
+func sameKeyFile(enc *config.Encryption, path string) bool {
+	abs, err := filepath.Abs(path)
+	return err == nil && enc.KDF == config.KDFKeyFile && enc.KeyFile == abs
+}
@@ -0,0 +1,107 @@
+package cli
+
+import (
+	"bytes"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/alex-mccollum/igw-cli/internal/config"
+	"github.com/alex-mccollum/igw-cli/internal/exitcode"
+	"github.com/alex-mccollum/igw-cli/internal/gateway"
+)
+
+type encryptTestEnv struct {
+	cfg        config.File
+	passphrase string
+	sentTokens []string
+}
+
+func (e *encryptTestEnv) cli(in string, out *bytes.Buffer) *CLI {
+	return &CLI{
+		In:  strings.NewReader(in),
+		Out: out,
+		Err: new(bytes.Buffer),
+		Getenv: func(key string) string {
+			if key == config.EnvPassphrase {
+				return e.passphrase
+			}
+			return ""
+		},
+		ReadConfig: func() (config.File, error) {
+			return e.cfg, nil
+		},
+		WriteConfig: func(cfg config.File) error {
+			e.cfg = cfg
+			return nil
+		},
+		HTTPClient: newMockHTTPClient(func(r *http.Request) (*http.Response, error) {
+			e.sentTokens = append(e.sentTokens, r.Header.Get(gateway.TokenHeader))
+			return mockHTTPResponse(http.StatusOK, `{}`, nil), nil
+		}),
+	}
+}
+
+func TestConfigEncryptResolveAndDecrypt(t *testing.T) {
+	env := &encryptTestEnv{
+		cfg: config.File{
+			Token: "top-token",
+			Profiles: map[string]config.Profile{
+				"dev": {GatewayURL: mockGatewayURL, Token: "dev-token"},
+			},
+		},
+		passphrase: "s3cret pass",
+	}
+
+	var out bytes.Buffer
+	if err := env.cli("", &out).Execute([]string{"config", "encrypt"}); err != nil {
+		t.Fatalf("config encrypt: %v", err)
+	}
+	if out.String() != "encrypted\ttokens=2\tkdf=scrypt\n" {
+		t.Fatalf("unexpected output %q", out.String())
+	}
+	if env.cfg.Encryption == nil || !config.IsEncryptedToken(env.cfg.Token) || !config.IsEncryptedToken(env.cfg.Profiles["dev"].Token) {
+		t.Fatalf("expected encrypted tokens, got %+v", env.cfg)
+	}
+
+	// Listings stay masked and need no passphrase.
+	env.passphrase = ""
+	out.Reset()
+	if err := env.cli("", &out).Execute([]string{"config", "profile", "list"}); err != nil {
+		t.Fatalf("profile list: %v", err)
+	}
+	if !strings.Contains(out.String(), "enc:v1:****") || strings.Contains(out.String(), "dev-token") {
+		t.Fatalf("unexpected profile list %q", out.String())
+	}
+
+	err := env.cli("", &out).Execute([]string{"call", "--profile", "dev", "--path", "/data/api/v1/gateway-info"})
+	if code := exitCodeForError(err); code != exitcode.Auth {
+		t.Fatalf("expected auth exit code without a passphrase, got %d (%v)", code, err)
+	}
+	err = env.cli("wrong\n", &out).Execute([]string{"call", "--profile", "dev", "--path", "/data/api/v1/gateway-info", "--passphrase-stdin"})
+	if code := exitCodeForError(err); code != exitcode.Auth || !strings.Contains(err.Error(), "wrong passphrase") {
+		t.Fatalf("expected auth exit code for a wrong passphrase, got %d (%v)", code, err)
+	}
+	if len(env.sentTokens) != 0 {
+		t.Fatalf("expected no requests before decryption succeeds, got %v", env.sentTokens)
+	}
+	if err := env.cli("s3cret pass\n", &out).Execute([]string{"call", "--profile", "dev", "--path", "/data/api/v1/gateway-info", "--passphrase-stdin"}); err != nil {
+		t.Fatalf("call with passphrase: %v", err)
+	}
+	if len(env.sentTokens) != 1 || env.sentTokens[0] != "dev-token" {
+		t.Fatalf("expected the decrypted token to be sent, got %v", env.sentTokens)
+	}
+
+	requireUsageExitCode(t, env.cli("", &out).Execute([]string{"config", "decrypt"}))
+	out.Reset()
+	if err := env.cli("s3cret pass\n", &out).Execute([]string{"config", "decrypt", "--passphrase-stdin", "--json"}); err != nil {
+		t.Fatalf("config decrypt: %v", err)
+	}
+	if env.cfg.Encryption != nil || env.cfg.Token != "top-token" || env.cfg.Profiles["dev"].Token != "dev-token" {
+		t.Fatalf("expected plaintext tokens after decrypt, got %+v", env.cfg)
+	}
+	if !strings.Contains(out.String(), `"tokens": 2`) {
+		t.Fatalf("unexpected decrypt output %q", out.String())
+	}
+	requireUsageExitCode(t, env.cli("", &out).Execute([]string{"config", "decrypt"}))
+}
@@ -46,13 +46,13 @@ func TestConfigShowMasksToken(t *testing.T) {
 func TestConfigSetAutoGateway(t *testing.T) {
 	t.Parallel()
 
-	var out bytes.Buffer
+	var out, errOut bytes.Buffer
 	var saved config.File
 
 	c := &CLI{
 		In:     strings.NewReader(""),
 		Out:    &out,
-		Err:    new(bytes.Buffer),
+		Err:    &errOut,
 		Getenv: func(string) string { return "" },
 		ReadConfig: func() (config.File, error) {
 			return config.File{}, nil
@@ -75,8 +75,11 @@ func TestConfigSetAutoGateway(t *testing.T) {
 		t.Fatalf("unexpected saved gateway url: %q", saved.GatewayURL)
 	}
 
-	if !strings.Contains(out.String(), "auto-detected gateway URL") {
-		t.Fatalf("expected auto-detect output, got %q", out.String())
+	if !strings.Contains(errOut.String(), "auto-detected gateway URL") {
+		t.Fatalf("expected auto-detect output, got %q", errOut.String())
+	}
+	if out.Len() != 0 {
+		t.Fatalf("unexpected stdout %q", out.String())
 	}
 }
 
@@ -165,6 +168,44 @@ func TestConfigSetJSONOutput(t *testing.T) {
 	}
 }
 
+func TestConfigShowListsDefaultHeadersMasked(t *testing.T) {
+	t.Parallel()
+
+	var out bytes.Buffer
+	c := &CLI{
+		In:     strings.NewReader(""),
+		Out:    &out,
+		Err:    new(bytes.Buffer),
+		Getenv: func(string) string { return "" },
+		ReadConfig: func() (config.File, error) {
+			return config.File{
+				DefaultHeaders: map[string]string{"X-Org-Id": "acme", "Authorization": "Bearer topsecretvalue"},
+				Profiles: map[string]config.Profile{
+					"dev": {GatewayURL: "http://d", DefaultHeaders: map[string]string{"X-Env": "dev"}},
+				},
+			}, nil
+		},
+	}
+
+	if err := c.Execute([]string{"config", "show"}); err != nil {
+		t.Fatalf("config show failed: %v", err)
+	}
+	text := out.String()
+	for _, want := range []string{"default_header\tAuthorization\tBear...ue\n", "default_header\tX-Org-Id\tacme\n", "profile_default_header\tdev\tX-Env\tdev\n"} {
+		if !strings.Contains(text, want) {
+			t.Fatalf("expected %q in %q", want, text)
+		}
+	}
+
+	out.Reset()
+	if err := c.Execute([]string{"config", "show", "--json"}); err != nil {
+		t.Fatalf("config show --json failed: %v", err)
+	}
+	if strings.Contains(out.String(), "topsecretvalue") || !strings.Contains(out.String(), `"X-Org-Id": "acme"`) {
+		t.Fatalf("expected masked default headers in %s", out.String())
+	}
+}
+
 func TestConfigShowTextSortsProfiles(t *testing.T) {
 	t.Parallel()
 
@@ -195,3 +236,131 @@ func TestConfigShowTextSortsProfiles(t *testing.T) {
 		t.Fatalf("profiles not sorted in text output: %q", got)
 	}
 }
+
+func TestConfigShowCompactJSON(t *testing.T) {
+	t.Parallel()
+
+	newCLI := func(out *bytes.Buffer) *CLI {
+		return &CLI{
+			In:     strings.NewReader(""),
+			Out:    out,
+			Err:    new(bytes.Buffer),
+			Getenv: func(string) string { return "" },
+			ReadConfig: func() (config.File, error) {
+				return config.File{
+					GatewayURL: "http://127.0.0.1:8088",
+					Token:      "abcd1234xyz",
+					Profiles: map[string]config.Profile{
+						"dev": {GatewayURL: "http://dev:8088", Token: "dev-token"},
+					},
+				}, nil
+			},
+		}
+	}
+
+	var compactOut bytes.Buffer
+	if err := newCLI(&compactOut).Execute([]string{"config", "show", "--json", "--compact"}); err != nil {
+		t.Fatalf("config show --json --compact failed: %v", err)
+	}
+	compact := compactOut.String()
+	if strings.Count(compact, "\n") != 1 || !strings.HasSuffix(compact, "\n") {
+		t.Fatalf("expected single-line JSON, got %q", compact)
+	}
+	var payload map[string]any
+	if err := json.Unmarshal([]byte(compact), &payload); err != nil {
+		t.Fatalf("decode compact output: %v", err)
+	}
+	if payload["profileCount"] != float64(1) {
+		t.Fatalf("unexpected profileCount: %#v", payload["profileCount"])
+	}
+
+	var indentedOut bytes.Buffer
+	if err := newCLI(&indentedOut).Execute([]string{"config", "show", "--json"}); err != nil {
+		t.Fatalf("config show --json failed: %v", err)
+	}
+	if !strings.Contains(indentedOut.String(), "\n  \"gatewayURL\"") {
+		t.Fatalf("expected indented JSON by default, got %q", indentedOut.String())
+	}
+
+	err := newCLI(new(bytes.Buffer)).Execute([]string{"config", "show", "--compact"})
+	var usageErr *igwerr.UsageError
+	if !errors.As(err, &usageErr) {
+		t.Fatalf("expected usage error for --compact without --json, got %v", err)
+	}
+}
+
+func TestConfigRevealToken(t *testing.T) {
+	t.Parallel()
+
+	newCLI := func(out *bytes.Buffer, errOut *bytes.Buffer) *CLI {
+		return &CLI{
+			In:     strings.NewReader(""),
+			Out:    out,
+			Err:    errOut,
+			Getenv: func(string) string { return "" },
+			ReadConfig: func() (config.File, error) {
+				return config.File{
+					GatewayURL: "http://127.0.0.1:8088",
+					Token:      "abcd1234xyz",
+					Profiles: map[string]config.Profile{
+						"dev": {GatewayURL: "http://dev:8088", Token: "dev-token-secret"},
+					},
+				}, nil
+			},
+		}
+	}
+
+	for _, args := range [][]string{
+		{"config", "show"},
+		{"config", "show", "--json"},
+		{"config", "profile", "list"},
+		{"config", "profile", "list", "--json"},
+	} {
+		var out, errOut bytes.Buffer
+		if err := newCLI(&out, &errOut).Execute(args); err != nil {
+			t.Fatalf("%v failed: %v", args, err)
+		}
+		if strings.Contains(out.String(), "abcd1234xyz") || strings.Contains(out.String(), "dev-token-secret") {
+			t.Fatalf("%v leaked a token by default: %s", args, out.String())
+		}
+		if strings.Contains(out.String(), `"token"`) {
+			t.Fatalf("%v should only emit tokenMasked by default: %s", args, out.String())
+		}
+		if errOut.Len() != 0 {
+			t.Fatalf("%v: unexpected stderr %q", args, errOut.String())
+		}
+
+		var revealOut, revealErr bytes.Buffer
+		if err := newCLI(&revealOut, &revealErr).Execute(append(args, "--reveal-token")); err != nil {
+			t.Fatalf("%v --reveal-token failed: %v", args, err)
+		}
+		if !strings.Contains(revealOut.String(), "dev-token-secret") {
+			t.Fatalf("%v --reveal-token did not reveal the profile token: %s", args, revealOut.String())
+		}
+		if !strings.Contains(revealErr.String(), "warning: --reveal-token") {
+			t.Fatalf("%v --reveal-token should warn on stderr, got %q", args, revealErr.String())
+		}
+	}
+
+	var out bytes.Buffer
+	if err := newCLI(&out, new(bytes.Buffer)).Execute([]string{"config", "show", "--json", "--reveal-token"}); err != nil {
+		t.Fatalf("config show --json --reveal-token failed: %v", err)
+	}
+	var payload struct {
+		Token       *string `json:"token"`
+		TokenMasked *string `json:"tokenMasked"`
+		Profiles    map[string]struct {
+			Token       string  `json:"token"`
+			TokenMasked *string `json:"tokenMasked"`
+		} `json:"profiles"`
+	}
+	if err := json.Unmarshal(out.Bytes(), &payload); err != nil {
+		t.Fatalf("decode output: %v", err)
+	}
+	if payload.Token == nil || *payload.Token != "abcd1234xyz" || payload.TokenMasked != nil {
+		t.Fatalf("expected token instead of tokenMasked, got %s", out.String())
+	}
+	if payload.Profiles["dev"].Token != "dev-token-secret" || payload.Profiles["dev"].TokenMasked != nil {
+		t.Fatalf("unexpected profile token view %s", out.String())
+	}
+}
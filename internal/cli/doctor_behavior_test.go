@@ -2,6 +2,7 @@ package cli
 
 import (
 	"bytes"
+	"net"
 	"net/http"
 	"net/http/httptest"
 	"strings"
@@ -128,6 +129,65 @@ func TestDoctorHintForTimeoutTransportError(t *testing.T) {
 	}
 }
 
+func TestDoctorSuggestFixForWSLTimeout(t *testing.T) {
+	t.Parallel()
+
+	release := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-release:
+		case <-r.Context().Done():
+		}
+	}))
+	defer srv.Close()
+	defer close(release)
+
+	_, port, err := net.SplitHostPort(strings.TrimPrefix(srv.URL, "http://"))
+	if err != nil {
+		t.Fatalf("split server address: %v", err)
+	}
+
+	var out bytes.Buffer
+	c := newDoctorTestCLI(srv.Client(), &out)
+	c.DetectWSL = func() bool { return true }
+
+	err = c.Execute([]string{
+		"doctor",
+		"--gateway-url", srv.URL,
+		"--api-key", "secret",
+		"--timeout", "100ms",
+		"--suggest-fix",
+	})
+	if err == nil {
+		t.Fatalf("expected timeout failure")
+	}
+
+	got := out.String()
+	wantRule := "New-NetFirewallRule -DisplayName \"Ignition Gateway " + port + " (WSL)\" -Direction Inbound -InterfaceAlias \"vEthernet (WSL (Hyper-V firewall))\" -Protocol TCP -LocalPort " + port
+	if !strings.Contains(got, "fix\tgateway_info\tpowershell (admin): "+wantRule) {
+		t.Fatalf("missing firewall suggestion in output: %q", got)
+	}
+	if !strings.Contains(got, "localport="+port) {
+		t.Fatalf("missing netsh suggestion in output: %q", got)
+	}
+}
+
+func TestDoctorFixForErrorIsPlatformAware(t *testing.T) {
+	t.Parallel()
+
+	timeout := &igwerr.TransportError{Timeout: true}
+	if fix := doctorFixForError(timeout, "8088", false); fix != nil {
+		t.Fatalf("expected no suggestion outside WSL, got %q", fix)
+	}
+	if fix := doctorFixForError(&igwerr.TransportError{}, "8088", true); fix != nil {
+		t.Fatalf("expected no suggestion for non-timeout error, got %q", fix)
+	}
+	fix := doctorFixForError(timeout, "8088", true)
+	if len(fix) != 2 || !strings.Contains(fix[0], "-LocalPort 8088") || !strings.Contains(fix[0], wslFirewallInterfaceAlias) {
+		t.Fatalf("unexpected suggestion %q", fix)
+	}
+}
+
 func newDoctorTestCLI(httpClient *http.Client, out *bytes.Buffer) *CLI {
 	if out == nil {
 		out = new(bytes.Buffer)
@@ -5,7 +5,6 @@ import (
 	"errors"
 	"flag"
 	"fmt"
-	"io"
 	"net"
 	"net/http"
 	"net/url"
@@ -23,9 +22,23 @@ func (c *CLI) runDoctor(args []string) error {
 
 	var common wrapperCommon
 	var checkWrite bool
+	var suggestFix bool
+	var remediate bool
+	var remediationOut string
+	var probePaths stringList
+	var outputFormat string
+	var outputTemplate string
 
 	bindWrapperCommonWithDefaults(fs, &common, 5*time.Second, false)
+	bindHARFlags(fs, &common.har)
+	bindVerboseFlags(fs, &common.verbose)
 	fs.BoolVar(&checkWrite, "check-write", false, "Include mutating write-permission check (scan projects)")
+	fs.BoolVar(&suggestFix, "suggest-fix", false, "Print platform-specific remediation commands for failed checks")
+	fs.BoolVar(&remediate, "remediate", false, "Attach a ready-to-run remediation (never executed) to failed checks, e.g. the WSL firewall rule")
+	fs.StringVar(&remediationOut, "remediation-out", "", "Also write remediation commands to this file (requires --remediate)")
+	fs.Var(&probePaths, "probe-paths", "Extra GET paths to probe for token_permissions (repeatable, comma-separated)")
+	fs.StringVar(&outputFormat, "output", outputFormatText, "Text output format: text|markdown")
+	fs.StringVar(&outputTemplate, "output-template", "", "Render the JSON result with a Go text/template (inline or @file)")
 
 	if err := fs.Parse(args); err != nil {
 		return &igwerr.UsageError{Msg: err.Error()}
@@ -33,37 +46,28 @@ func (c *CLI) runDoctor(args []string) error {
 	if fs.NArg() > 0 {
 		return &igwerr.UsageError{Msg: "unexpected positional arguments"}
 	}
+	if strings.TrimSpace(remediationOut) != "" && !remediate {
+		return &igwerr.UsageError{Msg: "--remediation-out requires --remediate"}
+	}
+	probes, err := parseProbePaths(probePaths)
+	if err != nil {
+		return err
+	}
 
-	selectOpts, selectErr := newJSONSelectOptions(common.jsonOutput, common.compactJSON, common.rawOutput, common.selectors)
+	selectOpts, selectErr := common.selectOptions()
 	if selectErr != nil {
 		return selectErr
 	}
-
-	if common.apiKeyStdin {
-		if common.apiKey != "" {
-			return &igwerr.UsageError{Msg: "use only one of --api-key or --api-key-stdin"}
-		}
-		tokenBytes, err := io.ReadAll(c.In)
-		if err != nil {
-			return igwerr.NewTransportError(err)
-		}
-		common.apiKey = strings.TrimSpace(string(tokenBytes))
-	}
-
-	resolved, err := c.resolveRuntimeConfig(common.profile, common.gatewayURL, common.apiKey)
+	outputTmpl, err := resolveOutputTemplate(outputFormat, outputTemplate, common.jsonOutput, doctorMarkdownTemplate)
 	if err != nil {
 		return err
 	}
 
-	if strings.TrimSpace(resolved.GatewayURL) == "" {
-		return &igwerr.UsageError{Msg: "required: --gateway-url (or IGNITION_GATEWAY_URL/config)"}
-	}
-	if strings.TrimSpace(resolved.Token) == "" {
-		return &igwerr.UsageError{Msg: "required: --api-key (or IGNITION_API_TOKEN/config)"}
-	}
-	if common.timeout <= 0 {
-		return &igwerr.UsageError{Msg: "--timeout must be positive"}
+	client, resolved, saveHAR, err := c.newGatewayClient(common)
+	if err != nil {
+		return err
 	}
+	defer saveHAR()
 
 	checks := make([]doctorCheck, 0, 4)
 	stats := map[string]any{}
@@ -77,7 +81,7 @@ func (c *CLI) runDoctor(args []string) error {
 			Message: uerr.Error(),
 			Hint:    "Use a full URL like http://<windows-host-ip>:8088",
 		})
-		return c.printDoctorResult(common.jsonOutput, selectOpts, resolved.GatewayURL, checks, stats, uerr)
+		return c.printDoctorResult(common.jsonOutput, selectOpts, outputTmpl, resolved.GatewayURL, checks, stats, uerr)
 	}
 	checks = append(checks, doctorCheck{
 		Name:    "gateway_url",
@@ -94,39 +98,74 @@ func (c *CLI) runDoctor(args []string) error {
 			Message: uerr.Error(),
 			Hint:    "Gateway URL must include a valid host and scheme",
 		})
-		return c.printDoctorResult(common.jsonOutput, selectOpts, resolved.GatewayURL, checks, stats, uerr)
+		return c.printDoctorResult(common.jsonOutput, selectOpts, outputTmpl, resolved.GatewayURL, checks, stats, uerr)
 	}
 
+	fixFor := func(error) []string { return nil }
+	if suggestFix {
+		inWSL := c.DetectWSL != nil && c.DetectWSL()
+		_, port, _ := net.SplitHostPort(addr)
+		fixFor = func(err error) []string { return doctorFixForError(err, port, inWSL) }
+	}
+
+	// Dial through the proxy requests will use, so tcp_connect reflects the
+	// path they take.
+	viaProxy, err := client.Proxy.Resolve(parsedURL)
+	if err != nil {
+		return &igwerr.UsageError{Msg: fmt.Sprintf("proxy from environment: %v", err)}
+	}
 	tcpStart := time.Now()
-	conn, err := net.DialTimeout("tcp", addr, common.timeout)
+	dialCtx, cancelDial := context.WithTimeout(context.Background(), common.timeout)
+	conn, err := gateway.DialContext(dialCtx, viaProxy, addr)
+	cancelDial()
 	if err != nil {
 		nerr := igwerr.NewTransportError(err)
-		checks = append(checks, doctorCheck{
+		check := doctorCheck{
 			Name:    "tcp_connect",
 			OK:      false,
 			Message: nerr.Error(),
 			Hint:    doctorHintForError(nerr),
-		})
+			Fix:     fixFor(nerr),
+		}
+		if remediate {
+			check.Remediation = c.doctorRemediationForConnect(nerr, addr)
+			if err := writeDoctorRemediation(remediationOut, check.Remediation); err != nil {
+				return err
+			}
+		}
+		checks = append(checks, check)
 		if common.timing || common.jsonStats {
 			stats["tcpConnectMs"] = time.Since(tcpStart).Milliseconds()
 		}
-		return c.printDoctorResult(common.jsonOutput, selectOpts, resolved.GatewayURL, checks, stats, nerr)
+		return c.printDoctorResult(common.jsonOutput, selectOpts, outputTmpl, resolved.GatewayURL, checks, stats, nerr)
+	}
+	connectMessage := addr
+	if viaProxy != nil {
+		connectMessage = fmt.Sprintf("%s via proxy %s", addr, gateway.Proxy{URL: viaProxy})
 	}
-	_ = conn.Close()
 	checks = append(checks, doctorCheck{
 		Name:    "tcp_connect",
 		OK:      true,
-		Message: addr,
+		Message: connectMessage,
 	})
 	if common.timing || common.jsonStats {
 		stats["tcpConnectMs"] = time.Since(tcpStart).Milliseconds()
 	}
-
-	client := &gateway.Client{
-		BaseURL: resolved.GatewayURL,
-		Token:   resolved.Token,
-		HTTP:    c.runtimeHTTPClient(),
+	if strings.EqualFold(parsedURL.Scheme, "https") {
+		// Validated by gatewayHTTPClient above.
+		tlsOpts, _ := common.tls.tlsOptions(resolved)
+		tlsStart := time.Now()
+		check, tlsErr := c.doctorTLSHandshake(conn, parsedURL.Hostname(), tlsOpts, common.timeout)
+		checks = append(checks, check)
+		if common.timing || common.jsonStats {
+			stats["tlsHandshakeMs"] = time.Since(tlsStart).Milliseconds()
+		}
+		if tlsErr != nil {
+			_ = conn.Close()
+			return c.printDoctorResult(common.jsonOutput, selectOpts, outputTmpl, resolved.GatewayURL, checks, stats, tlsErr)
+		}
 	}
+	_ = conn.Close()
 
 	type doctorCallResult struct {
 		resp      *gateway.CallResponse
@@ -135,11 +174,18 @@ func (c *CLI) runDoctor(args []string) error {
 	}
 
 	var (
-		gatewayInfo doctorCallResult
-		scanWrite   doctorCallResult
-		wg          sync.WaitGroup
+		gatewayInfo  doctorCallResult
+		scanWrite    doctorCallResult
+		probeResults []doctorProbeResult
+		wg           sync.WaitGroup
 	)
 
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		probeResults = runDoctorProbes(client, probes, common.timeout)
+	}()
+
 	wg.Add(1)
 	go func() {
 		defer wg.Done()
@@ -188,6 +234,7 @@ func (c *CLI) runDoctor(args []string) error {
 			OK:      false,
 			Message: gatewayInfo.err.Error(),
 			Hint:    doctorHintForError(gatewayInfo.err),
+			Fix:     fixFor(gatewayInfo.err),
 		})
 		if checkWrite {
 			checks = append(checks, doctorCheck{
@@ -202,7 +249,7 @@ func (c *CLI) runDoctor(args []string) error {
 				Message: "skipped (use --check-write)",
 			})
 		}
-		return c.printDoctorResult(common.jsonOutput, selectOpts, resolved.GatewayURL, checks, stats, gatewayInfo.err)
+		return c.printDoctorResult(common.jsonOutput, selectOpts, outputTmpl, resolved.GatewayURL, checks, stats, gatewayInfo.err)
 	}
 	checks = append(checks, doctorCheck{
 		Name:    "gateway_info",
@@ -218,29 +265,41 @@ func (c *CLI) runDoctor(args []string) error {
 				Message: scanWrite.err.Error(),
 				Hint:    doctorHintForError(scanWrite.err),
 			})
-			return c.printDoctorResult(common.jsonOutput, selectOpts, resolved.GatewayURL, checks, stats, scanWrite.err)
+			checks = append(checks, doctorProbeChecks(probeResults, doctorPermissionState(scanWrite.err))...)
+			return c.printDoctorResult(common.jsonOutput, selectOpts, outputTmpl, resolved.GatewayURL, checks, stats, scanWrite.err)
 		}
 		checks = append(checks, doctorCheck{
 			Name:    "scan_projects",
 			OK:      true,
 			Message: fmt.Sprintf("status %d", scanWrite.resp.StatusCode),
 		})
+		checks = append(checks, doctorProbeChecks(probeResults, "ok")...)
 	} else {
 		checks = append(checks, doctorCheck{
 			Name:    "scan_projects",
 			OK:      true,
 			Message: "skipped (use --check-write)",
 		})
+		checks = append(checks, doctorProbeChecks(probeResults, "skipped")...)
 	}
 
-	return c.printDoctorResult(common.jsonOutput, selectOpts, resolved.GatewayURL, checks, stats, nil)
+	return c.printDoctorResult(common.jsonOutput, selectOpts, outputTmpl, resolved.GatewayURL, checks, stats, nil)
 }
 
 type doctorCheck struct {
-	Name    string `json:"name"`
-	OK      bool   `json:"ok"`
-	Message string `json:"message"`
-	Hint    string `json:"hint,omitempty"`
+	Name        string             `json:"name"`
+	OK          bool               `json:"ok"`
+	Message     string             `json:"message"`
+	Hint        string             `json:"hint,omitempty"`
+	Fix         []string           `json:"fix,omitempty"`
+	Remediation *doctorRemediation `json:"remediation,omitempty"`
+	// Status, Optional, and Permissions are set by token_permissions and its
+	// probes, which never change the exit code.
+	Status      int               `json:"status,omitempty"`
+	Optional    bool              `json:"optional,omitempty"`
+	Permissions map[string]string `json:"permissions,omitempty"`
+	// Certificate is set by tls_handshake.
+	Certificate *gateway.CertificateSummary `json:"certificate,omitempty"`
 }
 
 type doctorEnvelope struct {
@@ -252,19 +311,32 @@ type doctorEnvelope struct {
 	Stats      map[string]any `json:"stats,omitempty"`
 }
 
-func (c *CLI) printDoctorResult(jsonOutput bool, selectOpts jsonSelectOptions, gatewayURL string, checks []doctorCheck, stats map[string]any, err error) error {
-	if jsonOutput {
-		payload := doctorEnvelope{
-			OK:         err == nil,
-			GatewayURL: gatewayURL,
-			Checks:     checks,
-			Stats:      stats,
+// doctorMarkdownTemplate renders --output markdown.
+const doctorMarkdownTemplate = `{{table .checks "name" "ok" "message" "hint"}}`
+
+func (c *CLI) printDoctorResult(jsonOutput bool, selectOpts jsonSelectOptions, outputTmpl string, gatewayURL string, checks []doctorCheck, stats map[string]any, err error) error {
+	payload := doctorEnvelope{
+		OK:         err == nil,
+		GatewayURL: gatewayURL,
+		Checks:     checks,
+		Stats:      stats,
+	}
+	if err != nil {
+		payload.Code = igwerr.ExitCode(err)
+		payload.Error = err.Error()
+	}
+
+	if outputTmpl != "" {
+		if renderErr := renderOutputTemplate(c.Out, outputTmpl, payload); renderErr != nil {
+			return renderErr
 		}
 		if err != nil {
-			payload.Code = igwerr.ExitCode(err)
-			payload.Error = err.Error()
+			fmt.Fprintln(c.Err, err.Error())
 		}
+		return err
+	}
 
+	if jsonOutput {
 		if selectWriteErr := printJSONSelection(c.Out, payload, selectOpts); selectWriteErr != nil {
 			_ = writeJSONWithOptions(c.Out, jsonErrorPayload(selectWriteErr), selectOpts.compact)
 			return selectWriteErr
@@ -274,14 +346,23 @@ func (c *CLI) printDoctorResult(jsonOutput bool, selectOpts jsonSelectOptions, g
 
 	for _, check := range checks {
 		state := "ok"
-		if !check.OK {
+		switch {
+		case !check.OK && check.Optional:
+			state = "warn"
+		case !check.OK:
 			state = "fail"
 		}
 		if check.Hint != "" {
 			fmt.Fprintf(c.Out, "%s\t%s\t%s\thint: %s\n", state, check.Name, check.Message, check.Hint)
-			continue
+		} else {
+			fmt.Fprintf(c.Out, "%s\t%s\t%s\n", state, check.Name, check.Message)
+		}
+		for _, fix := range check.Fix {
+			fmt.Fprintf(c.Out, "fix\t%s\t%s\n", check.Name, fix)
+		}
+		if check.Remediation != nil {
+			fmt.Fprintf(c.Out, "remediate\t%s\t%s: %s\n", check.Name, check.Remediation.Shell, check.Remediation.Command)
 		}
-		fmt.Fprintf(c.Out, "%s\t%s\t%s\n", state, check.Name, check.Message)
 	}
 
 	if err != nil {
@@ -303,7 +384,7 @@ func doctorHintForError(err error) string {
 
 	var transportErr *igwerr.TransportError
 	if errors.As(err, &transportErr) && transportErr.Timeout {
-		return "If this is WSL2 -> Windows, allow inbound TCP 8088 on interface alias \"vEthernet (WSL (Hyper-V firewall))\"."
+		return fmt.Sprintf("If this is WSL2 -> Windows, allow inbound TCP 8088 on interface alias %q.", wslFirewallInterfaceAlias)
 	}
 
 	if errors.As(err, &transportErr) {
@@ -313,6 +394,32 @@ func doctorHintForError(err error) string {
 	return ""
 }
 
+// wslFirewallInterfaceAlias is the Windows network adapter that carries WSL2
+// traffic to the host under the Hyper-V firewall.
+const wslFirewallInterfaceAlias = "vEthernet (WSL (Hyper-V firewall))"
+
+// doctorFixForError returns copy-pasteable commands for failures with a known
+// remedy on the current platform. Only WSL connect timeouts have one today:
+// the Windows firewall rule, run from an elevated Windows shell.
+func doctorFixForError(err error, port string, inWSL bool) []string {
+	var transportErr *igwerr.TransportError
+	if !inWSL || port == "" || !errors.As(err, &transportErr) || !transportErr.Timeout {
+		return nil
+	}
+
+	ruleName := fmt.Sprintf("Ignition Gateway %s (WSL)", port)
+	return []string{
+		fmt.Sprintf(
+			"powershell (admin): New-NetFirewallRule -DisplayName %q -Direction Inbound -InterfaceAlias %q -Protocol TCP -LocalPort %s -Action Allow",
+			ruleName, wslFirewallInterfaceAlias, port,
+		),
+		fmt.Sprintf(
+			"cmd (admin, all interfaces): netsh advfirewall firewall add rule name=%q dir=in action=allow protocol=TCP localport=%s",
+			ruleName, port,
+		),
+	}
+}
+
 func dialAddress(gatewayURL *url.URL) (string, error) {
 	host := strings.TrimSpace(gatewayURL.Hostname())
 	if host == "" {
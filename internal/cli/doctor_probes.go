@@ -0,0 +1,132 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/alex-mccollum/igw-cli/internal/gateway"
+	"github.com/alex-mccollum/igw-cli/internal/igwerr"
+)
+
+// doctorProbe is one read-only GET used to see what the token can reach.
+// The gateway has no endpoint that lists a token's security levels, so
+// doctor infers read/admin access from these and write access from
+// --check-write.
+type doctorProbe struct {
+	Category string
+	Path     string
+}
+
+var defaultDoctorProbes = []doctorProbe{
+	{Category: "read", Path: "/data/api/v1/projects"},
+	{Category: "admin", Path: "/data/api/v1/logs/loggers"},
+}
+
+// doctorPermissionCategories are reported by token_permissions, in order.
+var doctorPermissionCategories = []string{"read", "write", "admin"}
+
+// parseProbePaths adds --probe-paths (repeatable, comma-separated) to the
+// default probes.
+func parseProbePaths(values []string) ([]doctorProbe, error) {
+	probes := append([]doctorProbe(nil), defaultDoctorProbes...)
+	for _, value := range values {
+		for _, path := range strings.Split(value, ",") {
+			path = strings.TrimSpace(path)
+			if !strings.HasPrefix(path, "/") {
+				return nil, &igwerr.UsageError{Msg: fmt.Sprintf("invalid --probe-paths entry %q (expected an absolute API path)", path)}
+			}
+			probes = append(probes, doctorProbe{Category: "custom", Path: path})
+		}
+	}
+	return probes, nil
+}
+
+type doctorProbeResult struct {
+	probe  doctorProbe
+	status int
+	err    error
+}
+
+func runDoctorProbes(client *gateway.Client, probes []doctorProbe, timeout time.Duration) []doctorProbeResult {
+	results := make([]doctorProbeResult, len(probes))
+	var wg sync.WaitGroup
+	for i, probe := range probes {
+		wg.Add(1)
+		go func(i int, probe doctorProbe) {
+			defer wg.Done()
+			resp, err := client.Call(context.Background(), gateway.CallRequest{
+				Method:  http.MethodGet,
+				Path:    probe.Path,
+				Timeout: timeout,
+			})
+			result := doctorProbeResult{probe: probe, err: err}
+			var statusErr *igwerr.StatusError
+			switch {
+			case err == nil:
+				result.status = resp.StatusCode
+			case errors.As(err, &statusErr):
+				result.status = statusErr.StatusCode
+			}
+			results[i] = result
+		}(i, probe)
+	}
+	wg.Wait()
+	return results
+}
+
+// doctorProbeChecks builds the token_permissions summary followed by one
+// optional check per probe. writeState comes from the scan_projects check.
+func doctorProbeChecks(results []doctorProbeResult, writeState string) []doctorCheck {
+	permissions := map[string]string{"write": writeState}
+	probeChecks := make([]doctorCheck, 0, len(results))
+	for _, result := range results {
+		state := doctorPermissionState(result.err)
+		if result.probe.Category != "custom" && permissions[result.probe.Category] != "ok" {
+			permissions[result.probe.Category] = state
+		}
+
+		check := doctorCheck{
+			Name:     "probe " + result.probe.Path,
+			OK:       result.err == nil,
+			Message:  fmt.Sprintf("%s: status %d", result.probe.Category, result.status),
+			Status:   result.status,
+			Optional: true,
+		}
+		if result.err != nil {
+			check.Message = fmt.Sprintf("%s: %v", result.probe.Category, result.err)
+			check.Hint = doctorHintForError(result.err)
+		}
+		probeChecks = append(probeChecks, check)
+	}
+
+	parts := make([]string, 0, len(doctorPermissionCategories))
+	for _, category := range doctorPermissionCategories {
+		parts = append(parts, category+"="+permissions[category])
+	}
+	summary := doctorCheck{
+		Name:        "token_permissions",
+		OK:          permissions["read"] == "ok",
+		Message:     strings.Join(parts, " "),
+		Optional:    true,
+		Permissions: permissions,
+	}
+	return append([]doctorCheck{summary}, probeChecks...)
+}
+
+// doctorPermissionState classifies a probe outcome as ok, denied (401/403),
+// or error.
+func doctorPermissionState(err error) string {
+	if err == nil {
+		return "ok"
+	}
+	var statusErr *igwerr.StatusError
+	if errors.As(err, &statusErr) && statusErr.AuthFailure() {
+		return "denied"
+	}
+	return "error"
+}
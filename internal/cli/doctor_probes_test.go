@@ -0,0 +1,102 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestDoctorTokenPermissionProbes(t *testing.T) {
+	t.Parallel()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/data/api/v1/gateway-info", "/data/api/v1/projects":
+			_, _ = w.Write([]byte(`{}`))
+		case "/data/api/v1/logs/loggers":
+			http.Error(w, "forbidden", http.StatusForbidden)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+
+	var out bytes.Buffer
+	c := newDoctorTestCLI(srv.Client(), &out)
+	if err := c.Execute([]string{
+		"doctor",
+		"--gateway-url", srv.URL,
+		"--api-key", "secret",
+		"--timeout", "1s",
+		"--probe-paths", "/data/api/v1/tags",
+		"--json",
+	}); err != nil {
+		t.Fatalf("failed optional probes must not fail doctor: %v", err)
+	}
+
+	var payload struct {
+		OK     bool `json:"ok"`
+		Checks []struct {
+			Name        string            `json:"name"`
+			OK          bool              `json:"ok"`
+			Status      int               `json:"status"`
+			Optional    bool              `json:"optional"`
+			Permissions map[string]string `json:"permissions"`
+		} `json:"checks"`
+	}
+	if err := json.Unmarshal(out.Bytes(), &payload); err != nil {
+		t.Fatalf("parse doctor json: %v", err)
+	}
+
+	statuses := map[string]int{}
+	var permissions map[string]string
+	for _, check := range payload.Checks {
+		if strings.HasPrefix(check.Name, "probe ") {
+			if !check.Optional {
+				t.Fatalf("probe %s should be optional", check.Name)
+			}
+			statuses[check.Name] = check.Status
+		}
+		if check.Name == "token_permissions" {
+			permissions = check.Permissions
+		}
+	}
+
+	wantStatuses := map[string]int{
+		"probe /data/api/v1/projects":     200,
+		"probe /data/api/v1/logs/loggers": 403,
+		"probe /data/api/v1/tags":         404,
+	}
+	for name, want := range wantStatuses {
+		if statuses[name] != want {
+			t.Fatalf("%s: expected status %d, got %d (%s)", name, want, statuses[name], out.String())
+		}
+	}
+	if permissions["read"] != "ok" || permissions["write"] != "skipped" || permissions["admin"] != "denied" {
+		t.Fatalf("unexpected permissions %v", permissions)
+	}
+}
+
+func TestDoctorProbePathsValidation(t *testing.T) {
+	t.Parallel()
+
+	probes, err := parseProbePaths([]string{"/a, /b", "/c"})
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	if len(probes) != len(defaultDoctorProbes)+3 || probes[len(probes)-1].Path != "/c" {
+		t.Fatalf("unexpected probes %+v", probes)
+	}
+
+	c := newDoctorTestCLI(http.DefaultClient, nil)
+	err = c.Execute([]string{
+		"doctor",
+		"--gateway-url", "http://127.0.0.1:1",
+		"--api-key", "secret",
+		"--probe-paths", "data/api/v1/tags",
+	})
+	requireDoctorUsageError(t, err)
+}
@@ -0,0 +1,78 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"strings"
+
+	"github.com/alex-mccollum/igw-cli/internal/igwerr"
+)
+
+// doctorRemediationWSLFirewall is the kind for the Windows inbound firewall
+// rule that lets WSL2 reach a gateway on the host.
+const doctorRemediationWSLFirewall = "wsl_firewall_rule"
+
+// doctorRemediation is a command for the user to run; doctor never runs it.
+type doctorRemediation struct {
+	Kind    string `json:"kind"`
+	Shell   string `json:"shell"`
+	Command string `json:"command"`
+}
+
+// doctorRemediationForConnect returns the firewall rule for a tcp_connect
+// timeout inside WSL, or nil. The rule is scoped to the Windows host IP
+// (from WSL detection, falling back to the gateway URL host) and port.
+func (c *CLI) doctorRemediationForConnect(err error, addr string) *doctorRemediation {
+	var transportErr *igwerr.TransportError
+	if !errors.As(err, &transportErr) || !transportErr.Timeout {
+		return nil
+	}
+	if c.DetectWSL == nil || !c.DetectWSL() {
+		return nil
+	}
+	host, port, splitErr := net.SplitHostPort(addr)
+	if splitErr != nil || port == "" {
+		return nil
+	}
+
+	hostIP := ""
+	if c.DetectWSLHostIP != nil {
+		if detected, _, detectErr := c.DetectWSLHostIP(); detectErr == nil {
+			hostIP = strings.TrimSpace(detected)
+		}
+	}
+	if hostIP == "" && net.ParseIP(host) != nil {
+		hostIP = host
+	}
+
+	command := fmt.Sprintf(
+		"New-NetFirewallRule -DisplayName %q -Direction Inbound -InterfaceAlias %q -Protocol TCP",
+		fmt.Sprintf("Ignition Gateway %s (WSL)", port), wslFirewallInterfaceAlias,
+	)
+	if hostIP != "" {
+		command += " -LocalAddress " + hostIP
+	}
+	command += " -LocalPort " + port + " -Action Allow"
+
+	return &doctorRemediation{
+		Kind:    doctorRemediationWSLFirewall,
+		Shell:   "powershell (admin)",
+		Command: command,
+	}
+}
+
+// writeDoctorRemediation writes the command to path as a PowerShell script.
+// It is a no-op without a path or a remediation.
+func writeDoctorRemediation(path string, remediation *doctorRemediation) error {
+	path = strings.TrimSpace(path)
+	if path == "" || remediation == nil {
+		return nil
+	}
+	script := fmt.Sprintf("# igw doctor remediation (%s). Review, then run in an elevated PowerShell on Windows.\n%s\n", remediation.Kind, remediation.Command)
+	if err := os.WriteFile(path, []byte(script), 0o600); err != nil {
+		return &igwerr.UsageError{Msg: fmt.Sprintf("write --remediation-out: %v", err)}
+	}
+	return nil
+}
@@ -0,0 +1,82 @@
+package cli
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/alex-mccollum/igw-cli/internal/igwerr"
+)
+
+func TestDoctorRemediationForConnect(t *testing.T) {
+	t.Parallel()
+
+	timeout := &igwerr.TransportError{Timeout: true}
+	c := &CLI{
+		DetectWSL:       func() bool { return true },
+		DetectWSLHostIP: func() (string, string, error) { return "172.25.80.1", "ip route", nil },
+	}
+
+	got := c.doctorRemediationForConnect(timeout, "172.25.80.1:8088")
+	if got == nil {
+		t.Fatalf("expected remediation")
+	}
+	want := `New-NetFirewallRule -DisplayName "Ignition Gateway 8088 (WSL)" -Direction Inbound -InterfaceAlias "vEthernet (WSL (Hyper-V firewall))" -Protocol TCP -LocalAddress 172.25.80.1 -LocalPort 8088 -Action Allow`
+	if got.Kind != doctorRemediationWSLFirewall || got.Command != want {
+		t.Fatalf("unexpected remediation %+v", got)
+	}
+
+	c.DetectWSLHostIP = func() (string, string, error) { return "", "", errors.New("no route") }
+	if got := c.doctorRemediationForConnect(timeout, "10.0.0.5:9088"); got == nil || !strings.Contains(got.Command, "-LocalAddress 10.0.0.5 -LocalPort 9088") {
+		t.Fatalf("expected URL host fallback, got %+v", got)
+	}
+	if got := c.doctorRemediationForConnect(timeout, "gateway.local:8088"); got == nil || strings.Contains(got.Command, "-LocalAddress") {
+		t.Fatalf("expected rule without address for a hostname, got %+v", got)
+	}
+
+	if got := c.doctorRemediationForConnect(&igwerr.TransportError{}, "172.25.80.1:8088"); got != nil {
+		t.Fatalf("expected no remediation for a non-timeout error, got %+v", got)
+	}
+	c.DetectWSL = func() bool { return false }
+	if got := c.doctorRemediationForConnect(timeout, "172.25.80.1:8088"); got != nil {
+		t.Fatalf("expected no remediation outside WSL, got %+v", got)
+	}
+}
+
+func TestWriteDoctorRemediation(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "fix.ps1")
+	if err := writeDoctorRemediation(path, nil); err != nil {
+		t.Fatalf("nil remediation: %v", err)
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Fatalf("expected no file without a remediation, got %v", err)
+	}
+
+	remediation := &doctorRemediation{Kind: doctorRemediationWSLFirewall, Command: "New-NetFirewallRule -LocalPort 8088"}
+	if err := writeDoctorRemediation(path, remediation); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	b, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("read: %v", err)
+	}
+	if !strings.HasPrefix(string(b), "# igw doctor remediation (wsl_firewall_rule)") || !strings.HasSuffix(string(b), "\nNew-NetFirewallRule -LocalPort 8088\n") {
+		t.Fatalf("unexpected script %q", b)
+	}
+}
+
+func TestDoctorRemediationOutRequiresRemediate(t *testing.T) {
+	t.Parallel()
+
+	c := newDoctorTestCLI(nil, nil)
+	requireUsageExitCode(t, c.Execute([]string{
+		"doctor",
+		"--gateway-url", "http://127.0.0.1:8088",
+		"--api-key", "secret",
+		"--remediation-out", filepath.Join(t.TempDir(), "fix.ps1"),
+	}))
+}
@@ -0,0 +1,59 @@
+package cli
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net"
+	"time"
+
+	"github.com/alex-mccollum/igw-cli/internal/gateway"
+	"github.com/alex-mccollum/igw-cli/internal/igwerr"
+)
+
+// doctorCertExpiryWarnDays is how close to expiry tls_handshake adds a hint.
+const doctorCertExpiryWarnDays = 30
+
+// doctorTLSHandshake runs the tls_handshake check over the tcp_connect
+// connection, with the same TLS settings requests use, and reports the
+// gateway certificate.
+func (c *CLI) doctorTLSHandshake(conn net.Conn, serverName string, opts gateway.TLSOptions, timeout time.Duration) (doctorCheck, error) {
+	tlsConfig, err := opts.Config()
+	if err != nil {
+		uerr := &igwerr.UsageError{Msg: err.Error()}
+		return doctorCheck{Name: "tls_handshake", OK: false, Message: uerr.Error()}, uerr
+	}
+	tlsConfig.ServerName = serverName
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	tlsConn := tls.Client(conn, tlsConfig)
+	if err := tlsConn.HandshakeContext(ctx); err != nil {
+		nerr := igwerr.NewTransportError(err)
+		return doctorCheck{
+			Name:    "tls_handshake",
+			OK:      false,
+			Message: nerr.Error(),
+			Hint:    "Trust a private or self-signed certificate with --ca-cert, or skip verification with --insecure-skip-verify",
+		}, nerr
+	}
+
+	check := doctorCheck{Name: "tls_handshake", OK: true, Message: "no server certificate"}
+	state := tlsConn.ConnectionState()
+	if len(state.PeerCertificates) == 0 {
+		return check, nil
+	}
+	summary := gateway.SummarizeCertificate(state.PeerCertificates[0], c.clock())
+	check.Certificate = &summary
+	check.Message = fmt.Sprintf("subject=%s issuer=%s days_until_expiry=%d", summary.Subject, summary.Issuer, summary.DaysUntilExpiry)
+	if opts.InsecureSkipVerify {
+		check.Message += " (not verified)"
+	}
+	switch {
+	case summary.DaysUntilExpiry < 0:
+		check.Hint = "The gateway certificate has expired"
+	case summary.DaysUntilExpiry <= doctorCertExpiryWarnDays:
+		check.Hint = fmt.Sprintf("The gateway certificate expires in %d days", summary.DaysUntilExpiry)
+	}
+	return check, nil
+}
@@ -9,6 +9,7 @@ import (
 	"time"
 
 	"github.com/alex-mccollum/igw-cli/internal/apidocs"
+	"github.com/alex-mccollum/igw-cli/internal/config"
 	"github.com/alex-mccollum/igw-cli/internal/gateway"
 	"github.com/alex-mccollum/igw-cli/internal/igwerr"
 )
@@ -25,89 +26,191 @@ type callExecutionInput struct {
 	Headers     []string
 	Body        []byte
 	ContentType string
-	DryRun      bool
-	Yes         bool
+	// Form, when set, is streamed as a multipart body instead of Body.
+	Form *multipartForm
+	// NoDefaultContentType sends bodies without Content-Type unless
+	// ContentType is set explicitly.
+	NoDefaultContentType bool
+	DryRun               bool
+	Yes                  bool
 
 	Timeout      time.Duration
 	Retry        int
 	RetryBackoff time.Duration
+	RetryOnBody  func([]byte) bool
+
+	RetryAfterMax    time.Duration
+	IgnoreRetryAfter bool
+	RetryJitter      float64
+	// RetryMaxWait switches to capped exponential backoff with full jitter.
+	RetryMaxWait time.Duration
+	// RetryOn, when set, defines which failed statuses are retried.
+	RetryOn func(status int) bool
+	// HedgeAfter, when positive, races a duplicate request after the delay.
+	HedgeAfter time.Duration
+	// RawPath sends Path verbatim without normalization.
+	RawPath bool
+	// AcceptStatus, when set, defines which statuses count as success.
+	AcceptStatus func(status int) bool
+	// FollowRedirects, when set, caps followed redirects
+	// (--follow-redirects).
+	FollowRedirects *int
 
 	Stream       io.Writer
+	RawDump      io.Writer
 	MaxBodyBytes int64
 	EnableTiming bool
+
+	// Summary, when set, counts the request for --summary.
+	Summary *runSummary
+	// Policy, when set, refuses denied method+path combinations.
+	Policy *config.Policy
+	// Progress, when set, reports streamed download progress.
+	Progress *downloadProgress
+	// Outcomes, when set, receives a record for --success-out/--failure-out.
+	Outcomes *outcomeFiles
+	// Attempts, when set, counts retry-loop attempts for --attempts-out.
+	Attempts *attemptCounter
+	// OnRateWait, when set, is told each wait on the client's --rate
+	// limiter.
+	OnRateWait func(wait time.Duration)
 }
 
 func executeCallCore(client *gateway.Client, input callExecutionInput) (*gateway.CallResponse, string, string, error) {
+	req, err := buildCallRequest(input)
+	if err != nil {
+		return nil, req.Method, req.Path, err
+	}
+
+	callCtx := input.Context
+	if callCtx == nil {
+		callCtx = context.Background()
+	}
+
+	started := time.Now()
+	resp, err := client.Call(callCtx, req)
+	input.Summary.record(resp, err)
+	input.Outcomes.recordCall(req.Method, req.Path, resp, err, time.Since(started))
+	return resp, req.Method, req.Path, err
+}
+
+// buildCallRequest resolves and validates input into the request
+// executeCallCore sends. Method and Path are set on the returned request
+// even when validation fails after they are known.
+func buildCallRequest(input callExecutionInput) (gateway.CallRequest, error) {
 	method := strings.ToUpper(strings.TrimSpace(input.Method))
 	path := strings.TrimSpace(input.Path)
 	op := strings.TrimSpace(input.OperationID)
 
 	if op != "" {
 		if method != "" || path != "" {
-			return nil, "", "", &igwerr.UsageError{Msg: "use either op or method/path, not both"}
+			return gateway.CallRequest{}, &igwerr.UsageError{Msg: "use either op or method/path, not both"}
 		}
 		match, ok := resolveOperationByID(input.OperationMap, op)
 		if !ok {
-			return nil, "", "", &igwerr.UsageError{Msg: fmt.Sprintf("operationId %q not found", op)}
+			msg := fmt.Sprintf("operationId %q not found", op)
+			ops := make([]apidocs.Operation, 0, len(input.OperationMap))
+			for _, candidate := range input.OperationMap {
+				ops = append(ops, candidate)
+			}
+			if suggestions := suggestOperationIDs(ops, op); len(suggestions) > 0 {
+				msg += fmt.Sprintf("; did you mean: %s?", strings.Join(suggestions, ", "))
+			}
+			return gateway.CallRequest{}, &igwerr.UsageError{Msg: msg}
 		}
 		method = match.Method
 		path = match.Path
 	}
 
 	if path == "" {
-		return nil, "", "", &igwerr.UsageError{Msg: "required: --path"}
+		return gateway.CallRequest{}, &igwerr.UsageError{Msg: "required: --path"}
 	}
 	if method == "" {
 		method = http.MethodGet
 	}
+	if err := input.Policy.Check(method, path); err != nil {
+		return gateway.CallRequest{Method: method, Path: path}, &igwerr.UsageError{Msg: err.Error()}
+	}
 	if input.Timeout <= 0 {
-		return nil, method, path, &igwerr.UsageError{Msg: "--timeout must be positive"}
+		return gateway.CallRequest{Method: method, Path: path}, &igwerr.UsageError{Msg: "--timeout must be positive"}
 	}
 	if input.Retry < 0 {
-		return nil, method, path, &igwerr.UsageError{Msg: "--retry must be >= 0"}
+		return gateway.CallRequest{Method: method, Path: path}, &igwerr.UsageError{Msg: "--retry must be >= 0"}
 	}
 	if input.Retry > 0 && input.RetryBackoff <= 0 {
-		return nil, method, path, &igwerr.UsageError{Msg: "--retry-backoff must be positive when --retry is set"}
+		return gateway.CallRequest{Method: method, Path: path}, &igwerr.UsageError{Msg: "--retry-backoff must be positive when --retry is set"}
 	}
 	if isMutatingMethod(method) && !input.Yes {
-		return nil, method, path, &igwerr.UsageError{Msg: fmt.Sprintf("method %s requires --yes confirmation", method)}
+		return gateway.CallRequest{Method: method, Path: path}, &igwerr.UsageError{Msg: fmt.Sprintf("method %s requires --yes confirmation", method)}
 	}
 	if input.Retry > 0 && !isIdempotentMethod(method) {
-		return nil, method, path, &igwerr.UsageError{
+		return gateway.CallRequest{Method: method, Path: path}, &igwerr.UsageError{
 			Msg: fmt.Sprintf("--retry is only supported for idempotent methods; got %s", method),
 		}
 	}
 
+	if input.RetryOnBody != nil && input.Stream != nil {
+		// Streamed bodies are written out as they arrive, so there is no
+		// buffered body to match against.
+		return gateway.CallRequest{Method: method, Path: path}, &igwerr.UsageError{
+			Msg: "--retry-on-body-match needs a buffered response body and is not supported with streaming or rpc notify",
+		}
+	}
+
+	if input.RetryOnBody != nil && !isIdempotentMethod(method) {
+		return gateway.CallRequest{Method: method, Path: path}, &igwerr.UsageError{
+			Msg: fmt.Sprintf("--retry-on-body-match is only supported for idempotent methods; got %s", method),
+		}
+	}
+
+	if input.HedgeAfter > 0 && !isIdempotentMethod(method) {
+		return gateway.CallRequest{Method: method, Path: path}, &igwerr.UsageError{
+			Msg: fmt.Sprintf("--hedge-after is only supported for idempotent methods; got %s", method),
+		}
+	}
+
 	query := input.Query
 	if input.DryRun {
 		query = append(append([]string(nil), input.Query...), "dryRun=true")
 	}
 
 	contentType := strings.TrimSpace(input.ContentType)
-	if len(input.Body) > 0 && contentType == "" {
-		contentType = "application/json"
+	if input.Form != nil {
+		contentType = input.Form.contentType()
 	}
-
-	callCtx := input.Context
-	if callCtx == nil {
-		callCtx = context.Background()
+	if len(input.Body) > 0 && contentType == "" && !input.NoDefaultContentType {
+		contentType = "application/json"
 	}
 
-	resp, err := client.Call(callCtx, gateway.CallRequest{
-		Method:       method,
-		Path:         path,
-		Query:        query,
-		Headers:      input.Headers,
-		Body:         input.Body,
-		ContentType:  contentType,
-		Timeout:      input.Timeout,
-		Retry:        input.Retry,
-		RetryBackoff: input.RetryBackoff,
-		Stream:       input.Stream,
-		MaxBodyBytes: input.MaxBodyBytes,
-		EnableTiming: input.EnableTiming,
-	})
-	return resp, method, path, err
+	return gateway.CallRequest{
+		Method:           method,
+		Path:             path,
+		Query:            query,
+		Headers:          input.Headers,
+		Body:             input.Body,
+		ContentType:      contentType,
+		Timeout:          input.Timeout,
+		Retry:            input.Retry,
+		RetryBackoff:     input.RetryBackoff,
+		RetryOnBody:      input.RetryOnBody,
+		RetryAfterMax:    input.RetryAfterMax,
+		IgnoreRetryAfter: input.IgnoreRetryAfter,
+		RetryJitter:      input.RetryJitter,
+		RetryMaxWait:     input.RetryMaxWait,
+		RetryOn:          input.RetryOn,
+		HedgeAfter:       input.HedgeAfter,
+		RawPath:          input.RawPath,
+		AcceptStatus:     input.AcceptStatus,
+		FollowRedirects:  input.FollowRedirects,
+		OpenBody:         input.Form.opener(),
+		Stream:           input.Stream,
+		RawDump:          input.RawDump,
+		MaxBodyBytes:     input.MaxBodyBytes,
+		EnableTiming:     input.EnableTiming,
+		Progress:         input.Progress.reporter(),
+		OnAttempt:        input.Attempts.reporter(),
+		OnRateWait:       input.OnRateWait,
+	}, nil
 }
 
 func resolveOperationByID(opMap map[string]apidocs.Operation, operationID string) (apidocs.Operation, bool) {
@@ -27,6 +27,7 @@ func TestExitCodesCommandText(t *testing.T) {
 		"auth\t6",
 		"network\t7",
 		"ok\t0",
+		"unexpectedStatus\t8",
 		"usage\t2",
 	} {
 		if !strings.Contains(got, line) {
@@ -58,7 +59,7 @@ func TestExitCodesCommandJSON(t *testing.T) {
 	if !payload.OK {
 		t.Fatalf("expected ok=true payload")
 	}
-	if payload.ExitCodes["ok"] != 0 || payload.ExitCodes["usage"] != 2 || payload.ExitCodes["auth"] != 6 || payload.ExitCodes["network"] != 7 {
+	if payload.ExitCodes["ok"] != 0 || payload.ExitCodes["usage"] != 2 || payload.ExitCodes["auth"] != 6 || payload.ExitCodes["network"] != 7 || payload.ExitCodes["unexpectedStatus"] != 8 {
 		t.Fatalf("unexpected exit codes payload: %#v", payload.ExitCodes)
 	}
 }
@@ -0,0 +1,319 @@
+package cli
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"github.com/alex-mccollum/igw-cli/internal/buildinfo"
+	"github.com/alex-mccollum/igw-cli/internal/gateway"
+)
+
+const harRedacted = "REDACTED"
+
+// harSensitiveHeaders are replaced with harRedacted in recorded requests
+// and responses so a HAR file can be shared.
+var harSensitiveHeaders = map[string]bool{
+	http.CanonicalHeaderKey(gateway.TokenHeader): true,
+	"Authorization":       true,
+	"Proxy-Authorization": true,
+	"Cookie":              true,
+	"Set-Cookie":          true,
+}
+
+// harOptions holds --har and --har-bodies.
+type harOptions struct {
+	path   string
+	bodies bool
+}
+
+func bindHARFlags(fs *flag.FlagSet, opts *harOptions) {
+	fs.StringVar(&opts.path, "har", "", "Record every HTTP request/response to an HTTP Archive (HAR 1.2) file")
+	fs.BoolVar(&opts.bodies, "har-bodies", false, "Include request and response bodies in --har")
+}
+
+func (o harOptions) args() []string {
+	if strings.TrimSpace(o.path) == "" {
+		return nil
+	}
+	args := []string{"--har", strings.TrimSpace(o.path)}
+	if o.bodies {
+		args = append(args, "--har-bodies")
+	}
+	return args
+}
+
+// harRecorder collects gateway exchanges for --har. A nil *harRecorder
+// records nothing, so commands can thread it unconditionally.
+type harRecorder struct {
+	mu      sync.Mutex
+	path    string
+	bodies  bool
+	entries []harEntry
+}
+
+func newHARRecorder(opts harOptions) *harRecorder {
+	path := strings.TrimSpace(opts.path)
+	if path == "" {
+		return nil
+	}
+	return &harRecorder{path: path, bodies: opts.bodies}
+}
+
+// recorder adapts h to gateway.Client.Recorder.
+func (h *harRecorder) recorder() func(gateway.Exchange) {
+	if h == nil {
+		return nil
+	}
+	return h.record
+}
+
+func (h *harRecorder) record(exchange gateway.Exchange) {
+	entry := newHAREntry(exchange, h.bodies)
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.entries = append(h.entries, entry)
+}
+
+// save writes the archive. Failures only warn: the command's own result
+// has already been reported.
+func (c *CLI) saveHAR(h *harRecorder) {
+	if h == nil {
+		return
+	}
+	if err := h.writeFile(); err != nil {
+		fmt.Fprintf(c.Err, "warning: %v\n", err)
+	}
+}
+
+func (h *harRecorder) writeFile() error {
+	h.mu.Lock()
+	entries := append([]harEntry(nil), h.entries...)
+	h.mu.Unlock()
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].startedAt.Before(entries[j].startedAt)
+	})
+
+	archive := harArchive{Log: harLog{
+		Version: "1.2",
+		Creator: harCreator{Name: "igw", Version: buildinfo.Short()},
+		Entries: entries,
+	}}
+	if archive.Log.Entries == nil {
+		archive.Log.Entries = []harEntry{}
+	}
+	data, err := json.MarshalIndent(archive, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encode --har: %w", err)
+	}
+	if err := os.WriteFile(h.path, append(data, '\n'), 0o600); err != nil {
+		return fmt.Errorf("write --har: %w", err)
+	}
+	return nil
+}
+
+type harArchive struct {
+	Log harLog `json:"log"`
+}
+
+type harLog struct {
+	Version string     `json:"version"`
+	Creator harCreator `json:"creator"`
+	Entries []harEntry `json:"entries"`
+}
+
+type harCreator struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+}
+
+type harEntry struct {
+	StartedDateTime string      `json:"startedDateTime"`
+	Time            float64     `json:"time"`
+	Request         harRequest  `json:"request"`
+	Response        harResponse `json:"response"`
+	Cache           struct{}    `json:"cache"`
+	Timings         harTimings  `json:"timings"`
+	// Error is a custom field (HAR allows "_" prefixes) holding the
+	// transport error of an attempt that got no response.
+	Error string `json:"_error,omitempty"`
+
+	startedAt time.Time
+}
+
+type harRequest struct {
+	Method      string         `json:"method"`
+	URL         string         `json:"url"`
+	HTTPVersion string         `json:"httpVersion"`
+	Cookies     []harNameValue `json:"cookies"`
+	Headers     []harNameValue `json:"headers"`
+	QueryString []harNameValue `json:"queryString"`
+	PostData    *harPostData   `json:"postData,omitempty"`
+	HeadersSize int            `json:"headersSize"`
+	BodySize    int            `json:"bodySize"`
+}
+
+type harResponse struct {
+	Status      int            `json:"status"`
+	StatusText  string         `json:"statusText"`
+	HTTPVersion string         `json:"httpVersion"`
+	Cookies     []harNameValue `json:"cookies"`
+	Headers     []harNameValue `json:"headers"`
+	Content     harContent     `json:"content"`
+	RedirectURL string         `json:"redirectURL"`
+	HeadersSize int            `json:"headersSize"`
+	BodySize    int            `json:"bodySize"`
+}
+
+type harNameValue struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+type harPostData struct {
+	MimeType string `json:"mimeType"`
+	Text     string `json:"text"`
+}
+
+type harContent struct {
+	Size     int    `json:"size"`
+	MimeType string `json:"mimeType"`
+	Text     string `json:"text,omitempty"`
+	Encoding string `json:"encoding,omitempty"`
+}
+
+// harTimings uses -1 for phases that were not measured, as HAR requires.
+type harTimings struct {
+	Blocked float64 `json:"blocked"`
+	DNS     float64 `json:"dns"`
+	Connect float64 `json:"connect"`
+	SSL     float64 `json:"ssl"`
+	Send    float64 `json:"send"`
+	Wait    float64 `json:"wait"`
+	Receive float64 `json:"receive"`
+}
+
+func newHAREntry(exchange gateway.Exchange, includeBodies bool) harEntry {
+	entry := harEntry{
+		startedAt:       exchange.StartedAt,
+		StartedDateTime: exchange.StartedAt.UTC().Format(time.RFC3339Nano),
+		Time:            float64(exchange.Duration.Microseconds()) / 1000,
+		Request: harRequest{
+			Method:      exchange.Method,
+			URL:         exchange.URL,
+			HTTPVersion: "HTTP/1.1",
+			Cookies:     []harNameValue{},
+			Headers:     harHeaders(exchange.RequestHeaders),
+			QueryString: harQueryString(exchange.URL),
+			HeadersSize: -1,
+			BodySize:    len(exchange.RequestBody),
+		},
+		Response: harResponse{
+			Status:      exchange.StatusCode,
+			StatusText:  http.StatusText(exchange.StatusCode),
+			HTTPVersion: "HTTP/1.1",
+			Cookies:     []harNameValue{},
+			Headers:     harHeaders(exchange.ResponseHeaders),
+			Content: harContent{
+				Size:     len(exchange.ResponseBody),
+				MimeType: exchange.ResponseHeaders.Get("Content-Type"),
+			},
+			HeadersSize: -1,
+			BodySize:    len(exchange.ResponseBody),
+		},
+		Timings: harTimingsFor(exchange),
+	}
+	if exchange.StatusCode == 0 {
+		entry.Response.BodySize = -1
+	}
+	if exchange.Err != nil {
+		entry.Error = exchange.Err.Error()
+	}
+	if includeBodies {
+		if len(exchange.RequestBody) > 0 {
+			entry.Request.PostData = &harPostData{
+				MimeType: exchange.RequestHeaders.Get("Content-Type"),
+				Text:     string(exchange.RequestBody),
+			}
+		}
+		if len(exchange.ResponseBody) > 0 {
+			if utf8.Valid(exchange.ResponseBody) {
+				entry.Response.Content.Text = string(exchange.ResponseBody)
+			} else {
+				entry.Response.Content.Text = base64.StdEncoding.EncodeToString(exchange.ResponseBody)
+				entry.Response.Content.Encoding = "base64"
+			}
+		}
+	}
+	return entry
+}
+
+func harHeaders(headers http.Header) []harNameValue {
+	out := []harNameValue{}
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		for _, value := range headers[name] {
+			if harSensitiveHeaders[http.CanonicalHeaderKey(name)] {
+				value = harRedacted
+			}
+			out = append(out, harNameValue{Name: name, Value: value})
+		}
+	}
+	return out
+}
+
+func harQueryString(rawURL string) []harNameValue {
+	out := []harNameValue{}
+	parsed, err := url.Parse(rawURL)
+	if err != nil {
+		return out
+	}
+	for _, pair := range strings.Split(parsed.RawQuery, "&") {
+		if pair == "" {
+			continue
+		}
+		name, value, _ := strings.Cut(pair, "=")
+		name, _ = url.QueryUnescape(name)
+		value, _ = url.QueryUnescape(value)
+		out = append(out, harNameValue{Name: name, Value: value})
+	}
+	return out
+}
+
+// harTimingsFor splits the attempt into HAR phases. Without --timing only
+// the total is known, and it is reported as wait.
+func harTimingsFor(exchange gateway.Exchange) harTimings {
+	total := float64(exchange.Duration.Microseconds()) / 1000
+	timings := harTimings{Blocked: -1, DNS: -1, Connect: -1, SSL: -1, Send: 0, Wait: total, Receive: 0}
+	timing := exchange.Timing
+	if timing == nil || timing.FirstByteMs == 0 {
+		return timings
+	}
+	if timing.DNSMs != nil {
+		timings.DNS = float64(*timing.DNSMs)
+	}
+	if timing.ConnectMs != nil {
+		timings.Connect = float64(*timing.ConnectMs)
+	}
+	if timing.TLSHandshakeMs != nil {
+		timings.SSL = float64(*timing.TLSHandshakeMs)
+	}
+	timings.Wait = float64(timing.FirstByteMs - timing.RequestWriteDoneMs)
+	if timing.BodyReadMs != nil {
+		timings.Receive = float64(*timing.BodyReadMs)
+	}
+	return timings
+}
@@ -0,0 +1,131 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/alex-mccollum/igw-cli/internal/config"
+	"github.com/alex-mccollum/igw-cli/internal/gateway"
+)
+
+func readHARFile(t *testing.T, path string) harArchive {
+	t.Helper()
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("read har: %v", err)
+	}
+	var archive harArchive
+	if err := json.Unmarshal(data, &archive); err != nil {
+		t.Fatalf("decode har: %v", err)
+	}
+	return archive
+}
+
+func TestCallHARRecordsEveryAttempt(t *testing.T) {
+	t.Parallel()
+
+	harPath := filepath.Join(t.TempDir(), "session.har")
+	var calls atomic.Int32
+	c := &CLI{
+		In:     strings.NewReader(`{"method":"GET","path":"/data/api/v1/gateway-info"}` + "\n" + `{"method":"GET","path":"/data/api/v1/projects?limit=5"}` + "\n"),
+		Out:    new(bytes.Buffer),
+		Err:    new(bytes.Buffer),
+		Getenv: func(string) string { return "" },
+		ReadConfig: func() (config.File, error) {
+			return config.File{}, nil
+		},
+		HTTPClient: newMockHTTPClient(func(r *http.Request) (*http.Response, error) {
+			if calls.Add(1) == 1 {
+				return mockHTTPResponse(http.StatusServiceUnavailable, "busy", http.Header{"Retry-After": []string{"0"}}), nil
+			}
+			return mockHTTPResponse(http.StatusOK, `{"name":"gw"}`, http.Header{"Content-Type": []string{"application/json"}}), nil
+		}),
+	}
+	err := c.Execute([]string{
+		"call",
+		"--gateway-url", mockGatewayURL,
+		"--api-key", "secret",
+		"--batch", "-",
+		"--retry", "1",
+		"--retry-backoff", "1ms",
+		"--har", harPath,
+	})
+	if err != nil {
+		t.Fatalf("call batch failed: %v", err)
+	}
+
+	archive := readHARFile(t, harPath)
+	if archive.Log.Version != "1.2" || archive.Log.Creator.Name != "igw" {
+		t.Fatalf("unexpected har log header: %+v", archive.Log)
+	}
+	entries := archive.Log.Entries
+	if len(entries) != 3 {
+		t.Fatalf("expected 3 entries (one retry), got %d", len(entries))
+	}
+	statuses := []int{entries[0].Response.Status, entries[1].Response.Status, entries[2].Response.Status}
+	if statuses[0] != http.StatusServiceUnavailable || statuses[1] != http.StatusOK || statuses[2] != http.StatusOK {
+		t.Fatalf("unexpected statuses %v", statuses)
+	}
+	for _, entry := range entries {
+		if entry.Request.Method != http.MethodGet || entry.StartedDateTime == "" || entry.Time < 0 {
+			t.Fatalf("unexpected entry %+v", entry)
+		}
+		if entry.Timings.Wait < 0 || entry.Timings.Send < 0 || entry.Timings.Receive < 0 {
+			t.Fatalf("unexpected timings %+v", entry.Timings)
+		}
+		if entry.Response.Content.Text != "" {
+			t.Fatalf("bodies recorded without --har-bodies: %+v", entry.Response.Content)
+		}
+		for _, header := range entry.Request.Headers {
+			if strings.EqualFold(header.Name, gateway.TokenHeader) && header.Value != harRedacted {
+				t.Fatalf("token header not redacted: %+v", header)
+			}
+		}
+	}
+	if got := entries[2].Request.QueryString; len(got) != 1 || got[0].Name != "limit" || got[0].Value != "5" {
+		t.Fatalf("unexpected query string %+v", got)
+	}
+}
+
+func TestCallHARBodies(t *testing.T) {
+	t.Parallel()
+
+	harPath := filepath.Join(t.TempDir(), "call.har")
+	c := newAdminWrapperTestCLI(newStaticHTTPClient(http.StatusOK, `{"ok":true}`))
+	err := c.Execute([]string{
+		"call",
+		"--gateway-url", mockGatewayURL,
+		"--api-key", "secret",
+		"--method", "POST",
+		"--path", "/data/api/v1/scan/projects",
+		"--body", `{"force":true}`,
+		"--yes",
+		"--har", harPath,
+		"--har-bodies",
+	})
+	if err != nil {
+		t.Fatalf("call failed: %v", err)
+	}
+
+	entries := readHARFile(t, harPath).Log.Entries
+	if len(entries) != 1 {
+		t.Fatalf("expected 1 entry, got %d", len(entries))
+	}
+	entry := entries[0]
+	if entry.Request.PostData == nil || entry.Request.PostData.Text != `{"force":true}` {
+		t.Fatalf("unexpected postData %+v", entry.Request.PostData)
+	}
+	if entry.Response.Content.Text != `{"ok":true}` {
+		t.Fatalf("unexpected response content %+v", entry.Response.Content)
+	}
+	if data, _ := os.ReadFile(harPath); bytes.Contains(data, []byte("secret")) {
+		t.Fatalf("har file leaked the API token")
+	}
+}
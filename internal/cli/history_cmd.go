@@ -0,0 +1,349 @@
+package cli
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/alex-mccollum/igw-cli/internal/config"
+	"github.com/alex-mccollum/igw-cli/internal/gateway"
+	"github.com/alex-mccollum/igw-cli/internal/igwerr"
+)
+
+// historyEntryView is a history entry with its list index; 1 is the most
+// recent call.
+type historyEntryView struct {
+	Index int `json:"index"`
+	config.HistoryEntry
+}
+
+func (c *CLI) runHistory(args []string) error {
+	return c.runWrapperSubcommand(
+		args,
+		"Usage: igw history <list|show|replay|clear> [flags]",
+		"required history subcommand",
+		"unknown history subcommand %q",
+		map[string]func([]string) error{
+			"list":   c.runHistoryList,
+			"show":   c.runHistoryShow,
+			"replay": c.runHistoryReplay,
+			"clear":  c.runHistoryClear,
+		},
+	)
+}
+
+func (c *CLI) runHistoryList(args []string) error {
+	fs := flag.NewFlagSet("history list", flag.ContinueOnError)
+	fs.SetOutput(c.Err)
+
+	var limit int
+	var jsonOutput bool
+	var compact bool
+	fs.IntVar(&limit, "limit", 20, "Show at most this many recent entries (0 for all)")
+	fs.BoolVar(&jsonOutput, "json", false, "Print JSON output")
+	fs.BoolVar(&compact, "compact", false, "Print compact one-line JSON (requires --json)")
+
+	if err := fs.Parse(args); err != nil {
+		return &igwerr.UsageError{Msg: err.Error()}
+	}
+	if compact && !jsonOutput {
+		return c.printJSONCommandErrorWithOptions(false, false, &igwerr.UsageError{Msg: "--compact requires --json"})
+	}
+	if fs.NArg() > 0 {
+		return c.printJSONCommandErrorWithOptions(jsonOutput, compact, &igwerr.UsageError{Msg: "unexpected positional arguments"})
+	}
+	if limit < 0 {
+		return c.printJSONCommandErrorWithOptions(jsonOutput, compact, &igwerr.UsageError{Msg: "--limit must be >= 0"})
+	}
+
+	views, err := c.readHistoryViews()
+	if err != nil {
+		return c.printJSONCommandErrorWithOptions(jsonOutput, compact, err)
+	}
+	total := len(views)
+	if limit > 0 && len(views) > limit {
+		views = views[:limit]
+	}
+
+	if jsonOutput {
+		return writeJSONWithOptions(c.Out, map[string]any{
+			"count":   len(views),
+			"total":   total,
+			"entries": views,
+		}, compact)
+	}
+
+	fmt.Fprintln(c.Out, "N\tTIME\tMETHOD\tPATH\tSTATUS\tDURATION_MS\tPROFILE")
+	for _, view := range views {
+		fmt.Fprintf(c.Out, "%d\t%s\t%s\t%s\t%s\t%d\t%s\n",
+			view.Index, view.Time.Format(time.RFC3339), view.Method, historyTarget(view.HistoryEntry),
+			historyStatusText(view.Status), view.DurationMs, view.Profile)
+	}
+	return nil
+}
+
+func (c *CLI) runHistoryShow(args []string) error {
+	jsonRequested := argsWantJSON(args)
+	fs := flag.NewFlagSet("history show", flag.ContinueOnError)
+	fs.SetOutput(c.Err)
+	if jsonRequested {
+		fs.SetOutput(io.Discard)
+	}
+
+	var jsonOutput bool
+	var compact bool
+	fs.BoolVar(&jsonOutput, "json", false, "Print JSON output")
+	fs.BoolVar(&compact, "compact", false, "Print compact one-line JSON (requires --json)")
+
+	if err := fs.Parse(historyIndexLast(args)); err != nil {
+		return c.printJSONCommandErrorWithOptions(jsonRequested, argsWantCompact(args), &igwerr.UsageError{Msg: err.Error()})
+	}
+	if compact && !jsonOutput {
+		return c.printJSONCommandErrorWithOptions(false, false, &igwerr.UsageError{Msg: "--compact requires --json"})
+	}
+	index, err := parseHistoryIndex(fs.Args(), "show")
+	if err != nil {
+		return c.printJSONCommandErrorWithOptions(jsonOutput, compact, err)
+	}
+
+	view, err := c.historyEntry(index)
+	if err != nil {
+		return c.printJSONCommandErrorWithOptions(jsonOutput, compact, err)
+	}
+	if jsonOutput {
+		return writeJSONWithOptions(c.Out, view, compact)
+	}
+
+	fmt.Fprintf(c.Out, "index\t%d\n", view.Index)
+	fmt.Fprintf(c.Out, "time\t%s\n", view.Time.Format(time.RFC3339))
+	fmt.Fprintf(c.Out, "method\t%s\n", view.Method)
+	fmt.Fprintf(c.Out, "path\t%s\n", view.Path)
+	for _, query := range view.Query {
+		fmt.Fprintf(c.Out, "query\t%s\n", query)
+	}
+	fmt.Fprintf(c.Out, "status\t%s\n", historyStatusText(view.Status))
+	fmt.Fprintf(c.Out, "duration_ms\t%d\n", view.DurationMs)
+	if view.Profile != "" {
+		fmt.Fprintf(c.Out, "profile\t%s\n", view.Profile)
+	}
+	if view.GatewayURL != "" {
+		fmt.Fprintf(c.Out, "gateway_url\t%s\n", view.GatewayURL)
+	}
+	if view.BodyBytes > 0 {
+		fmt.Fprintf(c.Out, "body_bytes\t%d\n", view.BodyBytes)
+	}
+	if view.Form {
+		fmt.Fprintln(c.Out, "form\ttrue")
+	}
+	return nil
+}
+
+// runHistoryReplay re-runs an entry through call, so mutating methods still
+// need --yes. The recorded profile and gateway are used unless --profile or
+// --gateway-url is given; --profile alone picks that profile's gateway.
+func (c *CLI) runHistoryReplay(args []string) error {
+	fs := flag.NewFlagSet("history replay", flag.ContinueOnError)
+	fs.SetOutput(c.Err)
+
+	var common wrapperCommon
+	var yes bool
+	bindWrapperCommon(fs, &common)
+	fs.BoolVar(&yes, "yes", false, "Confirm a mutating replay")
+
+	if err := fs.Parse(historyIndexLast(args)); err != nil {
+		return &igwerr.UsageError{Msg: err.Error()}
+	}
+	index, err := parseHistoryIndex(fs.Args(), "replay")
+	if err != nil {
+		return c.printJSONCommandErrorWithOptions(common.jsonOutput, common.compactJSON, err)
+	}
+
+	view, err := c.historyEntry(index)
+	if err != nil {
+		return c.printJSONCommandErrorWithOptions(common.jsonOutput, common.compactJSON, err)
+	}
+	if view.BodyBytes > 0 || view.Form {
+		return c.printJSONCommandErrorWithOptions(common.jsonOutput, common.compactJSON, &igwerr.UsageError{
+			Msg: fmt.Sprintf("history entry %d sent a request body, which history does not record; re-run it with igw call", index),
+		})
+	}
+	if strings.TrimSpace(common.profile) == "" {
+		common.profile = view.Profile
+		if strings.TrimSpace(common.gatewayURL) == "" {
+			common.gatewayURL = view.GatewayURL
+		}
+	}
+
+	callArgs := []string{"--method", view.Method, "--path", view.Path}
+	for _, query := range view.Query {
+		callArgs = append(callArgs, "--query", query)
+	}
+	if yes {
+		callArgs = append(callArgs, "--yes")
+	}
+	return c.runWrapperCall(common, callArgs)
+}
+
+func (c *CLI) runHistoryClear(args []string) error {
+	fs := flag.NewFlagSet("history clear", flag.ContinueOnError)
+	fs.SetOutput(c.Err)
+
+	var jsonOutput bool
+	var compact bool
+	fs.BoolVar(&jsonOutput, "json", false, "Print JSON output")
+	fs.BoolVar(&compact, "compact", false, "Print compact one-line JSON (requires --json)")
+
+	if err := fs.Parse(args); err != nil {
+		return &igwerr.UsageError{Msg: err.Error()}
+	}
+	if compact && !jsonOutput {
+		return c.printJSONCommandErrorWithOptions(false, false, &igwerr.UsageError{Msg: "--compact requires --json"})
+	}
+	if fs.NArg() > 0 {
+		return c.printJSONCommandErrorWithOptions(jsonOutput, compact, &igwerr.UsageError{Msg: "unexpected positional arguments"})
+	}
+
+	path, err := c.historyPath()
+	if err == nil {
+		err = config.ClearHistory(path)
+	}
+	if err != nil {
+		return c.printJSONCommandErrorWithOptions(jsonOutput, compact, &igwerr.UsageError{Msg: err.Error()})
+	}
+
+	if jsonOutput {
+		return writeJSONWithOptions(c.Out, map[string]any{"ok": true, "historyPath": path}, compact)
+	}
+	c.info("cleared history: %s", path)
+	return nil
+}
+
+// historyIndexLast moves a leading <n> after the flags, so both
+// "show 3 --json" and "show --json 3" parse.
+func historyIndexLast(args []string) []string {
+	if len(args) == 0 || strings.HasPrefix(args[0], "-") {
+		return args
+	}
+	return append(append([]string(nil), args[1:]...), args[0])
+}
+
+// parseHistoryIndex reads <n> from the positional arguments left after
+// flag parsing.
+func parseHistoryIndex(args []string, subcommand string) (int, error) {
+	usage := &igwerr.UsageError{Msg: fmt.Sprintf("usage: igw history %s <n> [flags]", subcommand)}
+	if len(args) != 1 {
+		return 0, usage
+	}
+	index, err := strconv.Atoi(strings.TrimSpace(args[0]))
+	if err != nil {
+		return 0, usage
+	}
+	if index < 1 {
+		return 0, &igwerr.UsageError{Msg: "history index must be >= 1"}
+	}
+	return index, nil
+}
+
+func (c *CLI) historyPath() (string, error) {
+	if c.HistoryPath == nil {
+		return "", errors.New("history is not available in this runtime")
+	}
+	return c.HistoryPath()
+}
+
+// readHistoryViews returns the history newest first, numbered from 1.
+func (c *CLI) readHistoryViews() ([]historyEntryView, error) {
+	path, err := c.historyPath()
+	if err != nil {
+		return nil, &igwerr.UsageError{Msg: err.Error()}
+	}
+	entries, err := config.ReadHistory(path)
+	if err != nil {
+		return nil, &igwerr.UsageError{Msg: err.Error()}
+	}
+	views := make([]historyEntryView, 0, len(entries))
+	for i := len(entries) - 1; i >= 0; i-- {
+		views = append(views, historyEntryView{Index: len(views) + 1, HistoryEntry: entries[i]})
+	}
+	return views, nil
+}
+
+func (c *CLI) historyEntry(index int) (historyEntryView, error) {
+	views, err := c.readHistoryViews()
+	if err != nil {
+		return historyEntryView{}, err
+	}
+	if index > len(views) {
+		return historyEntryView{}, &igwerr.UsageError{Msg: fmt.Sprintf("history entry %d not found (%d recorded)", index, len(views))}
+	}
+	return views[index-1], nil
+}
+
+// recordHistory appends a finished call when history.enabled is set.
+// Failures only warn; the call itself has already run.
+func (c *CLI) recordHistory(entry config.HistoryEntry) {
+	if c.HistoryPath == nil || c.ReadConfig == nil {
+		return
+	}
+	cfg, err := c.ReadConfig()
+	if err != nil || !cfg.HistoryEnabled() {
+		return
+	}
+	path, err := c.HistoryPath()
+	if err == nil {
+		err = config.AppendHistory(path, entry)
+	}
+	if err != nil {
+		fmt.Fprintf(c.Err, "warning: record history: %v\n", err)
+	}
+}
+
+// newHistoryEntry describes a call that reached the gateway, or returns
+// false when err means nothing was sent (a usage error).
+func newHistoryEntry(input callExecutionInput, resolved config.Effective, start time.Time, resp *gateway.CallResponse, err error) (config.HistoryEntry, bool) {
+	var usageErr *igwerr.UsageError
+	if input.DryRun || errors.As(err, &usageErr) {
+		return config.HistoryEntry{}, false
+	}
+	method := strings.ToUpper(strings.TrimSpace(input.Method))
+	if method == "" {
+		method = "GET"
+	}
+	entry := config.HistoryEntry{
+		Time:       start.UTC(),
+		Method:     method,
+		Path:       strings.TrimSpace(input.Path),
+		Query:      append([]string(nil), input.Query...),
+		DurationMs: time.Since(start).Milliseconds(),
+		Profile:    resolved.Profile,
+		GatewayURL: resolved.GatewayURL,
+		BodyBytes:  len(input.Body),
+		Form:       input.Form != nil,
+	}
+	var statusErr *igwerr.StatusError
+	switch {
+	case resp != nil:
+		entry.Status = resp.StatusCode
+	case errors.As(err, &statusErr):
+		entry.Status = statusErr.StatusCode
+	}
+	return entry, true
+}
+
+func historyTarget(entry config.HistoryEntry) string {
+	if len(entry.Query) == 0 {
+		return entry.Path
+	}
+	return entry.Path + "?" + strings.Join(entry.Query, "&")
+}
+
+func historyStatusText(status int) string {
+	if status == 0 {
+		return "-"
+	}
+	return strconv.Itoa(status)
+}
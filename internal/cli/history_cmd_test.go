@@ -0,0 +1,154 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/alex-mccollum/igw-cli/internal/config"
+)
+
+// historyTestClient answers DELETE with 404 and everything else with 200.
+func historyTestClient() *http.Client {
+	return newMockHTTPClient(func(r *http.Request) (*http.Response, error) {
+		if r.Method == http.MethodDelete {
+			return mockHTTPResponse(http.StatusNotFound, `{}`, nil), nil
+		}
+		return mockHTTPResponse(http.StatusOK, `{"ok":true}`, nil), nil
+	})
+}
+
+func historyTestConfig(enabled bool) func() (config.File, error) {
+	return func() (config.File, error) {
+		return config.File{History: &config.History{Enabled: enabled}}, nil
+	}
+}
+
+func historyTestCall(t *testing.T, c *CLI, args ...string) error {
+	t.Helper()
+	base := []string{"call", "--gateway-url", mockGatewayURL, "--api-key", "history-secret"}
+	return c.Execute(append(base, args...))
+}
+
+func TestHistoryRecordsListsAndReplays(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "history.ndjson")
+	out := new(bytes.Buffer)
+	c := newAdminWrapperTestCLI(historyTestClient())
+	c.Out = out
+	c.ReadConfig = historyTestConfig(true)
+	c.HistoryPath = func() (string, error) { return path, nil }
+	if err := historyTestCall(t, c, "--path", "/data/api/v1/projects", "--query", "limit=5"); err != nil {
+		t.Fatalf("get: %v", err)
+	}
+	if err := historyTestCall(t, c, "--method", "POST", "--path", "/data/api/v1/scan/projects", "--body", `{"a":1}`, "--yes"); err != nil {
+		t.Fatalf("post: %v", err)
+	}
+	_ = historyTestCall(t, c, "--method", "DELETE", "--path", "/data/api/v1/projects/x", "--yes")
+	// Refused before sending, so not recorded.
+	_ = historyTestCall(t, c, "--method", "DELETE", "--path", "/data/api/v1/projects/y")
+
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("read history: %v", err)
+	}
+	if strings.Contains(string(raw), "history-secret") || strings.Contains(string(raw), `{\"a\":1}`) {
+		t.Fatalf("history must not hold the token or body: %s", raw)
+	}
+
+	out.Reset()
+	if err := c.Execute([]string{"history", "list", "--json"}); err != nil {
+		t.Fatalf("list: %v", err)
+	}
+	var listed struct {
+		Count   int                `json:"count"`
+		Entries []historyEntryView `json:"entries"`
+	}
+	if err := json.Unmarshal(out.Bytes(), &listed); err != nil {
+		t.Fatalf("parse list: %v\n%s", err, out.String())
+	}
+	if listed.Count != 3 || listed.Entries[0].Method != "DELETE" || listed.Entries[0].Status != 404 {
+		t.Fatalf("unexpected list %+v", listed)
+	}
+	if got := listed.Entries[2]; got.Index != 3 || got.Path != "/data/api/v1/projects" || len(got.Query) != 1 || got.Query[0] != "limit=5" {
+		t.Fatalf("unexpected oldest entry %+v", got)
+	}
+
+	out.Reset()
+	if err := c.Execute([]string{"history", "show", "2"}); err != nil {
+		t.Fatalf("show: %v", err)
+	}
+	if !strings.Contains(out.String(), "method\tPOST\n") || !strings.Contains(out.String(), "body_bytes\t7\n") {
+		t.Fatalf("unexpected show output %q", out.String())
+	}
+
+	out.Reset()
+	if err := c.Execute([]string{"history", "show", "--json", "2"}); err != nil || !strings.Contains(out.String(), `"bodyBytes": 7`) {
+		t.Fatalf("show with flags first: %v %q", err, out.String())
+	}
+
+	requireUsageExitCode(t, c.Execute([]string{"history", "replay", "2", "--gateway-url", mockGatewayURL, "--api-key", "history-secret", "--yes"}))
+	requireUsageExitCode(t, c.Execute([]string{"history", "replay", "1", "--gateway-url", mockGatewayURL, "--api-key", "history-secret"}))
+	if err := c.Execute([]string{"history", "replay", "3", "--gateway-url", mockGatewayURL, "--api-key", "history-secret"}); err != nil {
+		t.Fatalf("replay get: %v", err)
+	}
+
+	entries, err := config.ReadHistory(path)
+	if err != nil || len(entries) != 4 || entries[3].Path != "/data/api/v1/projects" {
+		t.Fatalf("expected the replay recorded, got %+v (%v)", entries, err)
+	}
+
+	if err := c.Execute([]string{"history", "clear"}); err != nil {
+		t.Fatalf("clear: %v", err)
+	}
+	requireUsageExitCode(t, c.Execute([]string{"history", "show", "1"}))
+}
+
+func TestHistoryReplayTargetsRecordedGateway(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "history.ndjson")
+	out := new(bytes.Buffer)
+	c := newAdminWrapperTestCLI(historyTestClient())
+	c.Out = out
+	c.ReadConfig = historyTestConfig(true)
+	c.HistoryPath = func() (string, error) { return path, nil }
+	if err := historyTestCall(t, c, "--path", "/data/api/v1/projects"); err != nil {
+		t.Fatalf("get: %v", err)
+	}
+
+	out.Reset()
+	if err := c.Execute([]string{"history", "show", "1"}); err != nil || !strings.Contains(out.String(), "gateway_url\t"+mockGatewayURL+"\n") {
+		t.Fatalf("show: %v %q", err, out.String())
+	}
+
+	// The config holds no gateway URL, so the replay can only succeed by
+	// using the one recorded with the entry.
+	if err := c.Execute([]string{"history", "replay", "1", "--api-key", "history-secret"}); err != nil {
+		t.Fatalf("replay: %v", err)
+	}
+	entries, err := config.ReadHistory(path)
+	if err != nil || len(entries) != 2 || entries[1].GatewayURL != mockGatewayURL {
+		t.Fatalf("expected the replay sent to the recorded gateway, got %+v (%v)", entries, err)
+	}
+}
+
+func TestHistoryDisabledRecordsNothing(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "history.ndjson")
+	c := newAdminWrapperTestCLI(historyTestClient())
+	c.ReadConfig = historyTestConfig(false)
+	c.HistoryPath = func() (string, error) { return path, nil }
+	if err := historyTestCall(t, c, "--path", "/data/api/v1/projects"); err != nil {
+		t.Fatalf("get: %v", err)
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Fatalf("expected no history file, got %v", err)
+	}
+}
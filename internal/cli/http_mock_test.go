@@ -18,6 +18,13 @@ func newMockHTTPClient(fn roundTripFunc) *http.Client {
 	return &http.Client{Transport: fn}
 }
 
+// newStaticHTTPClient answers every request with status and body.
+func newStaticHTTPClient(status int, body string) *http.Client {
+	return newMockHTTPClient(func(*http.Request) (*http.Response, error) {
+		return mockHTTPResponse(status, body, nil), nil
+	})
+}
+
 func mockHTTPResponse(status int, body string, headers http.Header) *http.Response {
 	if headers == nil {
 		headers = make(http.Header)
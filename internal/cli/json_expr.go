@@ -0,0 +1,690 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// jsonExpr is a parsed --expr: a path that may fan out through wildcards
+// and filters, followed by optional pipes to length or keys.
+//
+//	items[*].name
+//	items[?(@.enabled && @.state != 'faulted')].name | length
+//	response.body | keys
+type jsonExpr struct {
+	source string
+	steps  []exprStep
+	funcs  []string
+	// multi is set when a wildcard or filter can yield several matches;
+	// such expressions always print a list, even of one or zero matches.
+	multi bool
+}
+
+type exprStepKind int
+
+const (
+	exprStepKey exprStepKind = iota
+	exprStepIndex
+	exprStepWildcard
+	exprStepFilter
+)
+
+type exprStep struct {
+	kind   exprStepKind
+	key    string
+	index  int
+	filter exprNode
+}
+
+// exprParseError reports the 1-based byte position of a syntax error.
+type exprParseError struct {
+	pos int
+	msg string
+}
+
+func (e *exprParseError) Error() string {
+	return fmt.Sprintf("at position %d: %s", e.pos, e.msg)
+}
+
+var exprFuncs = []string{"length", "keys"}
+
+func parseJSONExpr(source string) (*jsonExpr, error) {
+	p := &exprParser{src: source}
+	expr := &jsonExpr{source: source}
+
+	p.skipSpace()
+	if p.eof() {
+		return nil, p.errorf("empty expression")
+	}
+	steps, multi, err := p.parsePath(false)
+	if err != nil {
+		return nil, err
+	}
+	expr.steps = steps
+	expr.multi = multi
+
+	for {
+		p.skipSpace()
+		if p.eof() {
+			break
+		}
+		if p.peek() != '|' {
+			return nil, p.errorf("unexpected %q", p.peek())
+		}
+		p.pos++
+		p.skipSpace()
+		start := p.pos
+		name := p.readIdent()
+		if name == "" {
+			return nil, p.errorf("expected function name after |")
+		}
+		if !slices.Contains(exprFuncs, name) {
+			return nil, &exprParseError{pos: start + 1, msg: fmt.Sprintf("unknown function %q (supported: %s)", name, strings.Join(exprFuncs, ", "))}
+		}
+		expr.funcs = append(expr.funcs, name)
+	}
+	return expr, nil
+}
+
+type exprParser struct {
+	src string
+	pos int
+}
+
+func (p *exprParser) eof() bool { return p.pos >= len(p.src) }
+
+func (p *exprParser) peek() byte { return p.src[p.pos] }
+
+func (p *exprParser) errorf(format string, args ...any) error {
+	return &exprParseError{pos: p.pos + 1, msg: fmt.Sprintf(format, args...)}
+}
+
+func (p *exprParser) skipSpace() {
+	for !p.eof() && (p.peek() == ' ' || p.peek() == '\t') {
+		p.pos++
+	}
+}
+
+func (p *exprParser) consume(token string) bool {
+	if strings.HasPrefix(p.src[p.pos:], token) {
+		p.pos += len(token)
+		return true
+	}
+	return false
+}
+
+func isExprIdentByte(b byte) bool {
+	return b == '_' || b == '-' || b >= '0' && b <= '9' || b >= 'a' && b <= 'z' || b >= 'A' && b <= 'Z' || b >= utf8.RuneSelf
+}
+
+func (p *exprParser) readIdent() string {
+	start := p.pos
+	for !p.eof() && isExprIdentByte(p.peek()) {
+		p.pos++
+	}
+	return p.src[start:p.pos]
+}
+
+// parsePath reads path steps. Inside a filter (relative is true) the path
+// follows @ and may not fan out.
+func (p *exprParser) parsePath(relative bool) ([]exprStep, bool, error) {
+	var steps []exprStep
+	multi := false
+
+	if !relative {
+		p.consume("$")
+		if !p.eof() && isExprIdentByte(p.peek()) {
+			steps = append(steps, exprStep{kind: exprStepKey, key: p.readIdent()})
+		}
+	}
+
+	for !p.eof() {
+		start := p.pos
+		switch p.peek() {
+		case '.':
+			p.pos++
+			if p.consume("*") {
+				steps = append(steps, exprStep{kind: exprStepWildcard})
+				multi = true
+				continue
+			}
+			name := p.readIdent()
+			if name == "" {
+				return nil, false, p.errorf("expected key after .")
+			}
+			steps = append(steps, exprStep{kind: exprStepKey, key: name})
+		case '[':
+			step, err := p.parseBracket(relative)
+			if err != nil {
+				return nil, false, err
+			}
+			if step.kind == exprStepWildcard || step.kind == exprStepFilter {
+				multi = true
+			}
+			steps = append(steps, step)
+		default:
+			return steps, multi, nil
+		}
+		if relative && multi {
+			return nil, false, &exprParseError{pos: start + 1, msg: "wildcards and filters are not supported inside a filter"}
+		}
+	}
+	return steps, multi, nil
+}
+
+func (p *exprParser) parseBracket(relative bool) (exprStep, error) {
+	open := p.pos
+	p.pos++
+	p.skipSpace()
+	if p.eof() {
+		return exprStep{}, p.errorf("unterminated [")
+	}
+
+	var step exprStep
+	switch ch := p.peek(); {
+	case ch == '*':
+		p.pos++
+		step = exprStep{kind: exprStepWildcard}
+	case ch == '?':
+		if relative {
+			return exprStep{}, p.errorf("wildcards and filters are not supported inside a filter")
+		}
+		p.pos++
+		filter, err := p.parseOr()
+		if err != nil {
+			return exprStep{}, err
+		}
+		step = exprStep{kind: exprStepFilter, filter: filter}
+	case ch == '\'' || ch == '"':
+		key, err := p.parseString()
+		if err != nil {
+			return exprStep{}, err
+		}
+		step = exprStep{kind: exprStepKey, key: key}
+	case ch == '-' || ch >= '0' && ch <= '9':
+		start := p.pos
+		p.pos++
+		for !p.eof() && p.peek() >= '0' && p.peek() <= '9' {
+			p.pos++
+		}
+		index, err := strconv.Atoi(p.src[start:p.pos])
+		if err != nil {
+			return exprStep{}, &exprParseError{pos: start + 1, msg: fmt.Sprintf("invalid index %q", p.src[start:p.pos])}
+		}
+		step = exprStep{kind: exprStepIndex, index: index}
+	default:
+		return exprStep{}, p.errorf("expected *, ?, an index, or a quoted key after [")
+	}
+
+	p.skipSpace()
+	if p.eof() || p.peek() != ']' {
+		if p.eof() {
+			return exprStep{}, &exprParseError{pos: open + 1, msg: "unterminated ["}
+		}
+		return exprStep{}, p.errorf("expected ]")
+	}
+	p.pos++
+	return step, nil
+}
+
+func (p *exprParser) parseString() (string, error) {
+	quote := p.peek()
+	start := p.pos
+	p.pos++
+	var b strings.Builder
+	for !p.eof() {
+		ch := p.peek()
+		p.pos++
+		switch {
+		case ch == quote:
+			return b.String(), nil
+		case ch == '\\' && !p.eof():
+			b.WriteByte(p.peek())
+			p.pos++
+		default:
+			b.WriteByte(ch)
+		}
+	}
+	return "", &exprParseError{pos: start + 1, msg: "unterminated string"}
+}
+
+// exprNode is a filter expression evaluated against one candidate (@).
+type exprNode interface {
+	eval(r jsonPathReader, current any) (any, bool)
+}
+
+type exprPathNode struct{ steps []exprStep }
+
+type exprLiteralNode struct{ value any }
+
+type exprNotNode struct{ operand exprNode }
+
+type exprLogicNode struct {
+	and         bool
+	left, right exprNode
+}
+
+type exprCompareNode struct {
+	op          string
+	left, right exprNode
+}
+
+func (p *exprParser) parseOr() (exprNode, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		p.skipSpace()
+		if !p.consume("||") {
+			return left, nil
+		}
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = exprLogicNode{left: left, right: right}
+	}
+}
+
+func (p *exprParser) parseAnd() (exprNode, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		p.skipSpace()
+		if !p.consume("&&") {
+			return left, nil
+		}
+		right, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		left = exprLogicNode{and: true, left: left, right: right}
+	}
+}
+
+func (p *exprParser) parseUnary() (exprNode, error) {
+	p.skipSpace()
+	if p.eof() {
+		return nil, p.errorf("unexpected end of filter")
+	}
+	if p.peek() == '!' && !strings.HasPrefix(p.src[p.pos:], "!=") {
+		p.pos++
+		operand, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return exprNotNode{operand: operand}, nil
+	}
+	if p.peek() == '(' {
+		open := p.pos
+		p.pos++
+		inner, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		p.skipSpace()
+		if !p.consume(")") {
+			if p.eof() {
+				return nil, &exprParseError{pos: open + 1, msg: "unterminated ("}
+			}
+			return nil, p.errorf("expected )")
+		}
+		return inner, nil
+	}
+	return p.parseComparison()
+}
+
+func (p *exprParser) parseComparison() (exprNode, error) {
+	left, err := p.parseOperand()
+	if err != nil {
+		return nil, err
+	}
+	p.skipSpace()
+	for _, op := range []string{"==", "!=", "<=", ">=", "<", ">"} {
+		if p.consume(op) {
+			right, err := p.parseOperand()
+			if err != nil {
+				return nil, err
+			}
+			return exprCompareNode{op: op, left: left, right: right}, nil
+		}
+	}
+	return left, nil
+}
+
+func (p *exprParser) parseOperand() (exprNode, error) {
+	p.skipSpace()
+	if p.eof() {
+		return nil, p.errorf("expected a value")
+	}
+	switch ch := p.peek(); {
+	case ch == '@':
+		p.pos++
+		steps, _, err := p.parsePath(true)
+		if err != nil {
+			return nil, err
+		}
+		return exprPathNode{steps: steps}, nil
+	case ch == '\'' || ch == '"':
+		text, err := p.parseString()
+		if err != nil {
+			return nil, err
+		}
+		return exprLiteralNode{value: text}, nil
+	case ch == '-' || ch >= '0' && ch <= '9':
+		start := p.pos
+		p.pos++
+		for !p.eof() && (p.peek() >= '0' && p.peek() <= '9' || p.peek() == '.' || p.peek() == 'e' || p.peek() == 'E') {
+			p.pos++
+		}
+		number, err := strconv.ParseFloat(p.src[start:p.pos], 64)
+		if err != nil {
+			return nil, &exprParseError{pos: start + 1, msg: fmt.Sprintf("invalid number %q", p.src[start:p.pos])}
+		}
+		return exprLiteralNode{value: number}, nil
+	default:
+		start := p.pos
+		switch word := p.readIdent(); word {
+		case "true":
+			return exprLiteralNode{value: true}, nil
+		case "false":
+			return exprLiteralNode{value: false}, nil
+		case "null":
+			return exprLiteralNode{value: nil}, nil
+		default:
+			p.pos = start
+			return nil, p.errorf("expected @, a string, a number, true, false, or null")
+		}
+	}
+}
+
+func (n exprPathNode) eval(r jsonPathReader, current any) (any, bool) {
+	for _, step := range n.steps {
+		matches, _ := r.exprStep(current, step)
+		if len(matches) != 1 {
+			return nil, false
+		}
+		current = matches[0]
+	}
+	return current, true
+}
+
+func (n exprLiteralNode) eval(jsonPathReader, any) (any, bool) { return n.value, true }
+
+func (n exprNotNode) eval(r jsonPathReader, current any) (any, bool) {
+	return !exprTruthy(n.operand.eval(r, current)), true
+}
+
+func (n exprLogicNode) eval(r jsonPathReader, current any) (any, bool) {
+	left := exprTruthy(n.left.eval(r, current))
+	if n.and {
+		return left && exprTruthy(n.right.eval(r, current)), true
+	}
+	return left || exprTruthy(n.right.eval(r, current)), true
+}
+
+func (n exprCompareNode) eval(r jsonPathReader, current any) (any, bool) {
+	left, leftOK := n.left.eval(r, current)
+	right, rightOK := n.right.eval(r, current)
+	if !leftOK || !rightOK {
+		return n.op == "!=", true
+	}
+	left, right = exprComparable(left), exprComparable(right)
+	switch n.op {
+	case "==":
+		return exprEqual(left, right), true
+	case "!=":
+		return !exprEqual(left, right), true
+	}
+
+	var cmp int
+	switch l := left.(type) {
+	case float64:
+		r, ok := right.(float64)
+		if !ok {
+			return false, true
+		}
+		cmp = compareOrdered(l, r)
+	case string:
+		r, ok := right.(string)
+		if !ok {
+			return false, true
+		}
+		cmp = strings.Compare(l, r)
+	default:
+		return false, true
+	}
+	switch n.op {
+	case "<":
+		return cmp < 0, true
+	case "<=":
+		return cmp <= 0, true
+	case ">":
+		return cmp > 0, true
+	default:
+		return cmp >= 0, true
+	}
+}
+
+func compareOrdered(a, b float64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// exprComparable turns --preserve-numbers json.Number values into float64
+// so literals compare against them.
+func exprComparable(value any) any {
+	if number, ok := value.(json.Number); ok {
+		if f, err := number.Float64(); err == nil {
+			return f
+		}
+	}
+	return value
+}
+
+func exprEqual(left, right any) bool {
+	switch l := left.(type) {
+	case nil, bool, float64, string:
+		return l == right
+	default:
+		a, errA := json.Marshal(left)
+		b, errB := json.Marshal(right)
+		return errA == nil && errB == nil && string(a) == string(b)
+	}
+}
+
+// exprTruthy treats missing values, null, false, 0, and "" as false.
+func exprTruthy(value any, ok bool) bool {
+	if !ok {
+		return false
+	}
+	switch v := exprComparable(value).(type) {
+	case nil:
+		return false
+	case bool:
+		return v
+	case float64:
+		return v != 0
+	case string:
+		return v != ""
+	default:
+		return true
+	}
+}
+
+// eval runs the expression against root. Single-valued expressions return
+// one value and an error when the path does not match; multi-valued ones
+// return every match, possibly none.
+func (e *jsonExpr) eval(r jsonPathReader, root any) ([]any, bool, error) {
+	nodes := []any{root}
+	var missing error
+	for _, step := range e.steps {
+		next := make([]any, 0, len(nodes))
+		for _, node := range nodes {
+			matches, err := r.exprStep(node, step)
+			if err != nil && missing == nil {
+				missing = err
+			}
+			next = append(next, matches...)
+		}
+		nodes = next
+	}
+	if !e.multi && len(nodes) == 0 {
+		return nil, false, missing
+	}
+
+	multi := e.multi
+	var value any = nodes
+	if !multi {
+		value = nodes[0]
+	}
+	for _, name := range e.funcs {
+		result, err := r.exprFunc(name, value)
+		if err != nil {
+			return nil, false, err
+		}
+		value = result
+		multi = name == "keys"
+	}
+	if multi {
+		return value.([]any), true, nil
+	}
+	return []any{value}, false, nil
+}
+
+// exprStep applies one step to node. Strings holding JSON are decoded
+// first, as with --select, so paths reach into envelope response bodies.
+func (r jsonPathReader) exprStep(node any, step exprStep) ([]any, error) {
+	if text, ok := node.(string); ok {
+		if decoded, ok := r.decodeEmbedded(text); ok {
+			node = decoded
+		}
+	}
+
+	switch step.kind {
+	case exprStepKey:
+		switch typed := node.(type) {
+		case map[string]any:
+			value, ok := typed[step.key]
+			if !ok {
+				return nil, fmt.Errorf("key %q not found", step.key)
+			}
+			return []any{value}, nil
+		case []any:
+			index, err := strconv.Atoi(step.key)
+			if err != nil {
+				return nil, fmt.Errorf("expected array index, got %q", step.key)
+			}
+			return exprIndex(typed, index)
+		default:
+			return nil, fmt.Errorf("cannot descend through %T at %q", node, step.key)
+		}
+	case exprStepIndex:
+		typed, ok := node.([]any)
+		if !ok {
+			return nil, fmt.Errorf("cannot index %T", node)
+		}
+		return exprIndex(typed, step.index)
+	case exprStepWildcard:
+		return exprChildren(node), nil
+	default:
+		var matches []any
+		for _, child := range exprChildren(node) {
+			if exprTruthy(step.filter.eval(r, child)) {
+				matches = append(matches, child)
+			}
+		}
+		return matches, nil
+	}
+}
+
+func exprIndex(items []any, index int) ([]any, error) {
+	if index < 0 {
+		index += len(items)
+	}
+	if index < 0 || index >= len(items) {
+		return nil, fmt.Errorf("array index %d out of range", index)
+	}
+	return []any{items[index]}, nil
+}
+
+// exprChildren lists array elements in order, or object values by key.
+func exprChildren(node any) []any {
+	switch typed := node.(type) {
+	case []any:
+		return typed
+	case map[string]any:
+		keys := make([]string, 0, len(typed))
+		for key := range typed {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		children := make([]any, 0, len(keys))
+		for _, key := range keys {
+			children = append(children, typed[key])
+		}
+		return children
+	default:
+		return nil
+	}
+}
+
+func (r jsonPathReader) exprFunc(name string, value any) (any, error) {
+	if text, ok := value.(string); ok {
+		if decoded, ok := r.decodeEmbedded(text); ok {
+			value = decoded
+		}
+	}
+
+	switch name {
+	case "length":
+		switch typed := value.(type) {
+		case nil:
+			return float64(0), nil
+		case []any:
+			return float64(len(typed)), nil
+		case map[string]any:
+			return float64(len(typed)), nil
+		case string:
+			return float64(utf8.RuneCountInString(typed)), nil
+		default:
+			return nil, fmt.Errorf("length: cannot take the length of %T", value)
+		}
+	default:
+		switch typed := value.(type) {
+		case map[string]any:
+			keys := make([]string, 0, len(typed))
+			for key := range typed {
+				keys = append(keys, key)
+			}
+			sort.Strings(keys)
+			out := make([]any, 0, len(keys))
+			for _, key := range keys {
+				out = append(out, key)
+			}
+			return out, nil
+		case []any:
+			out := make([]any, 0, len(typed))
+			for i := range typed {
+				out = append(out, float64(i))
+			}
+			return out, nil
+		default:
+			return nil, fmt.Errorf("keys: %T has no keys", value)
+		}
+	}
+}
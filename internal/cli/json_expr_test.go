@@ -0,0 +1,134 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/alex-mccollum/igw-cli/internal/igwerr"
+)
+
+const exprTestProjects = `{"items":[
+	{"name":"alpha","enabled":true,"tags":{"env":"prod"},"size":3},
+	{"name":"beta","enabled":false,"tags":{"env":"dev"},"size":12},
+	{"name":"gamma","enabled":true,"tags":{"env":"dev"},"size":7}
+]}`
+
+func TestJSONExprEval(t *testing.T) {
+	t.Parallel()
+
+	var root any
+	if err := json.Unmarshal([]byte(exprTestProjects), &root); err != nil {
+		t.Fatalf("decode fixture: %v", err)
+	}
+
+	tests := []struct {
+		expr  string
+		want  string
+		multi bool
+	}{
+		{expr: "items[*].name", want: `["alpha","beta","gamma"]`, multi: true},
+		{expr: "$.items.*.name", want: `["alpha","beta","gamma"]`, multi: true},
+		{expr: "items[?(@.enabled)].name", want: `["alpha","gamma"]`, multi: true},
+		{expr: "items[?(@.enabled == true && @.tags.env == 'dev')].name", want: `["gamma"]`, multi: true},
+		{expr: "items[?(@.size >= 7 || !@.enabled)].name", want: `["beta","gamma"]`, multi: true},
+		{expr: `items[?(@.tags["env"] != "prod")].size`, want: `[12,7]`, multi: true},
+		{expr: "items[?(@.missing)].name", want: `[]`, multi: true},
+		{expr: "items[?(@.enabled)] | length", want: `2`},
+		{expr: "items[-1].name", want: `"gamma"`},
+		{expr: "items.0.tags | keys", want: `["env"]`, multi: true},
+		{expr: "items | length", want: `3`},
+		{expr: "items[1]['name'] | length", want: `4`},
+	}
+
+	for _, tc := range tests {
+		expr, err := parseJSONExpr(tc.expr)
+		if err != nil {
+			t.Fatalf("%s: parse: %v", tc.expr, err)
+		}
+		matches, multi, err := expr.eval(jsonPathReader{}, root)
+		if err != nil {
+			t.Fatalf("%s: eval: %v", tc.expr, err)
+		}
+		var got any = matches
+		if !multi {
+			got = matches[0]
+		}
+		encoded, _ := json.Marshal(got)
+		if string(encoded) != tc.want || multi != tc.multi {
+			t.Fatalf("%s: got %s (multi=%t), want %s (multi=%t)", tc.expr, encoded, multi, tc.want, tc.multi)
+		}
+	}
+
+	expr, _ := parseJSONExpr("items.3.name")
+	if _, _, err := expr.eval(jsonPathReader{}, root); err == nil || !strings.Contains(err.Error(), "out of range") {
+		t.Fatalf("expected a missing single path to fail, got %v", err)
+	}
+}
+
+func TestJSONExprParseErrorsReportPosition(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]string{
+		"items[*":                      "at position 6: unterminated [",
+		"items[?(@.enabled]":           "at position 18: expected )",
+		"items[?(@.a == )].name":       "at position 16: expected @, a string, a number, true, false, or null",
+		"items | count":                "at position 9: unknown function \"count\"",
+		"items.":                       "at position 7: expected key after .",
+		"items[?(@.tags[*].env)].name": "at position 15: wildcards and filters are not supported inside a filter",
+		"items name":                   "at position 7: unexpected 'n'",
+	}
+	for source, want := range tests {
+		_, err := parseJSONExpr(source)
+		if err == nil || !strings.Contains(err.Error(), want) {
+			t.Fatalf("%s: expected %q, got %v", source, want, err)
+		}
+	}
+}
+
+func TestCallExprFiltersOutput(t *testing.T) {
+	t.Parallel()
+
+	c := newAdminWrapperTestCLI(newMockHTTPClient(func(*http.Request) (*http.Response, error) {
+		return mockHTTPResponse(http.StatusOK, exprTestProjects, http.Header{"Content-Type": {"application/json"}}), nil
+	}))
+	out := c.Out.(*bytes.Buffer)
+	base := []string{"call", "--gateway-url", mockGatewayURL, "--api-key", "secret", "--path", "/data/api/v1/projects/list", "--json"}
+
+	if err := c.Execute(append(base, "--expr", "response.body.items[?(@.enabled)].name")); err != nil {
+		t.Fatalf("call --expr: %v", err)
+	}
+	var names []string
+	if err := json.Unmarshal(out.Bytes(), &names); err != nil || strings.Join(names, ",") != "alpha,gamma" {
+		t.Fatalf("expected a JSON array of names, got %q", out.String())
+	}
+
+	out.Reset()
+	if err := c.Execute(append(base, "--expr-body", "--expr", "items[?(@.enabled)].name", "--raw")); err != nil {
+		t.Fatalf("call --expr-body --raw: %v", err)
+	}
+	if out.String() != "alpha\ngamma\n" {
+		t.Fatalf("expected one match per line, got %q", out.String())
+	}
+
+	out.Reset()
+	if err := c.Execute([]string{"projects", "list", "--gateway-url", mockGatewayURL, "--api-key", "secret", "--json", "--expr-body", "--expr", "items | length", "--raw"}); err != nil {
+		t.Fatalf("projects list --expr: %v", err)
+	}
+	if out.String() != "3\n" {
+		t.Fatalf("expected wrappers to forward --expr, got %q", out.String())
+	}
+
+	err := c.Execute(append(base, "--expr", "items[?(@.enabled"))
+	requireUsageExitCode(t, err)
+	if !strings.Contains(err.Error(), "position") {
+		t.Fatalf("expected the parse position in %v", err)
+	}
+	requireUsageExitCode(t, c.Execute(append(base, "--expr", "items", "--select", "ok")))
+	requireUsageExitCode(t, c.Execute(append(base, "--expr-body")))
+	if code := igwerr.ExitCode(c.Execute(append(base, "--expr", "response.body.nope"))); code != 2 {
+		t.Fatalf("expected a missing path to exit 2, got %d", code)
+	}
+}
@@ -1,16 +1,29 @@
 package cli
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
+	"io"
 	"strconv"
 	"strings"
 
 	"github.com/alex-mccollum/igw-cli/internal/igwerr"
 )
 
+// jsonPathReader walks JSON payloads for --select and friends. With
+// preserveNumbers set, numbers decode as json.Number so integers beyond
+// 2^53 keep every digit instead of rounding through float64.
+type jsonPathReader struct {
+	preserveNumbers bool
+}
+
 func extractJSONPathRaw(payload any, path string) (string, error) {
-	value, err := extractJSONPathValue(payload, path)
+	return jsonPathReader{}.extractRaw(payload, path)
+}
+
+func (r jsonPathReader) extractRaw(payload any, path string) (string, error) {
+	value, err := r.extractValue(payload, path)
 	if err != nil {
 		return "", err
 	}
@@ -39,15 +52,15 @@ func formatJSONValueForRawOutput(value any) (string, error) {
 	}
 }
 
-func selectJSONPaths(payload any, selectors []string) (map[string]any, error) {
-	root, err := normalizeJSONPayload(payload)
+func (r jsonPathReader) selectPaths(payload any, selectors []string) (map[string]any, error) {
+	root, err := r.normalize(payload)
 	if err != nil {
 		return nil, err
 	}
 
 	out := make(map[string]any, len(selectors))
 	for _, selector := range selectors {
-		value, extractErr := extractJSONPathValueFromRoot(root, selector)
+		value, extractErr := r.extractFromRoot(root, selector)
 		if extractErr != nil {
 			return nil, &igwerr.UsageError{
 				Msg: fmt.Sprintf("invalid --select path %q: %v", strings.TrimSpace(selector), extractErr),
@@ -59,15 +72,19 @@ func selectJSONPaths(payload any, selectors []string) (map[string]any, error) {
 	return out, nil
 }
 
-func extractJSONPathValue(payload any, path string) (any, error) {
-	root, err := normalizeJSONPayload(payload)
+func (r jsonPathReader) extractValue(payload any, path string) (any, error) {
+	root, err := r.normalize(payload)
 	if err != nil {
 		return nil, err
 	}
-	return extractJSONPathValueFromRoot(root, path)
+	return r.extractFromRoot(root, path)
 }
 
 func normalizeJSONPayload(payload any) (any, error) {
+	return jsonPathReader{}.normalize(payload)
+}
+
+func (r jsonPathReader) normalize(payload any) (any, error) {
 	switch root := payload.(type) {
 	case nil:
 		return nil, nil
@@ -79,40 +96,48 @@ func normalizeJSONPayload(payload any) (any, error) {
 		if len(root) == 0 {
 			return nil, nil
 		}
-		var decoded any
-		if err := json.Unmarshal(root, &decoded); err != nil {
-			return nil, fmt.Errorf("decode payload: %w", err)
-		}
-		return decoded, nil
+		return r.decode(root)
 	case json.RawMessage:
 		if len(root) == 0 {
 			return nil, nil
 		}
-		var decoded any
-		if err := json.Unmarshal(root, &decoded); err != nil {
-			return nil, fmt.Errorf("decode payload: %w", err)
-		}
-		return decoded, nil
+		return r.decode(root)
 	default:
 		b, err := json.Marshal(payload)
 		if err != nil {
 			return nil, fmt.Errorf("encode payload: %w", err)
 		}
-		var decoded any
-		if err := json.Unmarshal(b, &decoded); err != nil {
-			return nil, fmt.Errorf("decode payload: %w", err)
-		}
-		return decoded, nil
+		return r.decode(b)
+	}
+}
+
+func (r jsonPathReader) decode(data []byte) (any, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	if r.preserveNumbers {
+		decoder.UseNumber()
 	}
+	var decoded any
+	if err := decoder.Decode(&decoded); err != nil {
+		return nil, fmt.Errorf("decode payload: %w", err)
+	}
+	if _, err := decoder.Token(); err != io.EOF {
+		return nil, fmt.Errorf("decode payload: unexpected data after JSON value")
+	}
+	return decoded, nil
 }
 
 func extractJSONPathValueFromRoot(root any, path string) (any, error) {
+	return jsonPathReader{}.extractFromRoot(root, path)
+}
+
+func (r jsonPathReader) extractFromRoot(root any, path string) (any, error) {
 	path = strings.TrimSpace(path)
 	if path == "" {
 		return nil, fmt.Errorf("select path is empty")
 	}
 
 	current := root
+	previous := ""
 	segments := strings.Split(path, ".")
 	for _, segment := range segments {
 		token := strings.TrimSpace(segment)
@@ -120,6 +145,16 @@ func extractJSONPathValueFromRoot(root any, path string) (any, error) {
 			return nil, fmt.Errorf("invalid path segment in %q", path)
 		}
 
+		if text, ok := current.(string); ok && previous == "body" {
+			// Response bodies are carried as strings in envelopes; let paths
+			// continue into them when they hold a JSON object or array.
+			// Other string fields stay opaque even if they look like JSON.
+			if decoded, ok := r.decodeEmbedded(text); ok {
+				current = decoded
+			}
+		}
+		previous = token
+
 		switch node := current.(type) {
 		case map[string]any:
 			next, ok := node[token]
@@ -143,3 +178,15 @@ func extractJSONPathValueFromRoot(root any, path string) (any, error) {
 
 	return current, nil
 }
+
+func (r jsonPathReader) decodeEmbedded(text string) (any, bool) {
+	trimmed := strings.TrimSpace(text)
+	if !strings.HasPrefix(trimmed, "{") && !strings.HasPrefix(trimmed, "[") {
+		return nil, false
+	}
+	decoded, err := r.decode([]byte(trimmed))
+	if err != nil {
+		return nil, false
+	}
+	return decoded, true
+}
@@ -187,3 +187,31 @@ func TestNormalizeJSONPayloadFallbackRoundTrip(t *testing.T) {
 		}
 	})
 }
+
+func TestExtractJSONPathRawDescendsIntoJSONBody(t *testing.T) {
+	t.Parallel()
+
+	payload := map[string]any{
+		"response": map[string]any{
+			"body": `{"count":3,"items":[{"level":"WARN"}]}`,
+		},
+	}
+
+	got, err := extractJSONPathRaw(payload, "response.body.items.0.level")
+	if err != nil {
+		t.Fatalf("extract path: %v", err)
+	}
+	if got != "WARN" {
+		t.Fatalf("unexpected extracted value %q", got)
+	}
+
+	_, err = extractJSONPathRaw(map[string]any{"body": "plain text"}, "body.count")
+	if err == nil || !strings.Contains(err.Error(), "cannot descend") {
+		t.Fatalf("expected descend error for non-JSON body, got %v", err)
+	}
+
+	_, err = extractJSONPathRaw(map[string]any{"message": `{"count":3}`}, "message.count")
+	if err == nil || !strings.Contains(err.Error(), "cannot descend") {
+		t.Fatalf("expected only body strings to be decoded, got %v", err)
+	}
+}
@@ -12,12 +12,27 @@ type jsonSelectOptions struct {
 	compact   bool
 	raw       bool
 	selectors []string
+	// preserveNumbers keeps selected numbers as exact JSON literals
+	// (--preserve-numbers).
+	preserveNumbers bool
+	// flatten rewrites the whole payload as a single-level object
+	// (--flatten); it is never combined with selectors.
+	flatten *recordFlattener
+	// expr replaces selectors with a --expr expression; exprBody applies it
+	// to the response body instead of the whole envelope (--expr-body).
+	expr     *jsonExpr
+	exprBody bool
 }
 
 func newJSONSelectOptions(jsonOutput, compact, raw bool, selectors []string) (jsonSelectOptions, error) {
+	return newJSONExprSelectOptions(jsonOutput, compact, raw, selectors, "", false)
+}
+
+func newJSONExprSelectOptions(jsonOutput, compact, raw bool, selectors []string, expr string, exprBody bool) (jsonSelectOptions, error) {
 	opts := jsonSelectOptions{
-		compact: compact,
-		raw:     raw,
+		compact:  compact,
+		raw:      raw,
+		exprBody: exprBody,
 	}
 
 	normalized, err := normalizeJSONSelectors(selectors)
@@ -35,7 +50,22 @@ func newJSONSelectOptions(jsonOutput, compact, raw bool, selectors []string) (js
 	if len(opts.selectors) > 0 && !jsonOutput {
 		return opts, &igwerr.UsageError{Msg: "required: --json when using --select"}
 	}
-	if opts.raw && len(opts.selectors) != 1 {
+	if strings.TrimSpace(expr) != "" {
+		if len(opts.selectors) > 0 {
+			return opts, &igwerr.UsageError{Msg: "use either --expr or --select, not both"}
+		}
+		if !jsonOutput {
+			return opts, &igwerr.UsageError{Msg: "required: --json when using --expr"}
+		}
+		parsed, err := parseJSONExpr(strings.TrimSpace(expr))
+		if err != nil {
+			return opts, &igwerr.UsageError{Msg: fmt.Sprintf("invalid --expr %q: %v", strings.TrimSpace(expr), err)}
+		}
+		opts.expr = parsed
+	} else if exprBody {
+		return opts, &igwerr.UsageError{Msg: "required: --expr when using --expr-body"}
+	}
+	if opts.raw && opts.expr == nil && len(opts.selectors) != 1 {
 		return opts, &igwerr.UsageError{Msg: "required: exactly one --select when using --raw"}
 	}
 	if opts.raw && opts.compact {
@@ -74,8 +104,11 @@ func normalizeJSONSelectors(selectors []string) ([]string, error) {
 }
 
 func printJSONSelection(w io.Writer, payload any, opts jsonSelectOptions) error {
+	if opts.expr != nil {
+		return printJSONExpr(w, payload, opts)
+	}
 	if opts.raw {
-		extracted, err := extractJSONPathRaw(payload, opts.selectors[0])
+		extracted, err := opts.reader().extractRaw(payload, opts.selectors[0])
 		if err != nil {
 			return &igwerr.UsageError{
 				Msg: fmt.Sprintf("invalid --select path %q: %v", opts.selectors[0], err),
@@ -88,14 +121,66 @@ func printJSONSelection(w io.Writer, payload any, opts jsonSelectOptions) error
 	}
 
 	if len(opts.selectors) > 0 {
-		values, err := selectJSONPaths(payload, opts.selectors)
+		values, err := opts.reader().selectPaths(payload, opts.selectors)
 		if err != nil {
 			return err
 		}
 		return writeJSONWithOptions(w, values, opts.compact)
 	}
 
-	return writeJSONWithOptions(w, payload, opts.compact)
+	flattened, err := opts.flatten.apply(payload)
+	if err != nil {
+		return err
+	}
+	return writeJSONWithOptions(w, flattened, opts.compact)
+}
+
+// printJSONExpr prints --expr matches: a list expression prints one match
+// per line with --raw or a JSON array otherwise, and a single-valued one
+// prints its value.
+func printJSONExpr(w io.Writer, payload any, opts jsonSelectOptions) error {
+	reader := opts.reader()
+	root, err := reader.normalize(payload)
+	if err != nil {
+		return err
+	}
+	if opts.exprBody {
+		body, bodyErr := reader.extractFromRoot(root, "response.body")
+		if bodyErr != nil {
+			return &igwerr.UsageError{Msg: fmt.Sprintf("--expr-body: output has no response body: %v", bodyErr)}
+		}
+		if text, ok := body.(string); ok {
+			if decoded, ok := reader.decodeEmbedded(text); ok {
+				body = decoded
+			}
+		}
+		root = body
+	}
+
+	matches, multi, err := opts.expr.eval(reader, root)
+	if err != nil {
+		return &igwerr.UsageError{Msg: fmt.Sprintf("invalid --expr %q: %v", opts.expr.source, err)}
+	}
+	if !opts.raw {
+		if multi {
+			return writeJSONWithOptions(w, matches, opts.compact)
+		}
+		return writeJSONWithOptions(w, matches[0], opts.compact)
+	}
+	for _, match := range matches {
+		line, err := formatJSONValueForRawOutput(match)
+		if err != nil {
+			return &igwerr.UsageError{Msg: fmt.Sprintf("invalid --expr %q: %v", opts.expr.source, err)}
+		}
+		if _, err := fmt.Fprintln(w, line); err != nil {
+			return igwerr.NewTransportError(err)
+		}
+	}
+	return nil
+}
+
+func (o jsonSelectOptions) reader() jsonPathReader {
+	return jsonPathReader{preserveNumbers: o.preserveNumbers}
 }
 
 func selectionErrorOptions(opts jsonSelectOptions) jsonSelectOptions {
@@ -3,6 +3,7 @@ package cli
 import (
 	"bytes"
 	"encoding/json"
+	"net/http"
 	"strings"
 	"testing"
 
@@ -139,3 +140,36 @@ func TestPrintJSONSelectionRaw(t *testing.T) {
 		t.Fatalf("unexpected raw output %q", out.String())
 	}
 }
+
+func TestCallPreserveNumbersKeepsLargeIntegers(t *testing.T) {
+	t.Parallel()
+
+	// 2^60 is well past float64's exact integer range.
+	const body = `{"tag":{"id":1152921504606846976,"value":3.25}}`
+	run := func(args ...string) string {
+		t.Helper()
+		var out bytes.Buffer
+		c := newAdminWrapperTestCLI(newStaticHTTPClient(http.StatusOK, body))
+		c.Out = &out
+		err := c.Execute(append([]string{
+			"call",
+			"--gateway-url", mockGatewayURL,
+			"--api-key", "secret",
+			"--path", "/data/api/v1/tags",
+		}, args...))
+		if err != nil {
+			t.Fatalf("call %v failed: %v", args, err)
+		}
+		return out.String()
+	}
+
+	if got := run("--json", "--preserve-numbers", "--select", "response.body.tag.id", "--raw"); got != "1152921504606846976\n" {
+		t.Fatalf("raw select = %q", got)
+	}
+	if got := run("--json", "--preserve-numbers", "--compact", "--select", "response.body.tag"); got != `{"response.body.tag":{"id":1152921504606846976,"value":3.25}}`+"\n" {
+		t.Fatalf("object select = %q", got)
+	}
+	if got := run("--output", "kv"); !strings.Contains(got, "tag.id\t1152921504606846976\n") {
+		t.Fatalf("kv output = %q", got)
+	}
+}
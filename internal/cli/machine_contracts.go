@@ -9,10 +9,11 @@ type exitCodeEntry struct {
 
 func stableExitCodeMap() map[string]int {
 	return map[string]int{
-		"ok":      0,
-		"usage":   2,
-		"auth":    6,
-		"network": 7,
+		"ok":               0,
+		"usage":            2,
+		"auth":             6,
+		"network":          7,
+		"unexpectedStatus": 8,
 	}
 }
 
@@ -9,12 +9,23 @@ import (
 )
 
 func argsWantJSON(args []string) bool {
+	return argsWantBoolFlag(args, "json")
+}
+
+// argsWantCompact lets pre-parse errors honor --compact the same way
+// argsWantJSON lets them honor --json.
+func argsWantCompact(args []string) bool {
+	return argsWantBoolFlag(args, "compact")
+}
+
+func argsWantBoolFlag(args []string, name string) bool {
+	flagName := "--" + name
 	for _, arg := range args {
 		switch {
-		case arg == "--json":
+		case arg == flagName:
 			return true
-		case strings.HasPrefix(arg, "--json="):
-			raw := strings.TrimSpace(strings.TrimPrefix(arg, "--json="))
+		case strings.HasPrefix(arg, flagName+"="):
+			raw := strings.TrimSpace(strings.TrimPrefix(arg, flagName+"="))
 			if raw == "" {
 				continue
 			}
@@ -27,8 +38,12 @@ func argsWantJSON(args []string) bool {
 }
 
 func (c *CLI) printJSONCommandError(jsonOutput bool, err error) error {
+	return c.printJSONCommandErrorWithOptions(jsonOutput, false, err)
+}
+
+func (c *CLI) printJSONCommandErrorWithOptions(jsonOutput bool, compact bool, err error) error {
 	if jsonOutput {
-		_ = writeJSON(c.Out, jsonErrorPayload(err))
+		_ = writeJSONWithOptions(c.Out, jsonErrorPayload(err), compact)
 	}
 	return err
 }
@@ -50,6 +65,17 @@ func jsonErrorPayload(err error) map[string]any {
 		}
 	}
 
+	var unexpectedErr *unexpectedStatusError
+	if errors.As(err, &unexpectedErr) {
+		details["expectedStatus"] = unexpectedErr.Expected
+	}
+
+	var pinErr *specPinError
+	if errors.As(err, &pinErr) {
+		details["pin"] = pinErr.Pin
+		details["hash"] = pinErr.Hash
+	}
+
 	var transportErr *igwerr.TransportError
 	if errors.As(err, &transportErr) {
 		if transportErr.Timeout {
@@ -0,0 +1,112 @@
+package cli
+
+import (
+	"encoding/json"
+	"flag"
+	"strconv"
+
+	"github.com/alex-mccollum/igw-cli/internal/igwerr"
+)
+
+const defaultFlattenSep = "."
+
+// flattenOptions holds --flatten and --flatten-sep.
+type flattenOptions struct {
+	enabled bool
+	sep     string
+}
+
+func bindFlattenFlags(fs *flag.FlagSet, opts *flattenOptions) {
+	fs.BoolVar(&opts.enabled, "flatten", false, "Flatten JSON records into single-level objects with dotted keys")
+	fs.StringVar(&opts.sep, "flatten-sep", defaultFlattenSep, "Key separator for --flatten")
+}
+
+// flattener validates the flags and returns nil when flattening is off.
+// Flattened keys no longer match --select paths, so the two are exclusive.
+func (o flattenOptions) flattener(fs *flag.FlagSet, jsonOutput bool, selectOpts jsonSelectOptions) (*recordFlattener, error) {
+	if !o.enabled {
+		if flagWasSet(fs, "flatten-sep") {
+			return nil, &igwerr.UsageError{Msg: "--flatten-sep requires --flatten"}
+		}
+		return nil, nil
+	}
+	if !jsonOutput {
+		return nil, &igwerr.UsageError{Msg: "required: --json when using --flatten"}
+	}
+	if len(selectOpts.selectors) > 0 || selectOpts.expr != nil {
+		return nil, &igwerr.UsageError{Msg: "--flatten is not supported with --select or --expr"}
+	}
+	if o.sep == "" {
+		return nil, &igwerr.UsageError{Msg: "--flatten-sep must not be empty"}
+	}
+	return &recordFlattener{sep: o.sep}, nil
+}
+
+// recordFlattener rewrites a JSON record as a single-level object. Nested
+// object keys are joined with sep and array elements use their index, so
+// {"a":{"items":[{"name":"x"}]}} becomes {"a.items.0.name":"x"}. String
+// fields holding a JSON object or array (such as an envelope's response
+// body) are flattened as if they were nested. A nil *recordFlattener
+// leaves records unchanged.
+type recordFlattener struct {
+	sep string
+}
+
+func (f *recordFlattener) apply(record any) (any, error) {
+	if f == nil {
+		return record, nil
+	}
+	// Round-trip through JSON so structs nested inside maps are flattened
+	// by their JSON field names too.
+	data, err := json.Marshal(record)
+	if err != nil {
+		return nil, igwerr.NewTransportError(err)
+	}
+	reader := jsonPathReader{preserveNumbers: true}
+	root, err := reader.decode(data)
+	if err != nil {
+		return nil, igwerr.NewTransportError(err)
+	}
+	out := make(map[string]any)
+	f.walk(reader, out, "", root)
+	return out, nil
+}
+
+func (f *recordFlattener) walk(reader jsonPathReader, out map[string]any, prefix string, value any) {
+	if text, ok := value.(string); ok {
+		if decoded, ok := reader.decodeEmbedded(text); ok {
+			value = decoded
+		}
+	}
+
+	switch node := value.(type) {
+	case map[string]any:
+		if len(node) == 0 && prefix != "" {
+			out[prefix] = node
+			return
+		}
+		for key, child := range node {
+			f.walk(reader, out, f.join(prefix, key), child)
+		}
+	case []any:
+		if len(node) == 0 && prefix != "" {
+			out[prefix] = node
+			return
+		}
+		for i, child := range node {
+			f.walk(reader, out, f.join(prefix, strconv.Itoa(i)), child)
+		}
+	default:
+		if prefix == "" {
+			prefix = "value"
+		}
+		out[prefix] = value
+	}
+}
+
+func (f *recordFlattener) join(prefix string, key string) string {
+	if prefix == "" {
+		return key
+	}
+	return prefix + f.sep + key
+}
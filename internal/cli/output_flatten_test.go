@@ -0,0 +1,170 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/alex-mccollum/igw-cli/internal/config"
+)
+
+func TestRecordFlattenerFlattensNestedObjectsAndArrays(t *testing.T) {
+	t.Parallel()
+
+	record := map[string]any{
+		"name": "gw",
+		"tags": map[string]any{
+			"items": []any{
+				map[string]any{"name": "a", "value": 1},
+				map[string]any{"name": "b", "meta": map[string]any{}},
+			},
+			"empty": []any{},
+		},
+		"body": `{"nested":{"ok":true}}`,
+	}
+	got, err := (&recordFlattener{sep: "."}).apply(record)
+	if err != nil {
+		t.Fatalf("flatten: %v", err)
+	}
+
+	want := map[string]any{
+		"name":               "gw",
+		"tags.items.0.name":  "a",
+		"tags.items.0.value": json.Number("1"),
+		"tags.items.1.name":  "b",
+		"tags.items.1.meta":  map[string]any{},
+		"tags.empty":         []any{},
+		"body.nested.ok":     true,
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("flattened = %#v\nwant %#v", got, want)
+	}
+
+	var nilFlattener *recordFlattener
+	if same, _ := nilFlattener.apply(record); !reflect.DeepEqual(same, record) {
+		t.Fatalf("nil flattener changed the record: %#v", same)
+	}
+}
+
+func TestCallFlattenBatchUsesSeparator(t *testing.T) {
+	t.Parallel()
+
+	var out bytes.Buffer
+	c := newAdminWrapperTestCLI(newStaticHTTPClient(http.StatusOK, `{"items":[{"name":"a"}]}`))
+	c.Out = &out
+	c.In = strings.NewReader(`{"method":"GET","path":"/data/api/v1/tags"}` + "\n")
+	err := c.Execute([]string{
+		"call",
+		"--gateway-url", mockGatewayURL,
+		"--api-key", "secret",
+		"--batch", "-",
+		"--flatten",
+		"--flatten-sep", "/",
+	})
+	if err != nil {
+		t.Fatalf("call batch failed: %v", err)
+	}
+
+	var record map[string]any
+	if err := json.Unmarshal(out.Bytes(), &record); err != nil {
+		t.Fatalf("decode record %q: %v", out.String(), err)
+	}
+	if record["response/body/items/0/name"] != "a" || record["response/status"] != float64(200) {
+		t.Fatalf("unexpected flattened record: %v", record)
+	}
+	for key := range record {
+		if strings.Contains(key, ".") {
+			t.Fatalf("key %q used the default separator", key)
+		}
+	}
+}
+
+func TestCallFlattenJSONEnvelope(t *testing.T) {
+	t.Parallel()
+
+	var out bytes.Buffer
+	c := newAdminWrapperTestCLI(newStaticHTTPClient(http.StatusOK, `{"version":{"major":8}}`))
+	c.Out = &out
+	err := c.Execute([]string{
+		"call",
+		"--gateway-url", mockGatewayURL,
+		"--api-key", "secret",
+		"--path", "/data/api/v1/gateway-info",
+		"--json",
+		"--compact",
+		"--flatten",
+	})
+	if err != nil {
+		t.Fatalf("call failed: %v", err)
+	}
+	if !strings.Contains(out.String(), `"response.body.version.major":8`) {
+		t.Fatalf("expected flattened body key, got %s", out.String())
+	}
+}
+
+func TestWaitFlattenJSONResult(t *testing.T) {
+	t.Parallel()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"name":"gateway"}`))
+	}))
+	defer srv.Close()
+
+	var out bytes.Buffer
+	c := testWaitCLI(t, srv, &out)
+	if err := c.Execute([]string{
+		"wait", "gateway",
+		"--gateway-url", srv.URL,
+		"--api-key", "secret",
+		"--json",
+		"--json-stats",
+		"--flatten",
+	}); err != nil {
+		t.Fatalf("wait gateway failed: %v", err)
+	}
+
+	var record map[string]any
+	if err := json.Unmarshal(out.Bytes(), &record); err != nil {
+		t.Fatalf("decode output %q: %v", out.String(), err)
+	}
+	if record["ready"] != true {
+		t.Fatalf("expected ready in flattened result: %v", record)
+	}
+	for key, value := range record {
+		if _, nested := value.(map[string]any); nested {
+			t.Fatalf("key %q is still nested: %v", key, record)
+		}
+	}
+}
+
+func TestFlattenValidation(t *testing.T) {
+	t.Parallel()
+
+	for _, args := range [][]string{
+		{"--flatten"},
+		{"--json", "--flatten-sep", "/"},
+		{"--json", "--flatten", "--flatten-sep", ""},
+		{"--json", "--flatten", "--select", "ok"},
+	} {
+		c := &CLI{
+			In:     strings.NewReader(""),
+			Out:    new(bytes.Buffer),
+			Err:    new(bytes.Buffer),
+			Getenv: func(string) string { return "" },
+			ReadConfig: func() (config.File, error) {
+				return config.File{}, nil
+			},
+		}
+		err := c.Execute(append([]string{
+			"call",
+			"--gateway-url", mockGatewayURL,
+			"--api-key", "secret",
+			"--path", "/data/api/v1/gateway-info",
+		}, args...))
+		requireUsageExitCode(t, err)
+	}
+}
@@ -0,0 +1,95 @@
+package cli
+
+import (
+	"bufio"
+	"encoding/json"
+	"io"
+	"sync"
+)
+
+// recordWriter writes NDJSON records through a buffer. With flush set, each
+// record is flushed as soon as it is encoded so a reader on the other end of
+// a pipe sees it immediately; otherwise output is written in blocks and
+// flushed once at the end.
+type recordWriter struct {
+	buf   *bufio.Writer
+	enc   *json.Encoder
+	flush bool
+}
+
+func newRecordWriter(w io.Writer, flush bool) *recordWriter {
+	buf := bufio.NewWriter(w)
+	enc := json.NewEncoder(buf)
+	enc.SetEscapeHTML(false)
+	return &recordWriter{buf: buf, enc: enc, flush: flush}
+}
+
+func (w *recordWriter) writeRecord(record any) error {
+	if err := w.enc.Encode(record); err != nil {
+		return err
+	}
+	if w.flush {
+		return w.buf.Flush()
+	}
+	return nil
+}
+
+func (w *recordWriter) Flush() error {
+	return w.buf.Flush()
+}
+
+// batchRecordStream writes batch results to stdout in input order as soon
+// as every earlier item has finished, instead of holding them all until the
+// batch ends. A nil *batchRecordStream ignores results.
+type batchRecordStream struct {
+	mu      sync.Mutex
+	out     *recordWriter
+	flatten *recordFlattener
+	pending map[int]callBatchItemResult
+	next    int
+	err     error
+}
+
+func newBatchRecordStream(w io.Writer, flush bool, flatten *recordFlattener) *batchRecordStream {
+	return &batchRecordStream{
+		out:     newRecordWriter(w, flush),
+		flatten: flatten,
+		pending: make(map[int]callBatchItemResult),
+	}
+}
+
+func (s *batchRecordStream) add(result callBatchItemResult) {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.pending[result.Index] = result
+	for {
+		next, ok := s.pending[s.next]
+		if !ok {
+			return
+		}
+		delete(s.pending, s.next)
+		s.next++
+		if s.err == nil {
+			var record any
+			if record, s.err = s.flatten.apply(next); s.err == nil {
+				s.err = s.out.writeRecord(record)
+			}
+		}
+	}
+}
+
+// finish flushes buffered records and returns the first write error.
+func (s *batchRecordStream) finish() error {
+	if s == nil {
+		return nil
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := s.out.Flush(); s.err == nil {
+		s.err = err
+	}
+	return s.err
+}
@@ -0,0 +1,124 @@
+package cli
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/alex-mccollum/igw-cli/internal/config"
+)
+
+func TestCallBatchFlushesEachRecordToPipe(t *testing.T) {
+	t.Parallel()
+
+	pr, pw := io.Pipe()
+	firstRead := make(chan struct{})
+	c := &CLI{
+		In: strings.NewReader(
+			`{"id":"a","method":"GET","path":"/data/api/v1/gateway-info"}` + "\n" +
+				`{"id":"b","method":"GET","path":"/data/api/v1/slow"}` + "\n",
+		),
+		Out:    pw,
+		Err:    new(bytes.Buffer),
+		Getenv: func(string) string { return "" },
+		ReadConfig: func() (config.File, error) {
+			return config.File{}, nil
+		},
+		HTTPClient: newMockHTTPClient(func(r *http.Request) (*http.Response, error) {
+			if r.URL.Path == "/data/api/v1/slow" {
+				// Only answer once the consumer has seen the first record.
+				select {
+				case <-firstRead:
+				case <-time.After(5 * time.Second):
+				}
+			}
+			return mockHTTPResponse(http.StatusOK, `{"ok":true}`, nil), nil
+		}),
+	}
+
+	done := make(chan error, 1)
+	go func() {
+		err := c.Execute([]string{
+			"call",
+			"--gateway-url", mockGatewayURL,
+			"--api-key", "secret",
+			"--batch", "-",
+		})
+		_ = pw.Close()
+		done <- err
+	}()
+
+	reader := bufio.NewReader(pr)
+	readRecord := func() callBatchItemResult {
+		t.Helper()
+		line, err := reader.ReadBytes('\n')
+		if err != nil {
+			t.Fatalf("read record: %v", err)
+		}
+		var record callBatchItemResult
+		if err := json.Unmarshal(line, &record); err != nil {
+			t.Fatalf("decode record %q: %v", line, err)
+		}
+		return record
+	}
+
+	first := readRecord()
+	select {
+	case err := <-done:
+		t.Fatalf("batch finished before the first record was read: %v", err)
+	default:
+	}
+	close(firstRead)
+	if first.ID != "a" {
+		t.Fatalf("unexpected first record: %+v", first)
+	}
+	if second := readRecord(); second.ID != "b" {
+		t.Fatalf("unexpected second record: %+v", second)
+	}
+	if err := <-done; err != nil {
+		t.Fatalf("batch failed: %v", err)
+	}
+}
+
+func TestRecordWriterBuffersWithoutFlush(t *testing.T) {
+	t.Parallel()
+
+	var out bytes.Buffer
+	w := newRecordWriter(&out, false)
+	if err := w.writeRecord(map[string]string{"a": "<b>"}); err != nil {
+		t.Fatalf("write record: %v", err)
+	}
+	if out.Len() != 0 {
+		t.Fatalf("expected buffered output, got %q", out.String())
+	}
+	if err := w.Flush(); err != nil {
+		t.Fatalf("flush: %v", err)
+	}
+	if got := out.String(); got != `{"a":"<b>"}`+"\n" {
+		t.Fatalf("unexpected output %q", got)
+	}
+}
+
+func TestBatchRecordStreamKeepsInputOrder(t *testing.T) {
+	t.Parallel()
+
+	var out bytes.Buffer
+	s := newBatchRecordStream(&out, true, nil)
+	s.add(callBatchItemResult{Index: 1, ID: "b"})
+	if out.Len() != 0 {
+		t.Fatalf("expected item 1 to wait for item 0, got %q", out.String())
+	}
+	s.add(callBatchItemResult{Index: 0, ID: "a"})
+	if err := s.finish(); err != nil {
+		t.Fatalf("finish: %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	if len(lines) != 2 || !strings.Contains(lines[0], `"id":"a"`) || !strings.Contains(lines[1], `"id":"b"`) {
+		t.Fatalf("unexpected order: %q", out.String())
+	}
+}
@@ -0,0 +1,81 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/alex-mccollum/igw-cli/internal/igwerr"
+)
+
+const outputFormatKV = "kv"
+
+// parseResponseOutputFormat validates --output for commands that print a
+// response body.
+func parseResponseOutputFormat(format string) (string, error) {
+	format = strings.ToLower(strings.TrimSpace(format))
+	switch format {
+	case "", outputFormatText:
+		return outputFormatText, nil
+	case outputFormatKV, outputFormatTable, outputFormatTSV, outputFormatCSV:
+		return format, nil
+	default:
+		return "", &igwerr.UsageError{Msg: fmt.Sprintf("--output must be one of: %s, %s, %s, %s, %s", outputFormatText, outputFormatKV, outputFormatTable, outputFormatTSV, outputFormatCSV)}
+	}
+}
+
+// writeKVBody prints a single JSON object as field<TAB>value rows sorted by
+// field, with nested objects flattened into dotted keys. Anything else (an
+// array, a scalar, or non-JSON) is written unchanged.
+func writeKVBody(w io.Writer, body []byte) error {
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+	var object map[string]any
+	if err := decoder.Decode(&object); err != nil || object == nil {
+		_, err := w.Write(body)
+		return err
+	}
+
+	rows := make(map[string]string)
+	flattenKV(rows, "", object)
+	fields := make([]string, 0, len(rows))
+	for field := range rows {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+	for _, field := range fields {
+		if _, err := fmt.Fprintf(w, "%s\t%s\n", field, rows[field]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func flattenKV(rows map[string]string, prefix string, object map[string]any) {
+	if len(object) == 0 && prefix != "" {
+		rows[prefix] = "{}"
+		return
+	}
+	for key, value := range object {
+		field := key
+		if prefix != "" {
+			field = prefix + "." + key
+		}
+		if nested, ok := value.(map[string]any); ok {
+			flattenKV(rows, field, nested)
+			continue
+		}
+		rows[field] = formatKVValue(value)
+	}
+}
+
+// formatKVValue prints strings bare and everything else as compact JSON.
+func formatKVValue(value any) string {
+	if s, ok := value.(string); ok {
+		return s
+	}
+	return formatWatchValue(value)
+}
@@ -0,0 +1,80 @@
+package cli
+
+import (
+	"bytes"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestGatewayInfoOutputKVFlattensNestedObject(t *testing.T) {
+	t.Parallel()
+
+	var out bytes.Buffer
+	c := newAdminWrapperTestCLI(newStaticHTTPClient(http.StatusOK, `{"name":"gw","version":{"major":8,"minor":1},"redundancy":{"role":"Master","peer":{}},"modules":["a","b"],"trial":false}`))
+	c.Out = &out
+	err := c.Execute([]string{
+		"gateway", "info",
+		"--gateway-url", mockGatewayURL,
+		"--api-key", "secret",
+		"--output", "kv",
+	})
+	if err != nil {
+		t.Fatalf("gateway info failed: %v", err)
+	}
+
+	want := strings.Join([]string{
+		"modules\t[\"a\",\"b\"]",
+		"name\tgw",
+		"redundancy.peer\t{}",
+		"redundancy.role\tMaster",
+		"trial\tfalse",
+		"version.major\t8",
+		"version.minor\t1",
+	}, "\n") + "\n"
+	if got := out.String(); got != want {
+		t.Fatalf("unexpected kv output:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestCallOutputKVFallsBackForArrays(t *testing.T) {
+	t.Parallel()
+
+	var out bytes.Buffer
+	c := newAdminWrapperTestCLI(newStaticHTTPClient(http.StatusOK, `[{"name":"a"},{"name":"b"}]`))
+	c.Out = &out
+	err := c.Execute([]string{
+		"call",
+		"--gateway-url", mockGatewayURL,
+		"--api-key", "secret",
+		"--path", "/data/api/v1/projects",
+		"--output", "kv",
+	})
+	if err != nil {
+		t.Fatalf("call failed: %v", err)
+	}
+	if got := out.String(); got != `[{"name":"a"},{"name":"b"}]` {
+		t.Fatalf("expected unchanged body, got %q", got)
+	}
+}
+
+func TestCallOutputKVValidation(t *testing.T) {
+	t.Parallel()
+
+	for _, args := range [][]string{
+		{"--output", "yaml"},
+		{"--output", "kv", "--json"},
+		{"--output", "kv", "--stream"},
+	} {
+		var out bytes.Buffer
+		c := newAdminWrapperTestCLI(newStaticHTTPClient(http.StatusOK, `{}`))
+		c.Out = &out
+		err := c.Execute(append([]string{
+			"call",
+			"--gateway-url", mockGatewayURL,
+			"--api-key", "secret",
+			"--path", "/data/api/v1/gateway-info",
+		}, args...))
+		requireUsageExitCode(t, err)
+	}
+}
@@ -0,0 +1,180 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/alex-mccollum/igw-cli/internal/config"
+)
+
+// outputPurityTestGateway serves a gateway that answers every request with
+// the same JSON body and returns the flags that target it.
+func outputPurityTestGateway(t *testing.T) (*http.Client, []string) {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"name":"gw1","version":"8.1.40"}`))
+	}))
+	t.Cleanup(srv.Close)
+	return srv.Client(), []string{"--gateway-url", srv.URL, "--api-key", "secret"}
+}
+
+// stubLocalState keeps c's config and history in memory or a temp dir so
+// config and history commands can run.
+func stubLocalState(t *testing.T, c *CLI) {
+	t.Helper()
+	var cfg config.File
+	historyPath := filepath.Join(t.TempDir(), "history.jsonl")
+	c.ReadConfig = func() (config.File, error) { return cfg, nil }
+	c.WriteConfig = func(next config.File) error { cfg = next; return nil }
+	c.DetectWSLHostIP = func() (string, string, error) {
+		return "172.25.80.1", "ip route default gateway", nil
+	}
+	c.HistoryPath = func() (string, error) { return historyPath, nil }
+}
+
+func requireSingleJSONDocument(t *testing.T, name string, stdout string) {
+	t.Helper()
+	decoder := json.NewDecoder(strings.NewReader(stdout))
+	var doc any
+	if err := decoder.Decode(&doc); err != nil {
+		t.Fatalf("%s: stdout is not JSON: %v (%q)", name, err, stdout)
+	}
+	if _, err := decoder.Token(); err != io.EOF {
+		t.Fatalf("%s: unexpected text after the JSON document: %q", name, stdout)
+	}
+}
+
+func TestStdoutCarriesOnlyPayloadWithJSON(t *testing.T) {
+	t.Parallel()
+
+	var out, errOut bytes.Buffer
+	client, gateway := outputPurityTestGateway(t)
+	c := newAdminWrapperTestCLI(client)
+	c.Out = &out
+	c.Err = &errOut
+	stubLocalState(t, c)
+	dir := t.TempDir()
+	steps := [][]string{
+		{"config", "set", "--auto-gateway", "--json"},
+		{"config", "set", "--gateway-url", gateway[1], "--profile", "dev", "--json"},
+		append([]string{"config", "profile", "add", "dev", "--json"}, gateway...),
+		append([]string{"config", "profile", "add", "stage", "--json"}, gateway...),
+		{"config", "profile", "use", "dev", "--json"},
+		{"config", "profile", "rename", "stage", "prod", "--json"},
+		{"config", "profile", "remove", "prod", "--json"},
+		{"history", "clear", "--json"},
+		append([]string{"call", "--method", "GET", "--path", "/data/api/v1/gateway-info", "--out", filepath.Join(dir, "info.json"), "--json"}, gateway...),
+		append([]string{"tags", "export", "--out", filepath.Join(dir, "tags.json"), "--json"}, gateway...),
+		append([]string{"backup", "export", "--out", filepath.Join(dir, "gateway.gwbk"), "--json"}, gateway...),
+		append([]string{"gateway", "info", "--json"}, gateway...),
+		append([]string{"doctor", "--json"}, gateway...),
+	}
+
+	for _, args := range steps {
+		out.Reset()
+		errOut.Reset()
+		if err := c.Execute(args); err != nil {
+			t.Fatalf("%s: %v (stderr %q)", strings.Join(args, " "), err, errOut.String())
+		}
+		requireSingleJSONDocument(t, strings.Join(args, " "), out.String())
+	}
+}
+
+func TestStdoutCarriesOnlyPayloadWithRaw(t *testing.T) {
+	t.Parallel()
+
+	var out, errOut bytes.Buffer
+	client, gateway := outputPurityTestGateway(t)
+	c := newAdminWrapperTestCLI(client)
+	c.Out = &out
+	c.Err = &errOut
+	stubLocalState(t, c)
+	dir := t.TempDir()
+	steps := [][]string{
+		append([]string{"call", "--method", "GET", "--path", "/data/api/v1/gateway-info", "--out", filepath.Join(dir, "info.json"), "--json", "--select", "response.status", "--raw"}, gateway...),
+		append([]string{"gateway", "info", "--json", "--select", "response.body.name", "--raw"}, gateway...),
+		append([]string{"tags", "export", "--out", filepath.Join(dir, "tags.json"), "--json", "--select", "response.status", "--raw"}, gateway...),
+	}
+	want := []string{"200\n", "gw1\n", "200\n"}
+
+	for i, args := range steps {
+		out.Reset()
+		errOut.Reset()
+		if err := c.Execute(args); err != nil {
+			t.Fatalf("%s: %v (stderr %q)", strings.Join(args, " "), err, errOut.String())
+		}
+		if out.String() != want[i] {
+			t.Fatalf("%s: expected only %q on stdout, got %q", strings.Join(args, " "), want[i], out.String())
+		}
+	}
+}
+
+func TestQuietSuppressesInformationalMessages(t *testing.T) {
+	t.Parallel()
+
+	outPath := filepath.Join(t.TempDir(), "info.json")
+	var out, errOut bytes.Buffer
+	client, gateway := outputPurityTestGateway(t)
+	c := newAdminWrapperTestCLI(client)
+	c.Out = &out
+	c.Err = &errOut
+	stubLocalState(t, c)
+
+	if err := c.Execute(append([]string{"config", "profile", "add", "dev"}, gateway...)); err != nil {
+		t.Fatalf("profile add: %v", err)
+	}
+	if out.Len() != 0 || !strings.Contains(errOut.String(), "saved profile: dev") {
+		t.Fatalf("expected the confirmation on stderr only, got stdout %q stderr %q", out.String(), errOut.String())
+	}
+
+	batchPath := filepath.Join(t.TempDir(), "batch.ndjson")
+	if err := os.WriteFile(batchPath, []byte(`{"method":"GET","path":"/data/api/v1/gateway-info"}`+"\n"), 0o600); err != nil {
+		t.Fatalf("write batch file: %v", err)
+	}
+	batchArgs := append([]string{"call", "--batch", "@" + batchPath, "--batch-out", filepath.Join(t.TempDir(), "results")}, gateway...)
+	errOut.Reset()
+	if err := c.Execute(batchArgs); err != nil {
+		t.Fatalf("batch: %v", err)
+	}
+	if out.Len() != 0 || !strings.Contains(errOut.String(), "wrote batch results: ") {
+		t.Fatalf("expected the written batch files on stderr only, got stdout %q stderr %q", out.String(), errOut.String())
+	}
+
+	errOut.Reset()
+	for _, args := range [][]string{
+		{"--quiet", "config", "profile", "use", "dev"},
+		{"call", "--method", "GET", "--path", "/data/api/v1/gateway-info", "--out", outPath, "--quiet"},
+		append([]string{"--quiet"}, batchArgs...),
+	} {
+		if err := c.Execute(args); err != nil {
+			t.Fatalf("%s: %v", strings.Join(args, " "), err)
+		}
+	}
+	if out.Len() != 0 || errOut.Len() != 0 {
+		t.Fatalf("expected no output with --quiet, got stdout %q stderr %q", out.String(), errOut.String())
+	}
+
+	// --quiet leaves payloads alone, and a later run without it speaks again.
+	if err := c.Execute([]string{"call", "--method", "GET", "--path", "/data/api/v1/gateway-info", "--quiet=true"}); err != nil {
+		t.Fatalf("call: %v", err)
+	}
+	if !strings.Contains(out.String(), `"gw1"`) {
+		t.Fatalf("expected the response body with --quiet, got %q", out.String())
+	}
+	if err := c.Execute([]string{"config", "profile", "use", "dev"}); err != nil {
+		t.Fatalf("profile use: %v", err)
+	}
+	if !strings.Contains(errOut.String(), "active profile: dev") {
+		t.Fatalf("expected the confirmation without --quiet, got %q", errOut.String())
+	}
+
+	requireUsageExitCode(t, c.Execute([]string{"config", "show", "--quiet=maybe"}))
+}
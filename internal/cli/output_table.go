@@ -0,0 +1,208 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"text/tabwriter"
+)
+
+const (
+	outputFormatTable = "table"
+	outputFormatTSV   = "tsv"
+	outputFormatCSV   = "csv"
+)
+
+// recordOutputOptions holds --output and --columns for list wrappers,
+// which forward them to call.
+type recordOutputOptions struct {
+	format  string
+	columns string
+}
+
+func bindRecordOutputFlags(fs *flag.FlagSet, opts *recordOutputOptions) {
+	fs.StringVar(&opts.format, "output", "", "Response output format: text|table|tsv|csv")
+	fs.StringVar(&opts.columns, "columns", "", "Comma-separated columns for --output table|tsv|csv, as dotted paths (default: every key)")
+}
+
+func (o recordOutputOptions) args() []string {
+	var args []string
+	if format := strings.TrimSpace(o.format); format != "" {
+		args = append(args, "--output", format)
+	}
+	if columns := strings.TrimSpace(o.columns); columns != "" {
+		args = append(args, "--columns", columns)
+	}
+	return args
+}
+
+// defaultTable renders a table of columns when neither --json nor
+// --output was given.
+func (o recordOutputOptions) defaultTable(common wrapperCommon, columns string) recordOutputOptions {
+	if common.jsonOutput || strings.TrimSpace(o.format) != "" {
+		return o
+	}
+	o.format = outputFormatTable
+	if strings.TrimSpace(o.columns) == "" {
+		o.columns = columns
+	}
+	return o
+}
+
+func isRecordOutputFormat(format string) bool {
+	switch format {
+	case outputFormatTable, outputFormatTSV, outputFormatCSV:
+		return true
+	default:
+		return false
+	}
+}
+
+// parseColumns splits --columns into trimmed, non-empty names.
+func parseColumns(value string) []string {
+	var columns []string
+	for _, column := range strings.Split(value, ",") {
+		if column = strings.TrimSpace(column); column != "" {
+			columns = append(columns, column)
+		}
+	}
+	return columns
+}
+
+// writeRecordTable renders a JSON array of objects (or an object whose only
+// array field holds them) as one row per object. Nested objects are
+// flattened into dotted columns; missing keys and nulls print empty. With no
+// columns, the sorted union of all keys is used. Any other body is written
+// unchanged with a warning on warn.
+func writeRecordTable(w io.Writer, warn io.Writer, format string, columns []string, body []byte) error {
+	rows, ok := recordRows(body)
+	if !ok {
+		fmt.Fprintf(warn, "warning: --output %s needs a JSON array of objects; printing the response unchanged\n", format)
+		_, err := w.Write(body)
+		return err
+	}
+
+	if len(columns) == 0 {
+		seen := map[string]bool{}
+		for _, row := range rows {
+			for column := range row {
+				if !seen[column] {
+					seen[column] = true
+					columns = append(columns, column)
+				}
+			}
+		}
+		sort.Strings(columns)
+	}
+
+	records := make([][]string, 0, len(rows)+1)
+	records = append(records, columns)
+	for _, row := range rows {
+		record := make([]string, len(columns))
+		for i, column := range columns {
+			record[i] = row[column]
+		}
+		records = append(records, record)
+	}
+
+	switch format {
+	case outputFormatCSV:
+		writer := csv.NewWriter(w)
+		if err := writer.WriteAll(records); err != nil {
+			return err
+		}
+		return nil
+	case outputFormatTSV:
+		for _, record := range records {
+			for i, value := range record {
+				record[i] = tsvEscaper.Replace(value)
+			}
+			if _, err := fmt.Fprintln(w, strings.Join(record, "\t")); err != nil {
+				return err
+			}
+		}
+		return nil
+	default:
+		tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+		for i, record := range records {
+			if i == 0 {
+				record = upperColumns(record)
+			}
+			for j, value := range record {
+				record[j] = tsvEscaper.Replace(value)
+			}
+			if _, err := fmt.Fprintln(tw, strings.Join(record, "\t")); err != nil {
+				return err
+			}
+		}
+		return tw.Flush()
+	}
+}
+
+var tsvEscaper = strings.NewReplacer("\t", `\t`, "\n", `\n`, "\r", `\r`)
+
+func upperColumns(columns []string) []string {
+	out := make([]string, len(columns))
+	for i, column := range columns {
+		out[i] = strings.ToUpper(column)
+	}
+	return out
+}
+
+// recordRows decodes body into flattened rows. ok is false unless every
+// item is a JSON object.
+func recordRows(body []byte) ([]map[string]string, bool) {
+	items, _, _, err := pageItems(body)
+	if err != nil {
+		return nil, false
+	}
+	rows := make([]map[string]string, 0, len(items))
+	for _, item := range items {
+		object, isObject := item.(map[string]any)
+		if !isObject {
+			return nil, false
+		}
+		row := make(map[string]string)
+		flattenRecordRow(row, "", object)
+		rows = append(rows, row)
+	}
+	return rows, true
+}
+
+// flattenRecordRow is flattenKV with nulls printed empty.
+func flattenRecordRow(row map[string]string, prefix string, object map[string]any) {
+	for key, value := range object {
+		column := key
+		if prefix != "" {
+			column = prefix + "." + key
+		}
+		switch v := value.(type) {
+		case map[string]any:
+			if len(v) > 0 {
+				flattenRecordRow(row, column, v)
+				continue
+			}
+			row[column] = "{}"
+		case nil:
+			row[column] = ""
+		default:
+			row[column] = formatKVValue(v)
+		}
+	}
+}
+
+// marshalRecords encodes a Go slice for writeRecordTable.
+func marshalRecords(records any) ([]byte, error) {
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(records); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,137 @@
+package cli
+
+import (
+	"bytes"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestWriteRecordTableFormats(t *testing.T) {
+	t.Parallel()
+
+	body := []byte(`{"items":[{"name":"alpha","state":{"running":true},"note":"a,b"},{"name":"beta","note":null,"extra":1}]}`)
+
+	cases := map[string]string{
+		outputFormatTable: "NAME   STATE.RUNNING  NOTE\nalpha  true           a,b\nbeta                  \n",
+		outputFormatTSV:   "name\tstate.running\tnote\nalpha\ttrue\ta,b\nbeta\t\t\n",
+		outputFormatCSV:   "name,state.running,note\nalpha,true,\"a,b\"\nbeta,,\n",
+	}
+	for format, want := range cases {
+		var out bytes.Buffer
+		var warn bytes.Buffer
+		if err := writeRecordTable(&out, &warn, format, []string{"name", "state.running", "note"}, body); err != nil {
+			t.Fatalf("%s: %v", format, err)
+		}
+		if out.String() != want {
+			t.Fatalf("%s: got %q, want %q", format, out.String(), want)
+		}
+		if warn.Len() != 0 {
+			t.Fatalf("%s: unexpected warning %q", format, warn.String())
+		}
+	}
+}
+
+func TestWriteRecordTableAutoColumns(t *testing.T) {
+	t.Parallel()
+
+	var out bytes.Buffer
+	body := []byte(`[{"b":"x\ty","a":{"c":2}},{"d":[1,2]}]`)
+	if err := writeRecordTable(&out, new(bytes.Buffer), outputFormatTSV, nil, body); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	want := "a.c\tb\td\n2\tx\\ty\t\n\t\t[1,2]\n"
+	if out.String() != want {
+		t.Fatalf("got %q, want %q", out.String(), want)
+	}
+}
+
+func TestWriteRecordTableFallsBackForNonArrays(t *testing.T) {
+	t.Parallel()
+
+	for _, body := range []string{`{"name":"gw"}`, `[1,2]`, `plain text`} {
+		var out bytes.Buffer
+		var warn bytes.Buffer
+		if err := writeRecordTable(&out, &warn, outputFormatTable, nil, []byte(body)); err != nil {
+			t.Fatalf("write: %v", err)
+		}
+		if out.String() != body {
+			t.Fatalf("expected raw body %q, got %q", body, out.String())
+		}
+		if !strings.Contains(warn.String(), "--output table needs a JSON array of objects") {
+			t.Fatalf("expected warning for %q, got %q", body, warn.String())
+		}
+	}
+}
+
+func TestCallOutputTableAndLogsListCSV(t *testing.T) {
+	t.Parallel()
+
+	body := `[{"name":"gateway.log","size":10},{"name":"wrapper.log","size":20}]`
+
+	var out bytes.Buffer
+	c := newAdminWrapperTestCLI(newStaticHTTPClient(http.StatusOK, body))
+	c.Out = &out
+	if err := c.Execute([]string{
+		"call",
+		"--gateway-url", mockGatewayURL,
+		"--api-key", "secret",
+		"--path", "/data/api/v1/logs",
+		"--output", "table",
+	}); err != nil {
+		t.Fatalf("call failed: %v", err)
+	}
+	if out.String() != "NAME         SIZE\ngateway.log  10\nwrapper.log  20\n" {
+		t.Fatalf("unexpected table %q", out.String())
+	}
+
+	out.Reset()
+	c = newAdminWrapperTestCLI(newStaticHTTPClient(http.StatusOK, body))
+	c.Out = &out
+	if err := c.Execute([]string{
+		"logs", "list",
+		"--gateway-url", mockGatewayURL,
+		"--api-key", "secret",
+		"--output", "csv",
+		"--columns", "size,name",
+	}); err != nil {
+		t.Fatalf("logs list failed: %v", err)
+	}
+	if out.String() != "size,name\n10,gateway.log\n20,wrapper.log\n" {
+		t.Fatalf("unexpected csv %q", out.String())
+	}
+
+	c = newAdminWrapperTestCLI(newStaticHTTPClient(http.StatusOK, body))
+	requireUsageExitCode(t, c.Execute([]string{
+		"call",
+		"--gateway-url", mockGatewayURL,
+		"--api-key", "secret",
+		"--path", "/data/api/v1/logs",
+		"--columns", "name",
+	}))
+	requireUsageExitCode(t, c.Execute([]string{
+		"call",
+		"--gateway-url", mockGatewayURL,
+		"--api-key", "secret",
+		"--path", "/data/api/v1/logs",
+		"--output", "csv",
+		"--json",
+	}))
+}
+
+func TestAPIListOutputTSV(t *testing.T) {
+	t.Parallel()
+
+	specPath := writeAPISpec(t, apiSpecFixture)
+	var out bytes.Buffer
+	c := &CLI{Out: &out, Err: new(bytes.Buffer)}
+	if err := c.Execute([]string{"api", "list", "--spec-file", specPath, "--output", "tsv", "--columns", "operationId,tags"}); err != nil {
+		t.Fatalf("api list failed: %v", err)
+	}
+	got := out.String()
+	if !strings.HasPrefix(got, "operationId\ttags\n") || !strings.Contains(got, "scanProjects\t[\"gateway\",\"scan\"]\n") {
+		t.Fatalf("unexpected tsv %q", got)
+	}
+
+	requireUsageExitCode(t, c.Execute([]string{"api", "list", "--spec-file", specPath, "--columns", "path"}))
+}
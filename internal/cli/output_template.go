@@ -0,0 +1,166 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/template"
+
+	"github.com/alex-mccollum/igw-cli/internal/igwerr"
+)
+
+const (
+	outputFormatText     = "text"
+	outputFormatMarkdown = "markdown"
+)
+
+// outputTemplateFuncs are the helpers available to --output-template.
+// Values come from the command's JSON payload, so field names match --json.
+func outputTemplateFuncs() template.FuncMap {
+	return template.FuncMap{
+		"table":    templateTable,
+		"code":     templateCode,
+		"join":     templateJoin,
+		"jsonpath": templateJSONPath,
+	}
+}
+
+// resolveOutputTemplate picks the template for --output/--output-template.
+// An empty result means the command's normal text output.
+func resolveOutputTemplate(format string, tmpl string, jsonOutput bool, markdownPreset string) (string, error) {
+	format = strings.ToLower(strings.TrimSpace(format))
+	tmpl = strings.TrimSpace(tmpl)
+	if format != "" && format != outputFormatText && format != outputFormatMarkdown {
+		return "", &igwerr.UsageError{Msg: fmt.Sprintf("--output must be one of: %s, %s", outputFormatText, outputFormatMarkdown)}
+	}
+	if format == outputFormatMarkdown && tmpl != "" {
+		return "", &igwerr.UsageError{Msg: "use either --output markdown or --output-template, not both"}
+	}
+	if jsonOutput && (format == outputFormatMarkdown || tmpl != "") {
+		return "", &igwerr.UsageError{Msg: "--output markdown and --output-template are not supported with --json"}
+	}
+	if format == outputFormatMarkdown {
+		return markdownPreset, nil
+	}
+	if strings.HasPrefix(tmpl, "@") {
+		b, err := os.ReadFile(strings.TrimPrefix(tmpl, "@"))
+		if err != nil {
+			return "", &igwerr.UsageError{Msg: fmt.Sprintf("read --output-template: %v", err)}
+		}
+		return string(b), nil
+	}
+	return tmpl, nil
+}
+
+// renderOutputTemplate executes tmpl against the JSON form of payload.
+func renderOutputTemplate(w io.Writer, tmpl string, payload any) error {
+	parsed, err := template.New("output").Funcs(outputTemplateFuncs()).Parse(tmpl)
+	if err != nil {
+		return &igwerr.UsageError{Msg: fmt.Sprintf("parse --output-template: %v", err)}
+	}
+	// Round-trip through JSON so nested structs become maps and slices
+	// keyed by their JSON names.
+	encoded, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("encode payload: %w", err)
+	}
+	var data any
+	if err := json.Unmarshal(encoded, &data); err != nil {
+		return fmt.Errorf("decode payload: %w", err)
+	}
+	var out strings.Builder
+	if err := parsed.Execute(&out, data); err != nil {
+		return &igwerr.UsageError{Msg: fmt.Sprintf("render --output-template: %v", err)}
+	}
+	text := out.String()
+	if text != "" && !strings.HasSuffix(text, "\n") {
+		text += "\n"
+	}
+	_, err = io.WriteString(w, text)
+	return err
+}
+
+// templateTable renders items as a GitHub-flavored Markdown table with one
+// column per field path and one row per item. Missing fields render empty.
+func templateTable(items any, fields ...string) (string, error) {
+	if len(fields) == 0 {
+		return "", fmt.Errorf("table needs at least one field")
+	}
+	rows, ok := items.([]any)
+	if !ok && items != nil {
+		return "", fmt.Errorf("table expects an array, got %T", items)
+	}
+
+	var b strings.Builder
+	b.WriteString("| " + strings.Join(escapeMarkdownCells(fields), " | ") + " |\n")
+	b.WriteString("|" + strings.Repeat(" --- |", len(fields)) + "\n")
+	for _, row := range rows {
+		cells := make([]string, len(fields))
+		for i, field := range fields {
+			if value, err := extractJSONPathValueFromRoot(row, field); err == nil {
+				cells[i] = templateString(value)
+			}
+		}
+		b.WriteString("| " + strings.Join(escapeMarkdownCells(cells), " | ") + " |\n")
+	}
+	return b.String(), nil
+}
+
+// templateCode wraps a value in an inline code span, widening the fence
+// when the value itself contains backticks.
+func templateCode(value any) string {
+	text := templateString(value)
+	fence := "`"
+	for strings.Contains(text, fence) {
+		fence += "`"
+	}
+	if strings.HasPrefix(text, "`") || strings.HasSuffix(text, "`") {
+		return fence + " " + text + " " + fence
+	}
+	return fence + text + fence
+}
+
+func templateJoin(sep string, items any) string {
+	list, ok := items.([]any)
+	if !ok {
+		return templateString(items)
+	}
+	parts := make([]string, len(list))
+	for i, item := range list {
+		parts[i] = templateString(item)
+	}
+	return strings.Join(parts, sep)
+}
+
+// templateJSONPath reads a dot path (same syntax as --select); missing
+// paths yield nil so templates can test for them.
+func templateJSONPath(path string, value any) any {
+	result, err := extractJSONPathValueFromRoot(value, path)
+	if err != nil {
+		return nil
+	}
+	return result
+}
+
+func templateString(value any) string {
+	if value == nil {
+		return ""
+	}
+	text, err := formatJSONValueForRawOutput(value)
+	if err != nil {
+		return fmt.Sprint(value)
+	}
+	return text
+}
+
+func escapeMarkdownCells(cells []string) []string {
+	out := make([]string, len(cells))
+	for i, cell := range cells {
+		cell = strings.ReplaceAll(cell, "|", `\|`)
+		cell = strings.ReplaceAll(cell, "\r\n", " ")
+		out[i] = strings.ReplaceAll(cell, "\n", " ")
+	}
+	return out
+}
@@ -0,0 +1,110 @@
+package cli
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestAPIListOutputMarkdown(t *testing.T) {
+	t.Parallel()
+
+	specPath := writeAPISpec(t, apiSpecFixture)
+	var out bytes.Buffer
+	c := &CLI{Out: &out, Err: new(bytes.Buffer)}
+
+	if err := c.Execute([]string{"api", "list", "--spec-file", specPath, "--output", "markdown"}); err != nil {
+		t.Fatalf("api list --output markdown failed: %v", err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	if len(lines) != 4 {
+		t.Fatalf("expected header, separator, and 2 rows, got %q", out.String())
+	}
+	if lines[0] != "| method | path | operationId | summary |" {
+		t.Fatalf("unexpected header %q", lines[0])
+	}
+	if lines[1] != "| --- | --- | --- | --- |" {
+		t.Fatalf("unexpected separator %q", lines[1])
+	}
+	if !strings.Contains(out.String(), "| GET | /data/api/v1/gateway-info | gatewayInfo | Gateway info |") {
+		t.Fatalf("missing gateway info row: %q", out.String())
+	}
+}
+
+func TestDoctorOutputMarkdown(t *testing.T) {
+	t.Parallel()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"name":"gateway"}`))
+	}))
+	defer srv.Close()
+
+	var out bytes.Buffer
+	c := newDoctorTestCLI(srv.Client(), &out)
+	if err := c.Execute([]string{
+		"doctor",
+		"--gateway-url", srv.URL,
+		"--api-key", "secret",
+		"--timeout", "1s",
+		"--output", "markdown",
+	}); err != nil {
+		t.Fatalf("doctor --output markdown failed: %v", err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	if len(lines) < 3 || lines[1] != "| --- | --- | --- | --- |" {
+		t.Fatalf("expected a Markdown table, got %q", out.String())
+	}
+	rows := 0
+	for _, line := range lines[2:] {
+		if strings.HasPrefix(line, "| ") {
+			rows++
+		}
+	}
+	// gateway_url, tcp_connect, gateway_info, scan_projects, token_permissions,
+	// and the two default probes
+	if rows != 7 {
+		t.Fatalf("expected one row per check, got %d: %q", rows, out.String())
+	}
+	if !strings.Contains(out.String(), "| gateway_info | true | status 200 |") {
+		t.Fatalf("missing gateway_info row: %q", out.String())
+	}
+}
+
+func TestOutputTemplateFuncs(t *testing.T) {
+	t.Parallel()
+
+	payload := map[string]any{
+		"name":  "a|b",
+		"tags":  []any{"x", "y"},
+		"items": []any{map[string]any{"id": "1", "meta": map[string]any{"v": "line\nbreak"}}},
+	}
+
+	var out bytes.Buffer
+	tmpl := `{{code .name}} {{join ", " .tags}} {{jsonpath "items.0.id" .}}
+{{table .items "id" "meta.v" "missing"}}`
+	if err := renderOutputTemplate(&out, tmpl, payload); err != nil {
+		t.Fatalf("render failed: %v", err)
+	}
+	want := "`a|b` x, y 1\n| id | meta.v | missing |\n| --- | --- | --- |\n| 1 | line break |  |\n"
+	if out.String() != want {
+		t.Fatalf("unexpected output:\n%q\nwant\n%q", out.String(), want)
+	}
+
+	if got := templateCode("has `tick`"); got != "`` has `tick` ``" {
+		t.Fatalf("unexpected code span %q", got)
+	}
+}
+
+func TestOutputTemplateRejectsJSON(t *testing.T) {
+	t.Parallel()
+
+	specPath := writeAPISpec(t, apiSpecFixture)
+	c := &CLI{Out: new(bytes.Buffer), Err: new(bytes.Buffer)}
+	requireUsageExitCode(t, c.Execute([]string{"api", "list", "--spec-file", specPath, "--json", "--output", "markdown"}))
+	requireUsageExitCode(t, c.Execute([]string{"api", "list", "--spec-file", specPath, "--output", "html"}))
+	requireUsageExitCode(t, c.Execute([]string{"api", "list", "--spec-file", specPath, "--output-template", "{{"}))
+}
@@ -0,0 +1,119 @@
+package cli
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+
+	"github.com/alex-mccollum/igw-cli/internal/igwerr"
+)
+
+const (
+	defaultPager         = "less -FRX"
+	defaultTerminalLines = 24
+)
+
+// pagerOptions selects how long text output is paged: --pager always pipes
+// it through $PAGER, --no-pager never does, and otherwise output is paged
+// only when stdout is a terminal and it would not fit on one screen.
+type pagerOptions struct {
+	force   bool
+	disable bool
+}
+
+func bindPagerFlags(fs *flag.FlagSet, opts *pagerOptions) {
+	fs.BoolVar(&opts.force, "pager", false, "Page text output through $PAGER (default less -FRX)")
+	fs.BoolVar(&opts.disable, "no-pager", false, "Never page text output")
+}
+
+func (o pagerOptions) validate() error {
+	if o.force && o.disable {
+		return &igwerr.UsageError{Msg: "use only one of --pager or --no-pager"}
+	}
+	return nil
+}
+
+// pagedOutput buffers text output and, on flush, either writes it to the
+// real stdout or feeds it to the pager's stdin.
+type pagedOutput struct {
+	bytes.Buffer
+	cli  *CLI
+	opts pagerOptions
+}
+
+func (c *CLI) pagedOutput(opts pagerOptions) *pagedOutput {
+	return &pagedOutput{cli: c, opts: opts}
+}
+
+func (p *pagedOutput) flush() error {
+	if !p.shouldPage() {
+		_, err := p.cli.Out.Write(p.Bytes())
+		return err
+	}
+
+	argv := strings.Fields(p.pagerCommand())
+	if len(argv) == 0 {
+		_, err := p.cli.Out.Write(p.Bytes())
+		return err
+	}
+	cmd := exec.Command(argv[0], argv[1:]...) //nolint:gosec // user-selected $PAGER
+	cmd.Stdin = bytes.NewReader(p.Bytes())
+	cmd.Stdout = p.cli.Out
+	cmd.Stderr = p.cli.Err
+	if err := cmd.Run(); err != nil {
+		return igwerr.NewTransportError(fmt.Errorf("run pager %q: %w", argv[0], err))
+	}
+	return nil
+}
+
+func (p *pagedOutput) shouldPage() bool {
+	if p.opts.disable {
+		return false
+	}
+	if p.opts.force {
+		return true
+	}
+	if !isTerminal(p.cli.Out) {
+		return false
+	}
+	return bytes.Count(p.Bytes(), []byte("\n")) > p.terminalLines()
+}
+
+func (p *pagedOutput) pagerCommand() string {
+	if pager := strings.TrimSpace(p.getenv("PAGER")); pager != "" {
+		return pager
+	}
+	return defaultPager
+}
+
+// terminalLines reads $LINES, which shells export for the current terminal.
+func (p *pagedOutput) terminalLines() int {
+	if lines, err := strconv.Atoi(strings.TrimSpace(p.getenv("LINES"))); err == nil && lines > 0 {
+		return lines
+	}
+	return defaultTerminalLines
+}
+
+func (p *pagedOutput) getenv(key string) string {
+	if p.cli.Getenv == nil {
+		return ""
+	}
+	return p.cli.Getenv(key)
+}
+
+func isTerminal(w io.Writer) bool {
+	file, ok := w.(*os.File)
+	if !ok {
+		return false
+	}
+	info, err := file.Stat()
+	if err != nil {
+		return false
+	}
+	return info.Mode()&os.ModeCharDevice != 0
+}
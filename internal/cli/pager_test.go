@@ -0,0 +1,77 @@
+package cli
+
+import (
+	"bytes"
+	"os/exec"
+	"strings"
+	"testing"
+)
+
+func pagerTestGetenv(key string) string {
+	if key == "PAGER" {
+		return "sed s/^/paged:/"
+	}
+	return ""
+}
+
+func TestAPIListPagerSkippedWhenNotTerminal(t *testing.T) {
+	t.Parallel()
+
+	specPath := writeCallOpSpec(t, callOpSpecFixture)
+	for _, args := range [][]string{nil, {"--no-pager"}, {"--json"}} {
+		var out bytes.Buffer
+		c := newAdminWrapperTestCLI(nil)
+		c.Out = &out
+		c.Getenv = pagerTestGetenv
+		if err := c.Execute(append([]string{"api", "list", "--spec-file", specPath}, args...)); err != nil {
+			t.Fatalf("api list %v failed: %v", args, err)
+		}
+		if strings.Contains(out.String(), "paged:") {
+			t.Fatalf("api list %v ran the pager:\n%s", args, out.String())
+		}
+		if !strings.Contains(out.String(), "gatewayInfo") {
+			t.Fatalf("api list %v missing output:\n%s", args, out.String())
+		}
+	}
+}
+
+func TestAPIListPagerForcedFeedsPagerStdin(t *testing.T) {
+	t.Parallel()
+
+	if _, err := exec.LookPath("sed"); err != nil {
+		t.Skip("sed not available for the fake pager")
+	}
+
+	specPath := writeCallOpSpec(t, callOpSpecFixture)
+	var out bytes.Buffer
+	c := newAdminWrapperTestCLI(nil)
+	c.Out = &out
+	c.Getenv = pagerTestGetenv
+	if err := c.Execute([]string{"api", "list", "--spec-file", specPath, "--pager"}); err != nil {
+		t.Fatalf("api list --pager failed: %v", err)
+	}
+
+	lines := strings.Split(strings.TrimSuffix(out.String(), "\n"), "\n")
+	if len(lines) < 2 {
+		t.Fatalf("expected paged table, got %q", out.String())
+	}
+	for _, line := range lines {
+		if !strings.HasPrefix(line, "paged:") {
+			t.Fatalf("line %q did not pass through the pager", line)
+		}
+	}
+	if !strings.Contains(out.String(), "scanProjects") {
+		t.Fatalf("pager output missing operations:\n%s", out.String())
+	}
+}
+
+func TestAPIListPagerFlagsConflict(t *testing.T) {
+	t.Parallel()
+
+	specPath := writeCallOpSpec(t, callOpSpecFixture)
+	var out bytes.Buffer
+	c := newAdminWrapperTestCLI(nil)
+	c.Out = &out
+	c.Getenv = pagerTestGetenv
+	requireUsageExitCode(t, c.Execute([]string{"api", "list", "--spec-file", specPath, "--pager", "--no-pager"}))
+}
@@ -144,3 +144,276 @@ func TestConfigProfileAddAndUseJSONOutput(t *testing.T) {
 		t.Fatalf("unexpected use payload: %s", out.String())
 	}
 }
+
+func TestConfigProfileNamesPrintsSortedNames(t *testing.T) {
+	t.Parallel()
+
+	var out bytes.Buffer
+	c := &CLI{
+		In:     strings.NewReader(""),
+		Out:    &out,
+		Err:    new(bytes.Buffer),
+		Getenv: func(string) string { return "" },
+		ReadConfig: func() (config.File, error) {
+			return config.File{
+				ActiveProfile: "stage",
+				Profiles: map[string]config.Profile{
+					"stage": {GatewayURL: "http://10.0.1.5:8088/with space", Token: "stage-token"},
+					"dev":   {GatewayURL: "http://127.0.0.1:8088", Token: "dev-token"},
+					"prod":  {GatewayURL: "https://gw.example.com"},
+				},
+			}, nil
+		},
+	}
+
+	if err := c.Execute([]string{"config", "profile", "names"}); err != nil {
+		t.Fatalf("profile names failed: %v", err)
+	}
+	if out.String() != "dev\nprod\nstage\n" {
+		t.Fatalf("unexpected profile names output %q", out.String())
+	}
+}
+
+func TestConfigProfileTokenRefInheritsAndRejectsCycles(t *testing.T) {
+	t.Parallel()
+
+	cfg := config.File{Token: "shared-token-123"}
+	var out bytes.Buffer
+
+	c := &CLI{
+		In:     strings.NewReader(""),
+		Out:    &out,
+		Err:    new(bytes.Buffer),
+		Getenv: func(string) string { return "" },
+		ReadConfig: func() (config.File, error) {
+			return cfg, nil
+		},
+		WriteConfig: func(next config.File) error {
+			cfg = next
+			return nil
+		},
+	}
+
+	if err := c.Execute([]string{"config", "profile", "add", "east", "--gateway-url", "http://east:8088", "--token-ref", "@default"}); err != nil {
+		t.Fatalf("profile add east failed: %v", err)
+	}
+	if err := c.Execute([]string{"config", "profile", "add", "west", "--gateway-url", "http://west:8088", "--token-ref", "east"}); err != nil {
+		t.Fatalf("profile add west failed: %v", err)
+	}
+	if cfg.Profiles["west"].Token != "" || cfg.Profiles["west"].TokenRef != "east" {
+		t.Fatalf("unexpected west profile %+v", cfg.Profiles["west"])
+	}
+
+	out.Reset()
+	if err := c.Execute([]string{"config", "show", "--json"}); err != nil {
+		t.Fatalf("config show failed: %v", err)
+	}
+	var payload struct {
+		Profiles map[string]struct {
+			TokenMasked        string `json:"tokenMasked"`
+			TokenRef           string `json:"tokenRef"`
+			TokenInheritedFrom string `json:"tokenInheritedFrom"`
+		} `json:"profiles"`
+	}
+	if err := json.Unmarshal(out.Bytes(), &payload); err != nil {
+		t.Fatalf("decode config show: %v", err)
+	}
+	west := payload.Profiles["west"]
+	if west.TokenRef != "east" || west.TokenInheritedFrom != config.TokenRefDefault || west.TokenMasked != config.MaskToken("shared-token-123") {
+		t.Fatalf("unexpected west view %+v", west)
+	}
+
+	err := c.Execute([]string{"config", "profile", "add", "east", "--token-ref", "west"})
+	requireUsageExitCode(t, err)
+	if !strings.Contains(err.Error(), "tokenRef cycle") {
+		t.Fatalf("unexpected cycle error %v", err)
+	}
+}
+
+func TestConfigProfileRemoveRequiresForceForActiveProfile(t *testing.T) {
+	t.Parallel()
+
+	cfg := config.File{
+		ActiveProfile: "dev",
+		Profiles: map[string]config.Profile{
+			"dev":  {GatewayURL: "http://127.0.0.1:8088", Token: "dev-token"},
+			"prod": {GatewayURL: "https://prod:8043", Token: "prod-token"},
+		},
+	}
+	var out, errOut bytes.Buffer
+	c := &CLI{
+		In:     strings.NewReader(""),
+		Out:    &out,
+		Err:    &errOut,
+		Getenv: func(string) string { return "" },
+		ReadConfig: func() (config.File, error) {
+			return cfg, nil
+		},
+		WriteConfig: func(next config.File) error {
+			cfg = next
+			return nil
+		},
+	}
+
+	requireUsageExitCode(t, c.Execute([]string{"config", "profile", "remove", "dev"}))
+	requireUsageExitCode(t, c.Execute([]string{"config", "profile", "remove", "missing"}))
+
+	if err := c.Execute([]string{"config", "profile", "remove", "prod"}); err != nil {
+		t.Fatalf("remove prod failed: %v", err)
+	}
+	if _, ok := cfg.Profiles["prod"]; ok {
+		t.Fatalf("expected prod removed, got %+v", cfg.Profiles)
+	}
+	if out.Len() != 0 || !strings.HasSuffix(errOut.String(), "removed profile: prod\n") {
+		t.Fatalf("expected the confirmation on stderr only, got stdout %q stderr %q", out.String(), errOut.String())
+	}
+
+	out.Reset()
+	if err := c.Execute([]string{"config", "profile", "remove", "dev", "--force", "--json"}); err != nil {
+		t.Fatalf("forced remove failed: %v", err)
+	}
+	if cfg.ActiveProfile != "" || len(cfg.Profiles) != 0 {
+		t.Fatalf("expected empty config, got %+v", cfg)
+	}
+	var payload struct {
+		OK            bool              `json:"ok"`
+		Removed       string            `json:"removed"`
+		ActiveProfile string            `json:"activeProfile"`
+		Count         int               `json:"count"`
+		Profiles      []json.RawMessage `json:"profiles"`
+	}
+	if err := json.Unmarshal(out.Bytes(), &payload); err != nil {
+		t.Fatalf("decode json: %v (%q)", err, out.String())
+	}
+	if !payload.OK || payload.Removed != "dev" || payload.ActiveProfile != "" || payload.Count != 0 || payload.Profiles == nil {
+		t.Fatalf("unexpected payload %+v", payload)
+	}
+}
+
+func TestConfigProfileRenameMovesActiveAndTokenRefs(t *testing.T) {
+	t.Parallel()
+
+	cfg := config.File{
+		ActiveProfile: "dev",
+		Profiles: map[string]config.Profile{
+			"dev":     {GatewayURL: "http://127.0.0.1:8088", Token: "dev-token"},
+			"dev-alt": {GatewayURL: "http://127.0.0.1:9088", TokenRef: "dev"},
+			"stage":   {GatewayURL: "https://stage:8043", Token: "stage-token"},
+		},
+	}
+	var out, errOut bytes.Buffer
+	c := &CLI{
+		In:     strings.NewReader(""),
+		Out:    &out,
+		Err:    &errOut,
+		Getenv: func(string) string { return "" },
+		ReadConfig: func() (config.File, error) {
+			return cfg, nil
+		},
+		WriteConfig: func(next config.File) error {
+			cfg = next
+			return nil
+		},
+	}
+
+	requireUsageExitCode(t, c.Execute([]string{"config", "profile", "rename", "dev", "stage"}))
+	requireUsageExitCode(t, c.Execute([]string{"config", "profile", "rename", "dev"}))
+
+	if err := c.Execute([]string{"config", "profile", "rename", "dev", "local"}); err != nil {
+		t.Fatalf("rename failed: %v", err)
+	}
+	if _, ok := cfg.Profiles["dev"]; ok {
+		t.Fatalf("expected dev renamed, got %+v", cfg.Profiles)
+	}
+	if cfg.Profiles["local"].Token != "dev-token" || cfg.ActiveProfile != "local" {
+		t.Fatalf("unexpected config after rename: %+v", cfg)
+	}
+	if cfg.Profiles["dev-alt"].TokenRef != "local" {
+		t.Fatalf("expected tokenRef rewritten, got %+v", cfg.Profiles["dev-alt"])
+	}
+	if out.Len() != 0 || !strings.HasSuffix(errOut.String(), "renamed profile: dev -> local\n") {
+		t.Fatalf("expected the confirmation on stderr only, got stdout %q stderr %q", out.String(), errOut.String())
+	}
+
+	out.Reset()
+	if err := c.Execute([]string{"config", "profile", "rename", "local", "stage", "--overwrite", "--json"}); err != nil {
+		t.Fatalf("overwrite rename failed: %v", err)
+	}
+	if cfg.Profiles["stage"].Token != "dev-token" || len(cfg.Profiles) != 2 {
+		t.Fatalf("expected stage replaced, got %+v", cfg.Profiles)
+	}
+	var payload struct {
+		Renamed struct {
+			From string `json:"from"`
+			To   string `json:"to"`
+		} `json:"renamed"`
+		Replaced      bool   `json:"replaced"`
+		ActiveProfile string `json:"activeProfile"`
+		Count         int    `json:"count"`
+	}
+	if err := json.Unmarshal(out.Bytes(), &payload); err != nil {
+		t.Fatalf("decode json: %v (%q)", err, out.String())
+	}
+	if payload.Renamed.From != "local" || payload.Renamed.To != "stage" || !payload.Replaced || payload.ActiveProfile != "stage" || payload.Count != 2 {
+		t.Fatalf("unexpected payload %+v", payload)
+	}
+}
+
+func TestConfigSetAPIKeyEnvAndShowSource(t *testing.T) {
+	t.Parallel()
+
+	cfg := config.File{
+		Profiles: map[string]config.Profile{
+			"dev": {GatewayURL: "http://127.0.0.1:8088", Token: "dev-token"},
+		},
+	}
+	var out bytes.Buffer
+	c := &CLI{
+		In:     strings.NewReader(""),
+		Out:    &out,
+		Err:    new(bytes.Buffer),
+		Getenv: func(string) string { return "" },
+		ReadConfig: func() (config.File, error) {
+			return cfg, nil
+		},
+		WriteConfig: func(next config.File) error {
+			cfg = next
+			return nil
+		},
+	}
+
+	requireUsageExitCode(t, c.Execute([]string{"config", "set", "--api-key-env", "IGW_DEV_TOKEN"}))
+	requireUsageExitCode(t, c.Execute([]string{"config", "set", "--profile", "dev", "--api-key", "x", "--api-key-command", "pass show igw"}))
+
+	if err := c.Execute([]string{"config", "set", "--profile", "dev", "--api-key-env", "IGW_DEV_TOKEN"}); err != nil {
+		t.Fatalf("config set failed: %v", err)
+	}
+	if got := cfg.Profiles["dev"]; got.Token != "" || got.TokenEnv != "IGW_DEV_TOKEN" || got.TokenCommand != "" {
+		t.Fatalf("unexpected profile after set: %+v", got)
+	}
+
+	out.Reset()
+	if err := c.Execute([]string{"config", "profile", "list"}); err != nil {
+		t.Fatalf("profile list failed: %v", err)
+	}
+	if !strings.Contains(out.String(), "\tdev\thttp://127.0.0.1:8088\tenv:IGW_DEV_TOKEN") {
+		t.Fatalf("expected env source in listing, got %q", out.String())
+	}
+
+	out.Reset()
+	if err := c.Execute([]string{"config", "show", "--json"}); err != nil {
+		t.Fatalf("config show failed: %v", err)
+	}
+	var shown struct {
+		Profiles map[string]struct {
+			TokenMasked string `json:"tokenMasked"`
+			TokenSource string `json:"tokenSource"`
+		} `json:"profiles"`
+	}
+	if err := json.Unmarshal(out.Bytes(), &shown); err != nil {
+		t.Fatalf("decode json: %v", err)
+	}
+	if got := shown.Profiles["dev"]; got.TokenSource != "env:IGW_DEV_TOKEN" || got.TokenMasked != "" {
+		t.Fatalf("unexpected show output %+v", got)
+	}
+}
@@ -0,0 +1,150 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"sync"
+	"time"
+)
+
+// progressInterval throttles --progress lines so large downloads and batches
+// do not flood stderr.
+const progressInterval = 500 * time.Millisecond
+
+// downloadProgress prints byte progress for streamed downloads. A nil
+// progress reports nothing so callers can thread it unconditionally.
+type downloadProgress struct {
+	mu      sync.Mutex
+	w       io.Writer
+	now     func() time.Time
+	started time.Time
+	last    time.Time
+	done    int64
+	total   int64
+	seen    bool
+}
+
+func newDownloadProgress(w io.Writer, now func() time.Time) *downloadProgress {
+	return &downloadProgress{w: w, now: now, started: now(), total: -1}
+}
+
+// reporter adapts the progress to gateway.CallRequest.Progress.
+func (p *downloadProgress) reporter() func(done int64, total int64) {
+	if p == nil {
+		return nil
+	}
+	return p.update
+}
+
+func (p *downloadProgress) update(done int64, total int64) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.done, p.total, p.seen = done, total, true
+	at := p.now()
+	if at.Sub(p.last) < progressInterval || (total > 0 && done >= total) {
+		return
+	}
+	p.last = at
+	p.writeLine(at)
+}
+
+// finish prints the final line once the body has been fully written.
+func (p *downloadProgress) finish() {
+	if p == nil {
+		return
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if !p.seen {
+		return
+	}
+	p.writeLine(p.now())
+}
+
+func (p *downloadProgress) writeLine(at time.Time) {
+	elapsed := at.Sub(p.started)
+	rate := float64(0)
+	if elapsed > 0 {
+		rate = float64(p.done) / elapsed.Seconds()
+	}
+	if p.total <= 0 {
+		fmt.Fprintf(p.w, "progress: %s %s/s\n", formatByteSize(p.done), formatByteSize(int64(rate)))
+		return
+	}
+	percent := p.done * 100 / p.total
+	line := fmt.Sprintf("progress: %s / %s (%d%%) %s/s", formatByteSize(p.done), formatByteSize(p.total), percent, formatByteSize(int64(rate)))
+	if p.done < p.total && rate > 0 {
+		eta := time.Duration(float64(p.total-p.done) / rate * float64(time.Second))
+		line += " eta " + eta.Round(time.Second).String()
+	}
+	fmt.Fprintln(p.w, line)
+}
+
+// batchProgress prints completed/total item counts for --batch. The total
+// grows as items are read, so it is final only on the last line.
+type batchProgress struct {
+	mu        sync.Mutex
+	w         io.Writer
+	now       func() time.Time
+	last      time.Time
+	queued    int
+	completed int
+}
+
+func newBatchProgress(w io.Writer, now func() time.Time) *batchProgress {
+	return &batchProgress{w: w, now: now}
+}
+
+func (p *batchProgress) queue() {
+	if p == nil {
+		return
+	}
+	p.mu.Lock()
+	p.queued++
+	p.mu.Unlock()
+}
+
+func (p *batchProgress) complete() {
+	if p == nil {
+		return
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.completed++
+	at := p.now()
+	if at.Sub(p.last) < progressInterval || p.completed >= p.queued {
+		return
+	}
+	p.last = at
+	fmt.Fprintf(p.w, "progress: %d/%d requests\n", p.completed, p.queued)
+}
+
+func (p *batchProgress) finish() {
+	if p == nil {
+		return
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.queued == 0 {
+		return
+	}
+	fmt.Fprintf(p.w, "progress: %d/%d requests\n", p.completed, p.queued)
+}
+
+func formatByteSize(n int64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%d B", n)
+	}
+	value := float64(n)
+	suffixes := []string{"KiB", "MiB", "GiB", "TiB"}
+	suffix := ""
+	for _, s := range suffixes {
+		value /= unit
+		suffix = s
+		if value < unit {
+			break
+		}
+	}
+	return fmt.Sprintf("%.1f %s", value, suffix)
+}
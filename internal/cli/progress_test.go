@@ -0,0 +1,142 @@
+package cli
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// chunkReader returns at most size bytes per Read so streamed copies make
+// several writes.
+type chunkReader struct {
+	r    io.Reader
+	size int
+}
+
+func (c chunkReader) Read(p []byte) (int, error) {
+	if len(p) > c.size {
+		p = p[:c.size]
+	}
+	return c.r.Read(p)
+}
+
+// progressTestClock advances one second per call so every progress tick
+// is due.
+func progressTestClock() func() time.Time {
+	tick := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	return func() time.Time {
+		tick = tick.Add(time.Second)
+		return tick
+	}
+}
+
+func TestCallProgressReportsStreamedDownload(t *testing.T) {
+	t.Parallel()
+
+	payload := bytes.Repeat([]byte("x"), 4096)
+	client := newMockHTTPClient(func(r *http.Request) (*http.Response, error) {
+		return &http.Response{
+			StatusCode:    http.StatusOK,
+			Header:        make(http.Header),
+			ContentLength: int64(len(payload)),
+			Body:          io.NopCloser(chunkReader{r: bytes.NewReader(payload), size: 1024}),
+		}, nil
+	})
+
+	outFile := filepath.Join(t.TempDir(), "gateway.gwbk")
+	var errOut bytes.Buffer
+	c := newAdminWrapperTestCLI(client)
+	c.Err = &errOut
+	c.now = progressTestClock()
+	if err := c.Execute([]string{
+		"backup", "export",
+		"--gateway-url", mockGatewayURL,
+		"--api-key", "secret",
+		"--out", outFile,
+		"--progress",
+	}); err != nil {
+		t.Fatalf("backup export failed: %v", err)
+	}
+
+	written, err := os.ReadFile(outFile)
+	if err != nil || !bytes.Equal(written, payload) {
+		t.Fatalf("unexpected file contents (%d bytes, err=%v)", len(written), err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(errOut.String()), "\n")
+	if n := len(lines) - 1; n >= 0 && strings.HasPrefix(lines[n], "saved response body: ") {
+		lines = lines[:n]
+	}
+	if len(lines) < 2 {
+		t.Fatalf("expected an update and a final line, got %q", errOut.String())
+	}
+	if !strings.Contains(lines[0], "progress: 1.0 KiB / 4.0 KiB (25%)") || !strings.Contains(lines[0], "eta ") {
+		t.Fatalf("unexpected first progress line %q", lines[0])
+	}
+	if last := lines[len(lines)-1]; !strings.Contains(last, "4.0 KiB / 4.0 KiB (100%)") {
+		t.Fatalf("expected final 100%% line, got %q", last)
+	}
+	if strings.Contains(c.Out.(*bytes.Buffer).String(), "progress:") {
+		t.Fatalf("progress leaked to stdout: %q", c.Out.(*bytes.Buffer).String())
+	}
+}
+
+func TestCallProgressOffByDefault(t *testing.T) {
+	t.Parallel()
+
+	client := newMockHTTPClient(func(r *http.Request) (*http.Response, error) {
+		return mockHTTPResponse(http.StatusOK, "payload", nil), nil
+	})
+
+	var errOut bytes.Buffer
+	c := newAdminWrapperTestCLI(client)
+	c.Err = &errOut
+	c.now = progressTestClock()
+	if err := c.Execute([]string{
+		"call",
+		"--gateway-url", mockGatewayURL,
+		"--api-key", "secret",
+		"--path", "/data/api/v1/backup",
+		"--out", filepath.Join(t.TempDir(), "out.bin"),
+	}); err != nil {
+		t.Fatalf("call failed: %v", err)
+	}
+	if strings.Contains(errOut.String(), "progress:") {
+		t.Fatalf("progress should be off by default, got %q", errOut.String())
+	}
+}
+
+func TestCallBatchProgressCountsItems(t *testing.T) {
+	t.Parallel()
+
+	client := newMockHTTPClient(func(r *http.Request) (*http.Response, error) {
+		return mockHTTPResponse(http.StatusOK, `{"ok":true}`, nil), nil
+	})
+
+	var errOut bytes.Buffer
+	c := newAdminWrapperTestCLI(client)
+	c.Err = &errOut
+	c.now = progressTestClock()
+	c.In = strings.NewReader("{\"path\":\"/data/api/v1/a\"}\n{\"path\":\"/data/api/v1/b\"}\n{\"path\":\"/data/api/v1/c\"}\n")
+	if err := c.Execute([]string{
+		"call",
+		"--gateway-url", mockGatewayURL,
+		"--api-key", "secret",
+		"--batch", "@-",
+		"--progress",
+	}); err != nil {
+		t.Fatalf("batch failed: %v", err)
+	}
+
+	if !strings.HasSuffix(errOut.String(), "progress: 3/3 requests\n") {
+		t.Fatalf("expected final batch progress line, got %q", errOut.String())
+	}
+	if strings.Contains(c.Out.(*bytes.Buffer).String(), "progress:") {
+		t.Fatalf("progress leaked to stdout: %q", c.Out.(*bytes.Buffer).String())
+	}
+}
@@ -0,0 +1,141 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/alex-mccollum/igw-cli/internal/exitcode"
+	"github.com/alex-mccollum/igw-cli/internal/igwerr"
+)
+
+type rpcBatchArgs struct {
+	Items json.RawMessage `json:"items"`
+}
+
+// rpcBatchStats aggregates one batch op across its items.
+type rpcBatchStats struct {
+	Count     int   `json:"count"`
+	OK        int   `json:"ok"`
+	Failed    int   `json:"failed"`
+	Skipped   int   `json:"skipped,omitempty"`
+	BodyBytes int64 `json:"bodyBytes"`
+	ElapsedMs int64 `json:"elapsedMs"`
+}
+
+// rpcBatchItems accepts either a bare array of call items or {"items":[...]}.
+func rpcBatchItems(raw json.RawMessage) ([]byte, error) {
+	trimmed := bytes.TrimSpace(raw)
+	if len(trimmed) > 0 && trimmed[0] == '{' {
+		var args rpcBatchArgs
+		if err := json.Unmarshal(trimmed, &args); err != nil {
+			return nil, &igwerr.UsageError{Msg: fmt.Sprintf("invalid batch args: %v", err)}
+		}
+		trimmed = bytes.TrimSpace(args.Items)
+	}
+	if len(trimmed) == 0 || trimmed[0] != '[' {
+		return nil, &igwerr.UsageError{Msg: "batch args require an items array"}
+	}
+	return trimmed, nil
+}
+
+// handleRPCBatch runs a list of call items through the batch executor,
+// fanned out to the session's worker count, and answers with one response
+// holding the results in request order.
+func (c *CLI) handleRPCBatch(req rpcRequest, common wrapperCommon, specFile string, session *rpcSessionState) rpcResponse {
+	items, err := rpcBatchItems(req.Args)
+	if err != nil {
+		return rpcResponse{
+			ID:    req.ID,
+			OK:    false,
+			Code:  igwerr.ExitCode(err),
+			Error: err.Error(),
+		}
+	}
+
+	client, defaults, resolved, err := c.rpcCallRuntime(common, specFile, session)
+	if err != nil {
+		return rpcResponse{
+			ID:    req.ID,
+			OK:    false,
+			Code:  igwerr.ExitCode(err),
+			Error: err.Error(),
+		}
+	}
+
+	batchCtx, batchCancel := context.WithCancel(context.Background())
+	defer batchCancel()
+	if reqKey, ok := session.registerInFlight(req.ID, batchCancel); ok {
+		defer session.unregisterInFlight(reqKey)
+	}
+
+	defaults.Parallel = 1
+	if session != nil {
+		defaults.Parallel = max(session.workers, 1)
+		defaults.Breaker = session.breaker
+		defaults.Summary = session.summary
+	}
+	defaults.Policy = resolved.Policy
+	defaults.Context = batchCtx
+	opMapLoader := &batchOperationMapLoader{cli: c, defaults: defaults}
+
+	start := time.Now()
+	var (
+		resultsByIndex map[int]callBatchItemResult
+		exitState      batchExitState
+		itemCount      int
+	)
+	if defaults.Parallel == 1 {
+		resultsByIndex, exitState, itemCount, err = c.runCallBatchSequential(bytes.NewReader(items), client, defaults, opMapLoader)
+	} else {
+		resultsByIndex, exitState, itemCount, err = c.runCallBatchParallel(bytes.NewReader(items), client, defaults, opMapLoader)
+	}
+	if err == nil && itemCount == 0 {
+		err = &igwerr.UsageError{Msg: "batch request list is empty"}
+	}
+	if err != nil {
+		return rpcResponse{
+			ID:    req.ID,
+			OK:    false,
+			Code:  igwerr.ExitCode(err),
+			Error: err.Error(),
+		}
+	}
+
+	results := orderedBatchResults(resultsByIndex, itemCount)
+	stats := rpcBatchStats{
+		Count:     itemCount,
+		ElapsedMs: time.Since(start).Milliseconds(),
+	}
+	for _, result := range results {
+		switch {
+		case result.OK:
+			stats.OK++
+		case result.Skipped:
+			stats.Skipped++
+			stats.Failed++
+		default:
+			stats.Failed++
+		}
+		if result.Stats != nil {
+			stats.BodyBytes += result.Stats.BodyBytes
+		}
+	}
+
+	resp := rpcResponse{
+		ID:   req.ID,
+		OK:   true,
+		Code: exitState.result(),
+		Data: map[string]any{
+			"items": results,
+			"stats": stats,
+		},
+	}
+	if resp.Code != exitcode.Success {
+		resp.OK = false
+		resp.Error = "one or more batch requests failed"
+	}
+	return resp
+}
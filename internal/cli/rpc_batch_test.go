@@ -0,0 +1,114 @@
+package cli
+
+import (
+	"bytes"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestRPCBatchKeepsItemOrderAndReportsPartialFailures(t *testing.T) {
+	t.Parallel()
+
+	client := newMockHTTPClient(func(r *http.Request) (*http.Response, error) {
+		switch r.URL.Query().Get("n") {
+		case "1":
+			// The first item finishes last so completion order differs.
+			time.Sleep(40 * time.Millisecond)
+		case "3":
+			return mockHTTPResponse(http.StatusInternalServerError, `{"error":"boom"}`, nil), nil
+		}
+		return mockHTTPResponse(http.StatusOK, `{"n":"`+r.URL.Query().Get("n")+`"}`, nil), nil
+	})
+	out := new(bytes.Buffer)
+	c := newAdminWrapperTestCLI(client)
+	c.Out = out
+	c.In = strings.NewReader(strings.Join([]string{
+		`{"id":"b1","op":"batch","args":[` +
+			`{"id":"first","path":"/data/api/v1/gateway-info","query":["n=1"]},` +
+			`{"id":"second","path":"/data/api/v1/gateway-info","query":["n=2"]},` +
+			`{"id":"third","path":"/data/api/v1/gateway-info","query":["n=3"]},` +
+			`{"id":"fourth","path":"/data/api/v1/gateway-info","timeout":"soon"}]}`,
+		`{"id":"b2","op":"batch","args":{"items":[{"path":"/data/api/v1/gateway-info","query":["n=5"]}]}}`,
+		`{"id":"b3","op":"batch","args":{"items":[]}}`,
+		`{"id":"b4","op":"batch","args":{"path":"/data/api/v1/gateway-info"}}`,
+	}, "\n"))
+
+	if err := c.Execute([]string{"rpc", "--gateway-url", mockGatewayURL, "--api-key", "secret", "--workers", "4"}); err != nil {
+		t.Fatalf("rpc failed: %v", err)
+	}
+	responses := decodeRPCResponses(t, out.String())
+
+	mixed := responseByID(t, responses, "b1")
+	if mixed["ok"] != false || mixed["code"] != float64(2) {
+		t.Fatalf("expected a failed batch with the usage exit class, got %#v", mixed)
+	}
+	data := mixed["data"].(map[string]any)
+	items := data["items"].([]any)
+	wantIDs := []string{"first", "second", "third", "fourth"}
+	wantOK := []bool{true, true, false, false}
+	if len(items) != len(wantIDs) {
+		t.Fatalf("expected %d items, got %#v", len(wantIDs), items)
+	}
+	for i, raw := range items {
+		item := raw.(map[string]any)
+		if item["id"] != wantIDs[i] || item["ok"] != wantOK[i] {
+			t.Fatalf("item %d: expected id=%s ok=%t, got %#v", i, wantIDs[i], wantOK[i], item)
+		}
+	}
+	if body := items[0].(map[string]any)["response"].(map[string]any)["body"]; body != `{"n":"1"}` {
+		t.Fatalf("expected the slow first item to stay first, got %v", body)
+	}
+	if code := items[2].(map[string]any)["code"]; code != float64(7) {
+		t.Fatalf("expected the 500 item to carry the network class, got %v", code)
+	}
+	if msg, _ := items[3].(map[string]any)["error"].(string); !strings.Contains(msg, `invalid timeout "soon"`) {
+		t.Fatalf("expected the per-item timeout to be validated, got %q", msg)
+	}
+	stats := data["stats"].(map[string]any)
+	if stats["count"] != float64(4) || stats["ok"] != float64(2) || stats["failed"] != float64(2) {
+		t.Fatalf("unexpected aggregate stats: %#v", stats)
+	}
+
+	wrapped := responseByID(t, responses, "b2")
+	if wrapped["ok"] != true || wrapped["code"] != float64(0) {
+		t.Fatalf("expected the items form to succeed, got %#v", wrapped)
+	}
+	for _, id := range []string{"b3", "b4"} {
+		if resp := responseByID(t, responses, id); resp["ok"] != false || resp["code"] != float64(2) {
+			t.Fatalf("%s: expected a usage error, got %#v", id, resp)
+		}
+	}
+}
+
+func TestRPCCallHonorsPerRequestRetry(t *testing.T) {
+	t.Parallel()
+
+	var requests atomic.Int64
+	client := newMockHTTPClient(func(*http.Request) (*http.Response, error) {
+		if requests.Add(1) == 1 {
+			return mockHTTPResponse(http.StatusServiceUnavailable, `{}`, nil), nil
+		}
+		return mockHTTPResponse(http.StatusOK, `{"ok":true}`, nil), nil
+	})
+	out := new(bytes.Buffer)
+	c := newAdminWrapperTestCLI(client)
+	c.Out = out
+	c.In = strings.NewReader(strings.Join([]string{
+		`{"id":"c1","op":"call","args":{"path":"/data/api/v1/gateway-info","retry":1,"retryBackoff":"1ms","timeout":"2s"}}`,
+		`{"id":"c2","op":"call","args":{"path":"/data/api/v1/gateway-info","retryBackoff":"later"}}`,
+	}, "\n"))
+
+	if err := c.Execute([]string{"rpc", "--gateway-url", mockGatewayURL, "--api-key", "secret"}); err != nil {
+		t.Fatalf("rpc failed: %v", err)
+	}
+	responses := decodeRPCResponses(t, out.String())
+	if retried := responseByID(t, responses, "c1"); retried["ok"] != true || requests.Load() != 2 {
+		t.Fatalf("expected the call to succeed on retry, got %#v after %d requests", retried, requests.Load())
+	}
+	if invalid := responseByID(t, responses, "c2"); invalid["ok"] != false || invalid["code"] != float64(2) {
+		t.Fatalf("expected an invalid retryBackoff to be a usage error, got %#v", invalid)
+	}
+}
@@ -1,6 +1,7 @@
 package cli
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"errors"
@@ -10,6 +11,7 @@ import (
 	"time"
 
 	"github.com/alex-mccollum/igw-cli/internal/apidocs"
+	"github.com/alex-mccollum/igw-cli/internal/config"
 	"github.com/alex-mccollum/igw-cli/internal/gateway"
 	"github.com/alex-mccollum/igw-cli/internal/igwerr"
 )
@@ -27,6 +29,8 @@ type rpcResponse struct {
 	Status int    `json:"status,omitempty"`
 	Data   any    `json:"data,omitempty"`
 	Error  string `json:"error,omitempty"`
+	// Skipped marks a call short-circuited by --fail-fast-after.
+	Skipped bool `json:"skipped,omitempty"`
 }
 
 func (c *CLI) runRPC(args []string) error {
@@ -37,10 +41,20 @@ func (c *CLI) runRPC(args []string) error {
 	var specFile string
 	var workers int
 	var queueSize int
+	var framing string
+	var summary bool
+	var session bool
+	var failFastAfter int
+	var rate float64
 	bindWrapperCommonWithDefaults(fs, &common, 8*time.Second, false)
 	fs.StringVar(&specFile, "spec-file", "openapi.json", "Path to OpenAPI JSON file (for op-based call resolution)")
 	fs.IntVar(&workers, "workers", 1, "Number of concurrent request workers")
 	fs.IntVar(&queueSize, "queue-size", 64, "RPC request queue capacity")
+	fs.StringVar(&framing, "framing", rpcFramingNDJSON, "Response framing: ndjson|length-prefixed")
+	fs.BoolVar(&summary, "summary", false, "Print a requests/failures/bytes/elapsed summary to stderr when the session ends")
+	fs.BoolVar(&session, "session", false, "Keep cookies set by the gateway (for example sticky-session cookies) for every call in this session; never saved")
+	fs.IntVar(&failFastAfter, "fail-fast-after", 0, "Skip later call requests after N consecutive network failures (0 = off)")
+	fs.Float64Var(&rate, "rate", 0, "Maximum call requests per second shared by all workers, fractions allowed (0 = off)")
 
 	if err := fs.Parse(args); err != nil {
 		return &igwerr.UsageError{Msg: err.Error()}
@@ -51,22 +65,45 @@ func (c *CLI) runRPC(args []string) error {
 	if common.apiKeyStdin {
 		return &igwerr.UsageError{Msg: "--api-key-stdin is not supported in rpc mode"}
 	}
+	if common.passphraseStdin {
+		return &igwerr.UsageError{Msg: "--passphrase-stdin is not supported in rpc mode; set " + config.EnvPassphrase}
+	}
+	common.applyTokenEnv(c.Getenv)
 	if common.timeout <= 0 {
 		return &igwerr.UsageError{Msg: "--timeout must be positive"}
 	}
+	if _, err := common.requestSigner(); err != nil {
+		return err
+	}
 	if workers <= 0 {
 		return &igwerr.UsageError{Msg: "--workers must be >= 1"}
 	}
 	if queueSize <= 0 {
 		return &igwerr.UsageError{Msg: "--queue-size must be >= 1"}
 	}
+	if failFastAfter < 0 {
+		return &igwerr.UsageError{Msg: "--fail-fast-after must be >= 0"}
+	}
+	if rate < 0 {
+		return &igwerr.UsageError{Msg: "--rate must be >= 0"}
+	}
+	framing = strings.ToLower(strings.TrimSpace(framing))
+	if framing != rpcFramingNDJSON && framing != rpcFramingLengthPrefixed {
+		return &igwerr.UsageError{Msg: "--framing must be one of: ndjson, length-prefixed"}
+	}
 
+	c.reserveConnections(workers)
 	runner := rpcSessionRunner{
 		cli:       c,
 		common:    common,
 		specFile:  specFile,
 		workers:   workers,
 		queueSize: queueSize,
+		framing:   framing,
+		summary:   summary,
+		session:   session,
+		failFast:  failFastAfter,
+		rate:      rate,
 	}
 	return runner.run()
 }
@@ -192,6 +229,7 @@ func (c *CLI) handleRPCCancel(req rpcRequest, session *rpcSessionState) rpcRespo
 
 func (c *CLI) handleRPCCall(req rpcRequest, common wrapperCommon, specFile string, session *rpcSessionState) rpcResponse {
 	var item callBatchItem
+	var notifyArgs rpcNotifyArgs
 	if len(req.Args) == 0 {
 		item = callBatchItem{}
 	} else if err := json.Unmarshal(req.Args, &item); err != nil {
@@ -202,10 +240,16 @@ func (c *CLI) handleRPCCall(req rpcRequest, common wrapperCommon, specFile strin
 			Code:  igwerr.ExitCode(usageErr),
 			Error: usageErr.Error(),
 		}
+	} else if err := json.Unmarshal(req.Args, &notifyArgs); err != nil {
+		usageErr := &igwerr.UsageError{Msg: fmt.Sprintf("invalid call args: %v", err)}
+		return rpcResponse{
+			ID:    req.ID,
+			OK:    false,
+			Code:  igwerr.ExitCode(usageErr),
+			Error: usageErr.Error(),
+		}
 	}
-
-	resolved, err := c.resolveRuntimeConfig(common.profile, common.gatewayURL, common.apiKey)
-	if err != nil {
+	if err := notifyArgs.validate(); err != nil {
 		return rpcResponse{
 			ID:    req.ID,
 			OK:    false,
@@ -214,11 +258,14 @@ func (c *CLI) handleRPCCall(req rpcRequest, common wrapperCommon, specFile strin
 		}
 	}
 
-	defaults := callBatchDefaults{
-		SpecFile:   specFile,
-		Profile:    common.profile,
-		GatewayURL: common.gatewayURL,
-		APIKey:     common.apiKey,
+	client, defaults, resolved, err := c.rpcCallRuntime(common, specFile, session)
+	if err != nil {
+		return rpcResponse{
+			ID:    req.ID,
+			OK:    false,
+			Code:  igwerr.ExitCode(err),
+			Error: err.Error(),
+		}
 	}
 
 	opMap := map[string]apidocs.Operation(nil)
@@ -235,16 +282,12 @@ func (c *CLI) handleRPCCall(req rpcRequest, common wrapperCommon, specFile strin
 		}
 	}
 
-	client := &gateway.Client{
-		BaseURL: resolved.GatewayURL,
-		Token:   resolved.Token,
-		HTTP:    c.runtimeHTTPClient(),
-	}
-
+	// Per-request timeout, retry, and retryBackoff args override the
+	// session defaults.
 	input, parseErr := buildCallExecutionInputFromItem(item, callItemExecutionDefaults{
-		Timeout:      common.timeout,
-		Retry:        0,
-		RetryBackoff: 250 * time.Millisecond,
+		Timeout:      defaults.Timeout,
+		Retry:        defaults.Retry,
+		RetryBackoff: defaults.RetryBackoff,
 		Yes:          false,
 		OperationMap: opMap,
 		EnableTiming: true,
@@ -264,10 +307,47 @@ func (c *CLI) handleRPCCall(req rpcRequest, common wrapperCommon, specFile strin
 		defer session.unregisterInFlight(reqKey)
 	}
 
+	var breaker *batchCircuitBreaker
+	if session != nil {
+		breaker = session.breaker
+	}
+	if !breaker.allow() {
+		skipErr := breaker.skipError()
+		return rpcResponse{
+			ID:      req.ID,
+			OK:      false,
+			Code:    exitCodeForError(skipErr),
+			Error:   skipErr.Error(),
+			Skipped: true,
+		}
+	}
+
 	start := time.Now()
-	input.Context = callCtx
+	notifier := newRPCProgressNotifier(session, req.ID, notifyArgs)
+	input.Context = notifier.trace(callCtx)
+	if session != nil {
+		input.Summary = session.summary
+	}
+	input.Policy = resolved.Policy
+	var rateWait time.Duration
+	input.OnRateWait = func(wait time.Duration) {
+		rateWait += wait
+	}
+	// Body progress needs the streaming path; the body is still returned
+	// in the response.
+	var body bytes.Buffer
+	if notifier != nil {
+		input.Stream = notifier.writer(&body)
+	}
 	callResp, method, path, callErr := executeCallCore(client, input)
+	notifier.flush()
+	if notifier != nil && callResp != nil && callResp.Body == nil {
+		callResp.Body = body.Bytes()
+	}
 	elapsedMs := time.Since(start).Milliseconds()
+	breaker.record(callErr)
+	stats := buildCallStats(callResp, elapsedMs)
+	stats.RateWaitMs = rateWait.Milliseconds()
 	if callErr != nil {
 		return rpcResponse{
 			ID:    req.ID,
@@ -280,7 +360,7 @@ func (c *CLI) handleRPCCall(req rpcRequest, common wrapperCommon, specFile strin
 					URL:    path,
 				},
 				"cancelled": errors.Is(callErr, context.Canceled),
-				"stats":     buildCallStats(callResp, elapsedMs),
+				"stats":     stats,
 			},
 		}
 	}
@@ -303,7 +383,64 @@ func (c *CLI) handleRPCCall(req rpcRequest, common wrapperCommon, specFile strin
 				Truncated: callResp.Truncated,
 			},
 			"timingMs": elapsedMs, // backward-compatible shorthand
-			"stats":    buildCallStats(callResp, elapsedMs),
+			"stats":    stats,
 		},
 	}
 }
+
+// rpcCallRuntime resolves the gateway config and builds the client shared by
+// the call and batch ops. The client joins the session's cookie jar and rate
+// limiter when they are enabled.
+func (c *CLI) rpcCallRuntime(common wrapperCommon, specFile string, session *rpcSessionState) (*gateway.Client, callBatchDefaults, config.Effective, error) {
+	resolved, err := c.resolveRuntimeConfig(common.profile, common.gatewayURL, common.apiKey)
+	if err != nil {
+		return nil, callBatchDefaults{}, config.Effective{}, err
+	}
+
+	// Validated when the session starts, so the error cannot occur here.
+	signer, _ := common.requestSigner()
+	proxy, err := common.clientProxy(resolved)
+	if err != nil {
+		return nil, callBatchDefaults{}, config.Effective{}, err
+	}
+	httpClient, err := c.gatewayHTTPClient(common.tls, resolved)
+	if err != nil {
+		return nil, callBatchDefaults{}, config.Effective{}, err
+	}
+	defaultHeaders, err := configDefaultHeaders(resolved)
+	if err != nil {
+		return nil, callBatchDefaults{}, config.Effective{}, err
+	}
+	defaults := callBatchDefaults{
+		Timeout:      common.timeout,
+		RetryBackoff: 250 * time.Millisecond,
+		IncludeHeads: common.includeHeaders,
+		SpecFile:     specFile,
+		Profile:      common.profile,
+		GatewayURL:   common.gatewayURL,
+		APIKey:       common.apiKey,
+		Signer:       signer,
+		Proxy:        proxy,
+		HTTP:         httpClient,
+		TLS:          common.tls,
+
+		DefaultHeaders: defaultHeaders,
+	}
+
+	client := &gateway.Client{
+		BaseURL:        resolved.GatewayURL,
+		Token:          resolved.Token,
+		HTTP:           httpClient,
+		Signer:         signer,
+		Proxy:          proxy,
+		DefaultHeaders: defaultHeaders,
+	}
+	if session != nil && session.jar != nil {
+		client.Session = true
+		client.Jar = session.jar
+	}
+	if session != nil {
+		client.Limiter = session.limiter
+	}
+	return client, defaults, resolved, nil
+}
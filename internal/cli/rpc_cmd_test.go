@@ -2,6 +2,7 @@ package cli
 
 import (
 	"bytes"
+	"encoding/binary"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -149,7 +150,7 @@ func TestRPCOperationRegistryDrivesHelloMetadata(t *testing.T) {
 	t.Parallel()
 
 	c := &CLI{}
-	hello := c.handleRPCHello(rpcRequest{ID: "h1"})
+	hello := c.handleRPCHello(rpcRequest{ID: "h1"}, nil)
 	if !hello.OK || hello.Code != 0 {
 		t.Fatalf("expected hello success response: %#v", hello)
 	}
@@ -552,6 +553,9 @@ func responseByID(t *testing.T, responses []map[string]any, id string) map[strin
 	t.Helper()
 
 	for _, resp := range responses {
+		if _, isEvent := resp["event"]; isEvent {
+			continue
+		}
 		if fmt.Sprint(resp["id"]) == id {
 			return resp
 		}
@@ -568,3 +572,62 @@ func responseExistsByID(responses []map[string]any, id string) bool {
 	}
 	return false
 }
+
+func TestRPCModeLengthPrefixedFraming(t *testing.T) {
+	t.Parallel()
+
+	client := newMockHTTPClient(func(r *http.Request) (*http.Response, error) {
+		return mockHTTPResponse(http.StatusOK, "line one\nline two", nil), nil
+	})
+
+	var out bytes.Buffer
+	c := &CLI{
+		In: strings.NewReader(strings.Join([]string{
+			`{"id":"h1","op":"hello"}`,
+			`{"id":"c1","op":"call","args":{"method":"GET","path":"/data/api/v1/gateway-info"}}`,
+			`{"id":"s1","op":"shutdown"}`,
+		}, "\n")),
+		Out:    &out,
+		Err:    new(bytes.Buffer),
+		Getenv: func(string) string { return "" },
+		ReadConfig: func() (config.File, error) {
+			return config.File{}, nil
+		},
+		HTTPClient: client,
+	}
+
+	if err := c.Execute([]string{"rpc", "--gateway-url", mockGatewayURL, "--api-key", "secret", "--framing", "length-prefixed"}); err != nil {
+		t.Fatalf("rpc failed: %v", err)
+	}
+
+	var responses []map[string]any
+	stream := out.Bytes()
+	for len(stream) > 0 {
+		if len(stream) < 4 {
+			t.Fatalf("truncated frame header: %q", stream)
+		}
+		size := int(binary.BigEndian.Uint32(stream[:4]))
+		if len(stream) < 4+size {
+			t.Fatalf("truncated frame payload: want %d bytes, have %d", size, len(stream)-4)
+		}
+		var resp map[string]any
+		if err := json.Unmarshal(stream[4:4+size], &resp); err != nil {
+			t.Fatalf("decode frame: %v", err)
+		}
+		responses = append(responses, resp)
+		stream = stream[4+size:]
+	}
+	if len(responses) != 3 {
+		t.Fatalf("expected 3 frames, got %d", len(responses))
+	}
+
+	helloData, _ := responseByID(t, responses, "h1")["data"].(map[string]any)
+	if helloData["framing"] != rpcFramingLengthPrefixed {
+		t.Fatalf("expected hello to advertise length-prefixed framing: %#v", helloData)
+	}
+	callData, _ := responseByID(t, responses, "c1")["data"].(map[string]any)
+	response, _ := callData["response"].(map[string]any)
+	if response["body"] != "line one\nline two" {
+		t.Fatalf("unexpected call response body: %#v", callData)
+	}
+}
@@ -0,0 +1,233 @@
+package cli
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/alex-mccollum/igw-cli/internal/apidocs"
+	"github.com/alex-mccollum/igw-cli/internal/igwerr"
+)
+
+// rpcDownloadArgs are call args plus the file the body is streamed to.
+type rpcDownloadArgs struct {
+	callBatchItem
+	rpcNotifyArgs
+	Out string `json:"out"`
+}
+
+// rpcDownloadFile streams a response body into a temp file next to the
+// target and hashes it on the way. commit renames it into place; discard
+// removes it.
+type rpcDownloadFile struct {
+	path string
+	tmp  *os.File
+	hash io.Writer
+	sum  func() []byte
+}
+
+func newRPCDownloadFile(path string) (*rpcDownloadFile, error) {
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".*.partial")
+	if err != nil {
+		return nil, igwerr.NewTransportError(err)
+	}
+	hasher := sha256.New()
+	return &rpcDownloadFile{
+		path: path,
+		tmp:  tmp,
+		hash: io.MultiWriter(tmp, hasher),
+		sum:  func() []byte { return hasher.Sum(nil) },
+	}, nil
+}
+
+func (f *rpcDownloadFile) Write(p []byte) (int, error) {
+	return f.hash.Write(p)
+}
+
+func (f *rpcDownloadFile) commit() error {
+	if err := f.tmp.Close(); err != nil {
+		f.discard()
+		return igwerr.NewTransportError(err)
+	}
+	if err := os.Rename(f.tmp.Name(), f.path); err != nil {
+		f.discard()
+		return igwerr.NewTransportError(err)
+	}
+	return nil
+}
+
+func (f *rpcDownloadFile) discard() {
+	_ = f.tmp.Close()
+	_ = os.Remove(f.tmp.Name())
+}
+
+// handleRPCDownload streams one response body to args.out instead of
+// buffering it into the reply, which carries the byte count and sha256.
+// A failed or cancelled download leaves no file behind.
+func (c *CLI) handleRPCDownload(req rpcRequest, common wrapperCommon, specFile string, session *rpcSessionState) rpcResponse {
+	var args rpcDownloadArgs
+	if len(req.Args) > 0 {
+		if err := json.Unmarshal(req.Args, &args); err != nil {
+			usageErr := &igwerr.UsageError{Msg: fmt.Sprintf("invalid download args: %v", err)}
+			return rpcResponse{
+				ID:    req.ID,
+				OK:    false,
+				Code:  igwerr.ExitCode(usageErr),
+				Error: usageErr.Error(),
+			}
+		}
+	}
+	var usageErr error
+	switch {
+	case strings.TrimSpace(args.Out) == "":
+		usageErr = &igwerr.UsageError{Msg: "download args require out"}
+	case args.DryRun:
+		usageErr = &igwerr.UsageError{Msg: "download does not support dryRun"}
+	case args.SetHeaderFromResponse != nil || args.UseSessionHeaders:
+		usageErr = &igwerr.UsageError{Msg: "download does not support batch session headers"}
+	default:
+		usageErr = args.rpcNotifyArgs.validate()
+	}
+	if usageErr != nil {
+		return rpcResponse{
+			ID:    req.ID,
+			OK:    false,
+			Code:  igwerr.ExitCode(usageErr),
+			Error: usageErr.Error(),
+		}
+	}
+
+	client, defaults, resolved, err := c.rpcCallRuntime(common, specFile, session)
+	if err != nil {
+		return rpcResponse{
+			ID:    req.ID,
+			OK:    false,
+			Code:  igwerr.ExitCode(err),
+			Error: err.Error(),
+		}
+	}
+
+	opMap := map[string]apidocs.Operation(nil)
+	if strings.TrimSpace(args.OperationID) != "" {
+		opMap, err = c.loadBatchOperationMap(defaults)
+		if err != nil {
+			return rpcResponse{
+				ID:    req.ID,
+				OK:    false,
+				Code:  igwerr.ExitCode(err),
+				Error: err.Error(),
+			}
+		}
+	}
+
+	input, err := buildCallExecutionInputFromItem(args.callBatchItem, callItemExecutionDefaults{
+		Timeout:      defaults.Timeout,
+		Retry:        defaults.Retry,
+		RetryBackoff: defaults.RetryBackoff,
+		OperationMap: opMap,
+		EnableTiming: true,
+	})
+	if err != nil {
+		return rpcResponse{
+			ID:    req.ID,
+			OK:    false,
+			Code:  igwerr.ExitCode(err),
+			Error: err.Error(),
+		}
+	}
+
+	callCtx, callCancel := context.WithCancel(context.Background())
+	defer callCancel()
+	if reqKey, ok := session.registerInFlight(req.ID, callCancel); ok {
+		defer session.unregisterInFlight(reqKey)
+	}
+
+	var breaker *batchCircuitBreaker
+	if session != nil {
+		breaker = session.breaker
+		input.Summary = session.summary
+	}
+	if !breaker.allow() {
+		skipErr := breaker.skipError()
+		return rpcResponse{
+			ID:      req.ID,
+			OK:      false,
+			Code:    exitCodeForError(skipErr),
+			Error:   skipErr.Error(),
+			Skipped: true,
+		}
+	}
+
+	file, err := newRPCDownloadFile(args.Out)
+	if err != nil {
+		return rpcResponse{
+			ID:    req.ID,
+			OK:    false,
+			Code:  igwerr.ExitCode(err),
+			Error: err.Error(),
+		}
+	}
+
+	start := time.Now()
+	notifier := newRPCProgressNotifier(session, req.ID, args.rpcNotifyArgs)
+	input.Context = notifier.trace(callCtx)
+	input.Policy = resolved.Policy
+	input.Stream = notifier.writer(file)
+	var rateWait time.Duration
+	input.OnRateWait = func(wait time.Duration) {
+		rateWait += wait
+	}
+	callResp, method, path, callErr := executeCallCore(client, input)
+	notifier.flush()
+	breaker.record(callErr)
+	if callErr == nil {
+		callErr = file.commit()
+	} else {
+		file.discard()
+	}
+	elapsedMs := time.Since(start).Milliseconds()
+	stats := buildCallStats(callResp, elapsedMs)
+	stats.RateWaitMs = rateWait.Milliseconds()
+	if callErr != nil {
+		return rpcResponse{
+			ID:    req.ID,
+			OK:    false,
+			Code:  igwerr.ExitCode(callErr),
+			Error: callErr.Error(),
+			Data: map[string]any{
+				"request": callJSONRequest{
+					Method: method,
+					URL:    path,
+				},
+				"cancelled": errors.Is(callErr, context.Canceled),
+				"stats":     stats,
+			},
+		}
+	}
+
+	return rpcResponse{
+		ID:     req.ID,
+		OK:     true,
+		Code:   0,
+		Status: callResp.StatusCode,
+		Data: map[string]any{
+			"request": callJSONRequest{
+				Method: callResp.Method,
+				URL:    callResp.URL,
+			},
+			"out":      args.Out,
+			"bytes":    callResp.BodyBytes,
+			"sha256":   hex.EncodeToString(file.sum()),
+			"timingMs": elapsedMs,
+			"stats":    stats,
+		},
+	}
+}
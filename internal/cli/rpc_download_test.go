@@ -0,0 +1,137 @@
+package cli
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRPCDownloadStreamsBodyToFile(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	payloads := map[string]string{
+		"a": strings.Repeat("backup-a", 4096),
+		"b": strings.Repeat("backup-b", 2048),
+	}
+	client := newMockHTTPClient(func(r *http.Request) (*http.Response, error) {
+		name := r.URL.Query().Get("name")
+		if name == "missing" {
+			return mockHTTPResponse(http.StatusNotFound, `{"error":"not found"}`, nil), nil
+		}
+		return mockHTTPResponse(http.StatusOK, payloads[name], nil), nil
+	})
+	outA := filepath.Join(dir, "a.gwbk")
+	outB := filepath.Join(dir, "b.gwbk")
+	outMissing := filepath.Join(dir, "missing.gwbk")
+	out := new(bytes.Buffer)
+	c := newAdminWrapperTestCLI(client)
+	c.Out = out
+	c.In = strings.NewReader(strings.Join([]string{
+		`{"id":"d1","op":"download","args":{"path":"/data/api/v1/backup","query":["name=a"],"out":"` + outA + `"}}`,
+		`{"id":"d2","op":"download","args":{"path":"/data/api/v1/backup","query":["name=b"],"out":"` + outB + `"}}`,
+		`{"id":"d3","op":"download","args":{"path":"/data/api/v1/backup","query":["name=missing"],"out":"` + outMissing + `"}}`,
+		`{"id":"d4","op":"download","args":{"path":"/data/api/v1/backup"}}`,
+	}, "\n"))
+
+	if err := c.Execute([]string{"rpc", "--gateway-url", mockGatewayURL, "--api-key", "secret", "--workers", "2"}); err != nil {
+		t.Fatalf("rpc failed: %v", err)
+	}
+	responses := decodeRPCResponses(t, out.String())
+
+	for id, want := range map[string]struct{ out, body string }{"d1": {outA, payloads["a"]}, "d2": {outB, payloads["b"]}} {
+		resp := responseByID(t, responses, id)
+		data, _ := resp["data"].(map[string]any)
+		sum := sha256.Sum256([]byte(want.body))
+		if resp["ok"] != true || resp["status"] != float64(200) || data["bytes"] != float64(len(want.body)) || data["sha256"] != hex.EncodeToString(sum[:]) {
+			t.Fatalf("%s: unexpected download response %#v", id, resp)
+		}
+		if _, ok := data["response"]; ok {
+			t.Fatalf("%s: download must not echo the body: %#v", id, data)
+		}
+		if got, err := os.ReadFile(want.out); err != nil || string(got) != want.body {
+			t.Fatalf("%s: expected the body on disk, got %d bytes (err=%v)", id, len(got), err)
+		}
+	}
+
+	if missing := responseByID(t, responses, "d3"); missing["ok"] != false {
+		t.Fatalf("expected a 404 download to fail: %#v", missing)
+	}
+	if noOut := responseByID(t, responses, "d4"); noOut["ok"] != false || noOut["code"] != float64(2) {
+		t.Fatalf("expected a missing out to be a usage error: %#v", noOut)
+	}
+	requireOnlyFiles(t, dir, "a.gwbk", "b.gwbk")
+}
+
+func TestRPCDownloadCancelRemovesPartialFile(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	started := make(chan struct{})
+	client := newMockHTTPClient(func(r *http.Request) (*http.Response, error) {
+		body, writer := io.Pipe()
+		go func() {
+			_, _ = io.WriteString(writer, strings.Repeat("x", 64*1024))
+			close(started)
+			<-r.Context().Done()
+			_ = writer.CloseWithError(r.Context().Err())
+		}()
+		return &http.Response{StatusCode: http.StatusOK, Header: http.Header{}, Body: body, Request: r}, nil
+	})
+
+	inReader, inWriter := io.Pipe()
+	out := new(bytes.Buffer)
+	c := newAdminWrapperTestCLI(client)
+	c.Out = out
+	c.In = inReader
+	runErr := make(chan error, 1)
+	go func() {
+		runErr <- c.Execute([]string{"rpc", "--gateway-url", mockGatewayURL, "--api-key", "secret", "--workers", "2"})
+	}()
+
+	target := filepath.Join(dir, "big.gwbk")
+	_, _ = io.WriteString(inWriter, `{"id":"d1","op":"download","args":{"path":"/data/api/v1/backup","timeout":"5s","out":"`+target+`"}}`+"\n")
+	select {
+	case <-started:
+	case <-time.After(2 * time.Second):
+		t.Fatal("download never started")
+	}
+	time.Sleep(20 * time.Millisecond)
+	_, _ = io.WriteString(inWriter, `{"id":"x1","op":"cancel","args":{"id":"d1"}}`+"\n")
+	_ = inWriter.Close()
+	if err := <-runErr; err != nil {
+		t.Fatalf("rpc failed: %v", err)
+	}
+
+	responses := decodeRPCResponses(t, out.String())
+	if cancel := responseByID(t, responses, "x1"); cancel["data"].(map[string]any)["cancelled"] != true {
+		t.Fatalf("expected the download to be cancelled: %#v", cancel)
+	}
+	download := responseByID(t, responses, "d1")
+	if download["ok"] != false || download["data"].(map[string]any)["cancelled"] != true {
+		t.Fatalf("expected a cancelled download response: %#v", download)
+	}
+	requireOnlyFiles(t, dir)
+}
+
+func requireOnlyFiles(t *testing.T, dir string, want ...string) {
+	t.Helper()
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatalf("read dir: %v", err)
+	}
+	var got []string
+	for _, entry := range entries {
+		got = append(got, entry.Name())
+	}
+	if strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Fatalf("expected files %v in %s, got %v", want, dir, got)
+	}
+}
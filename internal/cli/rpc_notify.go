@@ -0,0 +1,162 @@
+package cli
+
+import (
+	"context"
+	"io"
+	"net/http/httptrace"
+	"sync"
+	"time"
+
+	"github.com/alex-mccollum/igw-cli/internal/igwerr"
+)
+
+// rpcNotifyDefaultBytes is how often body progress is reported when a
+// request sets notify without notifyBytes.
+const rpcNotifyDefaultBytes = 64 << 10
+
+// rpcEvent is an interim frame sent before a request's response. It always
+// carries event and never ok, which is how hosts tell the two apart.
+type rpcEvent struct {
+	ID    any            `json:"id,omitempty"`
+	Event string         `json:"event"`
+	Data  map[string]any `json:"data,omitempty"`
+}
+
+// rpcNotifyArgs are the progress options shared by the call and download
+// ops.
+type rpcNotifyArgs struct {
+	Notify      bool  `json:"notify,omitempty"`
+	NotifyBytes int64 `json:"notifyBytes,omitempty"`
+}
+
+func (a rpcNotifyArgs) validate() error {
+	if a.NotifyBytes < 0 {
+		return &igwerr.UsageError{Msg: "notifyBytes must be >= 0"}
+	}
+	if a.NotifyBytes > 0 && !a.Notify {
+		return &igwerr.UsageError{Msg: "notifyBytes requires notify"}
+	}
+	return nil
+}
+
+// rpcProgressNotifier emits progress events for one request: when a
+// connection is ready, when the first response byte arrives, and every
+// notifyBytes of successful body.
+//
+// Events never wait for the response writer. A frame the writer cannot take
+// right away is held in pending, where body frames coalesce into the newest
+// running total, and flush sends what is left before the response.
+type rpcProgressNotifier struct {
+	session *rpcSessionState
+	id      any
+	every   int64
+	start   time.Time
+
+	mu      sync.Mutex
+	done    int64
+	next    int64
+	pending []rpcEvent
+}
+
+// newRPCProgressNotifier returns nil unless args ask for notifications, and
+// every method is a no-op on nil.
+func newRPCProgressNotifier(session *rpcSessionState, id any, args rpcNotifyArgs) *rpcProgressNotifier {
+	if !args.Notify || session == nil || session.events == nil {
+		return nil
+	}
+	every := args.NotifyBytes
+	if every == 0 {
+		every = rpcNotifyDefaultBytes
+	}
+	return &rpcProgressNotifier{session: session, id: id, every: every, start: time.Now(), next: every}
+}
+
+func (n *rpcProgressNotifier) emit(data map[string]any) {
+	data["elapsedMs"] = time.Since(n.start).Milliseconds()
+	event := rpcEvent{ID: n.id, Event: "progress", Data: data}
+
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	if last := len(n.pending) - 1; last >= 0 && n.pending[last].Data["phase"] == "body" && data["phase"] == "body" {
+		n.pending[last] = event
+	} else {
+		n.pending = append(n.pending, event)
+	}
+	n.drain(false)
+}
+
+// flush sends every pending frame, waiting for the writer if needed. Call it
+// before sending the response so no event follows it.
+func (n *rpcProgressNotifier) flush() {
+	if n == nil {
+		return
+	}
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.drain(true)
+}
+
+// drain sends pending frames in order. Unless wait is set it stops at the
+// first frame the writer cannot take now. The caller holds n.mu.
+func (n *rpcProgressNotifier) drain(wait bool) {
+	for len(n.pending) > 0 {
+		if wait {
+			n.session.events <- n.pending[0]
+		} else {
+			select {
+			case n.session.events <- n.pending[0]:
+			default:
+				return
+			}
+		}
+		n.pending = n.pending[1:]
+	}
+}
+
+// trace attaches connection and first-byte hooks to ctx.
+func (n *rpcProgressNotifier) trace(ctx context.Context) context.Context {
+	if n == nil {
+		return ctx
+	}
+	return httptrace.WithClientTrace(ctx, &httptrace.ClientTrace{
+		GotConn: func(info httptrace.GotConnInfo) {
+			n.emit(map[string]any{"phase": "connected", "reused": info.Reused})
+		},
+		GotFirstResponseByte: func() {
+			n.emit(map[string]any{"phase": "firstByte"})
+		},
+	})
+}
+
+// writer counts body bytes written to w and reports each notifyBytes step.
+func (n *rpcProgressNotifier) writer(w io.Writer) io.Writer {
+	if n == nil {
+		return w
+	}
+	return &rpcProgressWriter{w: w, notifier: n}
+}
+
+func (n *rpcProgressNotifier) add(written int) {
+	n.mu.Lock()
+	n.done += int64(written)
+	done := n.done
+	report := done >= n.next
+	if report {
+		n.next = (done/n.every + 1) * n.every
+	}
+	n.mu.Unlock()
+	if report {
+		n.emit(map[string]any{"phase": "body", "bytes": done})
+	}
+}
+
+type rpcProgressWriter struct {
+	w        io.Writer
+	notifier *rpcProgressNotifier
+}
+
+func (p *rpcProgressWriter) Write(b []byte) (int, error) {
+	written, err := p.w.Write(b)
+	p.notifier.add(written)
+	return written, err
+}
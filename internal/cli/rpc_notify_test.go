@@ -0,0 +1,135 @@
+package cli
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/alex-mccollum/igw-cli/internal/gateway"
+)
+
+func TestRPCCallNotifyEmitsProgressBeforeResponse(t *testing.T) {
+	t.Parallel()
+
+	chunk := strings.Repeat("x", 1000)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		for i := 0; i < 3; i++ {
+			_, _ = w.Write([]byte(chunk))
+			w.(http.Flusher).Flush()
+		}
+	}))
+	defer server.Close()
+
+	out := new(bytes.Buffer)
+	c := newAdminWrapperTestCLI(nil)
+	c.Out = out
+	c.In = strings.NewReader(strings.Join([]string{
+		`{"id":"c1","op":"call","args":{"path":"/data/api/v1/backup","notify":true,"notifyBytes":1000}}`,
+		`{"id":"c2","op":"call","args":{"path":"/data/api/v1/backup"}}`,
+		`{"id":"c3","op":"call","args":{"path":"/data/api/v1/backup","notifyBytes":10}}`,
+		`{"id":"h1","op":"hello"}`,
+	}, "\n"))
+	if err := c.Execute([]string{"rpc", "--gateway-url", server.URL, "--api-key", "secret"}); err != nil {
+		t.Fatalf("rpc failed: %v", err)
+	}
+	frames := decodeRPCResponses(t, out.String())
+
+	var phases []string
+	var lastBytes float64
+	sawResponse := false
+	for _, frame := range frames {
+		if fmt.Sprint(frame["id"]) != "c1" {
+			if _, isEvent := frame["event"]; isEvent {
+				t.Fatalf("only notify requests may emit events, got %#v", frame)
+			}
+			continue
+		}
+		if _, isEvent := frame["event"]; !isEvent {
+			sawResponse = true
+			continue
+		}
+		if sawResponse {
+			t.Fatalf("event after the terminal response: %#v", frame)
+		}
+		if _, hasOK := frame["ok"]; hasOK || frame["event"] != "progress" {
+			t.Fatalf("events must carry event and omit ok: %#v", frame)
+		}
+		data := frame["data"].(map[string]any)
+		phase := fmt.Sprint(data["phase"])
+		if len(phases) == 0 || phases[len(phases)-1] != phase {
+			phases = append(phases, phase)
+		}
+		if phase == "body" {
+			lastBytes = data["bytes"].(float64)
+		}
+	}
+	if strings.Join(phases, ",") != "connected,firstByte,body" || lastBytes != 3000 {
+		t.Fatalf("expected connected, firstByte, then body up to 3000 bytes, got %v (last=%v)", phases, lastBytes)
+	}
+
+	resp := responseByID(t, frames, "c1")
+	if body := resp["data"].(map[string]any)["response"].(map[string]any)["body"]; body != strings.Repeat(chunk, 3) {
+		t.Fatalf("expected the full body in the response, got %d chars", len(fmt.Sprint(body)))
+	}
+	if invalid := responseByID(t, frames, "c3"); invalid["code"] != float64(2) {
+		t.Fatalf("expected notifyBytes without notify to be a usage error: %#v", invalid)
+	}
+	hello := responseByID(t, frames, "h1")["data"].(map[string]any)
+	if hello["notifySchema"] != float64(rpcNotifySchemaVersion) || hello["features"].(map[string]any)["progress"] != true {
+		t.Fatalf("expected hello to advertise progress events: %#v", hello)
+	}
+}
+
+func TestRPCProgressNotifierDoesNotWaitForWriter(t *testing.T) {
+	t.Parallel()
+
+	events := make(chan any)
+	session := newRPCSessionState()
+	session.events = events
+	notifier := newRPCProgressNotifier(session, "c1", rpcNotifyArgs{Notify: true, NotifyBytes: 10})
+
+	// Nobody reads events yet, so these must queue instead of blocking.
+	notifier.emit(map[string]any{"phase": "connected"})
+	for i := 0; i < 5; i++ {
+		notifier.add(10)
+	}
+
+	done := make(chan []any)
+	go func() {
+		var got []any
+		for event := range events {
+			got = append(got, event)
+		}
+		done <- got
+	}()
+	notifier.flush()
+	close(events)
+
+	got := <-done
+	if len(got) != 2 {
+		t.Fatalf("expected connected plus one coalesced body frame, got %#v", got)
+	}
+	last := got[1].(rpcEvent)
+	if last.Data["phase"] != "body" || last.Data["bytes"] != int64(50) {
+		t.Fatalf("expected the newest body total, got %#v", last)
+	}
+}
+
+func TestExecuteCallCoreRejectsStreamedBodyMatch(t *testing.T) {
+	t.Parallel()
+
+	client := &gateway.Client{BaseURL: mockGatewayURL, HTTP: newStaticHTTPClient(http.StatusOK, `{}`)}
+	_, _, _, err := executeCallCore(client, callExecutionInput{
+		Method:      http.MethodGet,
+		Path:        "/data/api/v1/gateway-info",
+		Timeout:     time.Second,
+		Stream:      io.Discard,
+		RetryOnBody: func([]byte) bool { return true },
+	})
+	requireUsageExitCode(t, err)
+}
@@ -10,8 +10,13 @@ import (
 
 const (
 	rpcProtocolName    = "igw-rpc-v1"
-	rpcProtocolSemver  = "1.0.0"
+	rpcProtocolSemver  = "1.3.0"
 	rpcProtocolMinHost = "1.0.0"
+	// rpcNotifySchemaVersion versions the progress event frames.
+	rpcNotifySchemaVersion = 1
+
+	rpcFramingNDJSON         = "ndjson"
+	rpcFramingLengthPrefixed = "length-prefixed"
 )
 
 type rpcOperationHandler func(*CLI, rpcRequest, wrapperCommon, string, *rpcSessionState) rpcResponse
@@ -27,8 +32,8 @@ func rpcOperationDefinitions() []rpcOperationDef {
 		{
 			Name:    "hello",
 			Feature: "hello",
-			Handler: func(c *CLI, req rpcRequest, _ wrapperCommon, _ string, _ *rpcSessionState) rpcResponse {
-				return c.handleRPCHello(req)
+			Handler: func(c *CLI, req rpcRequest, _ wrapperCommon, _ string, session *rpcSessionState) rpcResponse {
+				return c.handleRPCHello(req, session)
 			},
 		},
 		{
@@ -45,6 +50,20 @@ func rpcOperationDefinitions() []rpcOperationDef {
 				return c.handleRPCCall(req, common, specFile, session)
 			},
 		},
+		{
+			Name:    "batch",
+			Feature: "batch",
+			Handler: func(c *CLI, req rpcRequest, common wrapperCommon, specFile string, session *rpcSessionState) rpcResponse {
+				return c.handleRPCBatch(req, common, specFile, session)
+			},
+		},
+		{
+			Name:    "download",
+			Feature: "download",
+			Handler: func(c *CLI, req rpcRequest, common wrapperCommon, specFile string, session *rpcSessionState) rpcResponse {
+				return c.handleRPCDownload(req, common, specFile, session)
+			},
+		},
 		{
 			Name:    "cancel",
 			Feature: "cancel",
@@ -91,10 +110,13 @@ func rpcOperationNames() []string {
 
 func rpcFeatureFlags() map[string]bool {
 	features := map[string]bool{
-		"rpcWorkers":       true,
-		"rpcQueueSize":     true,
-		"sharedCallCoreV1": true,
-		"callStatsV1":      true,
+		"rpcWorkers":            true,
+		"rpcQueueSize":          true,
+		"sharedCallCoreV1":      true,
+		"callStatsV1":           true,
+		"callRetryOverrides":    true,
+		"progress":              true,
+		"framingLengthPrefixed": true,
 	}
 	for _, op := range rpcOperationDefinitions() {
 		feature := strings.TrimSpace(op.Feature)
@@ -130,7 +152,7 @@ func (c *CLI) handleRPCRequest(req rpcRequest, common wrapperCommon, specFile st
 	return op.Handler(c, req, common, specFile, session)
 }
 
-func (c *CLI) handleRPCHello(req rpcRequest) rpcResponse {
+func (c *CLI) handleRPCHello(req rpcRequest, session *rpcSessionState) rpcResponse {
 	return rpcResponse{
 		ID:   req.ID,
 		OK:   true,
@@ -142,6 +164,8 @@ func (c *CLI) handleRPCHello(req rpcRequest) rpcResponse {
 			"version":        buildinfo.Long(),
 			"features":       rpcFeatureFlags(),
 			"ops":            rpcOperationNames(),
+			"framing":        session.outputFraming(),
+			"notifySchema":   rpcNotifySchemaVersion,
 		},
 	}
 }
@@ -3,19 +3,42 @@ package cli
 import (
 	"context"
 	"fmt"
+	"net/http"
 	"strings"
 	"sync"
+
+	"github.com/alex-mccollum/igw-cli/internal/gateway"
 )
 
 type rpcSessionState struct {
 	mu       sync.Mutex
 	inFlight map[string]context.CancelFunc
+	framing  string
+	summary  *runSummary
+	// jar is shared by every call when rpc runs with --session.
+	jar http.CookieJar
+	// breaker skips calls after --fail-fast-after network failures.
+	breaker *batchCircuitBreaker
+	// limiter paces every call across workers (--rate).
+	limiter *gateway.RateLimiter
+	// workers is the --workers pool size; batch ops fan out to it.
+	workers int
+	// events receives progress frames ahead of a request's response.
+	events chan<- any
 }
 
 func newRPCSessionState() *rpcSessionState {
 	return &rpcSessionState{
 		inFlight: make(map[string]context.CancelFunc),
+		framing:  rpcFramingNDJSON,
+	}
+}
+
+func (s *rpcSessionState) outputFraming() string {
+	if s == nil || s.framing == "" {
+		return rpcFramingNDJSON
 	}
+	return s.framing
 }
 
 func rpcRequestIDKey(id any) (string, bool) {
@@ -2,12 +2,17 @@ package cli
 
 import (
 	"bufio"
+	"bytes"
+	"encoding/binary"
 	"encoding/json"
 	"fmt"
+	"io"
+	"math"
 	"strings"
 	"sync"
 	"time"
 
+	"github.com/alex-mccollum/igw-cli/internal/gateway"
 	"github.com/alex-mccollum/igw-cli/internal/igwerr"
 )
 
@@ -22,6 +27,11 @@ type rpcSessionRunner struct {
 	specFile  string
 	workers   int
 	queueSize int
+	framing   string
+	summary   bool
+	session   bool
+	failFast  int
+	rate      float64
 }
 
 func (r *rpcSessionRunner) run() error {
@@ -29,13 +39,30 @@ func (r *rpcSessionRunner) run() error {
 	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
 
 	workQueue := make(chan rpcWorkItem, r.queueSize)
-	results := make(chan rpcResponse, r.queueSize)
+	// results carries rpcResponse and rpcEvent frames; one writer encodes
+	// both so frames never interleave.
+	results := make(chan any, r.queueSize)
 	session := newRPCSessionState()
+	if r.framing != "" {
+		session.framing = r.framing
+	}
+	if r.session {
+		session.jar = gateway.NewSessionJar()
+	}
+	if r.summary {
+		session.summary = newRunSummary(r.cli.clock())
+		defer func() { session.summary.write(r.cli.Err, r.cli.clock()) }()
+	}
+	session.breaker = newBatchCircuitBreaker(r.failFast)
+	session.limiter = gateway.NewRateLimiter(r.rate)
+	session.workers = r.workers
+	session.events = results
+	defer session.breaker.writeSummary(r.cli.Err)
 
 	var workerWG sync.WaitGroup
 	r.startWorkers(session, workQueue, results, &workerWG)
 
-	writeErrCh := r.startResponseWriter(results)
+	writeErrCh := r.startResponseWriter(results, session.outputFraming())
 	scanErr, stopRead := r.scanRequests(scanner, workQueue, results)
 
 	close(workQueue)
@@ -54,7 +81,7 @@ func (r *rpcSessionRunner) run() error {
 	return nil
 }
 
-func (r *rpcSessionRunner) startWorkers(session *rpcSessionState, workQueue <-chan rpcWorkItem, results chan<- rpcResponse, workerWG *sync.WaitGroup) {
+func (r *rpcSessionRunner) startWorkers(session *rpcSessionState, workQueue <-chan rpcWorkItem, results chan<- any, workerWG *sync.WaitGroup) {
 	for worker := 0; worker < r.workers; worker++ {
 		workerWG.Add(1)
 		go func() {
@@ -63,7 +90,7 @@ func (r *rpcSessionRunner) startWorkers(session *rpcSessionState, workQueue <-ch
 				queueWaitMs := time.Since(work.enqueuedAt).Milliseconds()
 				queueDepth := len(workQueue)
 				resp := r.cli.handleRPCRequest(work.req, r.common, r.specFile, session)
-				if strings.EqualFold(strings.TrimSpace(work.req.Op), "call") {
+				if op := strings.TrimSpace(work.req.Op); strings.EqualFold(op, "call") || strings.EqualFold(op, "download") {
 					resp = withRPCCallQueueStats(resp, queueWaitMs, queueDepth)
 				}
 				results <- resp
@@ -72,17 +99,24 @@ func (r *rpcSessionRunner) startWorkers(session *rpcSessionState, workQueue <-ch
 	}
 }
 
-func (r *rpcSessionRunner) startResponseWriter(results <-chan rpcResponse) <-chan error {
+func (r *rpcSessionRunner) startResponseWriter(results <-chan any, framing string) <-chan error {
 	writeErrCh := make(chan error, 1)
 	go func() {
 		enc := json.NewEncoder(r.cli.Out)
 		enc.SetEscapeHTML(false)
 		var writeErr error
 		for result := range results {
-			if writeErr == nil {
-				if err := enc.Encode(result); err != nil {
-					writeErr = igwerr.NewTransportError(err)
-				}
+			if writeErr != nil {
+				continue
+			}
+			var err error
+			if framing == rpcFramingLengthPrefixed {
+				err = writeLengthPrefixedFrame(r.cli.Out, result)
+			} else {
+				err = enc.Encode(result)
+			}
+			if err != nil {
+				writeErr = igwerr.NewTransportError(err)
 			}
 		}
 		writeErrCh <- writeErr
@@ -90,7 +124,28 @@ func (r *rpcSessionRunner) startResponseWriter(results <-chan rpcResponse) <-cha
 	return writeErrCh
 }
 
-func (r *rpcSessionRunner) scanRequests(scanner *bufio.Scanner, workQueue chan<- rpcWorkItem, results chan<- rpcResponse) (error, bool) {
+// writeLengthPrefixedFrame writes one frame as a 4-byte big-endian payload
+// length followed by the compact JSON payload, with no trailing newline.
+func writeLengthPrefixedFrame(w io.Writer, message any) error {
+	var payload bytes.Buffer
+	enc := json.NewEncoder(&payload)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(message); err != nil {
+		return err
+	}
+	body := bytes.TrimSuffix(payload.Bytes(), []byte("\n"))
+	if uint64(len(body)) > math.MaxUint32 {
+		return fmt.Errorf("rpc frame too large: %d bytes", len(body))
+	}
+
+	frame := make([]byte, 4+len(body))
+	binary.BigEndian.PutUint32(frame[:4], uint32(len(body)))
+	copy(frame[4:], body)
+	_, err := w.Write(frame)
+	return err
+}
+
+func (r *rpcSessionRunner) scanRequests(scanner *bufio.Scanner, workQueue chan<- rpcWorkItem, results chan<- any) (error, bool) {
 	stopRead := false
 
 	for scanner.Scan() {
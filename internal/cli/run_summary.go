@@ -0,0 +1,57 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"sync/atomic"
+	"time"
+
+	"github.com/alex-mccollum/igw-cli/internal/gateway"
+	"github.com/alex-mccollum/igw-cli/internal/igwerr"
+)
+
+// runSummary aggregates every request issued during one command for
+// --summary. It is safe for concurrent use, and a nil summary ignores
+// records so callers can thread it unconditionally.
+type runSummary struct {
+	started  time.Time
+	requests atomic.Int64
+	failures atomic.Int64
+	bytes    atomic.Int64
+}
+
+func newRunSummary(started time.Time) *runSummary {
+	return &runSummary{started: started}
+}
+
+// record counts one request that reached the client. Response bytes come
+// from the body on success and from the error body on HTTP failures.
+func (s *runSummary) record(resp *gateway.CallResponse, err error) {
+	if s == nil {
+		return
+	}
+	s.requests.Add(1)
+	if resp != nil {
+		s.bytes.Add(resp.BodyBytes)
+	}
+	if err == nil {
+		return
+	}
+	s.failures.Add(1)
+	var statusErr *igwerr.StatusError
+	if resp == nil && errors.As(err, &statusErr) {
+		s.bytes.Add(int64(len(statusErr.Body)))
+	}
+}
+
+func (s *runSummary) write(w io.Writer, now time.Time) {
+	if s == nil || w == nil {
+		return
+	}
+	fmt.Fprintf(
+		w,
+		"requests=%d failures=%d bytes=%d elapsed=%dms\n",
+		s.requests.Load(), s.failures.Load(), s.bytes.Load(), now.Sub(s.started).Milliseconds(),
+	)
+}
@@ -0,0 +1,108 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/alex-mccollum/igw-cli/internal/config"
+)
+
+func TestCallBatchSummaryReportsRequestsAndFailures(t *testing.T) {
+	t.Parallel()
+
+	client := newMockHTTPClient(func(r *http.Request) (*http.Response, error) {
+		if r.URL.Path == "/missing" {
+			return mockHTTPResponse(http.StatusNotFound, `{"error":"missing"}`, nil), nil
+		}
+		return mockHTTPResponse(http.StatusOK, `{"ok":true}`, nil), nil
+	})
+
+	batchFile := filepath.Join(t.TempDir(), "batch.ndjson")
+	content := strings.Join([]string{
+		`{"id":"a","method":"GET","path":"/data/api/v1/gateway-info"}`,
+		`{"id":"b","method":"GET","path":"/missing"}`,
+		`{"id":"c","method":"GET","path":"/data/api/v1/gateway-info"}`,
+		`{"id":"d","method":"POST","path":"/data/api/v1/scan/projects"}`,
+	}, "\n")
+	if err := os.WriteFile(batchFile, []byte(content), 0o600); err != nil {
+		t.Fatalf("write batch file: %v", err)
+	}
+
+	for _, parallel := range []string{"1", "3"} {
+		var out, errOut bytes.Buffer
+		fixed := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+		c := &CLI{
+			In:     strings.NewReader(""),
+			Out:    &out,
+			Err:    &errOut,
+			Getenv: func(string) string { return "" },
+			ReadConfig: func() (config.File, error) {
+				return config.File{}, nil
+			},
+			HTTPClient: client,
+			now:        func() time.Time { return fixed },
+		}
+
+		_ = c.Execute([]string{
+			"call",
+			"--gateway-url", mockGatewayURL,
+			"--api-key", "secret",
+			"--batch", "@" + batchFile,
+			"--batch-output", "json",
+			"--parallel", parallel,
+			"--summary",
+		})
+
+		// The POST item lacks --yes, so it never reaches the gateway.
+		want := "requests=3 failures=1 bytes=41 elapsed=0ms\n"
+		if errOut.String() != want {
+			t.Fatalf("parallel=%s: unexpected summary %q, want %q", parallel, errOut.String(), want)
+		}
+
+		var payload []map[string]any
+		if err := json.Unmarshal(out.Bytes(), &payload); err != nil {
+			t.Fatalf("parallel=%s: stdout is not clean JSON: %v\n%s", parallel, err, out.String())
+		}
+		if len(payload) != 4 {
+			t.Fatalf("parallel=%s: expected 4 results, got %d", parallel, len(payload))
+		}
+	}
+}
+
+func TestCallSummaryOffByDefault(t *testing.T) {
+	t.Parallel()
+
+	client := newMockHTTPClient(func(r *http.Request) (*http.Response, error) {
+		return mockHTTPResponse(http.StatusOK, `{"ok":true}`, nil), nil
+	})
+
+	var errOut bytes.Buffer
+	c := &CLI{
+		In:     strings.NewReader(""),
+		Out:    new(bytes.Buffer),
+		Err:    &errOut,
+		Getenv: func(string) string { return "" },
+		ReadConfig: func() (config.File, error) {
+			return config.File{}, nil
+		},
+		HTTPClient: client,
+	}
+	if err := c.Execute([]string{
+		"call",
+		"--gateway-url", mockGatewayURL,
+		"--api-key", "secret",
+		"--path", "/data/api/v1/gateway-info",
+		"--repeat", "2",
+	}); err != nil {
+		t.Fatalf("call failed: %v", err)
+	}
+	if strings.Contains(errOut.String(), "requests=") {
+		t.Fatalf("summary should require --summary, got %q", errOut.String())
+	}
+}
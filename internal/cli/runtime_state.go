@@ -11,6 +11,7 @@ import (
 
 	"github.com/alex-mccollum/igw-cli/internal/apidocs"
 	"github.com/alex-mccollum/igw-cli/internal/config"
+	"github.com/alex-mccollum/igw-cli/internal/gateway"
 	"github.com/alex-mccollum/igw-cli/internal/igwerr"
 )
 
@@ -40,12 +41,20 @@ type runtimeState struct {
 	openAPIOps     map[string]cachedOpenAPIOperations
 
 	httpClient *http.Client
+	// tlsClients holds one client per distinct TLS setting.
+	tlsClients        map[gateway.TLSOptions]*http.Client
+	insecureTLSWarned bool
+	// workers is the largest worker count reserved by reserveConnections.
+	workers int
+	// configPassphrase is the --passphrase-stdin value, when given.
+	configPassphrase string
 }
 
 func newRuntimeState() *runtimeState {
 	return &runtimeState{
 		resolvedConfig: make(map[runtimeConfigKey]cachedRuntimeConfig),
 		openAPIOps:     make(map[string]cachedOpenAPIOperations),
+		tlsClients:     make(map[gateway.TLSOptions]*http.Client),
 	}
 }
 
@@ -89,9 +98,10 @@ func (c *CLI) runtimeHTTPClient() *http.Client {
 	return c.runtime.httpClient
 }
 
+// newRuntimeTransport builds a transport; the caller must hold c.runtime.mu.
 func (c *CLI) newRuntimeTransport() *http.Transport {
-	return &http.Transport{
-		Proxy:                 http.ProxyFromEnvironment,
+	transport := &http.Transport{
+		Proxy:                 gateway.ProxyFromContext,
 		ForceAttemptHTTP2:     true,
 		MaxIdleConns:          envIntWithDefault(c.Getenv, "IGW_MAX_IDLE_CONNS", 64),
 		MaxIdleConnsPerHost:   envIntWithDefault(c.Getenv, "IGW_MAX_IDLE_CONNS_PER_HOST", 16),
@@ -101,6 +111,46 @@ func (c *CLI) newRuntimeTransport() *http.Transport {
 		ExpectContinueTimeout: envDurationWithDefault(c.Getenv, "IGW_EXPECT_CONTINUE_TIMEOUT", 1*time.Second),
 		ResponseHeaderTimeout: envDurationWithDefault(c.Getenv, "IGW_RESPONSE_HEADER_TIMEOUT", 0),
 	}
+	sizeTransportPool(transport, c.runtime.workers)
+	return transport
+}
+
+// reserveConnections sizes the shared transports so workers concurrent
+// requests can each keep an idle keep-alive connection to one gateway
+// instead of redialing. Call it before the workers start.
+func (c *CLI) reserveConnections(workers int) {
+	if c.runtime == nil {
+		c.runtime = newRuntimeState()
+	}
+
+	c.runtime.mu.Lock()
+	defer c.runtime.mu.Unlock()
+	if workers <= c.runtime.workers {
+		return
+	}
+	c.runtime.workers = workers
+	if c.runtime.httpClient != nil {
+		if transport, ok := c.runtime.httpClient.Transport.(*http.Transport); ok {
+			sizeTransportPool(transport, workers)
+		}
+	}
+	for _, client := range c.runtime.tlsClients {
+		if transport, ok := client.Transport.(*http.Transport); ok {
+			sizeTransportPool(transport, workers)
+		}
+	}
+}
+
+func sizeTransportPool(transport *http.Transport, workers int) {
+	if transport.MaxIdleConnsPerHost < workers {
+		transport.MaxIdleConnsPerHost = workers
+	}
+	if transport.MaxIdleConns > 0 && transport.MaxIdleConns < workers {
+		transport.MaxIdleConns = workers
+	}
+	if transport.MaxConnsPerHost > 0 && transport.MaxConnsPerHost < workers {
+		transport.MaxConnsPerHost = workers
+	}
 }
 
 func envIntWithDefault(getenv func(string) string, key string, fallback int) int {
@@ -205,6 +255,10 @@ func (c *CLI) resolveRuntimeConfigCached(profile string, gatewayURL string, apiK
 	if err != nil {
 		return config.Effective{}, &igwerr.UsageError{Msg: err.Error()}
 	}
+	resolved.Token, err = cfg.DecryptEffectiveToken(resolved.Token, c.configPassphrase())
+	if err != nil {
+		return config.Effective{}, &configDecryptError{err: err}
+	}
 
 	c.runtime.mu.Lock()
 	c.runtime.resolvedConfig[key] = cachedRuntimeConfig{effective: resolved}
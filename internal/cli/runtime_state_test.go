@@ -0,0 +1,156 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/alex-mccollum/igw-cli/internal/config"
+)
+
+// newConnCountingGateway returns a gateway that counts accepted connections.
+func newConnCountingGateway(t *testing.T) (*httptest.Server, *atomic.Int64) {
+	t.Helper()
+	var conns atomic.Int64
+	srv := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`{"ok":true}`))
+	}))
+	srv.Config.ConnState = func(_ net.Conn, state http.ConnState) {
+		if state == http.StateNew {
+			conns.Add(1)
+		}
+	}
+	srv.Start()
+	t.Cleanup(srv.Close)
+	return srv, &conns
+}
+
+func connReusedCount(t *testing.T, records []map[string]any) int {
+	t.Helper()
+	reused := 0
+	for _, record := range records {
+		stats, _ := record["stats"].(map[string]any)
+		httpStats, ok := stats["http"].(map[string]any)
+		if !ok {
+			t.Fatalf("expected http stats in %v", record)
+		}
+		if httpStats["connReused"] == true {
+			reused++
+		}
+	}
+	return reused
+}
+
+func TestCallBatchReusesConnections(t *testing.T) {
+	t.Parallel()
+
+	srv, conns := newConnCountingGateway(t)
+	const items, parallel = 40, 4
+	var input strings.Builder
+	for i := 0; i < items; i++ {
+		fmt.Fprintf(&input, `{"id":"%d","method":"GET","path":"/data/api/v1/gateway-info"}`+"\n", i)
+	}
+	var out bytes.Buffer
+	c := &CLI{
+		In:         strings.NewReader(input.String()),
+		Out:        &out,
+		Err:        new(bytes.Buffer),
+		Getenv:     func(string) string { return "" },
+		ReadConfig: func() (config.File, error) { return config.File{}, nil },
+	}
+	err := c.Execute([]string{
+		"call", "--gateway-url", srv.URL, "--api-key", "secret",
+		"--batch", "-", "--batch-output", "ndjson", "--parallel", fmt.Sprint(parallel),
+	})
+	if err != nil {
+		t.Fatalf("batch: %v", err)
+	}
+
+	var records []map[string]any
+	decoder := json.NewDecoder(&out)
+	for decoder.More() {
+		var record map[string]any
+		if err := decoder.Decode(&record); err != nil {
+			t.Fatalf("decode result: %v", err)
+		}
+		records = append(records, record)
+	}
+	if len(records) != items {
+		t.Fatalf("expected %d results, got %d", items, len(records))
+	}
+	// A worker may race a connection being returned to the pool, so allow
+	// some slack over one connection per worker.
+	if got := conns.Load(); got > 2*parallel {
+		t.Fatalf("expected at most %d connections for %d items, got %d", 2*parallel, items, got)
+	}
+	if reused := connReusedCount(t, records); int64(reused) < int64(items)-conns.Load() {
+		t.Fatalf("expected at least %d reused connections, got %d", int64(items)-conns.Load(), reused)
+	}
+}
+
+func TestRPCReusesConnectionAcrossCalls(t *testing.T) {
+	t.Parallel()
+
+	srv, conns := newConnCountingGateway(t)
+	var out bytes.Buffer
+	c := &CLI{
+		In: strings.NewReader(strings.Join([]string{
+			`{"id":"c1","op":"call","args":{"method":"GET","path":"/data/api/v1/gateway-info"}}`,
+			`{"id":"c2","op":"call","args":{"method":"GET","path":"/data/api/v1/gateway-info"}}`,
+			`{"id":"c3","op":"call","args":{"method":"GET","path":"/data/api/v1/gateway-info"}}`,
+			`{"id":"s1","op":"shutdown"}`,
+		}, "\n")),
+		Out:        &out,
+		Err:        new(bytes.Buffer),
+		Getenv:     func(string) string { return "" },
+		ReadConfig: func() (config.File, error) { return config.File{}, nil },
+	}
+	if err := c.Execute([]string{"rpc", "--gateway-url", srv.URL, "--api-key", "secret"}); err != nil {
+		t.Fatalf("rpc: %v", err)
+	}
+
+	responses := decodeRPCResponses(t, out.String())
+	var records []map[string]any
+	for _, id := range []string{"c1", "c2", "c3"} {
+		data, _ := responseByID(t, responses, id)["data"].(map[string]any)
+		records = append(records, data)
+	}
+	if got := conns.Load(); got != 1 {
+		t.Fatalf("expected one connection for sequential rpc calls, got %d", got)
+	}
+	if reused := connReusedCount(t, records); reused != 2 {
+		t.Fatalf("expected the second and third calls to reuse the connection, got %d", reused)
+	}
+}
+
+func TestReserveConnectionsSizesTransports(t *testing.T) {
+	t.Parallel()
+
+	c := &CLI{Getenv: func(string) string { return "" }}
+	transport := c.runtimeHTTPClient().Transport.(*http.Transport)
+	if transport.MaxIdleConnsPerHost != 16 {
+		t.Fatalf("unexpected default idle pool %d", transport.MaxIdleConnsPerHost)
+	}
+
+	c.reserveConnections(100)
+	if transport.MaxIdleConnsPerHost != 100 || transport.MaxIdleConns != 100 || transport.MaxConnsPerHost != 100 {
+		t.Fatalf("existing transport not resized: %d/%d/%d", transport.MaxIdleConnsPerHost, transport.MaxIdleConns, transport.MaxConnsPerHost)
+	}
+	c.reserveConnections(8)
+	if transport.MaxIdleConnsPerHost != 100 {
+		t.Fatalf("a smaller reservation should not shrink the pool, got %d", transport.MaxIdleConnsPerHost)
+	}
+
+	c.runtime.mu.Lock()
+	fresh := c.newRuntimeTransport()
+	c.runtime.mu.Unlock()
+	if fresh.MaxIdleConnsPerHost != 100 {
+		t.Fatalf("new transports should use the reserved size, got %d", fresh.MaxIdleConnsPerHost)
+	}
+}
@@ -0,0 +1,97 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/alex-mccollum/igw-cli/internal/apidocs"
+	"github.com/alex-mccollum/igw-cli/internal/gateway"
+	"github.com/alex-mccollum/igw-cli/internal/igwerr"
+)
+
+const gatewayInfoPath = "/data/api/v1/gateway-info"
+
+// fetchGatewayVersion reads the platform version reported by gateway-info.
+func fetchGatewayVersion(ctx context.Context, client *gateway.Client, timeout time.Duration) (string, error) {
+	resp, err := client.Call(ctx, gateway.CallRequest{
+		Method:  http.MethodGet,
+		Path:    gatewayInfoPath,
+		Timeout: timeout,
+	})
+	if err != nil {
+		return "", err
+	}
+	return gatewayVersionFromInfo(resp.Body), nil
+}
+
+func gatewayVersionFromInfo(body []byte) string {
+	var info map[string]any
+	if err := json.Unmarshal(body, &info); err != nil {
+		return ""
+	}
+	for _, key := range []string{"version", "platformVersion", "gatewayVersion"} {
+		if value, ok := info[key].(string); ok && strings.TrimSpace(value) != "" {
+			return strings.TrimSpace(value)
+		}
+	}
+	return ""
+}
+
+// recordSpecSyncMeta stores the gateway version alongside a freshly synced
+// spec. An unknown version does not fail the sync; a failed write does.
+func recordSpecSyncMeta(ctx context.Context, client *gateway.Client, timeout time.Duration, specPath string, meta apidocs.SyncMeta) (string, error) {
+	version, err := fetchGatewayVersion(ctx, client, timeout)
+	if err != nil {
+		version = ""
+	}
+	meta.GatewayVersion = version
+	meta.SyncedAt = time.Now().UTC()
+	if err := apidocs.WriteSyncMeta(apidocs.SyncMetaPathForSpec(specPath), meta); err != nil {
+		return "", &igwerr.UsageError{Msg: fmt.Sprintf("save sync meta: %v", err)}
+	}
+	return version, nil
+}
+
+// checkSpecGatewayVersion compares the version recorded at sync time with
+// the live gateway, for every file of a --spec-file list, and fails on a
+// mismatch. It only runs under --strict-spec-version so plain --op calls do
+// not pay for an extra gateway-info request. The request runs under ctx, so
+// it counts against the call's --deadline.
+func (c *CLI) checkSpecGatewayVersion(ctx context.Context, client *gateway.Client, timeout time.Duration, specFile string) error {
+	type syncedSpec struct {
+		file    string
+		version string
+	}
+	var synced []syncedSpec
+	for _, file := range splitSpecFiles(specFile) {
+		resolvedSpecFile, _ := resolveSpecFile(file)
+		meta, err := apidocs.LoadSyncMeta(apidocs.SyncMetaPathForSpec(resolvedSpecFile))
+		if err != nil || strings.TrimSpace(meta.GatewayVersion) == "" {
+			return &igwerr.UsageError{Msg: fmt.Sprintf("--strict-spec-version: spec %q has no recorded gateway version (run igw api sync)", resolvedSpecFile)}
+		}
+		synced = append(synced, syncedSpec{file: resolvedSpecFile, version: meta.GatewayVersion})
+	}
+	if len(synced) == 0 {
+		return nil
+	}
+
+	live, err := fetchGatewayVersion(ctx, client, timeout)
+	if err != nil {
+		return err
+	}
+	if live == "" {
+		return &igwerr.UsageError{Msg: "--strict-spec-version: gateway-info did not report a gateway version"}
+	}
+
+	for _, spec := range synced {
+		if spec.version == live {
+			continue
+		}
+		return &igwerr.UsageError{Msg: fmt.Sprintf("spec %q was synced from gateway version %s but gateway reports %s (run igw api sync)", spec.file, spec.version, live)}
+	}
+	return nil
+}
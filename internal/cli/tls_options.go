@@ -0,0 +1,139 @@
+package cli
+
+import (
+	"flag"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/alex-mccollum/igw-cli/internal/config"
+	"github.com/alex-mccollum/igw-cli/internal/gateway"
+	"github.com/alex-mccollum/igw-cli/internal/igwerr"
+)
+
+// tlsFlags are the --ca-cert, --client-cert, --client-key, and
+// --insecure-skip-verify values.
+type tlsFlags struct {
+	caCert             string
+	clientCert         string
+	clientKey          string
+	insecureSkipVerify bool
+}
+
+func bindTLSFlags(fs *flag.FlagSet, flags *tlsFlags) {
+	fs.StringVar(&flags.caCert, "ca-cert", "", "PEM CA bundle trusted for https gateways, in addition to the system roots")
+	fs.StringVar(&flags.clientCert, "client-cert", "", "PEM client certificate for mutual TLS (requires --client-key)")
+	fs.StringVar(&flags.clientKey, "client-key", "", "PEM private key for --client-cert")
+	fs.BoolVar(&flags.insecureSkipVerify, "insecure-skip-verify", false, "Accept any https gateway certificate (unsafe)")
+}
+
+func (f tlsFlags) set() bool {
+	return f != tlsFlags{}
+}
+
+func (f tlsFlags) args() []string {
+	var args []string
+	if strings.TrimSpace(f.caCert) != "" {
+		args = append(args, "--ca-cert", strings.TrimSpace(f.caCert))
+	}
+	if strings.TrimSpace(f.clientCert) != "" {
+		args = append(args, "--client-cert", strings.TrimSpace(f.clientCert))
+	}
+	if strings.TrimSpace(f.clientKey) != "" {
+		args = append(args, "--client-key", strings.TrimSpace(f.clientKey))
+	}
+	if f.insecureSkipVerify {
+		args = append(args, "--insecure-skip-verify")
+	}
+	return args
+}
+
+// tlsOptions lays the flags over the profile's TLS settings. A client
+// certificate flag replaces the profile's certificate and key together.
+func (f tlsFlags) tlsOptions(resolved config.Effective) (gateway.TLSOptions, error) {
+	if f.set() && strings.TrimSpace(resolved.GatewayURL) != "" && !httpsGatewayURLs(resolved.GatewayURL) {
+		return gateway.TLSOptions{}, &igwerr.UsageError{Msg: "--ca-cert, --client-cert, --client-key, and --insecure-skip-verify require an https gateway URL"}
+	}
+
+	opts := gateway.TLSOptions{
+		CACert:             resolved.CACert,
+		ClientCert:         resolved.ClientCert,
+		ClientKey:          resolved.ClientKey,
+		InsecureSkipVerify: resolved.InsecureSkipVerify || f.insecureSkipVerify,
+	}
+	if v := strings.TrimSpace(f.caCert); v != "" {
+		opts.CACert = v
+	}
+	if strings.TrimSpace(f.clientCert) != "" || strings.TrimSpace(f.clientKey) != "" {
+		opts.ClientCert = strings.TrimSpace(f.clientCert)
+		opts.ClientKey = strings.TrimSpace(f.clientKey)
+	}
+	if (opts.ClientCert == "") != (opts.ClientKey == "") {
+		return gateway.TLSOptions{}, &igwerr.UsageError{Msg: "--client-cert and --client-key must be used together"}
+	}
+	return opts, nil
+}
+
+// httpsGatewayURLs reports whether every URL in a comma-separated gateway
+// list uses https.
+func httpsGatewayURLs(raw string) bool {
+	for _, part := range strings.Split(raw, ",") {
+		parsed, err := url.Parse(strings.TrimSpace(part))
+		if err != nil || !strings.EqualFold(parsed.Scheme, "https") {
+			return false
+		}
+	}
+	return true
+}
+
+// gatewayHTTPClient returns the HTTP client for resolved's gateway. TLS
+// settings get their own transport, cached per distinct setting so
+// connection reuse still works across clients.
+func (c *CLI) gatewayHTTPClient(flags tlsFlags, resolved config.Effective) (*http.Client, error) {
+	opts, err := flags.tlsOptions(resolved)
+	if err != nil {
+		return nil, err
+	}
+	if opts.IsZero() {
+		return c.runtimeHTTPClient(), nil
+	}
+	tlsConfig, err := opts.Config()
+	if err != nil {
+		return nil, &igwerr.UsageError{Msg: err.Error()}
+	}
+	if opts.InsecureSkipVerify {
+		c.warnInsecureTLS()
+	}
+	if c.HTTPClient != nil {
+		return c.HTTPClient, nil
+	}
+
+	if c.runtime == nil {
+		c.runtime = newRuntimeState()
+	}
+	c.runtime.mu.Lock()
+	defer c.runtime.mu.Unlock()
+	if client, ok := c.runtime.tlsClients[opts]; ok {
+		return client, nil
+	}
+	transport := c.newRuntimeTransport()
+	transport.TLSClientConfig = tlsConfig
+	client := &http.Client{Transport: transport}
+	c.runtime.tlsClients[opts] = client
+	return client, nil
+}
+
+// warnInsecureTLS prints the --insecure-skip-verify warning once per run.
+func (c *CLI) warnInsecureTLS() {
+	if c.runtime == nil {
+		c.runtime = newRuntimeState()
+	}
+	c.runtime.mu.Lock()
+	warned := c.runtime.insecureTLSWarned
+	c.runtime.insecureTLSWarned = true
+	c.runtime.mu.Unlock()
+	if !warned {
+		fmt.Fprintln(c.Err, "warning: TLS certificate verification is disabled (--insecure-skip-verify)")
+	}
+}
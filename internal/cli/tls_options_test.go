@@ -0,0 +1,106 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/alex-mccollum/igw-cli/internal/config"
+	"github.com/alex-mccollum/igw-cli/internal/igwerr"
+)
+
+func newTLSTestGateway(t *testing.T) (*httptest.Server, string) {
+	t.Helper()
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`{"name":"gateway"}`))
+	}))
+	t.Cleanup(srv.Close)
+	caPath := filepath.Join(t.TempDir(), "gateway-ca.pem")
+	if err := os.WriteFile(caPath, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: srv.Certificate().Raw}), 0o600); err != nil {
+		t.Fatalf("write CA: %v", err)
+	}
+	return srv, caPath
+}
+
+func TestCallTLSOptions(t *testing.T) {
+	t.Parallel()
+
+	srv, caPath := newTLSTestGateway(t)
+	var out, errOut bytes.Buffer
+	c := newAdminWrapperTestCLI(nil)
+	c.Out = &out
+	c.Err = &errOut
+	c.ReadConfig = func() (config.File, error) {
+		return config.File{Profiles: map[string]config.Profile{
+			"self-signed": {GatewayURL: srv.URL, Token: "secret", CACert: caPath},
+		}}, nil
+	}
+	base := []string{"call", "--gateway-url", srv.URL, "--api-key", "secret", "--path", "/data/api/v1/gateway-info"}
+
+	err := c.Execute(base)
+	if code := igwerr.ExitCode(err); code != 7 {
+		t.Fatalf("expected a network error for an untrusted certificate, got %d (%v)", code, err)
+	}
+	if err := c.Execute(append(base, "--ca-cert", caPath)); err != nil {
+		t.Fatalf("--ca-cert: %v", err)
+	}
+	if err := c.Execute([]string{"gateway", "info", "--profile", "self-signed"}); err != nil {
+		t.Fatalf("profile caCert: %v", err)
+	}
+
+	if err := c.Execute(append(base, "--insecure-skip-verify")); err != nil {
+		t.Fatalf("--insecure-skip-verify: %v", err)
+	}
+	if err := c.Execute(append(base, "--insecure-skip-verify")); err != nil {
+		t.Fatalf("--insecure-skip-verify again: %v", err)
+	}
+	if got := strings.Count(errOut.String(), "TLS certificate verification is disabled"); got != 1 {
+		t.Fatalf("expected one insecure warning per run, got %d in %q", got, errOut.String())
+	}
+}
+
+func TestTLSFlagsUsage(t *testing.T) {
+	t.Parallel()
+
+	_, caPath := newTLSTestGateway(t)
+	c := newAdminWrapperTestCLI(nil)
+	httpBase := []string{"call", "--gateway-url", "http://127.0.0.1:8088", "--api-key", "secret", "--path", "/x"}
+	httpsBase := []string{"call", "--gateway-url", "https://127.0.0.1:8043", "--api-key", "secret", "--path", "/x"}
+
+	requireUsageExitCode(t, c.Execute(append(httpBase, "--ca-cert", caPath)))
+	requireUsageExitCode(t, c.Execute(append(httpBase, "--insecure-skip-verify")))
+	requireUsageExitCode(t, c.Execute(append(httpsBase, "--client-cert", caPath)))
+	requireUsageExitCode(t, c.Execute(append(httpsBase, "--ca-cert", filepath.Join(t.TempDir(), "missing.pem"))))
+	requireUsageExitCode(t, c.Execute([]string{"doctor", "--gateway-url", "http://127.0.0.1:8088", "--api-key", "secret", "--insecure-skip-verify"}))
+}
+
+func TestDoctorTLSHandshakeReportsCertificate(t *testing.T) {
+	t.Parallel()
+
+	srv, caPath := newTLSTestGateway(t)
+	var out bytes.Buffer
+	c := newAdminWrapperTestCLI(nil)
+	c.Out = &out
+	base := []string{"doctor", "--gateway-url", srv.URL, "--api-key", "secret", "--timeout", "2s"}
+
+	if err := c.Execute(append(base, "--ca-cert", caPath)); err != nil {
+		t.Fatalf("doctor: %v\n%s", err, out.String())
+	}
+	if !strings.Contains(out.String(), "ok\ttls_handshake\tsubject=O=Acme Co issuer=O=Acme Co days_until_expiry=") {
+		t.Fatalf("unexpected doctor output %q", out.String())
+	}
+
+	out.Reset()
+	err := c.Execute(base)
+	if code := igwerr.ExitCode(err); code != 7 {
+		t.Fatalf("expected a network error without the CA, got %d (%v)", code, err)
+	}
+	if !strings.Contains(out.String(), "tls_handshake") || !strings.Contains(out.String(), "--ca-cert") {
+		t.Fatalf("expected a failed tls_handshake check with a hint, got %q", out.String())
+	}
+}
@@ -0,0 +1,170 @@
+package cli
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/alex-mccollum/igw-cli/internal/config"
+	"github.com/alex-mccollum/igw-cli/internal/gateway"
+)
+
+// verboseBodyBytes is how much of each response body --verbose=2 prints.
+const verboseBodyBytes = 512
+
+// verboseLevel is --verbose / -v: a bare flag means 1, --verbose=2 also
+// dumps response headers and the start of the body.
+type verboseLevel int
+
+func (v *verboseLevel) String() string {
+	if v == nil {
+		return "0"
+	}
+	return strconv.Itoa(int(*v))
+}
+
+func (v *verboseLevel) Set(value string) error {
+	switch strings.TrimSpace(strings.ToLower(value)) {
+	case "true":
+		*v = 1
+		return nil
+	case "false":
+		*v = 0
+		return nil
+	}
+	n, err := strconv.Atoi(strings.TrimSpace(value))
+	if err != nil || n < 0 || n > 2 {
+		return fmt.Errorf("invalid verbose level %q (expected 0, 1, or 2)", value)
+	}
+	*v = verboseLevel(n)
+	return nil
+}
+
+func (v *verboseLevel) IsBoolFlag() bool { return true }
+
+func bindVerboseFlags(fs *flag.FlagSet, level *verboseLevel) {
+	fs.Var(level, "verbose", "Trace requests to stderr: 1 for request/response lines, 2 adds response headers and body")
+	fs.Var(level, "v", "Shorthand for --verbose")
+}
+
+func (v verboseLevel) args() []string {
+	if v <= 0 {
+		return nil
+	}
+	return []string{"--verbose=" + strconv.Itoa(int(v))}
+}
+
+// verboseTracer writes --verbose traces to stderr. Sensitive headers are
+// masked and the token is masked wherever else it appears. A nil
+// *verboseTracer traces nothing, so commands can thread it unconditionally.
+type verboseTracer struct {
+	mu    sync.Mutex
+	w     io.Writer
+	level verboseLevel
+	token string
+}
+
+func (c *CLI) newVerboseTracer(level verboseLevel, token string) *verboseTracer {
+	if level <= 0 {
+		return nil
+	}
+	return &verboseTracer{w: c.Err, level: level, token: strings.TrimSpace(token)}
+}
+
+// recorder adapts t to gateway.Client.Recorder.
+func (t *verboseTracer) recorder() func(gateway.Exchange) {
+	if t == nil {
+		return nil
+	}
+	return t.record
+}
+
+// onRetry adapts t to gateway.Client.OnRetry.
+func (t *verboseTracer) onRetry() func(time.Duration) {
+	if t == nil {
+		return nil
+	}
+	return func(wait time.Duration) {
+		t.write(fmt.Sprintf("* retrying in %s\n", wait))
+	}
+}
+
+func (t *verboseTracer) record(exchange gateway.Exchange) {
+	var b strings.Builder
+	fmt.Fprintf(&b, "> %s %s\n", exchange.Method, exchange.URL)
+	writeVerboseHeaders(&b, ">", exchange.RequestHeaders)
+	fmt.Fprintf(&b, "> (%d byte body)\n", len(exchange.RequestBody))
+
+	duration := exchange.Duration.Round(time.Millisecond)
+	if exchange.StatusCode == 0 {
+		fmt.Fprintf(&b, "< error after %s: %v\n", duration, exchange.Err)
+	} else {
+		fmt.Fprintf(&b, "< %d %s in %s\n", exchange.StatusCode, http.StatusText(exchange.StatusCode), duration)
+	}
+	if t.level >= 2 && exchange.StatusCode != 0 {
+		writeVerboseHeaders(&b, "<", exchange.ResponseHeaders)
+		body := exchange.ResponseBody
+		if len(body) > verboseBodyBytes {
+			fmt.Fprintf(&b, "< (first %d of %d body bytes)\n", verboseBodyBytes, len(body))
+			body = body[:verboseBodyBytes]
+		}
+		if len(body) > 0 {
+			fmt.Fprintf(&b, "%s\n", strings.TrimRight(string(body), "\n"))
+		}
+	}
+	t.write(b.String())
+}
+
+func (t *verboseTracer) write(text string) {
+	if t.token != "" {
+		text = strings.ReplaceAll(text, t.token, config.MaskToken(t.token))
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	_, _ = io.WriteString(t.w, text)
+}
+
+// writeVerboseHeaders prints headers sorted by name with the values of
+// harSensitiveHeaders masked.
+func writeVerboseHeaders(b *strings.Builder, prefix string, headers http.Header) {
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		for _, value := range headers[name] {
+			if harSensitiveHeaders[http.CanonicalHeaderKey(name)] {
+				value = config.MaskToken(value)
+			}
+			fmt.Fprintf(b, "%s %s: %s\n", prefix, name, value)
+		}
+	}
+}
+
+// chainRecorders fans one gateway exchange out to every non-nil recorder.
+func chainRecorders(recorders ...func(gateway.Exchange)) func(gateway.Exchange) {
+	var active []func(gateway.Exchange)
+	for _, recorder := range recorders {
+		if recorder != nil {
+			active = append(active, recorder)
+		}
+	}
+	switch len(active) {
+	case 0:
+		return nil
+	case 1:
+		return active[0]
+	}
+	return func(exchange gateway.Exchange) {
+		for _, recorder := range active {
+			recorder(exchange)
+		}
+	}
+}
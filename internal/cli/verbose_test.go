@@ -0,0 +1,111 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/alex-mccollum/igw-cli/internal/config"
+)
+
+const verboseTestToken = "super-secret-token-value"
+
+// verboseTestClient answers the first request with 503 and later ones with
+// the API token it received, so tests can check it is redacted.
+func verboseTestClient() *http.Client {
+	var calls atomic.Int32
+	return newMockHTTPClient(func(r *http.Request) (*http.Response, error) {
+		if calls.Add(1) == 1 {
+			return mockHTTPResponse(http.StatusServiceUnavailable, `busy`, nil), nil
+		}
+		headers := http.Header{"Content-Type": {"application/json"}}
+		return mockHTTPResponse(http.StatusOK, `{"echo":"`+r.Header.Get("X-Ignition-API-Token")+`"}`, headers), nil
+	})
+}
+
+func TestCallVerboseTracesToStderrWithMaskedToken(t *testing.T) {
+	t.Parallel()
+
+	var out, errOut bytes.Buffer
+	c := newAdminWrapperTestCLI(verboseTestClient())
+	c.Out = &out
+	c.Err = &errOut
+	if err := c.Execute([]string{
+		"call",
+		"--gateway-url", mockGatewayURL,
+		"--api-key", verboseTestToken,
+		"--path", "/data/api/v1/gateway-info",
+		"--retry", "1",
+		"--retry-backoff", "1ms",
+		"--verbose=2",
+		"--json",
+	}); err != nil {
+		t.Fatalf("call failed: %v", err)
+	}
+
+	var payload map[string]any
+	if err := json.Unmarshal(out.Bytes(), &payload); err != nil {
+		t.Fatalf("stdout must stay clean JSON: %v\n%s", err, out.String())
+	}
+
+	trace := errOut.String()
+	for _, want := range []string{
+		"> GET " + mockGatewayURL + "/data/api/v1/gateway-info",
+		"> X-Ignition-Api-Token: " + config.MaskToken(verboseTestToken),
+		"< 503 Service Unavailable",
+		"* retrying in",
+		"< 200 OK",
+		"< Content-Type: application/json",
+		`{"echo":"` + config.MaskToken(verboseTestToken) + `"}`,
+	} {
+		if !strings.Contains(trace, want) {
+			t.Fatalf("trace missing %q:\n%s", want, trace)
+		}
+	}
+	if strings.Contains(trace, verboseTestToken) {
+		t.Fatalf("token leaked unmasked in verbose output:\n%s", trace)
+	}
+}
+
+func TestWrapperVerboseShorthand(t *testing.T) {
+	t.Parallel()
+
+	var out, errOut bytes.Buffer
+	c := newAdminWrapperTestCLI(verboseTestClient())
+	c.Out = &out
+	c.Err = &errOut
+	_ = c.Execute([]string{
+		"gateway", "info",
+		"--gateway-url", mockGatewayURL,
+		"--api-key", verboseTestToken,
+		"-v",
+	})
+
+	trace := errOut.String()
+	if !strings.Contains(trace, "< 503 Service Unavailable") {
+		t.Fatalf("expected a level 1 trace, got:\n%s", trace)
+	}
+	if strings.Contains(trace, "busy") {
+		t.Fatalf("level 1 must not dump bodies:\n%s", trace)
+	}
+	if strings.Contains(trace, verboseTestToken) || strings.Contains(out.String(), "> GET") {
+		t.Fatalf("trace leaked the token or reached stdout:\n%s", trace)
+	}
+}
+
+func TestVerboseLevelFlag(t *testing.T) {
+	t.Parallel()
+
+	var level verboseLevel
+	for value, want := range map[string]verboseLevel{"true": 1, "2": 2, "0": 0, "false": 0} {
+		if err := level.Set(value); err != nil || level != want {
+			t.Fatalf("Set(%q) = %d, %v; want %d", value, level, err, want)
+		}
+	}
+	if err := level.Set("3"); err == nil {
+		t.Fatalf("expected an error for level 3")
+	}
+}
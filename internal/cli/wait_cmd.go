@@ -16,13 +16,15 @@ import (
 
 func (c *CLI) runWait(args []string) error {
 	if len(args) == 0 {
-		fmt.Fprintln(c.Err, "Usage: igw wait <gateway|diagnostics-bundle|restart-tasks> [flags]")
+		fmt.Fprintln(c.Err, "Usage: igw wait <gateway|version|diagnostics-bundle|restart-tasks> [flags]")
 		return &igwerr.UsageError{Msg: "required wait target"}
 	}
 
 	switch args[0] {
 	case "gateway":
 		return c.runWaitTarget("gateway", "healthy", args[1:])
+	case "version":
+		return c.runWaitTarget("version", "matches", args[1:])
 	case "diagnostics-bundle":
 		return c.runWaitTarget("diagnostics-bundle", "ready", args[1:])
 	case "restart-tasks":
@@ -40,9 +42,35 @@ func (c *CLI) runWaitTarget(target string, suffix string, args []string) error {
 	var common wrapperCommon
 	var interval time.Duration
 	var waitTimeout time.Duration
+	var afterRestart bool
+	var restart bool
+	var session bool
+	var yes bool
+	var uptimeField string
+	var requireDowntime bool
+	var expectVersion string
+	var versionField string
+	var deadline commandDeadline
+	var flatten flattenOptions
 	bindWrapperCommonWithDefaults(fs, &common, 8*time.Second, false)
+	bindHARFlags(fs, &common.har)
+	bindVerboseFlags(fs, &common.verbose)
+	bindCommandDeadline(fs, &deadline)
+	bindFlattenFlags(fs, &flatten)
 	fs.DurationVar(&interval, "interval", 2*time.Second, "Polling interval")
 	fs.DurationVar(&waitTimeout, "wait-timeout", 2*time.Minute, "Maximum total wait time")
+	fs.BoolVar(&session, "session", false, "Keep cookies set by the gateway (for example sticky-session cookies) across polls; never saved")
+	if target == "gateway" {
+		fs.BoolVar(&afterRestart, "after-restart", false, "Wait until the gateway-info uptime resets (a new gateway process) instead of the first HTTP 200")
+		fs.BoolVar(&restart, "restart", false, "With --after-restart, request a gateway restart after recording the current uptime")
+		fs.BoolVar(&yes, "yes", false, "Confirm --restart")
+		fs.StringVar(&uptimeField, "uptime-field", defaultUptimeField, "gateway-info field (dot path) holding the numeric uptime used by --after-restart and --require-downtime")
+		fs.BoolVar(&requireDowntime, "require-downtime", false, "Wait for the gateway to go down (or its uptime to reset) before waiting for healthy")
+	}
+	if target == "version" {
+		fs.StringVar(&expectVersion, "expect", "", "Version to wait for; \"8.3\" also matches 8.3.x")
+		fs.StringVar(&versionField, "version-field", "", "gateway-info field (dot path) holding the version (default: version, then platformVersion)")
+	}
 
 	condition := ""
 	if len(args) > 0 && !strings.HasPrefix(strings.TrimSpace(args[0]), "-") {
@@ -61,10 +89,15 @@ func (c *CLI) runWaitTarget(target string, suffix string, args []string) error {
 		return parseErr
 	}
 
-	selectOpts, selectErr := newJSONSelectOptions(common.jsonOutput, common.compactJSON, common.rawOutput, common.selectors)
+	selectOpts, selectErr := common.selectOptions()
 	if selectErr != nil {
 		return c.printWaitError(common.jsonOutput, selectionErrorOptions(selectOpts), selectErr)
 	}
+	flattener, err := flatten.flattener(fs, common.jsonOutput, selectOpts)
+	if err != nil {
+		return c.printWaitError(common.jsonOutput, selectOpts, err)
+	}
+	selectOpts.flatten = flattener
 
 	if fs.NArg() > 0 {
 		if condition == "" && fs.NArg() == 1 {
@@ -79,47 +112,71 @@ func (c *CLI) runWaitTarget(target string, suffix string, args []string) error {
 		})
 	}
 
-	if common.apiKeyStdin {
-		if common.apiKey != "" {
-			return c.printWaitError(common.jsonOutput, selectOpts, &igwerr.UsageError{Msg: "use only one of --api-key or --api-key-stdin"})
-		}
-		tokenBytes, err := io.ReadAll(c.In)
-		if err != nil {
-			return c.printWaitError(common.jsonOutput, selectOpts, igwerr.NewTransportError(err))
-		}
-		common.apiKey = strings.TrimSpace(string(tokenBytes))
-	}
-
 	if interval <= 0 {
 		return c.printWaitError(common.jsonOutput, selectOpts, &igwerr.UsageError{Msg: "--interval must be positive"})
 	}
 	if waitTimeout <= 0 {
 		return c.printWaitError(common.jsonOutput, selectOpts, &igwerr.UsageError{Msg: "--wait-timeout must be positive"})
 	}
-
-	resolved, err := c.resolveRuntimeConfig(common.profile, common.gatewayURL, common.apiKey)
-	if err != nil {
-		return c.printWaitError(common.jsonOutput, selectOpts, err)
+	if restart && !afterRestart {
+		return c.printWaitError(common.jsonOutput, selectOpts, &igwerr.UsageError{Msg: "--restart requires --after-restart"})
 	}
-	if strings.TrimSpace(resolved.GatewayURL) == "" {
-		return c.printWaitError(common.jsonOutput, selectOpts, &igwerr.UsageError{Msg: "required: --gateway-url (or IGNITION_GATEWAY_URL/config)"})
+	if restart && !yes {
+		return c.printWaitError(common.jsonOutput, selectOpts, &igwerr.UsageError{Msg: "--restart requires --yes"})
 	}
-	if strings.TrimSpace(resolved.Token) == "" {
-		return c.printWaitError(common.jsonOutput, selectOpts, &igwerr.UsageError{Msg: "required: --api-key (or IGNITION_API_TOKEN/config)"})
+	if (afterRestart || requireDowntime) && strings.TrimSpace(uptimeField) == "" {
+		return c.printWaitError(common.jsonOutput, selectOpts, &igwerr.UsageError{Msg: "--uptime-field must not be empty"})
 	}
-	if common.timeout <= 0 {
-		return c.printWaitError(common.jsonOutput, selectOpts, &igwerr.UsageError{Msg: "--timeout must be positive"})
+	if afterRestart && requireDowntime {
+		return c.printWaitError(common.jsonOutput, selectOpts, &igwerr.UsageError{Msg: "use only one of --after-restart or --require-downtime"})
+	}
+	if target == "version" && strings.TrimSpace(expectVersion) == "" {
+		return c.printWaitError(common.jsonOutput, selectOpts, &igwerr.UsageError{Msg: "required: --expect"})
+	}
+	ctx, cancel, err := deadline.context(context.Background(), c.clock)
+	if err != nil {
+		return c.printWaitError(common.jsonOutput, selectOpts, err)
 	}
+	defer cancel()
 
-	client := &gateway.Client{
-		BaseURL: resolved.GatewayURL,
-		Token:   resolved.Token,
-		HTTP:    c.runtimeHTTPClient(),
+	client, resolved, saveHAR, err := c.newGatewayClient(common)
+	if err != nil {
+		return c.printWaitError(common.jsonOutput, selectOpts, err)
 	}
+	defer saveHAR()
+	client.Session = session
 
-	check := waitCheckForTarget(client, target, common.timeout)
 	start := time.Now()
-	result, waitErr := runWaitLoop(check, target, suffix, interval, waitTimeout)
+	check := waitCheckForTarget(ctx, client, target, common.timeout)
+	if afterRestart {
+		baseline, _, err := gatewayUptime(ctx, client, common.timeout, uptimeField)
+		if err != nil {
+			var terminalErr *waitTerminalError
+			if errors.As(err, &terminalErr) {
+				err = terminalErr.err
+			}
+			if ctx.Err() != nil {
+				err = deadlineExceededError("the uptime baseline check", err)
+			}
+			return c.printWaitError(common.jsonOutput, selectOpts, err)
+		}
+		if restart {
+			if err := restartGateway(ctx, client, common.timeout, resolved.Policy); err != nil {
+				if ctx.Err() != nil {
+					err = deadlineExceededError("the restart request", err)
+				}
+				return c.printWaitError(common.jsonOutput, selectOpts, err)
+			}
+		}
+		check = waitAfterRestartCheck(ctx, client, common.timeout, uptimeField, baseline)
+	}
+	if requireDowntime {
+		check = waitDowntimeCheck(ctx, client, common.timeout, uptimeField)
+	}
+	if target == "version" {
+		check = waitVersionCheck(ctx, client, common.timeout, versionField, strings.TrimSpace(expectVersion))
+	}
+	result, waitErr := runWaitLoop(ctx, check, target, suffix, interval, waitTimeout)
 	if waitErr != nil {
 		return c.printWaitError(common.jsonOutput, selectOpts, waitErr)
 	}
@@ -131,6 +188,9 @@ func (c *CLI) runWaitTarget(target string, suffix string, args []string) error {
 		if result.LastHTTP != nil {
 			stats["lastHTTP"] = result.LastHTTP
 		}
+		if session {
+			stats["sessionCookies"] = client.SessionCookies()
+		}
 	}
 
 	if common.jsonOutput {
@@ -205,7 +265,7 @@ func newWaitTerminalError(err error) error {
 	return &waitTerminalError{err: err}
 }
 
-func runWaitLoop(check waitCheck, target string, condition string, interval time.Duration, waitTimeout time.Duration) (waitResult, error) {
+func runWaitLoop(ctx context.Context, check waitCheck, target string, condition string, interval time.Duration, waitTimeout time.Duration) (waitResult, error) {
 	deadline := time.Now().Add(waitTimeout)
 	attempts := 0
 	var lastObservation waitObservation
@@ -241,6 +301,9 @@ func runWaitLoop(check waitCheck, target string, condition string, interval time
 			}
 		}
 
+		if ctx.Err() != nil {
+			return waitResult{}, deadlineExceededError(fmt.Sprintf("the wait for %s to become %s", target, condition), lastErr)
+		}
 		if time.Now().After(deadline) {
 			msg := fmt.Sprintf("timed out waiting for %s to become %s", target, condition)
 			if lastErr != nil {
@@ -251,7 +314,13 @@ func runWaitLoop(check waitCheck, target string, condition string, interval time
 			return waitResult{}, igwerr.NewTransportError(errors.New(msg))
 		}
 
-		time.Sleep(sleep)
+		timer := time.NewTimer(sleep)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return waitResult{}, deadlineExceededError(fmt.Sprintf("the wait for %s to become %s", target, condition), lastErr)
+		case <-timer.C:
+		}
 		sleep = nextAdaptiveWaitInterval(sleep, maxSleep)
 	}
 }
@@ -289,11 +358,11 @@ func retryableWaitError(err error) bool {
 	return true
 }
 
-func waitCheckForTarget(client *gateway.Client, target string, timeout time.Duration) waitCheck {
+func waitCheckForTarget(ctx context.Context, client *gateway.Client, target string, timeout time.Duration) waitCheck {
 	switch target {
 	case "gateway":
 		return func() (waitObservation, error) {
-			resp, err := client.Call(context.Background(), gateway.CallRequest{
+			resp, err := client.Call(ctx, gateway.CallRequest{
 				Method:       "GET",
 				Path:         "/data/api/v1/gateway-info",
 				Timeout:      timeout,
@@ -313,7 +382,7 @@ func waitCheckForTarget(client *gateway.Client, target string, timeout time.Dura
 		}
 	case "diagnostics-bundle":
 		return func() (waitObservation, error) {
-			resp, err := client.Call(context.Background(), gateway.CallRequest{
+			resp, err := client.Call(ctx, gateway.CallRequest{
 				Method:       "GET",
 				Path:         "/data/api/v1/diagnostics/bundle/status",
 				Timeout:      timeout,
@@ -350,7 +419,7 @@ func waitCheckForTarget(client *gateway.Client, target string, timeout time.Dura
 		}
 	default: // restart-tasks
 		return func() (waitObservation, error) {
-			resp, err := client.Call(context.Background(), gateway.CallRequest{
+			resp, err := client.Call(ctx, gateway.CallRequest{
 				Method:       "GET",
 				Path:         "/data/api/v1/restart-tasks/pending",
 				Timeout:      timeout,
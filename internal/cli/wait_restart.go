@@ -0,0 +1,175 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/alex-mccollum/igw-cli/internal/config"
+	"github.com/alex-mccollum/igw-cli/internal/gateway"
+	"github.com/alex-mccollum/igw-cli/internal/igwerr"
+)
+
+const defaultUptimeField = "uptime"
+
+// gatewayUptime reads the numeric uptime field from gateway-info.
+func gatewayUptime(ctx context.Context, client *gateway.Client, timeout time.Duration, field string) (float64, *gateway.CallResponse, error) {
+	resp, err := client.Call(ctx, gateway.CallRequest{
+		Method:       http.MethodGet,
+		Path:         "/data/api/v1/gateway-info",
+		Timeout:      timeout,
+		EnableTiming: true,
+	})
+	if err != nil {
+		return 0, nil, err
+	}
+	uptime, err := parseGatewayUptime(resp.Body, field)
+	return uptime, resp, err
+}
+
+// parseGatewayUptime reads the numeric uptime field from a gateway-info body.
+func parseGatewayUptime(raw []byte, field string) (float64, error) {
+	decoder := json.NewDecoder(bytes.NewReader(raw))
+	decoder.UseNumber()
+	var body any
+	if err := decoder.Decode(&body); err != nil {
+		return 0, igwerr.NewTransportError(fmt.Errorf("decode response json: %w", err))
+	}
+	value, err := extractJSONPathValueFromRoot(body, field)
+	if err != nil {
+		return 0, newWaitTerminalError(&igwerr.UsageError{Msg: fmt.Sprintf("gateway-info has no uptime field %q", field)})
+	}
+	var uptime float64
+	switch typed := value.(type) {
+	case json.Number:
+		uptime, err = typed.Float64()
+	case string:
+		uptime, err = strconv.ParseFloat(typed, 64)
+	default:
+		err = fmt.Errorf("unsupported type %T", value)
+	}
+	if err != nil {
+		return 0, newWaitTerminalError(&igwerr.UsageError{Msg: fmt.Sprintf("gateway-info field %q is not a number", field)})
+	}
+	return uptime, nil
+}
+
+// waitAfterRestartCheck is ready once the reported uptime drops below the
+// previous reading, which means a new gateway process is answering. The
+// old process can keep responding for a while after a restart request, so
+// a 200 alone is not enough.
+func waitAfterRestartCheck(ctx context.Context, client *gateway.Client, timeout time.Duration, field string, baseline float64) waitCheck {
+	previous := baseline
+	return func() (waitObservation, error) {
+		uptime, resp, err := gatewayUptime(ctx, client, timeout, field)
+		if err != nil {
+			return waitObservation{}, err
+		}
+		observation := waitObservation{
+			Ready: uptime < previous,
+			State: map[string]any{
+				"uptime":         uptime,
+				"previousUptime": previous,
+				"baselineUptime": baseline,
+			},
+			HTTP: resp.Timing,
+		}
+		if observation.Ready {
+			observation.Message = fmt.Sprintf("uptime reset from %s to %s", formatUptime(previous), formatUptime(uptime))
+		} else {
+			observation.Message = fmt.Sprintf("uptime=%s (waiting for reset)", formatUptime(uptime))
+		}
+		previous = uptime
+		return observation, nil
+	}
+}
+
+// restartGateway requests a gateway restart, the same call as
+// `igw restart gateway --yes`. It goes through executeCallCore so policy deny
+// rules apply.
+func restartGateway(ctx context.Context, client *gateway.Client, timeout time.Duration, policy *config.Policy) error {
+	_, _, _, err := executeCallCore(client, callExecutionInput{
+		Context: ctx,
+		Method:  http.MethodPost,
+		Path:    "/data/api/v1/restart-tasks/restart",
+		Query:   []string{"confirm=true"},
+		Timeout: timeout,
+		Yes:     true,
+		Policy:  policy,
+	})
+	return err
+}
+
+func formatUptime(v float64) string {
+	return strconv.FormatFloat(v, 'f', -1, 64)
+}
+
+// gatewayDown reports whether err means the gateway is down rather than
+// failing: it is unreachable, or a proxy in front of it answers 5xx.
+// Auth failures and other statuses are real errors.
+func gatewayDown(err error) bool {
+	var statusErr *igwerr.StatusError
+	if errors.As(err, &statusErr) {
+		return statusErr.StatusCode >= 500
+	}
+	var transportErr *igwerr.TransportError
+	return errors.As(err, &transportErr)
+}
+
+// waitDowntimeCheck is ready once the gateway answers again after going
+// down. While it is still up, errors that mean it is down are the expected
+// state, not failures. A restart faster than the polling interval is
+// caught by the uptime field dropping below its previous reading.
+func waitDowntimeCheck(ctx context.Context, client *gateway.Client, timeout time.Duration, field string) waitCheck {
+	downtimeObserved := false
+	previous, havePrevious := 0.0, false
+	return func() (waitObservation, error) {
+		resp, err := client.Call(ctx, gateway.CallRequest{
+			Method:       http.MethodGet,
+			Path:         "/data/api/v1/gateway-info",
+			Timeout:      timeout,
+			EnableTiming: true,
+		})
+		if err != nil {
+			if !gatewayDown(err) {
+				return waitObservation{}, err
+			}
+			downtimeObserved = true
+			return waitObservation{
+				Message: fmt.Sprintf("gateway down (%v)", err),
+				State:   map[string]any{"phase": "down", "downtimeObserved": true},
+			}, nil
+		}
+
+		state := map[string]any{"downtimeObserved": downtimeObserved}
+		uptime, uptimeErr := parseGatewayUptime(resp.Body, field)
+		if uptimeErr == nil {
+			state["uptime"] = uptime
+		}
+		observation := waitObservation{State: state, HTTP: resp.Timing}
+		switch {
+		case downtimeObserved:
+			observation.Ready = true
+			observation.Message = "gateway healthy after downtime"
+		case uptimeErr == nil && havePrevious && uptime < previous:
+			observation.Ready = true
+			observation.Message = fmt.Sprintf("uptime reset from %s to %s", formatUptime(previous), formatUptime(uptime))
+		default:
+			observation.Message = "gateway still up (waiting for downtime)"
+		}
+		if observation.Ready {
+			state["phase"] = "healthy"
+		} else {
+			state["phase"] = "up"
+		}
+		if uptimeErr == nil {
+			previous, havePrevious = uptime, true
+		}
+		return observation, nil
+	}
+}
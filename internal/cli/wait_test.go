@@ -3,6 +3,7 @@ package cli
 import (
 	"bytes"
 	"encoding/json"
+	"errors"
 	"net/http"
 	"net/http/httptest"
 	"strings"
@@ -434,6 +435,231 @@ func TestWaitRejectsUnexpectedConditionSuffix(t *testing.T) {
 	}
 }
 
+func TestWaitGatewayAfterRestartWaitsForUptimeReset(t *testing.T) {
+	t.Parallel()
+
+	// Baseline, then the old process still answering, then the new one.
+	uptimes := []string{"100", "104", "108", "3", "5"}
+	var (
+		gets     int
+		restarts int
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/data/api/v1/restart-tasks/restart":
+			restarts++
+			w.WriteHeader(http.StatusOK)
+		case r.Method == http.MethodGet && r.URL.Path == "/data/api/v1/gateway-info":
+			uptime := uptimes[len(uptimes)-1]
+			if gets < len(uptimes) {
+				uptime = uptimes[gets]
+			}
+			gets++
+			_, _ = w.Write([]byte(`{"name":"gateway","uptime":` + uptime + `}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+
+	var out bytes.Buffer
+	c := testWaitCLI(t, srv, &out)
+
+	if err := c.Execute([]string{
+		"wait", "gateway",
+		"--gateway-url", srv.URL,
+		"--api-key", "secret",
+		"--after-restart",
+		"--restart", "--yes",
+		"--interval", "1ms",
+		"--wait-timeout", "2s",
+		"--json",
+	}); err != nil {
+		t.Fatalf("wait gateway failed: %v", err)
+	}
+
+	if restarts != 1 {
+		t.Fatalf("expected 1 restart request, got %d", restarts)
+	}
+	if gets != 4 {
+		t.Fatalf("expected readiness on the 4th gateway-info read, got %d reads", gets)
+	}
+	var payload map[string]any
+	if err := json.Unmarshal(out.Bytes(), &payload); err != nil {
+		t.Fatalf("decode output: %v", err)
+	}
+	if payload["attempts"] != float64(3) {
+		t.Fatalf("expected 3 wait attempts, got %v", payload["attempts"])
+	}
+	if payload["message"] != "uptime reset from 108 to 3" {
+		t.Fatalf("unexpected message %v", payload["message"])
+	}
+}
+
+func TestWaitGatewayRestartHonorsPolicy(t *testing.T) {
+	t.Parallel()
+
+	var restarts int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPost {
+			restarts++
+		}
+		_, _ = w.Write([]byte(`{"name":"gateway","uptime":100}`))
+	}))
+	defer srv.Close()
+
+	var out bytes.Buffer
+	c := testWaitCLI(t, srv, &out)
+	c.ReadConfig = policyTestConfig(&config.Policy{Deny: []config.PolicyRule{{Method: "POST", Path: "/data/api/v1/restart-tasks/restart"}}})
+
+	err := c.Execute([]string{
+		"wait", "gateway",
+		"--gateway-url", srv.URL,
+		"--after-restart",
+		"--restart", "--yes",
+		"--interval", "1ms",
+		"--wait-timeout", "1s",
+	})
+	requireUsageExitCode(t, err)
+	if restarts != 0 {
+		t.Fatalf("denied restart must not be sent, got %d requests", restarts)
+	}
+}
+
+func TestWaitGatewayAfterRestartValidation(t *testing.T) {
+	t.Parallel()
+
+	srv := httptest.NewServer(http.NotFoundHandler())
+	defer srv.Close()
+
+	for _, args := range [][]string{
+		{"wait", "gateway", "--restart", "--yes"},
+		{"wait", "gateway", "--after-restart", "--restart"},
+		{"wait", "restart-tasks", "--after-restart"},
+		{"wait", "gateway", "--after-restart", "--require-downtime"},
+		{"wait", "restart-tasks", "--require-downtime"},
+	} {
+		var out bytes.Buffer
+		c := testWaitCLI(t, srv, &out)
+		err := c.Execute(append(args, "--gateway-url", "http://127.0.0.1:8088", "--api-key", "secret"))
+		var usageErr *igwerr.UsageError
+		if !errors.As(err, &usageErr) {
+			t.Fatalf("%v: expected usage error, got %v", args, err)
+		}
+	}
+}
+
+// closeConnection drops the request without a response, like a gateway
+// process that is shutting down.
+func closeConnection(t *testing.T, w http.ResponseWriter) {
+	t.Helper()
+	conn, _, err := w.(http.Hijacker).Hijack()
+	if err != nil {
+		t.Errorf("hijack: %v", err)
+		return
+	}
+	_ = conn.Close()
+}
+
+func TestWaitGatewayRequireDowntime(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		responses   []string
+		wantGets    int
+		wantMessage string
+	}{
+		{
+			// Still up, then a dropped connection and a proxy 503, then back.
+			name:        "downtime observed",
+			responses:   []string{"100", "104", "drop", "503", "2"},
+			wantGets:    5,
+			wantMessage: "gateway healthy after downtime",
+		},
+		{
+			// The restart finished between two polls.
+			name:        "uptime reset",
+			responses:   []string{"100", "104", "3"},
+			wantGets:    3,
+			wantMessage: "uptime reset from 104 to 3",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var gets int
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				response := tt.responses[len(tt.responses)-1]
+				if gets < len(tt.responses) {
+					response = tt.responses[gets]
+				}
+				gets++
+				switch response {
+				case "drop":
+					closeConnection(t, w)
+				case "503":
+					w.WriteHeader(http.StatusServiceUnavailable)
+				default:
+					_, _ = w.Write([]byte(`{"name":"gateway","uptime":` + response + `}`))
+				}
+			}))
+			defer srv.Close()
+
+			var out bytes.Buffer
+			c := testWaitCLI(t, srv, &out)
+			if err := c.Execute([]string{
+				"wait", "gateway",
+				"--gateway-url", srv.URL,
+				"--api-key", "secret",
+				"--require-downtime",
+				"--interval", "1ms",
+				"--wait-timeout", "2s",
+				"--json",
+			}); err != nil {
+				t.Fatalf("wait gateway failed: %v", err)
+			}
+
+			var payload map[string]any
+			if err := json.Unmarshal(out.Bytes(), &payload); err != nil {
+				t.Fatalf("decode output: %v", err)
+			}
+			if gets != tt.wantGets || payload["message"] != tt.wantMessage {
+				t.Fatalf("got %d reads and message %v", gets, payload["message"])
+			}
+		})
+	}
+}
+
+func TestWaitGatewayRequireDowntimeAuthFailureExitsImmediately(t *testing.T) {
+	t.Parallel()
+
+	var gets int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		gets++
+		if gets == 1 {
+			_, _ = w.Write([]byte(`{"uptime":100}`))
+			return
+		}
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer srv.Close()
+
+	c := testWaitCLI(t, srv, new(bytes.Buffer))
+	err := c.Execute([]string{
+		"wait", "gateway",
+		"--gateway-url", srv.URL,
+		"--api-key", "secret",
+		"--require-downtime",
+		"--interval", "1ms",
+		"--wait-timeout", "2s",
+	})
+	if code := igwerr.ExitCode(err); code != 6 || gets != 2 {
+		t.Fatalf("expected auth exit after 2 reads, got code %d after %d reads (%v)", code, gets, err)
+	}
+}
+
 func testWaitCLI(t *testing.T, srv *httptest.Server, out *bytes.Buffer) *CLI {
 	t.Helper()
 
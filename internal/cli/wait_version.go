@@ -0,0 +1,82 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/alex-mccollum/igw-cli/internal/gateway"
+)
+
+// defaultVersionFields are the gateway-info fields read by wait version
+// when --version-field is not set, matching gateway status.
+var defaultVersionFields = []string{"version", "platformVersion"}
+
+// waitVersionCheck is ready once gateway-info reports a version matching
+// expect. An upgrade restarts the gateway, so a gateway that is down is an
+// expected state while waiting.
+func waitVersionCheck(ctx context.Context, client *gateway.Client, timeout time.Duration, field string, expect string) waitCheck {
+	fields := defaultVersionFields
+	if strings.TrimSpace(field) != "" {
+		fields = []string{strings.TrimSpace(field)}
+	}
+	return func() (waitObservation, error) {
+		resp, err := client.Call(ctx, gateway.CallRequest{
+			Method:       http.MethodGet,
+			Path:         "/data/api/v1/gateway-info",
+			Timeout:      timeout,
+			EnableTiming: true,
+		})
+		if err != nil {
+			if !gatewayDown(err) {
+				return waitObservation{}, err
+			}
+			return waitObservation{
+				Message: fmt.Sprintf("gateway down (%v)", err),
+				State:   map[string]any{"expected": expect},
+			}, nil
+		}
+		body, err := decodeJSONBody(resp.Body)
+		if err != nil {
+			return waitObservation{}, err
+		}
+
+		version := ""
+		for _, name := range fields {
+			if value, err := extractJSONPathValueFromRoot(body, name); err == nil && value != nil {
+				version = strings.TrimSpace(fmt.Sprint(value))
+				break
+			}
+		}
+		observation := waitObservation{
+			Ready: versionMatches(version, expect),
+			State: map[string]any{"version": version, "expected": expect},
+			HTTP:  resp.Timing,
+		}
+		switch {
+		case observation.Ready:
+			observation.Message = fmt.Sprintf("version=%s", version)
+		case version == "":
+			observation.Message = fmt.Sprintf("gateway-info has no %s field", strings.Join(fields, " or "))
+		default:
+			observation.Message = fmt.Sprintf("version=%s (waiting for %s)", version, expect)
+		}
+		return observation, nil
+	}
+}
+
+// versionMatches reports whether reported is expect or extends it at a
+// component boundary, so "8.3" matches "8.3.1" and "8.3.1 (b2025)" but
+// "8.3.1" does not match "8.3.10".
+func versionMatches(reported string, expect string) bool {
+	reported = strings.TrimPrefix(strings.TrimSpace(reported), "v")
+	expect = strings.TrimPrefix(strings.TrimSpace(expect), "v")
+	if expect == "" || !strings.HasPrefix(reported, expect) {
+		return false
+	}
+	rest := reported[len(expect):]
+	return rest == "" || !unicode.IsDigit(rune(rest[0]))
+}
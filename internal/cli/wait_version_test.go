@@ -0,0 +1,83 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestWaitVersionPollsThroughUpgrade(t *testing.T) {
+	t.Parallel()
+
+	// The old version, the gateway down for the upgrade, then the new one.
+	responses := []string{`{"version":"8.1.40"}`, "drop", "503", `{"platformVersion":"8.3.1 (b2025061210)"}`}
+	var gets int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/data/api/v1/gateway-info" {
+			http.NotFound(w, r)
+			return
+		}
+		response := responses[len(responses)-1]
+		if gets < len(responses) {
+			response = responses[gets]
+		}
+		gets++
+		switch response {
+		case "drop":
+			closeConnection(t, w)
+		case "503":
+			w.WriteHeader(http.StatusServiceUnavailable)
+		default:
+			_, _ = w.Write([]byte(response))
+		}
+	}))
+	defer srv.Close()
+
+	var out bytes.Buffer
+	c := testWaitCLI(t, srv, &out)
+	if err := c.Execute([]string{
+		"wait", "version",
+		"--gateway-url", srv.URL,
+		"--api-key", "secret",
+		"--expect", "8.3",
+		"--interval", "1ms",
+		"--wait-timeout", "2s",
+		"--json",
+	}); err != nil {
+		t.Fatalf("wait version failed: %v", err)
+	}
+
+	var payload map[string]any
+	if err := json.Unmarshal(out.Bytes(), &payload); err != nil {
+		t.Fatalf("decode output: %v", err)
+	}
+	if gets != 4 || payload["condition"] != "matches" || payload["message"] != "version=8.3.1 (b2025061210)" {
+		t.Fatalf("unexpected result after %d reads: %v", gets, payload)
+	}
+
+	requireUsageExitCode(t, c.Execute([]string{"wait", "version", "--gateway-url", srv.URL, "--api-key", "secret"}))
+}
+
+func TestVersionMatches(t *testing.T) {
+	t.Parallel()
+
+	for _, tt := range []struct {
+		reported string
+		expect   string
+		want     bool
+	}{
+		{"8.3.1", "8.3.1", true},
+		{"8.3.1", "8.3", true},
+		{"v8.3.1", "8.3.1", true},
+		{"8.3.1 (b2025061210)", "8.3.1", true},
+		{"8.3.10", "8.3.1", false},
+		{"8.1.40", "8.3", false},
+		{"", "8.3", false},
+	} {
+		if got := versionMatches(tt.reported, tt.expect); got != tt.want {
+			t.Fatalf("versionMatches(%q, %q) = %t, want %t", tt.reported, tt.expect, got, tt.want)
+		}
+	}
+}
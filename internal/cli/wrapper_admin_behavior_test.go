@@ -507,3 +507,39 @@ func requireUsageExitCode(t *testing.T, err error) {
 		t.Fatalf("unexpected exit code %d", code)
 	}
 }
+
+func TestLogsListWrapperSelectsRawScalar(t *testing.T) {
+	t.Parallel()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"count":3,"items":[]}`))
+	}))
+	defer srv.Close()
+
+	var out bytes.Buffer
+	c := &CLI{
+		In:     strings.NewReader(""),
+		Out:    &out,
+		Err:    new(bytes.Buffer),
+		Getenv: func(string) string { return "" },
+		ReadConfig: func() (config.File, error) {
+			return config.File{}, nil
+		},
+		HTTPClient: srv.Client(),
+	}
+
+	if err := c.Execute([]string{
+		"logs", "list",
+		"--gateway-url", srv.URL,
+		"--api-key", "secret",
+		"--json",
+		"--select", "response.body.count",
+		"--raw",
+	}); err != nil {
+		t.Fatalf("logs list failed: %v", err)
+	}
+	if out.String() != "3\n" {
+		t.Fatalf("unexpected raw output %q", out.String())
+	}
+}
@@ -0,0 +1,268 @@
+package cli
+
+import (
+	"archive/zip"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/xml"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/alex-mccollum/igw-cli/internal/igwerr"
+)
+
+// backupInfoEntry is the archive member that describes a .gwbk.
+const backupInfoEntry = "backupinfo.xml"
+
+type backupArchiveEntry struct {
+	Name     string    `json:"name"`
+	Size     uint64    `json:"size"`
+	Modified time.Time `json:"modified"`
+}
+
+// backupArchive is what `backup inspect` and `backup export --verify` read
+// from a .gwbk without a gateway connection.
+type backupArchive struct {
+	File           string               `json:"file"`
+	GatewayVersion string               `json:"gatewayVersion,omitempty"`
+	Entries        []backupArchiveEntry `json:"entries"`
+	Warnings       []string             `json:"warnings,omitempty"`
+}
+
+// readBackupArchive opens path as a zip, which reads its central
+// directory, and pulls the gateway version from backupinfo.xml.
+func readBackupArchive(path string) (backupArchive, error) {
+	reader, err := zip.OpenReader(path)
+	if err != nil {
+		return backupArchive{}, fmt.Errorf("read backup archive %s: %w", path, err)
+	}
+	defer reader.Close()
+
+	archive := backupArchive{File: path, Entries: make([]backupArchiveEntry, 0, len(reader.File))}
+	var info *zip.File
+	for _, file := range reader.File {
+		archive.Entries = append(archive.Entries, backupArchiveEntry{
+			Name:     file.Name,
+			Size:     file.UncompressedSize64,
+			Modified: file.Modified.UTC(),
+		})
+		if strings.EqualFold(file.Name, backupInfoEntry) {
+			info = file
+		}
+	}
+	if info == nil {
+		archive.Warnings = append(archive.Warnings, backupInfoEntry+" not found; gateway version unknown")
+		return archive, nil
+	}
+	version, err := readBackupInfoVersion(info)
+	if err != nil {
+		archive.Warnings = append(archive.Warnings, err.Error())
+		return archive, nil
+	}
+	archive.GatewayVersion = version
+	return archive, nil
+}
+
+func readBackupInfoVersion(file *zip.File) (string, error) {
+	body, err := file.Open()
+	if err != nil {
+		return "", fmt.Errorf("open %s: %v", backupInfoEntry, err)
+	}
+	defer body.Close()
+
+	var info struct {
+		Version string `xml:"version"`
+	}
+	if err := xml.NewDecoder(body).Decode(&info); err != nil {
+		return "", fmt.Errorf("parse %s: %v", backupInfoEntry, err)
+	}
+	version := strings.TrimSpace(info.Version)
+	if version == "" {
+		return "", fmt.Errorf("%s has no version", backupInfoEntry)
+	}
+	return version, nil
+}
+
+// backupVerification is the result of `backup export --verify`.
+type backupVerification struct {
+	Out            string `json:"out"`
+	Bytes          int64  `json:"bytes"`
+	ContentLength  *int64 `json:"contentLength,omitempty"`
+	SHA256         string `json:"sha256"`
+	Entries        int    `json:"entries"`
+	GatewayVersion string `json:"gatewayVersion,omitempty"`
+}
+
+// verifyBackupFile checks a downloaded backup: the size against
+// Content-Length when the gateway sent one, and that the zip central
+// directory is readable. Failures are network-class errors and leave the
+// file in place for inspection.
+func verifyBackupFile(path string, headers http.Header) (backupVerification, error) {
+	result := backupVerification{Out: path}
+	file, err := os.Open(path)
+	if err != nil {
+		return result, backupVerifyError(err)
+	}
+	hasher := sha256.New()
+	result.Bytes, err = io.Copy(hasher, file)
+	_ = file.Close()
+	if err != nil {
+		return result, backupVerifyError(err)
+	}
+	result.SHA256 = hex.EncodeToString(hasher.Sum(nil))
+
+	if raw := strings.TrimSpace(headers.Get("Content-Length")); raw != "" {
+		contentLength, err := strconv.ParseInt(raw, 10, 64)
+		if err != nil {
+			return result, backupVerifyError(fmt.Errorf("invalid Content-Length %q", raw))
+		}
+		result.ContentLength = &contentLength
+		if contentLength != result.Bytes {
+			return result, backupVerifyError(fmt.Errorf("wrote %d bytes but Content-Length was %d", result.Bytes, contentLength))
+		}
+	}
+
+	archive, err := readBackupArchive(path)
+	if err != nil {
+		return result, backupVerifyError(err)
+	}
+	result.Entries = len(archive.Entries)
+	result.GatewayVersion = archive.GatewayVersion
+	return result, nil
+}
+
+func backupVerifyError(err error) error {
+	return igwerr.NewTransportError(fmt.Errorf("backup verification failed: %w", err))
+}
+
+// runBackupExportVerify downloads the backup with its own client, since
+// verification needs the response headers that a forwarded call does not
+// expose, then checks the written file.
+func (c *CLI) runBackupExportVerify(common wrapperCommon, query []string, outPath string, progress bool) error {
+	selectOpts, selectErr := common.selectOptions()
+	if selectErr != nil {
+		return c.printCallError(common.jsonOutput, selectionErrorOptions(selectOpts), selectErr)
+	}
+	client, resolved, saveHAR, err := c.newGatewayClient(common)
+	if err != nil {
+		return c.printCallError(common.jsonOutput, selectOpts, err)
+	}
+	defer saveHAR()
+
+	out, closeOut, err := c.callOutputWriter(outPath, true, false)
+	if err != nil {
+		return c.printCallError(common.jsonOutput, selectOpts, err)
+	}
+	input := callExecutionInput{
+		Method:       http.MethodGet,
+		Path:         "/data/api/v1/backup",
+		Query:        query,
+		Timeout:      common.timeout,
+		Stream:       out,
+		EnableTiming: true,
+		Policy:       resolved.Policy,
+	}
+	if progress {
+		input.Progress = newDownloadProgress(c.Err, c.clock)
+	}
+
+	start := time.Now()
+	resp, _, _, err := executeCallCore(client, input)
+	closeErr := closeOut()
+	if err == nil && closeErr != nil {
+		err = igwerr.NewTransportError(closeErr)
+	}
+	if err != nil {
+		return c.printCallError(common.jsonOutput, selectOpts, err)
+	}
+	input.Progress.finish()
+
+	verification, err := verifyBackupFile(outPath, resp.Headers)
+	if err != nil {
+		return c.printCallError(common.jsonOutput, selectOpts, err)
+	}
+	stats := buildCallStats(resp, time.Since(start).Milliseconds())
+
+	if common.jsonOutput {
+		payload := map[string]any{
+			"ok":       true,
+			"status":   resp.StatusCode,
+			"verified": true,
+			"backup":   verification,
+		}
+		if common.jsonStats || common.timing {
+			payload["stats"] = stats
+		}
+		if selectWriteErr := printJSONSelection(c.Out, payload, selectOpts); selectWriteErr != nil {
+			return c.printCallError(common.jsonOutput, selectionErrorOptions(selectOpts), selectWriteErr)
+		}
+		return nil
+	}
+
+	c.info("saved response body: %s", outPath)
+	fmt.Fprintf(c.Out, "out\t%s\n", verification.Out)
+	fmt.Fprintf(c.Out, "bytes\t%d\n", verification.Bytes)
+	if verification.ContentLength != nil {
+		fmt.Fprintf(c.Out, "content_length\t%d\n", *verification.ContentLength)
+	}
+	fmt.Fprintf(c.Out, "sha256\t%s\n", verification.SHA256)
+	fmt.Fprintf(c.Out, "entries\t%d\n", verification.Entries)
+	if verification.GatewayVersion != "" {
+		fmt.Fprintf(c.Out, "gateway_version\t%s\n", verification.GatewayVersion)
+	}
+	if common.timing {
+		printTimingSummary(c.Err, stats)
+	}
+	return nil
+}
+
+func (c *CLI) runBackupInspect(args []string) error {
+	fs := flag.NewFlagSet("backup inspect", flag.ContinueOnError)
+	fs.SetOutput(c.Err)
+
+	var inPath string
+	var jsonOutput bool
+	var compact bool
+	fs.StringVar(&inPath, "in", "", "Path to .gwbk file")
+	fs.BoolVar(&jsonOutput, "json", false, "Print JSON output")
+	fs.BoolVar(&compact, "compact", false, "Print compact one-line JSON (requires --json)")
+
+	if err := fs.Parse(args); err != nil {
+		return &igwerr.UsageError{Msg: err.Error()}
+	}
+	if compact && !jsonOutput {
+		return c.printJSONCommandErrorWithOptions(false, false, &igwerr.UsageError{Msg: "--compact requires --json"})
+	}
+	if fs.NArg() > 0 {
+		return c.printJSONCommandErrorWithOptions(jsonOutput, compact, &igwerr.UsageError{Msg: "unexpected positional arguments"})
+	}
+	if strings.TrimSpace(inPath) == "" {
+		return c.printJSONCommandErrorWithOptions(jsonOutput, compact, &igwerr.UsageError{Msg: "required: --in"})
+	}
+
+	archive, err := readBackupArchive(inPath)
+	if err != nil {
+		return c.printJSONCommandErrorWithOptions(jsonOutput, compact, igwerr.NewTransportError(err))
+	}
+	if jsonOutput {
+		return writeJSONWithOptions(c.Out, archive, compact)
+	}
+
+	for _, warning := range archive.Warnings {
+		fmt.Fprintf(c.Err, "warning: %s\n", warning)
+	}
+	if archive.GatewayVersion != "" {
+		fmt.Fprintf(c.Out, "gateway_version\t%s\n", archive.GatewayVersion)
+	}
+	fmt.Fprintln(c.Out, "NAME\tSIZE\tMODIFIED")
+	for _, entry := range archive.Entries {
+		fmt.Fprintf(c.Out, "%s\t%d\t%s\n", entry.Name, entry.Size, entry.Modified.Format(time.RFC3339))
+	}
+	return nil
+}
@@ -0,0 +1,155 @@
+package cli
+
+import (
+	"archive/zip"
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/alex-mccollum/igw-cli/internal/igwerr"
+)
+
+func buildTestGWBK(t *testing.T) []byte {
+	t.Helper()
+
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	modified := time.Date(2026, 3, 4, 5, 6, 7, 0, time.UTC)
+	for _, entry := range []struct{ name, body string }{
+		{"backupinfo.xml", `<?xml version="1.0" encoding="UTF-8"?><backupinfo><version>8.1.44 (b2024101512)</version><edition>standard</edition></backupinfo>`},
+		{"db_backup_sqlite.idb", strings.Repeat("d", 2048)},
+	} {
+		w, err := zw.CreateHeader(&zip.FileHeader{Name: entry.name, Method: zip.Deflate, Modified: modified})
+		if err != nil {
+			t.Fatalf("create %s: %v", entry.name, err)
+		}
+		if _, err := w.Write([]byte(entry.body)); err != nil {
+			t.Fatalf("write %s: %v", entry.name, err)
+		}
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatalf("close archive: %v", err)
+	}
+	return buf.Bytes()
+}
+
+func TestBackupExportVerifyChecksArchive(t *testing.T) {
+	t.Parallel()
+
+	archive := buildTestGWBK(t)
+	sum := sha256.Sum256(archive)
+	cases := []struct {
+		name          string
+		body          string
+		contentLength string
+		wantErr       string
+	}{
+		{name: "valid", body: string(archive), contentLength: strconv.Itoa(len(archive))},
+		{name: "no-length", body: string(archive)},
+		{name: "short", body: string(archive[:len(archive)/2]), contentLength: strconv.Itoa(len(archive)), wantErr: "Content-Length was"},
+		{name: "not-zip", body: "<html>login</html>", wantErr: "read backup archive"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			client := newMockHTTPClient(func(r *http.Request) (*http.Response, error) {
+				if r.URL.Path != "/data/api/v1/backup" || r.URL.Query().Get("includePeerLocal") != "false" {
+					t.Fatalf("unexpected request %s", r.URL)
+				}
+				headers := http.Header{}
+				if tc.contentLength != "" {
+					headers.Set("Content-Length", tc.contentLength)
+				}
+				return mockHTTPResponse(http.StatusOK, tc.body, headers), nil
+			})
+			c := newAdminWrapperTestCLI(client)
+			out := c.Out.(*bytes.Buffer)
+			outPath := filepath.Join(t.TempDir(), "gateway.gwbk")
+
+			err := c.Execute([]string{
+				"backup", "export", "--verify", "--json",
+				"--gateway-url", mockGatewayURL, "--api-key", "secret",
+				"--include-peer-local", "false", "--out", outPath,
+			})
+			var payload map[string]any
+			if decodeErr := json.Unmarshal(out.Bytes(), &payload); decodeErr != nil {
+				t.Fatalf("decode output %q: %v", out.String(), decodeErr)
+			}
+			if written, readErr := os.ReadFile(outPath); readErr != nil || string(written) != tc.body {
+				t.Fatalf("expected the downloaded body to stay on disk (err=%v)", readErr)
+			}
+
+			if tc.wantErr != "" {
+				if igwerr.ExitCode(err) != 7 || payload["ok"] != false || !strings.Contains(payload["error"].(string), tc.wantErr) {
+					t.Fatalf("expected a network-class verification failure containing %q, got err=%v payload=%v", tc.wantErr, err, payload)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("backup export --verify failed: %v", err)
+			}
+			backup := payload["backup"].(map[string]any)
+			if payload["verified"] != true || backup["sha256"] != hex.EncodeToString(sum[:]) || backup["bytes"] != float64(len(archive)) || backup["entries"] != float64(2) || backup["gatewayVersion"] != "8.1.44 (b2024101512)" {
+				t.Fatalf("unexpected verification payload: %v", payload)
+			}
+			if _, hasLength := backup["contentLength"]; hasLength != (tc.contentLength != "") {
+				t.Fatalf("contentLength should only be reported when the gateway sent one: %v", backup)
+			}
+		})
+	}
+}
+
+func TestBackupInspectListsEntriesOffline(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "gateway.gwbk")
+	if err := os.WriteFile(path, buildTestGWBK(t), 0o600); err != nil {
+		t.Fatalf("write archive: %v", err)
+	}
+	c := newAdminWrapperTestCLI(newMockHTTPClient(func(*http.Request) (*http.Response, error) {
+		t.Fatal("backup inspect must not contact a gateway")
+		return nil, nil
+	}))
+	out := c.Out.(*bytes.Buffer)
+
+	if err := c.Execute([]string{"backup", "inspect", "--in", path}); err != nil {
+		t.Fatalf("backup inspect failed: %v", err)
+	}
+	wantText := "gateway_version\t8.1.44 (b2024101512)\n" +
+		"NAME\tSIZE\tMODIFIED\n" +
+		"backupinfo.xml\t129\t2026-03-04T05:06:07Z\n" +
+		"db_backup_sqlite.idb\t2048\t2026-03-04T05:06:07Z\n"
+	if out.String() != wantText {
+		t.Fatalf("unexpected text output:\n%s", out.String())
+	}
+
+	out.Reset()
+	if err := c.Execute([]string{"backup", "inspect", "--in", path, "--json"}); err != nil {
+		t.Fatalf("backup inspect --json failed: %v", err)
+	}
+	var archive backupArchive
+	if err := json.Unmarshal(out.Bytes(), &archive); err != nil {
+		t.Fatalf("decode inspect output: %v", err)
+	}
+	if archive.GatewayVersion != "8.1.44 (b2024101512)" || len(archive.Entries) != 2 || archive.Entries[1].Size != 2048 {
+		t.Fatalf("unexpected inspect payload: %+v", archive)
+	}
+
+	requireUsageExitCode(t, c.Execute([]string{"backup", "inspect"}))
+	notZip := filepath.Join(t.TempDir(), "broken.gwbk")
+	if err := os.WriteFile(notZip, []byte("not a zip"), 0o600); err != nil {
+		t.Fatalf("write broken archive: %v", err)
+	}
+	if code := igwerr.ExitCode(c.Execute([]string{"backup", "inspect", "--in", notZip})); code != 7 {
+		t.Fatalf("expected an unreadable archive to exit 7, got %d", code)
+	}
+}
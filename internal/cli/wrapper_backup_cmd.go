@@ -13,9 +13,13 @@ func (c *CLI) runBackupExport(args []string) error {
 
 	var common wrapperCommon
 	var outPath string
+	var progress bool
 	var includePeerLocal string
+	var verify bool
 	bindWrapperCommon(fs, &common)
 	fs.StringVar(&outPath, "out", "", "Write gateway backup (.gwbk) to file")
+	fs.BoolVar(&progress, "progress", false, "Print throttled download progress to stderr")
+	fs.BoolVar(&verify, "verify", false, "Check the written archive against Content-Length and its zip directory, and print its sha256")
 	fs.StringVar(&includePeerLocal, "include-peer-local", "", "Set includePeerLocal query to true/false")
 
 	if err := parseWrapperFlagSet(fs, args); err != nil {
@@ -27,16 +31,26 @@ func (c *CLI) runBackupExport(args []string) error {
 		return err
 	}
 
+	resolvedOut := chooseDefaultOutPath(outPath, "gateway.gwbk")
+	if verify {
+		var query []string
+		if normalizedIncludePeerLocal != "" {
+			query = append(query, "includePeerLocal="+normalizedIncludePeerLocal)
+		}
+		return c.runBackupExportVerify(common, query, resolvedOut, progress)
+	}
+
 	callArgs := []string{"--method", "GET", "--path", "/data/api/v1/backup"}
-	callArgs = append(callArgs, common.callArgs()...)
 	if normalizedIncludePeerLocal != "" {
 		callArgs = append(callArgs, "--query", "includePeerLocal="+normalizedIncludePeerLocal)
 	}
-	resolvedOut := chooseDefaultOutPath(outPath, "gateway.gwbk")
 	if resolvedOut != "" {
 		callArgs = append(callArgs, "--out", resolvedOut)
 	}
-	return c.runCall(callArgs)
+	if progress {
+		callArgs = append(callArgs, "--progress")
+	}
+	return c.runWrapperCall(common, callArgs)
 }
 
 func (c *CLI) runBackupRestore(args []string) error {
@@ -86,7 +100,6 @@ func (c *CLI) runBackupRestore(args []string) error {
 		"--content-type", "application/octet-stream",
 		"--yes",
 	}
-	callArgs = append(callArgs, common.callArgs()...)
 	if normalizedRestoreDisabled != "" {
 		callArgs = append(callArgs, "--query", "restoreDisabled="+normalizedRestoreDisabled)
 	}
@@ -96,5 +109,5 @@ func (c *CLI) runBackupRestore(args []string) error {
 	if normalizedRenameEnabled != "" {
 		callArgs = append(callArgs, "--query", "renameEnabled="+normalizedRenameEnabled)
 	}
-	return c.runCall(callArgs)
+	return c.runWrapperCall(common, callArgs)
 }
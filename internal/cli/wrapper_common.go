@@ -3,59 +3,114 @@ package cli
 import (
 	"flag"
 	"fmt"
+	"io"
+	"net/http"
 	"path/filepath"
+	"sort"
 	"strings"
 	"time"
 
+	"github.com/alex-mccollum/igw-cli/internal/config"
+	"github.com/alex-mccollum/igw-cli/internal/gateway"
 	"github.com/alex-mccollum/igw-cli/internal/igwerr"
 )
 
 type wrapperCommon struct {
-	gatewayURL     string
-	apiKey         string
-	apiKeyStdin    bool
-	profile        string
-	timeout        time.Duration
-	jsonOutput     bool
-	compactJSON    bool
-	selectors      stringList
-	rawOutput      bool
-	includeHeaders bool
-	timing         bool
-	jsonStats      bool
+	gatewayURL  string
+	apiKey      string
+	apiKeyStdin bool
+	// passphraseStdin reads the config passphrase from stdin.
+	passphraseStdin bool
+	tokenEnv        string
+	profile         string
+	timeout         time.Duration
+	jsonOutput      bool
+	compactJSON     bool
+	selectors       stringList
+	expr            string
+	exprBody        bool
+	rawOutput       bool
+	includeHeaders  bool
+	timing          bool
+	jsonStats       bool
+	signKey         string
+	signHeader      string
+	expectStatus    stringList
+	har             harOptions
+	verbose         verboseLevel
+	// proxy and noProxy override the profile proxyURL and the environment.
+	proxy   string
+	noProxy bool
+	tls     tlsFlags
+	// headers holds --header for wrappers; call binds its own.
+	headers stringList
 }
 
 func bindWrapperCommon(fs *flag.FlagSet, common *wrapperCommon) {
 	bindWrapperCommonWithDefaults(fs, common, 8*time.Second, true)
+	bindHARFlags(fs, &common.har)
+	bindVerboseFlags(fs, &common.verbose)
+	bindExpectStatusFlag(fs, common)
+	fs.Var(&common.headers, "header", "Request header key:value, overriding config defaultHeaders (repeatable)")
+}
+
+func bindExpectStatusFlag(fs *flag.FlagSet, common *wrapperCommon) {
+	fs.Var(&common.expectStatus, "expect-status", "Fail with exit code 8 unless the status matches: a code or class like 2xx (repeatable)")
 }
 
 func bindWrapperCommonWithDefaults(fs *flag.FlagSet, common *wrapperCommon, timeoutDefault time.Duration, includeHeaders bool) {
 	fs.StringVar(&common.gatewayURL, "gateway-url", "", "Gateway base URL")
 	fs.StringVar(&common.apiKey, "api-key", "", "Ignition API token")
 	fs.BoolVar(&common.apiKeyStdin, "api-key-stdin", false, "Read API token from stdin")
+	fs.BoolVar(&common.passphraseStdin, "passphrase-stdin", false, "Read the passphrase for encrypted config tokens from stdin")
+	fs.StringVar(&common.tokenEnv, "token-env", "", "Read API token from the named environment variable")
 	fs.StringVar(&common.profile, "profile", "", "Config profile name")
 	fs.DurationVar(&common.timeout, "timeout", timeoutDefault, "Request timeout")
 	fs.BoolVar(&common.jsonOutput, "json", false, "Print JSON envelope")
 	fs.BoolVar(&common.compactJSON, "compact", false, "Print compact one-line JSON (requires --json)")
 	fs.Var(&common.selectors, "select", "Select JSON path from output (repeatable, requires --json)")
-	fs.BoolVar(&common.rawOutput, "raw", false, "Print selected value as plain text (requires --json and exactly one --select)")
+	fs.StringVar(&common.expr, "expr", "", "Filter JSON output with an expression: wildcards (items[*].name), filters (items[?(@.enabled)]), | length, | keys (requires --json)")
+	fs.BoolVar(&common.exprBody, "expr-body", false, "Apply --expr to the parsed response body instead of the JSON envelope")
+	fs.BoolVar(&common.rawOutput, "raw", false, "Print selected value as plain text (requires --json and exactly one --select, or --expr)")
 	fs.BoolVar(&common.timing, "timing", false, "Include command timing output")
 	fs.BoolVar(&common.jsonStats, "json-stats", false, "Include runtime stats in JSON output")
+	fs.StringVar(&common.signKey, "sign-key", "", "HMAC-SHA256 key for signing each request")
+	fs.StringVar(&common.signHeader, "sign-header", "", "Request signature header name (default "+gateway.DefaultSignHeader+"; requires --sign-key)")
+	fs.StringVar(&common.proxy, "proxy", "", "Proxy URL: http://, https://, or socks5:// (overrides profile proxyURL and HTTPS_PROXY)")
+	fs.BoolVar(&common.noProxy, "no-proxy", false, "Connect directly, ignoring the profile proxyURL and proxy environment variables")
+	bindTLSFlags(fs, &common.tls)
 	if includeHeaders {
 		fs.BoolVar(&common.includeHeaders, "include-headers", false, "Include response headers")
 	}
 }
 
-func (w wrapperCommon) callArgs() []string {
-	args := []string{
-		"--timeout", w.timeout.String(),
+// selectOptions builds the output selection from --select, --expr, and
+// --raw.
+func (w wrapperCommon) selectOptions() (jsonSelectOptions, error) {
+	return newJSONExprSelectOptions(w.jsonOutput, w.compactJSON, w.rawOutput, w.selectors, w.expr, w.exprBody)
+}
+
+// runWrapperCall delegates a wrapper command to runCall with the shared
+// wrapper flags appended, so every wrapper inherits connection settings and
+// output selection (--json, --select, --raw, --compact) the same way.
+func (c *CLI) runWrapperCall(common wrapperCommon, callArgs []string) error {
+	args := append(append([]string(nil), callArgs...), common.callArgs()...)
+	return c.runCall(args)
+}
+
+// applyTokenEnv fills apiKey from the --token-env variable when no explicit
+// key was given, so it ranks below --api-key but above IGNITION_API_TOKEN.
+func (w *wrapperCommon) applyTokenEnv(getenv func(string) string) {
+	name := strings.TrimSpace(w.tokenEnv)
+	if name == "" || strings.TrimSpace(w.apiKey) != "" || getenv == nil {
+		return
 	}
-	args = append(args, w.callArgsExcludingTimeout()...)
-	return args
+	w.apiKey = strings.TrimSpace(getenv(name))
 }
 
-func (w wrapperCommon) callArgsExcludingTimeout() []string {
-	args := make([]string, 0, 10)
+func (w wrapperCommon) callArgs() []string {
+	args := make([]string, 0, 12)
+	args = append(args, "--timeout", w.timeout.String())
 	if strings.TrimSpace(w.gatewayURL) != "" {
 		args = append(args, "--gateway-url", strings.TrimSpace(w.gatewayURL))
 	}
@@ -65,6 +120,12 @@ func (w wrapperCommon) callArgsExcludingTimeout() []string {
 	if w.apiKeyStdin {
 		args = append(args, "--api-key-stdin")
 	}
+	if w.passphraseStdin {
+		args = append(args, "--passphrase-stdin")
+	}
+	if strings.TrimSpace(w.tokenEnv) != "" {
+		args = append(args, "--token-env", strings.TrimSpace(w.tokenEnv))
+	}
 	if strings.TrimSpace(w.profile) != "" {
 		args = append(args, "--profile", strings.TrimSpace(w.profile))
 	}
@@ -79,6 +140,12 @@ func (w wrapperCommon) callArgsExcludingTimeout() []string {
 			args = append(args, "--select", strings.TrimSpace(selector))
 		}
 	}
+	if strings.TrimSpace(w.expr) != "" {
+		args = append(args, "--expr", strings.TrimSpace(w.expr))
+	}
+	if w.exprBody {
+		args = append(args, "--expr-body")
+	}
 	if w.rawOutput {
 		args = append(args, "--raw")
 	}
@@ -91,9 +158,224 @@ func (w wrapperCommon) callArgsExcludingTimeout() []string {
 	if w.jsonStats {
 		args = append(args, "--json-stats")
 	}
+	if w.signKey != "" {
+		args = append(args, "--sign-key", w.signKey)
+	}
+	if strings.TrimSpace(w.signHeader) != "" {
+		args = append(args, "--sign-header", strings.TrimSpace(w.signHeader))
+	}
+	for _, status := range w.expectStatus {
+		args = append(args, "--expect-status", status)
+	}
+	if strings.TrimSpace(w.proxy) != "" {
+		args = append(args, "--proxy", strings.TrimSpace(w.proxy))
+	}
+	if w.noProxy {
+		args = append(args, "--no-proxy")
+	}
+	for _, header := range w.headers {
+		args = append(args, "--header", header)
+	}
+	args = append(args, w.tls.args()...)
+	args = append(args, w.har.args()...)
+	args = append(args, w.verbose.args()...)
 	return args
 }
 
+// clientProxy picks the proxy for requests to resolved's gateway.
+func (w wrapperCommon) clientProxy(resolved config.Effective) (gateway.Proxy, error) {
+	return resolveClientProxy(w.proxy, w.noProxy, resolved)
+}
+
+// clientHeaders merges the config defaultHeaders with --header for wrappers
+// that build their own client; --header wins for the same name.
+func (w wrapperCommon) clientHeaders(resolved config.Effective) (http.Header, error) {
+	headers, err := configDefaultHeaders(resolved)
+	if err != nil {
+		return nil, err
+	}
+	flagHeaders := make(http.Header)
+	for _, pair := range w.headers {
+		key, value, ok := strings.Cut(pair, ":")
+		if !ok || strings.TrimSpace(key) == "" {
+			return nil, &igwerr.UsageError{Msg: fmt.Sprintf("invalid --header value %q (expected key:value)", pair)}
+		}
+		if strings.EqualFold(strings.TrimSpace(key), gateway.TokenHeader) {
+			return nil, &igwerr.UsageError{Msg: fmt.Sprintf("header %q is managed by the CLI and cannot be overridden", gateway.TokenHeader)}
+		}
+		flagHeaders.Add(strings.TrimSpace(key), strings.TrimSpace(value))
+	}
+	if len(flagHeaders) == 0 {
+		return headers, nil
+	}
+	if headers == nil {
+		headers = make(http.Header)
+	}
+	for name, values := range flagHeaders {
+		headers[name] = values
+	}
+	return headers, nil
+}
+
+// readCredentials applies --api-key-stdin, --passphrase-stdin, and
+// --token-env to common before the runtime config is resolved.
+func (c *CLI) readCredentials(common *wrapperCommon) error {
+	if common.apiKeyStdin {
+		if common.apiKey != "" {
+			return &igwerr.UsageError{Msg: "use only one of --api-key or --api-key-stdin"}
+		}
+		tokenBytes, err := io.ReadAll(c.In)
+		if err != nil {
+			return igwerr.NewTransportError(err)
+		}
+		common.apiKey = strings.TrimSpace(string(tokenBytes))
+	}
+	if err := c.readPassphraseStdin(*common); err != nil {
+		return err
+	}
+	common.applyTokenEnv(c.Getenv)
+	return nil
+}
+
+// newGatewayClient resolves the runtime config for a command that builds its
+// own client and returns that client with the --har and --verbose recorders
+// attached. The returned func saves the --har file and must be deferred.
+func (c *CLI) newGatewayClient(common wrapperCommon) (*gateway.Client, config.Effective, func(), error) {
+	if err := c.readCredentials(&common); err != nil {
+		return nil, config.Effective{}, nil, err
+	}
+	resolved, err := c.resolveRuntimeConfig(common.profile, common.gatewayURL, common.apiKey)
+	if err != nil {
+		return nil, config.Effective{}, nil, err
+	}
+	if strings.TrimSpace(resolved.GatewayURL) == "" {
+		return nil, config.Effective{}, nil, &igwerr.UsageError{Msg: "required: --gateway-url (or IGNITION_GATEWAY_URL/config)"}
+	}
+	if strings.TrimSpace(resolved.Token) == "" {
+		return nil, config.Effective{}, nil, &igwerr.UsageError{Msg: "required: --api-key (or IGNITION_API_TOKEN/config)"}
+	}
+	if common.timeout <= 0 {
+		return nil, config.Effective{}, nil, &igwerr.UsageError{Msg: "--timeout must be positive"}
+	}
+	signer, err := common.requestSigner()
+	if err != nil {
+		return nil, config.Effective{}, nil, err
+	}
+	proxy, err := common.clientProxy(resolved)
+	if err != nil {
+		return nil, config.Effective{}, nil, err
+	}
+	httpClient, err := c.gatewayHTTPClient(common.tls, resolved)
+	if err != nil {
+		return nil, config.Effective{}, nil, err
+	}
+	headers, err := common.clientHeaders(resolved)
+	if err != nil {
+		return nil, config.Effective{}, nil, err
+	}
+
+	har := newHARRecorder(common.har)
+	verbose := c.newVerboseTracer(common.verbose, resolved.Token)
+	client := &gateway.Client{
+		BaseURL:        resolved.GatewayURL,
+		Token:          resolved.Token,
+		HTTP:           httpClient,
+		Signer:         signer,
+		Proxy:          proxy,
+		Recorder:       chainRecorders(har.recorder(), verbose.recorder()),
+		OnRetry:        verbose.onRetry(),
+		DefaultHeaders: headers,
+	}
+	return client, resolved, func() { c.saveHAR(har) }, nil
+}
+
+// configDefaultHeaders returns the resolved defaultHeaders as request
+// headers, or nil when none are configured.
+func configDefaultHeaders(resolved config.Effective) (http.Header, error) {
+	if len(resolved.DefaultHeaders) == 0 {
+		return nil, nil
+	}
+	headers := make(http.Header, len(resolved.DefaultHeaders))
+	for name, value := range resolved.DefaultHeaders {
+		if name == "" {
+			return nil, &igwerr.UsageError{Msg: "config defaultHeaders: empty header name"}
+		}
+		if strings.EqualFold(name, gateway.TokenHeader) {
+			return nil, &igwerr.UsageError{Msg: fmt.Sprintf("config defaultHeaders: header %q is managed by the CLI and cannot be overridden", gateway.TokenHeader)}
+		}
+		headers.Set(name, value)
+	}
+	return headers, nil
+}
+
+// requestHeaderNames lists the header names a request sends on top of the
+// token: its own key:value pairs plus the defaults, sorted.
+func requestHeaderNames(defaults http.Header, pairs []string) []string {
+	seen := make(map[string]bool, len(defaults)+len(pairs))
+	for name := range defaults {
+		seen[name] = true
+	}
+	for _, pair := range pairs {
+		if key, _, ok := strings.Cut(pair, ":"); ok && strings.TrimSpace(key) != "" {
+			seen[http.CanonicalHeaderKey(strings.TrimSpace(key))] = true
+		}
+	}
+	names := make([]string, 0, len(seen))
+	for name := range seen {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// resolveClientProxy applies proxy precedence: --no-proxy, then --proxy,
+// then the profile (or top-level) proxyURL. The zero Proxy leaves the
+// choice to HTTPS_PROXY, HTTP_PROXY, and NO_PROXY.
+func resolveClientProxy(flagProxy string, noProxy bool, resolved config.Effective) (gateway.Proxy, error) {
+	flagProxy = strings.TrimSpace(flagProxy)
+	if noProxy {
+		if flagProxy != "" {
+			return gateway.Proxy{}, &igwerr.UsageError{Msg: "use only one of --proxy or --no-proxy"}
+		}
+		return gateway.Proxy{Disabled: true}, nil
+	}
+	raw, source := flagProxy, "--proxy"
+	if raw == "" {
+		raw, source = strings.TrimSpace(resolved.ProxyURL), "config proxyURL"
+	}
+	if raw == "" {
+		return gateway.Proxy{}, nil
+	}
+	proxyURL, err := gateway.ParseProxyURL(raw)
+	if err != nil {
+		return gateway.Proxy{}, &igwerr.UsageError{Msg: fmt.Sprintf("%s: %v", source, err)}
+	}
+	return gateway.Proxy{URL: proxyURL}, nil
+}
+
+// requestSigner builds the --sign-key signer, or nil when signing is off.
+func (w wrapperCommon) requestSigner() (*gateway.RequestSigner, error) {
+	header := strings.TrimSpace(w.signHeader)
+	if w.signKey == "" {
+		if header != "" {
+			return nil, &igwerr.UsageError{Msg: "--sign-header requires --sign-key"}
+		}
+		return nil, nil
+	}
+	return &gateway.RequestSigner{Key: []byte(w.signKey), Header: header}, nil
+}
+
+// flagWasSet reports whether name was given explicitly on the command line.
+func flagWasSet(fs *flag.FlagSet, name string) bool {
+	set := false
+	fs.Visit(func(f *flag.Flag) {
+		if f.Name == name {
+			set = true
+		}
+	})
+	return set
+}
+
 func parseWrapperFlagSet(fs *flag.FlagSet, args []string) error {
 	if err := fs.Parse(args); err != nil {
 		return &igwerr.UsageError{Msg: err.Error()}
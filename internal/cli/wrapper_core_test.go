@@ -6,6 +6,7 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"strings"
+	"sync"
 	"testing"
 
 	"github.com/alex-mccollum/igw-cli/internal/config"
@@ -313,3 +314,72 @@ func TestGatewayInfoWrapperPropagatesRawSelect(t *testing.T) {
 		t.Fatalf("unexpected raw output %q", out.String())
 	}
 }
+
+func TestDefaultHeadersReachWrappersAndCall(t *testing.T) {
+	t.Parallel()
+
+	// gateway status sends its sub-requests concurrently.
+	var mu sync.Mutex
+	got := map[string][]http.Header{}
+	c := newAdminWrapperTestCLI(newMockHTTPClient(func(r *http.Request) (*http.Response, error) {
+		mu.Lock()
+		got[r.URL.Path] = append(got[r.URL.Path], r.Header.Clone())
+		mu.Unlock()
+		return mockHTTPResponse(http.StatusOK, `{"state":"RUNNING"}`, nil), nil
+	}))
+	c.ReadConfig = func() (config.File, error) {
+		return config.File{
+			GatewayURL:     mockGatewayURL,
+			Token:          "secret",
+			DefaultHeaders: map[string]string{"X-Org-Id": "acme", "X-Env": "prod"},
+		}, nil
+	}
+	out := c.Out.(*bytes.Buffer)
+
+	if err := c.Execute([]string{"gateway", "info", "--header", "X-Env: dev"}); err != nil {
+		t.Fatalf("gateway info: %v", err)
+	}
+	if err := c.Execute([]string{"gateway", "status", "--header", "X-Env: dev"}); err != nil {
+		t.Fatalf("gateway status: %v", err)
+	}
+	mu.Lock()
+	wantRequests := map[string]int{
+		"/data/api/v1/gateway-info":          2,
+		"/data/api/v1/restart-tasks/pending": 1,
+		"/data/api/v1/logs":                  1,
+	}
+	for path, want := range wantRequests {
+		if len(got[path]) != want {
+			t.Fatalf("%s: expected %d requests, got %d", path, want, len(got[path]))
+		}
+		for _, headers := range got[path] {
+			if headers.Get("X-Org-Id") != "acme" || strings.Join(headers.Values("X-Env"), ",") != "dev" {
+				t.Fatalf("%s: expected defaults with --header winning, got %v", path, headers)
+			}
+		}
+	}
+	mu.Unlock()
+
+	out.Reset()
+	if err := c.Execute([]string{"call", "--path", "/data/api/v1/gateway-info", "--header", "X-Trace: 1", "--json"}); err != nil {
+		t.Fatalf("call: %v", err)
+	}
+	var envelope struct {
+		Request callJSONRequest `json:"request"`
+	}
+	if err := json.Unmarshal(out.Bytes(), &envelope); err != nil {
+		t.Fatalf("decode call envelope: %v", err)
+	}
+	if strings.Join(envelope.Request.HeaderNames, ",") != "X-Env,X-Org-Id,X-Trace" {
+		t.Fatalf("expected merged header names, got %v", envelope.Request.HeaderNames)
+	}
+
+	rejected := newAdminWrapperTestCLI(newMockHTTPClient(func(*http.Request) (*http.Response, error) {
+		t.Fatal("a rejected config must not reach the gateway")
+		return nil, nil
+	}))
+	rejected.ReadConfig = func() (config.File, error) {
+		return config.File{GatewayURL: mockGatewayURL, Token: "secret", DefaultHeaders: map[string]string{"X-Ignition-API-Token": "other"}}, nil
+	}
+	requireUsageExitCode(t, rejected.Execute([]string{"gateway", "info"}))
+}
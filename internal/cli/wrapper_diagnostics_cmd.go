@@ -23,8 +23,7 @@ func (c *CLI) runDiagnosticsBundleGenerate(args []string) error {
 	}
 
 	callArgs := []string{"--method", "POST", "--path", "/data/api/v1/diagnostics/bundle/generate", "--yes"}
-	callArgs = append(callArgs, common.callArgs()...)
-	return c.runCall(callArgs)
+	return c.runWrapperCall(common, callArgs)
 }
 
 func (c *CLI) runDiagnosticsBundleStatus(args []string) error {
@@ -39,8 +38,7 @@ func (c *CLI) runDiagnosticsBundleStatus(args []string) error {
 	}
 
 	callArgs := []string{"--method", "GET", "--path", "/data/api/v1/diagnostics/bundle/status"}
-	callArgs = append(callArgs, common.callArgs()...)
-	return c.runCall(callArgs)
+	return c.runWrapperCall(common, callArgs)
 }
 
 func (c *CLI) runDiagnosticsBundleDownload(args []string) error {
@@ -49,18 +47,22 @@ func (c *CLI) runDiagnosticsBundleDownload(args []string) error {
 
 	var common wrapperCommon
 	var outPath string
+	var progress bool
 	bindWrapperCommon(fs, &common)
 	fs.StringVar(&outPath, "out", "", "Write diagnostics bundle to file")
+	fs.BoolVar(&progress, "progress", false, "Print throttled download progress to stderr")
 
 	if err := parseWrapperFlagSet(fs, args); err != nil {
 		return err
 	}
 
 	callArgs := []string{"--method", "GET", "--path", "/data/api/v1/diagnostics/bundle/download"}
-	callArgs = append(callArgs, common.callArgs()...)
 	resolvedOut := chooseDefaultOutPath(outPath, "diagnostics.zip")
 	if resolvedOut != "" {
 		callArgs = append(callArgs, "--out", resolvedOut)
 	}
-	return c.runCall(callArgs)
+	if progress {
+		callArgs = append(callArgs, "--progress")
+	}
+	return c.runWrapperCall(common, callArgs)
 }
@@ -10,11 +10,12 @@ import (
 func (c *CLI) runLogs(args []string) error {
 	return c.runWrapperSubcommand(
 		args,
-		"Usage: igw logs <list|download|loggers|logger|level-reset> [flags]",
+		"Usage: igw logs <list|tail|download|loggers|logger|level-reset> [flags]",
 		"required logs subcommand",
 		"unknown logs subcommand %q",
 		map[string]func([]string) error{
 			"list":        c.runLogsList,
+			"tail":        c.runLogsTail,
 			"download":    c.runLogsDownload,
 			"loggers":     c.runLogsLoggers,
 			"logger":      c.runLogsLogger,
@@ -48,12 +49,13 @@ func (c *CLI) runDiagnostics(args []string) error {
 func (c *CLI) runBackup(args []string) error {
 	return c.runWrapperSubcommand(
 		args,
-		"Usage: igw backup <export|restore> [flags]",
+		"Usage: igw backup <export|restore|inspect> [flags]",
 		"required backup subcommand",
 		"unknown backup subcommand %q",
 		map[string]func([]string) error{
 			"export":  c.runBackupExport,
 			"restore": c.runBackupRestore,
+			"inspect": c.runBackupInspect,
 		},
 	)
 }
@@ -61,16 +63,49 @@ func (c *CLI) runBackup(args []string) error {
 func (c *CLI) runTags(args []string) error {
 	return c.runWrapperSubcommand(
 		args,
-		"Usage: igw tags <export|import> [flags]",
+		"Usage: igw tags <browse|read|write|export|import> [flags]",
 		"required tags subcommand",
 		"unknown tags subcommand %q",
 		map[string]func([]string) error{
+			"browse": c.runTagsBrowse,
+			"read":   c.runTagsRead,
+			"write":  c.runTagsWrite,
 			"export": c.runTagsExport,
 			"import": c.runTagsImport,
 		},
 	)
 }
 
+func (c *CLI) runModules(args []string) error {
+	return c.runWrapperSubcommand(
+		args,
+		"Usage: igw modules <list|install|uninstall|restart> [flags]",
+		"required modules subcommand",
+		"unknown modules subcommand %q",
+		map[string]func([]string) error{
+			"list":      c.runModulesList,
+			"install":   c.runModulesInstall,
+			"uninstall": c.runModulesUninstall,
+			"restart":   c.runModulesRestart,
+		},
+	)
+}
+
+func (c *CLI) runProjects(args []string) error {
+	return c.runWrapperSubcommand(
+		args,
+		"Usage: igw projects <list|export|import|delete> [flags]",
+		"required projects subcommand",
+		"unknown projects subcommand %q",
+		map[string]func([]string) error{
+			"list":   c.runProjectsList,
+			"export": c.runProjectsExport,
+			"import": c.runProjectsImport,
+			"delete": c.runProjectsDelete,
+		},
+	)
+}
+
 func (c *CLI) runRestart(args []string) error {
 	return c.runWrapperSubcommand(
 		args,
@@ -87,11 +122,12 @@ func (c *CLI) runRestart(args []string) error {
 func (c *CLI) runGateway(args []string) error {
 	return c.runWrapperSubcommand(
 		args,
-		"Usage: igw gateway <info> [flags]",
+		"Usage: igw gateway <info|status> [flags]",
 		"required gateway subcommand",
 		"unknown gateway subcommand %q",
 		map[string]func([]string) error{
-			"info": c.runGatewayInfo,
+			"info":   c.runGatewayInfo,
+			"status": c.runGatewayStatus,
 		},
 	)
 }
@@ -103,12 +139,13 @@ func (c *CLI) runScan(args []string) error {
 	}
 	return c.runWrapperSubcommand(
 		args,
-		"Usage: igw scan <projects|config> [flags]",
+		"Usage: igw scan <projects|config|resources> [flags]",
 		"required scan subcommand",
 		"unknown scan subcommand %q",
 		map[string]func([]string) error{
-			scanSubcommandProjects: c.runScanProjects,
-			scanSubcommandConfig:   c.runScanConfig,
+			scanSubcommandProjects:  c.runScanProjects,
+			scanSubcommandConfig:    c.runScanConfig,
+			scanSubcommandResources: c.runScanResources,
 		},
 	)
 }
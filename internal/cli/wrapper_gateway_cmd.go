@@ -4,6 +4,8 @@ import (
 	"flag"
 	"fmt"
 	"time"
+
+	"github.com/alex-mccollum/igw-cli/internal/igwerr"
 )
 
 func (c *CLI) runGatewayInfo(args []string) error {
@@ -14,26 +16,50 @@ func (c *CLI) runGatewayInfo(args []string) error {
 	var retry int
 	var retryBackoff time.Duration
 	var outPath string
+	var outputFormat string
+	var watch bool
+	var watchOpts gatewayWatchOptions
+	var fanout callFanoutOptions
 	bindWrapperCommon(fs, &common)
+	bindCallFanoutFlags(fs, &fanout)
 	fs.IntVar(&retry, "retry", 0, "Retry attempts for idempotent requests")
 	fs.DurationVar(&retryBackoff, "retry-backoff", 250*time.Millisecond, "Retry backoff duration")
 	fs.StringVar(&outPath, "out", "", "Write response body to file")
+	fs.StringVar(&outputFormat, "output", outputFormatText, "Response output format: text|kv (field<TAB>value rows)")
+	fs.BoolVar(&watch, "watch", false, "Poll gateway-info every --interval and print each response")
+	fs.BoolVar(&watchOpts.diff, "watch-diff", false, "Poll like --watch but print only top-level fields that changed since the previous poll")
+	fs.DurationVar(&watchOpts.interval, "interval", 2*time.Second, "Polling interval for --watch")
+	fs.IntVar(&watchOpts.count, "count", 0, "Stop --watch after N polls (0 = until interrupted)")
 
 	if err := parseWrapperFlagSet(fs, args); err != nil {
 		return err
 	}
+	if watch || watchOpts.diff {
+		if outPath != "" {
+			return &igwerr.UsageError{Msg: "--out is not supported with --watch"}
+		}
+		if flagWasSet(fs, "output") {
+			return &igwerr.UsageError{Msg: "--output is not supported with --watch"}
+		}
+		if fanout.enabled() {
+			return &igwerr.UsageError{Msg: "--all-profiles and --profiles are not supported with --watch"}
+		}
+		return c.runGatewayInfoWatch(common, retry, retryBackoff, watchOpts)
+	}
 
 	callArgs := []string{
 		"--method", "GET",
 		"--path", "/data/api/v1/gateway-info",
-		"--timeout", common.timeout.String(),
 		"--retry", fmt.Sprintf("%d", retry),
 		"--retry-backoff", retryBackoff.String(),
 	}
-	callArgs = append(callArgs, common.callArgsExcludingTimeout()...)
 	if outPath != "" {
 		callArgs = append(callArgs, "--out", outPath)
 	}
+	if flagWasSet(fs, "output") {
+		callArgs = append(callArgs, "--output", outputFormat)
+	}
+	callArgs = append(callArgs, fanout.args()...)
 
-	return c.runCall(callArgs)
+	return c.runWrapperCall(common, callArgs)
 }
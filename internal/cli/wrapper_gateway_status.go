@@ -0,0 +1,275 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/alex-mccollum/igw-cli/internal/gateway"
+	"github.com/alex-mccollum/igw-cli/internal/igwerr"
+)
+
+const gatewayStatusUnknown = "unknown"
+
+// Sub-requests of `gateway status`, also the keys of stats.requests.
+const (
+	gatewayStatusInfo     = "gatewayInfo"
+	gatewayStatusRestarts = "restartTasks"
+	gatewayStatusLogs     = "logs"
+)
+
+// gatewayStatusSubrequest is the outcome of one sub-request.
+type gatewayStatusSubrequest struct {
+	Status   int                 `json:"status,omitempty"`
+	TimingMs int64               `json:"timingMs"`
+	HTTP     *gateway.CallTiming `json:"http,omitempty"`
+	Error    string              `json:"error,omitempty"`
+
+	body []byte
+	err  error
+}
+
+func (c *CLI) runGatewayStatus(args []string) error {
+	fs := flag.NewFlagSet("gateway status", flag.ContinueOnError)
+	fs.SetOutput(c.Err)
+
+	var common wrapperCommon
+	var errorsSince time.Duration
+	var errorLevel string
+	bindWrapperCommon(fs, &common)
+	fs.DurationVar(&errorsSince, "errors-since", 15*time.Minute, "Count log events from this far back")
+	fs.StringVar(&errorLevel, "error-level", "ERROR", "Minimum log level counted as an error")
+
+	if err := parseWrapperFlagSet(fs, args); err != nil {
+		return err
+	}
+	selectOpts, selectErr := common.selectOptions()
+	if selectErr != nil {
+		return c.printCallError(common.jsonOutput, selectionErrorOptions(selectOpts), selectErr)
+	}
+	if errorsSince <= 0 {
+		return c.printCallError(common.jsonOutput, selectOpts, &igwerr.UsageError{Msg: "--errors-since must be positive"})
+	}
+	if common.timeout <= 0 {
+		return c.printCallError(common.jsonOutput, selectOpts, &igwerr.UsageError{Msg: "--timeout must be positive"})
+	}
+	client, _, saveHAR, err := c.newGatewayClient(common)
+	if err != nil {
+		return c.printCallError(common.jsonOutput, selectOpts, err)
+	}
+	defer saveHAR()
+
+	start := time.Now()
+	since := c.clock().Add(-errorsSince)
+	results := fetchGatewayStatus(client, common.timeout, map[string]gateway.CallRequest{
+		gatewayStatusInfo:     {Method: http.MethodGet, Path: "/data/api/v1/gateway-info"},
+		gatewayStatusRestarts: {Method: http.MethodGet, Path: "/data/api/v1/restart-tasks/pending"},
+		gatewayStatusLogs: {
+			Method: http.MethodGet,
+			Path:   "/data/api/v1/logs",
+			Query: []string{
+				"minLevel=" + strings.ToUpper(strings.TrimSpace(errorLevel)),
+				"startTime=" + strconv.FormatInt(since.UnixMilli(), 10),
+			},
+		},
+	})
+	elapsedMs := time.Since(start).Milliseconds()
+
+	// Partial answers are still a status; only a gateway that answered
+	// nothing fails the command.
+	if results[gatewayStatusInfo].err != nil && results[gatewayStatusRestarts].err != nil && results[gatewayStatusLogs].err != nil {
+		return c.printCallError(common.jsonOutput, selectOpts, results[gatewayStatusInfo].err)
+	}
+
+	summary := summarizeGatewayStatus(results)
+	summary["errorWindow"] = errorsSince.String()
+	if common.jsonOutput {
+		payload := map[string]any{"ok": true}
+		for key, value := range summary {
+			payload[key] = value
+		}
+		if common.jsonStats || common.timing {
+			payload["stats"] = map[string]any{
+				"elapsedMs": elapsedMs,
+				"requests":  results,
+			}
+		}
+		if selectWriteErr := printJSONSelection(c.Out, payload, selectOpts); selectWriteErr != nil {
+			return c.printCallError(common.jsonOutput, selectionErrorOptions(selectOpts), selectWriteErr)
+		}
+	} else {
+		writeGatewayStatusText(c.Out, summary)
+		if common.timing {
+			for _, name := range []string{gatewayStatusInfo, gatewayStatusRestarts, gatewayStatusLogs} {
+				fmt.Fprintf(c.Err, "timing\t%s\t%dms\n", name, results[name].TimingMs)
+			}
+			fmt.Fprintf(c.Err, "timing\ttotal\t%dms\n", elapsedMs)
+		}
+	}
+
+	return nil
+}
+
+// fetchGatewayStatus sends the sub-requests concurrently.
+func fetchGatewayStatus(client *gateway.Client, timeout time.Duration, requests map[string]gateway.CallRequest) map[string]*gatewayStatusSubrequest {
+	results := make(map[string]*gatewayStatusSubrequest, len(requests))
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	for name, req := range requests {
+		wg.Add(1)
+		go func(name string, req gateway.CallRequest) {
+			defer wg.Done()
+			req.Timeout = timeout
+			req.EnableTiming = true
+			start := time.Now()
+			resp, err := client.Call(context.Background(), req)
+			result := &gatewayStatusSubrequest{TimingMs: time.Since(start).Milliseconds(), err: err}
+			if err != nil {
+				result.Error = err.Error()
+				if statusErr, ok := err.(*igwerr.StatusError); ok {
+					result.Status = statusErr.StatusCode
+				}
+			} else {
+				result.Status = resp.StatusCode
+				result.HTTP = resp.Timing
+				result.body = resp.Body
+			}
+			mu.Lock()
+			results[name] = result
+			mu.Unlock()
+		}(name, req)
+	}
+	wg.Wait()
+	return results
+}
+
+// summarizeGatewayStatus extracts the summary fields. A field whose
+// sub-request failed or whose value is missing is "unknown", with the
+// reason in warnings.
+func summarizeGatewayStatus(results map[string]*gatewayStatusSubrequest) map[string]any {
+	summary := map[string]any{
+		"version":             gatewayStatusUnknown,
+		"edition":             gatewayStatusUnknown,
+		"uptime":              gatewayStatusUnknown,
+		"pendingRestartTasks": gatewayStatusUnknown,
+		"recentErrors":        gatewayStatusUnknown,
+	}
+	warnings := []string{}
+	warn := func(format string, args ...any) {
+		warnings = append(warnings, fmt.Sprintf(format, args...))
+	}
+
+	if info := results[gatewayStatusInfo]; info.err != nil {
+		warn("gateway-info: %v", info.err)
+	} else if body, err := decodeJSONBody(info.body); err != nil {
+		warn("gateway-info: %v", err)
+	} else {
+		for _, field := range []struct {
+			key   string
+			names []string
+		}{
+			{"version", []string{"version", "platformVersion"}},
+			{"edition", []string{"edition", "platformEdition"}},
+			{"uptime", []string{"uptime"}},
+		} {
+			if value, ok := firstStatusField(body, field.names); ok {
+				summary[field.key] = value
+			} else {
+				warn("gateway-info has no %s field", field.key)
+			}
+		}
+	}
+
+	if restarts := results[gatewayStatusRestarts]; restarts.err != nil {
+		warn("restart-tasks/pending: %v", restarts.err)
+	} else if body, err := decodePendingTasksBody(restarts.body); err != nil {
+		warn("restart-tasks/pending: %v", err)
+	} else {
+		summary["pendingRestartTasks"] = len(body.Pending)
+	}
+
+	if logs := results[gatewayStatusLogs]; logs.err != nil {
+		warn("logs: %v", logs.err)
+	} else if count, err := logEventCount(logs.body); err != nil {
+		warn("logs: %v", err)
+	} else {
+		summary["recentErrors"] = count
+	}
+
+	summary["warnings"] = warnings
+	return summary
+}
+
+// firstStatusField returns the first present, non-null field, flattening
+// non-string values to compact JSON.
+func firstStatusField(body map[string]any, names []string) (any, bool) {
+	for _, name := range names {
+		value, ok := body[name]
+		if !ok || value == nil {
+			continue
+		}
+		switch typed := value.(type) {
+		case string, float64, bool:
+			return typed, true
+		default:
+			return formatWatchValue(typed), true
+		}
+	}
+	return nil, false
+}
+
+// logEventCount prefers a numeric count or total field and otherwise
+// counts the returned events.
+func logEventCount(body []byte) (int, error) {
+	var object map[string]any
+	if json.Unmarshal(body, &object) == nil {
+		for _, name := range []string{"count", "total"} {
+			if number, ok := object[name].(float64); ok {
+				return int(number), nil
+			}
+		}
+	}
+	items, _, _, err := pageItems(body)
+	if err != nil {
+		return 0, err
+	}
+	return len(items), nil
+}
+
+func writeGatewayStatusText(w io.Writer, summary map[string]any) {
+	rows := [][2]string{
+		{"version", formatStatusValue(summary["version"])},
+		{"edition", formatStatusValue(summary["edition"])},
+		{"uptime", formatStatusValue(summary["uptime"])},
+		{"pending_restart_tasks", formatStatusValue(summary["pendingRestartTasks"])},
+		{"recent_errors", fmt.Sprintf("%s (last %s)", formatStatusValue(summary["recentErrors"]), summary["errorWindow"])},
+	}
+	for _, warning := range summary["warnings"].([]string) {
+		rows = append(rows, [2]string{"warning", warning})
+	}
+	width := 0
+	for _, row := range rows {
+		width = max(width, len(row[0]))
+	}
+	for _, row := range rows {
+		fmt.Fprintf(w, "%-*s  %s\n", width, row[0], row[1])
+	}
+}
+
+func formatStatusValue(value any) string {
+	switch typed := value.(type) {
+	case string:
+		return typed
+	case float64:
+		return formatUptime(typed)
+	default:
+		return fmt.Sprint(typed)
+	}
+}
@@ -0,0 +1,93 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+// gatewayStatusTestClient answers the three status sub-requests, using
+// logsStatus for the logs query, which it records.
+func gatewayStatusTestClient(logsStatus int) (*http.Client, *string) {
+	var logsQuery string
+	client := newMockHTTPClient(func(r *http.Request) (*http.Response, error) {
+		switch r.URL.Path {
+		case "/data/api/v1/gateway-info":
+			return mockHTTPResponse(http.StatusOK, `{"version":"8.1.44","edition":"standard","uptime":3725}`, nil), nil
+		case "/data/api/v1/restart-tasks/pending":
+			return mockHTTPResponse(http.StatusOK, `{"pending":["a","b"]}`, nil), nil
+		default:
+			logsQuery = r.URL.RawQuery
+			return mockHTTPResponse(logsStatus, `{"items":[{"level":"ERROR"},{"level":"ERROR"},{"level":"ERROR"}]}`, nil), nil
+		}
+	})
+	return client, &logsQuery
+}
+
+func TestGatewayStatusSummarizesSubrequests(t *testing.T) {
+	t.Parallel()
+
+	var out bytes.Buffer
+	client, logsQuery := gatewayStatusTestClient(http.StatusOK)
+	c := newAdminWrapperTestCLI(client)
+	c.Out = &out
+	err := c.Execute([]string{
+		"gateway", "status",
+		"--gateway-url", mockGatewayURL,
+		"--api-key", "secret",
+		"--errors-since", "1h",
+		"--json", "--json-stats",
+	})
+	if err != nil {
+		t.Fatalf("gateway status: %v", err)
+	}
+	if !strings.Contains(*logsQuery, "minLevel=ERROR") || !strings.Contains(*logsQuery, "startTime=") {
+		t.Fatalf("unexpected logs query %q", *logsQuery)
+	}
+
+	var payload struct {
+		OK           bool     `json:"ok"`
+		Version      string   `json:"version"`
+		Edition      string   `json:"edition"`
+		Pending      int      `json:"pendingRestartTasks"`
+		RecentErrors int      `json:"recentErrors"`
+		ErrorWindow  string   `json:"errorWindow"`
+		Warnings     []string `json:"warnings"`
+		Stats        struct {
+			Requests map[string]gatewayStatusSubrequest `json:"requests"`
+		} `json:"stats"`
+	}
+	if jsonErr := json.Unmarshal(out.Bytes(), &payload); jsonErr != nil {
+		t.Fatalf("parse: %v\n%s", jsonErr, out.String())
+	}
+	if !payload.OK || payload.Version != "8.1.44" || payload.Edition != "standard" || payload.Pending != 2 || payload.RecentErrors != 3 || payload.ErrorWindow != "1h0m0s" || len(payload.Warnings) != 0 {
+		t.Fatalf("unexpected payload %s", out.String())
+	}
+	if len(payload.Stats.Requests) != 3 || payload.Stats.Requests[gatewayStatusLogs].Status != http.StatusOK {
+		t.Fatalf("unexpected stats %s", out.String())
+	}
+}
+
+func TestGatewayStatusDegradesFailedSubrequest(t *testing.T) {
+	t.Parallel()
+
+	var out bytes.Buffer
+	client, _ := gatewayStatusTestClient(http.StatusNotFound)
+	c := newAdminWrapperTestCLI(client)
+	c.Out = &out
+	base := []string{"gateway", "status", "--gateway-url", mockGatewayURL, "--api-key", "secret"}
+	if err := c.Execute(base); err != nil {
+		t.Fatalf("gateway status: %v", err)
+	}
+
+	text := out.String()
+	for _, want := range []string{"version                8.1.44\n", "uptime                 3725\n", "pending_restart_tasks  2\n", "recent_errors          unknown (last 15m0s)\n", "warning                logs: "} {
+		if !strings.Contains(text, want) {
+			t.Fatalf("missing %q in output:\n%s", want, text)
+		}
+	}
+
+	requireUsageExitCode(t, c.Execute(append(base, "--errors-since", "0s")))
+}
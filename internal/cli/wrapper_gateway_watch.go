@@ -0,0 +1,144 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/alex-mccollum/igw-cli/internal/gateway"
+	"github.com/alex-mccollum/igw-cli/internal/igwerr"
+)
+
+// gatewayWatchOptions configures `gateway info --watch`.
+type gatewayWatchOptions struct {
+	interval time.Duration
+	count    int
+	diff     bool
+}
+
+// watchFieldChange is one top-level field that changed between polls.
+type watchFieldChange struct {
+	Old any `json:"old"`
+	New any `json:"new"`
+}
+
+// runGatewayInfoWatch polls gateway-info until interrupted or count polls
+// have run. With diff, each poll prints only the top-level fields that
+// changed since the previous one (all fields on the first poll).
+func (c *CLI) runGatewayInfoWatch(common wrapperCommon, retry int, retryBackoff time.Duration, opts gatewayWatchOptions) error {
+	if opts.interval <= 0 {
+		return c.printCallError(common.jsonOutput, jsonSelectOptions{}, &igwerr.UsageError{Msg: "--interval must be positive"})
+	}
+	if opts.count < 0 {
+		return c.printCallError(common.jsonOutput, jsonSelectOptions{}, &igwerr.UsageError{Msg: "--count must be >= 0"})
+	}
+	if len(common.selectors) > 0 || common.expr != "" || common.rawOutput {
+		return c.printCallError(common.jsonOutput, jsonSelectOptions{}, &igwerr.UsageError{Msg: "--select, --expr, and --raw are not supported with --watch"})
+	}
+	client, _, saveHAR, err := c.newGatewayClient(common)
+	if err != nil {
+		return c.printCallError(common.jsonOutput, jsonSelectOptions{}, err)
+	}
+	defer saveHAR()
+
+	var previous map[string]any
+	for cycle := 1; opts.count == 0 || cycle <= opts.count; cycle++ {
+		if cycle > 1 {
+			time.Sleep(opts.interval)
+		}
+		resp, err := client.Call(context.Background(), gateway.CallRequest{
+			Method:       http.MethodGet,
+			Path:         "/data/api/v1/gateway-info",
+			Timeout:      common.timeout,
+			Retry:        retry,
+			RetryBackoff: retryBackoff,
+		})
+		if err != nil {
+			return c.printCallError(common.jsonOutput, jsonSelectOptions{}, err)
+		}
+
+		if !opts.diff {
+			if common.jsonOutput {
+				fmt.Fprintln(c.Out, strings.TrimSpace(string(resp.Body)))
+			} else {
+				fmt.Fprintln(c.Out, strings.TrimRight(string(resp.Body), "\n"))
+			}
+			continue
+		}
+
+		current, err := decodeWatchObject(resp.Body)
+		if err != nil {
+			return c.printCallError(common.jsonOutput, jsonSelectOptions{}, err)
+		}
+		changed := diffTopLevelFields(previous, current)
+		previous = current
+		if len(changed) == 0 {
+			continue
+		}
+		if common.jsonOutput {
+			if err := writeJSONWithOptions(c.Out, map[string]any{"changed": changed}, true); err != nil {
+				return err
+			}
+			continue
+		}
+		for _, field := range sortedChangeFields(changed) {
+			change := changed[field]
+			fmt.Fprintf(c.Out, "changed\t%s\t%s -> %s\n", field, formatWatchValue(change.Old), formatWatchValue(change.New))
+		}
+	}
+	return nil
+}
+
+func decodeWatchObject(body []byte) (map[string]any, error) {
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+	var out map[string]any
+	if err := decoder.Decode(&out); err != nil {
+		return nil, igwerr.NewTransportError(fmt.Errorf("--watch-diff requires a JSON object response: %w", err))
+	}
+	return out, nil
+}
+
+// diffTopLevelFields reports fields added, removed, or changed between
+// previous and current. A removed field has a nil New value.
+func diffTopLevelFields(previous map[string]any, current map[string]any) map[string]watchFieldChange {
+	changed := make(map[string]watchFieldChange)
+	for key, value := range current {
+		old, ok := previous[key]
+		if !ok || !reflect.DeepEqual(old, value) {
+			changed[key] = watchFieldChange{Old: old, New: value}
+		}
+	}
+	for key, old := range previous {
+		if _, ok := current[key]; !ok {
+			changed[key] = watchFieldChange{Old: old}
+		}
+	}
+	return changed
+}
+
+func sortedChangeFields(changed map[string]watchFieldChange) []string {
+	fields := make([]string, 0, len(changed))
+	for field := range changed {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+	return fields
+}
+
+func formatWatchValue(value any) string {
+	if value == nil {
+		return "null"
+	}
+	b, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprint(value)
+	}
+	return string(b)
+}
@@ -0,0 +1,101 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/alex-mccollum/igw-cli/internal/config"
+)
+
+func newGatewayWatchCLI(out *bytes.Buffer, bodies []string) *CLI {
+	calls := 0
+	return &CLI{
+		In:     strings.NewReader(""),
+		Out:    out,
+		Err:    new(bytes.Buffer),
+		Getenv: func(string) string { return "" },
+		ReadConfig: func() (config.File, error) {
+			return config.File{}, nil
+		},
+		HTTPClient: newMockHTTPClient(func(r *http.Request) (*http.Response, error) {
+			body := bodies[len(bodies)-1]
+			if calls < len(bodies) {
+				body = bodies[calls]
+			}
+			calls++
+			return mockHTTPResponse(http.StatusOK, body, http.Header{"Content-Type": {"application/json"}}), nil
+		}),
+	}
+}
+
+func TestGatewayInfoWatchDiffJSON(t *testing.T) {
+	t.Parallel()
+
+	var out bytes.Buffer
+	c := newGatewayWatchCLI(&out, []string{
+		`{"name":"gw","state":"RUNNING","uptime":10}`,
+		`{"name":"gw","state":"STARTING","uptime":10}`,
+	})
+	err := c.Execute([]string{
+		"gateway", "info",
+		"--gateway-url", mockGatewayURL,
+		"--api-key", "secret",
+		"--watch-diff",
+		"--interval", "1ms",
+		"--count", "2",
+		"--json",
+	})
+	if err != nil {
+		t.Fatalf("watch failed: %v", err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("expected 2 cycles of output, got %q", out.String())
+	}
+	var second struct {
+		Changed map[string]watchFieldChange `json:"changed"`
+	}
+	if err := json.Unmarshal([]byte(lines[1]), &second); err != nil {
+		t.Fatalf("decode second cycle: %v", err)
+	}
+	if len(second.Changed) != 1 {
+		t.Fatalf("expected only state to change, got %+v", second.Changed)
+	}
+	change, ok := second.Changed["state"]
+	if !ok || change.Old != "RUNNING" || change.New != "STARTING" {
+		t.Fatalf("unexpected state change: %+v", second.Changed)
+	}
+}
+
+func TestGatewayInfoWatchDiffTextSuppressesUnchanged(t *testing.T) {
+	t.Parallel()
+
+	var out bytes.Buffer
+	c := newGatewayWatchCLI(&out, []string{
+		`{"name":"gw","uptime":10}`,
+		`{"name":"gw","uptime":10}`,
+		`{"name":"gw","uptime":12}`,
+	})
+	err := c.Execute([]string{
+		"gateway", "info",
+		"--gateway-url", mockGatewayURL,
+		"--api-key", "secret",
+		"--watch-diff",
+		"--interval", "1ms",
+		"--count", "3",
+	})
+	if err != nil {
+		t.Fatalf("watch failed: %v", err)
+	}
+
+	want := "changed\tname\tnull -> \"gw\"\n" +
+		"changed\tuptime\tnull -> 10\n" +
+		"changed\tuptime\t10 -> 12\n"
+	if out.String() != want {
+		t.Fatalf("unexpected output:\n%s\nwant:\n%s", out.String(), want)
+	}
+}
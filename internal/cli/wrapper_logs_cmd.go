@@ -14,17 +14,19 @@ func (c *CLI) runLogsList(args []string) error {
 
 	var common wrapperCommon
 	var query stringList
+	var output recordOutputOptions
 	bindWrapperCommon(fs, &common)
 	fs.Var(&query, "query", "Query parameter key=value (repeatable)")
+	bindRecordOutputFlags(fs, &output)
 
 	if err := parseWrapperFlagSet(fs, args); err != nil {
 		return err
 	}
 
 	callArgs := []string{"--method", "GET", "--path", "/data/api/v1/logs"}
-	callArgs = append(callArgs, common.callArgs()...)
 	callArgs = appendQueryArgs(callArgs, query)
-	return c.runCall(callArgs)
+	callArgs = append(callArgs, output.args()...)
+	return c.runWrapperCall(common, callArgs)
 }
 
 func (c *CLI) runLogsDownload(args []string) error {
@@ -33,20 +35,24 @@ func (c *CLI) runLogsDownload(args []string) error {
 
 	var common wrapperCommon
 	var outPath string
+	var progress bool
 	bindWrapperCommon(fs, &common)
 	fs.StringVar(&outPath, "out", "", "Write downloaded logs to file")
+	fs.BoolVar(&progress, "progress", false, "Print throttled download progress to stderr")
 
 	if err := parseWrapperFlagSet(fs, args); err != nil {
 		return err
 	}
 
 	callArgs := []string{"--method", "GET", "--path", "/data/api/v1/logs/download"}
-	callArgs = append(callArgs, common.callArgs()...)
 	resolvedOut := chooseDefaultOutPath(outPath, "gateway-logs.zip")
 	if resolvedOut != "" {
 		callArgs = append(callArgs, "--out", resolvedOut)
 	}
-	return c.runCall(callArgs)
+	if progress {
+		callArgs = append(callArgs, "--progress")
+	}
+	return c.runWrapperCall(common, callArgs)
 }
 
 func (c *CLI) runLogsLoggers(args []string) error {
@@ -55,17 +61,19 @@ func (c *CLI) runLogsLoggers(args []string) error {
 
 	var common wrapperCommon
 	var query stringList
+	var output recordOutputOptions
 	bindWrapperCommon(fs, &common)
 	fs.Var(&query, "query", "Query parameter key=value (repeatable)")
+	bindRecordOutputFlags(fs, &output)
 
 	if err := parseWrapperFlagSet(fs, args); err != nil {
 		return err
 	}
 
 	callArgs := []string{"--method", "GET", "--path", "/data/api/v1/logs/loggers"}
-	callArgs = append(callArgs, common.callArgs()...)
 	callArgs = appendQueryArgs(callArgs, query)
-	return c.runCall(callArgs)
+	callArgs = append(callArgs, output.args()...)
+	return c.runWrapperCall(common, callArgs)
 }
 
 func (c *CLI) runLogsLoggerSet(args []string) error {
@@ -98,8 +106,7 @@ func (c *CLI) runLogsLoggerSet(args []string) error {
 
 	path := "/data/api/v1/logs/loggers/" + url.PathEscape(strings.TrimSpace(name))
 	callArgs := []string{"--method", "POST", "--path", path, "--yes", "--query", "level=" + normalizedLevel}
-	callArgs = append(callArgs, common.callArgs()...)
-	return c.runCall(callArgs)
+	return c.runWrapperCall(common, callArgs)
 }
 
 func (c *CLI) runLogsLevelReset(args []string) error {
@@ -119,6 +126,5 @@ func (c *CLI) runLogsLevelReset(args []string) error {
 	}
 
 	callArgs := []string{"--method", "POST", "--path", "/data/api/v1/logs/levelreset", "--yes"}
-	callArgs = append(callArgs, common.callArgs()...)
-	return c.runCall(callArgs)
+	return c.runWrapperCall(common, callArgs)
 }
@@ -0,0 +1,298 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"os/signal"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/alex-mccollum/igw-cli/internal/gateway"
+	"github.com/alex-mccollum/igw-cli/internal/igwerr"
+)
+
+// logLevels orders the levels accepted by --min-level.
+var logLevels = []string{"TRACE", "DEBUG", "INFO", "WARN", "ERROR"}
+
+// logTailEvent is one log event with the fields tail prints and dedupes on.
+type logTailEvent struct {
+	raw       map[string]any
+	timestamp int64
+	level     string
+	logger    string
+	message   string
+	key       string
+}
+
+func (c *CLI) runLogsTail(args []string) error {
+	fs := flag.NewFlagSet("logs tail", flag.ContinueOnError)
+	fs.SetOutput(c.Err)
+
+	var common wrapperCommon
+	var minLevel string
+	var logger string
+	var since time.Duration
+	var follow bool
+	var interval time.Duration
+	var count int
+	bindWrapperCommon(fs, &common)
+	fs.StringVar(&minLevel, "min-level", "", "Minimum level: TRACE|DEBUG|INFO|WARN|ERROR")
+	fs.StringVar(&logger, "logger", "", "Only print events whose logger name contains this text (case-insensitive)")
+	fs.DurationVar(&since, "since", 10*time.Minute, "Start with events from this far back")
+	fs.BoolVar(&follow, "follow", true, "Keep polling for new events until interrupted (false: print once and exit)")
+	fs.DurationVar(&interval, "interval", 2*time.Second, "Polling interval with --follow")
+	fs.IntVar(&count, "count", 0, "Stop after N polls (0 = until interrupted)")
+
+	if err := parseWrapperFlagSet(fs, args); err != nil {
+		return err
+	}
+	if len(common.selectors) > 0 || common.expr != "" || common.rawOutput {
+		return c.printCallError(common.jsonOutput, jsonSelectOptions{}, &igwerr.UsageError{Msg: "--select, --expr, and --raw are not supported with logs tail"})
+	}
+	if since <= 0 {
+		return c.printCallError(common.jsonOutput, jsonSelectOptions{}, &igwerr.UsageError{Msg: "--since must be positive"})
+	}
+	if interval <= 0 {
+		return c.printCallError(common.jsonOutput, jsonSelectOptions{}, &igwerr.UsageError{Msg: "--interval must be positive"})
+	}
+	if count < 0 {
+		return c.printCallError(common.jsonOutput, jsonSelectOptions{}, &igwerr.UsageError{Msg: "--count must be >= 0"})
+	}
+	if strings.TrimSpace(minLevel) != "" {
+		normalized, err := parseRequiredEnumFlag("min-level", minLevel, logLevels)
+		if err != nil {
+			return c.printCallError(common.jsonOutput, jsonSelectOptions{}, err)
+		}
+		minLevel = normalized
+	}
+	if common.timeout <= 0 {
+		return c.printCallError(common.jsonOutput, jsonSelectOptions{}, &igwerr.UsageError{Msg: "--timeout must be positive"})
+	}
+	client, _, saveHAR, err := c.newGatewayClient(common)
+	if err != nil {
+		return c.printCallError(common.jsonOutput, jsonSelectOptions{}, err)
+	}
+	defer saveHAR()
+
+	// Ctrl-C ends a follow normally, so it exits 0.
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	tail := newLogTail(c.clock().Add(-since).UnixMilli(), minLevel, logger)
+	for poll := 1; count == 0 || poll <= count; poll++ {
+		if poll > 1 {
+			timer := time.NewTimer(interval)
+			select {
+			case <-ctx.Done():
+				timer.Stop()
+				return nil
+			case <-timer.C:
+			}
+		}
+
+		query := []string{"startTime=" + strconv.FormatInt(tail.cursor, 10)}
+		if minLevel != "" {
+			query = append(query, "minLevel="+minLevel)
+		}
+		resp, err := client.Call(ctx, gateway.CallRequest{
+			Method:  http.MethodGet,
+			Path:    "/data/api/v1/logs",
+			Query:   query,
+			Timeout: common.timeout,
+		})
+		if ctx.Err() != nil {
+			return nil
+		}
+		if err != nil {
+			// A restarting gateway should not end a follow.
+			if follow && gatewayDown(err) {
+				fmt.Fprintf(c.Err, "warning: %v (retrying)\n", err)
+				continue
+			}
+			return c.printCallError(common.jsonOutput, jsonSelectOptions{}, err)
+		}
+		events, err := tail.next(resp.Body)
+		if err != nil {
+			return c.printCallError(common.jsonOutput, jsonSelectOptions{}, igwerr.NewTransportError(err))
+		}
+		for _, event := range events {
+			if err := writeLogTailEvent(c.Out, event, common.jsonOutput); err != nil {
+				return err
+			}
+		}
+		if !follow {
+			return nil
+		}
+	}
+	return nil
+}
+
+// logTail tracks the events already printed. Each poll asks for events
+// from the newest timestamp seen, so pages overlap by at least that
+// millisecond; seen holds the keys of events at or after the cursor.
+type logTail struct {
+	cursor   int64
+	minLevel string
+	logger   string
+	seen     map[string]int64
+}
+
+func newLogTail(start int64, minLevel string, logger string) *logTail {
+	return &logTail{
+		cursor:   start,
+		minLevel: minLevel,
+		logger:   strings.ToLower(strings.TrimSpace(logger)),
+		seen:     make(map[string]int64),
+	}
+}
+
+// next returns the events in body not printed before, oldest first, and
+// advances the cursor.
+func (t *logTail) next(body []byte) ([]logTailEvent, error) {
+	items, _, _, err := pageItems(body)
+	if err != nil {
+		return nil, err
+	}
+
+	var fresh []logTailEvent
+	for _, item := range items {
+		raw, ok := item.(map[string]any)
+		if !ok {
+			continue
+		}
+		event := parseLogTailEvent(raw)
+		if _, dup := t.seen[event.key]; dup {
+			continue
+		}
+		t.seen[event.key] = event.timestamp
+		if event.timestamp > t.cursor {
+			t.cursor = event.timestamp
+		}
+		if t.matches(event) {
+			fresh = append(fresh, event)
+		}
+	}
+	sort.SliceStable(fresh, func(i, j int) bool { return fresh[i].timestamp < fresh[j].timestamp })
+
+	for key, timestamp := range t.seen {
+		if timestamp < t.cursor {
+			delete(t.seen, key)
+		}
+	}
+	return fresh, nil
+}
+
+func (t *logTail) matches(event logTailEvent) bool {
+	if t.logger != "" && !strings.Contains(strings.ToLower(event.logger), t.logger) {
+		return false
+	}
+	if t.minLevel != "" {
+		if rank := logLevelRank(event.level); rank >= 0 && rank < logLevelRank(t.minLevel) {
+			return false
+		}
+	}
+	return true
+}
+
+// logLevelRank orders level names; unknown levels rank -1 and always pass.
+func logLevelRank(level string) int {
+	level = strings.ToUpper(strings.TrimSpace(level))
+	if level == "WARNING" {
+		level = "WARN"
+	}
+	for i, name := range logLevels {
+		if name == level {
+			return i
+		}
+	}
+	return -1
+}
+
+func parseLogTailEvent(raw map[string]any) logTailEvent {
+	event := logTailEvent{
+		raw:       raw,
+		timestamp: logEventTimestamp(firstLogField(raw, "timestamp", "timeStamp", "time", "date")),
+		level:     logFieldString(firstLogField(raw, "level", "levelString")),
+		logger:    logFieldString(firstLogField(raw, "logger", "loggerName", "name")),
+		message:   logFieldString(firstLogField(raw, "message", "formattedMessage", "msg")),
+	}
+	if id := firstLogField(raw, "id", "eventId", "sequence"); id != nil {
+		event.key = "id:" + logFieldString(id)
+	} else {
+		key, _ := json.Marshal([]any{event.timestamp, event.level, event.logger, event.message})
+		event.key = string(key)
+	}
+	return event
+}
+
+func firstLogField(raw map[string]any, names ...string) any {
+	for _, name := range names {
+		if value, ok := raw[name]; ok && value != nil {
+			return value
+		}
+	}
+	return nil
+}
+
+func logFieldString(value any) string {
+	switch typed := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return typed
+	default:
+		return fmt.Sprint(typed)
+	}
+}
+
+// logEventTimestamp reads epoch milliseconds from a number or numeric
+// string, or parses an RFC 3339 string. Unreadable values are 0.
+func logEventTimestamp(value any) int64 {
+	switch typed := value.(type) {
+	case json.Number:
+		if ms, err := typed.Int64(); err == nil {
+			return ms
+		}
+		if ms, err := typed.Float64(); err == nil {
+			return int64(ms)
+		}
+	case float64:
+		return int64(typed)
+	case string:
+		if ms, err := strconv.ParseInt(strings.TrimSpace(typed), 10, 64); err == nil {
+			return ms
+		}
+		if parsed, err := time.Parse(time.RFC3339Nano, strings.TrimSpace(typed)); err == nil {
+			return parsed.UnixMilli()
+		}
+	}
+	return 0
+}
+
+func writeLogTailEvent(w io.Writer, event logTailEvent, jsonOutput bool) error {
+	if jsonOutput {
+		return writeJSONWithOptions(w, event.raw, true)
+	}
+	when := "-"
+	if event.timestamp > 0 {
+		when = time.UnixMilli(event.timestamp).UTC().Format("2006-01-02T15:04:05.000Z")
+	}
+	level := strings.ToUpper(event.level)
+	if level == "" {
+		level = "-"
+	}
+	logger := event.logger
+	if logger == "" {
+		logger = "-"
+	}
+	message := strings.ReplaceAll(strings.TrimRight(event.message, "\n"), "\n", "\n\t")
+	_, err := fmt.Fprintf(w, "%s %-5s %s %s\n", when, level, logger, message)
+	return err
+}
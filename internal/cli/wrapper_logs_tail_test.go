@@ -0,0 +1,105 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// logsTailTestClient serves pages in order, repeating the last one; a "503"
+// page fails that poll. The returned func lists each poll's startTime.
+func logsTailTestClient(pages []string) (*http.Client, func() []string) {
+	var mu sync.Mutex
+	var startTimes []string
+	client := newMockHTTPClient(func(r *http.Request) (*http.Response, error) {
+		mu.Lock()
+		poll := len(startTimes)
+		startTimes = append(startTimes, r.URL.Query().Get("startTime"))
+		mu.Unlock()
+		page := pages[min(poll, len(pages)-1)]
+		if page == "503" {
+			return mockHTTPResponse(http.StatusServiceUnavailable, `{}`, nil), nil
+		}
+		return mockHTTPResponse(http.StatusOK, page, nil), nil
+	})
+	return client, func() []string {
+		mu.Lock()
+		defer mu.Unlock()
+		return append([]string(nil), startTimes...)
+	}
+}
+
+func TestLogsTailSuppressesDuplicatesAcrossOverlappingPages(t *testing.T) {
+	t.Parallel()
+
+	pages := []string{
+		`{"items":[{"timestamp":1000,"level":"INFO","logger":"gateway.Startup","message":"a"},{"timestamp":2000,"level":"WARN","logger":"perspective.Session","message":"b"}]}`,
+		// Newest first, overlapping the last event of the previous page.
+		`{"items":[{"timestamp":3000,"level":"ERROR","logger":"perspective.Session","message":"c"},{"timestamp":2000,"level":"WARN","logger":"perspective.Session","message":"b"}]}`,
+		"503",
+		// A second event in the cursor's millisecond is new; the first is not.
+		`{"items":[{"timestamp":3000,"level":"ERROR","logger":"perspective.Session","message":"c"},{"timestamp":3000,"level":"INFO","logger":"gateway.Startup","message":"d\nsecond line"}]}`,
+	}
+	var out bytes.Buffer
+	client, startTimes := logsTailTestClient(pages)
+	c := newAdminWrapperTestCLI(client)
+	c.Out = &out
+	c.now = func() time.Time { return time.UnixMilli(600_000 + 500) }
+
+	if err := c.Execute([]string{"logs", "tail", "--gateway-url", mockGatewayURL, "--api-key", "secret", "--interval", "1ms", "--count", "5"}); err != nil {
+		t.Fatalf("logs tail: %v", err)
+	}
+	want := strings.Join([]string{
+		"1970-01-01T00:00:01.000Z INFO  gateway.Startup a",
+		"1970-01-01T00:00:02.000Z WARN  perspective.Session b",
+		"1970-01-01T00:00:03.000Z ERROR perspective.Session c",
+		"1970-01-01T00:00:03.000Z INFO  gateway.Startup d",
+		"\tsecond line",
+	}, "\n") + "\n"
+	if out.String() != want {
+		t.Fatalf("unexpected tail output:\n%s\nwant:\n%s", out.String(), want)
+	}
+	if got := strings.Join(startTimes(), ","); got != "500,2000,3000,3000,3000" {
+		t.Fatalf("unexpected startTime queries %s", got)
+	}
+	if !strings.Contains(c.Err.(*bytes.Buffer).String(), "warning:") {
+		t.Fatalf("expected a warning for the 503 poll, got %q", c.Err.(*bytes.Buffer).String())
+	}
+}
+
+func TestLogsTailOneShotFiltersAndJSON(t *testing.T) {
+	t.Parallel()
+
+	pages := []string{`[
+		{"timestamp":1000,"level":"DEBUG","logger":"perspective.Session","message":"noise"},
+		{"timestamp":2000,"level":"ERROR","logger":"Perspective.Session","message":"boom"},
+		{"timestamp":3000,"level":"ERROR","logger":"gateway.Startup","message":"other"}
+	]`}
+	var out bytes.Buffer
+	client, startTimes := logsTailTestClient(pages)
+	c := newAdminWrapperTestCLI(client)
+	c.Out = &out
+	c.now = func() time.Time { return time.UnixMilli(600_000 + 500) }
+
+	err := c.Execute([]string{
+		"logs", "tail", "--gateway-url", mockGatewayURL, "--api-key", "secret",
+		"--follow=false", "--since", "1m", "--min-level", "warn", "--logger", "perspective", "--json",
+	})
+	if err != nil {
+		t.Fatalf("logs tail: %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	var event map[string]any
+	if len(lines) != 1 || json.Unmarshal([]byte(lines[0]), &event) != nil || event["message"] != "boom" {
+		t.Fatalf("expected one NDJSON event, got %q", out.String())
+	}
+	if got := startTimes(); len(got) != 1 || got[0] != "540500" {
+		t.Fatalf("expected one poll from --since, got %v", got)
+	}
+
+	requireUsageExitCode(t, c.Execute([]string{"logs", "tail", "--gateway-url", mockGatewayURL, "--api-key", "secret", "--min-level", "LOUD"}))
+}
@@ -0,0 +1,102 @@
+package cli
+
+import (
+	"flag"
+	"fmt"
+	"net/url"
+	"path/filepath"
+	"strings"
+
+	"github.com/alex-mccollum/igw-cli/internal/igwerr"
+)
+
+func (c *CLI) runModulesList(args []string) error {
+	fs := flag.NewFlagSet("modules list", flag.ContinueOnError)
+	fs.SetOutput(c.Err)
+
+	var common wrapperCommon
+	var query stringList
+	bindWrapperCommon(fs, &common)
+	fs.Var(&query, "query", "Query parameter key=value (repeatable)")
+
+	if err := parseWrapperFlagSet(fs, args); err != nil {
+		return err
+	}
+
+	callArgs := []string{"--method", "GET", "--path", "/data/api/v1/modules"}
+	callArgs = appendQueryArgs(callArgs, query)
+	return c.runWrapperCall(common, callArgs)
+}
+
+func (c *CLI) runModulesInstall(args []string) error {
+	fs := flag.NewFlagSet("modules install", flag.ContinueOnError)
+	fs.SetOutput(c.Err)
+
+	var common wrapperCommon
+	var inPath string
+	var force bool
+	var yes bool
+	bindWrapperCommon(fs, &common)
+	fs.StringVar(&inPath, "in", "", "Path to module file (.modl)")
+	fs.BoolVar(&force, "force", false, "Install --in even when it does not end in .modl")
+	fs.BoolVar(&yes, "yes", false, "Confirm mutating request")
+
+	if err := parseWrapperFlagSet(fs, args); err != nil {
+		return err
+	}
+	inPath = strings.TrimSpace(inPath)
+	if inPath == "" {
+		return &igwerr.UsageError{Msg: "required: --in"}
+	}
+	if !force && !strings.EqualFold(filepath.Ext(inPath), ".modl") {
+		return &igwerr.UsageError{Msg: fmt.Sprintf("--in %q is not a .modl file (use --force to install it anyway)", inPath)}
+	}
+	if !yes {
+		return &igwerr.UsageError{Msg: "required: --yes"}
+	}
+
+	callArgs := []string{
+		"--method", "POST",
+		"--path", "/data/api/v1/modules/install",
+		"--body", "@" + inPath,
+		"--content-type", "application/octet-stream",
+		"--yes",
+	}
+	return c.runWrapperCall(common, callArgs)
+}
+
+func (c *CLI) runModulesUninstall(args []string) error {
+	return c.runModuleAction(args, "modules uninstall", "DELETE", "")
+}
+
+func (c *CLI) runModulesRestart(args []string) error {
+	return c.runModuleAction(args, "modules restart", "POST", "/restart")
+}
+
+// runModuleAction runs a --yes gated request against one module by --id.
+func (c *CLI) runModuleAction(args []string, name string, method string, suffix string) error {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	fs.SetOutput(c.Err)
+
+	var common wrapperCommon
+	var id string
+	var yes bool
+	bindWrapperCommon(fs, &common)
+	fs.StringVar(&id, "id", "", "Module id (e.g. com.inductiveautomation.perspective)")
+	fs.BoolVar(&yes, "yes", false, "Confirm mutating request")
+
+	if err := parseWrapperFlagSet(fs, args); err != nil {
+		return err
+	}
+	id = strings.TrimSpace(id)
+	if id == "" {
+		return &igwerr.UsageError{Msg: "required: --id"}
+	}
+	if !yes {
+		return &igwerr.UsageError{Msg: "required: --yes"}
+	}
+
+	path := "/data/api/v1/modules/" + url.PathEscape(id) + suffix
+	callArgs := []string{"--method", method, "--path", path, "--yes"}
+	return c.runWrapperCall(common, callArgs)
+}
@@ -0,0 +1,118 @@
+package cli
+
+import (
+	"bytes"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestModulesListWrapperSelectRaw(t *testing.T) {
+	t.Parallel()
+
+	var got recordedWrapperRequest
+	srv := newRecordingWrapperServer(t, &got, `{"items":[{"id":"com.inductiveautomation.perspective"}]}`)
+
+	var out bytes.Buffer
+	c := newAdminWrapperTestCLI(srv.Client())
+	c.Out = &out
+	if err := c.Execute([]string{
+		"modules", "list",
+		"--gateway-url", srv.URL,
+		"--api-key", "secret",
+		"--json",
+		"--select", "response.status",
+		"--raw",
+	}); err != nil {
+		t.Fatalf("modules list failed: %v", err)
+	}
+	if got.method != http.MethodGet || got.path != "/data/api/v1/modules" {
+		t.Fatalf("unexpected request %+v", got)
+	}
+	if strings.TrimSpace(out.String()) != "200" {
+		t.Fatalf("unexpected --raw output %q", out.String())
+	}
+}
+
+func TestModulesInstallWrapper(t *testing.T) {
+	t.Parallel()
+
+	var got recordedWrapperRequest
+	srv := newRecordingWrapperServer(t, &got, `{}`)
+	inPath := mustWriteAdminFixture(t, "perspective.modl", "modl-bytes")
+
+	if err := newAdminWrapperTestCLI(srv.Client()).Execute([]string{
+		"modules", "install",
+		"--gateway-url", srv.URL,
+		"--api-key", "secret",
+		"--in", inPath,
+		"--yes",
+	}); err != nil {
+		t.Fatalf("modules install failed: %v", err)
+	}
+	if got.method != http.MethodPost || got.path != "/data/api/v1/modules/install" {
+		t.Fatalf("unexpected request %+v", got)
+	}
+	if got.body != "modl-bytes" || got.contentType != "application/octet-stream" {
+		t.Fatalf("unexpected install body %q (%s)", got.body, got.contentType)
+	}
+}
+
+func TestModulesInstallWrapperForceAcceptsOtherExtensions(t *testing.T) {
+	t.Parallel()
+
+	var got recordedWrapperRequest
+	srv := newRecordingWrapperServer(t, &got, `{}`)
+	inPath := mustWriteAdminFixture(t, "perspective.zip", "modl-bytes")
+	args := []string{
+		"modules", "install",
+		"--gateway-url", srv.URL,
+		"--api-key", "secret",
+		"--in", inPath,
+		"--yes",
+	}
+
+	requireUsageExitCode(t, newAdminWrapperTestCLI(srv.Client()).Execute(args))
+	if err := newAdminWrapperTestCLI(srv.Client()).Execute(append(args, "--force")); err != nil {
+		t.Fatalf("modules install --force failed: %v", err)
+	}
+	if got.body != "modl-bytes" {
+		t.Fatalf("unexpected install body %q", got.body)
+	}
+}
+
+func TestModulesActionWrappers(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		subcommand string
+		method     string
+		path       string
+	}{
+		{subcommand: "uninstall", method: http.MethodDelete, path: "/data/api/v1/modules/com.example.mod"},
+		{subcommand: "restart", method: http.MethodPost, path: "/data/api/v1/modules/com.example.mod/restart"},
+	}
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.subcommand, func(t *testing.T) {
+			t.Parallel()
+
+			var got recordedWrapperRequest
+			srv := newRecordingWrapperServer(t, &got, `{}`)
+			args := []string{
+				"modules", tc.subcommand,
+				"--gateway-url", srv.URL,
+				"--api-key", "secret",
+				"--id", "com.example.mod",
+			}
+
+			requireUsageExitCode(t, newAdminWrapperTestCLI(srv.Client()).Execute(args))
+			if err := newAdminWrapperTestCLI(srv.Client()).Execute(append(args, "--yes")); err != nil {
+				t.Fatalf("modules %s failed: %v", tc.subcommand, err)
+			}
+			if got.method != tc.method || got.path != tc.path {
+				t.Fatalf("unexpected request %+v", got)
+			}
+		})
+	}
+}
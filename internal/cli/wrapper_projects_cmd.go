@@ -0,0 +1,134 @@
+package cli
+
+import (
+	"flag"
+	"net/url"
+	"strings"
+
+	"github.com/alex-mccollum/igw-cli/internal/igwerr"
+)
+
+func (c *CLI) runProjectsList(args []string) error {
+	fs := flag.NewFlagSet("projects list", flag.ContinueOnError)
+	fs.SetOutput(c.Err)
+
+	var common wrapperCommon
+	var query stringList
+	bindWrapperCommon(fs, &common)
+	fs.Var(&query, "query", "Query parameter key=value (repeatable)")
+
+	if err := parseWrapperFlagSet(fs, args); err != nil {
+		return err
+	}
+
+	callArgs := []string{"--method", "GET", "--path", "/data/api/v1/projects"}
+	callArgs = appendQueryArgs(callArgs, query)
+	return c.runWrapperCall(common, callArgs)
+}
+
+func (c *CLI) runProjectsExport(args []string) error {
+	fs := flag.NewFlagSet("projects export", flag.ContinueOnError)
+	fs.SetOutput(c.Err)
+
+	var common wrapperCommon
+	var name string
+	var outPath string
+	var progress bool
+	bindWrapperCommon(fs, &common)
+	fs.StringVar(&name, "name", "", "Project name")
+	fs.StringVar(&outPath, "out", "", "Write project export to file (default: <name>.zip)")
+	fs.BoolVar(&progress, "progress", false, "Print throttled download progress to stderr")
+
+	if err := parseWrapperFlagSet(fs, args); err != nil {
+		return err
+	}
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return &igwerr.UsageError{Msg: "required: --name"}
+	}
+
+	path := "/data/api/v1/projects/" + url.PathEscape(name) + "/export"
+	callArgs := []string{"--method", "GET", "--path", path}
+	resolvedOut := chooseDefaultOutPath(outPath, name+".zip")
+	if resolvedOut != "" {
+		callArgs = append(callArgs, "--out", resolvedOut)
+	}
+	if progress {
+		callArgs = append(callArgs, "--progress")
+	}
+	return c.runWrapperCall(common, callArgs)
+}
+
+func (c *CLI) runProjectsImport(args []string) error {
+	fs := flag.NewFlagSet("projects import", flag.ContinueOnError)
+	fs.SetOutput(c.Err)
+
+	var common wrapperCommon
+	var name string
+	var inPath string
+	var overwrite string
+	var yes bool
+	bindWrapperCommon(fs, &common)
+	fs.StringVar(&name, "name", "", "Project name to import as")
+	fs.StringVar(&inPath, "in", "", "Path to project export (.zip)")
+	fs.StringVar(&overwrite, "overwrite", "", "Set overwrite query to true/false")
+	fs.BoolVar(&yes, "yes", false, "Confirm mutating request")
+
+	if err := parseWrapperFlagSet(fs, args); err != nil {
+		return err
+	}
+	if strings.TrimSpace(inPath) == "" {
+		return &igwerr.UsageError{Msg: "required: --in"}
+	}
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return &igwerr.UsageError{Msg: "required: --name"}
+	}
+	normalizedOverwrite, err := parseOptionalBoolFlag("overwrite", overwrite)
+	if err != nil {
+		return err
+	}
+	if !yes {
+		return &igwerr.UsageError{Msg: "required: --yes"}
+	}
+
+	callArgs := []string{
+		"--method", "POST",
+		"--path", "/data/api/v1/projects/import",
+		"--query", "name=" + name,
+		"--body", "@" + inPath,
+		"--content-type", "application/octet-stream",
+		"--yes",
+	}
+	if normalizedOverwrite != "" {
+		callArgs = append(callArgs, "--query", "overwrite="+normalizedOverwrite)
+	}
+	return c.runWrapperCall(common, callArgs)
+}
+
+func (c *CLI) runProjectsDelete(args []string) error {
+	fs := flag.NewFlagSet("projects delete", flag.ContinueOnError)
+	fs.SetOutput(c.Err)
+
+	var common wrapperCommon
+	var name string
+	var yes bool
+	bindWrapperCommon(fs, &common)
+	fs.StringVar(&name, "name", "", "Project name")
+	fs.BoolVar(&yes, "yes", false, "Confirm mutating request")
+
+	if err := parseWrapperFlagSet(fs, args); err != nil {
+		return err
+	}
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return &igwerr.UsageError{Msg: "required: --name"}
+	}
+	if !yes {
+		return &igwerr.UsageError{Msg: "required: --yes"}
+	}
+
+	path := "/data/api/v1/projects/" + url.PathEscape(name)
+	callArgs := []string{"--method", "DELETE", "--path", path, "--yes"}
+	return c.runWrapperCall(common, callArgs)
+}
@@ -0,0 +1,154 @@
+package cli
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+type recordedWrapperRequest struct {
+	method      string
+	path        string
+	query       string
+	body        string
+	contentType string
+}
+
+func newRecordingWrapperServer(t *testing.T, got *recordedWrapperRequest, response string) *httptest.Server {
+	t.Helper()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		*got = recordedWrapperRequest{
+			method:      r.Method,
+			path:        r.URL.EscapedPath(),
+			query:       r.URL.RawQuery,
+			body:        string(body),
+			contentType: r.Header.Get("Content-Type"),
+		}
+		_, _ = w.Write([]byte(response))
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestProjectsListWrapper(t *testing.T) {
+	t.Parallel()
+
+	var got recordedWrapperRequest
+	srv := newRecordingWrapperServer(t, &got, `{"items":[]}`)
+
+	if err := newAdminWrapperTestCLI(srv.Client()).Execute([]string{
+		"projects", "list",
+		"--gateway-url", srv.URL,
+		"--api-key", "secret",
+		"--query", "limit=5",
+	}); err != nil {
+		t.Fatalf("projects list failed: %v", err)
+	}
+	if got.method != http.MethodGet || got.path != "/data/api/v1/projects" || got.query != "limit=5" {
+		t.Fatalf("unexpected request %+v", got)
+	}
+}
+
+func TestProjectsExportWrapperDefaultsOutToProjectName(t *testing.T) {
+	var got recordedWrapperRequest
+	srv := newRecordingWrapperServer(t, &got, "zip-bytes")
+
+	workDir := t.TempDir()
+	prevWD, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("getwd: %v", err)
+	}
+	if err := os.Chdir(workDir); err != nil {
+		t.Fatalf("chdir: %v", err)
+	}
+	t.Cleanup(func() { _ = os.Chdir(prevWD) })
+
+	if err := newAdminWrapperTestCLI(srv.Client()).Execute([]string{
+		"projects", "export",
+		"--gateway-url", srv.URL,
+		"--api-key", "secret",
+		"--name", "My Project",
+	}); err != nil {
+		t.Fatalf("projects export failed: %v", err)
+	}
+	if got.method != http.MethodGet || got.path != "/data/api/v1/projects/My%20Project/export" {
+		t.Fatalf("unexpected request %+v", got)
+	}
+	data, err := os.ReadFile(filepath.Join(workDir, "My Project.zip"))
+	if err != nil || string(data) != "zip-bytes" {
+		t.Fatalf("unexpected default export file %q: %v", data, err)
+	}
+}
+
+func TestProjectsImportWrapper(t *testing.T) {
+	t.Parallel()
+
+	var got recordedWrapperRequest
+	srv := newRecordingWrapperServer(t, &got, `{}`)
+	inPath := mustWriteAdminFixture(t, "demo.zip", "project-bytes")
+
+	if err := newAdminWrapperTestCLI(srv.Client()).Execute([]string{
+		"projects", "import",
+		"--gateway-url", srv.URL,
+		"--api-key", "secret",
+		"--in", inPath,
+		"--name", "demo",
+		"--overwrite", "TRUE",
+		"--yes",
+	}); err != nil {
+		t.Fatalf("projects import failed: %v", err)
+	}
+	if got.method != http.MethodPost || got.path != "/data/api/v1/projects/import" || got.query != "name=demo&overwrite=true" {
+		t.Fatalf("unexpected request %+v", got)
+	}
+	if got.body != "project-bytes" || got.contentType != "application/octet-stream" {
+		t.Fatalf("unexpected import body %q (%s)", got.body, got.contentType)
+	}
+}
+
+func TestProjectsDeleteWrapper(t *testing.T) {
+	t.Parallel()
+
+	var got recordedWrapperRequest
+	srv := newRecordingWrapperServer(t, &got, `{}`)
+
+	if err := newAdminWrapperTestCLI(srv.Client()).Execute([]string{
+		"projects", "delete",
+		"--gateway-url", srv.URL,
+		"--api-key", "secret",
+		"--name", "demo",
+		"--yes",
+	}); err != nil {
+		t.Fatalf("projects delete failed: %v", err)
+	}
+	if got.method != http.MethodDelete || got.path != "/data/api/v1/projects/demo" {
+		t.Fatalf("unexpected request %+v", got)
+	}
+}
+
+func TestProjectsWrapperValidation(t *testing.T) {
+	t.Parallel()
+
+	inPath := mustWriteAdminFixture(t, "demo.zip", "project-bytes")
+	base := []string{"--gateway-url", "http://127.0.0.1:8088", "--api-key", "secret"}
+	tests := map[string][]string{
+		"export without name":  {"projects", "export"},
+		"import without yes":   {"projects", "import", "--in", inPath, "--name", "demo"},
+		"import without name":  {"projects", "import", "--in", inPath, "--yes"},
+		"import bad overwrite": {"projects", "import", "--in", inPath, "--name", "demo", "--overwrite", "maybe", "--yes"},
+		"delete without yes":   {"projects", "delete", "--name", "demo"},
+		"unknown subcommand":   {"projects", "rename"},
+	}
+	for name, args := range tests {
+		args := append(append([]string(nil), args...), base...)
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			requireUsageExitCode(t, newAdminWrapperTestCLI(nil).Execute(args))
+		})
+	}
+}
@@ -18,8 +18,7 @@ func (c *CLI) runRestartTasks(args []string) error {
 	}
 
 	callArgs := []string{"--method", "GET", "--path", "/data/api/v1/restart-tasks/pending"}
-	callArgs = append(callArgs, common.callArgs()...)
-	return c.runCall(callArgs)
+	return c.runWrapperCall(common, callArgs)
 }
 
 func (c *CLI) runRestartGateway(args []string) error {
@@ -44,6 +43,5 @@ func (c *CLI) runRestartGateway(args []string) error {
 		"--query", "confirm=true",
 		"--yes",
 	}
-	callArgs = append(callArgs, common.callArgs()...)
-	return c.runCall(callArgs)
+	return c.runWrapperCall(common, callArgs)
 }
@@ -1,17 +1,24 @@
 package cli
 
-import "flag"
+import (
+	"flag"
+	"time"
+
+	"github.com/alex-mccollum/igw-cli/internal/igwerr"
+)
 
 const (
-	scanSubcommandProjects = "projects"
-	scanSubcommandConfig   = "config"
+	scanSubcommandProjects  = "projects"
+	scanSubcommandConfig    = "config"
+	scanSubcommandResources = "resources"
 )
 
-var scanSubcommands = []string{scanSubcommandProjects, scanSubcommandConfig}
+var scanSubcommands = []string{scanSubcommandProjects, scanSubcommandConfig, scanSubcommandResources}
 
 const (
-	scanProjectsPath = "/data/api/v1/scan/projects"
-	scanConfigPath   = "/data/api/v1/scan/config"
+	scanProjectsPath  = "/data/api/v1/scan/projects"
+	scanConfigPath    = "/data/api/v1/scan/config"
+	scanResourcesPath = "/data/api/v1/scan/resources"
 )
 
 func (c *CLI) runScanConfig(args []string) error {
@@ -22,6 +29,10 @@ func (c *CLI) runScanProjects(args []string) error {
 	return c.runScanMutation("scan projects", scanProjectsPath, args)
 }
 
+func (c *CLI) runScanResources(args []string) error {
+	return c.runScanMutation("scan resources", scanResourcesPath, args)
+}
+
 func (c *CLI) runScanMutation(commandName string, path string, args []string) error {
 	fs := flag.NewFlagSet(commandName, flag.ContinueOnError)
 	fs.SetOutput(c.Err)
@@ -29,20 +40,31 @@ func (c *CLI) runScanMutation(commandName string, path string, args []string) er
 	var common wrapperCommon
 	var yes bool
 	var dryRun bool
+	var wait scanWaitOptions
 	bindWrapperCommon(fs, &common)
 	fs.BoolVar(&yes, "yes", false, "Confirm mutating request")
 	fs.BoolVar(&dryRun, "dry-run", false, "Append dryRun=true query parameter")
+	fs.BoolVar(&wait.enabled, "wait", false, "Poll the scan status endpoint until the scan completes, then print the scan summary")
+	fs.DurationVar(&wait.interval, "interval", 2*time.Second, "Polling interval for --wait")
+	fs.DurationVar(&wait.timeout, "wait-timeout", 2*time.Minute, "Maximum total wait time for --wait")
 
 	if err := parseWrapperFlagSet(fs, args); err != nil {
 		return err
 	}
+	if !wait.enabled && (flagWasSet(fs, "interval") || flagWasSet(fs, "wait-timeout")) {
+		return &igwerr.UsageError{Msg: "--interval and --wait-timeout require --wait"}
+	}
+	if wait.enabled {
+		if dryRun {
+			return &igwerr.UsageError{Msg: "--wait is not supported with --dry-run"}
+		}
+		return c.runScanWait(commandName, path, common, yes, wait)
+	}
 
 	callArgs := []string{
 		"--method", "POST",
 		"--path", path,
-		"--timeout", common.timeout.String(),
 	}
-	callArgs = append(callArgs, common.callArgsExcludingTimeout()...)
 	if dryRun {
 		callArgs = append(callArgs, "--dry-run")
 	}
@@ -50,5 +72,5 @@ func (c *CLI) runScanMutation(commandName string, path string, args []string) er
 		callArgs = append(callArgs, "--yes")
 	}
 
-	return c.runCall(callArgs)
+	return c.runWrapperCall(common, callArgs)
 }
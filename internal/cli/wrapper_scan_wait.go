@@ -0,0 +1,164 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/alex-mccollum/igw-cli/internal/gateway"
+	"github.com/alex-mccollum/igw-cli/internal/igwerr"
+)
+
+const scanWaitCondition = "complete"
+
+type scanWaitOptions struct {
+	enabled  bool
+	interval time.Duration
+	timeout  time.Duration
+}
+
+// scanStatusPath is the status endpoint polled by --wait for a scan
+// trigger path, for example /data/api/v1/scan/projects/status.
+func scanStatusPath(path string) string {
+	return path + "/status"
+}
+
+// runScanWait starts a scan and polls its status endpoint with the wait
+// command's loop until the scan reports completion.
+func (c *CLI) runScanWait(commandName string, path string, common wrapperCommon, yes bool, opts scanWaitOptions) error {
+	selectOpts, selectErr := common.selectOptions()
+	if selectErr != nil {
+		return c.printWaitError(common.jsonOutput, selectionErrorOptions(selectOpts), selectErr)
+	}
+	if opts.interval <= 0 {
+		return c.printWaitError(common.jsonOutput, selectOpts, &igwerr.UsageError{Msg: "--interval must be positive"})
+	}
+	if opts.timeout <= 0 {
+		return c.printWaitError(common.jsonOutput, selectOpts, &igwerr.UsageError{Msg: "--wait-timeout must be positive"})
+	}
+	if !yes {
+		return c.printWaitError(common.jsonOutput, selectOpts, &igwerr.UsageError{Msg: "method POST requires --yes confirmation"})
+	}
+	client, resolved, saveHAR, err := c.newGatewayClient(common)
+	if err != nil {
+		return c.printWaitError(common.jsonOutput, selectOpts, err)
+	}
+	defer saveHAR()
+
+	ctx := context.Background()
+	start := time.Now()
+	// The trigger goes through executeCallCore so policy rules apply; only
+	// the status polling talks to the client directly.
+	resp, _, _, err := executeCallCore(client, callExecutionInput{
+		Context: ctx,
+		Method:  http.MethodPost,
+		Path:    path,
+		Timeout: common.timeout,
+		Yes:     yes,
+		Policy:  resolved.Policy,
+	})
+	if err != nil {
+		return c.printWaitError(common.jsonOutput, selectOpts, err)
+	}
+
+	check := scanStatusCheck(ctx, client, scanStatusPath(path), common.timeout)
+	result, waitErr := runWaitLoop(ctx, check, commandName, scanWaitCondition, opts.interval, opts.timeout)
+	if waitErr != nil {
+		return c.printWaitError(common.jsonOutput, selectOpts, waitErr)
+	}
+	result.ElapsedMs = time.Since(start).Milliseconds()
+
+	if common.jsonOutput {
+		payload := map[string]any{
+			"ok":        true,
+			"scan":      commandName,
+			"condition": result.Condition,
+			"status":    resp.StatusCode,
+			"attempts":  result.Attempts,
+			"elapsedMs": result.ElapsedMs,
+			"message":   result.Message,
+			"summary":   result.State,
+		}
+		if common.jsonStats || common.timing {
+			stats := map[string]any{
+				"attempts":  result.Attempts,
+				"elapsedMs": result.ElapsedMs,
+			}
+			if result.LastHTTP != nil {
+				stats["lastHTTP"] = result.LastHTTP
+			}
+			payload["stats"] = stats
+		}
+		if selectWriteErr := printJSONSelection(c.Out, payload, selectOpts); selectWriteErr != nil {
+			return c.printWaitError(common.jsonOutput, selectionErrorOptions(selectOpts), selectWriteErr)
+		}
+		return nil
+	}
+
+	fmt.Fprintf(c.Out, "%s\t%s\tattempts=%d\telapsed=%s\t%s\n",
+		result.Condition,
+		commandName,
+		result.Attempts,
+		time.Duration(result.ElapsedMs)*time.Millisecond,
+		result.Message,
+	)
+	if err := writeJSONWithOptions(c.Out, result.State, false); err != nil {
+		return err
+	}
+	if common.timing {
+		fmt.Fprintf(c.Err, "timing\tattempts=%d\telapsedMs=%d\n", result.Attempts, result.ElapsedMs)
+	}
+	return nil
+}
+
+// scanStatusCheck reads a scan status body. A boolean "running" field wins
+// when present; otherwise "state" decides, with failed states ending the
+// wait immediately.
+func scanStatusCheck(ctx context.Context, client *gateway.Client, statusPath string, timeout time.Duration) waitCheck {
+	return func() (waitObservation, error) {
+		resp, err := client.Call(ctx, gateway.CallRequest{
+			Method:       http.MethodGet,
+			Path:         statusPath,
+			Timeout:      timeout,
+			EnableTiming: true,
+		})
+		if err != nil {
+			return waitObservation{}, err
+		}
+		body, err := decodeJSONBody(resp.Body)
+		if err != nil {
+			return waitObservation{}, err
+		}
+
+		state := strings.ToUpper(strings.TrimSpace(stringFromMap(body, "state")))
+		if diagnosticsFailedState(state) {
+			return waitObservation{}, newWaitTerminalError(
+				igwerr.NewTransportError(fmt.Errorf("scan failed with state %q", state)),
+			)
+		}
+		ready := scanCompleteState(state)
+		message := fmt.Sprintf("state=%s", state)
+		if running, ok := body["running"].(bool); ok {
+			ready = !running
+			message = fmt.Sprintf("running=%t", running)
+		}
+
+		return waitObservation{
+			Ready:   ready,
+			Message: message,
+			State:   body,
+			HTTP:    resp.Timing,
+		}, nil
+	}
+}
+
+func scanCompleteState(state string) bool {
+	switch state {
+	case "COMPLETE", "COMPLETED", "DONE", "FINISHED", "IDLE", "SUCCESS":
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,113 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/alex-mccollum/igw-cli/internal/config"
+	"github.com/alex-mccollum/igw-cli/internal/igwerr"
+)
+
+// scanWaitTestClient accepts the scan POST and answers status polls with
+// statusBodies in order, repeating the last. It records every request.
+func scanWaitTestClient(statusBodies ...string) (*http.Client, *[]string) {
+	var mu sync.Mutex
+	var requests []string
+	polls := 0
+	client := newMockHTTPClient(func(r *http.Request) (*http.Response, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		requests = append(requests, r.Method+" "+r.URL.Path)
+		if r.Method == http.MethodPost {
+			return mockHTTPResponse(http.StatusAccepted, `{"started":true}`, nil), nil
+		}
+		body := statusBodies[len(statusBodies)-1]
+		if polls < len(statusBodies) {
+			body = statusBodies[polls]
+		}
+		polls++
+		return mockHTTPResponse(http.StatusOK, body, nil), nil
+	})
+	return client, &requests
+}
+
+func TestScanResourcesWaitPollsUntilComplete(t *testing.T) {
+	t.Parallel()
+
+	var out bytes.Buffer
+	client, requests := scanWaitTestClient(`{"running":true}`, `{"running":false,"resourcesScanned":3}`)
+	c := newAdminWrapperTestCLI(client)
+	c.Out = &out
+	err := c.Execute([]string{
+		"scan", "resources",
+		"--gateway-url", mockGatewayURL,
+		"--api-key", "secret",
+		"--yes", "--wait", "--interval", "1ms", "--json",
+	})
+	if err != nil {
+		t.Fatalf("scan resources --wait: %v", err)
+	}
+
+	want := []string{
+		"POST /data/api/v1/scan/resources",
+		"GET /data/api/v1/scan/resources/status",
+		"GET /data/api/v1/scan/resources/status",
+	}
+	if strings.Join(*requests, ",") != strings.Join(want, ",") {
+		t.Fatalf("unexpected requests %v", *requests)
+	}
+
+	var payload struct {
+		OK        bool           `json:"ok"`
+		Status    int            `json:"status"`
+		Attempts  int            `json:"attempts"`
+		ElapsedMs *int64         `json:"elapsedMs"`
+		Summary   map[string]any `json:"summary"`
+	}
+	if jsonErr := json.Unmarshal(out.Bytes(), &payload); jsonErr != nil {
+		t.Fatalf("parse: %v\n%s", jsonErr, out.String())
+	}
+	if !payload.OK || payload.Status != http.StatusAccepted || payload.Attempts != 2 || payload.ElapsedMs == nil || payload.Summary["resourcesScanned"] != float64(3) {
+		t.Fatalf("unexpected payload %s", out.String())
+	}
+}
+
+func TestScanWaitFailedStateAndUsage(t *testing.T) {
+	t.Parallel()
+
+	var out bytes.Buffer
+	client, _ := scanWaitTestClient(`{"state":"RUNNING"}`, `{"state":"FAILED"}`)
+	c := newAdminWrapperTestCLI(client)
+	c.Out = &out
+	base := []string{"scan", "projects", "--gateway-url", mockGatewayURL, "--api-key", "secret"}
+
+	err := c.Execute(append(base, "--yes", "--wait", "--interval", "1ms"))
+	if code := igwerr.ExitCode(err); code != 7 {
+		t.Fatalf("expected network exit code for a failed scan, got %d (%v)", code, err)
+	}
+	if !strings.Contains(err.Error(), `scan failed with state "FAILED"`) {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	requireUsageExitCode(t, c.Execute(append(base, "--wait")))
+	requireUsageExitCode(t, c.Execute(append(base, "--yes", "--wait-timeout", "5s")))
+	requireUsageExitCode(t, c.Execute(append(base, "--yes", "--wait", "--dry-run")))
+}
+
+func TestScanWaitHonorsPolicy(t *testing.T) {
+	t.Parallel()
+
+	client, requests := scanWaitTestClient(`{"running":false}`)
+	c := newAdminWrapperTestCLI(client)
+	c.ReadConfig = policyTestConfig(&config.Policy{Deny: []config.PolicyRule{{Method: "POST", Path: "/data/api/v1/scan"}}})
+
+	err := c.Execute([]string{"scan", "projects", "--yes", "--wait", "--interval", "1ms"})
+	requireUsageExitCode(t, err)
+	if len(*requests) != 0 {
+		t.Fatalf("denied scan must not reach the gateway, got %v", *requests)
+	}
+}
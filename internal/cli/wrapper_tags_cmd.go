@@ -1,6 +1,7 @@
 package cli
 
 import (
+	"encoding/json"
 	"flag"
 	"strings"
 
@@ -18,6 +19,7 @@ func (c *CLI) runTagsExport(args []string) error {
 	var recursive string
 	var includeUdts string
 	var outPath string
+	var prettyXML bool
 	bindWrapperCommon(fs, &common)
 	fs.StringVar(&provider, "provider", "default", "Tag provider name")
 	fs.StringVar(&exportType, "type", "json", "Export type: json|xml")
@@ -25,6 +27,7 @@ func (c *CLI) runTagsExport(args []string) error {
 	fs.StringVar(&recursive, "recursive", "", "Set recursive query to true/false")
 	fs.StringVar(&includeUdts, "include-udts", "", "Set includeUdts query to true/false")
 	fs.StringVar(&outPath, "out", "", "Write tag export to file")
+	fs.BoolVar(&prettyXML, "pretty-xml", false, "Re-indent XML exports (non-JSON mode)")
 
 	if err := parseWrapperFlagSet(fs, args); err != nil {
 		return err
@@ -53,7 +56,6 @@ func (c *CLI) runTagsExport(args []string) error {
 		"--query", "provider=" + provider,
 		"--query", "type=" + normalizedType,
 	}
-	callArgs = append(callArgs, common.callArgs()...)
 	if strings.TrimSpace(rootPath) != "" {
 		callArgs = append(callArgs, "--query", "path="+strings.TrimSpace(rootPath))
 	}
@@ -66,7 +68,10 @@ func (c *CLI) runTagsExport(args []string) error {
 	if strings.TrimSpace(outPath) != "" {
 		callArgs = append(callArgs, "--out", outPath)
 	}
-	return c.runCall(callArgs)
+	if prettyXML {
+		callArgs = append(callArgs, "--pretty-xml")
+	}
+	return c.runWrapperCall(common, callArgs)
 }
 
 func (c *CLI) runTagsImport(args []string) error {
@@ -128,9 +133,122 @@ func (c *CLI) runTagsImport(args []string) error {
 		"--content-type", "application/octet-stream",
 		"--yes",
 	}
-	callArgs = append(callArgs, common.callArgs()...)
 	if strings.TrimSpace(rootPath) != "" {
 		callArgs = append(callArgs, "--query", "path="+strings.TrimSpace(rootPath))
 	}
-	return c.runCall(callArgs)
+	return c.runWrapperCall(common, callArgs)
+}
+
+func (c *CLI) runTagsBrowse(args []string) error {
+	fs := flag.NewFlagSet("tags browse", flag.ContinueOnError)
+	fs.SetOutput(c.Err)
+
+	var common wrapperCommon
+	var provider string
+	var rootPath string
+	var output recordOutputOptions
+	bindWrapperCommon(fs, &common)
+	fs.StringVar(&provider, "provider", "default", "Tag provider name")
+	fs.StringVar(&rootPath, "path", "", "Folder to list (default: provider root)")
+	bindRecordOutputFlags(fs, &output)
+
+	if err := parseWrapperFlagSet(fs, args); err != nil {
+		return err
+	}
+
+	callArgs := []string{
+		"--method", "GET",
+		"--path", "/data/api/v1/tags/browse",
+		"--query", "provider=" + tagProvider(provider),
+	}
+	if strings.TrimSpace(rootPath) != "" {
+		callArgs = append(callArgs, "--query", "path="+strings.TrimSpace(rootPath))
+	}
+	callArgs = append(callArgs, output.defaultTable(common, "name,tagType").args()...)
+	return c.runWrapperCall(common, callArgs)
+}
+
+func (c *CLI) runTagsRead(args []string) error {
+	fs := flag.NewFlagSet("tags read", flag.ContinueOnError)
+	fs.SetOutput(c.Err)
+
+	var common wrapperCommon
+	var provider string
+	var paths string
+	var output recordOutputOptions
+	bindWrapperCommon(fs, &common)
+	fs.StringVar(&provider, "provider", "default", "Tag provider name")
+	fs.StringVar(&paths, "paths", "", "Comma-separated tag paths to read")
+	bindRecordOutputFlags(fs, &output)
+
+	if err := parseWrapperFlagSet(fs, args); err != nil {
+		return err
+	}
+	tagPaths := parseColumns(paths)
+	if len(tagPaths) == 0 {
+		return &igwerr.UsageError{Msg: "required: --paths"}
+	}
+
+	callArgs := []string{
+		"--method", "GET",
+		"--path", "/data/api/v1/tags/read",
+		"--query", "provider=" + tagProvider(provider),
+	}
+	for _, tagPath := range tagPaths {
+		callArgs = append(callArgs, "--query", "path="+tagPath)
+	}
+	callArgs = append(callArgs, output.defaultTable(common, "path,value,quality").args()...)
+	return c.runWrapperCall(common, callArgs)
+}
+
+func (c *CLI) runTagsWrite(args []string) error {
+	fs := flag.NewFlagSet("tags write", flag.ContinueOnError)
+	fs.SetOutput(c.Err)
+
+	var common wrapperCommon
+	var provider string
+	var tagPath string
+	var value string
+	var yes bool
+	bindWrapperCommon(fs, &common)
+	fs.StringVar(&provider, "provider", "default", "Tag provider name")
+	fs.StringVar(&tagPath, "path", "", "Tag path to write")
+	fs.StringVar(&value, "value", "", `JSON value to write, e.g. '{"value":42}'`)
+	fs.BoolVar(&yes, "yes", false, "Confirm mutating request")
+
+	if err := parseWrapperFlagSet(fs, args); err != nil {
+		return err
+	}
+	tagPath = strings.TrimSpace(tagPath)
+	if tagPath == "" {
+		return &igwerr.UsageError{Msg: "required: --path"}
+	}
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return &igwerr.UsageError{Msg: "required: --value"}
+	}
+	if !json.Valid([]byte(value)) {
+		return &igwerr.UsageError{Msg: "--value must be valid JSON, e.g. '{\"value\":42}'"}
+	}
+	if !yes {
+		return &igwerr.UsageError{Msg: "required: --yes"}
+	}
+
+	callArgs := []string{
+		"--method", "POST",
+		"--path", "/data/api/v1/tags/write",
+		"--query", "provider=" + tagProvider(provider),
+		"--query", "path=" + tagPath,
+		"--body", value,
+		"--content-type", "application/json",
+		"--yes",
+	}
+	return c.runWrapperCall(common, callArgs)
+}
+
+func tagProvider(provider string) string {
+	if provider = strings.TrimSpace(provider); provider != "" {
+		return provider
+	}
+	return "default"
 }
@@ -0,0 +1,109 @@
+package cli
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+type tagsTestRequest struct {
+	method string
+	path   string
+	query  url.Values
+	body   string
+}
+
+func newTagsTestGateway(t *testing.T, response string) (*httptest.Server, *tagsTestRequest) {
+	t.Helper()
+	got := &tagsTestRequest{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		*got = tagsTestRequest{method: r.Method, path: r.URL.Path, query: r.URL.Query(), body: string(body)}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(response))
+	}))
+	t.Cleanup(srv.Close)
+	return srv, got
+}
+
+func TestTagsBrowseRendersTable(t *testing.T) {
+	t.Parallel()
+
+	srv, got := newTagsTestGateway(t, `[{"name":"Motor1","tagType":"UdtInstance","hasChildren":true},{"name":"Speed","tagType":"AtomicTag"}]`)
+	c := newAdminWrapperTestCLI(srv.Client())
+	out := c.Out.(*bytes.Buffer)
+
+	if err := c.Execute([]string{"tags", "browse", "--gateway-url", srv.URL, "--api-key", "secret", "--path", "Line1/Motors"}); err != nil {
+		t.Fatalf("tags browse: %v", err)
+	}
+	if got.method != http.MethodGet || got.path != "/data/api/v1/tags/browse" {
+		t.Fatalf("unexpected request %s %s", got.method, got.path)
+	}
+	if want := (url.Values{"provider": {"default"}, "path": {"Line1/Motors"}}); !reflect.DeepEqual(got.query, want) {
+		t.Fatalf("unexpected query %v", got.query)
+	}
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	if len(lines) != 3 || strings.Fields(lines[0])[0] != "NAME" || strings.Fields(lines[1])[0] != "Motor1" || !strings.Contains(lines[2], "AtomicTag") {
+		t.Fatalf("unexpected browse table %q", out.String())
+	}
+
+	out.Reset()
+	if err := c.Execute([]string{"tags", "browse", "--gateway-url", srv.URL, "--api-key", "secret", "--provider", "edge", "--json", "--select", "response.body.0.name", "--raw"}); err != nil {
+		t.Fatalf("tags browse --json: %v", err)
+	}
+	if out.String() != "Motor1\n" || got.query.Get("provider") != "edge" || got.query.Has("path") {
+		t.Fatalf("unexpected raw select %q for query %v", out.String(), got.query)
+	}
+}
+
+func TestTagsReadSendsEachPath(t *testing.T) {
+	t.Parallel()
+
+	srv, got := newTagsTestGateway(t, `[{"path":"Line1/Speed","value":42.5,"quality":"Good"},{"path":"Line1/Running","value":true,"quality":"Good"}]`)
+	c := newAdminWrapperTestCLI(srv.Client())
+	out := c.Out.(*bytes.Buffer)
+
+	if err := c.Execute([]string{"tags", "read", "--gateway-url", srv.URL, "--api-key", "secret", "--paths", "Line1/Speed, Line1/Running"}); err != nil {
+		t.Fatalf("tags read: %v", err)
+	}
+	if got.method != http.MethodGet || got.path != "/data/api/v1/tags/read" {
+		t.Fatalf("unexpected request %s %s", got.method, got.path)
+	}
+	if want := (url.Values{"provider": {"default"}, "path": {"Line1/Speed", "Line1/Running"}}); !reflect.DeepEqual(got.query, want) {
+		t.Fatalf("unexpected query %v", got.query)
+	}
+	if !strings.Contains(out.String(), "Line1/Speed") || !strings.Contains(out.String(), "42.5") || !strings.Contains(out.String(), "Good") {
+		t.Fatalf("unexpected read table %q", out.String())
+	}
+
+	requireUsageExitCode(t, c.Execute([]string{"tags", "read", "--gateway-url", srv.URL, "--api-key", "secret"}))
+}
+
+func TestTagsWriteValidatesAndSendsValue(t *testing.T) {
+	t.Parallel()
+
+	srv, got := newTagsTestGateway(t, `{"ok":true}`)
+	c := newAdminWrapperTestCLI(srv.Client())
+	base := []string{"tags", "write", "--gateway-url", srv.URL, "--api-key", "secret", "--path", "Line1/Setpoint"}
+
+	requireUsageExitCode(t, c.Execute(append(base, "--value", `{"value":42}`)))
+	requireUsageExitCode(t, c.Execute(append(base, "--value", `{value:42}`, "--yes")))
+	if got.method != "" {
+		t.Fatalf("rejected writes should not reach the gateway, got %s %s", got.method, got.path)
+	}
+
+	if err := c.Execute(append(base, "--value", `{"value":42}`, "--yes", "--json")); err != nil {
+		t.Fatalf("tags write: %v", err)
+	}
+	if got.method != http.MethodPost || got.path != "/data/api/v1/tags/write" || got.body != `{"value":42}` {
+		t.Fatalf("unexpected request %s %s %q", got.method, got.path, got.body)
+	}
+	if want := (url.Values{"provider": {"default"}, "path": {"Line1/Setpoint"}}); !reflect.DeepEqual(got.query, want) {
+		t.Fatalf("unexpected query %v", got.query)
+	}
+}
@@ -0,0 +1,194 @@
+package config
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// CacheMaxBytes caps the response cache directory; the least recently
+// used entries are removed once a write takes it past the cap.
+const CacheMaxBytes = 32 << 20
+
+// CacheEntry is one cached GET response. It never holds the token; the
+// token only contributes a hash to the key.
+type CacheEntry struct {
+	StoredAt   time.Time   `json:"storedAt"`
+	ExpiresAt  time.Time   `json:"expiresAt"`
+	Method     string      `json:"method"`
+	URL        string      `json:"url"`
+	StatusCode int         `json:"status"`
+	Headers    http.Header `json:"headers,omitempty"`
+	Body       []byte      `json:"body"`
+}
+
+// ResponseCache stores entries as one file per key under Dir.
+type ResponseCache struct {
+	Dir      string
+	MaxBytes int64
+	Now      func() time.Time
+}
+
+func CacheDir() (string, error) {
+	dir, err := Dir()
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(dir, "cache"), nil
+}
+
+// CacheKey identifies a request by gateway, method, path, query, request
+// headers, and a hash of the token, so profiles with different tokens, and
+// requests that differ in Accept or any other header, never share entries.
+func CacheKey(gatewayURL string, method string, path string, query []string, headers http.Header, token string) string {
+	tokenHash := sha256.Sum256([]byte(token))
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	headerLines := make([]string, 0, len(names))
+	for _, name := range names {
+		headerLines = append(headerLines, http.CanonicalHeaderKey(name)+": "+strings.Join(headers[name], ", "))
+	}
+	material := strings.Join([]string{
+		strings.TrimRight(strings.TrimSpace(gatewayURL), "/"),
+		strings.ToUpper(method),
+		path,
+		strings.Join(query, "&"),
+		strings.Join(headerLines, "\r\n"),
+		hex.EncodeToString(tokenHash[:]),
+	}, "\n")
+	sum := sha256.Sum256([]byte(material))
+	return hex.EncodeToString(sum[:])
+}
+
+func (c ResponseCache) now() time.Time {
+	if c.Now != nil {
+		return c.Now()
+	}
+	return time.Now()
+}
+
+func (c ResponseCache) entryPath(key string) string {
+	return filepath.Join(c.Dir, key+".json")
+}
+
+// Get returns the entry for key. An expired entry is deleted and reported
+// as a miss; a hit marks the entry as recently used.
+func (c ResponseCache) Get(key string) (CacheEntry, bool, error) {
+	path := c.entryPath(key)
+	b, err := os.ReadFile(path) //nolint:gosec // path is derived from a hex key
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return CacheEntry{}, false, nil
+		}
+		return CacheEntry{}, false, fmt.Errorf("read cache entry: %w", err)
+	}
+
+	var entry CacheEntry
+	if err := json.Unmarshal(b, &entry); err != nil {
+		_ = os.Remove(path)
+		return CacheEntry{}, false, nil
+	}
+	now := c.now()
+	if !now.Before(entry.ExpiresAt) {
+		if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return CacheEntry{}, false, fmt.Errorf("evict cache entry: %w", err)
+		}
+		return CacheEntry{}, false, nil
+	}
+	_ = os.Chtimes(path, now, now)
+	return entry, true, nil
+}
+
+// Put stores entry under key, then prunes the directory to MaxBytes.
+func (c ResponseCache) Put(key string, entry CacheEntry) error {
+	if err := os.MkdirAll(c.Dir, 0o700); err != nil {
+		return fmt.Errorf("create cache dir: %w", err)
+	}
+	b, err := json.Marshal(entry)
+	if err != nil {
+		return fmt.Errorf("encode cache entry: %w", err)
+	}
+
+	// A unique temp file keeps concurrent writers of the same key from
+	// renaming each other's partial writes into place.
+	tmp, err := os.CreateTemp(c.Dir, key+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("create cache temp: %w", err)
+	}
+	_, writeErr := tmp.Write(b)
+	closeErr := tmp.Close()
+	if writeErr == nil {
+		writeErr = closeErr
+	}
+	if writeErr != nil {
+		_ = os.Remove(tmp.Name())
+		return fmt.Errorf("write cache temp: %w", writeErr)
+	}
+	path := c.entryPath(key)
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		_ = os.Remove(tmp.Name())
+		return fmt.Errorf("commit cache entry: %w", err)
+	}
+	now := c.now()
+	_ = os.Chtimes(path, now, now)
+	return c.prune()
+}
+
+// prune removes the least recently used entries until the directory fits
+// MaxBytes (CacheMaxBytes when unset).
+func (c ResponseCache) prune() error {
+	limit := c.MaxBytes
+	if limit <= 0 {
+		limit = CacheMaxBytes
+	}
+	dirEntries, err := os.ReadDir(c.Dir)
+	if err != nil {
+		return fmt.Errorf("read cache dir: %w", err)
+	}
+
+	type cacheFile struct {
+		path    string
+		size    int64
+		touched time.Time
+	}
+	var files []cacheFile
+	var total int64
+	for _, dirEntry := range dirEntries {
+		if dirEntry.IsDir() || filepath.Ext(dirEntry.Name()) != ".json" {
+			continue
+		}
+		info, err := dirEntry.Info()
+		if err != nil {
+			continue
+		}
+		files = append(files, cacheFile{path: filepath.Join(c.Dir, dirEntry.Name()), size: info.Size(), touched: info.ModTime()})
+		total += info.Size()
+	}
+	if total <= limit {
+		return nil
+	}
+
+	sort.Slice(files, func(i, j int) bool { return files[i].touched.Before(files[j].touched) })
+	for _, file := range files {
+		if total <= limit {
+			break
+		}
+		if err := os.Remove(file.path); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf("prune cache: %w", err)
+		}
+		total -= file.size
+	}
+	return nil
+}
@@ -0,0 +1,82 @@
+package config
+
+import (
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestResponseCacheExpiresOnRead(t *testing.T) {
+	t.Parallel()
+
+	now := time.Unix(1_700_000_000, 0)
+	cache := ResponseCache{Dir: filepath.Join(t.TempDir(), "cache"), Now: func() time.Time { return now }}
+	key := CacheKey("http://gw:8088/", "get", "/data/api/v1/gateway-info", []string{"a=1"}, nil, "secret")
+
+	if err := cache.Put(key, CacheEntry{ExpiresAt: now.Add(30 * time.Second), StatusCode: 200, Body: []byte(`{"ok":true}`)}); err != nil {
+		t.Fatalf("put: %v", err)
+	}
+	entry, hit, err := cache.Get(key)
+	if err != nil || !hit || string(entry.Body) != `{"ok":true}` {
+		t.Fatalf("expected a hit, got %+v hit=%t err=%v", entry, hit, err)
+	}
+	raw, _ := os.ReadFile(filepath.Join(cache.Dir, key+".json"))
+	if strings.Contains(string(raw), "secret") {
+		t.Fatalf("cache entry leaks the token: %s", raw)
+	}
+
+	now = now.Add(30 * time.Second)
+	if _, hit, err := cache.Get(key); hit || err != nil {
+		t.Fatalf("expected an expired miss, got hit=%t err=%v", hit, err)
+	}
+	if _, err := os.Stat(filepath.Join(cache.Dir, key+".json")); !os.IsNotExist(err) {
+		t.Fatalf("expired entry not evicted: %v", err)
+	}
+
+	if other := CacheKey("http://gw:8088", "GET", "/data/api/v1/gateway-info", []string{"a=1"}, nil, "other"); other == key {
+		t.Fatalf("tokens must not share cache keys")
+	}
+	if same := CacheKey("http://gw:8088", "GET", "/data/api/v1/gateway-info", []string{"a=1"}, nil, "secret"); same != key {
+		t.Fatalf("expected the trailing slash and method case to be normalized")
+	}
+	accept := http.Header{"Accept": {"text/plain"}}
+	if withAccept := CacheKey("http://gw:8088", "GET", "/data/api/v1/gateway-info", []string{"a=1"}, accept, "secret"); withAccept == key {
+		t.Fatalf("request headers must be part of the cache key")
+	}
+	if leftovers, _ := filepath.Glob(filepath.Join(cache.Dir, "*.tmp")); len(leftovers) != 0 {
+		t.Fatalf("expected no temp files after a put, got %v", leftovers)
+	}
+}
+
+func TestResponseCachePrunesLeastRecentlyUsed(t *testing.T) {
+	t.Parallel()
+
+	now := time.Unix(1_700_000_000, 0)
+	cache := ResponseCache{Dir: t.TempDir(), MaxBytes: 600, Now: func() time.Time { return now }}
+	body := []byte(strings.Repeat("x", 100))
+	put := func(key string) {
+		t.Helper()
+		now = now.Add(time.Second)
+		if err := cache.Put(key, CacheEntry{ExpiresAt: now.Add(time.Hour), Body: body}); err != nil {
+			t.Fatalf("put %s: %v", key, err)
+		}
+	}
+
+	put("a")
+	put("b")
+	now = now.Add(time.Second)
+	if _, hit, _ := cache.Get("a"); !hit {
+		t.Fatalf("expected a hit for a")
+	}
+	put("c")
+
+	for key, want := range map[string]bool{"a": true, "b": false, "c": true} {
+		_, err := os.Stat(filepath.Join(cache.Dir, key+".json"))
+		if exists := err == nil; exists != want {
+			t.Fatalf("entry %s: exists=%t, want %t", key, exists, want)
+		}
+	}
+}
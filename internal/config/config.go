@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"net/http"
 	"os"
 	"path/filepath"
 	"strings"
@@ -14,22 +15,68 @@ const (
 	EnvToken      = "IGNITION_API_TOKEN"
 )
 
+// TokenRefDefault is the tokenRef value that points a profile at the
+// top-level token instead of another profile.
+const TokenRefDefault = "@default"
+
 type File struct {
 	GatewayURL    string             `json:"gatewayURL,omitempty"`
 	Token         string             `json:"token,omitempty"`
 	ActiveProfile string             `json:"activeProfile,omitempty"`
 	Profiles      map[string]Profile `json:"profiles,omitempty"`
+	// Policy applies to every profile.
+	Policy *Policy `json:"policy,omitempty"`
+	// History, when enabled, records executed calls to HistoryPath.
+	History *History `json:"history,omitempty"`
+	// Encryption is set once `igw config encrypt` has encrypted the stored
+	// tokens; nil means they are plaintext.
+	Encryption *Encryption `json:"encryption,omitempty"`
+	// ProxyURL is the default proxy for every profile without its own.
+	ProxyURL string `json:"proxyURL,omitempty"`
+	// DefaultHeaders are sent on every request; profiles can add to or
+	// override them per name.
+	DefaultHeaders map[string]string `json:"defaultHeaders,omitempty"`
 }
 
 type Profile struct {
 	GatewayURL string `json:"gatewayURL,omitempty"`
 	Token      string `json:"token,omitempty"`
+	// TokenEnv names an environment variable read for the token at call
+	// time, so the token itself never lands in the config file.
+	TokenEnv string `json:"tokenEnv,omitempty"`
+	// TokenCommand is a shell command whose stdout is the token, e.g.
+	// "pass show igw". It runs at call time.
+	TokenCommand string `json:"tokenCommand,omitempty"`
+	// TokenRef names another profile (or TokenRefDefault) whose token is
+	// used when the profile has no token source of its own.
+	TokenRef string `json:"tokenRef,omitempty"`
+	// ProxyURL routes the profile's requests through an http, https, or
+	// socks5 proxy, overriding the top-level ProxyURL.
+	ProxyURL string `json:"proxyURL,omitempty"`
+	// CACert, ClientCert, and ClientKey are PEM file paths for https
+	// gateways; InsecureSkipVerify disables certificate verification.
+	CACert             string `json:"caCert,omitempty"`
+	ClientCert         string `json:"clientCert,omitempty"`
+	ClientKey          string `json:"clientKey,omitempty"`
+	InsecureSkipVerify bool   `json:"insecureSkipVerify,omitempty"`
+	// DefaultHeaders are merged over the top-level DefaultHeaders.
+	DefaultHeaders map[string]string `json:"defaultHeaders,omitempty"`
 }
 
 type Effective struct {
-	GatewayURL string `json:"gatewayURL,omitempty"`
-	Token      string `json:"token,omitempty"`
-	Profile    string `json:"profile,omitempty"`
+	GatewayURL string  `json:"gatewayURL,omitempty"`
+	Token      string  `json:"token,omitempty"`
+	Profile    string  `json:"profile,omitempty"`
+	ProxyURL   string  `json:"proxyURL,omitempty"`
+	Policy     *Policy `json:"-"`
+	// DefaultHeaders is the top-level map with the profile's entries
+	// merged over it, keyed by canonical header name.
+	DefaultHeaders map[string]string `json:"-"`
+
+	CACert             string `json:"caCert,omitempty"`
+	ClientCert         string `json:"clientCert,omitempty"`
+	ClientKey          string `json:"clientKey,omitempty"`
+	InsecureSkipVerify bool   `json:"insecureSkipVerify,omitempty"`
 }
 
 func Dir() (string, error) {
@@ -117,7 +164,10 @@ func ResolveWithProfile(fileCfg File, getenv func(string) string, flagGatewayURL
 	out := Effective{
 		GatewayURL: strings.TrimSpace(fileCfg.GatewayURL),
 		Token:      strings.TrimSpace(fileCfg.Token),
+		ProxyURL:   strings.TrimSpace(fileCfg.ProxyURL),
+		Policy:     fileCfg.Policy,
 	}
+	out.DefaultHeaders = mergeDefaultHeaders(out.DefaultHeaders, fileCfg.DefaultHeaders)
 
 	profile = strings.TrimSpace(profile)
 	if profile == "" {
@@ -129,9 +179,29 @@ func ResolveWithProfile(fileCfg File, getenv func(string) string, flagGatewayURL
 			return Effective{}, fmt.Errorf("profile %q not found", profile)
 		}
 
+		token, _, err := fileCfg.ProfileToken(profile)
+		if err != nil {
+			return Effective{}, err
+		}
+		// Env and command sources only run when nothing overrides them.
+		if token == "" && strings.TrimSpace(getenv(EnvToken)) == "" && strings.TrimSpace(flagToken) == "" {
+			token, err = fileCfg.externalProfileToken(profile, getenv)
+			if err != nil {
+				return Effective{}, err
+			}
+		}
+
 		out.GatewayURL = strings.TrimSpace(profileCfg.GatewayURL)
-		out.Token = strings.TrimSpace(profileCfg.Token)
+		out.Token = token
 		out.Profile = profile
+		if v := strings.TrimSpace(profileCfg.ProxyURL); v != "" {
+			out.ProxyURL = v
+		}
+		out.CACert = strings.TrimSpace(profileCfg.CACert)
+		out.ClientCert = strings.TrimSpace(profileCfg.ClientCert)
+		out.ClientKey = strings.TrimSpace(profileCfg.ClientKey)
+		out.InsecureSkipVerify = profileCfg.InsecureSkipVerify
+		out.DefaultHeaders = mergeDefaultHeaders(out.DefaultHeaders, profileCfg.DefaultHeaders)
 	}
 
 	if v := strings.TrimSpace(getenv(EnvGatewayURL)); v != "" {
@@ -150,10 +220,78 @@ func ResolveWithProfile(fileCfg File, getenv func(string) string, flagGatewayURL
 	return out, nil
 }
 
+// mergeDefaultHeaders copies headers over base by canonical name, so
+// "x-org-id" in a profile replaces "X-Org-Id" from the top level.
+func mergeDefaultHeaders(base map[string]string, headers map[string]string) map[string]string {
+	if len(headers) == 0 {
+		return base
+	}
+	if base == nil {
+		base = make(map[string]string, len(headers))
+	}
+	for name, value := range headers {
+		base[http.CanonicalHeaderKey(strings.TrimSpace(name))] = strings.TrimSpace(value)
+	}
+	return base
+}
+
+// ProfileToken returns the literal token for the named profile, following
+// tokenRef links. inheritedFrom names the profile (or TokenRefDefault) that
+// supplied the token and is empty when the profile sets its own. The token
+// is empty when the supplying profile uses tokenEnv or tokenCommand; see
+// ProfileTokenSource.
+func (f File) ProfileToken(name string) (token string, inheritedFrom string, err error) {
+	owner, inheritedFrom, err := f.profileTokenOwner(name)
+	if err != nil {
+		return "", "", err
+	}
+	if owner == TokenRefDefault {
+		return strings.TrimSpace(f.Token), inheritedFrom, nil
+	}
+	return strings.TrimSpace(f.Profiles[owner].Token), inheritedFrom, nil
+}
+
+// profileTokenOwner follows tokenRef links to the profile (or
+// TokenRefDefault) that supplies name's token.
+func (f File) profileTokenOwner(name string) (owner string, inheritedFrom string, err error) {
+	chain := []string{name}
+	seen := map[string]bool{name: true}
+	current := name
+	for {
+		profile, ok := f.Profiles[current]
+		if !ok {
+			return "", "", fmt.Errorf("profile %q not found", current)
+		}
+		if profile.hasOwnToken() || strings.TrimSpace(profile.TokenRef) == "" {
+			if current == name {
+				return current, "", nil
+			}
+			return current, current, nil
+		}
+
+		ref := strings.TrimSpace(profile.TokenRef)
+		if ref == TokenRefDefault {
+			return TokenRefDefault, TokenRefDefault, nil
+		}
+		if seen[ref] {
+			return "", "", fmt.Errorf("profile %q tokenRef cycle: %s -> %s", name, strings.Join(chain, " -> "), ref)
+		}
+		if _, ok := f.Profiles[ref]; !ok {
+			return "", "", fmt.Errorf("profile %q tokenRef %q not found", current, ref)
+		}
+		seen[ref] = true
+		chain = append(chain, ref)
+		current = ref
+	}
+}
+
 func MaskToken(token string) string {
 	if token == "" {
 		return ""
 	}
+	if IsEncryptedToken(token) {
+		return EncryptedTokenPrefix + "****"
+	}
 
 	if len(token) <= 4 {
 		return "****"
@@ -1,6 +1,10 @@
 package config
 
-import "testing"
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
 
 func TestResolvePrecedence(t *testing.T) {
 	t.Parallel()
@@ -75,3 +79,104 @@ func TestResolveWithProfileUnknown(t *testing.T) {
 		t.Fatalf("expected missing profile error")
 	}
 }
+
+func TestResolveWithProfileInheritsToken(t *testing.T) {
+	t.Parallel()
+
+	fileCfg := File{
+		Token: "shared-token",
+		Profiles: map[string]Profile{
+			"east": {GatewayURL: "http://east:8088", TokenRef: TokenRefDefault},
+			"west": {GatewayURL: "http://west:8088", TokenRef: "east"},
+		},
+	}
+
+	resolved, err := ResolveWithProfile(fileCfg, func(string) string { return "" }, "", "", "west")
+	if err != nil {
+		t.Fatalf("resolve inherited token: %v", err)
+	}
+	if resolved.GatewayURL != "http://west:8088" {
+		t.Fatalf("unexpected gateway url %q", resolved.GatewayURL)
+	}
+	if resolved.Token != "shared-token" {
+		t.Fatalf("unexpected token %q", resolved.Token)
+	}
+
+	_, from, err := fileCfg.ProfileToken("east")
+	if err != nil {
+		t.Fatalf("profile token: %v", err)
+	}
+	if from != TokenRefDefault {
+		t.Fatalf("unexpected inherited source %q", from)
+	}
+}
+
+func TestResolveWithProfileTokenRefCycle(t *testing.T) {
+	t.Parallel()
+
+	fileCfg := File{
+		Token: "shared-token",
+		Profiles: map[string]Profile{
+			"a": {TokenRef: "b"},
+			"b": {TokenRef: "c"},
+			"c": {TokenRef: "a"},
+		},
+	}
+
+	_, err := ResolveWithProfile(fileCfg, func(string) string { return "" }, "", "", "a")
+	if err == nil {
+		t.Fatalf("expected tokenRef cycle error")
+	}
+	if !strings.Contains(err.Error(), "tokenRef cycle: a -> b -> c -> a") {
+		t.Fatalf("unexpected error %q", err.Error())
+	}
+}
+
+func TestResolveWithProfileDefaultHeaders(t *testing.T) {
+	t.Parallel()
+
+	fileCfg := File{
+		DefaultHeaders: map[string]string{"X-Org-Id": "acme", "x-env": "prod"},
+		Profiles: map[string]Profile{
+			"dev": {GatewayURL: "http://gw:8088", DefaultHeaders: map[string]string{"X-ENV": "dev", "X-Team": "ops"}},
+		},
+	}
+	resolved, err := ResolveWithProfile(fileCfg, func(string) string { return "" }, "", "", "dev")
+	if err != nil {
+		t.Fatalf("resolve: %v", err)
+	}
+	want := map[string]string{"X-Org-Id": "acme", "X-Env": "dev", "X-Team": "ops"}
+	if !reflect.DeepEqual(resolved.DefaultHeaders, want) {
+		t.Fatalf("default headers %v, want %v", resolved.DefaultHeaders, want)
+	}
+	if fileCfg.DefaultHeaders["x-env"] != "prod" {
+		t.Fatalf("resolving must not modify the config: %v", fileCfg.DefaultHeaders)
+	}
+}
+
+func TestResolveWithProfileProxyURL(t *testing.T) {
+	t.Parallel()
+
+	fileCfg := File{
+		ProxyURL: "http://default-proxy:3128",
+		Profiles: map[string]Profile{
+			"jump":  {GatewayURL: "http://gw:8088", ProxyURL: "socks5://jump:1080"},
+			"plain": {GatewayURL: "http://gw:8088"},
+		},
+	}
+	getenv := func(string) string { return "" }
+
+	for profile, want := range map[string]string{
+		"jump":  "socks5://jump:1080",
+		"plain": "http://default-proxy:3128",
+		"":      "http://default-proxy:3128",
+	} {
+		resolved, err := ResolveWithProfile(fileCfg, getenv, "", "", profile)
+		if err != nil {
+			t.Fatalf("resolve %q: %v", profile, err)
+		}
+		if resolved.ProxyURL != want {
+			t.Fatalf("profile %q: proxy %q, want %q", profile, resolved.ProxyURL, want)
+		}
+	}
+}
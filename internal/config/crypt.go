@@ -0,0 +1,247 @@
+package config
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// EnvPassphrase supplies the passphrase for tokens encrypted with
+// `igw config encrypt`.
+const EnvPassphrase = "IGW_CONFIG_PASSPHRASE"
+
+// EncryptedTokenPrefix marks a stored token as a versioned ciphertext blob.
+const EncryptedTokenPrefix = "enc:v1:"
+
+const (
+	// KDFScrypt derives the token key from a passphrase.
+	KDFScrypt = "scrypt"
+	// KDFKeyFile derives the token key from the contents of a key file.
+	KDFKeyFile = "keyfile"
+)
+
+// Default scrypt cost: about 32 MiB and well under a second per derivation.
+const (
+	defaultScryptN = 1 << 15
+	defaultScryptR = 8
+	defaultScryptP = 1
+)
+
+const minKeyFileBytes = 16
+
+var (
+	// ErrPassphraseRequired means the config has encrypted tokens and no
+	// passphrase was supplied.
+	ErrPassphraseRequired = errors.New("config tokens are encrypted: set " + EnvPassphrase + " or use --passphrase-stdin")
+	// ErrDecrypt means a stored token could not be decrypted with the
+	// supplied passphrase or key file.
+	ErrDecrypt = errors.New("decrypt config token: wrong passphrase or key file")
+)
+
+// Encryption records how `igw config encrypt` derived the key for stored
+// tokens. The key itself is never stored.
+type Encryption struct {
+	Version int    `json:"version"`
+	KDF     string `json:"kdf"`
+	// Salt is base64-encoded.
+	Salt string `json:"salt"`
+	// N, R, and P are the scrypt cost parameters.
+	N int `json:"n,omitempty"`
+	R int `json:"r,omitempty"`
+	P int `json:"p,omitempty"`
+	// KeyFile is the absolute path of the key file for KDFKeyFile.
+	KeyFile string `json:"keyFile,omitempty"`
+}
+
+// NewEncryption returns fresh key-derivation settings with a random salt.
+// keyFile selects KDFKeyFile; empty selects KDFScrypt.
+func NewEncryption(keyFile string) (*Encryption, error) {
+	salt := make([]byte, 16)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, fmt.Errorf("generate salt: %w", err)
+	}
+	enc := &Encryption{Version: 1, Salt: base64.StdEncoding.EncodeToString(salt)}
+	if strings.TrimSpace(keyFile) != "" {
+		abs, err := filepath.Abs(strings.TrimSpace(keyFile))
+		if err != nil {
+			return nil, fmt.Errorf("resolve key file: %w", err)
+		}
+		enc.KDF = KDFKeyFile
+		enc.KeyFile = abs
+		return enc, nil
+	}
+	enc.KDF = KDFScrypt
+	enc.N, enc.R, enc.P = defaultScryptN, defaultScryptR, defaultScryptP
+	return enc, nil
+}
+
+// Key derives the 32-byte token key. Passphrase is only used by
+// KDFScrypt; KDFKeyFile reads the recorded key file.
+func (e *Encryption) Key(passphrase string) ([]byte, error) {
+	if e == nil {
+		return nil, errors.New("config has encrypted tokens but no encryption settings")
+	}
+	if e.Version != 1 {
+		return nil, fmt.Errorf("unsupported config encryption version %d", e.Version)
+	}
+	salt, err := base64.StdEncoding.DecodeString(e.Salt)
+	if err != nil || len(salt) == 0 {
+		return nil, errors.New("config encryption salt is invalid")
+	}
+
+	switch e.KDF {
+	case KDFScrypt:
+		if passphrase == "" {
+			return nil, ErrPassphraseRequired
+		}
+		return scryptKey([]byte(passphrase), salt, e.N, e.R, e.P, 32)
+	case KDFKeyFile:
+		secret, err := os.ReadFile(e.KeyFile) //nolint:gosec // path recorded by config encrypt
+		if err != nil {
+			return nil, fmt.Errorf("read key file: %w", err)
+		}
+		if len(secret) < minKeyFileBytes {
+			return nil, fmt.Errorf("key file %s must hold at least %d bytes", e.KeyFile, minKeyFileBytes)
+		}
+		mac := hmac.New(sha256.New, secret)
+		mac.Write(salt)
+		return mac.Sum(nil), nil
+	default:
+		return nil, fmt.Errorf("unsupported config encryption kdf %q", e.KDF)
+	}
+}
+
+// IsEncryptedToken reports whether token is an encrypted blob.
+func IsEncryptedToken(token string) bool {
+	return strings.HasPrefix(strings.TrimSpace(token), EncryptedTokenPrefix)
+}
+
+// EncryptToken seals token with AES-256-GCM under key.
+func EncryptToken(key []byte, token string) (string, error) {
+	aead, err := newTokenAEAD(key)
+	if err != nil {
+		return "", err
+	}
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", fmt.Errorf("generate nonce: %w", err)
+	}
+	sealed := aead.Seal(nonce, nonce, []byte(token), nil)
+	return EncryptedTokenPrefix + base64.StdEncoding.EncodeToString(sealed), nil
+}
+
+// DecryptToken opens a blob written by EncryptToken. Tokens without the
+// prefix are returned unchanged.
+func DecryptToken(key []byte, value string) (string, error) {
+	value = strings.TrimSpace(value)
+	if !IsEncryptedToken(value) {
+		return value, nil
+	}
+	aead, err := newTokenAEAD(key)
+	if err != nil {
+		return "", err
+	}
+	sealed, err := base64.StdEncoding.DecodeString(strings.TrimPrefix(value, EncryptedTokenPrefix))
+	if err != nil || len(sealed) < aead.NonceSize() {
+		return "", ErrDecrypt
+	}
+	plain, err := aead.Open(nil, sealed[:aead.NonceSize()], sealed[aead.NonceSize():], nil)
+	if err != nil {
+		return "", ErrDecrypt
+	}
+	return string(plain), nil
+}
+
+func newTokenAEAD(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("token cipher: %w", err)
+	}
+	return cipher.NewGCM(block)
+}
+
+// EncryptTokens encrypts every plaintext stored token and returns how many
+// changed. Tokens that are already encrypted must open with key, so a
+// second run with the wrong passphrase fails instead of mixing keys.
+func (f *File) EncryptTokens(key []byte) (int, error) {
+	return f.rewriteTokens(func(token string) (string, bool, error) {
+		if IsEncryptedToken(token) {
+			_, err := DecryptToken(key, token)
+			return token, false, err
+		}
+		sealed, err := EncryptToken(key, token)
+		return sealed, true, err
+	})
+}
+
+// DecryptTokens replaces every encrypted stored token with its plaintext
+// and returns how many changed.
+func (f *File) DecryptTokens(key []byte) (int, error) {
+	return f.rewriteTokens(func(token string) (string, bool, error) {
+		if !IsEncryptedToken(token) {
+			return token, false, nil
+		}
+		plain, err := DecryptToken(key, token)
+		return plain, true, err
+	})
+}
+
+// rewriteTokens applies fn to the top-level token and every profile token.
+// Nothing is modified unless every token succeeds.
+func (f *File) rewriteTokens(fn func(token string) (string, bool, error)) (int, error) {
+	changed := 0
+	apply := func(token string) (string, error) {
+		if strings.TrimSpace(token) == "" {
+			return token, nil
+		}
+		out, ok, err := fn(strings.TrimSpace(token))
+		if err != nil {
+			return "", err
+		}
+		if ok {
+			changed++
+		}
+		return out, nil
+	}
+
+	top, err := apply(f.Token)
+	if err != nil {
+		return 0, err
+	}
+	profiles := make(map[string]Profile, len(f.Profiles))
+	for name, profile := range f.Profiles {
+		token, err := apply(profile.Token)
+		if err != nil {
+			return 0, fmt.Errorf("profile %q: %w", name, err)
+		}
+		profile.Token = token
+		profiles[name] = profile
+	}
+
+	f.Token = top
+	if f.Profiles != nil {
+		f.Profiles = profiles
+	}
+	return changed, nil
+}
+
+// DecryptEffectiveToken decrypts a resolved token when it is an encrypted
+// blob, deriving the key from f.Encryption and passphrase.
+func (f File) DecryptEffectiveToken(token string, passphrase string) (string, error) {
+	if !IsEncryptedToken(token) {
+		return token, nil
+	}
+	key, err := f.Encryption.Key(passphrase)
+	if err != nil {
+		return "", err
+	}
+	return DecryptToken(key, token)
+}
@@ -0,0 +1,126 @@
+package config
+
+import (
+	"encoding/hex"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestScryptKnownAnswers(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		password, salt string
+		n, r, p        int
+		want           string
+	}{
+		// RFC 7914 section 12.
+		{"", "", 16, 1, 1, "77d6576238657b203b19ca42c18a0497f16b4844e3074ae8dfdffa3fede21442fcd0069ded0948f8326a753a0fc81f17e8d3e0fb2e0d3628cf35e20c38d18906"},
+		{"password", "NaCl", 1024, 8, 16, "fdbabe1c9d3472007856e7190d01e9fe7c6ad7cbc8237830e77376634b3731622eaf30d92e22a3886ff109279d9830dac727afb94a83ee6d8360cbdfa2cc0640"},
+	}
+	for _, tc := range cases {
+		got, err := scryptKey([]byte(tc.password), []byte(tc.salt), tc.n, tc.r, tc.p, 64)
+		if err != nil {
+			t.Fatalf("scrypt(%q): %v", tc.password, err)
+		}
+		if hex.EncodeToString(got) != tc.want {
+			t.Fatalf("scrypt(%q) = %x, want %s", tc.password, got, tc.want)
+		}
+	}
+
+	if got := hex.EncodeToString(pbkdf2SHA256([]byte("password"), []byte("salt"), 4096, 32)); got != "c5e478d59288c841aa530db6845c4c8d962893a001ce4e11a4963873aa98134a" {
+		t.Fatalf("pbkdf2 = %s", got)
+	}
+	if _, err := scryptKey([]byte("x"), []byte("y"), 1000, 8, 1, 32); err == nil {
+		t.Fatalf("expected an error for N that is not a power of two")
+	}
+}
+
+func TestEncryptTokensRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	enc := &Encryption{Version: 1, KDF: KDFScrypt, Salt: "c2FsdHNhbHRzYWx0", N: 16, R: 1, P: 1}
+	key, err := enc.Key("correct horse")
+	if err != nil {
+		t.Fatalf("key: %v", err)
+	}
+	cfg := File{
+		Token: "top-secret",
+		Profiles: map[string]Profile{
+			"dev":  {Token: "dev-secret"},
+			"prod": {TokenEnv: "PROD_TOKEN"},
+		},
+		Encryption: enc,
+	}
+
+	changed, err := cfg.EncryptTokens(key)
+	if err != nil || changed != 2 {
+		t.Fatalf("encrypt: changed=%d err=%v", changed, err)
+	}
+	if !IsEncryptedToken(cfg.Token) || !IsEncryptedToken(cfg.Profiles["dev"].Token) || strings.Contains(cfg.Token, "top-secret") {
+		t.Fatalf("expected encrypted tokens, got %+v", cfg)
+	}
+	if MaskToken(cfg.Token) != "enc:v1:****" {
+		t.Fatalf("unexpected mask %q", MaskToken(cfg.Token))
+	}
+
+	token, err := cfg.DecryptEffectiveToken(cfg.Profiles["dev"].Token, "correct horse")
+	if err != nil || token != "dev-secret" {
+		t.Fatalf("decrypt effective: %q %v", token, err)
+	}
+	if _, err := cfg.DecryptEffectiveToken(cfg.Token, "wrong"); !errors.Is(err, ErrDecrypt) {
+		t.Fatalf("expected ErrDecrypt, got %v", err)
+	}
+	if _, err := cfg.DecryptEffectiveToken(cfg.Token, ""); !errors.Is(err, ErrPassphraseRequired) {
+		t.Fatalf("expected ErrPassphraseRequired, got %v", err)
+	}
+
+	wrongKey, _ := enc.Key("wrong")
+	before := cfg.Token
+	if _, err := cfg.EncryptTokens(wrongKey); !errors.Is(err, ErrDecrypt) || cfg.Token != before {
+		t.Fatalf("expected re-encrypting with another key to fail untouched, got %v", err)
+	}
+
+	changed, err = cfg.DecryptTokens(key)
+	if err != nil || changed != 2 || cfg.Token != "top-secret" || cfg.Profiles["dev"].Token != "dev-secret" {
+		t.Fatalf("decrypt: changed=%d err=%v cfg=%+v", changed, err, cfg)
+	}
+}
+
+func TestEncryptionKeyFile(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "igw.key")
+	if err := os.WriteFile(path, []byte("0123456789abcdef0123456789abcdef"), 0o600); err != nil {
+		t.Fatalf("write key: %v", err)
+	}
+	enc, err := NewEncryption(path)
+	if err != nil {
+		t.Fatalf("new encryption: %v", err)
+	}
+	if enc.KDF != KDFKeyFile || enc.KeyFile != path {
+		t.Fatalf("unexpected settings %+v", enc)
+	}
+	key, err := enc.Key("")
+	if err != nil {
+		t.Fatalf("key: %v", err)
+	}
+	sealed, err := EncryptToken(key, "abc")
+	if err != nil {
+		t.Fatalf("encrypt: %v", err)
+	}
+	again, _ := enc.Key("ignored")
+	if plain, err := DecryptToken(again, sealed); err != nil || plain != "abc" {
+		t.Fatalf("decrypt: %q %v", plain, err)
+	}
+
+	if err := os.WriteFile(path, []byte("short"), 0o600); err != nil {
+		t.Fatalf("write key: %v", err)
+	}
+	if _, err := enc.Key(""); err == nil {
+		t.Fatalf("expected a short key file to be rejected")
+	}
+}
@@ -0,0 +1,159 @@
+package config
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// HistoryMaxEntries caps the history file; older entries are dropped.
+const HistoryMaxEntries = 1000
+
+// History controls request history recording.
+type History struct {
+	Enabled bool `json:"enabled,omitempty"`
+}
+
+// HistoryEntry is one executed request. It never holds the token or the
+// request body.
+type HistoryEntry struct {
+	Time       time.Time `json:"time"`
+	Method     string    `json:"method"`
+	Path       string    `json:"path"`
+	Query      []string  `json:"query,omitempty"`
+	Status     int       `json:"status,omitempty"`
+	DurationMs int64     `json:"durationMs"`
+	Profile    string    `json:"profile,omitempty"`
+	// GatewayURL is the gateway the call was sent to, so a replay reaches
+	// the same gateway even when it came from --gateway-url.
+	GatewayURL string `json:"gatewayURL,omitempty"`
+	// BodyBytes is the size of the request body that was sent; Form is set
+	// when a multipart form was sent instead.
+	BodyBytes int  `json:"bodyBytes,omitempty"`
+	Form      bool `json:"form,omitempty"`
+}
+
+// HistoryEnabled reports whether history.enabled is set.
+func (f File) HistoryEnabled() bool {
+	return f.History != nil && f.History.Enabled
+}
+
+func HistoryPath() (string, error) {
+	dir, err := Dir()
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(dir, "history.ndjson"), nil
+}
+
+// ReadHistory returns the entries in path, oldest first. A missing file
+// has no entries.
+func ReadHistory(path string) ([]HistoryEntry, error) {
+	b, err := os.ReadFile(path) //nolint:gosec // history path is fixed
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("read history: %w", err)
+	}
+
+	var entries []HistoryEntry
+	scanner := bufio.NewScanner(bytes.NewReader(b))
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for line := 1; scanner.Scan(); line++ {
+		if len(bytes.TrimSpace(scanner.Bytes())) == 0 {
+			continue
+		}
+		var entry HistoryEntry
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			return nil, fmt.Errorf("parse history line %d: %w", line, err)
+		}
+		entries = append(entries, entry)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("read history: %w", err)
+	}
+	return entries, nil
+}
+
+// AppendHistory adds entry to path as one appended line, so concurrent igw
+// processes do not lose each other's entries. Once the file holds more than
+// HistoryMaxEntries it is rewritten with only the newest entries.
+func AppendHistory(path string, entry HistoryEntry) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return fmt.Errorf("create history dir: %w", err)
+	}
+	line, err := json.Marshal(entry)
+	if err != nil {
+		return fmt.Errorf("encode history: %w", err)
+	}
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0o600) //nolint:gosec // history path is fixed
+	if err != nil {
+		return fmt.Errorf("open history: %w", err)
+	}
+	_, writeErr := file.Write(append(line, '\n'))
+	if closeErr := file.Close(); writeErr == nil {
+		writeErr = closeErr
+	}
+	if writeErr != nil {
+		return fmt.Errorf("append history: %w", writeErr)
+	}
+
+	entries, err := ReadHistory(path)
+	if err != nil {
+		return err
+	}
+	if len(entries) <= HistoryMaxEntries {
+		return nil
+	}
+	return writeHistory(path, entries[len(entries)-HistoryMaxEntries:])
+}
+
+// ClearHistory empties path.
+func ClearHistory(path string) error {
+	if _, err := os.Stat(path); errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	return writeHistory(path, nil)
+}
+
+func writeHistory(path string, entries []HistoryEntry) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return fmt.Errorf("create history dir: %w", err)
+	}
+
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	for _, entry := range entries {
+		if err := encoder.Encode(entry); err != nil {
+			return fmt.Errorf("encode history: %w", err)
+		}
+	}
+
+	// A unique temp file keeps concurrent rewrites from renaming each
+	// other's partial writes into place.
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("create history temp: %w", err)
+	}
+	_, writeErr := tmp.Write(buf.Bytes())
+	closeErr := tmp.Close()
+	if writeErr == nil {
+		writeErr = closeErr
+	}
+	if writeErr != nil {
+		_ = os.Remove(tmp.Name())
+		return fmt.Errorf("write history temp: %w", writeErr)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		_ = os.Remove(tmp.Name())
+		return fmt.Errorf("commit history: %w", err)
+	}
+	return nil
+}
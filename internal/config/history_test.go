@@ -0,0 +1,79 @@
+package config
+
+import (
+	"path/filepath"
+	"sync"
+	"testing"
+)
+
+func TestAppendHistoryKeepsNewestEntries(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "igw", "history.ndjson")
+	entries := make([]HistoryEntry, HistoryMaxEntries)
+	for i := range entries {
+		entries[i] = HistoryEntry{Method: "GET", Path: "/old", Status: i}
+	}
+	if err := writeHistory(path, entries); err != nil {
+		t.Fatalf("seed history: %v", err)
+	}
+
+	if err := AppendHistory(path, HistoryEntry{Method: "POST", Path: "/new", Status: 201}); err != nil {
+		t.Fatalf("append: %v", err)
+	}
+	got, err := ReadHistory(path)
+	if err != nil {
+		t.Fatalf("read: %v", err)
+	}
+	if len(got) != HistoryMaxEntries {
+		t.Fatalf("expected %d entries, got %d", HistoryMaxEntries, len(got))
+	}
+	if got[0].Status != 1 || got[len(got)-1].Path != "/new" {
+		t.Fatalf("expected the oldest entry dropped, got first=%+v last=%+v", got[0], got[len(got)-1])
+	}
+	if leftovers, _ := filepath.Glob(path + ".*.tmp"); len(leftovers) != 0 {
+		t.Fatalf("temp file left behind: %v", leftovers)
+	}
+}
+
+func TestAppendHistoryKeepsConcurrentEntries(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "history.ndjson")
+	const writers = 20
+	var wg sync.WaitGroup
+	for i := 0; i < writers; i++ {
+		wg.Add(1)
+		go func(status int) {
+			defer wg.Done()
+			if err := AppendHistory(path, HistoryEntry{Method: "GET", Path: "/a", Status: status}); err != nil {
+				t.Errorf("append %d: %v", status, err)
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	got, err := ReadHistory(path)
+	if err != nil || len(got) != writers {
+		t.Fatalf("expected %d entries, got %d (%v)", writers, len(got), err)
+	}
+}
+
+func TestClearHistory(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "history.ndjson")
+	if err := ClearHistory(path); err != nil {
+		t.Fatalf("clear missing file: %v", err)
+	}
+	if err := AppendHistory(path, HistoryEntry{Method: "GET", Path: "/a"}); err != nil {
+		t.Fatalf("append: %v", err)
+	}
+	if err := ClearHistory(path); err != nil {
+		t.Fatalf("clear: %v", err)
+	}
+	got, err := ReadHistory(path)
+	if err != nil || len(got) != 0 {
+		t.Fatalf("expected empty history, got %v (%v)", got, err)
+	}
+}
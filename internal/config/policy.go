@@ -0,0 +1,144 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Policy modes. In deny mode (the default) any request matching a deny rule
+// is blocked; in allow mode requests must also match an allow rule.
+const (
+	PolicyModeDeny  = "deny"
+	PolicyModeAllow = "allow"
+)
+
+// Policy is a local guardrail that refuses matching requests before they are
+// sent. Deny rules always win over allow rules.
+type Policy struct {
+	Mode  string       `json:"mode,omitempty"`
+	Deny  []PolicyRule `json:"deny,omitempty"`
+	Allow []PolicyRule `json:"allow,omitempty"`
+}
+
+// PolicyRule matches requests by method and path. An empty method matches
+// any method; an empty path matches any path. Paths match by segment prefix,
+// and a "*" segment matches any single segment.
+type PolicyRule struct {
+	Method string `json:"method,omitempty"`
+	Path   string `json:"path,omitempty"`
+}
+
+// PolicyViolation reports a request refused by Policy.Check.
+type PolicyViolation struct {
+	Method string
+	Path   string
+	// Rule is the deny rule that matched; nil when an allowlist missed.
+	Rule *PolicyRule
+}
+
+func (e *PolicyViolation) Error() string {
+	if e.Rule != nil {
+		return fmt.Sprintf("blocked by policy: %s %s matches deny rule %s", e.Method, e.Path, e.Rule)
+	}
+	return fmt.Sprintf("blocked by policy: %s %s is not on the allowlist", e.Method, e.Path)
+}
+
+// ParsePolicyMode normalizes a --policy-mode value; empty means deny.
+func ParsePolicyMode(value string) (string, error) {
+	switch strings.ToLower(strings.TrimSpace(value)) {
+	case "", PolicyModeDeny:
+		return PolicyModeDeny, nil
+	case PolicyModeAllow:
+		return PolicyModeAllow, nil
+	default:
+		return "", fmt.Errorf("invalid policy mode %q (expected %s|%s)", value, PolicyModeDeny, PolicyModeAllow)
+	}
+}
+
+// NewPolicyRule builds a normalized rule; at least one of method or path
+// must be set.
+func NewPolicyRule(method string, path string) (PolicyRule, error) {
+	rule := PolicyRule{
+		Method: strings.ToUpper(strings.TrimSpace(method)),
+		Path:   strings.TrimSpace(path),
+	}
+	if rule.Method == "" && rule.Path == "" {
+		return PolicyRule{}, fmt.Errorf("policy rule needs a method or a path")
+	}
+	if rule.Path != "" && !strings.HasPrefix(rule.Path, "/") {
+		return PolicyRule{}, fmt.Errorf("policy path %q must start with /", rule.Path)
+	}
+	return rule, nil
+}
+
+func (r PolicyRule) String() string {
+	method, path := r.Method, r.Path
+	if method == "" {
+		method = "*"
+	}
+	if path == "" {
+		path = "*"
+	}
+	return method + " " + path
+}
+
+// Matches reports whether the rule covers method and path.
+func (r PolicyRule) Matches(method string, path string) bool {
+	if r.Method != "" && !strings.EqualFold(r.Method, method) {
+		return false
+	}
+	if r.Path == "" {
+		return true
+	}
+
+	ruleSegments := pathSegments(r.Path)
+	reqSegments := pathSegments(path)
+	if len(ruleSegments) > len(reqSegments) {
+		return false
+	}
+	for i, segment := range ruleSegments {
+		if segment != "*" && segment != reqSegments[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// Check returns a *PolicyViolation when the request is blocked. A nil
+// policy allows everything.
+func (p *Policy) Check(method string, path string) error {
+	if p == nil {
+		return nil
+	}
+	method = strings.ToUpper(strings.TrimSpace(method))
+	path, _, _ = strings.Cut(strings.TrimSpace(path), "?")
+
+	for i := range p.Deny {
+		if p.Deny[i].Matches(method, path) {
+			rule := p.Deny[i]
+			return &PolicyViolation{Method: method, Path: path, Rule: &rule}
+		}
+	}
+	if p.Mode != PolicyModeAllow {
+		return nil
+	}
+	for _, rule := range p.Allow {
+		if rule.Matches(method, path) {
+			return nil
+		}
+	}
+	return &PolicyViolation{Method: method, Path: path}
+}
+
+// IsEmpty reports whether the policy has no effect.
+func (p *Policy) IsEmpty() bool {
+	return p == nil || (p.Mode != PolicyModeAllow && len(p.Deny) == 0 && len(p.Allow) == 0)
+}
+
+func pathSegments(path string) []string {
+	path = strings.Trim(path, "/")
+	if path == "" {
+		return nil
+	}
+	return strings.Split(path, "/")
+}
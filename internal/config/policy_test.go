@@ -0,0 +1,93 @@
+package config
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestPolicyCheckDenyRules(t *testing.T) {
+	t.Parallel()
+
+	policy := &Policy{Deny: []PolicyRule{
+		{Method: "DELETE", Path: "/data/api/v1/backup"},
+		{Path: "/data/api/v1/projects/*/delete"},
+	}}
+
+	cases := []struct {
+		method  string
+		path    string
+		blocked bool
+	}{
+		{"DELETE", "/data/api/v1/backup", true},
+		{"delete", "/data/api/v1/backup/latest?force=true", true},
+		{"GET", "/data/api/v1/backup", false},
+		{"DELETE", "/data/api/v1/backups", false},
+		{"POST", "/data/api/v1/projects/demo/delete", true},
+		{"POST", "/data/api/v1/projects/demo/copy", false},
+	}
+	for _, tc := range cases {
+		err := policy.Check(tc.method, tc.path)
+		if tc.blocked != (err != nil) {
+			t.Fatalf("Check(%s %s) = %v, want blocked=%v", tc.method, tc.path, err, tc.blocked)
+		}
+	}
+
+	err := policy.Check("DELETE", "/data/api/v1/backup")
+	var violation *PolicyViolation
+	if !errors.As(err, &violation) || violation.Rule == nil {
+		t.Fatalf("expected deny violation, got %#v", err)
+	}
+	if !strings.Contains(err.Error(), "blocked by policy") || !strings.Contains(err.Error(), "DELETE /data/api/v1/backup") {
+		t.Fatalf("unexpected message %q", err.Error())
+	}
+}
+
+func TestPolicyCheckAllowMode(t *testing.T) {
+	t.Parallel()
+
+	policy := &Policy{
+		Mode:  PolicyModeAllow,
+		Allow: []PolicyRule{{Method: "GET"}, {Method: "POST", Path: "/data/api/v1/scan"}},
+		Deny:  []PolicyRule{{Path: "/data/api/v1/users"}},
+	}
+
+	if err := policy.Check("GET", "/data/api/v1/gateway-info"); err != nil {
+		t.Fatalf("allowed GET blocked: %v", err)
+	}
+	if err := policy.Check("POST", "/data/api/v1/scan/projects"); err != nil {
+		t.Fatalf("allowed POST blocked: %v", err)
+	}
+	if err := policy.Check("PUT", "/data/api/v1/scan/projects"); err == nil || !strings.Contains(err.Error(), "not on the allowlist") {
+		t.Fatalf("expected allowlist miss, got %v", err)
+	}
+	if err := policy.Check("GET", "/data/api/v1/users/admin"); err == nil || !strings.Contains(err.Error(), "deny rule") {
+		t.Fatalf("deny rule should win over allow, got %v", err)
+	}
+
+	var nilPolicy *Policy
+	if err := nilPolicy.Check("DELETE", "/anything"); err != nil {
+		t.Fatalf("nil policy should allow everything: %v", err)
+	}
+}
+
+func TestNewPolicyRuleValidation(t *testing.T) {
+	t.Parallel()
+
+	if _, err := NewPolicyRule("", ""); err == nil {
+		t.Fatalf("expected error for empty rule")
+	}
+	if _, err := NewPolicyRule("GET", "data/api"); err == nil {
+		t.Fatalf("expected error for relative path")
+	}
+	rule, err := NewPolicyRule(" delete ", "/data/api/v1/backup")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if rule.String() != "DELETE /data/api/v1/backup" {
+		t.Fatalf("unexpected rule %q", rule.String())
+	}
+	if _, err := ParsePolicyMode("audit"); err == nil {
+		t.Fatalf("expected invalid mode error")
+	}
+}
@@ -0,0 +1,165 @@
+package config
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"math/bits"
+)
+
+// scryptKey derives keyLen bytes from password and salt with scrypt
+// (RFC 7914). N is the CPU/memory cost (a power of two above 1), r the
+// block size, and p the parallelization factor.
+func scryptKey(password []byte, salt []byte, n int, r int, p int, keyLen int) ([]byte, error) {
+	if n <= 1 || n&(n-1) != 0 {
+		return nil, errors.New("scrypt: N must be a power of 2 greater than 1")
+	}
+	const maxInt = int(^uint(0) >> 1)
+	if r <= 0 || p <= 0 || uint64(r)*uint64(p) >= 1<<30 || r > maxInt/128/p || r > maxInt/256 || n > maxInt/128/r {
+		return nil, errors.New("scrypt: parameters are too large")
+	}
+
+	xy := make([]uint32, 64*r)
+	v := make([]uint32, 32*n*r)
+	b := pbkdf2SHA256(password, salt, 1, p*128*r)
+	for i := 0; i < p; i++ {
+		scryptSMix(b[i*128*r:], r, n, v, xy)
+	}
+	return pbkdf2SHA256(password, b, 1, keyLen), nil
+}
+
+// pbkdf2SHA256 is PBKDF2 (RFC 8018) with HMAC-SHA256 as the PRF.
+func pbkdf2SHA256(password []byte, salt []byte, iterations int, keyLen int) []byte {
+	prf := hmac.New(sha256.New, password)
+	hashLen := prf.Size()
+	blocks := (keyLen + hashLen - 1) / hashLen
+	out := make([]byte, 0, blocks*hashLen)
+	var counter [4]byte
+	u := make([]byte, hashLen)
+	for block := 1; block <= blocks; block++ {
+		prf.Reset()
+		prf.Write(salt)
+		binary.BigEndian.PutUint32(counter[:], uint32(block))
+		prf.Write(counter[:])
+		out = prf.Sum(out)
+		t := out[len(out)-hashLen:]
+		copy(u, t)
+		for i := 2; i <= iterations; i++ {
+			prf.Reset()
+			prf.Write(u)
+			u = prf.Sum(u[:0])
+			for j := range u {
+				t[j] ^= u[j]
+			}
+		}
+	}
+	return out[:keyLen]
+}
+
+// scryptSMix is scryptROMix applied in place to one 128*r byte block.
+func scryptSMix(b []byte, r int, n int, v []uint32, xy []uint32) {
+	var tmp [16]uint32
+	words := 32 * r
+	x := xy
+	y := xy[words:]
+
+	for i := 0; i < words; i++ {
+		x[i] = binary.LittleEndian.Uint32(b[i*4:])
+	}
+	for i := 0; i < n; i += 2 {
+		copy(v[i*words:], x[:words])
+		scryptBlockMix(&tmp, x, y, r)
+		copy(v[(i+1)*words:], y[:words])
+		scryptBlockMix(&tmp, y, x, r)
+	}
+	for i := 0; i < n; i += 2 {
+		j := int(scryptIntegerify(x, r) & uint64(n-1))
+		xorWords(x, v[j*words:], words)
+		scryptBlockMix(&tmp, x, y, r)
+
+		j = int(scryptIntegerify(y, r) & uint64(n-1))
+		xorWords(y, v[j*words:], words)
+		scryptBlockMix(&tmp, y, x, r)
+	}
+	for i := 0; i < words; i++ {
+		binary.LittleEndian.PutUint32(b[i*4:], x[i])
+	}
+}
+
+// scryptBlockMix runs Salsa20/8 over the 2*r 64-byte blocks of in and
+// writes the even outputs followed by the odd ones to out.
+func scryptBlockMix(tmp *[16]uint32, in []uint32, out []uint32, r int) {
+	copy(tmp[:], in[(2*r-1)*16:])
+	for i := 0; i < 2*r; i += 2 {
+		salsa208XOR(tmp, in[i*16:], out[i*8:])
+		salsa208XOR(tmp, in[i*16+16:], out[i*8+r*16:])
+	}
+}
+
+func scryptIntegerify(b []uint32, r int) uint64 {
+	j := (2*r - 1) * 16
+	return uint64(b[j]) | uint64(b[j+1])<<32
+}
+
+func xorWords(dst []uint32, src []uint32, n int) {
+	for i := 0; i < n; i++ {
+		dst[i] ^= src[i]
+	}
+}
+
+// salsa208XOR sets tmp to Salsa20/8(tmp XOR in) and copies it to out.
+func salsa208XOR(tmp *[16]uint32, in []uint32, out []uint32) {
+	var w [16]uint32
+	for i := range w {
+		w[i] = tmp[i] ^ in[i]
+	}
+	x := w
+	rotl := bits.RotateLeft32
+	for round := 0; round < 8; round += 2 {
+		x[4] ^= rotl(x[0]+x[12], 7)
+		x[8] ^= rotl(x[4]+x[0], 9)
+		x[12] ^= rotl(x[8]+x[4], 13)
+		x[0] ^= rotl(x[12]+x[8], 18)
+
+		x[9] ^= rotl(x[5]+x[1], 7)
+		x[13] ^= rotl(x[9]+x[5], 9)
+		x[1] ^= rotl(x[13]+x[9], 13)
+		x[5] ^= rotl(x[1]+x[13], 18)
+
+		x[14] ^= rotl(x[10]+x[6], 7)
+		x[2] ^= rotl(x[14]+x[10], 9)
+		x[6] ^= rotl(x[2]+x[14], 13)
+		x[10] ^= rotl(x[6]+x[2], 18)
+
+		x[3] ^= rotl(x[15]+x[11], 7)
+		x[7] ^= rotl(x[3]+x[15], 9)
+		x[11] ^= rotl(x[7]+x[3], 13)
+		x[15] ^= rotl(x[11]+x[7], 18)
+
+		x[1] ^= rotl(x[0]+x[3], 7)
+		x[2] ^= rotl(x[1]+x[0], 9)
+		x[3] ^= rotl(x[2]+x[1], 13)
+		x[0] ^= rotl(x[3]+x[2], 18)
+
+		x[6] ^= rotl(x[5]+x[4], 7)
+		x[7] ^= rotl(x[6]+x[5], 9)
+		x[4] ^= rotl(x[7]+x[6], 13)
+		x[5] ^= rotl(x[4]+x[7], 18)
+
+		x[11] ^= rotl(x[10]+x[9], 7)
+		x[8] ^= rotl(x[11]+x[10], 9)
+		x[9] ^= rotl(x[8]+x[11], 13)
+		x[10] ^= rotl(x[9]+x[8], 18)
+
+		x[12] ^= rotl(x[15]+x[14], 7)
+		x[13] ^= rotl(x[12]+x[15], 9)
+		x[14] ^= rotl(x[13]+x[12], 13)
+		x[15] ^= rotl(x[14]+x[13], 18)
+	}
+	for i := range x {
+		x[i] += w[i]
+		out[i] = x[i]
+		tmp[i] = x[i]
+	}
+}
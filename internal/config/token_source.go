@@ -0,0 +1,120 @@
+package config
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os/exec"
+	"runtime"
+	"strings"
+	"time"
+)
+
+// TokenCommandTimeout bounds a tokenCommand run, so a hung credential
+// helper fails the command instead of hanging it.
+const TokenCommandTimeout = 30 * time.Second
+
+var execTokenCommand = func(command string) ([]byte, error) {
+	return runTokenCommand(command, TokenCommandTimeout)
+}
+
+// runTokenCommand runs command through the platform shell and returns its
+// stdout. It is killed after timeout; WaitDelay stops children that kept
+// the output pipes open from holding the run past that. Errors carry the
+// first line of the command's stderr.
+func runTokenCommand(command string, timeout time.Duration) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	var cmd *exec.Cmd
+	if runtime.GOOS == "windows" {
+		cmd = exec.CommandContext(ctx, "cmd", "/C", command) //nolint:gosec // user-configured tokenCommand
+	} else {
+		cmd = exec.CommandContext(ctx, "sh", "-c", command) //nolint:gosec // user-configured tokenCommand
+	}
+	cmd.WaitDelay = time.Second
+	out, err := cmd.Output()
+	if err == nil {
+		return out, nil
+	}
+	var detail string
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		detail = firstLine(exitErr.Stderr)
+	}
+	if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		err = fmt.Errorf("timed out after %s", timeout)
+	}
+	if detail != "" {
+		return nil, fmt.Errorf("%v: %s", err, detail)
+	}
+	return nil, err
+}
+
+func (p Profile) hasOwnToken() bool {
+	return strings.TrimSpace(p.Token) != "" || strings.TrimSpace(p.TokenEnv) != "" || strings.TrimSpace(p.TokenCommand) != ""
+}
+
+// TokenSource describes where the profile's own token comes from without
+// revealing it: "env:NAME", "command:CMD", or "" for a literal token (or
+// none). A literal token wins over tokenEnv, which wins over tokenCommand.
+func (p Profile) TokenSource() string {
+	switch {
+	case strings.TrimSpace(p.Token) != "":
+		return ""
+	case strings.TrimSpace(p.TokenEnv) != "":
+		return "env:" + strings.TrimSpace(p.TokenEnv)
+	case strings.TrimSpace(p.TokenCommand) != "":
+		return "command:" + strings.TrimSpace(p.TokenCommand)
+	default:
+		return ""
+	}
+}
+
+// ProfileTokenSource is TokenSource for the profile that supplies name's
+// token after following tokenRef links.
+func (f File) ProfileTokenSource(name string) (source string, inheritedFrom string, err error) {
+	owner, inheritedFrom, err := f.profileTokenOwner(name)
+	if err != nil || owner == TokenRefDefault {
+		return "", inheritedFrom, err
+	}
+	return f.Profiles[owner].TokenSource(), inheritedFrom, nil
+}
+
+// externalProfileToken reads name's token from its tokenEnv variable or
+// tokenCommand output. It returns "" when the supplying profile has
+// neither.
+func (f File) externalProfileToken(name string, getenv func(string) string) (string, error) {
+	owner, _, err := f.profileTokenOwner(name)
+	if err != nil || owner == TokenRefDefault {
+		return "", err
+	}
+	profile := f.Profiles[owner]
+
+	if envName := strings.TrimSpace(profile.TokenEnv); envName != "" {
+		token := strings.TrimSpace(getenv(envName))
+		if token == "" {
+			return "", fmt.Errorf("profile %q tokenEnv %s is not set", owner, envName)
+		}
+		return token, nil
+	}
+
+	command := strings.TrimSpace(profile.TokenCommand)
+	if command == "" {
+		return "", nil
+	}
+	out, err := execTokenCommand(command)
+	if err != nil {
+		return "", fmt.Errorf("profile %q tokenCommand failed: %v", owner, err)
+	}
+	token := strings.TrimSpace(string(out))
+	if token == "" {
+		return "", fmt.Errorf("profile %q tokenCommand printed no token", owner)
+	}
+	return token, nil
+}
+
+func firstLine(b []byte) string {
+	line, _, _ := strings.Cut(strings.TrimSpace(string(b)), "\n")
+	return strings.TrimSpace(line)
+}
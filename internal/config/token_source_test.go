@@ -0,0 +1,127 @@
+package config
+
+import (
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestResolveWithProfileTokenEnv(t *testing.T) {
+	t.Parallel()
+
+	fileCfg := File{
+		ActiveProfile: "dev",
+		Profiles: map[string]Profile{
+			"dev":     {GatewayURL: "http://dev:8088", TokenEnv: "IGW_DEV_TOKEN"},
+			"dev-alt": {GatewayURL: "http://dev-alt:8088", TokenRef: "dev"},
+		},
+	}
+	env := map[string]string{"IGW_DEV_TOKEN": "env-secret\n"}
+	getenv := func(key string) string { return env[key] }
+
+	resolved, err := ResolveWithProfile(fileCfg, getenv, "", "", "dev-alt")
+	if err != nil {
+		t.Fatalf("resolve: %v", err)
+	}
+	if resolved.Token != "env-secret" {
+		t.Fatalf("expected token from env, got %q", resolved.Token)
+	}
+
+	delete(env, "IGW_DEV_TOKEN")
+	_, err = ResolveWithProfile(fileCfg, getenv, "", "", "dev")
+	if err == nil || !strings.Contains(err.Error(), "tokenEnv IGW_DEV_TOKEN is not set") {
+		t.Fatalf("expected missing env error, got %v", err)
+	}
+
+	// An explicit token means the env source is never consulted.
+	resolved, err = ResolveWithProfile(fileCfg, getenv, "", "flag-token", "dev")
+	if err != nil || resolved.Token != "flag-token" {
+		t.Fatalf("expected flag token, got %q (%v)", resolved.Token, err)
+	}
+}
+
+func TestResolveWithProfileTokenCommand(t *testing.T) {
+	t.Parallel()
+	if runtime.GOOS == "windows" {
+		t.Skip("uses sh")
+	}
+
+	fileCfg := File{
+		Profiles: map[string]Profile{
+			"ok":    {TokenCommand: "printf 'cmd-secret\\n'"},
+			"fails": {TokenCommand: "echo denied >&2; exit 3"},
+			"empty": {TokenCommand: "true"},
+		},
+	}
+	getenv := func(string) string { return "" }
+
+	resolved, err := ResolveWithProfile(fileCfg, getenv, "", "", "ok")
+	if err != nil {
+		t.Fatalf("resolve: %v", err)
+	}
+	if resolved.Token != "cmd-secret" {
+		t.Fatalf("expected token from command, got %q", resolved.Token)
+	}
+
+	_, err = ResolveWithProfile(fileCfg, getenv, "", "", "fails")
+	if err == nil || !strings.Contains(err.Error(), `profile "fails" tokenCommand failed: exit status 3: denied`) {
+		t.Fatalf("expected command failure, got %v", err)
+	}
+
+	_, err = ResolveWithProfile(fileCfg, getenv, "", "", "empty")
+	if err == nil || !strings.Contains(err.Error(), "printed no token") {
+		t.Fatalf("expected empty output error, got %v", err)
+	}
+}
+
+func TestRunTokenCommandTimesOut(t *testing.T) {
+	t.Parallel()
+	if runtime.GOOS == "windows" {
+		t.Skip("uses sh")
+	}
+
+	started := time.Now()
+	_, err := runTokenCommand("echo waiting for unlock >&2; sleep 10", 100*time.Millisecond)
+	if err == nil || err.Error() != "timed out after 100ms: waiting for unlock" {
+		t.Fatalf("expected a timeout carrying stderr, got %v", err)
+	}
+	if elapsed := time.Since(started); elapsed > 5*time.Second {
+		t.Fatalf("timeout did not stop the command: %s", elapsed)
+	}
+}
+
+func TestProfileTokenSource(t *testing.T) {
+	t.Parallel()
+
+	fileCfg := File{
+		Token: "top-token",
+		Profiles: map[string]Profile{
+			"env":     {TokenEnv: "IGW_TOKEN"},
+			"cmd":     {TokenCommand: "pass show igw"},
+			"literal": {Token: "literal-token", TokenEnv: "IGNORED"},
+			"ref":     {TokenRef: "cmd"},
+			"default": {TokenRef: TokenRefDefault},
+		},
+	}
+
+	cases := map[string]struct {
+		source string
+		from   string
+	}{
+		"env":     {source: "env:IGW_TOKEN"},
+		"cmd":     {source: "command:pass show igw"},
+		"literal": {},
+		"ref":     {source: "command:pass show igw", from: "cmd"},
+		"default": {from: TokenRefDefault},
+	}
+	for name, want := range cases {
+		source, from, err := fileCfg.ProfileTokenSource(name)
+		if err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		if source != want.source || from != want.from {
+			t.Fatalf("%s: got source=%q from=%q, want %+v", name, source, from, want)
+		}
+	}
+}
@@ -5,4 +5,6 @@ const (
 	Usage   = 2
 	Auth    = 6
 	Network = 7
+	// UnexpectedStatus is a response outside the `call --expect-status` set.
+	UnexpectedStatus = 8
 )
@@ -2,25 +2,65 @@ package gateway
 
 import (
 	"bytes"
+	"compress/gzip"
 	"context"
 	"crypto/tls"
 	"fmt"
 	"io"
+	"math/rand"
 	"net/http"
 	"net/http/httptrace"
 	"net/url"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/alex-mccollum/igw-cli/internal/igwerr"
 )
 
-const tokenHeader = "X-Ignition-API-Token"
+// TokenHeader carries the Ignition API token on every request.
+const TokenHeader = "X-Ignition-API-Token"
 
 type Client struct {
+	// BaseURL is one gateway URL or a comma-separated list; with a list,
+	// requests that cannot reach a gateway move on to the next one.
 	BaseURL string
 	Token   string
 	HTTP    *http.Client
+	// Strategy selects how a URL list is used (StrategyFailover when empty).
+	Strategy string
+	// Signer, when set, signs every request attempt.
+	Signer *RequestSigner
+	// Rand, when set, supplies retry jitter (for example a seeded source for
+	// reproducible timing); nil uses the process-wide random source.
+	Rand *rand.Rand
+	// Sleep, when set, replaces the wait between retry attempts.
+	Sleep func(ctx context.Context, d time.Duration) error
+	// OnRetry, when set, is told the wait before each retry attempt.
+	OnRetry func(wait time.Duration)
+	// Recorder, when set, receives every attempt Call makes, including
+	// retries and failures. A shared client may call it concurrently.
+	Recorder func(Exchange)
+	// Session makes every call behave as if CallRequest.Session were set.
+	Session bool
+	// Jar, when set, is the session jar, so several Clients can share one
+	// session; nil creates one on the first session call.
+	Jar http.CookieJar
+	// Limiter, when set, paces every attempt; Clients sharing one limiter
+	// share its rate.
+	Limiter *RateLimiter
+	// Proxy routes requests when HTTP uses ProxyFromContext as its
+	// transport Proxy function.
+	Proxy Proxy
+	// DefaultHeaders are added to every request that does not set the same
+	// header itself. Keys must be canonical.
+	DefaultHeaders http.Header
+
+	next    atomic.Uint64
+	randMu  sync.Mutex
+	jarOnce sync.Once
+	jar     http.CookieJar
 }
 
 type CallRequest struct {
@@ -36,27 +76,110 @@ type CallRequest struct {
 	Stream       io.Writer
 	MaxBodyBytes int64
 	EnableTiming bool
+	// RetryOnBody, when set, marks an otherwise successful buffered response
+	// as retryable. It consumes the same retry budget as status retries.
+	RetryOnBody func(body []byte) bool
+	// RawDump, when set, receives the exact response bytes of the final
+	// attempt as read from the connection, before any gzip decoding. It is
+	// cut at MaxBodyBytes.
+	RawDump io.Writer
+	// RetryAfterMax caps honored Retry-After delays (0 = DefaultRetryAfterMax).
+	RetryAfterMax time.Duration
+	// IgnoreRetryAfter always waits RetryBackoff between status retries.
+	IgnoreRetryAfter bool
+	// RetryJitter spreads each RetryBackoff wait by up to ±RetryJitter
+	// (0..1). Retry-After delays are not jittered.
+	RetryJitter float64
+	// RetryMaxWait, when positive, switches to capped exponential backoff
+	// with full jitter: each wait is random in [0, min(RetryMaxWait,
+	// RetryBackoff*2^n)]. Honored Retry-After delays are capped by the
+	// smaller of RetryMaxWait and RetryAfterMax.
+	RetryMaxWait time.Duration
+	// RetryOn, when set, replaces the default 429/5xx check that decides
+	// which failed statuses are retried.
+	RetryOn func(status int) bool
+	// Progress, when set, is called as successful bodies are copied to
+	// Stream with the bytes written so far and Content-Length (-1 when
+	// unknown).
+	Progress func(done int64, total int64)
+	// HedgeAfter, when positive, sends a duplicate of each attempt that has
+	// not received a response within the delay and keeps whichever answers
+	// first. Only use it for idempotent requests.
+	HedgeAfter time.Duration
+	// RawPath sends Path exactly as given (already percent-encoded) instead
+	// of resolving it against the base URL, so encoded segments such as
+	// %2F and dot segments reach the gateway unchanged.
+	RawPath bool
+	// AcceptStatus, when set, replaces the 2xx check that decides whether
+	// a response is a success.
+	AcceptStatus func(status int) bool
+	// OnAttempt, when set, is called with the 1-based attempt number as
+	// each attempt starts, so callers can count attempts on failure too.
+	OnAttempt func(attempt int)
+	// OnRateWait, when set, is told each wait on the client's Limiter, so
+	// callers can account for throttling on failure too.
+	OnRateWait func(wait time.Duration)
+	// FollowRedirects, when set, caps how many redirects are followed.
+	// A 3xx response that is not followed is returned as a success so
+	// callers can inspect it. nil keeps the HTTP client's policy.
+	FollowRedirects *int
+	// OpenBody, when set, replaces Body for bodies too large to buffer. It
+	// is called for every attempt and returns a fresh stream and its length.
+	// Streamed bodies are not passed to Recorder or signed.
+	OpenBody func() (io.ReadCloser, int64, error)
+	// Session stores Set-Cookie responses in the client's in-memory jar
+	// and sends them on later session calls through the same Client, so
+	// sticky-session proxies keep routing to one node.
+	Session bool
 }
 
 type CallResponse struct {
-	Method     string
-	URL        string
+	Method string
+	URL    string
+	// GatewayURL is the base URL that served the response; it is only set
+	// when the client is configured with more than one.
+	GatewayURL string
 	StatusCode int
 	Headers    http.Header
 	Body       []byte
 	BodyBytes  int64
 	Truncated  bool
 	Timing     *CallTiming
+	// Hedge reports the hedged race of the final attempt when HedgeAfter
+	// is set.
+	Hedge *HedgeResult
+	// Attempts is how many attempts the retry loop made, including the
+	// successful one.
+	Attempts int
+	// RetryWait is the total time spent waiting between attempts.
+	RetryWait time.Duration
+	// Statuses lists the status of every attempt that got a response, in
+	// order; transport failures are left out.
+	Statuses []int
+	// Redirects lists the redirect hops followed by the final attempt.
+	Redirects []Redirect
+	// SessionCookies is how many cookies the session jar holds after a
+	// session call; nil when the call did not use the session.
+	SessionCookies *int
 }
 
+// CallTiming is the per-phase breakdown of one request. The phase fields
+// are nil when the phase did not happen, so a phase that took under a
+// millisecond still reports 0 instead of disappearing.
 type CallTiming struct {
-	TotalMs            int64 `json:"totalMs"`
-	DNSMs              int64 `json:"dnsMs,omitempty"`
-	ConnectMs          int64 `json:"connectMs,omitempty"`
-	TLSHandshakeMs     int64 `json:"tlsHandshakeMs,omitempty"`
-	FirstByteMs        int64 `json:"firstByteMs,omitempty"`
-	RequestWriteDoneMs int64 `json:"requestWriteDoneMs,omitempty"`
-	BodyReadMs         int64 `json:"bodyReadMs,omitempty"`
+	TotalMs        int64  `json:"totalMs"`
+	DNSMs          *int64 `json:"dnsMs,omitempty"`
+	ConnectMs      *int64 `json:"connectMs,omitempty"`
+	TLSHandshakeMs *int64 `json:"tlsHandshakeMs,omitempty"`
+	FirstByteMs    int64  `json:"firstByteMs,omitempty"`
+	// TTFBMs is the server's think time: from the request being written
+	// to the first response byte.
+	TTFBMs             *int64 `json:"ttfbMs,omitempty"`
+	RequestWriteDoneMs int64  `json:"requestWriteDoneMs,omitempty"`
+	BodyReadMs         *int64 `json:"bodyReadMs,omitempty"`
+	// ConnReused reports whether the request went out on a kept-alive
+	// connection instead of a new dial.
+	ConnReused bool `json:"connReused"`
 }
 
 func JoinURL(baseURL string, apiPath string) (string, error) {
@@ -74,22 +197,22 @@ func JoinURL(baseURL string, apiPath string) (string, error) {
 }
 
 func (c *Client) Call(ctx context.Context, req CallRequest) (*CallResponse, error) {
-	fullURL, err := JoinURL(c.BaseURL, req.Path)
-	if err != nil {
-		return nil, &igwerr.UsageError{Msg: err.Error()}
-	}
-
-	parsedURL, err := url.Parse(fullURL)
-	if err != nil {
-		return nil, &igwerr.UsageError{Msg: fmt.Sprintf("parse request url: %v", err)}
+	ctx = withProxy(ctx, c.Proxy)
+	bases := c.baseURLs()
+	targets := make([]*url.URL, len(bases))
+	for i, base := range bases {
+		target, err := requestURL(base, req)
+		if err != nil {
+			return nil, err
+		}
+		targets[i] = target
 	}
 
-	values := parsedURL.Query()
-	if err := addQuery(values, req.Query); err != nil {
+	// The first limiter wait happens before the per-request timeout
+	// starts, so throttling alone never times a request out.
+	if err := c.throttle(ctx, req); err != nil {
 		return nil, err
 	}
-	parsedURL.RawQuery = values.Encode()
-
 	ctxReq := ctx
 	cancel := func() {}
 	if req.Timeout > 0 {
@@ -101,6 +224,10 @@ func (c *Client) Call(ctx context.Context, req CallRequest) (*CallResponse, erro
 	if client == nil {
 		client = &http.Client{}
 	}
+	session := req.Session || c.Session
+	if session {
+		client = c.sessionHTTP(client)
+	}
 
 	attempts := req.Retry + 1
 	if attempts < 1 {
@@ -112,39 +239,70 @@ func (c *Client) Call(ctx context.Context, req CallRequest) (*CallResponse, erro
 	}
 
 	var lastErr error
+	var waited time.Duration
+	var statuses []int
+	pause := func(d time.Duration) error {
+		waited += d
+		return c.sleep(ctxReq, d)
+	}
 
 	for attempt := 1; attempt <= attempts; attempt++ {
-		var bodyReader io.Reader
-		if len(req.Body) > 0 {
-			bodyReader = bytes.NewReader(req.Body)
-		}
-
-		httpReq, err := http.NewRequestWithContext(ctxReq, req.Method, parsedURL.String(), bodyReader)
-		if err != nil {
-			return nil, &igwerr.UsageError{Msg: fmt.Sprintf("build request: %v", err)}
+		if attempt > 1 {
+			if err := c.throttle(ctxReq, req); err != nil {
+				return nil, err
+			}
 		}
-
-		startedAt := time.Now()
-		timing := &callTimingTrace{}
-		if req.EnableTiming {
-			httpReq = httpReq.WithContext(httptrace.WithClientTrace(httpReq.Context(), timing.httpTrace(startedAt)))
+		if req.OnAttempt != nil {
+			req.OnAttempt(attempt)
 		}
+		var (
+			sent      *http.Request
+			redirects []Redirect
+			resp      *http.Response
+			err       error
+			target    int
+			startedAt time.Time
+			timing    *callTimingTrace
+			hedge     *HedgeResult
+		)
+		for _, idx := range c.targetOrder(len(targets)) {
+			send := func(ctx context.Context) hedgeAttempt {
+				httpReq, err := c.newHTTPRequest(ctx, req, targets[idx])
+				if err != nil {
+					return hedgeAttempt{err: err}
+				}
+				a := hedgeAttempt{request: httpReq, startedAt: time.Now(), timing: &callTimingTrace{}}
+				if req.EnableTiming {
+					httpReq = httpReq.WithContext(httptrace.WithClientTrace(httpReq.Context(), a.timing.httpTrace(a.startedAt)))
+				}
+				a.resp, a.err = withRedirectCapture(client, req.FollowRedirects, &a.redirects).Do(httpReq)
+				return a
+			}
 
-		httpReq.Header.Set(tokenHeader, c.Token)
-
-		if len(req.Body) > 0 && req.ContentType != "" {
-			httpReq.Header.Set("Content-Type", req.ContentType)
-		}
+			var a hedgeAttempt
+			if req.HedgeAfter > 0 {
+				var result HedgeResult
+				a, result = doHedged(ctxReq, req.HedgeAfter, send)
+				hedge = &result
+			} else {
+				a = send(ctxReq)
+			}
+			if a.timing == nil {
+				// Building the request failed; that is not retryable.
+				return nil, a.err
+			}
 
-		if err := addHeaders(httpReq.Header, req.Headers); err != nil {
-			return nil, err
+			target = idx
+			sent, redirects, resp, err, startedAt, timing = a.request, a.redirects, a.resp, a.err, a.startedAt, a.timing
+			if err == nil || ctxReq.Err() != nil || !canFailOver(req.Method, err) {
+				break
+			}
 		}
-
-		resp, err := client.Do(httpReq)
 		if err != nil {
+			c.record(req, sent, startedAt, timing, nil, nil, err)
 			lastErr = igwerr.NewTransportError(err)
 			if attempt < attempts {
-				if sleepErr := sleepWithContext(ctxReq, backoff); sleepErr != nil {
+				if sleepErr := pause(c.backoffWait(req, backoff, attempt)); sleepErr != nil {
 					return nil, sleepErr
 				}
 				continue
@@ -152,40 +310,98 @@ func (c *Client) Call(ctx context.Context, req CallRequest) (*CallResponse, erro
 			return nil, lastErr
 		}
 
-		success := resp.StatusCode >= http.StatusOK && resp.StatusCode < http.StatusMultipleChoices
-		respBody, bodyBytes, truncated, readErr := readResponseBody(resp.Body, req.MaxBodyBytes, req.Stream, success)
+		success := req.successStatus(resp.StatusCode)
+		var bodySource io.Reader = resp.Body
+		var raw *rawCapture
+		if req.RawDump != nil {
+			// Write straight through when this attempt cannot be retried.
+			var dst io.Writer
+			final := attempt == attempts ||
+				(success && (req.RetryOnBody == nil || req.Stream != nil)) ||
+				(!success && !req.retryStatus(resp.StatusCode))
+			if final {
+				dst = req.RawDump
+			}
+			raw, bodySource, err = newRawCapture(resp, dst, req.MaxBodyBytes)
+			if err != nil {
+				_ = resp.Body.Close()
+				return nil, igwerr.NewTransportError(err)
+			}
+		}
+		stream := req.Stream
+		if stream != nil && req.Progress != nil && success {
+			stream = &progressWriter{w: stream, total: resp.ContentLength, report: req.Progress}
+		}
+		respBody, bodyBytes, truncated, readErr := readResponseBody(bodySource, req.MaxBodyBytes, stream, success)
+		if readErr == nil && raw != nil {
+			readErr = raw.drain()
+		}
 		_ = resp.Body.Close()
 		if readErr != nil {
+			c.record(req, sent, startedAt, timing, resp, respBody, readErr)
 			return nil, igwerr.NewTransportError(readErr)
 		}
 		timing.bodyReadDone = time.Now()
+		c.record(req, sent, startedAt, timing, resp, respBody, nil)
+		statuses = append(statuses, resp.StatusCode)
 
 		if !success {
 			statusErr := &igwerr.StatusError{
 				StatusCode: resp.StatusCode,
 				Body:       string(respBody),
 				Hint:       statusHint(resp.StatusCode),
+				Headers:    resp.Header.Clone(),
 			}
 			lastErr = statusErr
-			if attempt < attempts && shouldRetryStatus(resp.StatusCode) {
-				retryDelay := retryDelayForResponse(resp.StatusCode, resp.Header, backoff, time.Now())
-				if sleepErr := sleepWithContext(ctxReq, retryDelay); sleepErr != nil {
+			if attempt < attempts && req.retryStatus(resp.StatusCode) {
+				retryDelay := req.retryDelay(resp.StatusCode, resp.Header, c.backoffWait(req, backoff, attempt), time.Now())
+				if sleepErr := pause(retryDelay); sleepErr != nil {
 					return nil, sleepErr
 				}
 				continue
 			}
+			if err := raw.writeTo(req.RawDump); err != nil {
+				return nil, igwerr.NewTransportError(err)
+			}
 			return nil, statusErr
 		}
 
+		if attempt < attempts && req.Stream == nil && req.RetryOnBody != nil && req.RetryOnBody(respBody) {
+			if sleepErr := pause(c.backoffWait(req, backoff, attempt)); sleepErr != nil {
+				return nil, sleepErr
+			}
+			continue
+		}
+
+		if err := raw.writeTo(req.RawDump); err != nil {
+			return nil, igwerr.NewTransportError(err)
+		}
+		gatewayURL := ""
+		if len(targets) > 1 {
+			gatewayURL = bases[target]
+		}
+		var sessionCookies *int
+		if session {
+			count := c.SessionCookies()
+			sessionCookies = &count
+		}
 		return &CallResponse{
 			Method:     req.Method,
-			URL:        parsedURL.String(),
+			URL:        targets[target].String(),
+			GatewayURL: gatewayURL,
 			StatusCode: resp.StatusCode,
 			Headers:    resp.Header.Clone(),
 			Body:       respBody,
 			BodyBytes:  bodyBytes,
 			Truncated:  truncated,
 			Timing:     timing.toEnvelope(startedAt),
+			Hedge:      hedge,
+			Attempts:   attempt,
+			RetryWait:  waited,
+			Statuses:   statuses,
+			Redirects:  redirects,
+
+			SessionCookies: sessionCookies,
 		}, nil
 	}
 
@@ -196,6 +412,130 @@ func (c *Client) Call(ctx context.Context, req CallRequest) (*CallResponse, erro
 	return nil, igwerr.NewTransportError(fmt.Errorf("request failed"))
 }
 
+func (r CallRequest) successStatus(status int) bool {
+	if r.AcceptStatus != nil {
+		return r.AcceptStatus(status)
+	}
+	if r.FollowRedirects != nil && isRedirectStatus(status) {
+		return true
+	}
+	return status >= http.StatusOK && status < http.StatusMultipleChoices
+}
+
+func requestURL(baseURL string, req CallRequest) (*url.URL, error) {
+	if req.RawPath {
+		return rawRequestURL(baseURL, req)
+	}
+	fullURL, err := JoinURL(baseURL, req.Path)
+	if err != nil {
+		return nil, &igwerr.UsageError{Msg: err.Error()}
+	}
+
+	parsedURL, err := url.Parse(fullURL)
+	if err != nil {
+		return nil, &igwerr.UsageError{Msg: fmt.Sprintf("parse request url: %v", err)}
+	}
+
+	values := parsedURL.Query()
+	if err := addQuery(values, req.Query); err != nil {
+		return nil, err
+	}
+	parsedURL.RawQuery = values.Encode()
+	return parsedURL, nil
+}
+
+// rawRequestURL keeps the base URL's scheme and host and uses req.Path as
+// the escaped path verbatim.
+func rawRequestURL(baseURL string, req CallRequest) (*url.URL, error) {
+	if !strings.HasPrefix(req.Path, "/") || strings.ContainsAny(req.Path, "?#") {
+		return nil, &igwerr.UsageError{Msg: "raw path must start with / and must not contain ? or #"}
+	}
+	unescaped, err := url.PathUnescape(req.Path)
+	if err != nil {
+		return nil, &igwerr.UsageError{Msg: fmt.Sprintf("parse raw path: %v", err)}
+	}
+	base, err := url.Parse(strings.TrimRight(baseURL, "/"))
+	if err != nil {
+		return nil, &igwerr.UsageError{Msg: fmt.Sprintf("parse base url: %v", err)}
+	}
+
+	values := url.Values{}
+	if err := addQuery(values, req.Query); err != nil {
+		return nil, err
+	}
+	return &url.URL{
+		Scheme:   base.Scheme,
+		User:     base.User,
+		Host:     base.Host,
+		Path:     unescaped,
+		RawPath:  req.Path,
+		RawQuery: values.Encode(),
+	}, nil
+}
+
+// NewRequest builds the request Call would send first, against the first
+// configured base URL, without sending it. Retry, timeout, and streaming
+// fields of req are ignored.
+func (c *Client) NewRequest(ctx context.Context, req CallRequest) (*http.Request, error) {
+	ctx = withProxy(ctx, c.Proxy)
+	target, err := requestURL(c.baseURLs()[0], req)
+	if err != nil {
+		return nil, err
+	}
+	return c.newHTTPRequest(ctx, req, target)
+}
+
+func (c *Client) newHTTPRequest(ctx context.Context, req CallRequest, target *url.URL) (*http.Request, error) {
+	var bodyReader io.Reader
+	var bodySize int64
+	if req.OpenBody != nil {
+		stream, size, err := req.OpenBody()
+		if err != nil {
+			return nil, &igwerr.UsageError{Msg: fmt.Sprintf("open request body: %v", err)}
+		}
+		bodyReader, bodySize = stream, size
+	} else if len(req.Body) > 0 {
+		bodyReader = bytes.NewReader(req.Body)
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, req.Method, target.String(), bodyReader)
+	if err != nil {
+		if closer, ok := bodyReader.(io.Closer); ok {
+			_ = closer.Close()
+		}
+		return nil, &igwerr.UsageError{Msg: fmt.Sprintf("build request: %v", err)}
+	}
+	if req.OpenBody != nil {
+		httpReq.ContentLength = bodySize
+		httpReq.GetBody = func() (io.ReadCloser, error) {
+			stream, _, err := req.OpenBody()
+			return stream, err
+		}
+	}
+
+	httpReq.Header.Set(TokenHeader, c.Token)
+
+	if (len(req.Body) > 0 || req.OpenBody != nil) && req.ContentType != "" {
+		httpReq.Header.Set("Content-Type", req.ContentType)
+	}
+
+	if err := addHeaders(httpReq.Header, req.Headers); err != nil {
+		return nil, err
+	}
+	for name, values := range c.DefaultHeaders {
+		if _, set := httpReq.Header[name]; !set {
+			httpReq.Header[name] = append([]string(nil), values...)
+		}
+	}
+	c.Signer.apply(httpReq, req.Body)
+	if req.RawDump != nil && httpReq.Header.Get("Accept-Encoding") == "" {
+		// Requesting gzip explicitly disables the transport's transparent
+		// decompression, so the wire bytes stay observable.
+		httpReq.Header.Set("Accept-Encoding", "gzip")
+	}
+	return httpReq, nil
+}
+
 type callTimingTrace struct {
 	dnsStart          time.Time
 	dnsDone           time.Time
@@ -204,6 +544,7 @@ type callTimingTrace struct {
 	tlsStart          time.Time
 	tlsDone           time.Time
 	gotConn           time.Time
+	connReused        bool
 	wroteRequest      time.Time
 	firstResponseByte time.Time
 	bodyReadDone      time.Time
@@ -229,8 +570,9 @@ func (t *callTimingTrace) httpTrace(start time.Time) *httptrace.ClientTrace {
 		TLSHandshakeDone: func(tls.ConnectionState, error) {
 			t.tlsDone = time.Now()
 		},
-		GotConn: func(httptrace.GotConnInfo) {
+		GotConn: func(info httptrace.GotConnInfo) {
 			t.gotConn = time.Now()
+			t.connReused = info.Reused
 		},
 		WroteRequest: func(httptrace.WroteRequestInfo) {
 			t.wroteRequest = time.Now()
@@ -241,30 +583,35 @@ func (t *callTimingTrace) httpTrace(start time.Time) *httptrace.ClientTrace {
 	}
 }
 
+// phaseMs is the length of a traced phase, or nil when either end was
+// never recorded.
+func phaseMs(start time.Time, done time.Time) *int64 {
+	if start.IsZero() || done.IsZero() || done.Before(start) {
+		return nil
+	}
+	ms := done.Sub(start).Milliseconds()
+	return &ms
+}
+
 func (t *callTimingTrace) toEnvelope(start time.Time) *CallTiming {
 	if start.IsZero() {
 		return nil
 	}
 
-	out := &CallTiming{}
+	out := &CallTiming{ConnReused: t.connReused}
 	now := time.Now()
 	out.TotalMs = now.Sub(start).Milliseconds()
 
-	if !t.dnsStart.IsZero() && !t.dnsDone.IsZero() && t.dnsDone.After(t.dnsStart) {
-		out.DNSMs = t.dnsDone.Sub(t.dnsStart).Milliseconds()
-	}
-	if !t.connectStart.IsZero() && !t.connectDone.IsZero() && t.connectDone.After(t.connectStart) {
-		out.ConnectMs = t.connectDone.Sub(t.connectStart).Milliseconds()
-	}
-	if !t.tlsStart.IsZero() && !t.tlsDone.IsZero() && t.tlsDone.After(t.tlsStart) {
-		out.TLSHandshakeMs = t.tlsDone.Sub(t.tlsStart).Milliseconds()
-	}
+	out.DNSMs = phaseMs(t.dnsStart, t.dnsDone)
+	out.ConnectMs = phaseMs(t.connectStart, t.connectDone)
+	out.TLSHandshakeMs = phaseMs(t.tlsStart, t.tlsDone)
 	if !t.firstResponseByte.IsZero() {
 		out.FirstByteMs = t.firstResponseByte.Sub(start).Milliseconds()
 	}
 	if !t.wroteRequest.IsZero() {
 		out.RequestWriteDoneMs = t.wroteRequest.Sub(start).Milliseconds()
 	}
+	out.TTFBMs = phaseMs(t.wroteRequest, t.firstResponseByte)
 	if !t.bodyReadDone.IsZero() {
 		readStart := t.firstResponseByte
 		if readStart.IsZero() {
@@ -273,14 +620,88 @@ func (t *callTimingTrace) toEnvelope(start time.Time) *CallTiming {
 		if readStart.IsZero() {
 			readStart = start
 		}
-		if t.bodyReadDone.After(readStart) {
-			out.BodyReadMs = t.bodyReadDone.Sub(readStart).Milliseconds()
-		}
+		out.BodyReadMs = phaseMs(readStart, t.bodyReadDone)
 	}
 
 	return out
 }
 
+// rawCapture copies the undecoded response bytes of one attempt, up to limit
+// bytes, to CallRequest.RawDump. Attempts that may still be retried are held
+// in buf so only the final attempt is written.
+type rawCapture struct {
+	dst     io.Writer
+	buf     bytes.Buffer
+	limit   int64
+	written int64
+	tee     io.Reader
+}
+
+// newRawCapture wraps resp.Body. A non-nil dst receives the bytes as they are
+// read; otherwise they are buffered until writeTo.
+func newRawCapture(resp *http.Response, dst io.Writer, limit int64) (*rawCapture, io.Reader, error) {
+	raw := &rawCapture{dst: dst, limit: limit}
+	raw.tee = io.TeeReader(resp.Body, raw)
+	if !strings.EqualFold(strings.TrimSpace(resp.Header.Get("Content-Encoding")), "gzip") {
+		return raw, raw.tee, nil
+	}
+
+	decoded, err := gzip.NewReader(raw.tee)
+	if err != nil {
+		return nil, nil, fmt.Errorf("decode gzip response: %w", err)
+	}
+	// Mirror the transport's transparent decompression: the returned body is
+	// decoded, so the encoding headers no longer describe it.
+	resp.Header.Del("Content-Encoding")
+	resp.Header.Del("Content-Length")
+	return raw, decoded, nil
+}
+
+// Write keeps at most limit bytes and silently drops the rest.
+func (r *rawCapture) Write(p []byte) (int, error) {
+	keep := p
+	if r.limit > 0 {
+		if room := r.limit - r.written; room < int64(len(keep)) {
+			keep = keep[:max(room, 0)]
+		}
+	}
+	if len(keep) > 0 {
+		var err error
+		if r.dst != nil {
+			_, err = r.dst.Write(keep)
+		} else {
+			_, err = r.buf.Write(keep)
+		}
+		if err != nil {
+			return 0, err
+		}
+		r.written += int64(len(keep))
+	}
+	return len(p), nil
+}
+
+// drain consumes bytes left unread by decoded body limits so the dump holds
+// the wire payload, stopping at limit.
+func (r *rawCapture) drain() error {
+	if r.limit <= 0 {
+		_, err := io.Copy(io.Discard, r.tee)
+		return err
+	}
+	if r.written >= r.limit {
+		return nil
+	}
+	_, err := io.Copy(io.Discard, io.LimitReader(r.tee, r.limit-r.written))
+	return err
+}
+
+func (r *rawCapture) writeTo(w io.Writer) error {
+	if r == nil || w == nil || r.dst != nil {
+		return nil
+	}
+	_, err := w.Write(r.buf.Bytes())
+	return err
+}
+
 func readResponseBody(body io.Reader, maxBytes int64, stream io.Writer, allowStream bool) ([]byte, int64, bool, error) {
 	// Always keep non-2xx payloads in memory so callers can surface useful status errors.
 	if stream == nil || !allowStream {
@@ -343,6 +764,22 @@ func addQuery(values url.Values, pairs []string) error {
 	return nil
 }
 
+// RequestHeaders returns the headers req is sent with apart from the token
+// and signature: its own --header values, then DefaultHeaders for any name
+// those leave unset.
+func (c *Client) RequestHeaders(req CallRequest) (http.Header, error) {
+	headers := http.Header{}
+	if err := addHeaders(headers, req.Headers); err != nil {
+		return nil, err
+	}
+	for name, values := range c.DefaultHeaders {
+		if _, set := headers[name]; !set {
+			headers[name] = append([]string(nil), values...)
+		}
+	}
+	return headers, nil
+}
+
 func addHeaders(headers http.Header, pairs []string) error {
 	for _, pair := range pairs {
 		key, value, ok := strings.Cut(pair, ":")
@@ -351,11 +788,26 @@ func addHeaders(headers http.Header, pairs []string) error {
 		}
 
 		key = http.CanonicalHeaderKey(strings.TrimSpace(key))
-		if strings.EqualFold(key, tokenHeader) {
-			return &igwerr.UsageError{Msg: fmt.Sprintf("header %q is managed by the CLI and cannot be overridden", tokenHeader)}
+		if strings.EqualFold(key, TokenHeader) {
+			return &igwerr.UsageError{Msg: fmt.Sprintf("header %q is managed by the CLI and cannot be overridden", TokenHeader)}
 		}
 		headers.Add(key, strings.TrimSpace(value))
 	}
 
 	return nil
 }
+
+// progressWriter reports cumulative bytes written through it.
+type progressWriter struct {
+	w      io.Writer
+	done   int64
+	total  int64
+	report func(done int64, total int64)
+}
+
+func (p *progressWriter) Write(b []byte) (int, error) {
+	n, err := p.w.Write(b)
+	p.done += int64(n)
+	p.report(p.done, p.total)
+	return n, err
+}
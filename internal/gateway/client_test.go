@@ -1,10 +1,13 @@
 package gateway
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"io"
 	"net/http"
 	"net/http/httptest"
+	"reflect"
 	"strings"
 	"testing"
 	"time"
@@ -43,7 +46,7 @@ func TestCallBuildsRequest(t *testing.T) {
 		gotMethod = r.Method
 		gotPath = r.URL.Path
 		gotQuery = r.URL.RawQuery
-		gotTokenHeader = r.Header.Get(tokenHeader)
+		gotTokenHeader = r.Header.Get(TokenHeader)
 		gotCustom = r.Header.Get("X-Test")
 		gotContentType = r.Header.Get("Content-Type")
 
@@ -180,6 +183,169 @@ func TestCallRetriesOnServerError(t *testing.T) {
 	if resp.StatusCode != http.StatusOK {
 		t.Fatalf("unexpected status %d", resp.StatusCode)
 	}
+	if resp.Attempts != 2 {
+		t.Fatalf("expected resp.Attempts 2, got %d", resp.Attempts)
+	}
+}
+
+func TestCallReportsAttemptsOnFailure(t *testing.T) {
+	t.Parallel()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Retry-After", "0")
+		http.Error(w, "busy", http.StatusServiceUnavailable)
+	}))
+	defer srv.Close()
+
+	client := &Client{
+		BaseURL: srv.URL,
+		Token:   "secret-token",
+		HTTP:    srv.Client(),
+	}
+
+	var seen []int
+	_, err := client.Call(context.Background(), CallRequest{
+		Method:       http.MethodGet,
+		Path:         "/data/api/v1/gateway-info",
+		Timeout:      time.Second,
+		Retry:        2,
+		RetryBackoff: time.Millisecond,
+		OnAttempt:    func(attempt int) { seen = append(seen, attempt) },
+	})
+	if err == nil {
+		t.Fatalf("expected failure after retries")
+	}
+	if len(seen) != 3 || seen[0] != 1 || seen[2] != 3 {
+		t.Fatalf("unexpected attempt callbacks %v", seen)
+	}
+}
+
+func TestCallRecorderReceivesEveryAttempt(t *testing.T) {
+	t.Parallel()
+
+	var attempts int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		attempts++
+		if attempts < 2 {
+			w.Header().Set("Retry-After", "0")
+			http.Error(w, "busy", http.StatusServiceUnavailable)
+			return
+		}
+		_, _ = w.Write([]byte(`{"ok":true}`))
+	}))
+	defer srv.Close()
+
+	var exchanges []Exchange
+	client := &Client{
+		BaseURL:  srv.URL,
+		Token:    "secret-token",
+		HTTP:     srv.Client(),
+		Recorder: func(e Exchange) { exchanges = append(exchanges, e) },
+	}
+	_, err := client.Call(context.Background(), CallRequest{
+		Method:       http.MethodPost,
+		Path:         "/data/api/v1/scan/projects",
+		Body:         []byte(`{}`),
+		Timeout:      time.Second,
+		Retry:        1,
+		RetryBackoff: time.Millisecond,
+	})
+	if err != nil {
+		t.Fatalf("call failed: %v", err)
+	}
+	if len(exchanges) != 2 {
+		t.Fatalf("expected 2 recorded attempts, got %d", len(exchanges))
+	}
+	if exchanges[0].StatusCode != http.StatusServiceUnavailable || exchanges[1].StatusCode != http.StatusOK {
+		t.Fatalf("unexpected statuses %d, %d", exchanges[0].StatusCode, exchanges[1].StatusCode)
+	}
+	last := exchanges[1]
+	if last.Method != http.MethodPost || !strings.HasSuffix(last.URL, "/data/api/v1/scan/projects") {
+		t.Fatalf("unexpected request %s %s", last.Method, last.URL)
+	}
+	if last.RequestHeaders.Get(TokenHeader) != "secret-token" || string(last.RequestBody) != `{}` || string(last.ResponseBody) != `{"ok":true}` {
+		t.Fatalf("unexpected exchange contents: %+v", last)
+	}
+}
+
+func TestCallRetriesWhenBodyMatches(t *testing.T) {
+	t.Parallel()
+
+	var attempts int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		attempts++
+		w.WriteHeader(http.StatusOK)
+		if attempts < 2 {
+			_, _ = w.Write([]byte(`{"error":"temporarily unavailable"}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"ok":true}`))
+	}))
+	defer srv.Close()
+
+	client := &Client{
+		BaseURL: srv.URL,
+		Token:   "secret-token",
+		HTTP:    srv.Client(),
+	}
+
+	resp, err := client.Call(context.Background(), CallRequest{
+		Method:       http.MethodGet,
+		Path:         "/data/api/v1/gateway-info",
+		Timeout:      time.Second,
+		Retry:        2,
+		RetryBackoff: 10 * time.Millisecond,
+		RetryOnBody: func(body []byte) bool {
+			return strings.Contains(string(body), "unavailable")
+		},
+	})
+	if err != nil {
+		t.Fatalf("call with body retry: %v", err)
+	}
+	if attempts != 2 {
+		t.Fatalf("expected 2 attempts, got %d", attempts)
+	}
+	if string(resp.Body) != `{"ok":true}` {
+		t.Fatalf("unexpected body %q", resp.Body)
+	}
+}
+
+func TestCallRawDumpKeepsFinalAttemptUpToMaxBodyBytes(t *testing.T) {
+	t.Parallel()
+
+	var attempts int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		attempts++
+		if attempts < 2 {
+			http.Error(w, "temporary", http.StatusInternalServerError)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(strings.Repeat("x", 64)))
+	}))
+	defer srv.Close()
+
+	client := &Client{
+		BaseURL: srv.URL,
+		Token:   "secret-token",
+		HTTP:    srv.Client(),
+	}
+
+	var dump bytes.Buffer
+	if _, err := client.Call(context.Background(), CallRequest{
+		Method:       http.MethodGet,
+		Path:         "/data/api/v1/gateway-info",
+		Timeout:      time.Second,
+		Retry:        1,
+		RetryBackoff: 10 * time.Millisecond,
+		MaxBodyBytes: 16,
+		RawDump:      &dump,
+	}); err != nil {
+		t.Fatalf("call with raw dump: %v", err)
+	}
+	if got := dump.String(); got != strings.Repeat("x", 16) {
+		t.Fatalf("expected the final attempt cut at 16 bytes, got %q", got)
+	}
 }
 
 func TestRetryDelayForResponseUsesFallbackWhenUnavailable(t *testing.T) {
@@ -230,3 +396,247 @@ func TestRetryDelayForResponseParsesRetryAfterDate(t *testing.T) {
 		t.Fatalf("expected zero retry delay when Retry-After date is in the past, got %s", got)
 	}
 }
+
+func TestCallRetryAfterPolicy(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name string
+		req  CallRequest
+	}{
+		{name: "capped", req: CallRequest{RetryAfterMax: 10 * time.Millisecond}},
+		{name: "ignored", req: CallRequest{IgnoreRetryAfter: true}},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			calls := 0
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				calls++
+				if calls == 1 {
+					w.Header().Set("Retry-After", "86400")
+					w.WriteHeader(http.StatusTooManyRequests)
+					return
+				}
+				_, _ = w.Write([]byte(`ok`))
+			}))
+			defer srv.Close()
+
+			client := &Client{BaseURL: srv.URL, Token: "secret", HTTP: srv.Client()}
+			req := tc.req
+			req.Method = http.MethodGet
+			req.Path = "/data/api/v1/gateway-info"
+			req.Timeout = 5 * time.Second
+			req.Retry = 1
+			req.RetryBackoff = 5 * time.Millisecond
+
+			start := time.Now()
+			if _, err := client.Call(context.Background(), req); err != nil {
+				t.Fatalf("call: %v", err)
+			}
+			if elapsed := time.Since(start); elapsed > 2*time.Second {
+				t.Fatalf("Retry-After was not bounded, waited %s", elapsed)
+			}
+			if calls != 2 {
+				t.Fatalf("expected 2 attempts, got %d", calls)
+			}
+		})
+	}
+}
+
+func TestRetryDelayAppliesRetryAfterPolicy(t *testing.T) {
+	t.Parallel()
+
+	now := time.Unix(1700000000, 0).UTC()
+	backoff := 250 * time.Millisecond
+	headers := http.Header{"Retry-After": []string{"86400"}}
+
+	if got := (CallRequest{}).retryDelay(http.StatusTooManyRequests, headers, backoff, now); got != DefaultRetryAfterMax {
+		t.Fatalf("expected default cap %s, got %s", DefaultRetryAfterMax, got)
+	}
+	if got := (CallRequest{RetryAfterMax: 5 * time.Second}).retryDelay(http.StatusTooManyRequests, headers, backoff, now); got != 5*time.Second {
+		t.Fatalf("expected 5s cap, got %s", got)
+	}
+	if got := (CallRequest{IgnoreRetryAfter: true}).retryDelay(http.StatusTooManyRequests, headers, backoff, now); got != backoff {
+		t.Fatalf("expected backoff when ignoring Retry-After, got %s", got)
+	}
+	if got := (CallRequest{RetryAfterMax: 5 * time.Second, RetryMaxWait: time.Minute}).retryDelay(http.StatusTooManyRequests, headers, backoff, now); got != 5*time.Second {
+		t.Fatalf("expected the smaller --retry-after-max cap, got %s", got)
+	}
+	if got := (CallRequest{RetryMaxWait: 3 * time.Second}).retryDelay(http.StatusTooManyRequests, headers, backoff, now); got != 3*time.Second {
+		t.Fatalf("expected the smaller --retry-max-wait cap, got %s", got)
+	}
+	short := http.Header{"Retry-After": []string{"2"}}
+	if got := (CallRequest{RetryAfterMax: time.Minute}).retryDelay(http.StatusTooManyRequests, short, backoff, now); got != 2*time.Second {
+		t.Fatalf("expected uncapped 2s delay, got %s", got)
+	}
+}
+
+func TestCallRawPathKeepsEncodedSegments(t *testing.T) {
+	t.Parallel()
+
+	var gotURI string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotURI = r.RequestURI
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer srv.Close()
+
+	client := &Client{BaseURL: srv.URL + "/ignored", Token: "secret", HTTP: srv.Client()}
+	_, err := client.Call(context.Background(), CallRequest{
+		Method:  http.MethodGet,
+		Path:    "/data/api/v1/tags/default/Folder%2FTag/./value",
+		Query:   []string{"a=1"},
+		Timeout: 5 * time.Second,
+		RawPath: true,
+	})
+	if err != nil {
+		t.Fatalf("call failed: %v", err)
+	}
+	if want := "/data/api/v1/tags/default/Folder%2FTag/./value?a=1"; gotURI != want {
+		t.Fatalf("unexpected request uri: got %q want %q", gotURI, want)
+	}
+}
+
+func TestCallRetryOnAndRetryStats(t *testing.T) {
+	t.Parallel()
+
+	statuses := []int{http.StatusConflict, http.StatusServiceUnavailable, http.StatusOK}
+	calls := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		status := statuses[calls]
+		calls++
+		if status == http.StatusServiceUnavailable {
+			w.Header().Set("Retry-After", "7")
+		}
+		w.WriteHeader(status)
+	}))
+	defer srv.Close()
+
+	var waits []time.Duration
+	client := &Client{
+		BaseURL: srv.URL,
+		Token:   "secret",
+		HTTP:    srv.Client(),
+		Sleep: func(_ context.Context, d time.Duration) error {
+			waits = append(waits, d)
+			return nil
+		},
+	}
+	resp, err := client.Call(context.Background(), CallRequest{
+		Method:       http.MethodGet,
+		Path:         "/data/api/v1/gateway-info",
+		Retry:        3,
+		RetryBackoff: time.Second,
+		RetryMaxWait: 5 * time.Second,
+		RetryOn:      func(status int) bool { return status == http.StatusConflict || status == http.StatusServiceUnavailable },
+	})
+	if err != nil {
+		t.Fatalf("call failed: %v", err)
+	}
+	if len(waits) != 2 || waits[0] > time.Second || waits[1] != 5*time.Second {
+		t.Fatalf("expected a jittered first wait and a capped Retry-After wait, got %v", waits)
+	}
+	if resp.Attempts != 3 || resp.RetryWait != waits[0]+waits[1] || !reflect.DeepEqual(resp.Statuses, statuses) {
+		t.Fatalf("unexpected retry stats: attempts=%d wait=%v statuses=%v", resp.Attempts, resp.RetryWait, resp.Statuses)
+	}
+}
+
+func TestCallTimingKeepsSubMillisecondPhases(t *testing.T) {
+	t.Parallel()
+
+	start := time.Now()
+	trace := callTimingTrace{
+		dnsStart:          start,
+		dnsDone:           start.Add(200 * time.Microsecond),
+		connectStart:      start.Add(200 * time.Microsecond),
+		connectDone:       start.Add(500 * time.Microsecond),
+		gotConn:           start.Add(500 * time.Microsecond),
+		wroteRequest:      start.Add(600 * time.Microsecond),
+		firstResponseByte: start.Add(900 * time.Microsecond),
+		bodyReadDone:      start.Add(950 * time.Microsecond),
+	}
+	timing := trace.toEnvelope(start)
+	for name, phase := range map[string]*int64{
+		"dnsMs":      timing.DNSMs,
+		"connectMs":  timing.ConnectMs,
+		"ttfbMs":     timing.TTFBMs,
+		"bodyReadMs": timing.BodyReadMs,
+	} {
+		if phase == nil || *phase != 0 {
+			t.Fatalf("expected %s to be reported as 0, got %+v", name, timing)
+		}
+	}
+	if timing.TLSHandshakeMs != nil {
+		t.Fatalf("expected no TLS phase without a handshake, got %+v", timing)
+	}
+	encoded, err := json.Marshal(timing)
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+	if !strings.Contains(string(encoded), `"ttfbMs":0`) || strings.Contains(string(encoded), "tlsHandshakeMs") {
+		t.Fatalf("expected ttfbMs=0 and no tlsHandshakeMs, got %s", encoded)
+	}
+}
+
+func TestCallTimingBreakdown(t *testing.T) {
+	t.Parallel()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		time.Sleep(30 * time.Millisecond)
+		_, _ = w.Write([]byte(`{"ok":true}`))
+	}))
+	defer srv.Close()
+
+	client := &Client{BaseURL: srv.URL, Token: "secret", HTTP: &http.Client{Transport: &http.Transport{}}}
+	resp, err := client.Call(context.Background(), CallRequest{Method: http.MethodGet, Path: "/", EnableTiming: true})
+	if err != nil {
+		t.Fatalf("call: %v", err)
+	}
+	timing := resp.Timing
+	if timing == nil || timing.TTFBMs == nil || *timing.TTFBMs < 25 || timing.FirstByteMs < *timing.TTFBMs || timing.TotalMs < *timing.TTFBMs {
+		t.Fatalf("expected server think time in ttfbMs, got %+v", timing)
+	}
+	if timing.TLSHandshakeMs != nil || timing.ConnReused {
+		t.Fatalf("unexpected TLS or reuse on a fresh plain http connection: %+v", timing)
+	}
+	encoded, err := json.Marshal(timing)
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+	if strings.Contains(string(encoded), "tlsHandshakeMs") {
+		t.Fatalf("expected tlsHandshakeMs to be omitted on plain http: %s", encoded)
+	}
+}
+
+func TestCallAddsDefaultHeadersUnlessRequestSetsThem(t *testing.T) {
+	t.Parallel()
+
+	var got http.Header
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = r.Header.Clone()
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer srv.Close()
+
+	client := &Client{
+		BaseURL:        srv.URL,
+		Token:          "secret-token",
+		HTTP:           srv.Client(),
+		DefaultHeaders: http.Header{"X-Org-Id": {"acme"}, "X-Env": {"prod"}},
+	}
+	if _, err := client.Call(context.Background(), CallRequest{
+		Method:  http.MethodGet,
+		Path:    "/data/api/v1/gateway-info",
+		Headers: []string{"x-env: dev"},
+		Timeout: time.Second,
+	}); err != nil {
+		t.Fatalf("call: %v", err)
+	}
+	if got.Get("X-Org-Id") != "acme" || !reflect.DeepEqual(got.Values("X-Env"), []string{"dev"}) || got.Get(TokenHeader) != "secret-token" {
+		t.Fatalf("unexpected request headers: %v", got)
+	}
+}
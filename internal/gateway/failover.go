@@ -0,0 +1,79 @@
+package gateway
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+)
+
+// Gateway selection strategies for clients configured with several base URLs.
+const (
+	// StrategyFailover always starts with the first URL and moves down the
+	// list only when a gateway cannot be reached. Non-idempotent requests
+	// move on only when the connection was never made.
+	StrategyFailover = "failover"
+	// StrategyRoundRobin starts each attempt at the next URL in turn and
+	// still falls through the rest of the list on transport errors.
+	StrategyRoundRobin = "round-robin"
+)
+
+// ParseStrategy validates a --gateway-strategy value; empty means failover.
+func ParseStrategy(value string) (string, error) {
+	switch strings.ToLower(strings.TrimSpace(value)) {
+	case "", StrategyFailover:
+		return StrategyFailover, nil
+	case StrategyRoundRobin:
+		return StrategyRoundRobin, nil
+	default:
+		return "", fmt.Errorf("invalid gateway strategy %q (expected %s|%s)", value, StrategyFailover, StrategyRoundRobin)
+	}
+}
+
+// SplitBaseURLs splits a comma-separated gateway URL list, dropping empty
+// entries.
+func SplitBaseURLs(value string) []string {
+	parts := strings.Split(value, ",")
+	out := make([]string, 0, len(parts))
+	for _, part := range parts {
+		if part = strings.TrimSpace(part); part != "" {
+			out = append(out, part)
+		}
+	}
+	return out
+}
+
+func (c *Client) baseURLs() []string {
+	urls := SplitBaseURLs(c.BaseURL)
+	if len(urls) == 0 {
+		return []string{c.BaseURL}
+	}
+	return urls
+}
+
+// targetOrder returns the order in which n base URLs are tried for one
+// attempt.
+func (c *Client) targetOrder(n int) []int {
+	start := 0
+	if n > 1 && c.Strategy == StrategyRoundRobin {
+		start = int((c.next.Add(1) - 1) % uint64(n))
+	}
+	order := make([]int, n)
+	for i := range order {
+		order[i] = (start + i) % n
+	}
+	return order
+}
+
+// canFailOver reports whether a transport error may be retried on the next
+// gateway. A non-idempotent request that reached a gateway could already have
+// taken effect there, so it only moves on after a dial failure.
+func canFailOver(method string, err error) bool {
+	switch strings.ToUpper(strings.TrimSpace(method)) {
+	case "", http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	}
+	var opErr *net.OpError
+	return errors.As(err, &opErr) && opErr.Op == "dial"
+}
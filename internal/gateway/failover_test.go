@@ -0,0 +1,179 @@
+package gateway
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/alex-mccollum/igw-cli/internal/igwerr"
+)
+
+func closedServerURL(t *testing.T) string {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {}))
+	url := srv.URL
+	srv.Close()
+	return url
+}
+
+func TestCallFailsOverToNextGateway(t *testing.T) {
+	t.Parallel()
+
+	up := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"ok":true}`))
+	}))
+	defer up.Close()
+
+	down := closedServerURL(t)
+	client := &Client{BaseURL: down + ", " + up.URL, Token: "secret", HTTP: up.Client()}
+
+	resp, err := client.Call(context.Background(), CallRequest{Method: http.MethodGet, Path: "/data/api/v1/gateway-info"})
+	if err != nil {
+		t.Fatalf("expected failover to succeed, got %v", err)
+	}
+	if resp.GatewayURL != up.URL {
+		t.Fatalf("expected response from %q, got %q", up.URL, resp.GatewayURL)
+	}
+	if resp.URL != up.URL+"/data/api/v1/gateway-info" {
+		t.Fatalf("unexpected response url %q", resp.URL)
+	}
+}
+
+func TestCallDoesNotFailOverOnAuthError(t *testing.T) {
+	t.Parallel()
+
+	var secondHits atomic.Int32
+	first := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer first.Close()
+	second := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		secondHits.Add(1)
+	}))
+	defer second.Close()
+
+	client := &Client{BaseURL: first.URL + "," + second.URL, Token: "bad"}
+	_, err := client.Call(context.Background(), CallRequest{Method: http.MethodGet, Path: "/data/api/v1/gateway-info"})
+
+	var statusErr *igwerr.StatusError
+	if !errors.As(err, &statusErr) || statusErr.StatusCode != http.StatusUnauthorized {
+		t.Fatalf("expected 401 status error, got %v", err)
+	}
+	if secondHits.Load() != 0 {
+		t.Fatalf("auth failure must not fail over, second gateway got %d requests", secondHits.Load())
+	}
+}
+
+func TestCallDoesNotReplayPostOnAnotherGateway(t *testing.T) {
+	t.Parallel()
+
+	var firstHits, secondHits atomic.Int32
+	first := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		firstHits.Add(1)
+		// Drop the connection after the request arrived so the client sees a
+		// transport error for a request the gateway may have acted on.
+		conn, _, err := w.(http.Hijacker).Hijack()
+		if err == nil {
+			_ = conn.Close()
+		}
+	}))
+	defer first.Close()
+	second := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		secondHits.Add(1)
+	}))
+	defer second.Close()
+
+	client := &Client{BaseURL: first.URL + "," + second.URL, Token: "secret"}
+	_, err := client.Call(context.Background(), CallRequest{Method: http.MethodPost, Path: "/data/api/v1/scan/projects"})
+
+	var transportErr *igwerr.TransportError
+	if !errors.As(err, &transportErr) {
+		t.Fatalf("expected transport error, got %v", err)
+	}
+	if firstHits.Load() != 1 || secondHits.Load() != 0 {
+		t.Fatalf("POST must not be replayed on the next gateway, hits first=%d second=%d", firstHits.Load(), secondHits.Load())
+	}
+}
+
+func TestCallFailsOverPostWhenGatewayUnreachable(t *testing.T) {
+	t.Parallel()
+
+	up := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"ok":true}`))
+	}))
+	defer up.Close()
+
+	client := &Client{BaseURL: closedServerURL(t) + "," + up.URL, Token: "secret", HTTP: up.Client()}
+	resp, err := client.Call(context.Background(), CallRequest{Method: http.MethodPost, Path: "/data/api/v1/scan/projects"})
+	if err != nil {
+		t.Fatalf("expected dial failure to fail over, got %v", err)
+	}
+	if resp.GatewayURL != up.URL {
+		t.Fatalf("expected response from %q, got %q", up.URL, resp.GatewayURL)
+	}
+}
+
+func TestCallRoundRobinRotatesGateways(t *testing.T) {
+	t.Parallel()
+
+	var hitsA, hitsB atomic.Int32
+	a := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hitsA.Add(1)
+	}))
+	defer a.Close()
+	b := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hitsB.Add(1)
+	}))
+	defer b.Close()
+
+	client := &Client{BaseURL: a.URL + "," + b.URL, Strategy: StrategyRoundRobin}
+	for i := 0; i < 4; i++ {
+		resp, err := client.Call(context.Background(), CallRequest{Method: http.MethodGet, Path: "/"})
+		if err != nil {
+			t.Fatalf("call %d: %v", i, err)
+		}
+		want := a.URL
+		if i%2 == 1 {
+			want = b.URL
+		}
+		if resp.GatewayURL != want {
+			t.Fatalf("call %d served by %q, want %q", i, resp.GatewayURL, want)
+		}
+	}
+	if hitsA.Load() != 2 || hitsB.Load() != 2 {
+		t.Fatalf("expected 2/2 distribution, got %d/%d", hitsA.Load(), hitsB.Load())
+	}
+}
+
+func TestCallSingleGatewayLeavesGatewayURLEmpty(t *testing.T) {
+	t.Parallel()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer srv.Close()
+
+	client := &Client{BaseURL: srv.URL}
+	resp, err := client.Call(context.Background(), CallRequest{Method: http.MethodGet, Path: "/"})
+	if err != nil {
+		t.Fatalf("call: %v", err)
+	}
+	if resp.GatewayURL != "" {
+		t.Fatalf("expected empty GatewayURL for a single gateway, got %q", resp.GatewayURL)
+	}
+}
+
+func TestParseStrategy(t *testing.T) {
+	t.Parallel()
+
+	for input, want := range map[string]string{"": StrategyFailover, "failover": StrategyFailover, "Round-Robin": StrategyRoundRobin} {
+		got, err := ParseStrategy(input)
+		if err != nil || got != want {
+			t.Fatalf("ParseStrategy(%q) = %q, %v; want %q", input, got, err, want)
+		}
+	}
+	if _, err := ParseStrategy("random"); err == nil {
+		t.Fatalf("expected error for unknown strategy")
+	}
+}
@@ -0,0 +1,115 @@
+package gateway
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"time"
+)
+
+const (
+	// HedgePrimary identifies the original request of a hedged pair.
+	HedgePrimary = 1
+	// HedgeSecondary identifies the duplicate sent after HedgeAfter.
+	HedgeSecondary = 2
+)
+
+// HedgeResult reports how a hedged request was resolved.
+type HedgeResult struct {
+	// Triggered is true when the duplicate request was sent.
+	Triggered bool
+	// Winner is HedgePrimary or HedgeSecondary.
+	Winner int
+}
+
+// hedgeAttempt is one in-flight request of a hedged pair.
+type hedgeAttempt struct {
+	request   *http.Request
+	redirects []Redirect
+	resp      *http.Response
+	err       error
+	timing    *callTimingTrace
+	startedAt time.Time
+	index     int
+	cancel    context.CancelFunc
+}
+
+// sendFunc issues one request under ctx.
+type sendFunc func(ctx context.Context) hedgeAttempt
+
+// doHedged sends the request and, when no response has arrived after delay,
+// a concurrent duplicate. The first response wins and the other request is
+// canceled; the winner's context lives until its body is closed. An error
+// is only returned once every sent request has failed.
+func doHedged(ctx context.Context, delay time.Duration, send sendFunc) (hedgeAttempt, HedgeResult) {
+	results := make(chan hedgeAttempt, 2)
+	var cancels []context.CancelFunc
+	launch := func(index int) {
+		attemptCtx, cancel := context.WithCancel(ctx)
+		cancels = append(cancels, cancel)
+		go func() {
+			a := send(attemptCtx)
+			a.index = index
+			a.cancel = cancel
+			results <- a
+		}()
+	}
+
+	launch(HedgePrimary)
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	hedgeC := timer.C
+
+	pending := 1
+	for {
+		select {
+		case <-hedgeC:
+			hedgeC = nil
+			launch(HedgeSecondary)
+			pending++
+		case a := <-results:
+			pending--
+			result := HedgeResult{Triggered: len(cancels) > 1, Winner: a.index}
+			if a.err == nil {
+				for i, cancel := range cancels {
+					if i+1 != a.index {
+						cancel()
+					}
+				}
+				go discardHedgeLosers(results, pending)
+				a.resp.Body = &cancelOnClose{ReadCloser: a.resp.Body, cancel: a.cancel}
+				return a, result
+			}
+			a.cancel()
+			if pending == 0 {
+				// Everything sent so far failed (a primary that fails
+				// before the delay is not hedged); leave it to the retry
+				// loop.
+				return a, result
+			}
+		}
+	}
+}
+
+func discardHedgeLosers(results <-chan hedgeAttempt, pending int) {
+	for i := 0; i < pending; i++ {
+		a := <-results
+		if a.resp != nil {
+			_ = a.resp.Body.Close()
+		}
+		a.cancel()
+	}
+}
+
+// cancelOnClose releases the winning request's context once its body has
+// been read.
+type cancelOnClose struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+func (c *cancelOnClose) Close() error {
+	err := c.ReadCloser.Close()
+	c.cancel()
+	return err
+}
@@ -0,0 +1,91 @@
+package gateway
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestCallHedgeWinsWhenPrimaryIsSlow(t *testing.T) {
+	t.Parallel()
+
+	var (
+		requests       atomic.Int32
+		primaryCancels atomic.Int32
+	)
+	release := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if requests.Add(1) == 1 {
+			select {
+			case <-r.Context().Done():
+				primaryCancels.Add(1)
+				return
+			case <-release:
+			}
+			_, _ = w.Write([]byte(`{"from":"primary"}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"from":"hedge"}`))
+	}))
+	defer srv.Close()
+	defer close(release)
+
+	client := &Client{BaseURL: srv.URL, Token: "secret", HTTP: srv.Client()}
+	resp, err := client.Call(context.Background(), CallRequest{
+		Method:     http.MethodGet,
+		Path:       "/data/api/v1/gateway-info",
+		Timeout:    5 * time.Second,
+		HedgeAfter: 20 * time.Millisecond,
+	})
+	if err != nil {
+		t.Fatalf("call failed: %v", err)
+	}
+	if string(resp.Body) != `{"from":"hedge"}` {
+		t.Fatalf("expected hedge response, got %s", resp.Body)
+	}
+	if resp.Hedge == nil || !resp.Hedge.Triggered || resp.Hedge.Winner != HedgeSecondary {
+		t.Fatalf("unexpected hedge result: %+v", resp.Hedge)
+	}
+	if got := requests.Load(); got != 2 {
+		t.Fatalf("expected 2 requests, got %d", got)
+	}
+
+	deadline := time.Now().Add(2 * time.Second)
+	for primaryCancels.Load() == 0 {
+		if time.Now().After(deadline) {
+			t.Fatalf("slow primary request was not canceled")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+func TestCallHedgeNotTriggeredForFastResponse(t *testing.T) {
+	t.Parallel()
+
+	var requests atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		_, _ = w.Write([]byte(`{"ok":true}`))
+	}))
+	defer srv.Close()
+
+	client := &Client{BaseURL: srv.URL, Token: "secret", HTTP: srv.Client()}
+	resp, err := client.Call(context.Background(), CallRequest{
+		Method:     http.MethodGet,
+		Path:       "/data/api/v1/gateway-info",
+		Timeout:    5 * time.Second,
+		HedgeAfter: time.Minute,
+	})
+	if err != nil {
+		t.Fatalf("call failed: %v", err)
+	}
+	if resp.Hedge == nil || resp.Hedge.Triggered || resp.Hedge.Winner != HedgePrimary {
+		t.Fatalf("unexpected hedge result: %+v", resp.Hedge)
+	}
+	if got := requests.Load(); got != 1 {
+		t.Fatalf("expected 1 request, got %d", got)
+	}
+}
@@ -0,0 +1,61 @@
+package gateway
+
+import (
+	"context"
+	"math/rand"
+	"time"
+)
+
+// jitter spreads backoff by up to ±fraction. A fraction <= 0 returns the
+// backoff unchanged, so jitter stays opt-in.
+func (c *Client) jitter(backoff time.Duration, fraction float64) time.Duration {
+	if fraction <= 0 || backoff <= 0 {
+		return backoff
+	}
+	if fraction > 1 {
+		fraction = 1
+	}
+	r := c.randFloat64()
+	return time.Duration(float64(backoff) * (1 + fraction*(2*r-1)))
+}
+
+// backoffWait is the wait before the retry that follows attempt (1-based).
+// Without RetryMaxWait it is the fixed, optionally jittered, backoff.
+// With it, the backoff doubles per attempt up to RetryMaxWait and the wait
+// is drawn uniformly from [0, that ceiling] ("full jitter"), so many
+// clients retrying together spread out instead of retrying in lockstep.
+func (c *Client) backoffWait(req CallRequest, backoff time.Duration, attempt int) time.Duration {
+	if req.RetryMaxWait <= 0 {
+		return c.jitter(backoff, req.RetryJitter)
+	}
+	ceiling := req.RetryMaxWait
+	if shift := attempt - 1; shift < 32 {
+		if grown := backoff << shift; grown > 0 && grown < ceiling {
+			ceiling = grown
+		}
+	}
+	return time.Duration(float64(ceiling) * c.randFloat64())
+}
+
+func (c *Client) randFloat64() float64 {
+	if c.Rand == nil {
+		return rand.Float64()
+	}
+	// *rand.Rand is not safe for concurrent use; batch workers share one
+	// client.
+	c.randMu.Lock()
+	defer c.randMu.Unlock()
+	return c.Rand.Float64()
+}
+
+// sleep waits d before a retry unless ctx ends first, using the client's
+// Sleep hook when set.
+func (c *Client) sleep(ctx context.Context, d time.Duration) error {
+	if c.OnRetry != nil {
+		c.OnRetry(d)
+	}
+	if c.Sleep != nil {
+		return c.Sleep(ctx, d)
+	}
+	return sleepWithContext(ctx, d)
+}
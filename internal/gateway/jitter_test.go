@@ -0,0 +1,123 @@
+package gateway
+
+import (
+	"context"
+	"math/rand"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func retryDelays(t *testing.T, rng *rand.Rand) []time.Duration {
+	t.Helper()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer srv.Close()
+
+	var delays []time.Duration
+	client := &Client{
+		BaseURL: srv.URL,
+		Token:   "secret",
+		HTTP:    srv.Client(),
+		Rand:    rng,
+		Sleep: func(_ context.Context, d time.Duration) error {
+			delays = append(delays, d)
+			return nil
+		},
+	}
+	_, err := client.Call(context.Background(), CallRequest{
+		Method:       http.MethodGet,
+		Path:         "/data/api/v1/gateway-info",
+		Retry:        5,
+		RetryBackoff: time.Second,
+		RetryJitter:  0.5,
+	})
+	if err == nil {
+		t.Fatalf("expected final 503 error")
+	}
+	if len(delays) != 5 {
+		t.Fatalf("expected 5 retry waits, got %d", len(delays))
+	}
+	for _, d := range delays {
+		if d < 500*time.Millisecond || d > 1500*time.Millisecond {
+			t.Fatalf("delay %v outside ±50%% of backoff", d)
+		}
+	}
+	return delays
+}
+
+func TestRetryJitterSeedIsReproducible(t *testing.T) {
+	t.Parallel()
+
+	first := retryDelays(t, rand.New(rand.NewSource(42)))
+	second := retryDelays(t, rand.New(rand.NewSource(42)))
+	if !reflect.DeepEqual(first, second) {
+		t.Fatalf("seeded runs differ: %v vs %v", first, second)
+	}
+
+	unseededA := retryDelays(t, nil)
+	unseededB := retryDelays(t, nil)
+	if reflect.DeepEqual(unseededA, unseededB) {
+		t.Fatalf("unseeded runs should differ, both were %v", unseededA)
+	}
+}
+
+func TestRetryJitterZeroKeepsBackoff(t *testing.T) {
+	t.Parallel()
+
+	client := &Client{Rand: rand.New(rand.NewSource(1))}
+	if got := client.jitter(time.Second, 0); got != time.Second {
+		t.Fatalf("expected unchanged backoff, got %v", got)
+	}
+}
+
+func TestOnRetryReportsEachWait(t *testing.T) {
+	t.Parallel()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer srv.Close()
+
+	var waits []time.Duration
+	client := &Client{
+		BaseURL: srv.URL,
+		Token:   "secret",
+		HTTP:    srv.Client(),
+		Sleep:   func(context.Context, time.Duration) error { return nil },
+		OnRetry: func(d time.Duration) { waits = append(waits, d) },
+	}
+	_, _ = client.Call(context.Background(), CallRequest{
+		Method:       http.MethodGet,
+		Path:         "/data/api/v1/gateway-info",
+		Retry:        2,
+		RetryBackoff: time.Second,
+	})
+	if !reflect.DeepEqual(waits, []time.Duration{time.Second, time.Second}) {
+		t.Fatalf("unexpected retry waits %v", waits)
+	}
+}
+
+func TestBackoffWaitFullJitterIsCappedExponential(t *testing.T) {
+	t.Parallel()
+
+	client := &Client{Rand: rand.New(rand.NewSource(7))}
+	req := CallRequest{RetryMaxWait: 3 * time.Second}
+	for attempt, ceiling := range []time.Duration{time.Second, 2 * time.Second, 3 * time.Second, 3 * time.Second} {
+		for i := 0; i < 50; i++ {
+			if got := client.backoffWait(req, time.Second, attempt+1); got < 0 || got > ceiling {
+				t.Fatalf("attempt %d: wait %v outside [0, %v]", attempt+1, got, ceiling)
+			}
+		}
+	}
+	if got := client.backoffWait(req, time.Second, 200); got > 3*time.Second {
+		t.Fatalf("large attempt counts must stay capped, got %v", got)
+	}
+	if got := client.backoffWait(CallRequest{}, time.Second, 3); got != time.Second {
+		t.Fatalf("expected fixed backoff without RetryMaxWait, got %v", got)
+	}
+}
@@ -0,0 +1,334 @@
+package gateway
+
+import (
+	"bufio"
+	"context"
+	"crypto/tls"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Proxy routes a Client's requests. The zero value defers to the
+// environment (HTTPS_PROXY, HTTP_PROXY, NO_PROXY); Disabled bypasses every
+// proxy, including the environment.
+type Proxy struct {
+	URL      *url.URL
+	Disabled bool
+}
+
+// ParseProxyURL validates a proxy URL. Supported schemes are http, https,
+// socks5, and socks5h; a bare host:port is taken as http.
+func ParseProxyURL(raw string) (*url.URL, error) {
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		return nil, errors.New("proxy URL is empty")
+	}
+	if !strings.Contains(raw, "://") {
+		raw = "http://" + raw
+	}
+	u, err := url.Parse(raw)
+	if err != nil {
+		return nil, fmt.Errorf("invalid proxy URL: %w", err)
+	}
+	switch u.Scheme {
+	case "http", "https", "socks5", "socks5h":
+	default:
+		return nil, fmt.Errorf("unsupported proxy scheme %q (expected http, https, socks5, or socks5h)", u.Scheme)
+	}
+	if u.Hostname() == "" {
+		return nil, fmt.Errorf("proxy URL %q has no host", raw)
+	}
+	return u, nil
+}
+
+// String describes the proxy for display, without credentials.
+func (p Proxy) String() string {
+	switch {
+	case p.Disabled:
+		return "none"
+	case p.URL != nil:
+		return redactProxyURL(p.URL)
+	default:
+		return "environment"
+	}
+}
+
+func redactProxyURL(u *url.URL) string {
+	redacted := *u
+	if redacted.User != nil {
+		redacted.User = url.User("REDACTED")
+	}
+	return redacted.String()
+}
+
+type proxyContextKey struct{}
+
+func withProxy(ctx context.Context, proxy Proxy) context.Context {
+	return context.WithValue(ctx, proxyContextKey{}, proxy)
+}
+
+// ProxyFromContext is an http.Transport Proxy function. It uses the Proxy
+// of the Client that sent the request and falls back to
+// http.ProxyFromEnvironment, so one shared transport serves Clients with
+// different proxies.
+func ProxyFromContext(req *http.Request) (*url.URL, error) {
+	proxy, _ := req.Context().Value(proxyContextKey{}).(Proxy)
+	return proxy.forRequest(req)
+}
+
+func (p Proxy) forRequest(req *http.Request) (*url.URL, error) {
+	if p.Disabled {
+		return nil, nil
+	}
+	if p.URL != nil {
+		return p.URL, nil
+	}
+	return http.ProxyFromEnvironment(req)
+}
+
+// Resolve returns the proxy a request to target would use, or nil for a
+// direct connection.
+func (p Proxy) Resolve(target *url.URL) (*url.URL, error) {
+	return p.forRequest(&http.Request{URL: target, Header: http.Header{}})
+}
+
+// DialContext opens a TCP connection to addr through proxy: an HTTP CONNECT
+// tunnel for http and https proxies, a SOCKS5 CONNECT for socks5 and
+// socks5h. A nil proxy dials addr directly.
+func DialContext(ctx context.Context, proxy *url.URL, addr string) (net.Conn, error) {
+	var dialer net.Dialer
+	if proxy == nil {
+		return dialer.DialContext(ctx, "tcp", addr)
+	}
+
+	conn, err := dialer.DialContext(ctx, "tcp", proxyAddr(proxy))
+	if err != nil {
+		return nil, fmt.Errorf("dial proxy %s: %w", redactProxyURL(proxy), err)
+	}
+	if deadline, ok := ctx.Deadline(); ok {
+		_ = conn.SetDeadline(deadline)
+	}
+	if proxy.Scheme == "https" {
+		tlsConn := tls.Client(conn, &tls.Config{ServerName: proxy.Hostname()})
+		if err := tlsConn.HandshakeContext(ctx); err != nil {
+			_ = conn.Close()
+			return nil, fmt.Errorf("proxy %s TLS handshake: %w", redactProxyURL(proxy), err)
+		}
+		conn = tlsConn
+	}
+
+	var tunnel net.Conn
+	switch proxy.Scheme {
+	case "socks5", "socks5h":
+		tunnel, err = socks5Connect(conn, proxy, addr)
+	default:
+		tunnel, err = httpConnect(conn, proxy, addr)
+	}
+	if err != nil {
+		_ = conn.Close()
+		return nil, fmt.Errorf("proxy %s: %w", redactProxyURL(proxy), err)
+	}
+	_ = tunnel.SetDeadline(time.Time{})
+	return tunnel, nil
+}
+
+func proxyAddr(proxy *url.URL) string {
+	if proxy.Port() != "" {
+		return proxy.Host
+	}
+	port := "80"
+	switch proxy.Scheme {
+	case "https":
+		port = "443"
+	case "socks5", "socks5h":
+		port = "1080"
+	}
+	return net.JoinHostPort(proxy.Hostname(), port)
+}
+
+func httpConnect(conn net.Conn, proxy *url.URL, addr string) (net.Conn, error) {
+	req := &http.Request{
+		Method: http.MethodConnect,
+		URL:    &url.URL{Opaque: addr},
+		Host:   addr,
+		Header: http.Header{},
+	}
+	if proxy.User != nil {
+		password, _ := proxy.User.Password()
+		credentials := base64.StdEncoding.EncodeToString([]byte(proxy.User.Username() + ":" + password))
+		req.Header.Set("Proxy-Authorization", "Basic "+credentials)
+	}
+	if err := req.Write(conn); err != nil {
+		return nil, err
+	}
+	reader := bufio.NewReader(conn)
+	resp, err := http.ReadResponse(reader, req)
+	if err != nil {
+		return nil, err
+	}
+	_ = resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("CONNECT %s: %s", addr, resp.Status)
+	}
+	if reader.Buffered() > 0 {
+		return &bufferedConn{Conn: conn, reader: reader}, nil
+	}
+	return conn, nil
+}
+
+// bufferedConn keeps bytes the CONNECT response reader read past the
+// response headers.
+type bufferedConn struct {
+	net.Conn
+	reader *bufio.Reader
+}
+
+func (c *bufferedConn) Read(p []byte) (int, error) {
+	return c.reader.Read(p)
+}
+
+// SOCKS5 constants from RFC 1928 and RFC 1929.
+const (
+	socksVersion       = 0x05
+	socksAuthNone      = 0x00
+	socksAuthPassword  = 0x02
+	socksAuthNoAccept  = 0xff
+	socksCmdConnect    = 0x01
+	socksAddrIPv4      = 0x01
+	socksAddrDomain    = 0x03
+	socksAddrIPv6      = 0x04
+	socksPasswordAuthV = 0x01
+)
+
+var socksReplies = map[byte]string{
+	0x01: "general SOCKS server failure",
+	0x02: "connection not allowed by ruleset",
+	0x03: "network unreachable",
+	0x04: "host unreachable",
+	0x05: "connection refused",
+	0x06: "TTL expired",
+	0x07: "command not supported",
+	0x08: "address type not supported",
+}
+
+func socks5Connect(conn net.Conn, proxy *url.URL, addr string) (net.Conn, error) {
+	host, portText, err := net.SplitHostPort(addr)
+	if err != nil {
+		return nil, err
+	}
+	port, err := strconv.ParseUint(portText, 10, 16)
+	if err != nil {
+		return nil, fmt.Errorf("invalid port %q", portText)
+	}
+
+	methods := []byte{socksAuthNone}
+	if proxy.User != nil {
+		methods = append(methods, socksAuthPassword)
+	}
+	if _, err := conn.Write(append([]byte{socksVersion, byte(len(methods))}, methods...)); err != nil {
+		return nil, err
+	}
+	var choice [2]byte
+	if _, err := io.ReadFull(conn, choice[:]); err != nil {
+		return nil, err
+	}
+	if choice[0] != socksVersion {
+		return nil, fmt.Errorf("unexpected SOCKS version %d", choice[0])
+	}
+	switch choice[1] {
+	case socksAuthNone:
+	case socksAuthPassword:
+		if proxy.User == nil {
+			return nil, errors.New("SOCKS server requires a username and password")
+		}
+		if err := socks5Authenticate(conn, proxy.User); err != nil {
+			return nil, err
+		}
+	case socksAuthNoAccept:
+		return nil, errors.New("SOCKS server accepted no authentication method")
+	default:
+		return nil, fmt.Errorf("SOCKS server chose unsupported method %d", choice[1])
+	}
+
+	request := []byte{socksVersion, socksCmdConnect, 0x00}
+	if ip := net.ParseIP(host); ip != nil {
+		if ip4 := ip.To4(); ip4 != nil {
+			request = append(append(request, socksAddrIPv4), ip4...)
+		} else {
+			request = append(append(request, socksAddrIPv6), ip.To16()...)
+		}
+	} else {
+		if len(host) > 255 {
+			return nil, fmt.Errorf("host name %q is too long for SOCKS5", host)
+		}
+		request = append(append(request, socksAddrDomain, byte(len(host))), host...)
+	}
+	request = binary.BigEndian.AppendUint16(request, uint16(port))
+	if _, err := conn.Write(request); err != nil {
+		return nil, err
+	}
+
+	var reply [4]byte
+	if _, err := io.ReadFull(conn, reply[:]); err != nil {
+		return nil, err
+	}
+	if reply[1] != 0x00 {
+		message, ok := socksReplies[reply[1]]
+		if !ok {
+			message = fmt.Sprintf("SOCKS reply %d", reply[1])
+		}
+		return nil, fmt.Errorf("CONNECT %s: %s", addr, message)
+	}
+	var boundLen int
+	switch reply[3] {
+	case socksAddrIPv4:
+		boundLen = net.IPv4len
+	case socksAddrIPv6:
+		boundLen = net.IPv6len
+	case socksAddrDomain:
+		var size [1]byte
+		if _, err := io.ReadFull(conn, size[:]); err != nil {
+			return nil, err
+		}
+		boundLen = int(size[0])
+	default:
+		return nil, fmt.Errorf("unexpected SOCKS address type %d", reply[3])
+	}
+	// Skip the bound address and port.
+	if _, err := io.ReadFull(conn, make([]byte, boundLen+2)); err != nil {
+		return nil, err
+	}
+	return conn, nil
+}
+
+func socks5Authenticate(conn net.Conn, user *url.Userinfo) error {
+	username := user.Username()
+	password, _ := user.Password()
+	if len(username) > 255 || len(password) > 255 {
+		return errors.New("SOCKS username and password must be at most 255 bytes")
+	}
+	request := []byte{socksPasswordAuthV, byte(len(username))}
+	request = append(request, username...)
+	request = append(request, byte(len(password)))
+	request = append(request, password...)
+	if _, err := conn.Write(request); err != nil {
+		return err
+	}
+	var status [2]byte
+	if _, err := io.ReadFull(conn, status[:]); err != nil {
+		return err
+	}
+	if status[1] != 0x00 {
+		return errors.New("SOCKS authentication failed")
+	}
+	return nil
+}
@@ -0,0 +1,81 @@
+package gateway
+
+import (
+	"context"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/alex-mccollum/igw-cli/internal/igwerr"
+)
+
+// RateLimiter is a token bucket that paces requests to a fixed rate.
+// Clients that share one limiter share its budget, so concurrent workers
+// together never exceed it. A nil limiter never waits.
+type RateLimiter struct {
+	perSecond float64
+
+	mu     sync.Mutex
+	tokens float64
+	last   time.Time
+	now    func() time.Time
+	sleep  func(ctx context.Context, d time.Duration) error
+}
+
+// NewRateLimiter returns a limiter allowing perSecond requests per second
+// (fractions allowed) with a burst of one, or nil when perSecond <= 0.
+func NewRateLimiter(perSecond float64) *RateLimiter {
+	if perSecond <= 0 || math.IsNaN(perSecond) || math.IsInf(perSecond, 0) {
+		return nil
+	}
+	return &RateLimiter{perSecond: perSecond, tokens: 1, now: time.Now, sleep: sleepWithContext}
+}
+
+// Wait blocks until a request may be sent and returns how long it waited.
+// It returns early with a transport error when ctx ends, giving the
+// reserved slot back.
+func (l *RateLimiter) Wait(ctx context.Context) (time.Duration, error) {
+	if l == nil {
+		return 0, nil
+	}
+	if err := ctx.Err(); err != nil {
+		return 0, igwerr.NewTransportError(err)
+	}
+	wait := l.reserve()
+	if wait <= 0 {
+		return 0, nil
+	}
+	if err := l.sleep(ctx, wait); err != nil {
+		l.mu.Lock()
+		l.tokens++
+		l.mu.Unlock()
+		return 0, err
+	}
+	return wait, nil
+}
+
+// reserve takes one token, going into debt when none is available, and
+// returns how long the caller must wait for its token to be earned.
+func (l *RateLimiter) reserve() time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	now := l.now()
+	if !l.last.IsZero() {
+		l.tokens = math.Min(1, l.tokens+now.Sub(l.last).Seconds()*l.perSecond)
+	}
+	l.last = now
+	l.tokens--
+	if l.tokens >= 0 {
+		return 0
+	}
+	return time.Duration(-l.tokens / l.perSecond * float64(time.Second))
+}
+
+// throttle waits on the client's limiter and reports the wait to req.
+func (c *Client) throttle(ctx context.Context, req CallRequest) error {
+	wait, err := c.Limiter.Wait(ctx)
+	if wait > 0 && req.OnRateWait != nil {
+		req.OnRateWait(wait)
+	}
+	return err
+}
@@ -0,0 +1,99 @@
+package gateway
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func newTestRateLimiter(perSecond float64, clock *time.Time, slept *[]time.Duration) *RateLimiter {
+	l := NewRateLimiter(perSecond)
+	l.now = func() time.Time { return *clock }
+	l.sleep = func(_ context.Context, d time.Duration) error {
+		*slept = append(*slept, d)
+		return nil
+	}
+	return l
+}
+
+func TestRateLimiterPacesRequests(t *testing.T) {
+	t.Parallel()
+
+	clock := time.Unix(0, 0)
+	var slept []time.Duration
+	l := newTestRateLimiter(2, &clock, &slept)
+	for i := 0; i < 3; i++ {
+		if _, err := l.Wait(context.Background()); err != nil {
+			t.Fatalf("wait: %v", err)
+		}
+	}
+	clock = clock.Add(time.Second)
+	if _, err := l.Wait(context.Background()); err != nil {
+		t.Fatalf("wait: %v", err)
+	}
+
+	want := []time.Duration{500 * time.Millisecond, time.Second, 500 * time.Millisecond}
+	if !reflect.DeepEqual(slept, want) {
+		t.Fatalf("expected waits %v, got %v", want, slept)
+	}
+	if NewRateLimiter(0) != nil {
+		t.Fatalf("expected a zero rate to disable the limiter")
+	}
+}
+
+func TestRateLimiterCanceledWaitReturnsSlot(t *testing.T) {
+	t.Parallel()
+
+	clock := time.Unix(0, 0)
+	l := NewRateLimiter(1)
+	l.now = func() time.Time { return clock }
+	l.sleep = func(context.Context, time.Duration) error { return errors.New("canceled") }
+
+	if _, err := l.Wait(context.Background()); err != nil {
+		t.Fatalf("first wait: %v", err)
+	}
+	if _, err := l.Wait(context.Background()); err == nil {
+		t.Fatalf("expected canceled wait to fail")
+	}
+	if wait := l.reserve(); wait != time.Second {
+		t.Fatalf("expected the canceled slot to be returned, next wait %v", wait)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := NewRateLimiter(1).Wait(ctx); err == nil {
+		t.Fatalf("expected an error for a canceled context")
+	}
+}
+
+func TestClientLimiterReportsWait(t *testing.T) {
+	t.Parallel()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	clock := time.Unix(0, 0)
+	var slept []time.Duration
+	limiter := newTestRateLimiter(4, &clock, &slept)
+	var reported time.Duration
+	for i := 0; i < 2; i++ {
+		client := &Client{BaseURL: srv.URL, Token: "secret", HTTP: srv.Client(), Limiter: limiter}
+		_, err := client.Call(context.Background(), CallRequest{
+			Method:     http.MethodGet,
+			Path:       "/data/api/v1/gateway-info",
+			OnRateWait: func(d time.Duration) { reported += d },
+		})
+		if err != nil {
+			t.Fatalf("call: %v", err)
+		}
+	}
+	if reported != 250*time.Millisecond || len(slept) != 1 {
+		t.Fatalf("expected one shared 250ms wait, got %v (%v)", reported, slept)
+	}
+}
@@ -0,0 +1,50 @@
+package gateway
+
+import (
+	"net/http"
+	"time"
+)
+
+// Exchange is one HTTP attempt reported to Client.Recorder: the request as
+// sent (including the token header; recorders must redact it) and whatever
+// response arrived.
+type Exchange struct {
+	StartedAt      time.Time
+	Duration       time.Duration
+	Method         string
+	URL            string
+	RequestHeaders http.Header
+	RequestBody    []byte
+	// StatusCode is 0 when the attempt failed before a response arrived.
+	StatusCode      int
+	ResponseHeaders http.Header
+	// ResponseBody is the body as buffered by Call: empty when it was
+	// streamed to CallRequest.Stream, and cut at CallRequest.MaxBodyBytes.
+	ResponseBody []byte
+	Timing       *CallTiming
+	Err          error
+}
+
+// record reports one attempt to c.Recorder. sent is nil when building the
+// request failed, which is not reported.
+func (c *Client) record(req CallRequest, sent *http.Request, startedAt time.Time, timing *callTimingTrace, resp *http.Response, body []byte, err error) {
+	if c.Recorder == nil || sent == nil {
+		return
+	}
+	exchange := Exchange{
+		StartedAt:      startedAt,
+		Duration:       time.Since(startedAt),
+		Method:         sent.Method,
+		URL:            sent.URL.String(),
+		RequestHeaders: sent.Header.Clone(),
+		RequestBody:    req.Body,
+		ResponseBody:   body,
+		Timing:         timing.toEnvelope(startedAt),
+		Err:            err,
+	}
+	if resp != nil {
+		exchange.StatusCode = resp.StatusCode
+		exchange.ResponseHeaders = resp.Header.Clone()
+	}
+	c.Recorder(exchange)
+}
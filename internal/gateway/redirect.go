@@ -0,0 +1,59 @@
+package gateway
+
+import (
+	"fmt"
+	"net/http"
+)
+
+// defaultMaxRedirects matches http.Client's own limit.
+const defaultMaxRedirects = 10
+
+// Redirect is one redirect hop followed while making a request.
+type Redirect struct {
+	Status   int    `json:"status"`
+	From     string `json:"from"`
+	Location string `json:"location"`
+	// TokenResent reports whether the API token header was sent on to
+	// Location.
+	TokenResent bool `json:"tokenResent"`
+}
+
+// withRedirectCapture returns a copy of client that appends every followed
+// hop to hops. limit caps the number of hops (0 returns the first 3xx
+// response as-is); nil keeps the client's own policy, or the default
+// limit of 10 when it has none.
+func withRedirectCapture(client *http.Client, limit *int, hops *[]Redirect) *http.Client {
+	wrapped := *client
+	previous := client.CheckRedirect
+	wrapped.CheckRedirect = func(req *http.Request, via []*http.Request) error {
+		switch {
+		case limit != nil:
+			if len(via) > *limit {
+				return http.ErrUseLastResponse
+			}
+		case previous != nil:
+			if err := previous(req, via); err != nil {
+				return err
+			}
+		case len(via) >= defaultMaxRedirects:
+			return fmt.Errorf("stopped after %d redirects", defaultMaxRedirects)
+		}
+
+		hop := Redirect{
+			Location:    req.URL.String(),
+			From:        via[len(via)-1].URL.String(),
+			TokenResent: req.Header.Get(TokenHeader) != "",
+		}
+		if req.Response != nil {
+			hop.Status = req.Response.StatusCode
+		}
+		*hops = append(*hops, hop)
+		return nil
+	}
+	return &wrapped
+}
+
+// isRedirectStatus reports whether status is a 3xx response.
+func isRedirectStatus(status int) bool {
+	return status >= http.StatusMultipleChoices && status < http.StatusBadRequest
+}
@@ -0,0 +1,89 @@
+package gateway
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func newRedirectServer(t *testing.T) *httptest.Server {
+	t.Helper()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/old":
+			http.Redirect(w, r, "/mid", http.StatusMovedPermanently)
+		case "/mid":
+			http.Redirect(w, r, "/new", http.StatusFound)
+		default:
+			_, _ = w.Write([]byte(`{"ok":true}`))
+		}
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestCallReportsRedirectChain(t *testing.T) {
+	t.Parallel()
+
+	srv := newRedirectServer(t)
+	client := &Client{BaseURL: srv.URL, Token: "secret-token", HTTP: srv.Client()}
+
+	limit := 5
+	resp, err := client.Call(context.Background(), CallRequest{
+		Method:          http.MethodGet,
+		Path:            "/old",
+		Timeout:         time.Second,
+		FollowRedirects: &limit,
+	})
+	if err != nil {
+		t.Fatalf("call: %v", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("status: got %d", resp.StatusCode)
+	}
+	want := []Redirect{
+		{Status: http.StatusMovedPermanently, From: srv.URL + "/old", Location: srv.URL + "/mid", TokenResent: true},
+		{Status: http.StatusFound, From: srv.URL + "/mid", Location: srv.URL + "/new", TokenResent: true},
+	}
+	if len(resp.Redirects) != len(want) {
+		t.Fatalf("redirects: got %+v", resp.Redirects)
+	}
+	for i := range want {
+		if resp.Redirects[i] != want[i] {
+			t.Fatalf("redirect %d: got %+v want %+v", i, resp.Redirects[i], want[i])
+		}
+	}
+}
+
+func TestCallFollowRedirectsLimit(t *testing.T) {
+	t.Parallel()
+
+	srv := newRedirectServer(t)
+	client := &Client{BaseURL: srv.URL, Token: "secret-token", HTTP: srv.Client()}
+
+	for _, tc := range []struct {
+		limit  int
+		status int
+		hops   int
+	}{
+		{limit: 0, status: http.StatusMovedPermanently, hops: 0},
+		{limit: 1, status: http.StatusFound, hops: 1},
+	} {
+		limit := tc.limit
+		resp, err := client.Call(context.Background(), CallRequest{
+			Method:          http.MethodGet,
+			Path:            "/old",
+			Timeout:         time.Second,
+			FollowRedirects: &limit,
+		})
+		if err != nil {
+			t.Fatalf("limit %d: call: %v", tc.limit, err)
+		}
+		if resp.StatusCode != tc.status || len(resp.Redirects) != tc.hops {
+			t.Fatalf("limit %d: got status %d with %d hops", tc.limit, resp.StatusCode, len(resp.Redirects))
+		}
+	}
+}
@@ -24,8 +24,47 @@ func shouldRetryStatus(statusCode int) bool {
 	return statusCode == http.StatusTooManyRequests || statusCode >= http.StatusInternalServerError
 }
 
+// retryStatus reports whether a failed status is retried, using RetryOn
+// when set.
+func (req CallRequest) retryStatus(statusCode int) bool {
+	if req.RetryOn != nil {
+		return req.RetryOn(statusCode)
+	}
+	return shouldRetryStatus(statusCode)
+}
+
+// DefaultRetryAfterMax caps server-provided Retry-After delays when a request
+// does not set its own limit.
+const DefaultRetryAfterMax = 2 * time.Minute
+
+// retryDelay applies the request's Retry-After policy on top of
+// retryDelayForResponse. Retry-After is capped by the smaller of
+// RetryAfterMax and RetryMaxWait; the cap never shortens the configured
+// backoff.
+func (req CallRequest) retryDelay(statusCode int, headers http.Header, backoff time.Duration, now time.Time) time.Duration {
+	if req.IgnoreRetryAfter {
+		return backoff
+	}
+
+	delay := retryDelayForResponse(statusCode, headers, backoff, now)
+	limit := req.RetryAfterMax
+	if limit <= 0 {
+		limit = DefaultRetryAfterMax
+	}
+	if req.RetryMaxWait > 0 && req.RetryMaxWait < limit {
+		limit = req.RetryMaxWait
+	}
+	if limit < backoff {
+		limit = backoff
+	}
+	if delay > limit {
+		return limit
+	}
+	return delay
+}
+
 func retryDelayForResponse(statusCode int, headers http.Header, fallback time.Duration, now time.Time) time.Duration {
-	if statusCode != http.StatusTooManyRequests || headers == nil {
+	if (statusCode != http.StatusTooManyRequests && statusCode != http.StatusServiceUnavailable) || headers == nil {
 		return fallback
 	}
 
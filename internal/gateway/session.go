@@ -0,0 +1,50 @@
+package gateway
+
+import (
+	"net/http"
+	"net/http/cookiejar"
+	"net/url"
+)
+
+// NewSessionJar returns an empty in-memory cookie jar for Client.Jar. It
+// is never written to disk.
+func NewSessionJar() http.CookieJar {
+	// cookiejar.New only fails on invalid options.
+	jar, _ := cookiejar.New(nil)
+	return jar
+}
+
+// sessionJar returns Jar, or a jar of the client's own created on first
+// use.
+func (c *Client) sessionJar() http.CookieJar {
+	c.jarOnce.Do(func() {
+		c.jar = c.Jar
+		if c.jar == nil {
+			c.jar = NewSessionJar()
+		}
+	})
+	return c.jar
+}
+
+// sessionHTTP returns a copy of client that stores and replays cookies in
+// the session jar.
+func (c *Client) sessionHTTP(client *http.Client) *http.Client {
+	wrapped := *client
+	wrapped.Jar = c.sessionJar()
+	return &wrapped
+}
+
+// SessionCookies counts the cookies the session jar would send to the
+// configured gateways.
+func (c *Client) SessionCookies() int {
+	jar := c.sessionJar()
+	count := 0
+	for _, base := range c.baseURLs() {
+		target, err := url.Parse(base)
+		if err != nil {
+			continue
+		}
+		count += len(jar.Cookies(target))
+	}
+	return count
+}
@@ -0,0 +1,61 @@
+package gateway
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// newStickyServer sets a session cookie on the first request and rejects
+// later requests that do not send it back.
+func newStickyServer(t *testing.T) *httptest.Server {
+	t.Helper()
+
+	first := true
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if first {
+			first = false
+			http.SetCookie(w, &http.Cookie{Name: "node", Value: "a", Path: "/"})
+			return
+		}
+		if cookie, err := r.Cookie("node"); err != nil || cookie.Value != "a" {
+			http.Error(w, "no session", http.StatusForbidden)
+		}
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestCallSessionReplaysCookies(t *testing.T) {
+	t.Parallel()
+
+	srv := newStickyServer(t)
+	client := &Client{BaseURL: srv.URL, Token: "secret", HTTP: srv.Client()}
+	for i := 0; i < 2; i++ {
+		resp, err := client.Call(context.Background(), CallRequest{Method: http.MethodGet, Path: "/data/api/v1/gateway-info", Session: true})
+		if err != nil {
+			t.Fatalf("call %d: %v", i+1, err)
+		}
+		if resp.SessionCookies == nil || *resp.SessionCookies != 1 {
+			t.Fatalf("call %d: expected 1 session cookie, got %v", i+1, resp.SessionCookies)
+		}
+	}
+	if srv.Client().Jar != nil {
+		t.Fatalf("session must not modify the shared HTTP client")
+	}
+}
+
+func TestCallWithoutSessionDropsCookies(t *testing.T) {
+	t.Parallel()
+
+	srv := newStickyServer(t)
+	client := &Client{BaseURL: srv.URL, Token: "secret", HTTP: srv.Client()}
+	resp, err := client.Call(context.Background(), CallRequest{Method: http.MethodGet, Path: "/data/api/v1/gateway-info"})
+	if err != nil || resp.SessionCookies != nil {
+		t.Fatalf("first call: %v %v", err, resp)
+	}
+	if _, err := client.Call(context.Background(), CallRequest{Method: http.MethodGet, Path: "/data/api/v1/gateway-info"}); err == nil {
+		t.Fatalf("expected the second call to be rejected without a session")
+	}
+}
@@ -0,0 +1,80 @@
+package gateway
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestCallSignsRequest(t *testing.T) {
+	t.Parallel()
+
+	var gotHeaders http.Header
+	var gotURI string
+	var gotBody []byte
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotHeaders = r.Header.Clone()
+		gotURI = r.URL.RequestURI()
+		gotBody, _ = io.ReadAll(r.Body)
+		_, _ = w.Write([]byte(`{"ok":true}`))
+	}))
+	defer srv.Close()
+
+	client := &Client{
+		BaseURL: srv.URL,
+		Token:   "secret",
+		HTTP:    srv.Client(),
+		Signer: &RequestSigner{
+			Key:    []byte("shared-key"),
+			Header: "X-Proxy-Signature",
+			Now:    func() time.Time { return time.Unix(1700000000, 0) },
+		},
+	}
+	body := []byte(`{"name":"demo"}`)
+	if _, err := client.Call(context.Background(), CallRequest{
+		Method:      http.MethodPost,
+		Path:        "/data/api/v1/projects",
+		Query:       []string{"b=2", "a=1"},
+		Body:        body,
+		ContentType: "application/json",
+	}); err != nil {
+		t.Fatalf("call failed: %v", err)
+	}
+
+	if got := gotHeaders.Get(SignTimestampHeader); got != "1700000000" {
+		t.Fatalf("unexpected timestamp header %q", got)
+	}
+	if gotURI != "/data/api/v1/projects?a=1&b=2" {
+		t.Fatalf("unexpected request URI %q", gotURI)
+	}
+
+	// Recompute independently of CanonicalRequest/Sign.
+	bodyHash := sha256.Sum256(gotBody)
+	canonical := "POST\n/data/api/v1/projects?a=1&b=2\n1700000000\n" + hex.EncodeToString(bodyHash[:])
+	mac := hmac.New(sha256.New, []byte("shared-key"))
+	mac.Write([]byte(canonical))
+	want := hex.EncodeToString(mac.Sum(nil))
+
+	if got := gotHeaders.Get("X-Proxy-Signature"); got != want {
+		t.Fatalf("signature mismatch: got %q want %q", got, want)
+	}
+	if gotHeaders.Get(DefaultSignHeader) != "" {
+		t.Fatalf("default signature header should not be set when a custom one is configured")
+	}
+}
+
+func TestCanonicalRequestEmptyBody(t *testing.T) {
+	t.Parallel()
+
+	got := CanonicalRequest("get", "/data/api/v1/gateway-info", "1", nil)
+	want := "GET\n/data/api/v1/gateway-info\n1\ne3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"
+	if got != want {
+		t.Fatalf("unexpected canonical string %q", got)
+	}
+}
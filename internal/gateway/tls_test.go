@@ -0,0 +1,127 @@
+package gateway
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func writePEM(t *testing.T, path string, blockType string, der []byte) string {
+	t.Helper()
+	if err := os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: blockType, Bytes: der}), 0o600); err != nil {
+		t.Fatalf("write %s: %v", path, err)
+	}
+	return path
+}
+
+// writeClientCert writes a self-signed client certificate and its key.
+func writeClientCert(t *testing.T, dir string) (certPath string, keyPath string) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("generate key: %v", err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "igw-client"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("create certificate: %v", err)
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatalf("marshal key: %v", err)
+	}
+	return writePEM(t, filepath.Join(dir, "client.pem"), "CERTIFICATE", der),
+		writePEM(t, filepath.Join(dir, "client-key.pem"), "EC PRIVATE KEY", keyDER)
+}
+
+func tlsTestGet(opts TLSOptions, url string) error {
+	cfg, err := opts.Config()
+	if err != nil {
+		return err
+	}
+	client := &Client{
+		BaseURL: url,
+		Token:   "secret",
+		HTTP:    &http.Client{Transport: &http.Transport{TLSClientConfig: cfg}},
+	}
+	_, err = client.Call(context.Background(), CallRequest{Method: http.MethodGet, Path: "/"})
+	return err
+}
+
+func TestTLSOptionsConfig(t *testing.T) {
+	t.Parallel()
+
+	var clientCN string
+	srv := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if len(r.TLS.PeerCertificates) > 0 {
+			clientCN = r.TLS.PeerCertificates[0].Subject.CommonName
+		}
+	}))
+	srv.TLS = &tls.Config{ClientAuth: tls.RequestClientCert}
+	srv.StartTLS()
+	defer srv.Close()
+
+	dir := t.TempDir()
+	caPath := writePEM(t, filepath.Join(dir, "ca.pem"), "CERTIFICATE", srv.Certificate().Raw)
+	certPath, keyPath := writeClientCert(t, dir)
+
+	if err := tlsTestGet(TLSOptions{}, srv.URL); err == nil {
+		t.Fatalf("expected the self-signed gateway to be rejected by default")
+	}
+	if err := tlsTestGet(TLSOptions{InsecureSkipVerify: true}, srv.URL); err != nil {
+		t.Fatalf("insecure skip verify: %v", err)
+	}
+	if err := tlsTestGet(TLSOptions{CACert: caPath, ClientCert: certPath, ClientKey: keyPath}, srv.URL); err != nil {
+		t.Fatalf("custom CA with client cert: %v", err)
+	}
+	if clientCN != "igw-client" {
+		t.Fatalf("expected the client certificate to be presented, got %q", clientCN)
+	}
+
+	for name, opts := range map[string]TLSOptions{
+		"missing key":  {ClientCert: certPath},
+		"missing CA":   {CACert: filepath.Join(dir, "missing.pem")},
+		"not a bundle": {CACert: keyPath},
+		"bad pair":     {ClientCert: caPath, ClientKey: keyPath},
+	} {
+		if _, err := opts.Config(); err == nil {
+			t.Fatalf("%s: expected error", name)
+		}
+	}
+}
+
+func TestSummarizeCertificate(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	cert := &x509.Certificate{
+		Subject:  pkix.Name{CommonName: "gateway.plant"},
+		Issuer:   pkix.Name{CommonName: "Plant CA"},
+		NotAfter: now.Add(45*24*time.Hour + time.Hour),
+	}
+	got := SummarizeCertificate(cert, now)
+	if got.Subject != "CN=gateway.plant" || got.Issuer != "CN=Plant CA" || got.DaysUntilExpiry != 45 {
+		t.Fatalf("unexpected summary %+v", got)
+	}
+	if expired := SummarizeCertificate(cert, now.Add(50*24*time.Hour)); expired.DaysUntilExpiry >= 0 {
+		t.Fatalf("expected negative days for an expired certificate, got %d", expired.DaysUntilExpiry)
+	}
+}
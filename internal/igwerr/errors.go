@@ -21,6 +21,9 @@ type StatusError struct {
 	StatusCode int
 	Body       string
 	Hint       string
+	// Headers are the response headers of the failed request, when the
+	// gateway answered.
+	Headers http.Header
 }
 
 func (e *StatusError) Error() string {
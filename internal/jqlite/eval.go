@@ -0,0 +1,825 @@
+// Package jqlite implements a small, dependency-free subset of the jq
+// language for reshaping decoded JSON values.
+//
+// Supported syntax: identity (.), field access (.a, ."a b", .a.b), indexing
+// (.[0], .["k"]), iteration (.[]), optional suffix (?), pipes (|), comma
+// streams, alternatives (//), comparisons, and/or, +/-, array and object
+// construction, literals, and the builtins listed in builtins.
+//
+// Numbers in the input may be float64 or, when decoded with UseNumber,
+// json.Number. A json.Number passes through unchanged, so large integers
+// keep every digit unless they take part in arithmetic, which yields
+// float64.
+package jqlite
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"math/big"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// RuntimeError reports a failure while evaluating an expression against input.
+type RuntimeError struct {
+	Msg string
+}
+
+func (e *RuntimeError) Error() string {
+	return e.Msg
+}
+
+// Query is a compiled expression that can be run against many inputs.
+type Query struct {
+	source string
+	root   node
+}
+
+// Compile parses expr into a reusable Query.
+func Compile(expr string) (*Query, error) {
+	tokens, err := lex(expr)
+	if err != nil {
+		return nil, err
+	}
+	p := &parser{tokens: tokens}
+	root, err := p.parsePipe()
+	if err != nil {
+		return nil, err
+	}
+	if tok := p.peek(); tok.kind != tokEOF {
+		return nil, &ParseError{Pos: tok.pos, Msg: fmt.Sprintf("unexpected token %q", tok.text)}
+	}
+	return &Query{source: expr, root: root}, nil
+}
+
+// String returns the original expression text.
+func (q *Query) String() string {
+	return q.source
+}
+
+// Run evaluates the query against input (a value produced by encoding/json
+// decoding into any) and returns every emitted result in order.
+func (q *Query) Run(input any) ([]any, error) {
+	return q.root.eval(input)
+}
+
+type node interface {
+	eval(input any) ([]any, error)
+}
+
+type identityNode struct{}
+
+func (identityNode) eval(input any) ([]any, error) {
+	return []any{input}, nil
+}
+
+type literalNode struct {
+	value any
+}
+
+func (n *literalNode) eval(any) ([]any, error) {
+	return []any{n.value}, nil
+}
+
+type fieldNode struct {
+	name string
+}
+
+func (n *fieldNode) eval(input any) ([]any, error) {
+	switch v := input.(type) {
+	case nil:
+		return []any{nil}, nil
+	case map[string]any:
+		return []any{v[n.name]}, nil
+	default:
+		return nil, &RuntimeError{Msg: fmt.Sprintf("cannot index %s with %q", typeName(input), n.name)}
+	}
+}
+
+type indexNode struct {
+	index node
+}
+
+func (n *indexNode) eval(input any) ([]any, error) {
+	keys, err := n.index.eval(input)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]any, 0, len(keys))
+	for _, key := range keys {
+		value, err := indexValue(input, key)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, value)
+	}
+	return out, nil
+}
+
+func indexValue(input any, key any) (any, error) {
+	if input == nil {
+		return nil, nil
+	}
+	if k, ok := key.(string); ok {
+		obj, ok := input.(map[string]any)
+		if !ok {
+			return nil, &RuntimeError{Msg: fmt.Sprintf("cannot index %s with %q", typeName(input), k)}
+		}
+		return obj[k], nil
+	}
+	k, ok := number(key)
+	if !ok {
+		return nil, &RuntimeError{Msg: fmt.Sprintf("cannot index %s with %s", typeName(input), typeName(key))}
+	}
+	arr, ok := input.([]any)
+	if !ok {
+		return nil, &RuntimeError{Msg: fmt.Sprintf("cannot index %s with number", typeName(input))}
+	}
+	idx := int(math.Floor(k))
+	if idx < 0 {
+		idx += len(arr)
+	}
+	if idx < 0 || idx >= len(arr) {
+		return nil, nil
+	}
+	return arr[idx], nil
+}
+
+type iterateNode struct{}
+
+func (iterateNode) eval(input any) ([]any, error) {
+	switch v := input.(type) {
+	case []any:
+		out := make([]any, len(v))
+		copy(out, v)
+		return out, nil
+	case map[string]any:
+		keys := sortedKeys(v)
+		out := make([]any, 0, len(keys))
+		for _, key := range keys {
+			out = append(out, v[key])
+		}
+		return out, nil
+	default:
+		return nil, &RuntimeError{Msg: fmt.Sprintf("cannot iterate over %s", typeName(input))}
+	}
+}
+
+type tryNode struct {
+	body node
+}
+
+func (n *tryNode) eval(input any) ([]any, error) {
+	out, err := n.body.eval(input)
+	if err != nil {
+		return nil, nil
+	}
+	return out, nil
+}
+
+type pipeNode struct {
+	left  node
+	right node
+}
+
+func (n *pipeNode) eval(input any) ([]any, error) {
+	lefts, err := n.left.eval(input)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]any, 0, len(lefts))
+	for _, value := range lefts {
+		rights, err := n.right.eval(value)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, rights...)
+	}
+	return out, nil
+}
+
+type commaNode struct {
+	left  node
+	right node
+}
+
+func (n *commaNode) eval(input any) ([]any, error) {
+	lefts, err := n.left.eval(input)
+	if err != nil {
+		return nil, err
+	}
+	rights, err := n.right.eval(input)
+	if err != nil {
+		return nil, err
+	}
+	return append(lefts, rights...), nil
+}
+
+type altNode struct {
+	left  node
+	right node
+}
+
+func (n *altNode) eval(input any) ([]any, error) {
+	lefts, err := n.left.eval(input)
+	if err == nil {
+		kept := make([]any, 0, len(lefts))
+		for _, value := range lefts {
+			if truthy(value) {
+				kept = append(kept, value)
+			}
+		}
+		if len(kept) > 0 {
+			return kept, nil
+		}
+	}
+	return n.right.eval(input)
+}
+
+type logicNode struct {
+	op    string
+	left  node
+	right node
+}
+
+func (n *logicNode) eval(input any) ([]any, error) {
+	lefts, err := n.left.eval(input)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]any, 0, len(lefts))
+	for _, left := range lefts {
+		if n.op == "and" && !truthy(left) {
+			out = append(out, false)
+			continue
+		}
+		if n.op == "or" && truthy(left) {
+			out = append(out, true)
+			continue
+		}
+		rights, err := n.right.eval(input)
+		if err != nil {
+			return nil, err
+		}
+		for _, right := range rights {
+			out = append(out, truthy(right))
+		}
+	}
+	return out, nil
+}
+
+type binaryNode struct {
+	op    string
+	left  node
+	right node
+}
+
+func (n *binaryNode) eval(input any) ([]any, error) {
+	rights, err := n.right.eval(input)
+	if err != nil {
+		return nil, err
+	}
+	lefts, err := n.left.eval(input)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]any, 0, len(lefts)*len(rights))
+	for _, right := range rights {
+		for _, left := range lefts {
+			value, err := applyBinary(n.op, left, right)
+			if err != nil {
+				return nil, err
+			}
+			out = append(out, value)
+		}
+	}
+	return out, nil
+}
+
+func applyBinary(op string, left any, right any) (any, error) {
+	switch op {
+	case "==":
+		return compareValues(left, right) == 0, nil
+	case "!=":
+		return compareValues(left, right) != 0, nil
+	case "<":
+		return compareValues(left, right) < 0, nil
+	case "<=":
+		return compareValues(left, right) <= 0, nil
+	case ">":
+		return compareValues(left, right) > 0, nil
+	case ">=":
+		return compareValues(left, right) >= 0, nil
+	case "+":
+		return addValues(left, right)
+	case "-":
+		return subtractValues(left, right)
+	}
+	return nil, &RuntimeError{Msg: fmt.Sprintf("unsupported operator %q", op)}
+}
+
+func addValues(left any, right any) (any, error) {
+	if left == nil {
+		return right, nil
+	}
+	if right == nil {
+		return left, nil
+	}
+	if l, ok := number(left); ok {
+		if r, ok := number(right); ok {
+			return l + r, nil
+		}
+	}
+	switch l := left.(type) {
+	case string:
+		if r, ok := right.(string); ok {
+			return l + r, nil
+		}
+	case []any:
+		if r, ok := right.([]any); ok {
+			out := make([]any, 0, len(l)+len(r))
+			out = append(out, l...)
+			return append(out, r...), nil
+		}
+	case map[string]any:
+		if r, ok := right.(map[string]any); ok {
+			out := make(map[string]any, len(l)+len(r))
+			for k, v := range l {
+				out[k] = v
+			}
+			for k, v := range r {
+				out[k] = v
+			}
+			return out, nil
+		}
+	}
+	return nil, &RuntimeError{Msg: fmt.Sprintf("%s and %s cannot be added", typeName(left), typeName(right))}
+}
+
+func subtractValues(left any, right any) (any, error) {
+	if l, ok := number(left); ok {
+		if r, ok := number(right); ok {
+			return l - r, nil
+		}
+	}
+	switch l := left.(type) {
+	case []any:
+		if r, ok := right.([]any); ok {
+			out := make([]any, 0, len(l))
+			for _, candidate := range l {
+				removed := false
+				for _, drop := range r {
+					if compareValues(candidate, drop) == 0 {
+						removed = true
+						break
+					}
+				}
+				if !removed {
+					out = append(out, candidate)
+				}
+			}
+			return out, nil
+		}
+	}
+	return nil, &RuntimeError{Msg: fmt.Sprintf("%s and %s cannot be subtracted", typeName(left), typeName(right))}
+}
+
+type arrayNode struct {
+	body node
+}
+
+func (n *arrayNode) eval(input any) ([]any, error) {
+	if n.body == nil {
+		return []any{[]any{}}, nil
+	}
+	values, err := n.body.eval(input)
+	if err != nil {
+		return nil, err
+	}
+	if values == nil {
+		values = []any{}
+	}
+	return []any{values}, nil
+}
+
+type objectEntry struct {
+	key   node
+	value node
+}
+
+type objectNode struct {
+	entries []objectEntry
+}
+
+func (n *objectNode) eval(input any) ([]any, error) {
+	results := []map[string]any{{}}
+	for _, entry := range n.entries {
+		keys, err := entry.key.eval(input)
+		if err != nil {
+			return nil, err
+		}
+		values, err := entry.value.eval(input)
+		if err != nil {
+			return nil, err
+		}
+		next := make([]map[string]any, 0, len(results)*len(keys)*len(values))
+		for _, base := range results {
+			for _, rawKey := range keys {
+				key, ok := rawKey.(string)
+				if !ok {
+					return nil, &RuntimeError{Msg: fmt.Sprintf("object keys must be strings, got %s", typeName(rawKey))}
+				}
+				for _, value := range values {
+					cp := make(map[string]any, len(base)+1)
+					for k, v := range base {
+						cp[k] = v
+					}
+					cp[key] = value
+					next = append(next, cp)
+				}
+			}
+		}
+		results = next
+	}
+	out := make([]any, len(results))
+	for i := range results {
+		out[i] = results[i]
+	}
+	return out, nil
+}
+
+type callNode struct {
+	name string
+	args []node
+}
+
+func (n *callNode) eval(input any) ([]any, error) {
+	return builtins[n.name].fn(input, n.args)
+}
+
+type builtinDef struct {
+	arity int
+	fn    func(input any, args []node) ([]any, error)
+}
+
+var builtins map[string]builtinDef
+
+func init() {
+	builtins = map[string]builtinDef{
+		"empty":        {arity: 0, fn: func(any, []node) ([]any, error) { return nil, nil }},
+		"not":          {arity: 0, fn: func(input any, _ []node) ([]any, error) { return []any{!truthy(input)}, nil }},
+		"length":       {arity: 0, fn: builtinLength},
+		"keys":         {arity: 0, fn: builtinKeys},
+		"values":       {arity: 0, fn: builtinValues},
+		"add":          {arity: 0, fn: builtinAdd},
+		"type":         {arity: 0, fn: func(input any, _ []node) ([]any, error) { return []any{typeName(input)}, nil }},
+		"tostring":     {arity: 0, fn: builtinToString},
+		"tonumber":     {arity: 0, fn: builtinToNumber},
+		"to_entries":   {arity: 0, fn: builtinToEntries},
+		"from_entries": {arity: 0, fn: builtinFromEntries},
+		"map":          {arity: 1, fn: builtinMap},
+		"select":       {arity: 1, fn: builtinSelect},
+		"has":          {arity: 1, fn: builtinHas},
+		"with_entries": {arity: 1, fn: builtinWithEntries},
+	}
+}
+
+func builtinLength(input any, _ []node) ([]any, error) {
+	switch v := input.(type) {
+	case nil:
+		return []any{float64(0)}, nil
+	case string:
+		return []any{float64(len([]rune(v)))}, nil
+	case []any:
+		return []any{float64(len(v))}, nil
+	case map[string]any:
+		return []any{float64(len(v))}, nil
+	case float64:
+		return []any{math.Abs(v)}, nil
+	case json.Number:
+		if strings.HasPrefix(v.String(), "-") {
+			return []any{json.Number(strings.TrimPrefix(v.String(), "-"))}, nil
+		}
+		return []any{v}, nil
+	default:
+		return nil, &RuntimeError{Msg: fmt.Sprintf("%s has no length", typeName(input))}
+	}
+}
+
+func builtinKeys(input any, _ []node) ([]any, error) {
+	switch v := input.(type) {
+	case map[string]any:
+		keys := sortedKeys(v)
+		out := make([]any, len(keys))
+		for i, key := range keys {
+			out[i] = key
+		}
+		return []any{out}, nil
+	case []any:
+		out := make([]any, len(v))
+		for i := range v {
+			out[i] = float64(i)
+		}
+		return []any{out}, nil
+	default:
+		return nil, &RuntimeError{Msg: fmt.Sprintf("%s has no keys", typeName(input))}
+	}
+}
+
+func builtinValues(input any, _ []node) ([]any, error) {
+	values, err := iterateNode{}.eval(input)
+	if err != nil {
+		return nil, err
+	}
+	return []any{values}, nil
+}
+
+func builtinAdd(input any, _ []node) ([]any, error) {
+	items, err := iterateNode{}.eval(input)
+	if err != nil {
+		return nil, err
+	}
+	var acc any
+	for _, item := range items {
+		acc, err = addValues(acc, item)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return []any{acc}, nil
+}
+
+func builtinToString(input any, _ []node) ([]any, error) {
+	if s, ok := input.(string); ok {
+		return []any{s}, nil
+	}
+	b, err := json.Marshal(input)
+	if err != nil {
+		return nil, &RuntimeError{Msg: err.Error()}
+	}
+	return []any{string(b)}, nil
+}
+
+func builtinToNumber(input any, _ []node) ([]any, error) {
+	switch v := input.(type) {
+	case float64, json.Number:
+		return []any{v}, nil
+	case string:
+		parsed, err := strconv.ParseFloat(v, 64)
+		if err != nil {
+			return nil, &RuntimeError{Msg: fmt.Sprintf("cannot parse %q as a number", v)}
+		}
+		return []any{parsed}, nil
+	default:
+		return nil, &RuntimeError{Msg: fmt.Sprintf("%s cannot be parsed as a number", typeName(input))}
+	}
+}
+
+func builtinToEntries(input any, _ []node) ([]any, error) {
+	obj, ok := input.(map[string]any)
+	if !ok {
+		return nil, &RuntimeError{Msg: fmt.Sprintf("%s has no entries", typeName(input))}
+	}
+	keys := sortedKeys(obj)
+	out := make([]any, 0, len(keys))
+	for _, key := range keys {
+		out = append(out, map[string]any{"key": key, "value": obj[key]})
+	}
+	return []any{out}, nil
+}
+
+func builtinFromEntries(input any, _ []node) ([]any, error) {
+	arr, ok := input.([]any)
+	if !ok {
+		return nil, &RuntimeError{Msg: fmt.Sprintf("cannot build object from %s", typeName(input))}
+	}
+	out := make(map[string]any, len(arr))
+	for _, raw := range arr {
+		entry, ok := raw.(map[string]any)
+		if !ok {
+			return nil, &RuntimeError{Msg: fmt.Sprintf("from_entries expects objects, got %s", typeName(raw))}
+		}
+		key, ok := entry["key"].(string)
+		if !ok {
+			if name, nameOK := entry["name"].(string); nameOK {
+				key = name
+			} else {
+				return nil, &RuntimeError{Msg: "from_entries requires string key"}
+			}
+		}
+		out[key] = entry["value"]
+	}
+	return []any{out}, nil
+}
+
+func builtinMap(input any, args []node) ([]any, error) {
+	items, err := iterateNode{}.eval(input)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]any, 0, len(items))
+	for _, item := range items {
+		mapped, err := args[0].eval(item)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, mapped...)
+	}
+	return []any{out}, nil
+}
+
+func builtinSelect(input any, args []node) ([]any, error) {
+	conds, err := args[0].eval(input)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]any, 0, 1)
+	for _, cond := range conds {
+		if truthy(cond) {
+			out = append(out, input)
+		}
+	}
+	return out, nil
+}
+
+func builtinHas(input any, args []node) ([]any, error) {
+	keys, err := args[0].eval(input)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]any, 0, len(keys))
+	for _, key := range keys {
+		switch v := input.(type) {
+		case map[string]any:
+			k, ok := key.(string)
+			if !ok {
+				return nil, &RuntimeError{Msg: "has() on an object requires a string key"}
+			}
+			_, exists := v[k]
+			out = append(out, exists)
+		case []any:
+			idx, ok := number(key)
+			if !ok {
+				return nil, &RuntimeError{Msg: "has() on an array requires a number"}
+			}
+			out = append(out, idx >= 0 && int(idx) < len(v))
+		default:
+			return nil, &RuntimeError{Msg: fmt.Sprintf("cannot check whether %s has a key", typeName(input))}
+		}
+	}
+	return out, nil
+}
+
+func builtinWithEntries(input any, args []node) ([]any, error) {
+	entries, err := builtinToEntries(input, nil)
+	if err != nil {
+		return nil, err
+	}
+	mapped, err := builtinMap(entries[0], args)
+	if err != nil {
+		return nil, err
+	}
+	return builtinFromEntries(mapped[0], nil)
+}
+
+func truthy(value any) bool {
+	switch v := value.(type) {
+	case nil:
+		return false
+	case bool:
+		return v
+	default:
+		return true
+	}
+}
+
+func typeName(value any) string {
+	switch value.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case float64, json.Number:
+		return "number"
+	case string:
+		return "string"
+	case []any:
+		return "array"
+	case map[string]any:
+		return "object"
+	default:
+		return fmt.Sprintf("%T", value)
+	}
+}
+
+func typeOrder(value any) int {
+	switch v := value.(type) {
+	case nil:
+		return 0
+	case bool:
+		if v {
+			return 2
+		}
+		return 1
+	case float64, json.Number:
+		return 3
+	case string:
+		return 4
+	case []any:
+		return 5
+	case map[string]any:
+		return 6
+	default:
+		return 7
+	}
+}
+
+// compareValues orders values the way jq does: null < false < true <
+// numbers < strings < arrays < objects.
+func compareValues(left any, right any) int {
+	lo, ro := typeOrder(left), typeOrder(right)
+	if lo != ro {
+		if lo < ro {
+			return -1
+		}
+		return 1
+	}
+	switch l := left.(type) {
+	case float64, json.Number:
+		return compareNumbers(l, right)
+	case string:
+		r := right.(string)
+		switch {
+		case l < r:
+			return -1
+		case l > r:
+			return 1
+		default:
+			return 0
+		}
+	case []any:
+		r := right.([]any)
+		for i := 0; i < len(l) && i < len(r); i++ {
+			if cmp := compareValues(l[i], r[i]); cmp != 0 {
+				return cmp
+			}
+		}
+		return len(l) - len(r)
+	case map[string]any:
+		if reflect.DeepEqual(left, right) {
+			return 0
+		}
+		lb, _ := json.Marshal(left)
+		rb, _ := json.Marshal(right)
+		if string(lb) < string(rb) {
+			return -1
+		}
+		return 1
+	}
+	return 0
+}
+
+// number returns value as a float64 when it is a JSON number.
+func number(value any) (float64, bool) {
+	switch v := value.(type) {
+	case float64:
+		return v, true
+	case json.Number:
+		f, err := v.Float64()
+		return f, err == nil
+	default:
+		return 0, false
+	}
+}
+
+// compareNumbers compares exactly, so json.Number integers beyond 2^53
+// that round to the same float64 still order correctly.
+func compareNumbers(left any, right any) int {
+	return exactNumber(left).Cmp(exactNumber(right))
+}
+
+func exactNumber(value any) *big.Float {
+	out := new(big.Float).SetPrec(256)
+	switch v := value.(type) {
+	case float64:
+		out.SetFloat64(v)
+	case json.Number:
+		if _, ok := out.SetString(v.String()); !ok {
+			f, _ := number(v)
+			out.SetFloat64(f)
+		}
+	}
+	return out
+}
+
+func sortedKeys(obj map[string]any) []string {
+	keys := make([]string, 0, len(obj))
+	for key := range obj {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,138 @@
+package jqlite
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func runJSON(t *testing.T, expr string, input string) []any {
+	t.Helper()
+
+	var value any
+	if err := json.Unmarshal([]byte(input), &value); err != nil {
+		t.Fatalf("decode input: %v", err)
+	}
+	query, err := Compile(expr)
+	if err != nil {
+		t.Fatalf("compile %q: %v", expr, err)
+	}
+	out, err := query.Run(value)
+	if err != nil {
+		t.Fatalf("run %q: %v", expr, err)
+	}
+	return out
+}
+
+func TestRunExpressions(t *testing.T) {
+	t.Parallel()
+
+	input := `{"name":"demo","items":[{"id":1,"on":true},{"id":2,"on":false}],"meta":null}`
+	cases := []struct {
+		expr string
+		want string
+	}{
+		{expr: ".", want: `[` + input + `]`},
+		{expr: ".name", want: `["demo"]`},
+		{expr: ".items[0].id", want: `[1]`},
+		{expr: ".items[-1].id", want: `[2]`},
+		{expr: ".items[].id", want: `[1,2]`},
+		{expr: `.["name"]`, want: `["demo"]`},
+		{expr: ".meta.missing", want: `[null]`},
+		{expr: ".meta // \"fallback\"", want: `["fallback"]`},
+		{expr: "[.items[] | select(.on) | .id]", want: `[[1]]`},
+		{expr: "{name, ids: [.items[].id]}", want: `[{"name":"demo","ids":[1,2]}]`},
+		{expr: "{(.name): 1}", want: `[{"demo":1}]`},
+		{expr: ".items | map(.id + 10)", want: `[[11,12]]`},
+		{expr: ".items | length", want: `[2]`},
+		{expr: "keys", want: `[["items","meta","name"]]`},
+		{expr: "has(\"name\"), has(\"nope\")", want: `[true,false]`},
+		{expr: ".items[0].id == 1 and .name != \"x\"", want: `[true]`},
+		{expr: "[.items[].id] | add", want: `[3]`},
+		{expr: "{a:1,b:2} | with_entries(select(.key == \"b\"))", want: `[{"b":2}]`},
+		{expr: ".name | type", want: `["string"]`},
+		{expr: "-(.items[0].id)", want: `[-1]`},
+		{expr: ".name[0]?", want: `[]`},
+		{expr: "empty", want: `[]`},
+	}
+
+	for _, tc := range cases {
+		got := runJSON(t, tc.expr, input)
+		var want []any
+		if err := json.Unmarshal([]byte(tc.want), &want); err != nil {
+			t.Fatalf("decode want for %q: %v", tc.expr, err)
+		}
+		if len(got) == 0 && len(want) == 0 {
+			continue
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Fatalf("%s: expected %#v, got %#v", tc.expr, want, got)
+		}
+	}
+}
+
+func TestCompileRejectsInvalidExpressions(t *testing.T) {
+	t.Parallel()
+
+	for _, expr := range []string{"", "{a: }", ".a |", "nosuchfn", "map", ".a ; .b", `"unterminated`} {
+		_, err := Compile(expr)
+		var parseErr *ParseError
+		if !errors.As(err, &parseErr) {
+			t.Fatalf("%q: expected ParseError, got %v", expr, err)
+		}
+	}
+}
+
+func TestRunReportsRuntimeErrors(t *testing.T) {
+	t.Parallel()
+
+	query, err := Compile(".a.b")
+	if err != nil {
+		t.Fatalf("compile: %v", err)
+	}
+	_, err = query.Run(map[string]any{"a": "text"})
+	var runtimeErr *RuntimeError
+	if !errors.As(err, &runtimeErr) {
+		t.Fatalf("expected RuntimeError, got %v", err)
+	}
+}
+
+func TestRunKeepsJSONNumbers(t *testing.T) {
+	t.Parallel()
+
+	decoder := json.NewDecoder(strings.NewReader(`{"id":12345678901234567891,"other":12345678901234567890,"n":2,"list":[10,20,30]}`))
+	decoder.UseNumber()
+	var input any
+	if err := decoder.Decode(&input); err != nil {
+		t.Fatalf("decode input: %v", err)
+	}
+
+	cases := []struct {
+		expr string
+		want string
+	}{
+		{expr: "{id}", want: `[{"id":12345678901234567891}]`},
+		{expr: ".id > .other, .n == 2, .n + 1e3, (.list | has(2))", want: `[true,true,1002,true]`},
+		{expr: ".n | type, length, tonumber", want: `["number",2,2]`},
+		{expr: "2.5e-1, 1E+2", want: `[0.25,100]`},
+	}
+	for _, tc := range cases {
+		query, err := Compile(tc.expr)
+		if err != nil {
+			t.Fatalf("compile %q: %v", tc.expr, err)
+		}
+		out, err := query.Run(input)
+		if err != nil {
+			t.Fatalf("run %q: %v", tc.expr, err)
+		}
+		got, err := json.Marshal(out)
+		if err != nil {
+			t.Fatalf("encode %q: %v", tc.expr, err)
+		}
+		if string(got) != tc.want {
+			t.Fatalf("%s: expected %s, got %s", tc.expr, tc.want, got)
+		}
+	}
+}
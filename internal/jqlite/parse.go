@@ -0,0 +1,507 @@
+package jqlite
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ParseError reports an invalid expression together with the byte offset
+// where parsing stopped.
+type ParseError struct {
+	Pos int
+	Msg string
+}
+
+func (e *ParseError) Error() string {
+	return fmt.Sprintf("parse error at position %d: %s", e.Pos, e.Msg)
+}
+
+type tokenKind int
+
+const (
+	tokEOF tokenKind = iota
+	tokDot
+	tokField
+	tokIdent
+	tokString
+	tokNumber
+	tokPunct
+)
+
+type token struct {
+	kind tokenKind
+	text string
+	pos  int
+}
+
+func lex(src string) ([]token, error) {
+	tokens := make([]token, 0, 16)
+	i := 0
+	for i < len(src) {
+		ch := src[i]
+		switch {
+		case ch == ' ' || ch == '\t' || ch == '\n' || ch == '\r':
+			i++
+		case ch == '.':
+			start := i
+			i++
+			if i < len(src) && isIdentStart(src[i]) {
+				j := i
+				for j < len(src) && isIdentPart(src[j]) {
+					j++
+				}
+				tokens = append(tokens, token{kind: tokField, text: src[i:j], pos: start})
+				i = j
+				continue
+			}
+			tokens = append(tokens, token{kind: tokDot, text: ".", pos: start})
+		case ch == '"':
+			start := i
+			j := i + 1
+			for j < len(src) && src[j] != '"' {
+				if src[j] == '\\' {
+					j++
+				}
+				j++
+			}
+			if j >= len(src) {
+				return nil, &ParseError{Pos: start, Msg: "unterminated string"}
+			}
+			var decoded string
+			if err := json.Unmarshal([]byte(src[start:j+1]), &decoded); err != nil {
+				return nil, &ParseError{Pos: start, Msg: "invalid string literal"}
+			}
+			tokens = append(tokens, token{kind: tokString, text: decoded, pos: start})
+			i = j + 1
+		case ch >= '0' && ch <= '9':
+			start := i
+			i = scanNumber(src, i)
+			tokens = append(tokens, token{kind: tokNumber, text: src[start:i], pos: start})
+		case isIdentStart(ch):
+			start := i
+			for i < len(src) && isIdentPart(src[i]) {
+				i++
+			}
+			tokens = append(tokens, token{kind: tokIdent, text: src[start:i], pos: start})
+		default:
+			start := i
+			two := ""
+			if i+1 < len(src) {
+				two = src[i : i+2]
+			}
+			switch two {
+			case "==", "!=", "<=", ">=", "//":
+				tokens = append(tokens, token{kind: tokPunct, text: two, pos: start})
+				i += 2
+				continue
+			}
+			if !strings.ContainsRune("|,()[]{}:<>+-*?", rune(ch)) {
+				return nil, &ParseError{Pos: start, Msg: fmt.Sprintf("unexpected character %q", ch)}
+			}
+			tokens = append(tokens, token{kind: tokPunct, text: string(ch), pos: start})
+			i++
+		}
+	}
+	tokens = append(tokens, token{kind: tokEOF, pos: len(src)})
+	return tokens, nil
+}
+
+func isIdentStart(ch byte) bool {
+	return ch == '_' || (ch >= 'a' && ch <= 'z') || (ch >= 'A' && ch <= 'Z')
+}
+
+func isIdentPart(ch byte) bool {
+	return isIdentStart(ch) || (ch >= '0' && ch <= '9')
+}
+
+type parser struct {
+	tokens []token
+	pos    int
+}
+
+func (p *parser) peek() token {
+	return p.tokens[p.pos]
+}
+
+func (p *parser) next() token {
+	tok := p.tokens[p.pos]
+	if tok.kind != tokEOF {
+		p.pos++
+	}
+	return tok
+}
+
+func (p *parser) isPunct(text string) bool {
+	tok := p.peek()
+	return tok.kind == tokPunct && tok.text == text
+}
+
+func (p *parser) isKeyword(text string) bool {
+	tok := p.peek()
+	return tok.kind == tokIdent && tok.text == text
+}
+
+func (p *parser) expectPunct(text string) error {
+	tok := p.peek()
+	if tok.kind != tokPunct || tok.text != text {
+		return &ParseError{Pos: tok.pos, Msg: fmt.Sprintf("expected %q", text)}
+	}
+	p.next()
+	return nil
+}
+
+func (p *parser) parsePipe() (node, error) {
+	left, err := p.parseComma()
+	if err != nil {
+		return nil, err
+	}
+	for p.isPunct("|") {
+		p.next()
+		right, err := p.parseComma()
+		if err != nil {
+			return nil, err
+		}
+		left = &pipeNode{left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *parser) parseComma() (node, error) {
+	left, err := p.parseAlt()
+	if err != nil {
+		return nil, err
+	}
+	for p.isPunct(",") {
+		p.next()
+		right, err := p.parseAlt()
+		if err != nil {
+			return nil, err
+		}
+		left = &commaNode{left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *parser) parseAlt() (node, error) {
+	left, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	for p.isPunct("//") {
+		p.next()
+		right, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		left = &altNode{left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *parser) parseOr() (node, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for p.isKeyword("or") {
+		p.next()
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = &logicNode{op: "or", left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *parser) parseAnd() (node, error) {
+	left, err := p.parseCompare()
+	if err != nil {
+		return nil, err
+	}
+	for p.isKeyword("and") {
+		p.next()
+		right, err := p.parseCompare()
+		if err != nil {
+			return nil, err
+		}
+		left = &logicNode{op: "and", left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *parser) parseCompare() (node, error) {
+	left, err := p.parseAdditive()
+	if err != nil {
+		return nil, err
+	}
+	tok := p.peek()
+	if tok.kind == tokPunct {
+		switch tok.text {
+		case "==", "!=", "<", "<=", ">", ">=":
+			p.next()
+			right, err := p.parseAdditive()
+			if err != nil {
+				return nil, err
+			}
+			return &binaryNode{op: tok.text, left: left, right: right}, nil
+		}
+	}
+	return left, nil
+}
+
+func (p *parser) parseAdditive() (node, error) {
+	left, err := p.parsePostfix()
+	if err != nil {
+		return nil, err
+	}
+	for p.isPunct("+") || p.isPunct("-") {
+		op := p.next().text
+		right, err := p.parsePostfix()
+		if err != nil {
+			return nil, err
+		}
+		left = &binaryNode{op: op, left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *parser) parsePostfix() (node, error) {
+	current, err := p.parsePrimary()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		tok := p.peek()
+		switch {
+		case tok.kind == tokField:
+			p.next()
+			current = &pipeNode{left: current, right: &fieldNode{name: tok.text}}
+		case tok.kind == tokDot && p.tokens[p.pos+1].kind == tokString:
+			p.next()
+			name := p.next().text
+			current = &pipeNode{left: current, right: &fieldNode{name: name}}
+		case tok.kind == tokDot && p.tokens[p.pos+1].kind == tokPunct && p.tokens[p.pos+1].text == "[":
+			p.next()
+		case tok.kind == tokPunct && tok.text == "[":
+			suffix, err := p.parseBracketSuffix()
+			if err != nil {
+				return nil, err
+			}
+			current = &pipeNode{left: current, right: suffix}
+		case tok.kind == tokPunct && tok.text == "?":
+			p.next()
+			current = &tryNode{body: current}
+		default:
+			return current, nil
+		}
+	}
+}
+
+func (p *parser) parseBracketSuffix() (node, error) {
+	if err := p.expectPunct("["); err != nil {
+		return nil, err
+	}
+	if p.isPunct("]") {
+		p.next()
+		return &iterateNode{}, nil
+	}
+	index, err := p.parsePipe()
+	if err != nil {
+		return nil, err
+	}
+	if err := p.expectPunct("]"); err != nil {
+		return nil, err
+	}
+	return &indexNode{index: index}, nil
+}
+
+func (p *parser) parsePrimary() (node, error) {
+	tok := p.peek()
+	switch tok.kind {
+	case tokDot:
+		p.next()
+		if p.peek().kind == tokString {
+			return &fieldNode{name: p.next().text}, nil
+		}
+		if p.isPunct("[") {
+			return p.parseBracketSuffix()
+		}
+		return &identityNode{}, nil
+	case tokField:
+		p.next()
+		return &fieldNode{name: tok.text}, nil
+	case tokString:
+		p.next()
+		return &literalNode{value: tok.text}, nil
+	case tokNumber:
+		p.next()
+		value, err := strconv.ParseFloat(tok.text, 64)
+		if err != nil {
+			return nil, &ParseError{Pos: tok.pos, Msg: fmt.Sprintf("invalid number %q", tok.text)}
+		}
+		return &literalNode{value: value}, nil
+	case tokIdent:
+		return p.parseIdent()
+	case tokPunct:
+		switch tok.text {
+		case "(":
+			p.next()
+			inner, err := p.parsePipe()
+			if err != nil {
+				return nil, err
+			}
+			if err := p.expectPunct(")"); err != nil {
+				return nil, err
+			}
+			return inner, nil
+		case "[":
+			p.next()
+			if p.isPunct("]") {
+				p.next()
+				return &arrayNode{}, nil
+			}
+			inner, err := p.parsePipe()
+			if err != nil {
+				return nil, err
+			}
+			if err := p.expectPunct("]"); err != nil {
+				return nil, err
+			}
+			return &arrayNode{body: inner}, nil
+		case "{":
+			return p.parseObject()
+		case "-":
+			p.next()
+			operand, err := p.parsePostfix()
+			if err != nil {
+				return nil, err
+			}
+			return &binaryNode{op: "-", left: &literalNode{value: float64(0)}, right: operand}, nil
+		}
+	case tokEOF:
+		return nil, &ParseError{Pos: tok.pos, Msg: "unexpected end of expression"}
+	}
+	return nil, &ParseError{Pos: tok.pos, Msg: fmt.Sprintf("unexpected token %q", tok.text)}
+}
+
+func (p *parser) parseIdent() (node, error) {
+	tok := p.next()
+	switch tok.text {
+	case "true":
+		return &literalNode{value: true}, nil
+	case "false":
+		return &literalNode{value: false}, nil
+	case "null":
+		return &literalNode{value: nil}, nil
+	}
+
+	var args []node
+	if p.isPunct("(") {
+		p.next()
+		arg, err := p.parsePipe()
+		if err != nil {
+			return nil, err
+		}
+		args = append(args, arg)
+		if err := p.expectPunct(")"); err != nil {
+			return nil, err
+		}
+	}
+
+	def, ok := builtins[tok.text]
+	if !ok {
+		return nil, &ParseError{Pos: tok.pos, Msg: fmt.Sprintf("unknown function %q", tok.text)}
+	}
+	if def.arity != len(args) {
+		return nil, &ParseError{Pos: tok.pos, Msg: fmt.Sprintf("function %s/%d is not defined", tok.text, len(args))}
+	}
+	return &callNode{name: tok.text, args: args}, nil
+}
+
+func (p *parser) parseObject() (node, error) {
+	if err := p.expectPunct("{"); err != nil {
+		return nil, err
+	}
+	obj := &objectNode{}
+	if p.isPunct("}") {
+		p.next()
+		return obj, nil
+	}
+	for {
+		tok := p.peek()
+		var key node
+		var keyName string
+		switch {
+		case tok.kind == tokIdent || tok.kind == tokString:
+			p.next()
+			keyName = tok.text
+			key = &literalNode{value: tok.text}
+		case tok.kind == tokPunct && tok.text == "(":
+			p.next()
+			inner, err := p.parsePipe()
+			if err != nil {
+				return nil, err
+			}
+			if err := p.expectPunct(")"); err != nil {
+				return nil, err
+			}
+			key = inner
+		default:
+			return nil, &ParseError{Pos: tok.pos, Msg: "expected object key"}
+		}
+
+		var value node
+		if p.isPunct(":") {
+			p.next()
+			parsed, err := p.parseAlt()
+			if err != nil {
+				return nil, err
+			}
+			value = parsed
+		} else {
+			if keyName == "" {
+				return nil, &ParseError{Pos: p.peek().pos, Msg: "expected ':' after computed object key"}
+			}
+			value = &fieldNode{name: keyName}
+		}
+		obj.entries = append(obj.entries, objectEntry{key: key, value: value})
+
+		if p.isPunct(",") {
+			p.next()
+			continue
+		}
+		if err := p.expectPunct("}"); err != nil {
+			return nil, err
+		}
+		return obj, nil
+	}
+}
+
+// scanNumber returns the end of the number literal starting at i: digits,
+// an optional fraction, and an optional exponent such as 1e3 or 2.5E-4.
+func scanNumber(src string, i int) int {
+	i = scanDigits(src, i)
+	if i < len(src) && src[i] == '.' {
+		i = scanDigits(src, i+1)
+	}
+	if i < len(src) && (src[i] == 'e' || src[i] == 'E') {
+		j := i + 1
+		if j < len(src) && (src[j] == '+' || src[j] == '-') {
+			j++
+		}
+		if end := scanDigits(src, j); end > j {
+			i = end
+		}
+	}
+	return i
+}
+
+func scanDigits(src string, i int) int {
+	for i < len(src) && src[i] >= '0' && src[i] <= '9' {
+		i++
+	}
+	return i
+}
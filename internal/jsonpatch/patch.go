@@ -0,0 +1,382 @@
+// Package jsonpatch applies RFC 6902 JSON Patch documents to JSON values.
+//
+// All six operations (add, remove, replace, move, copy, test) are supported.
+// Numbers are decoded as json.Number so values the patch does not touch keep
+// their original text; object keys are re-encoded in sorted order.
+package jsonpatch
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Operation is one entry of a JSON Patch document.
+type Operation struct {
+	Op    string          `json:"op"`
+	Path  string          `json:"path"`
+	From  string          `json:"from,omitempty"`
+	Value json.RawMessage `json:"value,omitempty"`
+}
+
+// TestFailedError reports a "test" operation whose value did not match.
+type TestFailedError struct {
+	Index int
+	Path  string
+}
+
+func (e *TestFailedError) Error() string {
+	return fmt.Sprintf("patch operation %d: test failed at %q", e.Index, e.Path)
+}
+
+// Decode parses and validates a JSON Patch document without applying it.
+func Decode(raw []byte) ([]Operation, error) {
+	var ops []Operation
+	if err := json.Unmarshal(raw, &ops); err != nil {
+		return nil, fmt.Errorf("parse patch: %w", err)
+	}
+	for i, op := range ops {
+		if err := op.validate(); err != nil {
+			return nil, fmt.Errorf("patch operation %d: %w", i, err)
+		}
+	}
+	return ops, nil
+}
+
+func (op Operation) validate() error {
+	if _, err := parsePointer(op.Path); err != nil {
+		return err
+	}
+	switch op.Op {
+	case "add", "replace", "test":
+		if op.Value == nil {
+			return fmt.Errorf("%q requires a value", op.Op)
+		}
+	case "remove":
+	case "move", "copy":
+		if _, err := parsePointer(op.From); err != nil {
+			return fmt.Errorf("from: %w", err)
+		}
+	default:
+		return fmt.Errorf("unsupported op %q", op.Op)
+	}
+	return nil
+}
+
+// Apply runs ops against doc in order and returns the patched document.
+// Any failing operation aborts the whole patch.
+func Apply(doc []byte, ops []Operation) ([]byte, error) {
+	root, err := decodeValue(doc)
+	if err != nil {
+		return nil, fmt.Errorf("parse document: %w", err)
+	}
+
+	for i, op := range ops {
+		root, err = applyOne(root, op)
+		if err != nil {
+			var testErr *TestFailedError
+			if errors.As(err, &testErr) {
+				testErr.Index = i
+				return nil, testErr
+			}
+			return nil, fmt.Errorf("patch operation %d (%s %q): %w", i, op.Op, op.Path, err)
+		}
+	}
+
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(root); err != nil {
+		return nil, err
+	}
+	return bytes.TrimSuffix(buf.Bytes(), []byte("\n")), nil
+}
+
+func applyOne(root any, op Operation) (any, error) {
+	path, err := parsePointer(op.Path)
+	if err != nil {
+		return nil, err
+	}
+
+	switch op.Op {
+	case "add":
+		value, err := decodeValue(op.Value)
+		if err != nil {
+			return nil, err
+		}
+		return add(root, path, value)
+	case "remove":
+		return remove(root, path)
+	case "replace":
+		value, err := decodeValue(op.Value)
+		if err != nil {
+			return nil, err
+		}
+		if len(path) == 0 {
+			return value, nil
+		}
+		return update(root, path, func(container any, key string) (any, error) {
+			switch c := container.(type) {
+			case map[string]any:
+				if _, ok := c[key]; !ok {
+					return nil, fmt.Errorf("no member %q", key)
+				}
+				c[key] = value
+				return c, nil
+			case []any:
+				idx, err := arrayIndex(key, len(c)-1)
+				if err != nil {
+					return nil, err
+				}
+				c[idx] = value
+				return c, nil
+			default:
+				return nil, errNotContainer
+			}
+		})
+	case "move", "copy":
+		from, err := parsePointer(op.From)
+		if err != nil {
+			return nil, err
+		}
+		value, err := get(root, from)
+		if err != nil {
+			return nil, fmt.Errorf("from: %w", err)
+		}
+		if op.Op == "move" {
+			if isProperPrefix(from, path) {
+				return nil, errors.New("cannot move a value into one of its children")
+			}
+			if root, err = remove(root, from); err != nil {
+				return nil, err
+			}
+		} else if value, err = deepCopy(value); err != nil {
+			return nil, err
+		}
+		return add(root, path, value)
+	case "test":
+		want, err := decodeValue(op.Value)
+		if err != nil {
+			return nil, err
+		}
+		got, err := get(root, path)
+		if err != nil || !equal(got, want) {
+			return nil, &TestFailedError{Path: op.Path}
+		}
+		return root, nil
+	default:
+		return nil, fmt.Errorf("unsupported op %q", op.Op)
+	}
+}
+
+var errNotContainer = errors.New("parent is not an object or array")
+
+func add(root any, path []string, value any) (any, error) {
+	if len(path) == 0 {
+		return value, nil
+	}
+	return update(root, path, func(container any, key string) (any, error) {
+		switch c := container.(type) {
+		case map[string]any:
+			c[key] = value
+			return c, nil
+		case []any:
+			if key == "-" {
+				return append(c, value), nil
+			}
+			idx, err := arrayIndex(key, len(c))
+			if err != nil {
+				return nil, err
+			}
+			c = append(c, nil)
+			copy(c[idx+1:], c[idx:])
+			c[idx] = value
+			return c, nil
+		default:
+			return nil, errNotContainer
+		}
+	})
+}
+
+func remove(root any, path []string) (any, error) {
+	if len(path) == 0 {
+		return nil, errors.New("cannot remove the document root")
+	}
+	return update(root, path, func(container any, key string) (any, error) {
+		switch c := container.(type) {
+		case map[string]any:
+			if _, ok := c[key]; !ok {
+				return nil, fmt.Errorf("no member %q", key)
+			}
+			delete(c, key)
+			return c, nil
+		case []any:
+			idx, err := arrayIndex(key, len(c)-1)
+			if err != nil {
+				return nil, err
+			}
+			return append(c[:idx], c[idx+1:]...), nil
+		default:
+			return nil, errNotContainer
+		}
+	})
+}
+
+// update walks to the parent of the last path token, lets fn rewrite that
+// container, and stores the (possibly reallocated) result back up the chain.
+func update(node any, path []string, fn func(container any, key string) (any, error)) (any, error) {
+	if len(path) == 1 {
+		return fn(node, path[0])
+	}
+
+	child, err := child(node, path[0])
+	if err != nil {
+		return nil, err
+	}
+	updated, err := update(child, path[1:], fn)
+	if err != nil {
+		return nil, err
+	}
+
+	switch c := node.(type) {
+	case map[string]any:
+		c[path[0]] = updated
+	case []any:
+		idx, _ := arrayIndex(path[0], len(c)-1)
+		c[idx] = updated
+	}
+	return node, nil
+}
+
+func get(node any, path []string) (any, error) {
+	for _, token := range path {
+		next, err := child(node, token)
+		if err != nil {
+			return nil, err
+		}
+		node = next
+	}
+	return node, nil
+}
+
+func child(node any, token string) (any, error) {
+	switch c := node.(type) {
+	case map[string]any:
+		value, ok := c[token]
+		if !ok {
+			return nil, fmt.Errorf("no member %q", token)
+		}
+		return value, nil
+	case []any:
+		idx, err := arrayIndex(token, len(c)-1)
+		if err != nil {
+			return nil, err
+		}
+		return c[idx], nil
+	default:
+		return nil, fmt.Errorf("cannot index %q into a scalar", token)
+	}
+}
+
+// arrayIndex parses an RFC 6901 array index and checks it against max.
+func arrayIndex(token string, max int) (int, error) {
+	if token == "" || (len(token) > 1 && token[0] == '0') || strings.Trim(token, "0123456789") != "" {
+		return 0, fmt.Errorf("invalid array index %q", token)
+	}
+	idx, err := strconv.Atoi(token)
+	if err != nil || idx > max {
+		return 0, fmt.Errorf("array index %q out of range", token)
+	}
+	return idx, nil
+}
+
+// parsePointer splits an RFC 6901 JSON Pointer into unescaped tokens.
+func parsePointer(pointer string) ([]string, error) {
+	if pointer == "" {
+		return nil, nil
+	}
+	if !strings.HasPrefix(pointer, "/") {
+		return nil, fmt.Errorf("invalid JSON pointer %q (must start with /)", pointer)
+	}
+	tokens := strings.Split(pointer[1:], "/")
+	for i, token := range tokens {
+		tokens[i] = strings.NewReplacer("~1", "/", "~0", "~").Replace(token)
+	}
+	return tokens, nil
+}
+
+func isProperPrefix(prefix []string, path []string) bool {
+	if len(prefix) >= len(path) {
+		return false
+	}
+	for i := range prefix {
+		if prefix[i] != path[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func decodeValue(raw []byte) (any, error) {
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	dec.UseNumber()
+	var value any
+	if err := dec.Decode(&value); err != nil {
+		return nil, err
+	}
+	return value, nil
+}
+
+func deepCopy(value any) (any, error) {
+	raw, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+	return decodeValue(raw)
+}
+
+// equal compares decoded JSON values, treating numbers by value so 1 and
+// 1.0 match as RFC 6902 requires.
+func equal(a any, b any) bool {
+	switch av := a.(type) {
+	case json.Number:
+		bv, ok := b.(json.Number)
+		if !ok {
+			return false
+		}
+		if av == bv {
+			return true
+		}
+		af, aErr := av.Float64()
+		bf, bErr := bv.Float64()
+		return aErr == nil && bErr == nil && af == bf
+	case map[string]any:
+		bv, ok := b.(map[string]any)
+		if !ok || len(av) != len(bv) {
+			return false
+		}
+		for key, value := range av {
+			other, ok := bv[key]
+			if !ok || !equal(value, other) {
+				return false
+			}
+		}
+		return true
+	case []any:
+		bv, ok := b.([]any)
+		if !ok || len(av) != len(bv) {
+			return false
+		}
+		for i := range av {
+			if !equal(av[i], bv[i]) {
+				return false
+			}
+		}
+		return true
+	default:
+		return a == b
+	}
+}
@@ -0,0 +1,130 @@
+package jsonpatch
+
+import (
+	"errors"
+	"testing"
+)
+
+func applyJSON(t *testing.T, doc string, patch string) (string, error) {
+	t.Helper()
+	ops, err := Decode([]byte(patch))
+	if err != nil {
+		t.Fatalf("decode patch %s: %v", patch, err)
+	}
+	out, err := Apply([]byte(doc), ops)
+	return string(out), err
+}
+
+func TestApplyOperations(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name  string
+		doc   string
+		patch string
+		want  string
+	}{
+		{
+			name:  "replace keeps untouched numbers",
+			doc:   `{"name":"old","enabled":false,"scale":1.50}`,
+			patch: `[{"op":"replace","path":"/name","value":"new"}]`,
+			want:  `{"enabled":false,"name":"new","scale":1.50}`,
+		},
+		{
+			name:  "add member and array insert and append",
+			doc:   `{"tags":["a","c"]}`,
+			patch: `[{"op":"add","path":"/tags/1","value":"b"},{"op":"add","path":"/tags/-","value":"d"},{"op":"add","path":"/owner","value":{"id":7}}]`,
+			want:  `{"owner":{"id":7},"tags":["a","b","c","d"]}`,
+		},
+		{
+			name:  "remove member and array element",
+			doc:   `{"a":1,"b":[1,2,3]}`,
+			patch: `[{"op":"remove","path":"/a"},{"op":"remove","path":"/b/0"}]`,
+			want:  `{"b":[2,3]}`,
+		},
+		{
+			name:  "escaped pointer tokens",
+			doc:   `{"a/b":{"c~d":1}}`,
+			patch: `[{"op":"replace","path":"/a~1b/c~0d","value":2}]`,
+			want:  `{"a/b":{"c~d":2}}`,
+		},
+		{
+			name:  "move and copy",
+			doc:   `{"src":{"v":[1]},"dst":{}}`,
+			patch: `[{"op":"copy","from":"/src/v","path":"/dst/copy"},{"op":"move","from":"/src","path":"/moved"}]`,
+			want:  `{"dst":{"copy":[1]},"moved":{"v":[1]}}`,
+		},
+		{
+			name:  "passing test compares numbers by value",
+			doc:   `{"version":1,"meta":{"x":[true,null]}}`,
+			patch: `[{"op":"test","path":"/version","value":1.0},{"op":"test","path":"/meta","value":{"x":[true,null]}}]`,
+			want:  `{"meta":{"x":[true,null]},"version":1}`,
+		},
+		{
+			name:  "replace root",
+			doc:   `{"a":1}`,
+			patch: `[{"op":"replace","path":"","value":[1]}]`,
+			want:  `[1]`,
+		},
+	}
+
+	for _, tc := range cases {
+		got, err := applyJSON(t, tc.doc, tc.patch)
+		if err != nil {
+			t.Fatalf("%s: apply: %v", tc.name, err)
+		}
+		if got != tc.want {
+			t.Fatalf("%s: got %s want %s", tc.name, got, tc.want)
+		}
+	}
+}
+
+func TestApplyFailingTestAborts(t *testing.T) {
+	t.Parallel()
+
+	_, err := applyJSON(t, `{"version":2}`, `[{"op":"replace","path":"/version","value":3},{"op":"test","path":"/version","value":2}]`)
+	var testErr *TestFailedError
+	if !errors.As(err, &testErr) {
+		t.Fatalf("expected TestFailedError, got %v", err)
+	}
+	if testErr.Index != 1 || testErr.Path != "/version" {
+		t.Fatalf("unexpected test failure %+v", testErr)
+	}
+}
+
+func TestApplyErrors(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		doc   string
+		patch string
+	}{
+		{doc: `{"a":1}`, patch: `[{"op":"remove","path":"/missing"}]`},
+		{doc: `{"a":1}`, patch: `[{"op":"replace","path":"/missing","value":1}]`},
+		{doc: `{"a":[1]}`, patch: `[{"op":"add","path":"/a/5","value":1}]`},
+		{doc: `{"a":[1]}`, patch: `[{"op":"remove","path":"/a/01"}]`},
+		{doc: `{"a":{"b":1}}`, patch: `[{"op":"move","from":"/a","path":"/a/b/c"}]`},
+		{doc: `{"a":1}`, patch: `[{"op":"add","path":"/a/b","value":1}]`},
+	}
+	for _, tc := range cases {
+		if _, err := applyJSON(t, tc.doc, tc.patch); err == nil {
+			t.Fatalf("expected error applying %s to %s", tc.patch, tc.doc)
+		}
+	}
+}
+
+func TestDecodeRejectsInvalidPatches(t *testing.T) {
+	t.Parallel()
+
+	for _, patch := range []string{
+		`{"op":"add"}`,
+		`[{"op":"add","path":"/a"}]`,
+		`[{"op":"increment","path":"/a"}]`,
+		`[{"op":"remove","path":"a"}]`,
+		`[{"op":"move","path":"/a","from":"b"}]`,
+	} {
+		if _, err := Decode([]byte(patch)); err == nil {
+			t.Fatalf("expected decode error for %s", patch)
+		}
+	}
+}
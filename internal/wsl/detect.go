@@ -18,6 +18,12 @@ var readResolvConf = func() ([]byte, error) {
 	return os.ReadFile("/etc/resolv.conf")
 }
 
+var readOSRelease = func() ([]byte, error) {
+	return os.ReadFile("/proc/sys/kernel/osrelease")
+}
+
+var getenv = os.Getenv
+
 func ParseDefaultGatewayFromIPRoute(routeOutput string) (string, bool) {
 	scanner := bufio.NewScanner(strings.NewReader(routeOutput))
 	for scanner.Scan() {
@@ -90,3 +96,15 @@ func DetectWindowsHostIP() (ip string, source string, err error) {
 
 	return "", "", errors.New("could not detect Windows host IP from ip route or /etc/resolv.conf")
 }
+
+// IsWSL reports whether the current process runs inside WSL.
+func IsWSL() bool {
+	if strings.TrimSpace(getenv("WSL_DISTRO_NAME")) != "" {
+		return true
+	}
+	release, err := readOSRelease()
+	if err != nil {
+		return false
+	}
+	return strings.Contains(strings.ToLower(string(release)), "microsoft")
+}
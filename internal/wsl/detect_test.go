@@ -86,3 +86,33 @@ func TestDetectWindowsHostIPFallsBackToResolvConf(t *testing.T) {
 		t.Fatalf("unexpected source %q", source)
 	}
 }
+
+func TestIsWSL(t *testing.T) {
+	origRead := readOSRelease
+	origGetenv := getenv
+	t.Cleanup(func() {
+		readOSRelease = origRead
+		getenv = origGetenv
+	})
+
+	getenv = func(string) string { return "" }
+	readOSRelease = func() ([]byte, error) { return []byte("5.15.167.4-microsoft-standard-WSL2\n"), nil }
+	if !IsWSL() {
+		t.Fatalf("expected WSL kernel release to be detected")
+	}
+
+	readOSRelease = func() ([]byte, error) { return []byte("6.8.0-45-generic\n"), nil }
+	if IsWSL() {
+		t.Fatalf("expected generic kernel not to be detected as WSL")
+	}
+
+	getenv = func(name string) string {
+		if name == "WSL_DISTRO_NAME" {
+			return "Ubuntu"
+		}
+		return ""
+	}
+	if !IsWSL() {
+		t.Fatalf("expected WSL_DISTRO_NAME to indicate WSL")
+	}
+}